@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ONSdigital/dis-migration-service/application"
 	"github.com/ONSdigital/dis-migration-service/config"
@@ -17,11 +18,18 @@ import (
 
 // MigrationAPI provides a struct to wrap the api around
 type MigrationAPI struct {
-	JobService     application.JobService
-	Migrator       migrator.Migrator
-	Paginator      *Paginator
-	Router         *mux.Router
-	AuthMiddleware auth.Middleware
+	JobService        application.JobService
+	Digester          Digester
+	SlackApprovals    *SlackApprovals
+	Migrator          migrator.Migrator
+	Paginator         *Paginator
+	Router            *mux.Router
+	AuthMiddleware    auth.Middleware
+	HeartbeatInterval time.Duration
+	BatchMaxSize      int
+	// Permissions checks whether users may approve jobs they submitted
+	Permissions                PermissionsChecker
+	FourEyesOverridePermission string
 }
 
 // Setup function sets up the api and returns an api
@@ -30,10 +38,14 @@ func Setup(_ context.Context, cfg *config.Config, router *mux.Router, jobService
 	paginator := NewPaginator(cfg.DefaultLimit, cfg.DefaultOffset, cfg.DefaultMaxLimit)
 
 	api := &MigrationAPI{
-		Router:         router,
-		JobService:     jobService,
-		Paginator:      paginator,
-		AuthMiddleware: authMiddleware,
+		Router:            router,
+		JobService:        jobService,
+		Paginator:         paginator,
+		AuthMiddleware:    authMiddleware,
+		HeartbeatInterval: cfg.StreamHeartbeatInterval,
+		BatchMaxSize:      cfg.BatchMaxSize,
+
+		FourEyesOverridePermission: cfg.FourEyesOverridePermission,
 	}
 
 	api.get("/v1/migration-jobs",
@@ -45,22 +57,111 @@ func Setup(_ context.Context, cfg *config.Config, router *mux.Router, jobService
 		authMiddleware.Require("migrations:create", api.createJob),
 	)
 
+	api.post(
+		"/v1/migration-jobs/batch",
+		authMiddleware.Require("migrations:create", api.createJobBatch),
+	)
+
+	api.get(
+		fmt.Sprintf("/v1/migration-batches/{%s}", PathParameterBatchID),
+		authMiddleware.Require("migrations:read", api.getBatch),
+	)
+
+	api.post("/v1/migration-release-groups",
+		authMiddleware.Require("migrations:edit", api.createReleaseGroup),
+	)
+
+	api.get(fmt.Sprintf("/v1/migration-release-groups/{%s}", PathParameterReleaseGroupID),
+		authMiddleware.Require("migrations:read", api.getReleaseGroup),
+	)
+
+	api.delete(fmt.Sprintf("/v1/migration-release-groups/{%s}", PathParameterReleaseGroupID),
+		authMiddleware.Require("migrations:edit", api.deleteReleaseGroup),
+	)
+
 	api.put(
 		fmt.Sprintf("/v1/migration-jobs/{%s}/state", PathParameterJobNumber),
 		authMiddleware.Require("migrations:edit", api.updateJobState),
 	)
 
+	api.put(
+		fmt.Sprintf("/v1/migration-jobs/{%s}/schedule", PathParameterJobNumber),
+		authMiddleware.Require("migrations:edit", api.scheduleJob),
+	)
+
+	api.delete(
+		fmt.Sprintf("/v1/migration-jobs/{%s}/schedule", PathParameterJobNumber),
+		authMiddleware.Require("migrations:edit", api.cancelJobSchedule),
+	)
+
+	api.put(
+		fmt.Sprintf("/v1/migration-jobs/{%s}/priority", PathParameterJobNumber),
+		authMiddleware.Require("migrations:edit", api.updateJobPriority),
+	)
+
 	api.get(
 		fmt.Sprintf("/v1/migration-jobs/{%s}", PathParameterJobNumber),
 		authMiddleware.Require("migrations:read", api.getJob),
 	)
 
 	api.get(fmt.Sprintf("/v1/migration-jobs/{%s}/tasks", PathParameterJobNumber),
-		authMiddleware.Require("migrations:read", paginator.Paginate(api.getJobTasks)),
+		authMiddleware.Require("migrations:read", paginator.PaginateWithCursor(api.getJobTasks, api.getJobTasksAfter)),
+	)
+
+	api.get(fmt.Sprintf("/v1/migration-jobs/{%s}/tasks/tree", PathParameterJobNumber),
+		authMiddleware.Require("migrations:read", api.getJobTaskTree),
 	)
 
 	api.get(fmt.Sprintf("/v1/migration-jobs/{%s}/events", PathParameterJobNumber),
-		authMiddleware.Require("migrations:read", paginator.Paginate(api.getJobEvents)),
+		authMiddleware.Require("migrations:read", paginator.PaginateWithCursor(api.getJobEvents, api.getJobEventsAfter)),
+	)
+
+	api.post(fmt.Sprintf("/v1/migration-jobs/{%s}/comments", PathParameterJobNumber),
+		authMiddleware.Require("migrations:edit", api.createJobComment),
+	)
+
+	api.get(fmt.Sprintf("/v1/migration-jobs/{%s}/comments", PathParameterJobNumber),
+		authMiddleware.Require("migrations:read", paginator.Paginate(api.getJobComments)),
+	)
+
+	api.get(fmt.Sprintf("/v1/migration-jobs/{%s}/stream", PathParameterJobNumber),
+		authMiddleware.Require("migrations:read", api.streamJob),
+	)
+
+	api.get("/v1/migration-digest",
+		authMiddleware.Require("migrations:read", api.getDigest),
+	)
+
+	api.post("/v1/migration-digest",
+		authMiddleware.Require("migrations:admin", api.sendDigest),
+	)
+
+	// Slack interactions are authenticated by Slack's request signature,
+	// and the Slack user's identity is checked for permission by the handler
+	api.post("/v1/slack/interactions", api.handleSlackInteraction)
+
+	api.post("/v1/webhooks",
+		authMiddleware.Require("migrations:admin", api.createWebhook),
+	)
+
+	api.get("/v1/webhooks",
+		authMiddleware.Require("migrations:admin", paginator.Paginate(api.getWebhooks)),
+	)
+
+	api.get(fmt.Sprintf("/v1/webhooks/{%s}", PathParameterWebhookID),
+		authMiddleware.Require("migrations:admin", api.getWebhook),
+	)
+
+	api.put(fmt.Sprintf("/v1/webhooks/{%s}", PathParameterWebhookID),
+		authMiddleware.Require("migrations:admin", api.updateWebhook),
+	)
+
+	api.delete(fmt.Sprintf("/v1/webhooks/{%s}", PathParameterWebhookID),
+		authMiddleware.Require("migrations:admin", api.deleteWebhook),
+	)
+
+	api.get(fmt.Sprintf("/v1/webhooks/{%s}/deliveries", PathParameterWebhookID),
+		authMiddleware.Require("migrations:admin", paginator.Paginate(api.getWebhookDeliveries)),
 	)
 
 	return api
@@ -34,9 +34,25 @@ func TestSetup(t *testing.T) {
 		Convey("When created the following routes should have been added", func() {
 			So(hasRoute(api.Router, "/v1/migration-jobs", "POST"), ShouldBeTrue)
 			So(hasRoute(api.Router, "/v1/migration-jobs/myJob", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/migration-jobs/batch", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/migration-batches/myBatch", "GET"), ShouldBeTrue)
 			So(hasRoute(api.Router, "/v1/migration-jobs/myJob/state", "PUT"), ShouldBeTrue)
 			So(hasRoute(api.Router, "/v1/migration-jobs/myJob/tasks", "GET"), ShouldBeTrue)
 			So(hasRoute(api.Router, "/v1/migration-jobs/myJob/events", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/migration-jobs/myJob/comments", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/migration-jobs/myJob/comments", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/migration-jobs/myJob/schedule", "PUT"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/migration-release-groups", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/migration-release-groups/myGroup", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/migration-release-groups/myGroup", "DELETE"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/migration-jobs/myJob/schedule", "DELETE"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/migration-jobs/myJob/priority", "PUT"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/webhooks", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/webhooks", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/webhooks/myWebhook", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/webhooks/myWebhook", "PUT"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/webhooks/myWebhook", "DELETE"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/v1/webhooks/myWebhook/deliveries", "GET"), ShouldBeTrue)
 		})
 	})
 }
@@ -0,0 +1,242 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// contentTypeCSV is the media type of a CSV batch manifest.
+	contentTypeCSV = "text/csv"
+
+	csvColumnSourceID = "source_id"
+	csvColumnTargetID = "target_id"
+	csvColumnType     = "type"
+
+	// maxBatchManifestSize is the largest batch manifest request body
+	// accepted
+	maxBatchManifestSize = 1 << 20
+
+	// batchRowWriteTimeout is how long the response to a batch creation
+	// request can take to write after the last row was processed.
+	batchRowWriteTimeout = 30 * time.Second
+)
+
+// createJobBatch handles requests to create a batch of migration jobs from
+// a manifest. The manifest is either a JSON array of job configs or, when
+// the Content-Type is text/csv, a CSV document of source_id,target_id rows
+// with an optional type column.
+func (api *MigrationAPI) createJobBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "createJobBatch endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	userAuthToken, err := dprequest.GetAuthToken(r)
+	if err != nil {
+		handleError(ctx, w, r, appErrors.ErrUnauthorized)
+		return
+	}
+
+	// Extract user ID from JWT token
+	userID, err := api.GetUserID(r)
+	if err != nil {
+		log.Info(ctx, "failed to extract user ID from token", log.Data{
+			"error": err.Error(),
+		})
+		handleError(ctx, w, r, appErrors.ErrUnauthorized)
+		return
+	}
+
+	jobConfigs, err := parseBatchManifest(http.MaxBytesReader(w, r.Body, maxBatchManifestSize), r.Header.Get("Content-Type"), api.BatchMaxSize)
+	if err != nil {
+		log.Info(ctx, "failed to parse batch manifest", log.Data{
+			"error": err.Error(),
+		})
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			handleError(ctx, w, r, appErrors.ErrBatchTooLarge)
+			return
+		}
+		handleError(ctx, w, r, appErrors.ErrUnableToParseBody)
+		return
+	}
+
+	// Each row can call Zebedee and the dataset API, so the write deadline
+	// is extended as rows are processed so that a large batch outlives the
+	// server's write timeout.
+	rc := http.NewResponseController(w)
+	extendDeadline := func() {
+		if err := rc.SetWriteDeadline(time.Now().Add(batchRowWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			log.Error(ctx, "failed to extend batch write deadline", err)
+		}
+	}
+	extendDeadline()
+
+	batch, err := api.JobService.CreateBatch(ctx, jobConfigs, userID, userAuthToken, extendDeadline)
+	if err != nil {
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	bytes, err := json.Marshal(batch)
+	if err != nil {
+		log.Error(ctx, "failed to marshal response", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully created job batch", authEntityData, domain.ActionCreate, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusCreated, bytes)
+}
+
+// getBatch handles requests to retrieve a migration batch and the progress
+// of the jobs it created.
+func (api *MigrationAPI) getBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "getBatch endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	batchID := mux.Vars(r)[PathParameterBatchID]
+
+	batch, err := api.JobService.GetBatch(ctx, batchID)
+	if err != nil {
+		if !errors.Is(err, appErrors.ErrBatchNotFound) {
+			log.Error(ctx, "failed to get batch with id: "+batchID, err)
+		}
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	bytes, err := json.Marshal(batch)
+	if err != nil {
+		log.Error(ctx, "failed to marshal response", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully retrieved batch", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusOK, bytes)
+}
+
+// parseBatchManifest reads the job configs from the request body, as CSV
+// or JSON depending on the request's Content-Type. CSV rows are only read
+// until there are more than maxRows, if it is positive, as the batch will
+// be rejected.
+func parseBatchManifest(body io.Reader, contentType string, maxRows int) ([]*domain.JobConfig, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && mediaType == contentTypeCSV {
+		return parseCSVManifest(body, maxRows)
+	}
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobConfigs []*domain.JobConfig
+	if err := json.Unmarshal(b, &jobConfigs); err != nil {
+		return nil, err
+	}
+
+	return jobConfigs, nil
+}
+
+// parseCSVManifest reads job configs from CSV. If the first record is a
+// header row, columns are matched by name, otherwise the columns are
+// expected in source_id, target_id, type order. Where no type column is
+// provided, the job type defaults to a static dataset. Reading stops once
+// there are more than maxRows rows, if it is positive.
+func parseCSVManifest(body io.Reader, maxRows int) ([]*domain.JobConfig, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+
+		// The first record may be a header, so one more is read
+		if maxRows > 0 && len(records) > maxRows+1 {
+			break
+		}
+	}
+
+	columns := map[string]int{
+		csvColumnSourceID: 0,
+		csvColumnTargetID: 1,
+		csvColumnType:     2,
+	}
+
+	if len(records) > 0 && isCSVHeader(records[0]) {
+		columns = map[string]int{}
+		for i, name := range records[0] {
+			columns[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+		records = records[1:]
+	}
+
+	jobConfigs := make([]*domain.JobConfig, 0, len(records))
+	for _, record := range records {
+		jobType := domain.JobType(csvField(record, columns, csvColumnType))
+		if _, ok := columns[csvColumnType]; !ok || jobType == "" {
+			jobType = domain.JobTypeStaticDataset
+		}
+
+		jobConfigs = append(jobConfigs, &domain.JobConfig{
+			SourceID: csvField(record, columns, csvColumnSourceID),
+			TargetID: csvField(record, columns, csvColumnTargetID),
+			Type:     jobType,
+		})
+	}
+
+	return jobConfigs, nil
+}
+
+// isCSVHeader returns true if the record is a header row naming the
+// source_id column.
+func isCSVHeader(record []string) bool {
+	for _, field := range record {
+		if strings.EqualFold(strings.TrimSpace(field), csvColumnSourceID) {
+			return true
+		}
+	}
+	return false
+}
+
+// csvField returns the trimmed value of the named column in the record, or
+// an empty string if the column is not present.
+func csvField(record []string, columns map[string]int, name string) string {
+	i, ok := columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
@@ -0,0 +1,276 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	applicationMock "github.com/ONSdigital/dis-migration-service/application/mock"
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	authorisationMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
+
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testBatchID = "test-batch-id"
+
+func TestCreateJobBatch(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice that creates a batch", t, func() {
+		mockService := applicationMock.JobServiceMock{
+			CreateBatchFunc: func(ctx context.Context, jobConfigs []*domain.JobConfig, userID string, userAuthToken string, rowDone func()) (*domain.Batch, error) {
+				batch := domain.NewBatch(len(jobConfigs), userID)
+				for i, jobConfig := range jobConfigs {
+					batch.AddJob(i+1, jobConfig, i+1)
+				}
+				return &batch, nil
+			},
+		}
+
+		mockAuthMiddleware := &authorisationMock.MiddlewareMock{
+			RequireFunc: requireWithAuthEntity,
+			CloseFunc: func(ctx context.Context) error {
+				return nil
+			},
+			ParseFunc: func(token string) (*permsdk.EntityData, error) {
+				return &permsdk.EntityData{
+					UserID: testAuthUserID,
+				}, nil
+			},
+		}
+
+		r := mux.NewRouter()
+		ctx := context.Background()
+		cfg := &config.Config{}
+		api := Setup(ctx, cfg, r, &mockService, mockAuthMiddleware)
+
+		Convey("When a valid JSON manifest is submitted", func() {
+			bodyBytes, err := json.Marshal([]domain.JobConfig{
+				{SourceID: testSourceID, TargetID: testTargetID, Type: testType},
+				{SourceID: testSourceID + "-2", TargetID: testTargetID + "-2", Type: testType},
+			})
+			So(err, ShouldBeNil)
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:30100/v1/migration-jobs/batch", bytes.NewBuffer(bodyBytes))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the batch is returned with a result for every row", func() {
+				So(resp.Code, ShouldEqual, http.StatusCreated)
+
+				var batch domain.Batch
+				err := json.Unmarshal(resp.Body.Bytes(), &batch)
+				So(err, ShouldBeNil)
+
+				So(batch.TotalRows, ShouldEqual, 2)
+				So(batch.Results, ShouldHaveLength, 2)
+
+				So(mockService.CreateBatchCalls(), ShouldHaveLength, 1)
+				So(mockService.CreateBatchCalls()[0].UserID, ShouldEqual, testAuthUserID)
+				So(mockService.CreateBatchCalls()[0].JobConfigs[1].SourceID, ShouldEqual, testSourceID+"-2")
+				So(mockService.CreateBatchCalls()[0].RowDone, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When a CSV manifest with a header row is submitted", func() {
+			body := "target_id,source_id\n" + testTargetID + "," + testSourceID + "\n"
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:30100/v1/migration-jobs/batch", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+			resp := httptest.NewRecorder()
+
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the columns are matched by name and the type defaults to a static dataset", func() {
+				So(resp.Code, ShouldEqual, http.StatusCreated)
+				So(mockService.CreateBatchCalls(), ShouldHaveLength, 1)
+
+				jobConfigs := mockService.CreateBatchCalls()[0].JobConfigs
+				So(jobConfigs, ShouldHaveLength, 1)
+				So(jobConfigs[0].SourceID, ShouldEqual, testSourceID)
+				So(jobConfigs[0].TargetID, ShouldEqual, testTargetID)
+				So(jobConfigs[0].Type, ShouldEqual, domain.JobTypeStaticDataset)
+			})
+		})
+
+		Convey("When a CSV manifest without a header row is submitted", func() {
+			body := testSourceID + "," + testTargetID + "\n" + testSourceID + "-2, " + testTargetID + "-2," + string(testType) + "\n"
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:30100/v1/migration-jobs/batch", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			req.Header.Set("Content-Type", "text/csv")
+			resp := httptest.NewRecorder()
+
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the columns are read in source_id, target_id, type order", func() {
+				So(resp.Code, ShouldEqual, http.StatusCreated)
+				So(mockService.CreateBatchCalls(), ShouldHaveLength, 1)
+
+				jobConfigs := mockService.CreateBatchCalls()[0].JobConfigs
+				So(jobConfigs, ShouldHaveLength, 2)
+				So(jobConfigs[0].SourceID, ShouldEqual, testSourceID)
+				So(jobConfigs[0].TargetID, ShouldEqual, testTargetID)
+				So(jobConfigs[1].SourceID, ShouldEqual, testSourceID+"-2")
+				So(jobConfigs[1].TargetID, ShouldEqual, testTargetID+"-2")
+				So(jobConfigs[1].Type, ShouldEqual, testType)
+			})
+		})
+
+		Convey("When a CSV manifest with more rows than the maximum batch size is submitted", func() {
+			api.BatchMaxSize = 2
+			body := "source_id,target_id\n" + strings.Repeat(testSourceID+","+testTargetID+"\n", 10)
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:30100/v1/migration-jobs/batch", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			req.Header.Set("Content-Type", "text/csv")
+			resp := httptest.NewRecorder()
+
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then only one row more than the maximum is read", func() {
+				So(mockService.CreateBatchCalls(), ShouldHaveLength, 1)
+				So(mockService.CreateBatchCalls()[0].JobConfigs, ShouldHaveLength, 3)
+			})
+		})
+
+		Convey("When a manifest larger than the maximum body size is submitted", func() {
+			body := "[" + strings.Repeat(`{"source_id":"`+testSourceID+`"},`, maxBatchManifestSize/10) + "{}]"
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:30100/v1/migration-jobs/batch", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a batch too large error is returned without creating the batch", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrBatchTooLarge.Error())
+				So(mockService.CreateBatchCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When an invalid manifest is submitted", func() {
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:30100/v1/migration-jobs/batch", bytes.NewBufferString("invalidJson"))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a bad request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrUnableToParseBody.Error())
+				So(mockService.CreateBatchCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+
+	Convey("Given a test API instance and a mocked jobservice that rejects the batch", t, func() {
+		mockService := applicationMock.JobServiceMock{
+			CreateBatchFunc: func(ctx context.Context, jobConfigs []*domain.JobConfig, userID string, userAuthToken string, rowDone func()) (*domain.Batch, error) {
+				return nil, appErrors.ErrBatchEmpty
+			},
+		}
+
+		mockAuthMiddleware := &authorisationMock.MiddlewareMock{
+			RequireFunc: requireWithAuthEntity,
+			CloseFunc: func(ctx context.Context) error {
+				return nil
+			},
+			ParseFunc: func(token string) (*permsdk.EntityData, error) {
+				return &permsdk.EntityData{
+					UserID: testAuthUserID,
+				}, nil
+			},
+		}
+
+		r := mux.NewRouter()
+		ctx := context.Background()
+		cfg := &config.Config{}
+		api := Setup(ctx, cfg, r, &mockService, mockAuthMiddleware)
+
+		Convey("When an empty manifest is submitted", func() {
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:30100/v1/migration-jobs/batch", bytes.NewBufferString("[]"))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a bad request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrBatchEmpty.Error())
+			})
+		})
+	})
+}
+
+func TestGetBatch(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice that returns a batch", t, func() {
+		mockService := applicationMock.JobServiceMock{
+			GetBatchFunc: func(ctx context.Context, batchID string) (*domain.Batch, error) {
+				if batchID != testBatchID {
+					return nil, appErrors.ErrBatchNotFound
+				}
+				return &domain.Batch{
+					ID:        batchID,
+					TotalRows: 1,
+					States: []domain.StateSummary{
+						{ID: domain.StateSubmitted, Count: 1},
+					},
+				}, nil
+			},
+		}
+
+		mockAuthMiddleware := &authorisationMock.MiddlewareMock{
+			RequireFunc: requireWithAuthEntity,
+			CloseFunc: func(ctx context.Context) error {
+				return nil
+			},
+		}
+
+		r := mux.NewRouter()
+		ctx := context.Background()
+		cfg := &config.Config{}
+		api := Setup(ctx, cfg, r, &mockService, mockAuthMiddleware)
+
+		Convey("When a request is made for an existing batch", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-batches/"+testBatchID, http.NoBody)
+			resp := httptest.NewRecorder()
+
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the batch and its progress are returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+
+				var batch domain.Batch
+				err := json.Unmarshal(resp.Body.Bytes(), &batch)
+				So(err, ShouldBeNil)
+
+				So(batch.ID, ShouldEqual, testBatchID)
+				So(batch.States, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a request is made for a batch that does not exist", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-batches/missing", http.NoBody)
+			resp := httptest.NewRecorder()
+
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a not found error is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusNotFound)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrBatchNotFound.Error())
+			})
+		})
+	})
+}
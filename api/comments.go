@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// CommentRequest represents the payload used to comment on a job.
+type CommentRequest struct {
+	Text string `json:"text"`
+}
+
+// createJobComment handles requests to comment on a migration job. The
+// comment is attributed to the user in the request's token.
+func (api *MigrationAPI) createJobComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "createJobComment endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	// Extract user ID from JWT token
+	userID, err := api.GetUserID(r)
+	if err != nil {
+		log.Info(ctx, "failed to extract user ID from token", log.Data{
+			"error": err.Error(),
+		})
+		handleError(ctx, w, r, appErrors.ErrUnauthorized)
+		return
+	}
+
+	jobNumber, err := strconv.Atoi(mux.Vars(r)[PathParameterJobNumber])
+	if err != nil {
+		log.Info(ctx, "failed to comment on job - job number must be an int")
+		handleError(ctx, w, r, appErrors.ErrJobNumberMustBeInt)
+		return
+	}
+
+	logData := log.Data{
+		"job_number": jobNumber,
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Info(ctx, "unable to read request body", logData)
+		handleError(ctx, w, r, appErrors.ErrUnableToParseBody)
+		return
+	}
+
+	var req CommentRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		log.Info(ctx, "failed to decode comment request body", logData)
+		handleError(ctx, w, r, appErrors.ErrUnableToParseBody)
+		return
+	}
+
+	comment := domain.NewComment(jobNumber, req.Text, userID)
+	if errs := comment.Validate(); len(errs) > 0 {
+		log.Info(ctx, "failed to validate comment", logData)
+		handleError(ctx, w, r, errs...)
+		return
+	}
+
+	comment, err = api.JobService.CreateComment(ctx, comment)
+	if err != nil {
+		if !errors.Is(err, appErrors.ErrJobNotFound) {
+			log.Error(ctx, "failed to create comment", err, logData)
+		}
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	bytes, err := json.Marshal(comment)
+	if err != nil {
+		log.Error(ctx, "failed to marshal response", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully created job comment", authEntityData, domain.ActionCreate, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusCreated, bytes)
+}
+
+// getJobComments is an implementation of PaginatedHandler for retrieving
+// the comments on a job, oldest first.
+func (api *MigrationAPI) getJobComments(w http.ResponseWriter, r *http.Request, limit, offset int) (items interface{}, totalCount int, err error) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "getJobComments endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		return nil, 0, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil)
+	}
+
+	jobNumber, err := api.getExistingJobNumber(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	comments, totalCount, err := api.JobService.GetJobComments(ctx, jobNumber, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(comments) == 0 {
+		comments = []*domain.Comment{}
+	}
+
+	logAuditEvent(ctx, "successfully retrieved job comments", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	return comments, totalCount, nil
+}
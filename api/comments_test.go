@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	applicationMock "github.com/ONSdigital/dis-migration-service/application/mock"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testCommentsURL = "http://localhost:30100/v1/migration-jobs/12/comments"
+
+func TestCreateJobComment(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice that creates comments", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			CreateCommentFunc: func(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+				return comment, nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When a comment is submitted", func() {
+			req := httptest.NewRequest(http.MethodPost, testCommentsURL, strings.NewReader(`{"text":"Dimension labels look wrong"}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the comment is created and attributed to the token's user", func() {
+				So(resp.Code, ShouldEqual, http.StatusCreated)
+
+				So(mockService.CreateCommentCalls(), ShouldHaveLength, 1)
+				comment := mockService.CreateCommentCalls()[0].Comment
+				So(comment.JobNumber, ShouldEqual, 12)
+				So(comment.Text, ShouldEqual, "Dimension labels look wrong")
+				So(comment.CreatedBy.ID, ShouldEqual, testAuthUserID)
+
+				var response domain.Comment
+				So(json.Unmarshal(resp.Body.Bytes(), &response), ShouldBeNil)
+				So(response.ID, ShouldEqual, comment.ID)
+			})
+		})
+
+		Convey("When a comment without text is submitted", func() {
+			req := httptest.NewRequest(http.MethodPost, testCommentsURL, strings.NewReader(`{"text":"  "}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 Bad Request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrCommentTextNotProvided.Error())
+				So(mockService.CreateCommentCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the body cannot be parsed", func() {
+			req := httptest.NewRequest(http.MethodPost, testCommentsURL, strings.NewReader("{"))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 Bad Request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(mockService.CreateCommentCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a test API instance and a mocked jobservice without the job", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			CreateCommentFunc: func(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+				return nil, appErrors.ErrJobNotFound
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When a comment is submitted", func() {
+			req := httptest.NewRequest(http.MethodPost, testCommentsURL, strings.NewReader(`{"text":"text"}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 404 Not Found is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestGetJobComments(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice with comments on a job", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{JobNumber: jobNumber}, nil
+			},
+			GetJobCommentsFunc: func(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Comment, int, error) {
+				return []*domain.Comment{domain.NewComment(jobNumber, "text", testAuthUserID)}, 1, nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When the comments are requested", func() {
+			req := httptest.NewRequest(http.MethodGet, testCommentsURL+"?limit=5&offset=0", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a paginated list of comments is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+
+				var response struct {
+					Items      []*domain.Comment `json:"items"`
+					TotalCount int               `json:"total_count"`
+				}
+				So(json.Unmarshal(resp.Body.Bytes(), &response), ShouldBeNil)
+				So(response.Items, ShouldHaveLength, 1)
+				So(response.Items[0].CreatedBy.ID, ShouldEqual, testAuthUserID)
+				So(response.TotalCount, ShouldEqual, 1)
+
+				So(mockService.GetJobCommentsCalls()[0].JobNumber, ShouldEqual, 12)
+				So(mockService.GetJobCommentsCalls()[0].Limit, ShouldEqual, 5)
+			})
+		})
+	})
+
+	Convey("Given a test API instance and a mocked jobservice without the job", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return nil, appErrors.ErrJobNotFound
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When the comments are requested", func() {
+			req := httptest.NewRequest(http.MethodGet, testCommentsURL, http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 404 Not Found is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusNotFound)
+				So(mockService.GetJobCommentsCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Digester sends a digest of migration activity on demand
+type Digester interface {
+	Send(ctx context.Context) (*domain.Digest, error)
+}
+
+// getDigest handles requests to preview the digest of migration activity
+// without sending it.
+func (api *MigrationAPI) getDigest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "getDigest endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	digest, err := api.JobService.GetDigest(ctx)
+	if err != nil {
+		log.Error(ctx, "failed to build migration digest", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	bytes, err := json.Marshal(digest)
+	if err != nil {
+		log.Error(ctx, "failed to marshal response", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully previewed migration digest", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusOK, bytes)
+}
+
+// sendDigest handles requests to send the digest of migration activity
+// now, rather than waiting for the scheduled time.
+func (api *MigrationAPI) sendDigest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "sendDigest endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	if api.Digester == nil {
+		handleError(ctx, w, r, appErrors.ErrDigestUnavailable)
+		return
+	}
+
+	digest, err := api.Digester.Send(ctx)
+	if err != nil {
+		log.Error(ctx, "failed to send migration digest", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	bytes, err := json.Marshal(digest)
+	if err != nil {
+		log.Error(ctx, "failed to marshal response", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully sent migration digest", authEntityData, domain.ActionCreate, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusOK, bytes)
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	applicationMock "github.com/ONSdigital/dis-migration-service/application/mock"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testDigestURL = "http://localhost:30100/v1/migration-digest"
+
+// digesterFunc is a Digester backed by a function
+type digesterFunc func(ctx context.Context) (*domain.Digest, error)
+
+func (f digesterFunc) Send(ctx context.Context) (*domain.Digest, error) {
+	return f(ctx)
+}
+
+func newTestDigest() *domain.Digest {
+	return &domain.Digest{
+		JobsCreated: 4,
+		AwaitingApproval: domain.DigestJobs{
+			Count: 1,
+			Jobs:  []*domain.DigestJob{{JobNumber: 3, Label: "Test", State: domain.StateInReview}},
+		},
+	}
+}
+
+func TestGetDigest(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice that builds a digest", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			GetDigestFunc: func(ctx context.Context) (*domain.Digest, error) {
+				return newTestDigest(), nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		var sent int
+		api.Digester = digesterFunc(func(ctx context.Context) (*domain.Digest, error) {
+			sent++
+			return newTestDigest(), nil
+		})
+
+		Convey("When the digest is previewed", func() {
+			req := httptest.NewRequest(http.MethodGet, testDigestURL, http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the digest is returned without being sent", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(mockService.GetDigestCalls(), ShouldHaveLength, 1)
+				So(sent, ShouldEqual, 0)
+
+				var digest domain.Digest
+				So(json.Unmarshal(resp.Body.Bytes(), &digest), ShouldBeNil)
+				So(digest.JobsCreated, ShouldEqual, 4)
+				So(digest.AwaitingApproval.Jobs[0].JobNumber, ShouldEqual, 3)
+			})
+		})
+	})
+
+	Convey("Given a test API instance and a mocked jobservice that fails to build a digest", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			GetDigestFunc: func(ctx context.Context) (*domain.Digest, error) {
+				return nil, errors.New("database error")
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When the digest is previewed", func() {
+			req := httptest.NewRequest(http.MethodGet, testDigestURL, http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then an internal server error is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestSendDigest(t *testing.T) {
+	Convey("Given a test API instance with a digester", t, func() {
+		api := newWebhookTestAPI(&applicationMock.JobServiceMock{})
+
+		var sent int
+		api.Digester = digesterFunc(func(ctx context.Context) (*domain.Digest, error) {
+			sent++
+			return newTestDigest(), nil
+		})
+
+		Convey("When the digest is triggered", func() {
+			req := httptest.NewRequest(http.MethodPost, testDigestURL, http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the digest is sent and returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(sent, ShouldEqual, 1)
+
+				var digest domain.Digest
+				So(json.Unmarshal(resp.Body.Bytes(), &digest), ShouldBeNil)
+				So(digest.JobsCreated, ShouldEqual, 4)
+			})
+		})
+	})
+
+	Convey("Given a test API instance with a digester that fails to send", t, func() {
+		api := newWebhookTestAPI(&applicationMock.JobServiceMock{})
+		api.Digester = digesterFunc(func(ctx context.Context) (*domain.Digest, error) {
+			return nil, errors.New("slack unavailable")
+		})
+
+		Convey("When the digest is triggered", func() {
+			req := httptest.NewRequest(http.MethodPost, testDigestURL, http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then an internal server error is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+
+	Convey("Given a test API instance without a digester", t, func() {
+		api := newWebhookTestAPI(&applicationMock.JobServiceMock{})
+
+		Convey("When the digest is triggered", func() {
+			req := httptest.NewRequest(http.MethodPost, testDigestURL, http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a service unavailable error is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusServiceUnavailable)
+			})
+		})
+	})
+}
@@ -1,12 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	sort "github.com/ONSdigital/dis-migration-service/api/sort"
 	"github.com/ONSdigital/dis-migration-service/domain"
@@ -14,13 +16,18 @@ import (
 	"github.com/ONSdigital/dis-migration-service/statemachine"
 	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
 	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
 
-// StateChangeRequest represents the payload used to request a job state change.
+// StateChangeRequest represents the payload used to request a job state
+// change. The optional reason is recorded on the resulting event, and an
+// optional publish time schedules an approved job.
 type StateChangeRequest struct {
-	State domain.State `json:"state"`
+	State     domain.State `json:"state"`
+	Reason    string       `json:"reason,omitempty"`
+	PublishAt *time.Time   `json:"publish_at,omitempty"`
 }
 
 // JobsListResponse represents the response structure for a paginated list of
@@ -31,6 +38,20 @@ type JobsListResponse struct {
 	PaginationFields
 }
 
+// TaskTreeResponse represents the response for a job's task tree
+type TaskTreeResponse struct {
+	Items      []*domain.TaskNode `json:"items"`
+	TotalCount int                `json:"total_count"`
+}
+
+// JobsCursorListResponse represents the response for listing jobs
+// paginated with a cursor
+type JobsCursorListResponse struct {
+	Items  []*domain.Job         `json:"items"`
+	States []domain.StateSummary `json:"states"`
+	CursorPaginationFields
+}
+
 // getJob is an implementation for retrieving a migration job.
 func (api *MigrationAPI) getJob(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -81,24 +102,22 @@ func (api *MigrationAPI) getJobs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	after, cursorPaginated, err := api.Paginator.getCursorParameter(r)
+	if err != nil {
+		handleError(ctx, w, r, err)
+		return
+	}
+
 	limit, offset, errs := api.Paginator.getPaginationParameters(r)
 	if len(errs) > 0 {
 		handleError(ctx, w, r, errs...)
 		return
 	}
 
-	statesParam := r.URL.Query()["state"] // supports ?state=a&state=b and ?state=a,b
-	states := make([]domain.State, 0, len(statesParam))
-
-	for _, s := range statesParam {
-		for _, p := range strings.Split(s, ",") {
-			state := domain.State(strings.TrimSpace(p))
-			if !domain.IsValidState(state) {
-				handleError(ctx, w, r, appErrors.ErrJobStateInvalid)
-				return
-			}
-			states = append(states, state)
-		}
+	filter, errs := getJobFilterParameters(r)
+	if len(errs) > 0 {
+		handleError(ctx, w, r, errs...)
+		return
 	}
 
 	sortParam := r.URL.Query()[QueryParameterSort]
@@ -108,7 +127,24 @@ func (api *MigrationAPI) getJobs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jobs, totalCount, err := api.JobService.GetJobs(ctx, field, direction, states, limit, offset)
+	// The last updated time of a job changes while its pages are being
+	// read, so it cannot be used to place a cursor
+	if cursorPaginated && field == sort.SortParameterFieldLastUpdated {
+		handleError(ctx, w, r, appErrors.ErrCursorSortInvalid)
+		return
+	}
+
+	var (
+		jobs       []*domain.Job
+		totalCount int
+		next       *domain.Cursor
+	)
+
+	if cursorPaginated {
+		jobs, next, err = api.JobService.GetJobsAfter(ctx, field, direction, filter, after, limit)
+	} else {
+		jobs, totalCount, err = api.JobService.GetJobs(ctx, field, direction, filter, limit, offset)
+	}
 	if err != nil {
 		handleError(ctx, w, r, err)
 		return
@@ -124,15 +160,24 @@ func (api *MigrationAPI) getJobs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jobsListResponse := JobsListResponse{
-		Items:  jobs,
-		States: stateSummaries,
-		PaginationFields: PaginationFields{
-			Count:      len(jobs),
-			Limit:      limit,
-			Offset:     offset,
-			TotalCount: totalCount,
-		},
+	var jobsListResponse interface{}
+	if cursorPaginated {
+		jobsListResponse = JobsCursorListResponse{
+			Items:                  jobs,
+			States:                 stateSummaries,
+			CursorPaginationFields: generateCursorPaginationFields(r, len(jobs), limit, next),
+		}
+	} else {
+		jobsListResponse = JobsListResponse{
+			Items:  jobs,
+			States: stateSummaries,
+			PaginationFields: PaginationFields{
+				Count:      len(jobs),
+				Limit:      limit,
+				Offset:     offset,
+				TotalCount: totalCount,
+			},
+		}
 	}
 
 	responseBytes, err := json.Marshal(jobsListResponse)
@@ -145,6 +190,58 @@ func (api *MigrationAPI) getJobs(w http.ResponseWriter, r *http.Request) {
 	handleSuccess(ctx, w, r, http.StatusOK, responseBytes)
 }
 
+// getJobFilterParameters parses the filter query parameters of the jobs
+// list, returning an error for each invalid parameter.
+func getJobFilterParameters(r *http.Request) (filter *domain.JobFilter, errs []error) {
+	query := r.URL.Query()
+
+	statesParam := query[QueryParameterState] // supports ?state=a&state=b and ?state=a,b
+	filter = &domain.JobFilter{
+		States:    make([]domain.State, 0, len(statesParam)),
+		SourceID:  strings.TrimSpace(query.Get(QueryParameterSourceID)),
+		TargetID:  strings.TrimSpace(query.Get(QueryParameterTargetID)),
+		Label:     strings.TrimSpace(query.Get(QueryParameterLabel)),
+		CreatedBy: strings.TrimSpace(query.Get(QueryParameterCreatedBy)),
+	}
+
+	for _, s := range statesParam {
+		for _, p := range strings.Split(s, ",") {
+			state := domain.State(strings.TrimSpace(p))
+			if !domain.IsValidState(state) {
+				return nil, []error{appErrors.ErrJobStateInvalid}
+			}
+			filter.States = append(filter.States, state)
+		}
+	}
+
+	if typeParam := strings.TrimSpace(query.Get(QueryParameterType)); typeParam != "" {
+		filter.Type = domain.JobType(typeParam)
+		if !domain.IsValidJobType(filter.Type) {
+			errs = append(errs, appErrors.ErrJobTypeInvalid)
+		}
+	}
+
+	if updatedAfter := query.Get(QueryParameterUpdatedAfter); updatedAfter != "" {
+		t, err := time.Parse(time.RFC3339, updatedAfter)
+		if err != nil {
+			errs = append(errs, appErrors.ErrUpdatedAfterInvalid)
+		} else {
+			filter.UpdatedAfter = &t
+		}
+	}
+
+	if updatedBefore := query.Get(QueryParameterUpdatedBefore); updatedBefore != "" {
+		t, err := time.Parse(time.RFC3339, updatedBefore)
+		if err != nil {
+			errs = append(errs, appErrors.ErrUpdatedBeforeInvalid)
+		} else {
+			filter.UpdatedBefore = &t
+		}
+	}
+
+	return filter, errs
+}
+
 // getJobTasks is an implementation of PaginatedHandler for retrieving
 // job tasks.
 func (api *MigrationAPI) getJobTasks(w http.ResponseWriter, r *http.Request, limit, offset int) (items interface{}, totalCount int, err error) {
@@ -155,44 +252,132 @@ func (api *MigrationAPI) getJobTasks(w http.ResponseWriter, r *http.Request, lim
 		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
 		return nil, 0, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil)
 	}
-	vars := mux.Vars(r)
-	jobNumberStr := vars[PathParameterJobNumber]
 
+	jobNumber, err := api.getExistingJobNumber(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	states, err := getTaskStateParameters(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Fetch tasks for the job
+	tasks, totalCount, err := api.JobService.GetJobTasks(ctx, states, jobNumber, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	logAuditEvent(ctx, "successfully retrieved job tasks", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	return tasks, totalCount, nil
+}
+
+func (api *MigrationAPI) getJobTasksAfter(w http.ResponseWriter, r *http.Request, limit int, after *domain.Cursor) (items interface{}, next *domain.Cursor, err error) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "getJobTasks endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		return nil, nil, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil)
+	}
+
+	jobNumber, err := api.getExistingJobNumber(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	states, err := getTaskStateParameters(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tasks, next, err := api.JobService.GetJobTasksAfter(ctx, states, jobNumber, after, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logAuditEvent(ctx, "successfully retrieved job tasks", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	return tasks, next, nil
+}
+
+// getJobTaskTree handles requests for the tasks of a job arranged by the
+// task that created them.
+func (api *MigrationAPI) getJobTaskTree(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "getJobTaskTree endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	jobNumber, err := api.getExistingJobNumber(r)
+	if err != nil {
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	nodes, totalCount, err := api.JobService.GetJobTaskTree(ctx, jobNumber)
+	if err != nil {
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	bytes, err := json.Marshal(TaskTreeResponse{
+		Items:      nodes,
+		TotalCount: totalCount,
+	})
+	if err != nil {
+		log.Error(ctx, "failed to marshal response", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully retrieved job task tree", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusOK, bytes)
+}
+
+// getExistingJobNumber parses the job number path parameter, returning an
+// error if it is invalid or if the job does not exist.
+func (api *MigrationAPI) getExistingJobNumber(r *http.Request) (int, error) {
+	ctx := r.Context()
+
+	jobNumberStr := mux.Vars(r)[PathParameterJobNumber]
 	if jobNumberStr == "" {
-		return nil, 0, appErrors.ErrJobNumberNotProvided
+		return 0, appErrors.ErrJobNumberNotProvided
 	}
+
 	jobNumber, err := strconv.Atoi(jobNumberStr)
 	if err != nil {
 		log.Info(ctx, "failed to get job - job number must be an int")
-		return nil, 0, appErrors.ErrJobNumberMustBeInt
+		return 0, appErrors.ErrJobNumberMustBeInt
 	}
 
 	// Ensure job exists -> return 404 if not found
 	if _, err := api.JobService.GetJob(ctx, jobNumber); err != nil {
-		return nil, 0, err // This will return 404 if job not found
+		return 0, err
 	}
 
-	statesParam := r.URL.Query()["state"]
+	return jobNumber, nil
+}
+
+// getTaskStateParameters parses the state query parameters used to filter
+// the tasks of a job.
+func getTaskStateParameters(r *http.Request) ([]domain.State, error) {
+	statesParam := r.URL.Query()[QueryParameterState]
 	states := make([]domain.State, 0, len(statesParam))
 
 	for _, s := range statesParam {
 		for _, p := range strings.Split(s, ",") {
 			state := domain.State(strings.TrimSpace(p))
 			if !domain.IsValidState(state) {
-				return nil, 0, appErrors.ErrTaskStateInvalid
+				return nil, appErrors.ErrTaskStateInvalid
 			}
 			states = append(states, state)
 		}
 	}
 
-	// Fetch tasks for the job
-	tasks, totalCount, err := api.JobService.GetJobTasks(ctx, states, jobNumber, limit, offset)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	logAuditEvent(ctx, "successfully retrieved job tasks", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
-	return tasks, totalCount, nil
+	return states, nil
 }
 
 func (api *MigrationAPI) createJob(w http.ResponseWriter, r *http.Request) {
@@ -243,7 +428,7 @@ func (api *MigrationAPI) createJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	job, err := api.JobService.CreateJob(ctx, jobConfig, userID, userAuthToken)
+	job, err := api.JobService.CreateJob(ctx, jobConfig, userID, userAuthToken, r.Header.Get(HeaderIdempotencyKey))
 	if err != nil {
 		handleError(ctx, w, r, err)
 		return
@@ -269,26 +454,19 @@ func (api *MigrationAPI) getJobEvents(w http.ResponseWriter, r *http.Request, li
 		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
 		return nil, 0, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil)
 	}
-	vars := mux.Vars(r)
-	jobNumberStr := vars[PathParameterJobNumber]
-
-	if jobNumberStr == "" {
-		return nil, 0, appErrors.ErrJobNumberNotProvided
-	}
 
-	jobNumber, err := strconv.Atoi(jobNumberStr)
+	jobNumber, err := api.getExistingJobNumber(r)
 	if err != nil {
-		log.Info(ctx, "failed to get job - job number must be an int")
-		return nil, 0, appErrors.ErrJobNumberMustBeInt
+		return nil, 0, err
 	}
 
-	// Ensure job exists -> return 404 if not found
-	if _, err := api.JobService.GetJob(ctx, jobNumber); err != nil {
+	filter, err := getEventFilterParameters(r)
+	if err != nil {
 		return nil, 0, err
 	}
 
 	// Fetch events for the job
-	events, totalCount, err := api.JobService.GetJobEvents(ctx, jobNumber, limit, offset)
+	events, totalCount, err := api.JobService.GetJobEvents(ctx, jobNumber, filter, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -297,6 +475,72 @@ func (api *MigrationAPI) getJobEvents(w http.ResponseWriter, r *http.Request, li
 	return events, totalCount, nil
 }
 
+func (api *MigrationAPI) getJobEventsAfter(w http.ResponseWriter, r *http.Request, limit int, after *domain.Cursor) (items interface{}, next *domain.Cursor, err error) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "getJobEvents endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		return nil, nil, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil)
+	}
+
+	jobNumber, err := api.getExistingJobNumber(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter, err := getEventFilterParameters(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events, next, err := api.JobService.GetJobEventsAfter(ctx, jobNumber, filter, after, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logAuditEvent(ctx, "successfully retrieved job events", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	return events, next, nil
+}
+
+// getEventFilterParameters parses the filter query parameters of the
+// events list, returning an error for the first invalid parameter.
+func getEventFilterParameters(r *http.Request) (*domain.EventFilter, error) {
+	query := r.URL.Query()
+
+	actionsParam := query[QueryParameterAction] // supports ?action=a&action=b and ?action=a,b
+	filter := &domain.EventFilter{
+		Actions: make([]domain.EventAction, 0, len(actionsParam)),
+	}
+
+	for _, a := range actionsParam {
+		for _, p := range strings.Split(a, ",") {
+			action := domain.EventAction(strings.TrimSpace(p))
+			if !domain.IsValidEventAction(action) {
+				return nil, appErrors.ErrEventActionInvalid
+			}
+			filter.Actions = append(filter.Actions, action)
+		}
+	}
+
+	if createdAfter := query.Get(QueryParameterCreatedAfter); createdAfter != "" {
+		t, err := time.Parse(time.RFC3339, createdAfter)
+		if err != nil {
+			return nil, appErrors.ErrCreatedAfterInvalid
+		}
+		filter.CreatedAfter = &t
+	}
+
+	if createdBefore := query.Get(QueryParameterCreatedBefore); createdBefore != "" {
+		t, err := time.Parse(time.RFC3339, createdBefore)
+		if err != nil {
+			return nil, appErrors.ErrCreatedBeforeInvalid
+		}
+		filter.CreatedBefore = &t
+	}
+
+	return filter, nil
+}
+
 // updateJobState handles requests to update the state of a migration job.
 func (api *MigrationAPI) updateJobState(
 	w http.ResponseWriter,
@@ -371,6 +615,22 @@ func (api *MigrationAPI) updateJobState(
 		return
 	}
 
+	if req.PublishAt != nil {
+		if req.State != domain.StateApproved {
+			log.Info(ctx, "publish time provided for a state other than approved", logData)
+			handleError(ctx, w, r, appErrors.ErrPublishAtNotAllowed)
+			return
+		}
+
+		if !req.PublishAt.After(time.Now()) {
+			log.Info(ctx, "publish time provided is in the past", logData)
+			handleError(ctx, w, r, appErrors.ErrPublishAtInPast)
+			return
+		}
+
+		logData["publish_at"] = req.PublishAt
+	}
+
 	// Get current job
 	job, err := api.JobService.GetJob(ctx, jobNumber)
 	if err != nil {
@@ -384,8 +644,19 @@ func (api *MigrationAPI) updateJobState(
 	// Add current state once job is known
 	logData["current_state"] = job.State
 
+	opts := &domain.JobStateOptions{PublishAt: req.PublishAt}
+
+	if req.State == domain.StateApproved {
+		opts.FourEyesOverride, err = api.hasFourEyesOverride(ctx, authEntityData.EntityData)
+		if err != nil {
+			log.Error(ctx, "failed to check four eyes override permission", err, logData)
+			handleError(ctx, w, r, err)
+			return
+		}
+	}
+
 	// Attempt state transition
-	err = api.JobService.UpdateJobState(ctx, jobNumber, req.State, userID)
+	err = api.JobService.UpdateJobState(ctx, jobNumber, req.State, userID, strings.TrimSpace(req.Reason), opts)
 	if err != nil {
 		var transitionErr *statemachine.TransitionError
 		if errors.As(err, &transitionErr) {
@@ -394,6 +665,19 @@ func (api *MigrationAPI) updateJobState(
 			return
 		}
 
+		if errors.Is(err, appErrors.ErrJobScheduleNotAllowed) || errors.Is(err, appErrors.ErrPublishAtInPast) {
+			log.Info(ctx, "job schedule refused", logData)
+			handleError(ctx, w, r, err)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrApproverIsSubmitter) || errors.Is(err, appErrors.ErrJobAlreadyApprovedByUser) {
+			log.Info(ctx, "job approval refused", logData)
+			logAuditEvent(ctx, "job approval refused", authEntityData, domain.ActionUpdate, r.URL.Path, domain.OutcomeFailure, err.Error(), nil)
+			handleError(ctx, w, r, err)
+			return
+		}
+
 		log.Error(ctx, "failed to update job state", err, logData)
 		handleError(ctx, w, r, err)
 		return
@@ -404,6 +688,16 @@ func (api *MigrationAPI) updateJobState(
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// hasFourEyesOverride checks if the entity holds the four eyes override
+// permission, allowing them to approve jobs they submitted.
+func (api *MigrationAPI) hasFourEyesOverride(ctx context.Context, entityData *permsdk.EntityData) (bool, error) {
+	if api.Permissions == nil || api.FourEyesOverridePermission == "" || entityData == nil {
+		return false, nil
+	}
+
+	return api.Permissions.HasPermission(ctx, *entityData, api.FourEyesOverridePermission, nil)
+}
+
 // GetUserID extracts the user ID from the Authorization header by parsing
 // the JWT token. Returns the user ID or an empty string if the token
 // cannot be parsed.
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -228,7 +229,7 @@ func TestGetJobs(t *testing.T) {
 		}
 
 		mockService := applicationMock.JobServiceMock{
-			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 				jobs := testJobs
 				return jobs, len(jobs), nil
 			},
@@ -278,7 +279,7 @@ func TestGetJobs(t *testing.T) {
 
 				Convey("And both service methods are called", func() {
 					So(len(mockService.GetJobsCalls()), ShouldEqual, 1)
-					So(mockService.GetJobsCalls()[0].States, ShouldResemble, []domain.State{})
+					So(mockService.GetJobsCalls()[0].Filter.States, ShouldResemble, []domain.State{})
 					So(len(mockService.GetJobStatesSummaryCalls()), ShouldEqual, 1)
 				})
 			})
@@ -330,7 +331,7 @@ func TestGetJobs(t *testing.T) {
 		})
 
 		Convey("When a request is made with a single valid state", func() {
-			mockService.GetJobsFunc = func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+			mockService.GetJobsFunc = func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 				jobs := []*domain.Job{testSubmittedJob}
 				return jobs, len(testJobs), nil
 			}
@@ -363,14 +364,14 @@ func TestGetJobs(t *testing.T) {
 
 				Convey("And the service is called with the submitted state", func() {
 					So(len(mockService.GetJobsCalls()), ShouldEqual, 1)
-					So(mockService.GetJobsCalls()[0].States, ShouldResemble, []domain.State{domain.StateSubmitted})
+					So(mockService.GetJobsCalls()[0].Filter.States, ShouldResemble, []domain.State{domain.StateSubmitted})
 					So(len(mockService.GetJobStatesSummaryCalls()), ShouldEqual, 1)
 				})
 			})
 		})
 
 		Convey("When a request is made with multiple valid states, using repeated query param", func() {
-			mockService.GetJobsFunc = func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+			mockService.GetJobsFunc = func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 				jobs := []*domain.Job{testSubmittedJob, testApprovedJob}
 				return jobs, len(testJobs), nil
 			}
@@ -403,14 +404,14 @@ func TestGetJobs(t *testing.T) {
 
 				Convey("And the service is called with both states", func() {
 					So(len(mockService.GetJobsCalls()), ShouldEqual, 1)
-					So(mockService.GetJobsCalls()[0].States, ShouldResemble, []domain.State{domain.StateSubmitted, domain.StateApproved})
+					So(mockService.GetJobsCalls()[0].Filter.States, ShouldResemble, []domain.State{domain.StateSubmitted, domain.StateApproved})
 					So(len(mockService.GetJobStatesSummaryCalls()), ShouldEqual, 1)
 				})
 			})
 		})
 
 		Convey("When a request is made with multiple valid states, using comma-separated values", func() {
-			mockService.GetJobsFunc = func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+			mockService.GetJobsFunc = func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 				jobs := []*domain.Job{testSubmittedJob, testApprovedJob}
 				return jobs, len(testJobs), nil
 			}
@@ -443,7 +444,7 @@ func TestGetJobs(t *testing.T) {
 
 				Convey("And the service is called with both states", func() {
 					So(len(mockService.GetJobsCalls()), ShouldEqual, 1)
-					So(mockService.GetJobsCalls()[0].States, ShouldResemble, []domain.State{domain.StateSubmitted, domain.StateApproved})
+					So(mockService.GetJobsCalls()[0].Filter.States, ShouldResemble, []domain.State{domain.StateSubmitted, domain.StateApproved})
 					So(len(mockService.GetJobStatesSummaryCalls()), ShouldEqual, 1)
 				})
 			})
@@ -465,7 +466,7 @@ func TestGetJobs(t *testing.T) {
 		})
 
 		Convey("When a request is made with valid sort parameters", func() {
-			mockService.GetJobsFunc = func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+			mockService.GetJobsFunc = func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 				return testJobs, len(testJobs), nil
 			}
 			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs?sort=job_number:asc", http.NoBody)
@@ -503,6 +504,56 @@ func TestGetJobs(t *testing.T) {
 			})
 		})
 
+		Convey("When a request is made with last_updated in the sort parameter", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs?sort=last_updated:desc", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the service is called with the last_updated field", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(len(mockService.GetJobsCalls()), ShouldEqual, 1)
+				So(mockService.GetJobsCalls()[0].Field, ShouldResemble, sort.SortParameterFieldLastUpdated)
+				So(mockService.GetJobsCalls()[0].Direction, ShouldResemble, sort.SortParameterDirectionDesc)
+			})
+		})
+
+		Convey("When a request is made with all the filter parameters", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs?type=static_dataset&source_id=/source-id&target_id=target-id&label=Consumer%20Price&created_by=user-123&updated_after=2025-01-01T00:00:00Z&updated_before=2025-02-01T00:00:00Z", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the service is called with the filter", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(len(mockService.GetJobsCalls()), ShouldEqual, 1)
+
+				filter := mockService.GetJobsCalls()[0].Filter
+				So(filter.Type, ShouldEqual, domain.JobTypeStaticDataset)
+				So(filter.SourceID, ShouldEqual, "/source-id")
+				So(filter.TargetID, ShouldEqual, "target-id")
+				So(filter.Label, ShouldEqual, "Consumer Price")
+				So(filter.CreatedBy, ShouldEqual, "user-123")
+				So(*filter.UpdatedAfter, ShouldEqual, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+				So(*filter.UpdatedBefore, ShouldEqual, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+			})
+		})
+
+		Convey("When a request is made with invalid filter parameters", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs?type=unknown&updated_after=yesterday&updated_before=tomorrow", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 Bad Request is returned with an error for each parameter", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrJobTypeInvalid.Error())
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrUpdatedAfterInvalid.Error())
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrUpdatedBeforeInvalid.Error())
+
+				Convey("And the service is not called", func() {
+					So(len(mockService.GetJobsCalls()), ShouldEqual, 0)
+				})
+			})
+		})
+
 		Convey("When a request is made with an invalid field in sort parameter", func() {
 			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs?sort=unknown:asc", http.NoBody)
 			resp := httptest.NewRecorder()
@@ -536,7 +587,7 @@ func TestGetJobs(t *testing.T) {
 
 	Convey("Given a test API instance and a mocked jobservice that returns no jobs", t, func() {
 		mockService := applicationMock.JobServiceMock{
-			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 				return []*domain.Job{}, 0, nil
 			},
 			GetJobStatesSummaryFunc: func(ctx context.Context) ([]domain.StateSummary, error) {
@@ -593,7 +644,7 @@ func TestGetJobs(t *testing.T) {
 
 	Convey("Given a test API instance and a mocked jobservice that returns an error when calling GetJobs", t, func() {
 		mockService := applicationMock.JobServiceMock{
-			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 				return nil, 0, errors.New("database failure")
 			},
 		}
@@ -624,7 +675,7 @@ func TestGetJobs(t *testing.T) {
 
 	Convey("Given a test API instance and a mocked jobservice that returns an error when calling GetJobStatesSummary", t, func() {
 		mockService := applicationMock.JobServiceMock{
-			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 				jobs := []*domain.Job{{ID: "job1", State: domain.StateSubmitted}}
 				return jobs, len(jobs), nil
 			},
@@ -718,6 +769,103 @@ func TestGetJobs(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a test API instance and a mocked jobservice that returns a page of jobs after a cursor", t, func() {
+		testJobs := []*domain.Job{
+			{ID: "job2", JobNumber: 2, State: domain.StateSubmitted},
+			{ID: "job1", JobNumber: 1, State: domain.StateSubmitted},
+		}
+		testSummaries := []domain.StateSummary{
+			{ID: domain.StateSubmitted, Label: "Submitted", Count: 2},
+		}
+		nextCursor := &domain.Cursor{Value: "1", ID: "job1"}
+
+		mockService := applicationMock.JobServiceMock{
+			GetJobsAfterFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error) {
+				return testJobs, nextCursor, nil
+			},
+			GetJobStatesSummaryFunc: func(ctx context.Context) ([]domain.StateSummary, error) {
+				return testSummaries, nil
+			},
+		}
+
+		mockAuthMiddleware := &authorisationMock.MiddlewareMock{
+			RequireFunc: requireWithAuthEntity,
+			CloseFunc: func(ctx context.Context) error {
+				return nil
+			},
+		}
+
+		r := mux.NewRouter()
+		ctx := context.Background()
+		cfg := &config.Config{}
+		api := Setup(ctx, cfg, r, &mockService, mockAuthMiddleware)
+
+		Convey("When a request is made with an after parameter", func() {
+			after := &domain.Cursor{Value: "3", ID: "job3"}
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs?state=submitted&after="+after.Encode(), http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the page of jobs is returned with a link to the next page", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+
+				var jobsResponse JobsCursorListResponse
+				err := json.Unmarshal(resp.Body.Bytes(), &jobsResponse)
+				So(err, ShouldBeNil)
+				So(jobsResponse.Items, ShouldResemble, testJobs)
+				So(jobsResponse.States, ShouldResemble, testSummaries)
+				So(jobsResponse.Count, ShouldEqual, 2)
+				So(jobsResponse.Links.Next, ShouldNotBeNil)
+				So(jobsResponse.Links.Next.HRef, ShouldContainSubstring, "/v1/migration-jobs?")
+				So(jobsResponse.Links.Next.HRef, ShouldContainSubstring, "after="+nextCursor.Encode())
+				So(jobsResponse.Links.Next.HRef, ShouldContainSubstring, "state=submitted")
+				So(resp.Body.String(), ShouldNotContainSubstring, `"total_count"`)
+
+				Convey("And the service is called with the decoded cursor", func() {
+					So(len(mockService.GetJobsAfterCalls()), ShouldEqual, 1)
+					So(mockService.GetJobsAfterCalls()[0].After, ShouldResemble, after)
+					So(mockService.GetJobsAfterCalls()[0].Filter.States, ShouldResemble, []domain.State{domain.StateSubmitted})
+					So(len(mockService.GetJobsCalls()), ShouldEqual, 0)
+				})
+			})
+		})
+
+		Convey("When a request is made with an invalid after parameter", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs?after=invalid", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a bad request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrCursorInvalid.Error())
+				So(len(mockService.GetJobsAfterCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When a request is made with both after and offset parameters", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs?after=&offset=5", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a bad request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrCursorWithOffset.Error())
+			})
+		})
+
+		Convey("When a request is made with the after parameter and sorted by last_updated", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs?after=&sort=last_updated:desc", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a bad request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrCursorSortInvalid.Error())
+				So(len(mockService.GetJobsAfterCalls()), ShouldEqual, 0)
+			})
+		})
+	})
 }
 
 func TestCreateJob(t *testing.T) {
@@ -747,7 +895,7 @@ func TestCreateJob(t *testing.T) {
 		}
 
 		mockService := applicationMock.JobServiceMock{
-			CreateJobFunc: func(ctx context.Context, jobConfig *domain.JobConfig, userID string, userAuthToken string) (*domain.Job, error) {
+			CreateJobFunc: func(ctx context.Context, jobConfig *domain.JobConfig, userID string, userAuthToken string, idempotencyKey string) (*domain.Job, error) {
 				return &createdJob, nil
 			},
 			GetNextJobNumberFunc: func(ctx context.Context) (*domain.Counter, error) {
@@ -800,6 +948,24 @@ func TestCreateJob(t *testing.T) {
 			})
 		})
 
+		Convey("When a valid request is made with an idempotency key", func() {
+			bodyBytes, err := json.Marshal(testConfig)
+			So(err, ShouldBeNil)
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:30100/v1/migration-jobs", bytes.NewBuffer(bodyBytes))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			req.Header.Set(HeaderIdempotencyKey, "test-idempotency-key")
+			resp := httptest.NewRecorder()
+
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the idempotency key is passed to the job service", func() {
+				So(resp.Code, ShouldEqual, http.StatusAccepted)
+				So(mockService.CreateJobCalls(), ShouldHaveLength, 1)
+				So(mockService.CreateJobCalls()[0].IdempotencyKey, ShouldEqual, "test-idempotency-key")
+			})
+		})
+
 		Convey("When an invalid request is made", func() {
 			bodyBytes := []byte("invalidJson")
 
@@ -1125,6 +1291,105 @@ func TestGetJobTasks(t *testing.T) {
 	})
 }
 
+func TestGetJobTaskTree(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice", t, func() {
+		mockAuthMiddleware := &authorisationMock.MiddlewareMock{
+			RequireFunc: requireWithAuthEntity,
+			CloseFunc: func(ctx context.Context) error {
+				return nil
+			},
+		}
+
+		r := mux.NewRouter()
+		ctx := context.Background()
+		cfg := &config.Config{}
+
+		Convey("When a request for a job's task tree is made", func() {
+			series := &domain.Task{ID: "series", JobNumber: testJobNumber, State: domain.StateInReview}
+			edition := &domain.Task{ID: "edition", JobNumber: testJobNumber, State: domain.StateFailedMigration}
+			edition.SetParent(series)
+			nodes := domain.BuildTaskTree([]*domain.Task{series, edition})
+
+			mockService := applicationMock.JobServiceMock{
+				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+					return &domain.Job{JobNumber: jobNumber}, nil
+				},
+				GetJobTaskTreeFunc: func(ctx context.Context, jobNumber int) ([]*domain.TaskNode, int, error) {
+					return nodes, 2, nil
+				},
+			}
+			api := Setup(ctx, cfg, r, &mockService, mockAuthMiddleware)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs/123/tasks/tree", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the nested tasks are returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(len(mockService.GetJobTaskTreeCalls()), ShouldEqual, 1)
+				So(mockService.GetJobTaskTreeCalls()[0].JobNumber, ShouldEqual, testJobNumber)
+
+				var response struct {
+					Items []struct {
+						ID            string       `json:"id"`
+						RolledUpState domain.State `json:"rolled_up_state"`
+						Children      []struct {
+							ID           string   `json:"id"`
+							ParentTaskID string   `json:"parent_task_id"`
+							Depth        int      `json:"depth"`
+							Path         []string `json:"path"`
+						} `json:"children"`
+					} `json:"items"`
+					TotalCount int `json:"total_count"`
+				}
+				err := json.Unmarshal(resp.Body.Bytes(), &response)
+				So(err, ShouldBeNil)
+				So(response.TotalCount, ShouldEqual, 2)
+				So(len(response.Items), ShouldEqual, 1)
+				So(response.Items[0].ID, ShouldEqual, "series")
+				So(response.Items[0].RolledUpState, ShouldEqual, domain.StateFailedMigration)
+				So(len(response.Items[0].Children), ShouldEqual, 1)
+				So(response.Items[0].Children[0].ID, ShouldEqual, "edition")
+				So(response.Items[0].Children[0].ParentTaskID, ShouldEqual, "series")
+				So(response.Items[0].Children[0].Depth, ShouldEqual, 1)
+				So(response.Items[0].Children[0].Path, ShouldResemble, []string{"series"})
+			})
+		})
+
+		Convey("When a request for the task tree of a job that does not exist is made", func() {
+			mockService := applicationMock.JobServiceMock{
+				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+					return nil, appErrors.ErrJobNotFound
+				},
+			}
+			api := Setup(ctx, cfg, r, &mockService, mockAuthMiddleware)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs/123/tasks/tree", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 404 is returned and the tree is not built", func() {
+				So(resp.Code, ShouldEqual, http.StatusNotFound)
+				So(len(mockService.GetJobTaskTreeCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When a request for the task tree is made with an invalid job number", func() {
+			mockService := applicationMock.JobServiceMock{}
+			api := Setup(ctx, cfg, r, &mockService, mockAuthMiddleware)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs/invalid/tasks/tree", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrJobNumberMustBeInt.Error())
+			})
+		})
+	})
+}
+
 func TestGetJobEvents(t *testing.T) {
 	Convey("Given the getJobEvents endpoint", t, func() {
 		mockAuthMiddleware := &authorisationMock.MiddlewareMock{
@@ -1260,8 +1525,8 @@ func TestGetJobEvents(t *testing.T) {
 				{
 					ID:        "event-1",
 					JobNumber: testJobNumber,
-					CreatedAt: "2025-11-19T13:30:00Z",
-					Action:    "submitted",
+					CreatedAt: time.Date(2025, 11, 19, 13, 30, 0, 0, time.UTC),
+					Action:    domain.EventActionJobCreated,
 					RequestedBy: &domain.User{
 						ID:    "user-1",
 						Email: "user1@ons.gov.uk",
@@ -1270,8 +1535,8 @@ func TestGetJobEvents(t *testing.T) {
 				{
 					ID:        "event-2",
 					JobNumber: testJobNumber,
-					CreatedAt: "2025-11-19T13:35:00Z",
-					Action:    "approved",
+					CreatedAt: time.Date(2025, 11, 19, 13, 35, 0, 0, time.UTC),
+					Action:    domain.EventActionStateChanged,
 					RequestedBy: &domain.User{
 						ID:    "user-2",
 						Email: "user2@ons.gov.uk",
@@ -1283,7 +1548,7 @@ func TestGetJobEvents(t *testing.T) {
 				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 					return &domain.Job{JobNumber: jobNumber}, nil
 				},
-				GetJobEventsFunc: func(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Event, int, error) {
+				GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
 					return mockEvents, len(mockEvents), nil
 				},
 			}
@@ -1307,20 +1572,91 @@ func TestGetJobEvents(t *testing.T) {
 					So(ok, ShouldBeTrue)
 					So(len(gotEvents), ShouldEqual, 2)
 					So(gotEvents[0].ID, ShouldEqual, "event-1")
-					So(gotEvents[0].Action, ShouldEqual, "submitted")
+					So(gotEvents[0].Action, ShouldEqual, domain.EventActionJobCreated)
 					So(gotEvents[1].ID, ShouldEqual, "event-2")
-					So(gotEvents[1].Action, ShouldEqual, "approved")
+					So(gotEvents[1].Action, ShouldEqual, domain.EventActionStateChanged)
+				})
+			})
+		})
+
+		Convey("And the request has action and time range filters", func() {
+			mockService := &applicationMock.JobServiceMock{
+				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+					return &domain.Job{JobNumber: jobNumber}, nil
+				},
+				GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
+					return []*domain.Event{}, 0, nil
+				},
+			}
+			api := Setup(ctx, cfg, r, mockService, mockAuthMiddleware)
+
+			req := httptest.NewRequest(http.MethodGet,
+				"http://localhost:30100/v1/migration-jobs/123/events?action=state_changed,task_failed&action=job_created&created_after=2025-01-01T00:00:00Z&created_before=2025-02-01T00:00:00Z", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{PathParameterJobNumber: "123"})
+			req = withAuthEntity(req)
+			rr := httptest.NewRecorder()
+
+			Convey("When getJobEvents is called", func() {
+				_, _, err := api.getJobEvents(rr, req, 10, 0)
+
+				Convey("Then the service is called with the filter", func() {
+					So(err, ShouldBeNil)
+					So(len(mockService.GetJobEventsCalls()), ShouldEqual, 1)
+
+					filter := mockService.GetJobEventsCalls()[0].Filter
+					So(filter.Actions, ShouldResemble, []domain.EventAction{
+						domain.EventActionStateChanged,
+						domain.EventActionTaskFailed,
+						domain.EventActionJobCreated,
+					})
+					So(*filter.CreatedAfter, ShouldEqual, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+					So(*filter.CreatedBefore, ShouldEqual, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
 				})
 			})
 		})
 
+		Convey("And the request has invalid filters", func() {
+			mockService := &applicationMock.JobServiceMock{
+				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+					return &domain.Job{JobNumber: jobNumber}, nil
+				},
+			}
+			api := Setup(ctx, cfg, r, mockService, mockAuthMiddleware)
+
+			testCases := []struct {
+				query       string
+				expectedErr error
+			}{
+				{"action=approved", appErrors.ErrEventActionInvalid},
+				{"created_after=yesterday", appErrors.ErrCreatedAfterInvalid},
+				{"created_before=tomorrow", appErrors.ErrCreatedBeforeInvalid},
+			}
+
+			for _, tc := range testCases {
+				Convey(fmt.Sprintf("When getJobEvents is called with %s", tc.query), func() {
+					req := httptest.NewRequest(http.MethodGet,
+						"http://localhost:30100/v1/migration-jobs/123/events?"+tc.query, http.NoBody)
+					req = mux.SetURLVars(req, map[string]string{PathParameterJobNumber: "123"})
+					req = withAuthEntity(req)
+					rr := httptest.NewRecorder()
+
+					_, _, err := api.getJobEvents(rr, req, 10, 0)
+
+					Convey("Then the error is returned and events are not fetched", func() {
+						So(err, ShouldEqual, tc.expectedErr)
+						So(len(mockService.GetJobEventsCalls()), ShouldEqual, 0)
+					})
+				})
+			}
+		})
+
 		Convey("And GetJob succeeds but GetJobEvents returns an error", func() {
 			testErr := fmt.Errorf("find failure")
 			mockService := &applicationMock.JobServiceMock{
 				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 					return &domain.Job{JobNumber: jobNumber}, nil
 				},
-				GetJobEventsFunc: func(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Event, int, error) {
+				GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
 					return nil, 0, testErr
 				},
 			}
@@ -1349,7 +1685,7 @@ func TestGetJobEvents(t *testing.T) {
 				{
 					ID:          "event-2",
 					JobNumber:   testJobNumber,
-					Action:      "approved",
+					Action:      domain.EventActionStateChanged,
 					RequestedBy: &domain.User{ID: "user-2"},
 				},
 			}
@@ -1358,7 +1694,7 @@ func TestGetJobEvents(t *testing.T) {
 				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 					return &domain.Job{JobNumber: jobNumber}, nil
 				},
-				GetJobEventsFunc: func(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Event, int, error) {
+				GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
 					So(limit, ShouldEqual, 1)
 					So(offset, ShouldEqual, 1)
 					return mockEvents, 5, nil
@@ -1394,7 +1730,7 @@ func TestGetJobEvents(t *testing.T) {
 				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 					return &domain.Job{JobNumber: jobNumber}, nil
 				},
-				GetJobEventsFunc: func(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Event, int, error) {
+				GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
 					return []*domain.Event{}, 0, nil
 				},
 			}
@@ -1426,7 +1762,7 @@ func TestGetJobEvents(t *testing.T) {
 				{
 					ID:        "event-human",
 					JobNumber: testJobNumber,
-					Action:    "approved",
+					Action:    domain.EventActionStateChanged,
 					RequestedBy: &domain.User{
 						ID:    "human-user",
 						Email: "human@ons.gov.uk",
@@ -1435,7 +1771,7 @@ func TestGetJobEvents(t *testing.T) {
 				{
 					ID:        "event-service",
 					JobNumber: testJobNumber,
-					Action:    "migrating",
+					Action:    domain.EventActionStateChanged,
 					RequestedBy: &domain.User{
 						ID: "service-account",
 					},
@@ -1446,7 +1782,7 @@ func TestGetJobEvents(t *testing.T) {
 				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 					return &domain.Job{JobNumber: jobNumber}, nil
 				},
-				GetJobEventsFunc: func(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Event, int, error) {
+				GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
 					return mockEvents, len(mockEvents), nil
 				},
 			}
@@ -1480,19 +1816,19 @@ func TestGetJobEvents(t *testing.T) {
 				{
 					ID:          "event-1",
 					JobNumber:   testJobNumber,
-					Action:      "submitted",
+					Action:      domain.EventActionJobCreated,
 					RequestedBy: &domain.User{ID: "user-1"},
 				},
 				{
 					ID:          "event-2",
 					JobNumber:   testJobNumber,
-					Action:      "migrating",
+					Action:      domain.EventActionStateChanged,
 					RequestedBy: &domain.User{ID: "user-2"},
 				},
 				{
 					ID:          "event-3",
 					JobNumber:   testJobNumber,
-					Action:      "completed",
+					Action:      domain.EventActionTaskFailed,
 					RequestedBy: &domain.User{ID: "user-3"},
 				},
 			}
@@ -1501,7 +1837,7 @@ func TestGetJobEvents(t *testing.T) {
 				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 					return &domain.Job{JobNumber: jobNumber}, nil
 				},
-				GetJobEventsFunc: func(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Event, int, error) {
+				GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
 					return mockEvents, len(mockEvents), nil
 				},
 			}
@@ -1524,12 +1860,177 @@ func TestGetJobEvents(t *testing.T) {
 					gotEvents, ok := items.([]*domain.Event)
 					So(ok, ShouldBeTrue)
 					So(len(gotEvents), ShouldEqual, 3)
-					So(gotEvents[0].Action, ShouldEqual, "submitted")
-					So(gotEvents[1].Action, ShouldEqual, "migrating")
-					So(gotEvents[2].Action, ShouldEqual, "completed")
+					So(gotEvents[0].Action, ShouldEqual, domain.EventActionJobCreated)
+					So(gotEvents[1].Action, ShouldEqual, domain.EventActionStateChanged)
+					So(gotEvents[2].Action, ShouldEqual, domain.EventActionTaskFailed)
+				})
+			})
+		})
+	})
+}
+
+func TestGetJobTasksAfter(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice", t, func() {
+		mockAuthMiddleware := &authorisationMock.MiddlewareMock{
+			RequireFunc: requireWithAuthEntity,
+			CloseFunc: func(ctx context.Context) error {
+				return nil
+			},
+		}
+
+		r := mux.NewRouter()
+		ctx := context.Background()
+		cfg := &config.Config{}
+
+		Convey("When a request for a job's tasks is made with an after parameter", func() {
+			mockTasks := []*domain.Task{
+				{ID: "t3", JobNumber: testJobNumber, State: domain.StateSubmitted},
+			}
+
+			mockService := applicationMock.JobServiceMock{
+				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+					return &domain.Job{JobNumber: jobNumber}, nil
+				},
+				GetJobTasksAfterFunc: func(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error) {
+					return mockTasks, nil, nil
+				},
+			}
+			api := Setup(ctx, cfg, r, &mockService, mockAuthMiddleware)
+
+			after := &domain.Cursor{ID: "t2"}
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs/123/tasks?state=submitted&after="+after.Encode(), http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the server should respond with status 200 OK", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(resp.Body.String(), ShouldContainSubstring, `"id":"t3"`)
+				So(resp.Body.String(), ShouldNotContainSubstring, `"next"`)
+
+				Convey("And the service is called with the cursor and state", func() {
+					So(len(mockService.GetJobTasksAfterCalls()), ShouldEqual, 1)
+					So(mockService.GetJobTasksAfterCalls()[0].JobNumber, ShouldEqual, testJobNumber)
+					So(mockService.GetJobTasksAfterCalls()[0].After, ShouldResemble, after)
+					So(mockService.GetJobTasksAfterCalls()[0].States, ShouldResemble, []domain.State{domain.StateSubmitted})
+					So(len(mockService.GetJobTasksCalls()), ShouldEqual, 0)
+				})
+			})
+		})
+
+		Convey("job not found should return ErrJobNotFound", func() {
+			mockService := applicationMock.JobServiceMock{
+				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+					return nil, appErrors.ErrJobNotFound
+				},
+			}
+			api := Setup(ctx, cfg, r, &mockService, mockAuthMiddleware)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs/123/tasks", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{PathParameterJobNumber: "123"})
+			req = withAuthEntity(req)
+			rr := httptest.NewRecorder()
+
+			items, next, err := api.getJobTasksAfter(rr, req, 10, &domain.Cursor{})
+			So(items, ShouldBeNil)
+			So(next, ShouldBeNil)
+			So(err, ShouldEqual, appErrors.ErrJobNotFound)
+			So(len(mockService.GetJobTasksAfterCalls()), ShouldEqual, 0)
+		})
+
+		Convey("invalid state should return ErrTaskStateInvalid", func() {
+			mockService := applicationMock.JobServiceMock{
+				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+					return &domain.Job{JobNumber: jobNumber}, nil
+				},
+			}
+			api := Setup(ctx, cfg, r, &mockService, mockAuthMiddleware)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs/123/tasks?state=unknown", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{PathParameterJobNumber: "123"})
+			req = withAuthEntity(req)
+			rr := httptest.NewRecorder()
+
+			items, next, err := api.getJobTasksAfter(rr, req, 10, &domain.Cursor{})
+			So(items, ShouldBeNil)
+			So(next, ShouldBeNil)
+			So(err, ShouldEqual, appErrors.ErrTaskStateInvalid)
+		})
+	})
+}
+
+func TestGetJobEventsAfter(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice", t, func() {
+		mockAuthMiddleware := &authorisationMock.MiddlewareMock{
+			RequireFunc: requireWithAuthEntity,
+			CloseFunc: func(ctx context.Context) error {
+				return nil
+			},
+		}
+
+		r := mux.NewRouter()
+		ctx := context.Background()
+		cfg := &config.Config{}
+
+		Convey("When a request for a job's events is made with an empty after parameter", func() {
+			mockEvents := []*domain.Event{
+				{ID: "event-2", JobNumber: testJobNumber, CreatedAt: time.Date(2025, 11, 19, 13, 35, 0, 0, time.UTC)},
+			}
+			nextCursor := &domain.Cursor{Value: "2025-11-19T13:35:00Z", ID: "event-2"}
+
+			mockService := applicationMock.JobServiceMock{
+				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+					return &domain.Job{JobNumber: jobNumber}, nil
+				},
+				GetJobEventsAfterFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error) {
+					return mockEvents, nextCursor, nil
+				},
+			}
+			api := Setup(ctx, cfg, r, &mockService, mockAuthMiddleware)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs/123/events?after=", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the first page of events is returned with a link to the next page", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+
+				var paginatedResponse CursorPaginatedResponse
+				err := json.Unmarshal(resp.Body.Bytes(), &paginatedResponse)
+				So(err, ShouldBeNil)
+				So(paginatedResponse.Count, ShouldEqual, 1)
+				So(paginatedResponse.Links.Next, ShouldNotBeNil)
+				So(paginatedResponse.Links.Next.HRef, ShouldEqual, "/v1/migration-jobs/123/events?after="+nextCursor.Encode())
+
+				Convey("And the service is called from the start of the list", func() {
+					So(len(mockService.GetJobEventsAfterCalls()), ShouldEqual, 1)
+					So(mockService.GetJobEventsAfterCalls()[0].JobNumber, ShouldEqual, testJobNumber)
+					So(mockService.GetJobEventsAfterCalls()[0].After.IsStart(), ShouldBeTrue)
 				})
 			})
 		})
+
+		Convey("GetJobEventsAfter returns an error is propagated", func() {
+			testErr := fmt.Errorf("find failure")
+			mockService := applicationMock.JobServiceMock{
+				GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+					return &domain.Job{JobNumber: jobNumber}, nil
+				},
+				GetJobEventsAfterFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error) {
+					return nil, nil, testErr
+				},
+			}
+			api := Setup(ctx, cfg, r, &mockService, mockAuthMiddleware)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs/123/events", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{PathParameterJobNumber: "123"})
+			req = withAuthEntity(req)
+			rr := httptest.NewRecorder()
+
+			items, next, err := api.getJobEventsAfter(rr, req, 10, &domain.Cursor{})
+			So(items, ShouldBeNil)
+			So(next, ShouldBeNil)
+			So(err, ShouldBeError, testErr)
+		})
 	})
 }
 
@@ -1663,3 +2164,179 @@ func TestGetUserID(t *testing.T) {
 		})
 	})
 }
+
+func TestUpdateJobStateReason(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice with a job in review", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{JobNumber: jobNumber, State: domain.StateInReview}, nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
+				return nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When the job is rejected with a reason", func() {
+			body := `{"state":"rejected","reason":" Wrong dataset edition "}`
+			req := httptest.NewRequest(http.MethodPut, "http://localhost:30100/v1/migration-jobs/12/state", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the reason is passed on with the state change", func() {
+				So(resp.Code, ShouldEqual, http.StatusNoContent)
+				So(mockService.UpdateJobStateCalls(), ShouldHaveLength, 1)
+				call := mockService.UpdateJobStateCalls()[0]
+				So(call.NewState, ShouldEqual, domain.StateRejected)
+				So(call.UserID, ShouldEqual, testAuthUserID)
+				So(call.Reason, ShouldEqual, "Wrong dataset edition")
+			})
+		})
+	})
+}
+
+func TestUpdateJobStatePublishAt(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice with a job in review", t, func() {
+		var scheduled []time.Time
+
+		mockService := &applicationMock.JobServiceMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{JobNumber: jobNumber, State: domain.StateInReview}, nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
+				if opts != nil && opts.PublishAt != nil {
+					scheduled = append(scheduled, *opts.PublishAt)
+				}
+				return nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		updateState := func(body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPut, "http://localhost:30100/v1/migration-jobs/12/state", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+			return resp
+		}
+
+		Convey("When the job is approved with a future publish time", func() {
+			publishAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+			resp := updateState(fmt.Sprintf(`{"state":"approved","publish_at":%q}`, publishAt.Format(time.RFC3339)))
+
+			Convey("Then the publish time is passed on with the approval", func() {
+				So(resp.Code, ShouldEqual, http.StatusNoContent)
+				So(scheduled, ShouldHaveLength, 1)
+				So(scheduled[0].Equal(publishAt), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the job is approved with a publish time in the past", func() {
+			resp := updateState(`{"state":"approved","publish_at":"2020-01-01T09:30:00Z"}`)
+
+			Convey("Then a 400 Bad Request is returned and the job is not updated", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrPublishAtInPast.Error())
+				So(mockService.UpdateJobStateCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the job is rejected with a publish time", func() {
+			publishAt := time.Now().Add(time.Hour).UTC()
+			resp := updateState(fmt.Sprintf(`{"state":"rejected","publish_at":%q}`, publishAt.Format(time.RFC3339)))
+
+			Convey("Then a 400 Bad Request is returned and the job is not updated", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrPublishAtNotAllowed.Error())
+				So(mockService.UpdateJobStateCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestUpdateJobStateFourEyesOverride(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice with a job in review", t, func() {
+		var overridden []bool
+
+		mockService := &applicationMock.JobServiceMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{JobNumber: jobNumber, State: domain.StateInReview}, nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
+				overridden = append(overridden, opts.FourEyesOverride)
+				return nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+		api.FourEyesOverridePermission = "migrations:admin"
+
+		approve := func() *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPut, "http://localhost:30100/v1/migration-jobs/12/state", strings.NewReader(`{"state":"approved"}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+			return resp
+		}
+
+		Convey("When a user holding the override permission approves the job", func() {
+			api.Permissions = permissionsCheckerFunc(func(entityData permsdk.EntityData, permission string) (bool, error) {
+				return entityData.UserID == testAuthUserID && permission == "migrations:admin", nil
+			})
+			resp := approve()
+
+			Convey("Then the job is approved with the four eyes rule overridden", func() {
+				So(resp.Code, ShouldEqual, http.StatusNoContent)
+				So(overridden, ShouldResemble, []bool{true})
+			})
+		})
+
+		Convey("When a user without the override permission approves the job", func() {
+			api.Permissions = permissionsCheckerFunc(func(entityData permsdk.EntityData, permission string) (bool, error) {
+				return false, nil
+			})
+			resp := approve()
+
+			Convey("Then the job is approved without overriding the four eyes rule", func() {
+				So(resp.Code, ShouldEqual, http.StatusNoContent)
+				So(overridden, ShouldResemble, []bool{false})
+			})
+		})
+
+		Convey("When the override permission cannot be checked", func() {
+			api.Permissions = permissionsCheckerFunc(func(entityData permsdk.EntityData, permission string) (bool, error) {
+				return false, errors.New("permissions unavailable")
+			})
+			resp := approve()
+
+			Convey("Then a 500 Internal Server Error is returned and the job is not updated", func() {
+				So(resp.Code, ShouldEqual, http.StatusInternalServerError)
+				So(overridden, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a test API instance and a mocked jobservice that refuses the submitter's approval", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{JobNumber: jobNumber, State: domain.StateInReview}, nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
+				return appErrors.ErrApproverIsSubmitter
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When the submitter approves the job", func() {
+			req := httptest.NewRequest(http.MethodPut, "http://localhost:30100/v1/migration-jobs/12/state", strings.NewReader(`{"state":"approved"}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 403 Forbidden is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusForbidden)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrApproverIsSubmitter.Error())
+			})
+		})
+	})
+}
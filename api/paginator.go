@@ -3,15 +3,22 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strconv"
 
+	"github.com/ONSdigital/dis-migration-service/domain"
 	appErrors "github.com/ONSdigital/dis-migration-service/errors"
 )
 
 // PaginatedHandler defines a handler function signature for paginated endpoints
 type PaginatedHandler func(w http.ResponseWriter, r *http.Request, limit int, offset int) (items interface{}, totalCount int, err error)
 
+// CursorPaginatedHandler defines a handler function signature for endpoints
+// paginated with a cursor. The returned cursor is nil if there are no more
+// items.
+type CursorPaginatedHandler func(w http.ResponseWriter, r *http.Request, limit int, after *domain.Cursor) (items interface{}, next *domain.Cursor, err error)
+
 // PaginatedResponse represents a paginated API response
 type PaginatedResponse struct {
 	Items            interface{} `json:"items"`
@@ -27,6 +34,25 @@ type PaginationFields struct {
 	TotalCount int `json:"total_count"`
 }
 
+// CursorPaginatedResponse represents a cursor paginated API response
+type CursorPaginatedResponse struct {
+	Items                  interface{} `json:"items"`
+	CursorPaginationFields             // embedded, flattening fields into JSON
+}
+
+// CursorPaginationFields represents the generic fields used to describe
+// cursor pagination in an API response
+type CursorPaginationFields struct {
+	Count int         `json:"count"`
+	Limit int         `json:"limit"`
+	Links CursorLinks `json:"links"`
+}
+
+// CursorLinks contains the links to navigate a cursor paginated response
+type CursorLinks struct {
+	Next *domain.LinkObject `json:"next,omitempty"`
+}
+
 // Paginator handles pagination logic for API endpoints
 type Paginator struct {
 	DefaultLimit    int
@@ -73,6 +99,27 @@ func (p *Paginator) getPaginationParameters(r *http.Request) (limit, offset int,
 	return limit, offset, errs
 }
 
+// getCursorParameter returns the cursor provided by the after query
+// parameter, and whether the parameter was provided at all. An empty after
+// parameter requests the first page.
+func (p *Paginator) getCursorParameter(r *http.Request) (after *domain.Cursor, ok bool, err error) {
+	query := r.URL.Query()
+	if _, ok := query[QueryParameterAfter]; !ok {
+		return nil, false, nil
+	}
+
+	if query.Get(QueryParameterOffset) != "" {
+		return nil, true, appErrors.ErrCursorWithOffset
+	}
+
+	after, err = domain.DecodeCursor(query.Get(QueryParameterAfter))
+	if err != nil {
+		return nil, true, err
+	}
+
+	return after, true, nil
+}
+
 func generatePaginatedResponse(list interface{}, limit, offset, totalCount int) PaginatedResponse {
 	if listLength(list) == 0 {
 		list = []interface{}{}
@@ -89,6 +136,35 @@ func generatePaginatedResponse(list interface{}, limit, offset, totalCount int)
 	}
 }
 
+func generateCursorPaginationFields(r *http.Request, count, limit int, next *domain.Cursor) CursorPaginationFields {
+	fields := CursorPaginationFields{
+		Count: count,
+		Limit: limit,
+	}
+
+	if next != nil {
+		fields.Links.Next = &domain.LinkObject{
+			HRef: nextPageURL(r, next),
+		}
+	}
+
+	return fields
+}
+
+// nextPageURL returns the URL of the request with the after query parameter
+// replaced by the provided cursor.
+func nextPageURL(r *http.Request, next *domain.Cursor) string {
+	query := r.URL.Query()
+	query.Set(QueryParameterAfter, next.Encode())
+
+	nextURL := url.URL{
+		Path:     r.URL.Path,
+		RawQuery: query.Encode(),
+	}
+
+	return nextURL.String()
+}
+
 func listLength(list interface{}) int {
 	l := reflect.ValueOf(list)
 	return l.Len()
@@ -122,3 +198,54 @@ func (p *Paginator) Paginate(paginatedHandler PaginatedHandler) func(w http.Resp
 		handleSuccess(ctx, w, r, http.StatusOK, responseBytes)
 	}
 }
+
+// PaginateWithCursor is a middleware function that handles pagination for
+// API endpoints that support both offset and cursor pagination. Requests
+// with an after query parameter are paginated with a cursor, otherwise the
+// offset handler is used.
+func (p *Paginator) PaginateWithCursor(paginatedHandler PaginatedHandler, cursorPaginatedHandler CursorPaginatedHandler) func(w http.ResponseWriter, r *http.Request) {
+	paginate := p.Paginate(paginatedHandler)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		after, ok, err := p.getCursorParameter(r)
+		if !ok {
+			paginate(w, r)
+			return
+		}
+		if err != nil {
+			handleError(ctx, w, r, err)
+			return
+		}
+
+		limit, _, errs := p.getPaginationParameters(r)
+		if len(errs) > 0 {
+			handleError(ctx, w, r, errs...)
+			return
+		}
+
+		items, next, err := cursorPaginatedHandler(w, r, limit, after)
+		if err != nil {
+			handleError(ctx, w, r, err)
+			return
+		}
+
+		if listLength(items) == 0 {
+			items = []interface{}{}
+		}
+
+		paginatedResults := CursorPaginatedResponse{
+			Items:                  items,
+			CursorPaginationFields: generateCursorPaginationFields(r, listLength(items), limit, next),
+		}
+
+		responseBytes, err := json.Marshal(paginatedResults)
+		if err != nil {
+			handleError(ctx, w, r, err)
+			return
+		}
+
+		handleSuccess(ctx, w, r, http.StatusOK, responseBytes)
+	}
+}
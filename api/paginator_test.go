@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -150,6 +153,127 @@ func TestPaginatorPaginate(t *testing.T) {
 	})
 }
 
+func TestPaginatorPaginateWithCursor(t *testing.T) {
+	Convey("Given a Paginator with offset and cursor handlers", t, func() {
+		p := NewPaginator(10, 0, 100)
+
+		var (
+			offsetCalls int
+			cursorAfter *domain.Cursor
+			cursorLimit int
+			nextCursor  *domain.Cursor
+		)
+
+		offsetHandler := func(w http.ResponseWriter, r *http.Request, limit, offset int) (interface{}, int, error) {
+			offsetCalls++
+			return []testItem{{ID: "item1"}}, 1, nil
+		}
+		cursorHandler := func(w http.ResponseWriter, r *http.Request, limit int, after *domain.Cursor) (interface{}, *domain.Cursor, error) {
+			cursorAfter = after
+			cursorLimit = limit
+			return []testItem{{ID: "item1"}, {ID: "item2"}}, nextCursor, nil
+		}
+		paginate := p.PaginateWithCursor(offsetHandler, cursorHandler)
+
+		Convey("When a request is made without an after parameter", func() {
+			req := httptest.NewRequest(http.MethodGet, "/test?limit=2&offset=0", http.NoBody)
+			resp := httptest.NewRecorder()
+			paginate(resp, req)
+
+			Convey("Then the offset handler is used", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(offsetCalls, ShouldEqual, 1)
+				So(cursorAfter, ShouldBeNil)
+				So(resp.Body.String(), ShouldContainSubstring, `"total_count":1`)
+			})
+		})
+
+		Convey("When a request is made with an empty after parameter and there are more items", func() {
+			nextCursor = &domain.Cursor{ID: "item2"}
+			req := httptest.NewRequest(http.MethodGet, "/test?limit=2&state=submitted&after=", http.NoBody)
+			resp := httptest.NewRecorder()
+			paginate(resp, req)
+
+			Convey("Then the cursor handler is called from the start", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(offsetCalls, ShouldEqual, 0)
+				So(cursorAfter, ShouldNotBeNil)
+				So(cursorAfter.IsStart(), ShouldBeTrue)
+				So(cursorLimit, ShouldEqual, 2)
+
+				Convey("And the response contains a link to the next page", func() {
+					var paginatedResponse CursorPaginatedResponse
+					err := json.Unmarshal(resp.Body.Bytes(), &paginatedResponse)
+					So(err, ShouldBeNil)
+					So(paginatedResponse.Count, ShouldEqual, 2)
+					So(paginatedResponse.Limit, ShouldEqual, 2)
+					So(paginatedResponse.Links.Next, ShouldNotBeNil)
+
+					nextURL, err := url.Parse(paginatedResponse.Links.Next.HRef)
+					So(err, ShouldBeNil)
+					So(nextURL.Path, ShouldEqual, "/test")
+					So(nextURL.Query().Get(QueryParameterLimit), ShouldEqual, "2")
+					So(nextURL.Query().Get(QueryParameterState), ShouldEqual, "submitted")
+					So(nextURL.Query().Get(QueryParameterAfter), ShouldEqual, nextCursor.Encode())
+				})
+			})
+		})
+
+		Convey("When a request is made with a cursor for the last page", func() {
+			after := &domain.Cursor{ID: "item0"}
+			req := httptest.NewRequest(http.MethodGet, "/test?after="+after.Encode(), http.NoBody)
+			resp := httptest.NewRecorder()
+			paginate(resp, req)
+
+			Convey("Then the cursor is passed to the handler with the default limit", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(cursorAfter, ShouldResemble, after)
+				So(cursorLimit, ShouldEqual, 10)
+
+				Convey("And the response does not contain a next link", func() {
+					So(resp.Body.String(), ShouldNotContainSubstring, `"next"`)
+					So(resp.Body.String(), ShouldNotContainSubstring, `"total_count"`)
+				})
+			})
+		})
+
+		Convey("When a request is made with an invalid after parameter", func() {
+			req := httptest.NewRequest(http.MethodGet, "/test?after=invalid", http.NoBody)
+			resp := httptest.NewRecorder()
+			paginate(resp, req)
+
+			Convey("Then a bad request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrCursorInvalid.Error())
+			})
+		})
+
+		Convey("When a request is made with both after and offset parameters", func() {
+			req := httptest.NewRequest(http.MethodGet, "/test?after=&offset=10", http.NoBody)
+			resp := httptest.NewRecorder()
+			paginate(resp, req)
+
+			Convey("Then a bad request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrCursorWithOffset.Error())
+				So(offsetCalls, ShouldEqual, 0)
+				So(cursorAfter, ShouldBeNil)
+			})
+		})
+
+		Convey("When a request is made with an after parameter and an invalid limit", func() {
+			req := httptest.NewRequest(http.MethodGet, "/test?after=&limit=1000", http.NoBody)
+			resp := httptest.NewRecorder()
+			paginate(resp, req)
+
+			Convey("Then a bad request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(cursorAfter, ShouldBeNil)
+			})
+		})
+	})
+}
+
 func TestListLength(t *testing.T) {
 	Convey("Given a slice of testItem", t, func() {
 		items := []testItem{{ID: "item1"}, {ID: "item2"}}
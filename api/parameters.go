@@ -1,12 +1,51 @@
 package api
 
 const (
+	// HeaderIdempotencyKey is the name of the header used to make job
+	// creation requests safe to retry.
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderLastEventID is the name of the header used by stream clients to
+	// resume from the last message they received.
+	HeaderLastEventID = "Last-Event-ID"
+	// PathParameterBatchID is the name of the batch ID path parameter.
+	PathParameterBatchID = "batch_id"
 	// PathParameterJobNumber is the name of the job number path parameter.
 	PathParameterJobNumber = "job_number"
+	// PathParameterReleaseGroupID is the name of the release group ID path
+	// parameter.
+	PathParameterReleaseGroupID = "release_group_id"
+	// PathParameterWebhookID is the name of the webhook ID path parameter.
+	PathParameterWebhookID = "webhook_id"
+	// QueryParameterAction is the name of the event action query parameter.
+	QueryParameterAction = "action"
+	// QueryParameterAfter is the name of the cursor query parameter.
+	QueryParameterAfter = "after"
+	// QueryParameterCreatedAfter is the name of the created after query parameter.
+	QueryParameterCreatedAfter = "created_after"
+	// QueryParameterCreatedBefore is the name of the created before query parameter.
+	QueryParameterCreatedBefore = "created_before"
+	// QueryParameterCreatedBy is the name of the created by query parameter.
+	QueryParameterCreatedBy = "created_by"
+	// QueryParameterLabel is the name of the label search query parameter.
+	QueryParameterLabel = "label"
 	// QueryParameterLimit is the name of the limit query parameter.
 	QueryParameterLimit = "limit"
 	// QueryParameterOffset is the name of the offset query parameter.
 	QueryParameterOffset = "offset"
 	// QueryParameterSort is the name of the sort query parameter.
 	QueryParameterSort = "sort"
+	// QueryParameterSourceID is the name of the source ID query parameter.
+	QueryParameterSourceID = "source_id"
+	// QueryParameterState is the name of the state query parameter.
+	QueryParameterState = "state"
+	// QueryParameterStatus is the name of the webhook delivery status query parameter.
+	QueryParameterStatus = "status"
+	// QueryParameterTargetID is the name of the target ID query parameter.
+	QueryParameterTargetID = "target_id"
+	// QueryParameterType is the name of the job type query parameter.
+	QueryParameterType = "type"
+	// QueryParameterUpdatedAfter is the name of the updated after query parameter.
+	QueryParameterUpdatedAfter = "updated_after"
+	// QueryParameterUpdatedBefore is the name of the updated before query parameter.
+	QueryParameterUpdatedBefore = "updated_before"
 )
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// PriorityRequest represents the payload used to change the priority of a
+// job.
+type PriorityRequest struct {
+	Priority *int `json:"priority"`
+}
+
+// updateJobPriority handles requests to change the priority of a migration
+// job and its tasks.
+func (api *MigrationAPI) updateJobPriority(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "job priority endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	// Extract user ID from JWT token
+	userID, err := api.GetUserID(r)
+	if err != nil {
+		log.Info(ctx, "failed to extract user ID from token", log.Data{
+			"error": err.Error(),
+		})
+		handleError(ctx, w, r, appErrors.ErrUnauthorized)
+		return
+	}
+
+	jobNumber, err := strconv.Atoi(mux.Vars(r)[PathParameterJobNumber])
+	if err != nil {
+		log.Info(ctx, "failed to update job priority - job number must be an int")
+		handleError(ctx, w, r, appErrors.ErrJobNumberMustBeInt)
+		return
+	}
+
+	logData := log.Data{
+		"job_number": jobNumber,
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Info(ctx, "unable to read request body", logData)
+		handleError(ctx, w, r, appErrors.ErrUnableToParseBody)
+		return
+	}
+
+	var req PriorityRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		log.Info(ctx, "failed to decode priority request body", logData)
+		handleError(ctx, w, r, appErrors.ErrUnableToParseBody)
+		return
+	}
+
+	if req.Priority == nil {
+		log.Info(ctx, "priority not provided", logData)
+		handleError(ctx, w, r, appErrors.ErrJobPriorityNotProvided)
+		return
+	}
+
+	logData["priority"] = *req.Priority
+
+	err = api.JobService.UpdateJobPriority(ctx, jobNumber, *req.Priority, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrJobNotFound),
+			errors.Is(err, appErrors.ErrJobPriorityInvalid),
+			errors.Is(err, appErrors.ErrJobPriorityNotAllowed):
+			log.Info(ctx, "job priority change refused", logData)
+		default:
+			log.Error(ctx, "failed to update job priority", err, logData)
+		}
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully updated job priority", authEntityData, domain.ActionUpdate, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	log.Info(ctx, "job priority updated successfully", logData)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	applicationMock "github.com/ONSdigital/dis-migration-service/application/mock"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testPriorityURL = "http://localhost:30100/v1/migration-jobs/12/priority"
+
+func TestUpdateJobPriority(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice that changes job priorities", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			UpdateJobPriorityFunc: func(ctx context.Context, jobNumber int, priority int, userID string) error {
+				return nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When a job's priority is changed", func() {
+			req := httptest.NewRequest(http.MethodPut, testPriorityURL, strings.NewReader(`{"priority":8}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the job's priority is changed by the token's user", func() {
+				So(resp.Code, ShouldEqual, http.StatusNoContent)
+				So(mockService.UpdateJobPriorityCalls(), ShouldHaveLength, 1)
+				call := mockService.UpdateJobPriorityCalls()[0]
+				So(call.JobNumber, ShouldEqual, 12)
+				So(call.Priority, ShouldEqual, 8)
+				So(call.UserID, ShouldEqual, testAuthUserID)
+			})
+		})
+
+		Convey("When a job's priority is changed without a priority", func() {
+			req := httptest.NewRequest(http.MethodPut, testPriorityURL, strings.NewReader(`{}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 Bad Request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrJobPriorityNotProvided.Error())
+				So(mockService.UpdateJobPriorityCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a job's priority is changed with an invalid body", func() {
+			req := httptest.NewRequest(http.MethodPut, testPriorityURL, strings.NewReader(`{"priority":"high"}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 Bad Request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(mockService.UpdateJobPriorityCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a test API instance and a mocked jobservice with a job that has finished", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			UpdateJobPriorityFunc: func(ctx context.Context, jobNumber int, priority int, userID string) error {
+				return appErrors.ErrJobPriorityNotAllowed
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When the job's priority is changed", func() {
+			req := httptest.NewRequest(http.MethodPut, testPriorityURL, strings.NewReader(`{"priority":8}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 409 Conflict is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusConflict)
+			})
+		})
+	})
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// ReleaseGroupRequest represents the payload used to create a release
+// group.
+type ReleaseGroupRequest struct {
+	Name       string `json:"name"`
+	JobNumbers []int  `json:"job_numbers"`
+}
+
+// createReleaseGroup handles requests to group migration jobs so that
+// they are published together.
+func (api *MigrationAPI) createReleaseGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "createReleaseGroup endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	// Extract user ID from JWT token
+	userID, err := api.GetUserID(r)
+	if err != nil {
+		log.Info(ctx, "failed to extract user ID from token", log.Data{
+			"error": err.Error(),
+		})
+		handleError(ctx, w, r, appErrors.ErrUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Info(ctx, "unable to read request body")
+		handleError(ctx, w, r, appErrors.ErrUnableToParseBody)
+		return
+	}
+
+	var req ReleaseGroupRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		log.Info(ctx, "failed to decode release group request body")
+		handleError(ctx, w, r, appErrors.ErrUnableToParseBody)
+		return
+	}
+
+	group := domain.NewReleaseGroup(req.Name, req.JobNumbers, userID)
+	if errs := group.Validate(); len(errs) > 0 {
+		log.Info(ctx, "failed to validate release group")
+		handleError(ctx, w, r, errs...)
+		return
+	}
+
+	logData := log.Data{
+		"release_group_id": group.ID,
+		"job_numbers":      group.JobNumbers,
+	}
+
+	group, err = api.JobService.CreateReleaseGroup(ctx, group)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrReleaseGroupJobNotFound),
+			errors.Is(err, appErrors.ErrJobAlreadyInReleaseGroup),
+			errors.Is(err, appErrors.ErrJobNotGroupable):
+			log.Info(ctx, "release group refused", logData)
+		default:
+			log.Error(ctx, "failed to create release group", err, logData)
+		}
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	bytes, err := json.Marshal(group)
+	if err != nil {
+		log.Error(ctx, "failed to marshal response", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully created release group", authEntityData, domain.ActionCreate, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusCreated, bytes)
+}
+
+// getReleaseGroup handles requests to retrieve a release group and the
+// progress of its jobs.
+func (api *MigrationAPI) getReleaseGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "getReleaseGroup endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	groupID := mux.Vars(r)[PathParameterReleaseGroupID]
+
+	group, err := api.JobService.GetReleaseGroup(ctx, groupID)
+	if err != nil {
+		if !errors.Is(err, appErrors.ErrReleaseGroupNotFound) {
+			log.Error(ctx, "failed to get release group with id: "+groupID, err)
+		}
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	bytes, err := json.Marshal(group)
+	if err != nil {
+		log.Error(ctx, "failed to marshal response", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully retrieved release group", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusOK, bytes)
+}
+
+// deleteReleaseGroup handles requests to disband a release group, so that
+// its jobs are published independently.
+func (api *MigrationAPI) deleteReleaseGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "deleteReleaseGroup endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	groupID := mux.Vars(r)[PathParameterReleaseGroupID]
+
+	if err := api.JobService.DeleteReleaseGroup(ctx, groupID); err != nil {
+		if !errors.Is(err, appErrors.ErrReleaseGroupNotFound) {
+			log.Error(ctx, "failed to delete release group with id: "+groupID, err)
+		}
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully deleted release group", authEntityData, domain.ActionDelete, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusNoContent, nil)
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	applicationMock "github.com/ONSdigital/dis-migration-service/application/mock"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testReleaseGroupsURL = "http://localhost:30100/v1/migration-release-groups"
+
+func TestCreateReleaseGroup(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice that creates release groups", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			CreateReleaseGroupFunc: func(ctx context.Context, group *domain.ReleaseGroup) (*domain.ReleaseGroup, error) {
+				return group, nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When a release group is submitted", func() {
+			req := httptest.NewRequest(http.MethodPost, testReleaseGroupsURL, strings.NewReader(`{"name":"Regional series","job_numbers":[1,2]}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the group is created by the token's user", func() {
+				So(resp.Code, ShouldEqual, http.StatusCreated)
+
+				So(mockService.CreateReleaseGroupCalls(), ShouldHaveLength, 1)
+				group := mockService.CreateReleaseGroupCalls()[0].Group
+				So(group.Name, ShouldEqual, "Regional series")
+				So(group.JobNumbers, ShouldResemble, []int{1, 2})
+				So(group.CreatedBy.ID, ShouldEqual, testAuthUserID)
+
+				var response domain.ReleaseGroup
+				So(json.Unmarshal(resp.Body.Bytes(), &response), ShouldBeNil)
+				So(response.ID, ShouldEqual, group.ID)
+			})
+		})
+
+		Convey("When a release group with a single job is submitted", func() {
+			req := httptest.NewRequest(http.MethodPost, testReleaseGroupsURL, strings.NewReader(`{"name":"Regional series","job_numbers":[1]}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 Bad Request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrReleaseGroupTooFewJobs.Error())
+				So(mockService.CreateReleaseGroupCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the body cannot be parsed", func() {
+			req := httptest.NewRequest(http.MethodPost, testReleaseGroupsURL, strings.NewReader("{"))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 Bad Request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(mockService.CreateReleaseGroupCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a test API instance and a mocked jobservice with a job that has started publishing", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			CreateReleaseGroupFunc: func(ctx context.Context, group *domain.ReleaseGroup) (*domain.ReleaseGroup, error) {
+				return nil, appErrors.ErrJobNotGroupable
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When a release group is submitted", func() {
+			req := httptest.NewRequest(http.MethodPost, testReleaseGroupsURL, strings.NewReader(`{"name":"Regional series","job_numbers":[1,2]}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 409 Conflict is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusConflict)
+			})
+		})
+	})
+}
+
+func TestGetReleaseGroup(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice with a release group", t, func() {
+		group := domain.NewReleaseGroup("Regional series", []int{1, 2}, "user-123")
+		group.SetStatus([]*domain.Job{{JobNumber: 1, State: domain.StateApproved}, {JobNumber: 2, State: domain.StateInReview}})
+
+		mockService := &applicationMock.JobServiceMock{
+			GetReleaseGroupFunc: func(ctx context.Context, groupID string) (*domain.ReleaseGroup, error) {
+				if groupID != group.ID {
+					return nil, appErrors.ErrReleaseGroupNotFound
+				}
+				return group, nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When the release group is requested", func() {
+			req := httptest.NewRequest(http.MethodGet, testReleaseGroupsURL+"/"+group.ID, http.NoBody)
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the group and its status are returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+
+				var response domain.ReleaseGroup
+				So(json.Unmarshal(resp.Body.Bytes(), &response), ShouldBeNil)
+				So(response.ID, ShouldEqual, group.ID)
+				So(response.Status, ShouldEqual, domain.ReleaseGroupStatusAwaitingApproval)
+				So(response.Jobs, ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When a release group that does not exist is requested", func() {
+			req := httptest.NewRequest(http.MethodGet, testReleaseGroupsURL+"/missing", http.NoBody)
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 404 Not Found is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestDeleteReleaseGroup(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice that deletes release groups", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			DeleteReleaseGroupFunc: func(ctx context.Context, groupID string) error {
+				return nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When a release group is deleted", func() {
+			req := httptest.NewRequest(http.MethodDelete, testReleaseGroupsURL+"/group-id", http.NoBody)
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the group is deleted", func() {
+				So(resp.Code, ShouldEqual, http.StatusNoContent)
+				So(mockService.DeleteReleaseGroupCalls(), ShouldHaveLength, 1)
+				So(mockService.DeleteReleaseGroupCalls()[0].GroupID, ShouldEqual, "group-id")
+			})
+		})
+	})
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// ScheduleRequest represents the payload used to schedule when a job is
+// published.
+type ScheduleRequest struct {
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+// scheduleJob handles requests to set or change when a migration job in
+// review or approved is published.
+func (api *MigrationAPI) scheduleJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	logData := log.Data{
+		"job_number": mux.Vars(r)[PathParameterJobNumber],
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Info(ctx, "unable to read request body", logData)
+		handleError(ctx, w, r, appErrors.ErrUnableToParseBody)
+		return
+	}
+
+	var req ScheduleRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		log.Info(ctx, "failed to decode schedule request body", logData)
+		handleError(ctx, w, r, appErrors.ErrUnableToParseBody)
+		return
+	}
+
+	if req.PublishAt == nil {
+		log.Info(ctx, "publish time not provided", logData)
+		handleError(ctx, w, r, appErrors.ErrPublishAtNotProvided)
+		return
+	}
+
+	if !req.PublishAt.After(time.Now()) {
+		log.Info(ctx, "publish time provided is in the past", logData)
+		handleError(ctx, w, r, appErrors.ErrPublishAtInPast)
+		return
+	}
+
+	api.updateJobSchedule(w, r, req.PublishAt, domain.ActionUpdate)
+}
+
+// cancelJobSchedule handles requests to remove the scheduled publish time
+// of a migration job, so that it is published as soon as it is approved.
+func (api *MigrationAPI) cancelJobSchedule(w http.ResponseWriter, r *http.Request) {
+	api.updateJobSchedule(w, r, nil, domain.ActionDelete)
+}
+
+// updateJobSchedule sets or, when publishAt is nil, cancels the schedule
+// of the job in the request path on behalf of the user in the request's
+// token.
+func (api *MigrationAPI) updateJobSchedule(w http.ResponseWriter, r *http.Request, publishAt *time.Time, action domain.Action) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "job schedule endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	// Extract user ID from JWT token
+	userID, err := api.GetUserID(r)
+	if err != nil {
+		log.Info(ctx, "failed to extract user ID from token", log.Data{
+			"error": err.Error(),
+		})
+		handleError(ctx, w, r, appErrors.ErrUnauthorized)
+		return
+	}
+
+	jobNumber, err := strconv.Atoi(mux.Vars(r)[PathParameterJobNumber])
+	if err != nil {
+		log.Info(ctx, "failed to schedule job - job number must be an int")
+		handleError(ctx, w, r, appErrors.ErrJobNumberMustBeInt)
+		return
+	}
+
+	logData := log.Data{
+		"job_number": jobNumber,
+		"publish_at": publishAt,
+	}
+
+	err = api.JobService.ScheduleJob(ctx, jobNumber, publishAt, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrJobNotFound),
+			errors.Is(err, appErrors.ErrJobScheduleNotAllowed),
+			errors.Is(err, appErrors.ErrPublishAtInPast):
+			log.Info(ctx, "job schedule refused", logData)
+		default:
+			log.Error(ctx, "failed to update job schedule", err, logData)
+		}
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully updated job schedule", authEntityData, action, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	log.Info(ctx, "job schedule updated successfully", logData)
+	w.WriteHeader(http.StatusNoContent)
+}
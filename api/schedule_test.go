@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	applicationMock "github.com/ONSdigital/dis-migration-service/application/mock"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testScheduleURL = "http://localhost:30100/v1/migration-jobs/12/schedule"
+
+func TestScheduleJob(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice that schedules jobs", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			ScheduleJobFunc: func(ctx context.Context, jobNumber int, publishAt *time.Time, userID string) error {
+				return nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When a job is scheduled for a future time", func() {
+			publishAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+			body := fmt.Sprintf(`{"publish_at":%q}`, publishAt.Format(time.RFC3339))
+			req := httptest.NewRequest(http.MethodPut, testScheduleURL, strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the job is scheduled by the token's user", func() {
+				So(resp.Code, ShouldEqual, http.StatusNoContent)
+				So(mockService.ScheduleJobCalls(), ShouldHaveLength, 1)
+				call := mockService.ScheduleJobCalls()[0]
+				So(call.JobNumber, ShouldEqual, 12)
+				So(call.PublishAt.Equal(publishAt), ShouldBeTrue)
+				So(call.UserID, ShouldEqual, testAuthUserID)
+			})
+		})
+
+		Convey("When a job is scheduled without a publish time", func() {
+			req := httptest.NewRequest(http.MethodPut, testScheduleURL, strings.NewReader(`{}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 Bad Request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrPublishAtNotProvided.Error())
+				So(mockService.ScheduleJobCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a job is scheduled for a time in the past", func() {
+			req := httptest.NewRequest(http.MethodPut, testScheduleURL, strings.NewReader(`{"publish_at":"2020-01-01T09:30:00Z"}`))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 Bad Request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrPublishAtInPast.Error())
+				So(mockService.ScheduleJobCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a job's schedule is cancelled", func() {
+			req := httptest.NewRequest(http.MethodDelete, testScheduleURL, http.NoBody)
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the job's publish time is cleared", func() {
+				So(resp.Code, ShouldEqual, http.StatusNoContent)
+				So(mockService.ScheduleJobCalls(), ShouldHaveLength, 1)
+				So(mockService.ScheduleJobCalls()[0].PublishAt, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a test API instance and a mocked jobservice with a job that cannot be scheduled", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			ScheduleJobFunc: func(ctx context.Context, jobNumber int, publishAt *time.Time, userID string) error {
+				return appErrors.ErrJobScheduleNotAllowed
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When the job's schedule is cancelled", func() {
+			req := httptest.NewRequest(http.MethodDelete, testScheduleURL, http.NoBody)
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 409 Conflict is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusConflict)
+			})
+		})
+	})
+}
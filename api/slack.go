@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/dis-migration-service/slack"
+	"github.com/ONSdigital/dis-migration-service/statemachine"
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// slackApprovalPermission is the permission a Slack user's identity must
+// hold to approve or reject jobs
+const slackApprovalPermission = "migrations:edit"
+
+// maxSlackInteractionSize is the largest interaction request body accepted
+// from Slack
+const maxSlackInteractionSize = 1 << 20
+
+// slackActionStates maps the buttons on approval requests to the states
+// they move jobs to
+var slackActionStates = map[string]domain.State{
+	slack.ActionApproveJob: domain.StateApproved,
+	slack.ActionRejectJob:  domain.StateRejected,
+}
+
+// PermissionsChecker checks whether an entity holds a permission
+type PermissionsChecker interface {
+	HasPermission(ctx context.Context, entityData permsdk.EntityData, permission string, attributes map[string]string) (bool, error)
+}
+
+// SlackApprovals holds what is needed to approve and reject jobs from the
+// buttons on Slack approval requests
+type SlackApprovals struct {
+	// SigningSecret verifies that interaction requests were sent by Slack
+	SigningSecret string
+	// UserIdentities maps Slack user IDs to the identities they act as
+	UserIdentities map[string]string
+	// Permissions checks that an identity may approve and reject jobs
+	Permissions PermissionsChecker
+	// Client updates approval requests with their outcome
+	Client slack.Clienter
+}
+
+// handleSlackInteraction handles Slack's requests for buttons clicked on
+// approval requests, approving or rejecting the job as the identity the
+// Slack user is mapped to. Requests are authenticated by Slack's signature
+// rather than a token.
+func (api *MigrationAPI) handleSlackInteraction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if api.SlackApprovals == nil {
+		handleError(ctx, w, r, appErrors.ErrSlackApprovalsUnavailable)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSlackInteractionSize))
+	if err != nil {
+		log.Info(ctx, "unable to read slack interaction body", log.Data{"error": err.Error()})
+		handleError(ctx, w, r, appErrors.ErrUnableToParseBody)
+		return
+	}
+
+	if err := slack.VerifyRequest(r.Header, body, api.SlackApprovals.SigningSecret); err != nil {
+		log.Info(ctx, "failed to verify slack interaction", log.Data{"error": err.Error()})
+		handleError(ctx, w, r, appErrors.ErrSlackRequestUnverified)
+		return
+	}
+
+	interaction, err := slack.ParseInteraction(body)
+	if err != nil {
+		log.Info(ctx, "failed to parse slack interaction", log.Data{"error": err.Error()})
+		handleError(ctx, w, r, appErrors.ErrSlackInteractionInvalid)
+		return
+	}
+
+	logData := log.Data{
+		"slack_user_id": interaction.UserID,
+		"action_id":     interaction.ActionID,
+	}
+
+	newState, ok := slackActionStates[interaction.ActionID]
+	if !ok {
+		log.Info(ctx, "unknown slack interaction action", logData)
+		handleError(ctx, w, r, appErrors.ErrSlackInteractionInvalid)
+		return
+	}
+
+	jobNumber, err := strconv.Atoi(interaction.Value)
+	if err != nil {
+		log.Info(ctx, "slack interaction job number must be an int", logData)
+		handleError(ctx, w, r, appErrors.ErrJobNumberMustBeInt)
+		return
+	}
+	logData["job_number"] = jobNumber
+
+	identity, ok := api.SlackApprovals.UserIdentities[interaction.UserID]
+	if !ok || identity == "" {
+		log.Info(ctx, "slack user is not mapped to an identity", logData)
+		logAuditEvent(ctx, "slack user is not mapped to an identity", nil, domain.ActionUpdate, r.URL.Path, domain.OutcomeFailure, "unmapped slack user", &domain.AuditEventParams{"slack_user_id": interaction.UserID})
+		handleError(ctx, w, r, appErrors.ErrSlackUserNotPermitted)
+		return
+	}
+
+	authEntityData := &authorisation.AuthEntityData{
+		EntityData: &permsdk.EntityData{UserID: identity},
+	}
+
+	permitted, err := api.SlackApprovals.Permissions.HasPermission(ctx, *authEntityData.EntityData, slackApprovalPermission, nil)
+	if err != nil {
+		log.Error(ctx, "failed to check slack user permissions", err, logData)
+		handleError(ctx, w, r, err)
+		return
+	}
+	if !permitted {
+		log.Info(ctx, "slack user does not have permission to approve or reject jobs", logData)
+		logAuditEvent(ctx, "slack user is not permitted to update job state", authEntityData, domain.ActionUpdate, r.URL.Path, domain.OutcomeFailure, "missing permission", nil)
+		handleError(ctx, w, r, appErrors.ErrSlackUserNotPermitted)
+		return
+	}
+
+	opts := &domain.JobStateOptions{}
+
+	if newState == domain.StateApproved {
+		opts.FourEyesOverride, err = api.hasFourEyesOverride(ctx, authEntityData.EntityData)
+		if err != nil {
+			log.Error(ctx, "failed to check four eyes override permission", err, logData)
+			handleError(ctx, w, r, err)
+			return
+		}
+	}
+
+	reason := fmt.Sprintf("%s in Slack by %s", newState, interaction.UserID)
+
+	err = api.JobService.UpdateJobState(ctx, jobNumber, newState, identity, reason, opts)
+	if err != nil {
+		var transitionErr *statemachine.TransitionError
+		if errors.As(err, &transitionErr) || errors.Is(err, appErrors.ErrStateAlreadyAtTarget) {
+			log.Info(ctx, "invalid state transition from slack", logData)
+			handleError(ctx, w, r, appErrors.ErrJobStateTransitionNotAllowed)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrApproverIsSubmitter) || errors.Is(err, appErrors.ErrJobAlreadyApprovedByUser) {
+			log.Info(ctx, "job approval from slack refused", logData)
+			logAuditEvent(ctx, "job approval from slack refused", authEntityData, domain.ActionUpdate, r.URL.Path, domain.OutcomeFailure, err.Error(), nil)
+			handleError(ctx, w, r, err)
+			return
+		}
+
+		if !errors.Is(err, appErrors.ErrJobNotFound) {
+			log.Error(ctx, "failed to update job state from slack", err, logData)
+		}
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	outcome := fmt.Sprintf("Migration job %d was %s by <@%s>", jobNumber, newState, interaction.UserID)
+	if err := api.SlackApprovals.Client.ResolveApprovalRequest(ctx, interaction.Message, outcome); err != nil {
+		log.Error(ctx, "failed to resolve slack approval request", err, logData)
+	}
+
+	logAuditEvent(ctx, "successfully updated job state from slack", authEntityData, domain.ActionUpdate, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	log.Info(ctx, "job state updated from slack", logData)
+	handleSuccess(ctx, w, r, http.StatusOK, nil)
+}
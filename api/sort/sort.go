@@ -19,6 +19,9 @@ const (
 	// SortParameterFieldLabel is the name of the field,
 	// label, in the sort parameter.
 	SortParameterFieldLabel SortParameterField = "label"
+	// SortParameterFieldLastUpdated is the name of the field,
+	// last updated, in the sort parameter.
+	SortParameterFieldLastUpdated SortParameterField = "last_updated"
 	// SortParameterDirectionAsc is the name of the direction,
 	// ascending, in the sort parameter.
 	SortParameterDirectionAsc SortParameterDirection = "asc"
@@ -32,6 +35,7 @@ func ParseSortParameters(sortParam []string) (field SortParameterField, directio
 	validSortParameterFields := []SortParameterField{
 		SortParameterFieldJobNumber,
 		SortParameterFieldLabel,
+		SortParameterFieldLastUpdated,
 	}
 
 	validSortParameterDirections := []SortParameterDirection{
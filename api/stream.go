@@ -0,0 +1,140 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/dis-migration-service/stream"
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// defaultHeartbeatInterval is used when no stream heartbeat interval is
+// configured.
+const defaultHeartbeatInterval = 15 * time.Second
+
+// streamJob handles requests to stream the progress of a migration job as
+// Server-Sent Events. A heartbeat comment is sent periodically so that
+// idle connections are kept open, and clients can resume a stream by
+// sending the ID of the last message they received in the Last-Event-ID
+// header.
+func (api *MigrationAPI) streamJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(ctx)
+	if !ok {
+		log.Error(ctx, "streamJob endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	jobNumber, err := api.getExistingJobNumber(r)
+	if err != nil {
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	sub, initial, err := api.JobService.StreamJob(ctx, jobNumber, r.Header.Get(HeaderLastEventID))
+	if err != nil {
+		handleError(ctx, w, r, err)
+		return
+	}
+	defer sub.Close()
+
+	heartbeatInterval := api.HeartbeatInterval
+	if heartbeatInterval <= 0 {
+		heartbeatInterval = defaultHeartbeatInterval
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	logAuditEvent(ctx, "successfully subscribed to job stream", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
+
+	sw := &streamWriter{
+		w:        w,
+		rc:       http.NewResponseController(w),
+		deadline: 2 * heartbeatInterval,
+	}
+
+	for _, msg := range initial {
+		if err := sw.writeMessage(msg); err != nil {
+			log.Info(ctx, "job stream closed", log.Data{"job_number": jobNumber, "reason": err.Error()})
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-sub.Messages():
+			if !ok {
+				// The subscription was dropped for falling behind. The client
+				// will reconnect and resume from the last message it received.
+				log.Info(ctx, "job stream subscription dropped", log.Data{"job_number": jobNumber})
+				return
+			}
+			err = sw.writeMessage(msg)
+		case <-heartbeat.C:
+			err = sw.write([]byte(": heartbeat\n\n"))
+		}
+
+		if err != nil {
+			log.Info(ctx, "job stream closed", log.Data{"job_number": jobNumber, "reason": err.Error()})
+			return
+		}
+	}
+}
+
+// streamWriter writes Server-Sent Events to a response, flushing each one
+// immediately. The write deadline is extended before every write so that
+// the stream outlives the server's write timeout while the client is
+// still reading.
+type streamWriter struct {
+	w        http.ResponseWriter
+	rc       *http.ResponseController
+	deadline time.Duration
+}
+
+// writeMessage writes a message as a Server-Sent Event, using the message
+// type as the event name.
+func (sw *streamWriter) writeMessage(msg *stream.Message) error {
+	data, err := json.Marshal(msg.Data)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	if msg.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", msg.ID)
+	}
+	fmt.Fprintf(&b, "event: %s\n", msg.Type)
+	fmt.Fprintf(&b, "data: %s\n\n", data)
+
+	return sw.write(b.Bytes())
+}
+
+// write writes and flushes raw stream content.
+func (sw *streamWriter) write(p []byte) error {
+	if err := sw.rc.SetWriteDeadline(time.Now().Add(sw.deadline)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	if _, err := sw.w.Write(p); err != nil {
+		return err
+	}
+
+	return sw.rc.Flush()
+}
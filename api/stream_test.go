@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	applicationMock "github.com/ONSdigital/dis-migration-service/application/mock"
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/dis-migration-service/stream"
+	authorisationMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStreamJob(t *testing.T) {
+	Convey("Given a test API instance and a broadcaster with a subscriber to a job", t, func() {
+		mockAuthMiddleware := &authorisationMock.MiddlewareMock{
+			RequireFunc: requireWithAuthEntity,
+			CloseFunc:   func(ctx context.Context) error { return nil },
+		}
+
+		broadcaster := stream.NewBroadcaster(10)
+		sub, _, _ := broadcaster.Subscribe(testJobNumber, "")
+
+		mockService := &applicationMock.JobServiceMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{JobNumber: jobNumber}, nil
+			},
+			StreamJobFunc: func(ctx context.Context, jobNumber int, lastEventID string) (*stream.Subscription, []*stream.Message, error) {
+				snapshot := &stream.Message{
+					JobNumber: jobNumber,
+					Type:      stream.MessageTypeJob,
+					Data:      &domain.Job{JobNumber: jobNumber, State: domain.StateMigrating},
+				}
+				return sub, []*stream.Message{snapshot}, nil
+			},
+		}
+
+		api := Setup(context.Background(), &config.Config{}, mux.NewRouter(), mockService, mockAuthMiddleware)
+
+		Convey("When a task changes state and the subscription ends", func() {
+			broadcaster.Publish(testJobNumber, stream.MessageTypeTaskStateChanged, &stream.StateChange{
+				JobNumber: testJobNumber,
+				TaskID:    "task-1",
+				FromState: domain.StateMigrating,
+				ToState:   domain.StateInReview,
+			})
+			sub.Close()
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs/123/stream", http.NoBody)
+			req.Header.Set(HeaderLastEventID, "last-id")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the snapshot and the change are streamed as Server-Sent Events", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(resp.Header().Get("Content-Type"), ShouldEqual, "text/event-stream")
+				So(resp.Body.String(), ShouldStartWith, "event: job\ndata: {")
+				So(resp.Body.String(), ShouldContainSubstring, "\"state\":\"migrating\"")
+				So(resp.Body.String(), ShouldContainSubstring, "event: task_state_changed\n")
+				So(resp.Body.String(), ShouldContainSubstring, "\"task_id\":\"task-1\"")
+				So(resp.Body.String(), ShouldContainSubstring, "id: ")
+			})
+
+			Convey("And the service is asked to resume from the Last-Event-ID", func() {
+				So(len(mockService.StreamJobCalls()), ShouldEqual, 1)
+				So(mockService.StreamJobCalls()[0].JobNumber, ShouldEqual, testJobNumber)
+				So(mockService.StreamJobCalls()[0].LastEventID, ShouldEqual, "last-id")
+			})
+		})
+
+		Convey("When the stream is idle", func() {
+			api.HeartbeatInterval = time.Millisecond
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs/123/stream", http.NoBody).WithContext(ctx)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then heartbeat comments are sent until the client disconnects", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(resp.Body.String(), ShouldContainSubstring, ": heartbeat\n\n")
+			})
+		})
+	})
+
+	Convey("Given a test API instance and a job that does not exist", t, func() {
+		mockAuthMiddleware := &authorisationMock.MiddlewareMock{
+			RequireFunc: requireWithAuthEntity,
+			CloseFunc:   func(ctx context.Context) error { return nil },
+		}
+
+		mockService := &applicationMock.JobServiceMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return nil, appErrors.ErrJobNotFound
+			},
+		}
+
+		api := Setup(context.Background(), &config.Config{}, mux.NewRouter(), mockService, mockAuthMiddleware)
+
+		Convey("When a stream is requested", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/migration-jobs/123/stream", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 404 Not Found is returned and no stream is started", func() {
+				So(resp.Code, ShouldEqual, http.StatusNotFound)
+				So(len(mockService.StreamJobCalls()), ShouldEqual, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// WebhookRequest represents the payload used to create or update a webhook.
+// The secret may be omitted on update to keep the existing secret, and
+// enabled defaults to true on create.
+type WebhookRequest struct {
+	URL        string                    `json:"url"`
+	Secret     string                    `json:"secret"`
+	EventTypes []domain.WebhookEventType `json:"event_types"`
+	JobTypes   []domain.JobType          `json:"job_types"`
+	Enabled    *bool                     `json:"enabled"`
+}
+
+// createWebhook handles requests to subscribe a webhook to job lifecycle
+// events.
+func (api *MigrationAPI) createWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "createWebhook endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	// Extract user ID from JWT token
+	userID, err := api.GetUserID(r)
+	if err != nil {
+		log.Info(ctx, "failed to extract user ID from token", log.Data{
+			"error": err.Error(),
+		})
+		handleError(ctx, w, r, appErrors.ErrUnauthorized)
+		return
+	}
+
+	req, err := parseWebhookRequest(r)
+	if err != nil {
+		log.Info(ctx, "failed to decode webhook request body")
+		handleError(ctx, w, r, appErrors.ErrUnableToParseBody)
+		return
+	}
+
+	webhook := domain.NewWebhook(req.URL, req.Secret, req.EventTypes, req.JobTypes, userID)
+	if req.Enabled != nil {
+		webhook.Enabled = *req.Enabled
+	}
+
+	if errs := webhook.Validate(); len(errs) > 0 {
+		log.Info(ctx, "failed to validate webhook")
+		handleError(ctx, w, r, errs...)
+		return
+	}
+
+	webhook, err = api.JobService.CreateWebhook(ctx, webhook)
+	if err != nil {
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	bytes, err := json.Marshal(webhook)
+	if err != nil {
+		log.Error(ctx, "failed to marshal response", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully created webhook", authEntityData, domain.ActionCreate, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusCreated, bytes)
+}
+
+// getWebhooks is an implementation of PaginatedHandler for retrieving
+// webhooks.
+func (api *MigrationAPI) getWebhooks(w http.ResponseWriter, r *http.Request, limit, offset int) (items interface{}, totalCount int, err error) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "getWebhooks endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		return nil, 0, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil)
+	}
+
+	webhooks, totalCount, err := api.JobService.GetWebhooks(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(webhooks) == 0 {
+		webhooks = []*domain.Webhook{}
+	}
+
+	logAuditEvent(ctx, "successfully retrieved webhooks", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	return webhooks, totalCount, nil
+}
+
+// getWebhook handles requests to retrieve a webhook.
+func (api *MigrationAPI) getWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "getWebhook endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	webhookID := mux.Vars(r)[PathParameterWebhookID]
+
+	webhook, err := api.JobService.GetWebhook(ctx, webhookID)
+	if err != nil {
+		if !errors.Is(err, appErrors.ErrWebhookNotFound) {
+			log.Error(ctx, "failed to get webhook with id: "+webhookID, err)
+		}
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	bytes, err := json.Marshal(webhook)
+	if err != nil {
+		log.Error(ctx, "failed to marshal response", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully retrieved webhook", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusOK, bytes)
+}
+
+// updateWebhook handles requests to replace a webhook's URL, filters and
+// enabled flag, and optionally its secret.
+func (api *MigrationAPI) updateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "updateWebhook endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	webhookID := mux.Vars(r)[PathParameterWebhookID]
+
+	req, err := parseWebhookRequest(r)
+	if err != nil {
+		log.Info(ctx, "failed to decode webhook request body", log.Data{"webhook_id": webhookID})
+		handleError(ctx, w, r, appErrors.ErrUnableToParseBody)
+		return
+	}
+
+	webhook, err := api.JobService.GetWebhook(ctx, webhookID)
+	if err != nil {
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	webhook.URL = req.URL
+	webhook.EventTypes = req.EventTypes
+	webhook.JobTypes = req.JobTypes
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+	if req.Enabled != nil {
+		webhook.Enabled = *req.Enabled
+	}
+
+	if errs := webhook.Validate(); len(errs) > 0 {
+		log.Info(ctx, "failed to validate webhook", log.Data{"webhook_id": webhookID})
+		handleError(ctx, w, r, errs...)
+		return
+	}
+
+	if err := api.JobService.UpdateWebhook(ctx, webhook); err != nil {
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	bytes, err := json.Marshal(webhook)
+	if err != nil {
+		log.Error(ctx, "failed to marshal response", err)
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully updated webhook", authEntityData, domain.ActionUpdate, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusOK, bytes)
+}
+
+// deleteWebhook handles requests to remove a webhook. Its pending
+// deliveries are moved to the dead-letter list when they are next due.
+func (api *MigrationAPI) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "deleteWebhook endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		handleError(ctx, w, r, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil))
+		return
+	}
+
+	webhookID := mux.Vars(r)[PathParameterWebhookID]
+
+	if err := api.JobService.DeleteWebhook(ctx, webhookID); err != nil {
+		handleError(ctx, w, r, err)
+		return
+	}
+
+	logAuditEvent(ctx, "successfully deleted webhook", authEntityData, domain.ActionDelete, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	handleSuccess(ctx, w, r, http.StatusNoContent, nil)
+}
+
+// getWebhookDeliveries is an implementation of PaginatedHandler for
+// retrieving the deliveries to a webhook, optionally filtered by status.
+func (api *MigrationAPI) getWebhookDeliveries(w http.ResponseWriter, r *http.Request, limit, offset int) (items interface{}, totalCount int, err error) {
+	ctx := r.Context()
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(r.Context())
+	if !ok {
+		log.Error(ctx, "getWebhookDeliveries endpoint: failed to parse auth entity data", errors.New(appErrors.EntityDataErrorDescription))
+		return nil, 0, handleAuthEntityDataError(ctx, errors.New(appErrors.EntityDataErrorDescription), nil)
+	}
+
+	webhookID := mux.Vars(r)[PathParameterWebhookID]
+
+	status := domain.WebhookDeliveryStatus(r.URL.Query().Get(QueryParameterStatus))
+	if status != "" && !domain.IsValidWebhookDeliveryStatus(status) {
+		return nil, 0, appErrors.ErrWebhookDeliveryStatusInvalid
+	}
+
+	deliveries, totalCount, err := api.JobService.GetWebhookDeliveries(ctx, webhookID, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(deliveries) == 0 {
+		deliveries = []*domain.WebhookDelivery{}
+	}
+
+	logAuditEvent(ctx, "successfully retrieved webhook deliveries", authEntityData, domain.ActionRead, r.URL.Path, domain.OutcomeSuccess, "", nil)
+	return deliveries, totalCount, nil
+}
+
+// parseWebhookRequest reads a WebhookRequest from the request body.
+func parseWebhookRequest(r *http.Request) (*WebhookRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var req WebhookRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
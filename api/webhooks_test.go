@@ -0,0 +1,302 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	applicationMock "github.com/ONSdigital/dis-migration-service/application/mock"
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	authorisationMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
+
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testWebhookID  = "test-webhook-id"
+	testWebhookURL = "https://example.com/hooks/migrations"
+)
+
+func newWebhookTestAPI(mockService *applicationMock.JobServiceMock) *MigrationAPI {
+	mockAuthMiddleware := &authorisationMock.MiddlewareMock{
+		RequireFunc: requireWithAuthEntity,
+		CloseFunc: func(ctx context.Context) error {
+			return nil
+		},
+		ParseFunc: func(token string) (*permsdk.EntityData, error) {
+			return &permsdk.EntityData{
+				UserID: testAuthUserID,
+			}, nil
+		},
+	}
+
+	cfg := &config.Config{DefaultLimit: 10, DefaultMaxLimit: 100}
+	return Setup(context.Background(), cfg, mux.NewRouter(), mockService, mockAuthMiddleware)
+}
+
+func newTestWebhook() *domain.Webhook {
+	webhook := domain.NewWebhook(testWebhookURL, "old-secret", []domain.WebhookEventType{"job.published"}, nil, testAuthUserID)
+	webhook.ID = testWebhookID
+	webhook.Links = domain.NewWebhookLinks(testWebhookID)
+	return webhook
+}
+
+func TestCreateWebhook(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice that creates webhooks", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			CreateWebhookFunc: func(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+				return webhook, nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When a valid webhook is submitted", func() {
+			body := `{"url":"` + testWebhookURL + `","secret":"s3cret","event_types":["job.published","job.failed_migration"],"job_types":["static_dataset"]}`
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:30100/v1/webhooks", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the enabled webhook is created and returned without its secret", func() {
+				So(resp.Code, ShouldEqual, http.StatusCreated)
+				So(resp.Body.String(), ShouldNotContainSubstring, "s3cret")
+
+				So(mockService.CreateWebhookCalls(), ShouldHaveLength, 1)
+				webhook := mockService.CreateWebhookCalls()[0].Webhook
+				So(webhook.URL, ShouldEqual, testWebhookURL)
+				So(webhook.Secret, ShouldEqual, "s3cret")
+				So(webhook.EventTypes, ShouldHaveLength, 2)
+				So(webhook.JobTypes, ShouldResemble, []domain.JobType{domain.JobTypeStaticDataset})
+				So(webhook.Enabled, ShouldBeTrue)
+				So(webhook.CreatedBy.ID, ShouldEqual, testAuthUserID)
+			})
+		})
+
+		Convey("When an invalid webhook is submitted", func() {
+			body := `{"url":"not-a-url","event_types":["job.unknown"]}`
+
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:30100/v1/webhooks", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 Bad Request is returned listing every problem", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrWebhookURLInvalid.Error())
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrWebhookSecretNotProvided.Error())
+				So(resp.Body.String(), ShouldContainSubstring, appErrors.ErrWebhookEventTypeInvalid.Error())
+				So(mockService.CreateWebhookCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the body cannot be parsed", func() {
+			req := httptest.NewRequest(http.MethodPost, "http://localhost:30100/v1/webhooks", strings.NewReader("{"))
+			req.Header.Set("Authorization", "Bearer test-jwt-token")
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 Bad Request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
+
+func TestGetWebhooks(t *testing.T) {
+	Convey("Given a test API instance and a mocked jobservice with webhooks", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			GetWebhooksFunc: func(ctx context.Context, limit, offset int) ([]*domain.Webhook, int, error) {
+				return []*domain.Webhook{newTestWebhook()}, 1, nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When the webhooks are requested", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/webhooks?limit=5&offset=0", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a paginated list of webhooks is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+
+				var response struct {
+					Items      []*domain.Webhook `json:"items"`
+					TotalCount int               `json:"total_count"`
+				}
+				So(json.Unmarshal(resp.Body.Bytes(), &response), ShouldBeNil)
+				So(response.Items, ShouldHaveLength, 1)
+				So(response.Items[0].ID, ShouldEqual, testWebhookID)
+				So(response.TotalCount, ShouldEqual, 1)
+
+				So(mockService.GetWebhooksCalls()[0].Limit, ShouldEqual, 5)
+			})
+		})
+	})
+}
+
+func TestGetWebhook(t *testing.T) {
+	Convey("Given a test API instance", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			GetWebhookFunc: func(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+				if webhookID == testWebhookID {
+					return newTestWebhook(), nil
+				}
+				return nil, appErrors.ErrWebhookNotFound
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When an existing webhook is requested", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/webhooks/"+testWebhookID, http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then it is returned with its links", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(resp.Body.String(), ShouldContainSubstring, "/v1/webhooks/"+testWebhookID+"/deliveries")
+				So(resp.Body.String(), ShouldNotContainSubstring, "old-secret")
+			})
+		})
+
+		Convey("When a webhook that does not exist is requested", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/webhooks/unknown", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 404 Not Found is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	Convey("Given a test API instance and an existing webhook", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			GetWebhookFunc: func(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+				if webhookID == testWebhookID {
+					return newTestWebhook(), nil
+				}
+				return nil, appErrors.ErrWebhookNotFound
+			},
+			UpdateWebhookFunc: func(ctx context.Context, webhook *domain.Webhook) error {
+				return nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When it is updated without a secret", func() {
+			body := `{"url":"https://example.com/other","event_types":["job.failed_publish"],"enabled":false}`
+
+			req := httptest.NewRequest(http.MethodPut, "http://localhost:30100/v1/webhooks/"+testWebhookID, strings.NewReader(body))
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then the webhook is replaced and keeps its secret", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(mockService.UpdateWebhookCalls(), ShouldHaveLength, 1)
+
+				webhook := mockService.UpdateWebhookCalls()[0].Webhook
+				So(webhook.URL, ShouldEqual, "https://example.com/other")
+				So(webhook.EventTypes, ShouldResemble, []domain.WebhookEventType{"job.failed_publish"})
+				So(webhook.Secret, ShouldEqual, "old-secret")
+				So(webhook.Enabled, ShouldBeFalse)
+			})
+		})
+
+		Convey("When a webhook that does not exist is updated", func() {
+			body := `{"url":"https://example.com/other","event_types":["job.published"]}`
+
+			req := httptest.NewRequest(http.MethodPut, "http://localhost:30100/v1/webhooks/unknown", strings.NewReader(body))
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 404 Not Found is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusNotFound)
+				So(mockService.UpdateWebhookCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	Convey("Given a test API instance", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			DeleteWebhookFunc: func(ctx context.Context, webhookID string) error {
+				if webhookID == testWebhookID {
+					return nil
+				}
+				return appErrors.ErrWebhookNotFound
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When an existing webhook is deleted", func() {
+			req := httptest.NewRequest(http.MethodDelete, "http://localhost:30100/v1/webhooks/"+testWebhookID, http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 204 No Content is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusNoContent)
+				So(mockService.DeleteWebhookCalls()[0].WebhookID, ShouldEqual, testWebhookID)
+			})
+		})
+
+		Convey("When a webhook that does not exist is deleted", func() {
+			req := httptest.NewRequest(http.MethodDelete, "http://localhost:30100/v1/webhooks/unknown", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 404 Not Found is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	Convey("Given a test API instance and a webhook with a dead-lettered delivery", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			GetWebhookDeliveriesFunc: func(ctx context.Context, webhookID string, status domain.WebhookDeliveryStatus, limit, offset int) ([]*domain.WebhookDelivery, int, error) {
+				return []*domain.WebhookDelivery{
+					{ID: "delivery-1", WebhookID: webhookID, Status: domain.WebhookDeliveryStatusDeadLetter, Attempts: 5},
+				}, 1, nil
+			},
+		}
+		api := newWebhookTestAPI(mockService)
+
+		Convey("When the dead-lettered deliveries are requested", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/webhooks/"+testWebhookID+"/deliveries?status=dead_letter", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then they are returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusOK)
+				So(resp.Body.String(), ShouldContainSubstring, `"status":"dead_letter"`)
+
+				So(mockService.GetWebhookDeliveriesCalls(), ShouldHaveLength, 1)
+				So(mockService.GetWebhookDeliveriesCalls()[0].WebhookID, ShouldEqual, testWebhookID)
+				So(mockService.GetWebhookDeliveriesCalls()[0].Status, ShouldEqual, domain.WebhookDeliveryStatusDeadLetter)
+			})
+		})
+
+		Convey("When an invalid status is requested", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:30100/v1/webhooks/"+testWebhookID+"/deliveries?status=unknown", http.NoBody)
+			resp := httptest.NewRecorder()
+			api.Router.ServeHTTP(resp, req)
+
+			Convey("Then a 400 Bad Request is returned", func() {
+				So(resp.Code, ShouldEqual, http.StatusBadRequest)
+				So(mockService.GetWebhookDeliveriesCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
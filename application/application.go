@@ -2,7 +2,10 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	sort "github.com/ONSdigital/dis-migration-service/api/sort"
@@ -10,8 +13,10 @@ import (
 	"github.com/ONSdigital/dis-migration-service/config"
 	"github.com/ONSdigital/dis-migration-service/domain"
 	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/dis-migration-service/mongo"
 	"github.com/ONSdigital/dis-migration-service/statemachine"
 	"github.com/ONSdigital/dis-migration-service/store"
+	"github.com/ONSdigital/dis-migration-service/stream"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
@@ -19,52 +24,222 @@ import (
 //
 //go:generate moq -out mock/jobservice.go -pkg mock . JobService
 type JobService interface {
-	CreateJob(ctx context.Context, jobConfig *domain.JobConfig, userID string, userAuthToken string) (*domain.Job, error)
+	CreateJob(ctx context.Context, jobConfig *domain.JobConfig, userID string, userAuthToken string, idempotencyKey string) (*domain.Job, error)
 	GetJob(ctx context.Context, jobNumber int) (*domain.Job, error)
 	ClaimJob(ctx context.Context) (*domain.Job, error)
-	UpdateJobState(ctx context.Context, jobNumber int, newState domain.State, userID string) error
+	UpdateJobState(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error
+	UpdateJobPriority(ctx context.Context, jobNumber, priority int, userID string) error
+	ScheduleJob(ctx context.Context, jobNumber int, publishAt *time.Time, userID string) error
 	UpdateJobCollectionID(ctx context.Context, jobNumber int, collectionID string) error
-	GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error)
+	UpdateJobNotificationThread(ctx context.Context, jobNumber int, thread *domain.NotificationThread) error
+	GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error)
+	GetJobsAfter(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error)
 	GetJobStatesSummary(ctx context.Context) ([]domain.StateSummary, error)
+	GetDigest(ctx context.Context) (*domain.Digest, error)
+	ClaimDigestRun(ctx context.Context, scheduledAt time.Time) (bool, error)
 	GetJobTasks(ctx context.Context, states []domain.State, jobNumber int, limit, offset int) ([]*domain.Task, int, error)
+	GetJobTasksAfter(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error)
+	GetJobTaskTree(ctx context.Context, jobNumber int) ([]*domain.TaskNode, int, error)
 	CreateTask(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error)
 	UpdateTask(ctx context.Context, task *domain.Task) error
-	UpdateTaskState(ctx context.Context, taskID string, newState domain.State) error
-	ClaimTask(ctx context.Context) (*domain.Task, error)
+	UpdateTaskState(ctx context.Context, taskID string, newState domain.State, reason string) error
+	ClaimTask(ctx context.Context, excludedTypes map[domain.State][]domain.TaskType, excludedJobNumbers []int) (*domain.Task, error)
+	WatchWork(ctx context.Context, notify func()) error
 	CountTasksByJobNumber(ctx context.Context, jobNumber int) (int, error)
 	GetNextJobNumber(ctx context.Context) (*domain.Counter, error)
 	CreateEvent(ctx context.Context, jobNumber int, event *domain.Event) (*domain.Event, error)
-	GetJobEvents(ctx context.Context, jobNumber int, limit, offset int) ([]*domain.Event, int, error)
+	GetJobEvents(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error)
+	GetJobEventsAfter(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error)
 	CountEventsByJobNumber(ctx context.Context, jobNumber int) (int, error)
+	CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
+	GetJobComments(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Comment, int, error)
+	CreateBatch(ctx context.Context, jobConfigs []*domain.JobConfig, userID string, userAuthToken string, rowDone func()) (*domain.Batch, error)
+	GetBatch(ctx context.Context, batchID string) (*domain.Batch, error)
+	CreateReleaseGroup(ctx context.Context, group *domain.ReleaseGroup) (*domain.ReleaseGroup, error)
+	GetReleaseGroup(ctx context.Context, groupID string) (*domain.ReleaseGroup, error)
+	DeleteReleaseGroup(ctx context.Context, groupID string) error
+	StreamJob(ctx context.Context, jobNumber int, lastEventID string) (*stream.Subscription, []*stream.Message, error)
+	CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	GetWebhook(ctx context.Context, webhookID string) (*domain.Webhook, error)
+	GetWebhooks(ctx context.Context, limit, offset int) ([]*domain.Webhook, int, error)
+	UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	GetWebhookDeliveries(ctx context.Context, webhookID string, status domain.WebhookDeliveryStatus, limit, offset int) ([]*domain.WebhookDelivery, int, error)
+	NotifyWebhooks(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error
+	ClaimWebhookDelivery(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
 }
 
 type jobService struct {
-	store   *store.Datastore
-	clients *clients.ClientList
-	config  *config.Config
+	store       *store.Datastore
+	clients     *clients.ClientList
+	config      *config.Config
+	broadcaster *stream.Broadcaster
+
+	// lastTaskJobNumbers records, for each pending task state, the job
+	// number of the last task claimed so that tasks of equal priority are
+	// claimed from each job in turn.
+	lastTaskJobNumbers   map[domain.State]int
+	lastTaskJobNumbersMu sync.Mutex
 }
 
 // Setup initializes a new JobService with the provided
 // dependencies.
 func Setup(datastore *store.Datastore, appClients *clients.ClientList, cfg *config.Config) JobService {
 	return &jobService{
-		store:   datastore,
-		clients: appClients,
-		config:  cfg,
+		store:       datastore,
+		clients:     appClients,
+		config:      cfg,
+		broadcaster: stream.NewBroadcaster(cfg.StreamHistorySize),
+
+		lastTaskJobNumbers: map[domain.State]int{},
 	}
 }
 
 // CreateJob creates a new migration job based on the
 // provided job configuration and logs an event with the requesting user's ID.
-func (js *jobService) CreateJob(ctx context.Context, jobConfig *domain.JobConfig, userID, userAuthToken string) (*domain.Job, error) {
+// If an idempotency key is provided, repeated requests from the same user
+// with the same key and job configuration return the job as it was returned
+// to the original request.
+func (js *jobService) CreateJob(ctx context.Context, jobConfig *domain.JobConfig, userID, userAuthToken, idempotencyKey string) (*domain.Job, error) {
+	if idempotencyKey == "" {
+		return js.createJob(ctx, jobConfig, userID, userAuthToken, "")
+	}
+
+	return js.createIdempotentJob(ctx, jobConfig, userID, userAuthToken, idempotencyKey)
+}
+
+// createIdempotentJob reserves the user's idempotency key before creating the
+// job, so that concurrent requests with the same key cannot both create a
+// job. If the key is already reserved, the original request's job is
+// replayed. An expired key is replaced, unless a concurrent request has
+// already replaced it.
+func (js *jobService) createIdempotentJob(ctx context.Context, jobConfig *domain.JobConfig, userID, userAuthToken, idempotencyKey string) (*domain.Job, error) {
+	logData := log.Data{
+		"idempotency_key": idempotencyKey,
+		"user_id":         userID,
+	}
+
+	requestHash, err := domain.HashJobConfig(jobConfig)
+	if err != nil {
+		log.Error(ctx, "failed to hash job config", err, logData)
+		return &domain.Job{}, appErrors.ErrInternalServerError
+	}
+
+	record := domain.NewIdempotencyRecord(userID, idempotencyKey, requestHash, js.config.IdempotencyKeyExpiry, js.config.IdempotencyKeyPendingExpiry)
+
+	for attempt := 1; ; attempt++ {
+		err = js.store.CreateIdempotencyRecord(ctx, &record)
+		if !errors.Is(err, appErrors.ErrIdempotencyKeyExists) {
+			break
+		}
+
+		err = js.replaceExpiredIdempotencyRecord(ctx, &record)
+		if !errors.Is(err, appErrors.ErrIdempotencyKeyExists) {
+			break
+		}
+
+		existing, err := js.store.GetIdempotencyRecord(ctx, record.ID)
+		retry := attempt < idempotencyReserveAttempts
+		if errors.Is(err, appErrors.ErrIdempotencyKeyNotFound) && retry {
+			// The record was removed when it expired after the key was
+			// found to exist, so the key can be reserved again.
+			continue
+		}
+		if err != nil {
+			log.Error(ctx, "failed to get idempotency record", err, logData)
+			return &domain.Job{}, appErrors.ErrInternalServerError
+		}
+		if existing.IsExpired() && retry {
+			// The record expired after it could not be replaced, so it
+			// is not replayed.
+			continue
+		}
+
+		return js.replayIdempotentJob(ctx, existing, requestHash)
+	}
+	if err != nil {
+		log.Error(ctx, "failed to create idempotency record", err, logData)
+		return &domain.Job{}, appErrors.ErrInternalServerError
+	}
+
+	job, err := js.createJob(ctx, jobConfig, userID, userAuthToken, "")
+	if err != nil {
+		// Release the key so that the client can retry the request.
+		if deleteErr := js.store.DeleteIdempotencyRecord(ctx, &record); deleteErr != nil {
+			log.Error(ctx, "failed to delete idempotency record for failed job creation", deleteErr, logData)
+		}
+		return job, err
+	}
+
+	if err := js.store.CompleteIdempotencyRecord(ctx, &record, job); err != nil {
+		// The job has been created, so return it rather than failing the
+		// request. The record is left pending, so that a retry is told the
+		// request is in progress rather than creating another job, until
+		// its pending expiry passes.
+		logData["job_number"] = job.JobNumber
+		log.Error(ctx, "failed to record job for idempotency key", err, logData)
+	}
+
+	return job, nil
+}
+
+// idempotencyReserveAttempts is the number of times an idempotency key is
+// reserved when the record it clashes with expires before it can be read
+const idempotencyReserveAttempts = 3
+
+// replaceExpiredIdempotencyRecord replaces an existing record for the key
+// with the given record if the existing record has expired, or is still
+// pending after its pending expiry. If it has not, or it has already been
+// replaced by a concurrent request, ErrIdempotencyKeyExists is returned.
+func (js *jobService) replaceExpiredIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error {
+	if js.config.IdempotencyKeyExpiry <= 0 && js.config.IdempotencyKeyPendingExpiry <= 0 {
+		return appErrors.ErrIdempotencyKeyExists
+	}
+
+	return js.store.ReplaceExpiredIdempotencyRecord(ctx, record)
+}
+
+// replayIdempotentJob returns the job created by the original request made
+// with an idempotency key, as it was returned to that request, provided the
+// repeated request is the same.
+func (js *jobService) replayIdempotentJob(ctx context.Context, record *domain.IdempotencyRecord, requestHash string) (*domain.Job, error) {
+	if record.RequestHash != requestHash {
+		return &domain.Job{}, appErrors.ErrIdempotencyKeyReused
+	}
+
+	if record.IsPending() {
+		return &domain.Job{}, appErrors.ErrIdempotencyKeyInProgress
+	}
+
+	log.Info(ctx, "replaying job for idempotency key", log.Data{
+		"idempotency_key": record.Key,
+		"user_id":         record.UserID,
+		"job_number":      record.Job.JobNumber,
+	})
+
+	return record.Job, nil
+}
+
+// createJob validates the job configuration against external systems and
+// creates the job, optionally as part of the batch with the given ID.
+func (js *jobService) createJob(ctx context.Context, jobConfig *domain.JobConfig, userID, userAuthToken, batchID string) (*domain.Job, error) {
 	label, err := jobConfig.ValidateExternal(ctx, *js.clients, userAuthToken)
 	if err != nil {
 		return &domain.Job{}, err
 	}
 
+	return js.createValidatedJob(ctx, jobConfig, label, userID, batchID)
+}
+
+// createValidatedJob creates a migration job with the provided label for
+// a job configuration that has already been validated externally.
+func (js *jobService) createValidatedJob(ctx context.Context, jobConfig *domain.JobConfig, label, userID, batchID string) (*domain.Job, error) {
 	// Create job with label.
 	// Set job number to 0, initially, to prevent the next consecutive number from being skipped if validation fails.
 	job := domain.NewJob(jobConfig, 0, label)
+	job.BatchID = batchID
+	job.SetCreatedBy(userID)
 
 	foundJobs, err := js.store.GetJobsBySourceOrTargetAndState(ctx, job.Config, domain.GetNonCancelledStates(), 1, 0)
 	if err != nil {
@@ -92,15 +267,7 @@ func (js *jobService) CreateJob(ctx context.Context, jobConfig *domain.JobConfig
 		return &domain.Job{}, appErrors.ErrInternalServerError
 	}
 
-	// Log job submission event
-	if js.config.EnableEventLogging {
-		if err := js.logJobEvent(ctx, job.JobNumber, string(domain.StateSubmitted), userID); err != nil {
-			log.Error(ctx, "failed to log job submission event", err, log.Data{
-				"job_number": job.JobNumber,
-			})
-			// TODO: Consider implementing a notification mechanism (e.g., alerts) for event logging failure
-		}
-	}
+	js.logEvent(ctx, domain.NewStateChangeEvent(job.JobNumber, "", job.State, userID, ""))
 
 	return &job, nil
 }
@@ -123,23 +290,39 @@ func (js *jobService) UpdateJobCollectionID(ctx context.Context, jobNumber int,
 		return err
 	}
 
-	job.Config.CollectionID = collectionID
+	// Only the collection ID is set, so that changes made to the job since
+	// it was read, such as to its priority, are kept
+	err = js.store.UpdateJobCollectionID(ctx, job.ID, collectionID)
+	if err != nil {
+		return fmt.Errorf("failed to update job collection ID: %w", err)
+	}
 
-	now := time.Now().UTC()
+	return nil
+}
 
-	job.LastUpdated = now
+// UpdateJobNotificationThread records the parent message that
+// notifications about a migration job are threaded under.
+func (js *jobService) UpdateJobNotificationThread(ctx context.Context, jobNumber int, thread *domain.NotificationThread) error {
+	job, err := js.store.GetJob(ctx, jobNumber)
+	if err != nil {
+		return err
+	}
 
-	err = js.store.UpdateJob(ctx, job)
+	err = js.store.UpdateJobNotificationThread(ctx, job.ID, thread)
 	if err != nil {
-		return fmt.Errorf("failed to update job collection ID: %w", err)
+		return fmt.Errorf("failed to update job notification thread: %w", err)
 	}
 
 	return nil
 }
 
 // UpdateJobState updates the state of a migration job and logs
-// an event with the requesting user's ID.
-func (js *jobService) UpdateJobState(ctx context.Context, jobNumber int, newState domain.State, userID string) error {
+// an event with the requesting user's ID and the reason for the change.
+// An empty user ID indicates the change was made by the system. A job in
+// review cannot be approved by the user who submitted it unless opts, which
+// may be nil, allows the four eyes override. A job being approved is
+// scheduled to publish if opts includes a publish time.
+func (js *jobService) UpdateJobState(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 	job, err := js.store.GetJob(ctx, jobNumber)
 	if err != nil {
 		return err
@@ -154,27 +337,63 @@ func (js *jobService) UpdateJobState(ctx context.Context, jobNumber int, newStat
 	}
 
 	now := time.Now().UTC()
-	err = js.store.UpdateJobState(ctx, job.ID, job.State, newState, now)
-	if err != nil {
-		return fmt.Errorf("failed to update job state: %w", err)
-	}
 
-	// Log event for approval or rejected state transitions if feature is enabled
-	if js.config.EnableEventLogging && (newState == domain.StateApproved || newState == domain.StateRejected) {
-		if err := js.logJobEvent(ctx, jobNumber, string(newState), userID); err != nil {
-			log.Error(ctx, "failed to log job state transition event", err, log.Data{
-				"job_number": jobNumber,
-				"new_state":  newState,
-			})
-			// TODO: Consider implementing a notification mechanism (e.g., alerts) for event logging failure
+	// Jobs in review must be approved by someone other than their
+	// submitter, and remain in review until they have enough approvals
+	if job.State == domain.StateInReview && newState == domain.StateApproved {
+		if opts == nil {
+			opts = &domain.JobStateOptions{}
+		}
+
+		var publishAt *time.Time
+		if opts.PublishAt != nil {
+			if publishAt, err = validatePublishAt(job, opts.PublishAt); err != nil {
+				return err
+			}
+		}
+
+		approved, err := js.approveJob(ctx, job, userID, reason, opts.FourEyesOverride, publishAt)
+		if err != nil {
+			return err
+		}
+
+		if !approved {
+			return nil
+		}
+	} else {
+		err = js.store.UpdateJobState(ctx, job.ID, job.State, newState, now)
+		if err != nil {
+			return fmt.Errorf("failed to update job state: %w", err)
 		}
 	}
+
+	js.publishJobStateChange(jobNumber, job.State, newState, now)
+	js.logEvent(ctx, domain.NewStateChangeEvent(jobNumber, job.State, newState, userID, reason))
+
+	// Zebedee pages are only cached while the job is migrating
+	if job.State == domain.StateMigrating && js.clients != nil {
+		js.clients.PageCache.Invalidate(ctx, jobNumber)
+	}
+
+	// Jobs in a release group are released together once every job in the
+	// group reaches the same release gate
+	if job.ReleaseGroupID != "" && slices.Contains(domain.ReleaseGates, newState) {
+		js.releaseGroupIfReady(ctx, job.ReleaseGroupID)
+	}
+
 	return nil
 }
 
-// GetJobs retrieves a list of migration jobs with pagination.
-func (js *jobService) GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
-	return js.store.GetJobs(ctx, field, direction, states, limit, offset)
+// GetJobs retrieves a list of migration jobs, matching the provided filter,
+// with pagination.
+func (js *jobService) GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
+	return js.store.GetJobs(ctx, field, direction, filter, limit, offset)
+}
+
+// GetJobsAfter retrieves a page of migration jobs, starting after the
+// provided cursor. The returned cursor is nil if there are no more jobs.
+func (js *jobService) GetJobsAfter(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error) {
+	return js.store.GetJobsAfter(ctx, field, direction, filter, after, limit)
 }
 
 // GetJobStatesSummary retrieves a summary of job counts by state.
@@ -184,8 +403,104 @@ func (js *jobService) GetJobStatesSummary(ctx context.Context) ([]domain.StateSu
 		return nil, fmt.Errorf("failed to get job state counts: %w", err)
 	}
 
-	stateSummaries := make([]domain.StateSummary, len(jobStateCounts))
-	for i, result := range jobStateCounts {
+	return toStateSummaries(jobStateCounts)
+}
+
+// GetDigest builds a summary of the jobs created, completed and failed over
+// the configured digest period, along with the jobs waiting for approval
+// and the tasks that have been in an active state for longer than the stuck
+// task threshold. Created, completed and failed jobs are counted from
+// events.
+func (js *jobService) GetDigest(ctx context.Context) (*domain.Digest, error) {
+	now := time.Now().UTC()
+
+	digest := &domain.Digest{
+		GeneratedAt: now,
+		PeriodStart: now.Add(-js.config.DigestPeriod),
+	}
+
+	jobStates, err := js.GetJobStatesSummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	digest.JobStates = jobStates
+
+	createdCounts, err := js.store.GetJobEventStateCounts(ctx, domain.EventActionJobCreated, digest.PeriodStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count created jobs: %w", err)
+	}
+
+	for _, result := range createdCounts {
+		digest.JobsCreated += result.Count
+	}
+
+	transitionCounts, err := js.store.GetJobEventStateCounts(ctx, domain.EventActionStateChanged, digest.PeriodStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count job state changes: %w", err)
+	}
+
+	for _, result := range transitionCounts {
+		switch {
+		case result.State == domain.StateCompleted:
+			digest.JobsCompleted += result.Count
+		case domain.IsFailedState(result.State):
+			digest.JobsFailed += result.Count
+		}
+	}
+
+	inReviewFilter := &domain.JobFilter{States: []domain.State{domain.StateInReview}}
+
+	jobs, jobCount, err := js.store.GetJobs(ctx, sort.SortParameterFieldLastUpdated, sort.SortParameterDirectionAsc, inReviewFilter, js.config.DigestMaxItems, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs awaiting approval: %w", err)
+	}
+
+	digest.AwaitingApproval = domain.DigestJobs{
+		Count: jobCount,
+		Jobs:  make([]*domain.DigestJob, len(jobs)),
+	}
+	for i, job := range jobs {
+		digest.AwaitingApproval.Jobs[i] = domain.NewDigestJob(job, now)
+	}
+
+	tasks, taskCount, err := js.store.GetStaleTasks(ctx, domain.GetActiveTaskStates(), now.Add(-js.config.DigestStuckTaskThreshold), js.config.DigestMaxItems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stuck tasks: %w", err)
+	}
+
+	digest.StuckTasks = domain.DigestTasks{
+		Count: taskCount,
+		Tasks: make([]*domain.DigestTask, len(tasks)),
+	}
+	for i, task := range tasks {
+		digest.StuckTasks.Tasks[i] = domain.NewDigestTask(task, now)
+	}
+
+	return digest, nil
+}
+
+// ClaimDigestRun claims the digest scheduled at the given time, returning
+// false if it has already been claimed, such as by another instance of the
+// service, so that each day's digest is only sent once.
+func (js *jobService) ClaimDigestRun(ctx context.Context, scheduledAt time.Time) (bool, error) {
+	run := domain.NewDigestRun(scheduledAt)
+
+	err := js.store.ClaimDigestRun(ctx, &run)
+	if errors.Is(err, appErrors.ErrDigestRunClaimed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim digest run: %w", err)
+	}
+
+	return true, nil
+}
+
+// toStateSummaries converts state counts from the store into labelled
+// state summaries.
+func toStateSummaries(stateCounts []mongo.StateCountResult) ([]domain.StateSummary, error) {
+	stateSummaries := make([]domain.StateSummary, len(stateCounts))
+	for i, result := range stateCounts {
 		label, err := domain.GetStateLabel(result.State)
 		if err != nil {
 			return nil, err
@@ -218,6 +533,8 @@ func (js *jobService) ClaimJob(ctx context.Context) (*domain.Job, error) {
 			return nil, err
 		}
 		if job != nil {
+			js.publishJobStateChange(job.JobNumber, tr.from, tr.to, job.LastUpdated)
+			js.logEvent(ctx, domain.NewStateChangeEvent(job.JobNumber, tr.from, tr.to, domain.SystemUserID, ""))
 			return job, nil
 		}
 	}
@@ -228,13 +545,16 @@ func (js *jobService) ClaimJob(ctx context.Context) (*domain.Job, error) {
 // CreateTask creates a new migration task for a job.
 func (js *jobService) CreateTask(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error) {
 	// Verify job exists
-	_, err := js.store.GetJob(ctx, jobNumber)
+	job, err := js.store.GetJob(ctx, jobNumber)
 	if err != nil {
 		return nil, err
 	}
 
 	// TODO: Validate job is in a state where tasks can be created
 
+	// Tasks are claimed in order of the priority of the job they belong to
+	task.Priority = job.Priority
+
 	// Create the task in the store
 	err = js.store.CreateTask(ctx, task)
 	if err != nil {
@@ -257,8 +577,9 @@ func (js *jobService) UpdateTask(ctx context.Context, task *domain.Task) error {
 	return nil
 }
 
-// UpdateTaskState updates the state of a migration task.
-func (js *jobService) UpdateTaskState(ctx context.Context, taskID string, newState domain.State) error {
+// UpdateTaskState updates the state of a migration task and logs an event
+// with the reason for the change.
+func (js *jobService) UpdateTaskState(ctx context.Context, taskID string, newState domain.State, reason string) error {
 	task, err := js.store.GetTask(ctx, taskID)
 	if err != nil {
 		return err
@@ -269,7 +590,7 @@ func (js *jobService) UpdateTaskState(ctx context.Context, taskID string, newSta
 	}
 
 	// Validate state transition
-	if err := statemachine.ValidateTransition(task.State, newState); err != nil {
+	if err := statemachine.ValidateTaskTransition(task.State, newState); err != nil {
 		return err
 	}
 
@@ -280,38 +601,96 @@ func (js *jobService) UpdateTaskState(ctx context.Context, taskID string, newSta
 		return fmt.Errorf("failed to update task state: %w", err)
 	}
 
+	js.publishTaskStateChange(task, task.State, newState, now)
+	js.logEvent(ctx, domain.NewTaskStateChangeEvent(task, task.State, newState, reason))
+
+	// Tasks waiting for this one may never be able to run
+	js.cascadeTaskState(ctx, task, task.State, newState)
+
 	return nil
 }
 
-// ClaimTask claims a pending task for processing.
-func (js *jobService) ClaimTask(ctx context.Context) (*domain.Task, error) {
-	transitions := []struct {
-		from domain.State
-		to   domain.State
-	}{
-		{from: domain.StateSubmitted, to: domain.StateMigrating},
-		{from: domain.StateApproved, to: domain.StatePublishing},
-		{from: domain.StatePendingPostPublish, to: domain.StatePostPublishing},
-		{from: domain.StateRejected, to: domain.StateReverting},
-	}
-
-	for _, tr := range transitions {
-		task, err := js.store.ClaimTask(ctx, tr.from, tr.to)
+// ClaimTask claims a pending task for processing. Tasks of the types
+// excluded for their pending state, or of the excluded jobs, are not
+// claimed, nor are tasks waiting for their dependencies.
+func (js *jobService) ClaimTask(ctx context.Context, excludedTypes map[domain.State][]domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
+	for _, stage := range domain.GetTaskStages() {
+		task, err := js.store.ClaimTask(ctx, stage.PendingState, stage.ActiveState, stage.DependencyState, js.lastTaskJobNumber(stage.PendingState), excludedTypes[stage.PendingState], excludedJobNumbers)
 		if err != nil {
 			return nil, err
 		}
 		if task != nil {
+			js.setLastTaskJobNumber(stage.PendingState, task.JobNumber)
+			js.publishTaskStateChange(task, stage.PendingState, stage.ActiveState, task.LastUpdated)
+			js.logEvent(ctx, domain.NewTaskStateChangeEvent(task, stage.PendingState, stage.ActiveState, ""))
 			return task, nil
 		}
 	}
 	return nil, nil
 }
 
+// lastTaskJobNumber returns the job number of the last task claimed from
+// the provided pending state, or 0 if none have been claimed yet.
+func (js *jobService) lastTaskJobNumber(state domain.State) int {
+	js.lastTaskJobNumbersMu.Lock()
+	defer js.lastTaskJobNumbersMu.Unlock()
+
+	return js.lastTaskJobNumbers[state]
+}
+
+// setLastTaskJobNumber records the job number of the last task claimed
+// from the provided pending state.
+func (js *jobService) setLastTaskJobNumber(state domain.State, jobNumber int) {
+	js.lastTaskJobNumbersMu.Lock()
+	defer js.lastTaskJobNumbersMu.Unlock()
+
+	if js.lastTaskJobNumbers == nil {
+		js.lastTaskJobNumbers = map[domain.State]int{}
+	}
+	js.lastTaskJobNumbers[state] = jobNumber
+}
+
+// WatchWork calls notify whenever a job or task may have become
+// claimable, until the context is done or watching fails.
+func (js *jobService) WatchWork(ctx context.Context, notify func()) error {
+	return js.store.WatchWork(ctx, notify)
+}
+
 // GetJobTasks retrieves a list of migration tasks for a job with pagination.
 func (js *jobService) GetJobTasks(ctx context.Context, states []domain.State, jobNumber, limit, offset int) ([]*domain.Task, int, error) {
 	return js.store.GetJobTasks(ctx, states, jobNumber, limit, offset)
 }
 
+// GetJobTasksAfter retrieves a page of migration tasks for a job, starting
+// after the provided cursor. The returned cursor is nil if there are no more
+// tasks.
+func (js *jobService) GetJobTasksAfter(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error) {
+	return js.store.GetJobTasksAfter(ctx, states, jobNumber, after, limit)
+}
+
+// taskTreePageSize is the number of tasks read from the store at a time
+// when building a job's task tree.
+const taskTreePageSize = 1000
+
+// GetJobTaskTree retrieves all of the tasks for a job, arranged into trees
+// by the task that created them, along with the total number of tasks.
+func (js *jobService) GetJobTaskTree(ctx context.Context, jobNumber int) ([]*domain.TaskNode, int, error) {
+	tasks := []*domain.Task{}
+	after := &domain.Cursor{}
+
+	for after != nil {
+		page, next, err := js.store.GetJobTasksAfter(ctx, nil, jobNumber, after, taskTreePageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		tasks = append(tasks, page...)
+		after = next
+	}
+
+	return domain.BuildTaskTree(tasks), len(tasks), nil
+}
+
 // CountTasksByJobNumber returns the total count of tasks for a job.
 func (js *jobService) CountTasksByJobNumber(ctx context.Context, jobNumber int) (int, error) {
 	return js.store.CountTasksByJobNumber(ctx, jobNumber)
@@ -331,12 +710,21 @@ func (js *jobService) CreateEvent(ctx context.Context, jobNumber int, event *dom
 		return nil, err
 	}
 
+	js.broadcaster.Publish(jobNumber, stream.MessageTypeEvent, event)
+
 	return event, nil
 }
 
 // GetJobEvents retrieves a list of migration events for a job with pagination.
-func (js *jobService) GetJobEvents(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Event, int, error) {
-	return js.store.GetJobEvents(ctx, jobNumber, limit, offset)
+func (js *jobService) GetJobEvents(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
+	return js.store.GetJobEvents(ctx, jobNumber, filter, limit, offset)
+}
+
+// GetJobEventsAfter retrieves a page of migration events for a job, starting
+// after the provided cursor. The returned cursor is nil if there are no more
+// events.
+func (js *jobService) GetJobEventsAfter(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error) {
+	return js.store.GetJobEventsAfter(ctx, jobNumber, filter, after, limit)
 }
 
 // CountEventsByJobNumber returns the total count of events for a job.
@@ -344,22 +732,335 @@ func (js *jobService) CountEventsByJobNumber(ctx context.Context, jobNumber int)
 	return js.store.CountEventsByJobNumber(ctx, jobNumber)
 }
 
-// logJobEvent creates and stores an event for a job state transition.
-func (js *jobService) logJobEvent(ctx context.Context, jobNumber int, action, userID string) error {
-	// Create the event using domain factory
-	event := domain.NewEvent(jobNumber, action, userID)
+// CreateComment adds a comment to a migration job, recording that it was
+// made in the job's events.
+func (js *jobService) CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	if _, err := js.store.GetJob(ctx, comment.JobNumber); err != nil {
+		return nil, err
+	}
 
-	// Store the event
-	if err := js.store.CreateEvent(ctx, event); err != nil {
-		return fmt.Errorf("failed to create event: %w", err)
+	if err := js.store.CreateComment(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	event := domain.NewEvent(comment.JobNumber, domain.EventActionCommentAdded, comment.CreatedBy.ID)
+	event.Payload = map[string]interface{}{
+		"comment_id": comment.ID,
+	}
+	js.logEvent(ctx, event)
+
+	return comment, nil
+}
+
+// GetJobComments retrieves a list of the comments on a migration job with
+// pagination.
+func (js *jobService) GetJobComments(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Comment, int, error) {
+	return js.store.GetJobComments(ctx, jobNumber, limit, offset)
+}
+
+// CreateBatch creates a migration job for each of the provided job
+// configurations, grouping them under a shared batch. Each row is validated
+// and created independently, so a failing row does not prevent the others
+// from being created. Rows are checked against Zebedee and the dataset API
+// concurrently, but their jobs are created one at a time so that rows for
+// the same dataset are still found to be running. rowDone, if provided, is
+// called as each row is processed so that the caller can extend its
+// deadline. The outcome of every row is recorded on the batch. If the
+// outcomes cannot be stored, the batch is returned with a partial status.
+func (js *jobService) CreateBatch(ctx context.Context, jobConfigs []*domain.JobConfig, userID, userAuthToken string, rowDone func()) (*domain.Batch, error) {
+	if len(jobConfigs) == 0 {
+		return nil, appErrors.ErrBatchEmpty
+	}
+
+	if js.config.BatchMaxSize > 0 && len(jobConfigs) > js.config.BatchMaxSize {
+		return nil, appErrors.ErrBatchTooLarge
+	}
+
+	batch := domain.NewBatch(len(jobConfigs), userID)
+	logData := log.Data{
+		"batch_id":   batch.ID,
+		"total_rows": batch.TotalRows,
+	}
+
+	// Store the batch before creating any jobs so that every job created
+	// references a batch that exists.
+	if err := js.store.CreateBatch(ctx, &batch); err != nil {
+		log.Error(ctx, "failed to create batch", err, logData)
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	results := make([]batchRowResult, len(jobConfigs))
+	rows := make(chan int)
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for range min(max(js.config.BatchMaxConcurrentRows, 1), len(jobConfigs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rows {
+				label, errs := js.validateBatchRow(ctx, jobConfigs[i], userAuthToken)
+
+				mu.Lock()
+				if errs == nil {
+					job, err := js.createValidatedJob(ctx, jobConfigs[i], label, userID, batch.ID)
+					if err != nil {
+						errs = []error{err}
+					} else {
+						results[i].jobNumber = job.JobNumber
+					}
+				}
+				results[i].errs = errs
+				if rowDone != nil {
+					rowDone()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range jobConfigs {
+		rows <- i
+	}
+	close(rows)
+	wg.Wait()
+
+	for i, jobConfig := range jobConfigs {
+		row := i + 1
+
+		if results[i].errs != nil {
+			log.Info(ctx, "failed to create job for batch row", log.Data{
+				"batch_id": batch.ID,
+				"row":      row,
+				"error":    errors.Join(results[i].errs...).Error(),
+			})
+			batch.AddErrors(row, jobConfig, results[i].errs...)
+			continue
+		}
+
+		batch.AddJob(row, jobConfig, results[i].jobNumber)
+	}
+
+	batch.LastUpdated = time.Now().UTC()
+	batch.Status = domain.BatchStatusCompleted
+
+	if err := js.store.UpdateBatch(ctx, &batch); err != nil {
+		// The jobs have been created, so return the results rather than
+		// failing the request, marked as partial as they are not stored.
+		log.Error(ctx, "failed to update batch with results", err, logData)
+		batch.Status = domain.BatchStatusPartial
+	}
+
+	logData["jobs_created"] = batch.JobsCreated
+	logData["rows_failed"] = batch.RowsFailed
+	logData["status"] = batch.Status
+	log.Info(ctx, "batch created", logData)
+
+	return &batch, nil
+}
+
+// batchRowResult is the outcome of creating the job for a row of a batch
+type batchRowResult struct {
+	jobNumber int
+	errs      []error
+}
+
+// validateBatchRow validates the job configuration of a batch row, both
+// internally and against Zebedee and the dataset API, returning the label
+// of the job to create or the errors that prevent it being created.
+func (js *jobService) validateBatchRow(ctx context.Context, jobConfig *domain.JobConfig, userAuthToken string) (string, []error) {
+	if errs := jobConfig.ValidateInternal(); errs != nil {
+		return "", errs
+	}
+
+	label, err := jobConfig.ValidateExternal(ctx, *js.clients, userAuthToken)
+	if err != nil {
+		return "", []error{err}
+	}
+
+	return label, nil
+}
+
+// GetBatch retrieves a migration batch by its ID, along with a summary
+// of the states of the jobs it created.
+func (js *jobService) GetBatch(ctx context.Context, batchID string) (*domain.Batch, error) {
+	batch, err := js.store.GetBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobStateCounts, err := js.store.GetBatchJobStateCounts(ctx, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch job state counts: %w", err)
+	}
+
+	batch.States, err = toStateSummaries(jobStateCounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// StreamJob subscribes to the progress of a job. If lastEventID identifies
+// a message that can be resumed from, the messages published since are
+// returned to be sent first. Otherwise a snapshot of the job is returned so
+// that the subscriber starts from its current state. The caller must close
+// the subscription when done.
+func (js *jobService) StreamJob(ctx context.Context, jobNumber int, lastEventID string) (*stream.Subscription, []*stream.Message, error) {
+	// Subscribe before reading the job so no changes are missed between the
+	// snapshot and the first message.
+	sub, missed, resumed := js.broadcaster.Subscribe(jobNumber, lastEventID)
+	if resumed {
+		return sub, missed, nil
+	}
+
+	job, err := js.store.GetJob(ctx, jobNumber)
+	if err != nil {
+		sub.Close()
+		return nil, nil, err
 	}
 
-	log.Info(ctx, "job event logged successfully", log.Data{
-		"job_number": jobNumber,
-		"action":     action,
+	snapshot := &stream.Message{
+		JobNumber: jobNumber,
+		Type:      stream.MessageTypeJob,
+		Data:      job,
+	}
+
+	return sub, []*stream.Message{snapshot}, nil
+}
+
+// CreateWebhook creates a new webhook subscription.
+func (js *jobService) CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	if err := js.store.CreateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// GetWebhook retrieves a webhook subscription by its ID.
+func (js *jobService) GetWebhook(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+	return js.store.GetWebhook(ctx, webhookID)
+}
+
+// GetWebhooks retrieves a list of webhook subscriptions with pagination.
+func (js *jobService) GetWebhooks(ctx context.Context, limit, offset int) ([]*domain.Webhook, int, error) {
+	return js.store.GetWebhooks(ctx, limit, offset)
+}
+
+// UpdateWebhook updates an existing webhook subscription.
+func (js *jobService) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	webhook.LastUpdated = time.Now().UTC()
+	return js.store.UpdateWebhook(ctx, webhook)
+}
+
+// DeleteWebhook deletes a webhook subscription. Any of its deliveries
+// still pending are moved to the dead-letter list when next attempted.
+func (js *jobService) DeleteWebhook(ctx context.Context, webhookID string) error {
+	return js.store.DeleteWebhook(ctx, webhookID)
+}
+
+// GetWebhookDeliveries retrieves a list of the deliveries to a webhook
+// with pagination, optionally filtered by status.
+func (js *jobService) GetWebhookDeliveries(ctx context.Context, webhookID string, status domain.WebhookDeliveryStatus, limit, offset int) ([]*domain.WebhookDelivery, int, error) {
+	if _, err := js.store.GetWebhook(ctx, webhookID); err != nil {
+		return nil, 0, err
+	}
+
+	return js.store.GetWebhookDeliveries(ctx, webhookID, status, limit, offset)
+}
+
+// NotifyWebhooks creates a pending delivery for each webhook subscribed to
+// a job reaching the given state. The deliveries are made asynchronously,
+// and none are created when webhooks are disabled.
+func (js *jobService) NotifyWebhooks(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+	if !js.config.EnableWebhooks {
+		return nil
+	}
+
+	var jobType domain.JobType
+	if job.Config != nil {
+		jobType = job.Config.Type
+	}
+
+	webhooks, err := js.store.GetWebhooksForEvent(ctx, domain.WebhookEventTypeForState(toState), jobType)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		delivery, err := domain.NewWebhookDelivery(webhook, job, fromState, toState, reason)
+		if err != nil {
+			return err
+		}
+
+		if err := js.store.CreateWebhookDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ClaimWebhookDelivery claims a due webhook delivery, leasing it until the
+// given time.
+func (js *jobService) ClaimWebhookDelivery(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error) {
+	return js.store.ClaimWebhookDelivery(ctx, leaseUntil)
+}
+
+// UpdateWebhookDelivery updates an existing webhook delivery.
+func (js *jobService) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return js.store.UpdateWebhookDelivery(ctx, delivery)
+}
+
+// logEvent stores an event for a job or task state transition. Failing to
+// store an event does not fail the transition, so errors are logged rather
+// than returned.
+func (js *jobService) logEvent(ctx context.Context, event *domain.Event) {
+	logData := log.Data{
+		"job_number": event.JobNumber,
+		"task_id":    event.TaskID,
+		"action":     event.Action,
+		"from_state": event.FromState,
+		"to_state":   event.ToState,
 		"user_id":    event.RequestedBy.ID,
 		"event_id":   event.ID,
+	}
+
+	if err := js.store.CreateEvent(ctx, event); err != nil {
+		log.Error(ctx, "failed to log state transition event", err, logData)
+		// TODO: Consider implementing a notification mechanism (e.g., alerts) for event logging failure
+		return
+	}
+
+	js.broadcaster.Publish(event.JobNumber, stream.MessageTypeEvent, event)
+
+	log.Info(ctx, "state transition event logged successfully", logData)
+}
+
+// publishJobStateChange notifies streams of a job's progress that the job
+// has changed state.
+func (js *jobService) publishJobStateChange(jobNumber int, fromState, toState domain.State, updatedAt time.Time) {
+	js.broadcaster.Publish(jobNumber, stream.MessageTypeJobStateChanged, &stream.StateChange{
+		JobNumber: jobNumber,
+		FromState: fromState,
+		ToState:   toState,
+		UpdatedAt: updatedAt,
 	})
+}
 
-	return nil
+// publishTaskStateChange notifies streams of a job's progress that one of
+// its tasks has changed state.
+func (js *jobService) publishTaskStateChange(task *domain.Task, fromState, toState domain.State, updatedAt time.Time) {
+	js.broadcaster.Publish(task.JobNumber, stream.MessageTypeTaskStateChanged, &stream.StateChange{
+		JobNumber: task.JobNumber,
+		TaskID:    task.ID,
+		FromState: fromState,
+		ToState:   toState,
+		UpdatedAt: updatedAt,
+	})
 }
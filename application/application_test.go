@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	sort "github.com/ONSdigital/dis-migration-service/api/sort"
 	"github.com/ONSdigital/dis-migration-service/clients"
+	clientMocks "github.com/ONSdigital/dis-migration-service/clients/mock"
 	"github.com/ONSdigital/dis-migration-service/config"
 	"github.com/ONSdigital/dis-migration-service/domain"
 	domainMocks "github.com/ONSdigital/dis-migration-service/domain/mock"
@@ -16,6 +18,8 @@ import (
 	"github.com/ONSdigital/dis-migration-service/mongo"
 	"github.com/ONSdigital/dis-migration-service/store"
 	storeMocks "github.com/ONSdigital/dis-migration-service/store/mock"
+	"github.com/ONSdigital/dis-migration-service/stream"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -66,7 +70,7 @@ func TestCreateJob(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 		jobConfig := domain.JobConfig{
 			SourceID:  "/source-id",
@@ -78,7 +82,7 @@ func TestCreateJob(t *testing.T) {
 		ctx := context.Background()
 
 		Convey("When a job is created", func() {
-			job, err := jobService.CreateJob(ctx, &jobConfig, testUserAuthToken, "")
+			job, err := jobService.CreateJob(ctx, &jobConfig, testUserAuthToken, "", "")
 
 			Convey("Then the validator should be called to get the title", func() {
 				So(len(mockValidator.ValidateSourceIDWithExternalCalls()), ShouldEqual, 1)
@@ -103,7 +107,7 @@ func TestCreateJob(t *testing.T) {
 
 							Convey("And an event should be logged for job submission", func() {
 								So(len(mockMongo.CreateEventCalls()), ShouldEqual, 1)
-								So(mockMongo.CreateEventCalls()[0].Event.Action, ShouldEqual, string(domain.StateSubmitted))
+								So(mockMongo.CreateEventCalls()[0].Event.Action, ShouldEqual, domain.EventActionJobCreated)
 								So(mockMongo.CreateEventCalls()[0].Event.JobNumber, ShouldEqual, testJobNumberCounterValue)
 							})
 						})
@@ -113,7 +117,7 @@ func TestCreateJob(t *testing.T) {
 		})
 	})
 
-	Convey("Given a job service with event logging disabled", t, func() {
+	Convey("Given a job service and a store that fails to record events", t, func() {
 		mockMongo := &storeMocks.MongoDBMock{
 			GetJobsBySourceOrTargetAndStateFunc: func(ctx context.Context, jc *domain.JobConfig, states []domain.State, limit, offset int) ([]*domain.Job, error) {
 				return nil, nil
@@ -124,6 +128,9 @@ func TestCreateJob(t *testing.T) {
 			GetNextJobNumberCounterFunc: func(ctx context.Context) (*domain.Counter, error) {
 				return &domain.Counter{CounterName: testJobNumberCounterName, CounterValue: testJobNumberCounterValue}, nil
 			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return errors.New("failed to create event")
+			},
 		}
 
 		mockStore := store.Datastore{
@@ -140,7 +147,7 @@ func TestCreateJob(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 		jobConfig := domain.JobConfig{
 			SourceID:  "/source-id",
@@ -152,7 +159,7 @@ func TestCreateJob(t *testing.T) {
 		ctx := context.Background()
 
 		Convey("When a job is created", func() {
-			job, err := jobService.CreateJob(ctx, &jobConfig, testUserAuthToken, "")
+			job, err := jobService.CreateJob(ctx, &jobConfig, testUserAuthToken, "", "")
 
 			Convey("Then no error should be returned", func() {
 				So(err, ShouldBeNil)
@@ -161,8 +168,8 @@ func TestCreateJob(t *testing.T) {
 					So(job, ShouldNotEqual, &domain.Job{})
 					So(len(mockMongo.CreateJobCalls()), ShouldEqual, 1)
 
-					Convey("And no event should be logged", func() {
-						So(len(mockMongo.CreateEventCalls()), ShouldEqual, 0)
+					Convey("And recording the event should have been attempted", func() {
+						So(len(mockMongo.CreateEventCalls()), ShouldEqual, 1)
 					})
 				})
 			})
@@ -193,7 +200,7 @@ func TestCreateJob(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 		jobConfig := domain.JobConfig{
 			SourceID:  "/source-id",
@@ -205,7 +212,7 @@ func TestCreateJob(t *testing.T) {
 		ctx := context.Background()
 
 		Convey("When a job is created", func() {
-			job, err := jobService.CreateJob(ctx, &jobConfig, testUserAuthToken, "")
+			job, err := jobService.CreateJob(ctx, &jobConfig, testUserAuthToken, "", "")
 
 			Convey("Then an error should be returned", func() {
 				So(err, ShouldNotBeNil)
@@ -253,7 +260,7 @@ func TestCreateJob(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 		jobConfig := domain.JobConfig{
 			SourceID:  "/source-id",
@@ -265,7 +272,7 @@ func TestCreateJob(t *testing.T) {
 		ctx := context.Background()
 
 		Convey("When a job is created", func() {
-			job, err := jobService.CreateJob(ctx, &jobConfig, testUserAuthToken, "")
+			job, err := jobService.CreateJob(ctx, &jobConfig, testUserAuthToken, "", "")
 
 			Convey("Then the store should be checked for matching jobs", func() {
 				So(len(mockMongo.GetJobsBySourceOrTargetAndStateCalls()), ShouldEqual, 1)
@@ -310,7 +317,7 @@ func TestCreateJob(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 		jobConfig := domain.JobConfig{
 			SourceID:  "/source-id",
@@ -322,7 +329,7 @@ func TestCreateJob(t *testing.T) {
 		ctx := context.Background()
 
 		Convey("When a job is created", func() {
-			job, err := jobService.CreateJob(ctx, &jobConfig, testUserAuthToken, "")
+			job, err := jobService.CreateJob(ctx, &jobConfig, testUserAuthToken, "", "")
 
 			Convey("Then the store should be checked for matching jobs", func() {
 				So(len(mockMongo.GetJobsBySourceOrTargetAndStateCalls()), ShouldEqual, 1)
@@ -367,7 +374,7 @@ func TestCreateJob(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 		jobConfig := domain.JobConfig{
 			SourceID:  "/source-id",
@@ -379,7 +386,7 @@ func TestCreateJob(t *testing.T) {
 		ctx := context.Background()
 
 		Convey("When a job is created", func() {
-			job, err := jobService.CreateJob(ctx, &jobConfig, testUserAuthToken, "")
+			job, err := jobService.CreateJob(ctx, &jobConfig, testUserAuthToken, "", "")
 
 			Convey("Then the store should be called to create a job", func() {
 				So(len(mockMongo.GetJobsBySourceOrTargetAndStateCalls()), ShouldEqual, 1)
@@ -395,6 +402,383 @@ func TestCreateJob(t *testing.T) {
 	})
 }
 
+func TestCreateJobWithIdempotencyKey(t *testing.T) {
+	testIdempotencyKey := "test-idempotency-key"
+
+	newJobConfig := func() *domain.JobConfig {
+		return &domain.JobConfig{
+			SourceID: "/source-id",
+			TargetID: "target-id",
+			Type:     domain.JobTypeStaticDataset,
+			Validator: &domainMocks.JobValidatorMock{
+				ValidateSourceIDWithExternalFunc: func(ctx context.Context, sourceID string, appClients *clients.ClientList, userAuthToken string) (string, error) {
+					return testDatasetTitle, nil
+				},
+				ValidateTargetIDWithExternalFunc: func(ctx context.Context, targetID string, appClients *clients.ClientList, userAuthToken string) error {
+					return nil
+				},
+			},
+		}
+	}
+
+	Convey("Given a job service and a store that has not seen the idempotency key", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			CreateIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord) error {
+				return nil
+			},
+			CompleteIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord, job *domain.Job) error {
+				return nil
+			},
+			DeleteIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord) error {
+				return nil
+			},
+			GetJobsBySourceOrTargetAndStateFunc: func(ctx context.Context, jc *domain.JobConfig, states []domain.State, limit, offset int) ([]*domain.Job, error) {
+				return nil, nil
+			},
+			CreateJobFunc: func(ctx context.Context, job *domain.Job) error {
+				return nil
+			},
+			GetNextJobNumberCounterFunc: func(ctx context.Context) (*domain.Counter, error) {
+				return &domain.Counter{CounterName: testJobNumberCounterName, CounterValue: testJobNumberCounterValue}, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{IdempotencyKeyExpiry: time.Hour, IdempotencyKeyPendingExpiry: time.Minute}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When a job is created with the idempotency key", func() {
+			job, err := jobService.CreateJob(ctx, newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then the job is created and stored against the user's key", func() {
+				So(err, ShouldBeNil)
+				So(job.JobNumber, ShouldEqual, testJobNumberCounterValue)
+
+				So(len(mockMongo.CreateIdempotencyRecordCalls()), ShouldEqual, 1)
+				record := mockMongo.CreateIdempotencyRecordCalls()[0].Record
+				So(record.ID, ShouldEqual, domain.IdempotencyRecordID("test-user", testIdempotencyKey))
+				So(record.Key, ShouldEqual, testIdempotencyKey)
+				So(record.UserID, ShouldEqual, "test-user")
+				So(*record.ExpiresAt, ShouldEqual, record.CreatedAt.Add(time.Hour))
+				So(*record.PendingExpiresAt, ShouldEqual, record.CreatedAt.Add(time.Minute))
+				So(len(mockMongo.CreateJobCalls()), ShouldEqual, 1)
+				So(len(mockMongo.CompleteIdempotencyRecordCalls()), ShouldEqual, 1)
+				So(mockMongo.CompleteIdempotencyRecordCalls()[0].Record, ShouldEqual, record)
+				So(mockMongo.CompleteIdempotencyRecordCalls()[0].Job, ShouldEqual, job)
+				So(len(mockMongo.DeleteIdempotencyRecordCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the job is created but cannot be stored against the key", func() {
+			mockMongo.CompleteIdempotencyRecordFunc = func(ctx context.Context, record *domain.IdempotencyRecord, job *domain.Job) error {
+				return errors.New("fake store error")
+			}
+
+			job, err := jobService.CreateJob(ctx, newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then the job is returned and the key is left in progress so that a retry cannot create another job", func() {
+				So(err, ShouldBeNil)
+				So(job.JobNumber, ShouldEqual, testJobNumberCounterValue)
+				So(len(mockMongo.DeleteIdempotencyRecordCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When job creation fails", func() {
+			mockMongo.CreateJobFunc = func(ctx context.Context, job *domain.Job) error {
+				return errors.New("fake store error")
+			}
+
+			_, err := jobService.CreateJob(ctx, newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then the error is returned and the key is released", func() {
+				So(err, ShouldEqual, appErrors.ErrInternalServerError)
+				So(len(mockMongo.DeleteIdempotencyRecordCalls()), ShouldEqual, 1)
+				So(mockMongo.DeleteIdempotencyRecordCalls()[0].Record, ShouldEqual, mockMongo.CreateIdempotencyRecordCalls()[0].Record)
+			})
+		})
+	})
+
+	Convey("Given a job service and a store that has already seen the idempotency key", t, func() {
+		requestHash, err := domain.HashJobConfig(newJobConfig())
+		So(err, ShouldBeNil)
+
+		existingRecord := domain.NewIdempotencyRecord("test-user", testIdempotencyKey, requestHash, time.Hour, time.Minute)
+		existingRecord.Job = &domain.Job{JobNumber: testJobNumber, State: domain.StateSubmitted}
+
+		mockMongo := &storeMocks.MongoDBMock{
+			CreateIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord) error {
+				return appErrors.ErrIdempotencyKeyExists
+			},
+			ReplaceExpiredIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord) error {
+				return appErrors.ErrIdempotencyKeyExists
+			},
+			GetIdempotencyRecordFunc: func(ctx context.Context, id string) (*domain.IdempotencyRecord, error) {
+				return &existingRecord, nil
+			},
+			GetJobsBySourceOrTargetAndStateFunc: func(ctx context.Context, jc *domain.JobConfig, states []domain.State, limit, offset int) ([]*domain.Job, error) {
+				return nil, nil
+			},
+			CreateJobFunc: func(ctx context.Context, job *domain.Job) error {
+				return nil
+			},
+			GetNextJobNumberCounterFunc: func(ctx context.Context) (*domain.Counter, error) {
+				return &domain.Counter{CounterName: testJobNumberCounterName, CounterValue: testJobNumberCounterValue}, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+			CompleteIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord, job *domain.Job) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{IdempotencyKeyExpiry: time.Hour}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When the same request is repeated", func() {
+			job, err := jobService.CreateJob(ctx, newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then the job is returned as it was to the original request without creating another", func() {
+				So(err, ShouldBeNil)
+				So(job, ShouldEqual, existingRecord.Job)
+				So(len(mockMongo.GetIdempotencyRecordCalls()), ShouldEqual, 1)
+				So(mockMongo.GetIdempotencyRecordCalls()[0].ID, ShouldEqual, domain.IdempotencyRecordID("test-user", testIdempotencyKey))
+				So(len(mockMongo.CreateJobCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When a different request is made with the same key", func() {
+			jobConfig := newJobConfig()
+			jobConfig.TargetID = "other-target-id"
+
+			_, err := jobService.CreateJob(ctx, jobConfig, "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then the key reuse is rejected", func() {
+				So(err, ShouldEqual, appErrors.ErrIdempotencyKeyReused)
+				So(len(mockMongo.CreateJobCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the same request is repeated before the original has created its job", func() {
+			existingRecord.Job = nil
+
+			_, err := jobService.CreateJob(ctx, newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then a conflict is returned", func() {
+				So(err, ShouldEqual, appErrors.ErrIdempotencyKeyInProgress)
+				So(len(mockMongo.CreateJobCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the key has expired", func() {
+			mockMongo.ReplaceExpiredIdempotencyRecordFunc = func(ctx context.Context, record *domain.IdempotencyRecord) error {
+				return nil
+			}
+
+			job, err := jobService.CreateJob(ctx, newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then the expired key is replaced and a new job is created", func() {
+				So(err, ShouldBeNil)
+				So(job.JobNumber, ShouldEqual, testJobNumberCounterValue)
+				So(len(mockMongo.ReplaceExpiredIdempotencyRecordCalls()), ShouldEqual, 1)
+				So(mockMongo.ReplaceExpiredIdempotencyRecordCalls()[0].Record, ShouldEqual, mockMongo.CreateIdempotencyRecordCalls()[0].Record)
+				So(len(mockMongo.GetIdempotencyRecordCalls()), ShouldEqual, 0)
+				So(len(mockMongo.CreateJobCalls()), ShouldEqual, 1)
+				So(len(mockMongo.CompleteIdempotencyRecordCalls()), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the existing record is removed by its expiry before it can be read", func() {
+			mockMongo.CreateIdempotencyRecordFunc = func(ctx context.Context, record *domain.IdempotencyRecord) error {
+				if len(mockMongo.CreateIdempotencyRecordCalls()) == 1 {
+					return appErrors.ErrIdempotencyKeyExists
+				}
+				return nil
+			}
+			mockMongo.GetIdempotencyRecordFunc = func(ctx context.Context, id string) (*domain.IdempotencyRecord, error) {
+				return nil, appErrors.ErrIdempotencyKeyNotFound
+			}
+
+			job, err := jobService.CreateJob(ctx, newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then the key is reserved again and a new job is created", func() {
+				So(err, ShouldBeNil)
+				So(job.JobNumber, ShouldEqual, testJobNumberCounterValue)
+				So(len(mockMongo.CreateIdempotencyRecordCalls()), ShouldEqual, 2)
+				So(len(mockMongo.CreateJobCalls()), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the existing record keeps being removed before it can be read", func() {
+			mockMongo.GetIdempotencyRecordFunc = func(ctx context.Context, id string) (*domain.IdempotencyRecord, error) {
+				return nil, appErrors.ErrIdempotencyKeyNotFound
+			}
+
+			_, err := jobService.CreateJob(ctx, newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then it gives up after a limited number of attempts", func() {
+				So(err, ShouldEqual, appErrors.ErrInternalServerError)
+				So(len(mockMongo.CreateIdempotencyRecordCalls()), ShouldEqual, 3)
+				So(len(mockMongo.CreateJobCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the existing record has expired since it could not be replaced", func() {
+			expiresAt := time.Now().UTC().Add(-time.Second)
+			existingRecord.ExpiresAt = &expiresAt
+			mockMongo.ReplaceExpiredIdempotencyRecordFunc = func(ctx context.Context, record *domain.IdempotencyRecord) error {
+				if len(mockMongo.ReplaceExpiredIdempotencyRecordCalls()) == 1 {
+					return appErrors.ErrIdempotencyKeyExists
+				}
+				return nil
+			}
+
+			job, err := jobService.CreateJob(ctx, newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then its job is not replayed and the key is replaced", func() {
+				So(err, ShouldBeNil)
+				So(job.JobNumber, ShouldEqual, testJobNumberCounterValue)
+				So(len(mockMongo.ReplaceExpiredIdempotencyRecordCalls()), ShouldEqual, 2)
+				So(len(mockMongo.CreateJobCalls()), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the key has expired but a concurrent request has already replaced it", func() {
+			existingRecord.Job = nil
+
+			_, err := jobService.CreateJob(ctx, newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then the concurrent request's reservation is kept and no job is created", func() {
+				So(err, ShouldEqual, appErrors.ErrIdempotencyKeyInProgress)
+				So(len(mockMongo.ReplaceExpiredIdempotencyRecordCalls()), ShouldEqual, 1)
+				So(len(mockMongo.CreateJobCalls()), ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given a job service whose idempotency keys do not expire and a store that has already seen the key", t, func() {
+		requestHash, err := domain.HashJobConfig(newJobConfig())
+		So(err, ShouldBeNil)
+
+		existingRecord := domain.NewIdempotencyRecord("test-user", testIdempotencyKey, requestHash, 0, 0)
+		existingRecord.Job = &domain.Job{JobNumber: testJobNumber, State: domain.StateSubmitted}
+
+		mockMongo := &storeMocks.MongoDBMock{
+			CreateIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord) error {
+				return appErrors.ErrIdempotencyKeyExists
+			},
+			GetIdempotencyRecordFunc: func(ctx context.Context, id string) (*domain.IdempotencyRecord, error) {
+				return &existingRecord, nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		Convey("When the same request is repeated", func() {
+			job, err := jobService.CreateJob(context.Background(), newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then the original job is replayed without trying to replace the key", func() {
+				So(err, ShouldBeNil)
+				So(job, ShouldEqual, existingRecord.Job)
+			})
+		})
+	})
+	Convey("Given a job service whose idempotency keys do not expire and a store with a pending record older than the pending expiry", t, func() {
+		requestHash, err := domain.HashJobConfig(newJobConfig())
+		So(err, ShouldBeNil)
+
+		existingRecord := domain.NewIdempotencyRecord("test-user", testIdempotencyKey, requestHash, 0, time.Minute)
+		pendingExpiresAt := time.Now().UTC().Add(-time.Second)
+		existingRecord.PendingExpiresAt = &pendingExpiresAt
+
+		mockMongo := &storeMocks.MongoDBMock{
+			CreateIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord) error {
+				return appErrors.ErrIdempotencyKeyExists
+			},
+			ReplaceExpiredIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord) error {
+				if !existingRecord.IsExpired() {
+					return appErrors.ErrIdempotencyKeyExists
+				}
+				existingRecord = *record
+				return nil
+			},
+			GetIdempotencyRecordFunc: func(ctx context.Context, id string) (*domain.IdempotencyRecord, error) {
+				return &existingRecord, nil
+			},
+			GetJobsBySourceOrTargetAndStateFunc: func(ctx context.Context, jc *domain.JobConfig, states []domain.State, limit, offset int) ([]*domain.Job, error) {
+				return nil, nil
+			},
+			CreateJobFunc: func(ctx context.Context, job *domain.Job) error {
+				return nil
+			},
+			GetNextJobNumberCounterFunc: func(ctx context.Context) (*domain.Counter, error) {
+				return &domain.Counter{CounterName: testJobNumberCounterName, CounterValue: testJobNumberCounterValue}, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+			CompleteIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord, job *domain.Job) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{IdempotencyKeyPendingExpiry: time.Minute}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		Convey("When the same request is retried", func() {
+			job, err := jobService.CreateJob(context.Background(), newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then the abandoned reservation is taken over and the job is created", func() {
+				So(err, ShouldBeNil)
+				So(job.JobNumber, ShouldEqual, testJobNumberCounterValue)
+				So(len(mockMongo.ReplaceExpiredIdempotencyRecordCalls()), ShouldEqual, 1)
+				So(len(mockMongo.GetIdempotencyRecordCalls()), ShouldEqual, 0)
+				So(len(mockMongo.CreateJobCalls()), ShouldEqual, 1)
+				So(len(mockMongo.CompleteIdempotencyRecordCalls()), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the same request is retried before the pending expiry has passed", func() {
+			pendingExpiresAt := time.Now().UTC().Add(time.Minute)
+			existingRecord.PendingExpiresAt = &pendingExpiresAt
+
+			_, err := jobService.CreateJob(context.Background(), newJobConfig(), "test-user", testUserAuthToken, testIdempotencyKey)
+
+			Convey("Then the request is still in progress and no job is created", func() {
+				So(err, ShouldEqual, appErrors.ErrIdempotencyKeyInProgress)
+				So(len(mockMongo.CreateJobCalls()), ShouldEqual, 0)
+			})
+		})
+	})
+}
+
 func TestGetJob(t *testing.T) {
 	Convey("Given a job service and a store that has a job for the requested id", t, func() {
 		expectedJob := &domain.Job{
@@ -412,7 +796,7 @@ func TestGetJob(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -444,7 +828,7 @@ func TestGetJob(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -477,7 +861,7 @@ func TestGetJob(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -524,14 +908,14 @@ func TestUpdateJobState(t *testing.T) {
 
 		mockClients := clients.ClientList{}
 
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 		newState := domain.StateMigrating
 
 		Convey("When a job state is updated to migrating", func() {
-			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, newState, "")
+			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, newState, "", "", nil)
 
 			Convey("Then the store should be called to update the job state", func() {
 				So(len(mockMongo.UpdateJobStateCalls()), ShouldEqual, 1)
@@ -541,15 +925,21 @@ func TestUpdateJobState(t *testing.T) {
 				Convey("Then no error should be returned", func() {
 					So(err, ShouldBeNil)
 
-					Convey("And no event should be logged for migrating state", func() {
-						So(len(mockMongo.CreateEventCalls()), ShouldEqual, 0)
+					Convey("And a system event should be logged for the migrating state", func() {
+						So(len(mockMongo.CreateEventCalls()), ShouldEqual, 1)
+						event := mockMongo.CreateEventCalls()[0].Event
+						So(event.Action, ShouldEqual, domain.EventActionStateChanged)
+						So(event.FromState, ShouldEqual, domain.StateSubmitted)
+						So(event.ToState, ShouldEqual, domain.StateMigrating)
+						So(event.RequestedBy.ID, ShouldEqual, domain.SystemUserID)
+						So(event.TaskID, ShouldBeEmpty)
 					})
 				})
 			})
 		})
 	})
 
-	Convey("Given a job service with event logging enabled and store with approved state transition", t, func() {
+	Convey("Given a job service and store with approved state transition", t, func() {
 		fakeJob := &domain.Job{
 			ID:        "test-job-id",
 			JobNumber: testJobNumber,
@@ -560,8 +950,8 @@ func TestUpdateJobState(t *testing.T) {
 			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 				return fakeJob, nil
 			},
-			UpdateJobStateFunc: func(ctx context.Context, id string, oldState, newState domain.State, lastUpdated time.Time) error {
-				return nil
+			ApproveJobFunc: func(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error) {
+				return &domain.Job{ID: jobID, JobNumber: testJobNumber, State: domain.StateApproved}, nil
 			},
 			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
 				return nil
@@ -573,19 +963,20 @@ func TestUpdateJobState(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 		newState := domain.StateApproved
 
 		Convey("When a job state is updated to approved", func() {
-			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, newState, "")
+			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, newState, "", "", nil)
 
-			Convey("Then the store should be called to update the job state", func() {
-				So(len(mockMongo.UpdateJobStateCalls()), ShouldEqual, 1)
-				So(mockMongo.UpdateJobStateCalls()[0].ID, ShouldEqual, fakeJob.ID)
-				So(mockMongo.UpdateJobStateCalls()[0].NewState, ShouldEqual, newState)
+			Convey("Then the store should be called to approve the job", func() {
+				So(len(mockMongo.ApproveJobCalls()), ShouldEqual, 1)
+				So(mockMongo.ApproveJobCalls()[0].JobID, ShouldEqual, fakeJob.ID)
+				So(mockMongo.ApproveJobCalls()[0].Approval, ShouldBeNil)
+				So(mockMongo.UpdateJobStateCalls(), ShouldBeEmpty)
 
 				Convey("Then no error should be returned", func() {
 					So(err, ShouldBeNil)
@@ -593,7 +984,7 @@ func TestUpdateJobState(t *testing.T) {
 					Convey("And an event should be logged for approval", func() {
 						So(len(mockMongo.GetJobCalls()), ShouldEqual, 1)
 						So(len(mockMongo.CreateEventCalls()), ShouldEqual, 1)
-						So(mockMongo.CreateEventCalls()[0].Event.Action, ShouldEqual, string(domain.StateApproved))
+						So(mockMongo.CreateEventCalls()[0].Event.Action, ShouldEqual, domain.EventActionStateChanged)
 						So(mockMongo.CreateEventCalls()[0].Event.JobNumber, ShouldEqual, testJobNumber)
 					})
 				})
@@ -601,7 +992,7 @@ func TestUpdateJobState(t *testing.T) {
 		})
 	})
 
-	Convey("Given a job service with event logging enabled and store with rejected state transition", t, func() {
+	Convey("Given a job service and store with rejected state transition", t, func() {
 		fakeJob := &domain.Job{
 			ID:        "test-job-id",
 			JobNumber: testJobNumber,
@@ -639,14 +1030,14 @@ func TestUpdateJobState(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 		newState := domain.StateRejected
 
 		Convey("When a job state is updated to rejected", func() {
-			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, newState, "")
+			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, newState, "", "", nil)
 
 			Convey("Then no error should be returned", func() {
 				So(err, ShouldBeNil)
@@ -665,7 +1056,7 @@ func TestUpdateJobState(t *testing.T) {
 		})
 	})
 
-	Convey("Given a job service with event logging disabled and approved state transition", t, func() {
+	Convey("Given a job service whose store fails to record events and an approved state transition", t, func() {
 		fakeJob := &domain.Job{
 			ID:        "test-job-id",
 			JobNumber: testJobNumber,
@@ -676,11 +1067,11 @@ func TestUpdateJobState(t *testing.T) {
 			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 				return fakeJob, nil
 			},
-			UpdateJobStateFunc: func(ctx context.Context, id string, oldState, newState domain.State, lastUpdated time.Time) error {
-				return nil
+			ApproveJobFunc: func(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error) {
+				return &domain.Job{ID: jobID, JobNumber: testJobNumber, State: domain.StateApproved}, nil
 			},
 			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
-				return nil
+				return errors.New("failed to create event")
 			},
 		}
 
@@ -689,95 +1080,36 @@ func TestUpdateJobState(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 		newState := domain.StateApproved
 
 		Convey("When a job state is updated to approved", func() {
-			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, newState, "")
+			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, newState, "", "", nil)
 
-			Convey("Then the store should be called to update the job state", func() {
-				So(len(mockMongo.UpdateJobStateCalls()), ShouldEqual, 1)
-				So(mockMongo.UpdateJobStateCalls()[0].ID, ShouldEqual, fakeJob.ID)
-				So(mockMongo.UpdateJobStateCalls()[0].NewState, ShouldEqual, newState)
+			Convey("Then the store should be called to approve the job", func() {
+				So(len(mockMongo.ApproveJobCalls()), ShouldEqual, 1)
+				So(mockMongo.ApproveJobCalls()[0].JobID, ShouldEqual, fakeJob.ID)
+				So(mockMongo.ApproveJobCalls()[0].Approval, ShouldBeNil)
+				So(mockMongo.UpdateJobStateCalls(), ShouldBeEmpty)
 
 				Convey("Then no error should be returned", func() {
 					So(err, ShouldBeNil)
 
-					Convey("And no event should be logged even though state is approved", func() {
-						So(len(mockMongo.CreateEventCalls()), ShouldEqual, 0)
+					Convey("And recording the event should have been attempted", func() {
+						So(len(mockMongo.CreateEventCalls()), ShouldEqual, 1)
 					})
 				})
 			})
 		})
 	})
 
-	Convey("Given a job service with event logging disabled and rejected state transition", t, func() {
+	Convey("Given a job service and store that returns an error when updating a job", t, func() {
 		fakeJob := &domain.Job{
-			ID:        "test-job-id",
 			JobNumber: testJobNumber,
-			State:     domain.StateInReview,
-		}
-		fakeTask := &domain.Task{
-			ID:        "task-2",
-			JobNumber: testJobNumber,
-			State:     domain.StateInReview,
-		}
-
-		mockMongo := &storeMocks.MongoDBMock{
-			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
-				return fakeJob, nil
-			},
-			CountTasksByJobNumberFunc: func(ctx context.Context, jobNumber int) (int, error) {
-				return 1, nil
-			},
-			GetJobTasksFunc: func(ctx context.Context, states []domain.State, jobNumber int, limit int, offset int) ([]*domain.Task, int, error) {
-				return []*domain.Task{fakeTask}, 1, nil
-			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, lastUpdated time.Time) error {
-				return nil
-			},
-			UpdateJobStateFunc: func(ctx context.Context, id string, oldState, newState domain.State, lastUpdated time.Time) error {
-				return nil
-			},
-		}
-
-		mockStore := store.Datastore{
-			Backend: mockMongo,
-		}
-
-		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
-		jobService := Setup(&mockStore, &mockClients, cfg)
-
-		ctx := context.Background()
-		newState := domain.StateRejected
-
-		Convey("When a job state is updated to rejected", func() {
-			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, newState, "")
-
-			Convey("Then no error should be returned", func() {
-				So(err, ShouldBeNil)
-
-				Convey("Then the store should be called to update the job state directly to rejected", func() {
-					So(len(mockMongo.UpdateJobStateCalls()), ShouldEqual, 1)
-					So(mockMongo.UpdateJobStateCalls()[0].ID, ShouldEqual, fakeJob.ID)
-					So(mockMongo.UpdateJobStateCalls()[0].NewState, ShouldEqual, domain.StateRejected)
-
-					Convey("And no event should be logged since event logging is disabled", func() {
-						So(len(mockMongo.CreateEventCalls()), ShouldEqual, 0)
-					})
-				})
-			})
-		})
-	})
-
-	Convey("Given a job service and store that returns an error when updating a job", t, func() {
-		fakeJob := &domain.Job{
-			JobNumber: testJobNumber,
-			State:     domain.StateSubmitted,
+			State:     domain.StateSubmitted,
 		}
 
 		mockMongo := &storeMocks.MongoDBMock{
@@ -794,14 +1126,14 @@ func TestUpdateJobState(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 		newState := domain.StateMigrating
 
 		Convey("When a job state is updated", func() {
-			err := jobService.UpdateJobState(ctx, testJobNumber, newState, "")
+			err := jobService.UpdateJobState(ctx, testJobNumber, newState, "", "", nil)
 			Convey("Then an error should be returned", func() {
 				So(len(mockMongo.UpdateJobStateCalls()), ShouldEqual, 1)
 				So(err, ShouldNotBeNil)
@@ -821,17 +1153,20 @@ func TestUpdateJobState(t *testing.T) {
 			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 				return fakeJob, nil
 			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
 		}
 
 		mockStore := store.Datastore{Backend: mockMongo}
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 
 		Convey("When transitioning the job to rejected", func() {
-			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, domain.StateRejected, "")
+			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, domain.StateRejected, "", "", nil)
 
 			Convey("Then the transition should be blocked by state machine", func() {
 				So(err, ShouldNotBeNil)
@@ -844,7 +1179,7 @@ func TestUpdateJobState(t *testing.T) {
 				return nil
 			}
 
-			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, domain.StateCancelled, "")
+			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, domain.StateCancelled, "", "", nil)
 
 			Convey("Then the transition should succeed", func() {
 				So(err, ShouldBeNil)
@@ -871,14 +1206,14 @@ func TestUpdateJobState(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 		invalidNewState := domain.StateSubmitted // Invalid transition
 
 		Convey("When attempting an invalid state transition", func() {
-			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, invalidNewState, "")
+			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, invalidNewState, "", "", nil)
 
 			Convey("Then an error should be returned", func() {
 				So(len(mockMongo.GetJobCalls()), ShouldEqual, 1)
@@ -905,14 +1240,14 @@ func TestUpdateJobState(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 		sameState := domain.StateSubmitted
 
 		Convey("When attempting to transition to the same state", func() {
-			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, sameState, "")
+			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, sameState, "", "", nil)
 
 			Convey("Then an error should be returned and the store should not be called to update the job state", func() {
 				So(len(mockMongo.GetJobCalls()), ShouldEqual, 1)
@@ -942,14 +1277,14 @@ func TestUpdateJobState(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 		newState := domain.StateMigrating
 
 		Convey("When a job state is updated", func() {
-			err := jobService.UpdateJobState(ctx, testJobNumber, newState, "")
+			err := jobService.UpdateJobState(ctx, testJobNumber, newState, "", "", nil)
 
 			Convey("Then an error should be returned indicating the state was not updated", func() {
 				So(len(mockMongo.UpdateJobStateCalls()), ShouldEqual, 1)
@@ -959,10 +1294,342 @@ func TestUpdateJobState(t *testing.T) {
 	})
 }
 
+func TestUpdateJobStateFourEyes(t *testing.T) {
+	newJobInReview := func() *domain.Job {
+		job := &domain.Job{
+			ID:        "test-job-id",
+			JobNumber: testJobNumber,
+			State:     domain.StateInReview,
+		}
+		job.SetCreatedBy("submitter")
+		return job
+	}
+
+	Convey("Given a job service and a job in review", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return newJobInReview(), nil
+			},
+			ApproveJobFunc: func(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error) {
+				return &domain.Job{ID: jobID, JobNumber: testJobNumber, State: domain.StateApproved}, nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, id string, oldState, newState domain.State, lastUpdated time.Time) error {
+				return nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the submitter approves the job", func() {
+			err := jobService.UpdateJobState(context.Background(), testJobNumber, domain.StateApproved, "submitter", "", nil)
+
+			Convey("Then the approval is refused and the job is not updated", func() {
+				So(err, ShouldEqual, appErrors.ErrApproverIsSubmitter)
+				So(mockMongo.ApproveJobCalls(), ShouldBeEmpty)
+				So(mockMongo.CreateEventCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the submitter approves the job with the override permission", func() {
+			err := jobService.UpdateJobState(context.Background(), testJobNumber, domain.StateApproved, "submitter", "", &domain.JobStateOptions{FourEyesOverride: true})
+
+			Convey("Then the job is approved", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.ApproveJobCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the submitter rejects the job", func() {
+			err := jobService.UpdateJobState(context.Background(), testJobNumber, domain.StateRejected, "submitter", "", nil)
+
+			Convey("Then the job is rejected", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.UpdateJobStateCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When another user approves the job", func() {
+			err := jobService.UpdateJobState(context.Background(), testJobNumber, domain.StateApproved, "reviewer", "", nil)
+
+			Convey("Then the job is approved without recording separate approvals", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.ApproveJobCalls(), ShouldHaveLength, 1)
+				So(mockMongo.ApproveJobCalls()[0].Approval, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a job service requiring two approvals and a job in review", t, func() {
+		var approvals []*domain.Approval
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return newJobInReview(), nil
+			},
+			ApproveJobFunc: func(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error) {
+				approvals = append(approvals, approval)
+				job := newJobInReview()
+				job.Approvals = approvals
+				if len(approvals) >= requiredApprovals {
+					job.State = domain.StateApproved
+				}
+				return job, nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, id string, oldState, newState domain.State, lastUpdated time.Time) error {
+				return nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{RequiredApprovals: 2})
+
+		Convey("When the first reviewer approves the job", func() {
+			err := jobService.UpdateJobState(context.Background(), testJobNumber, domain.StateApproved, "reviewer-1", "looks good", nil)
+
+			Convey("Then the approval is recorded but the job stays in review", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.ApproveJobCalls(), ShouldHaveLength, 1)
+				So(mockMongo.ApproveJobCalls()[0].Approval.User.ID, ShouldEqual, "reviewer-1")
+				So(mockMongo.ApproveJobCalls()[0].RequiredApprovals, ShouldEqual, 2)
+				So(mockMongo.UpdateJobStateCalls(), ShouldBeEmpty)
+
+				So(mockMongo.CreateEventCalls(), ShouldHaveLength, 1)
+				event := mockMongo.CreateEventCalls()[0].Event
+				So(event.Action, ShouldEqual, domain.EventActionApprovalAdded)
+				So(event.RequestedBy.ID, ShouldEqual, "reviewer-1")
+				So(event.Reason, ShouldEqual, "looks good")
+				So(event.Payload["approvals"], ShouldEqual, 1)
+				So(event.Payload["required_approvals"], ShouldEqual, 2)
+			})
+
+			Convey("And when a second reviewer approves the job", func() {
+				err := jobService.UpdateJobState(context.Background(), testJobNumber, domain.StateApproved, "reviewer-2", "", nil)
+
+				Convey("Then the job is approved along with the approval", func() {
+					So(err, ShouldBeNil)
+					So(mockMongo.ApproveJobCalls(), ShouldHaveLength, 2)
+					So(mockMongo.UpdateJobStateCalls(), ShouldBeEmpty)
+
+					events := mockMongo.CreateEventCalls()
+					So(events, ShouldHaveLength, 2)
+					So(events[1].Event.Action, ShouldEqual, domain.EventActionStateChanged)
+				})
+			})
+		})
+
+		Convey("When the submitter approves the job", func() {
+			err := jobService.UpdateJobState(context.Background(), testJobNumber, domain.StateApproved, "submitter", "", nil)
+
+			Convey("Then the approval is refused without being recorded", func() {
+				So(err, ShouldEqual, appErrors.ErrApproverIsSubmitter)
+				So(mockMongo.ApproveJobCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a reviewer approves the job with a publish time in the past", func() {
+			publishAt := time.Now().Add(-time.Minute)
+			err := jobService.UpdateJobState(context.Background(), testJobNumber, domain.StateApproved, "reviewer-1", "", &domain.JobStateOptions{PublishAt: &publishAt})
+
+			Convey("Then the approval is refused without being recorded", func() {
+				So(err, ShouldEqual, appErrors.ErrPublishAtInPast)
+				So(mockMongo.ApproveJobCalls(), ShouldBeEmpty)
+				So(mockMongo.UpdateJobStateCalls(), ShouldBeEmpty)
+				So(mockMongo.CreateEventCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a job service requiring two approvals and a store that rejects repeat approvals", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return newJobInReview(), nil
+			},
+			ApproveJobFunc: func(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error) {
+				return nil, appErrors.ErrJobAlreadyApprovedByUser
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{RequiredApprovals: 2})
+
+		Convey("When a reviewer approves the job again", func() {
+			err := jobService.UpdateJobState(context.Background(), testJobNumber, domain.StateApproved, "reviewer-1", "", nil)
+
+			Convey("Then the error is returned and the job is not updated", func() {
+				So(err, ShouldEqual, appErrors.ErrJobAlreadyApprovedByUser)
+				So(mockMongo.UpdateJobStateCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestScheduleJob(t *testing.T) {
+	Convey("Given a job service and an approved job", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{ID: "test-job-id", JobNumber: jobNumber, State: domain.StateApproved}, nil
+			},
+			UpdateJobPublishAtFunc: func(ctx context.Context, jobID string, states []domain.State, publishAt *time.Time) error {
+				return nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the job is scheduled for a future time", func() {
+			publishAt := time.Now().Add(time.Hour)
+			err := jobService.ScheduleJob(context.Background(), testJobNumber, &publishAt, "user-123")
+
+			Convey("Then the publish time is stored for jobs in review or approved", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.UpdateJobPublishAtCalls(), ShouldHaveLength, 1)
+				call := mockMongo.UpdateJobPublishAtCalls()[0]
+				So(call.JobID, ShouldEqual, "test-job-id")
+				So(call.States, ShouldResemble, []domain.State{domain.StateInReview, domain.StateApproved})
+				So(call.PublishAt, ShouldNotBeNil)
+				So(call.PublishAt.Equal(publishAt), ShouldBeTrue)
+
+				Convey("And a schedule changed event is logged", func() {
+					So(mockMongo.CreateEventCalls(), ShouldHaveLength, 1)
+					event := mockMongo.CreateEventCalls()[0].Event
+					So(event.Action, ShouldEqual, domain.EventActionScheduleChanged)
+					So(event.RequestedBy.ID, ShouldEqual, "user-123")
+					So(event.Payload["publish_at"], ShouldEqual, publishAt.UTC().Format(time.RFC3339))
+				})
+			})
+		})
+
+		Convey("When the job's schedule is cancelled", func() {
+			err := jobService.ScheduleJob(context.Background(), testJobNumber, nil, "user-123")
+
+			Convey("Then the publish time is cleared and an event is logged without a payload", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.UpdateJobPublishAtCalls(), ShouldHaveLength, 1)
+				So(mockMongo.UpdateJobPublishAtCalls()[0].PublishAt, ShouldBeNil)
+				So(mockMongo.CreateEventCalls(), ShouldHaveLength, 1)
+				So(mockMongo.CreateEventCalls()[0].Event.Payload, ShouldBeNil)
+			})
+		})
+
+		Convey("When the job is scheduled for a time in the past", func() {
+			publishAt := time.Now().Add(-time.Minute)
+			err := jobService.ScheduleJob(context.Background(), testJobNumber, &publishAt, "user-123")
+
+			Convey("Then an error is returned and nothing is stored", func() {
+				So(err, ShouldEqual, appErrors.ErrPublishAtInPast)
+				So(mockMongo.UpdateJobPublishAtCalls(), ShouldBeEmpty)
+				So(mockMongo.CreateEventCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a job service and a job in review", t, func() {
+		approveErr := error(nil)
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{ID: "test-job-id", JobNumber: jobNumber, State: domain.StateInReview}, nil
+			},
+			ApproveJobFunc: func(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error) {
+				if approveErr != nil {
+					return nil, approveErr
+				}
+				return &domain.Job{ID: jobID, JobNumber: testJobNumber, State: domain.StateApproved, PublishAt: publishAt}, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the job is approved with a publish time", func() {
+			publishAt := time.Now().Add(time.Hour)
+			err := jobService.UpdateJobState(context.Background(), testJobNumber, domain.StateApproved, "reviewer", "", &domain.JobStateOptions{PublishAt: &publishAt})
+
+			Convey("Then the job is scheduled in the same update that approves it", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.ApproveJobCalls(), ShouldHaveLength, 1)
+				So(mockMongo.ApproveJobCalls()[0].PublishAt.Equal(publishAt), ShouldBeTrue)
+				So(mockMongo.UpdateJobPublishAtCalls(), ShouldBeEmpty)
+				So(mockMongo.UpdateJobStateCalls(), ShouldBeEmpty)
+
+				So(mockMongo.CreateEventCalls(), ShouldHaveLength, 2)
+				So(mockMongo.CreateEventCalls()[0].Event.Action, ShouldEqual, domain.EventActionScheduleChanged)
+				So(mockMongo.CreateEventCalls()[1].Event.Action, ShouldEqual, domain.EventActionStateChanged)
+			})
+		})
+
+		Convey("When the job is approved with a publish time after it has left review", func() {
+			approveErr = appErrors.ErrStateUnexpected
+			publishAt := time.Now().Add(time.Hour)
+			err := jobService.UpdateJobState(context.Background(), testJobNumber, domain.StateApproved, "reviewer", "", &domain.JobStateOptions{PublishAt: &publishAt})
+
+			Convey("Then the error is returned without logging the schedule or the approval", func() {
+				So(err, ShouldEqual, appErrors.ErrStateUnexpected)
+				So(mockMongo.ApproveJobCalls(), ShouldHaveLength, 1)
+				So(mockMongo.CreateEventCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a job service and a job that is already publishing", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{ID: "test-job-id", JobNumber: jobNumber, State: domain.StatePublishing}, nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the job is scheduled", func() {
+			publishAt := time.Now().Add(time.Hour)
+			err := jobService.ScheduleJob(context.Background(), testJobNumber, &publishAt, "user-123")
+
+			Convey("Then a schedule not allowed error is returned", func() {
+				So(err, ShouldEqual, appErrors.ErrJobScheduleNotAllowed)
+				So(mockMongo.UpdateJobPublishAtCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a job service and a job that does not exist", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return nil, appErrors.ErrJobNotFound
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the job is scheduled", func() {
+			err := jobService.ScheduleJob(context.Background(), testJobNumber, nil, "user-123")
+
+			Convey("Then a job not found error is returned", func() {
+				So(err, ShouldEqual, appErrors.ErrJobNotFound)
+			})
+		})
+	})
+}
+
 func TestGetJobs(t *testing.T) {
 	Convey("Given a job service and store that has stored jobs", t, func() {
 		mockMongo := &storeMocks.MongoDBMock{
-			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 				jobs := []*domain.Job{
 					{ID: "job1", State: domain.StateSubmitted},
 					{ID: "job2", State: domain.StateApproved},
@@ -977,7 +1644,7 @@ func TestGetJobs(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -988,11 +1655,11 @@ func TestGetJobs(t *testing.T) {
 				domain.StateApproved,
 			}
 
-			jobs, total, err := jobService.GetJobs(ctx, "", "", states, 20, 5)
+			jobs, total, err := jobService.GetJobs(ctx, "", "", &domain.JobFilter{States: states}, 20, 5)
 
 			Convey("Then the store should be called with correct parameters", func() {
 				So(len(mockMongo.GetJobsCalls()), ShouldEqual, 1)
-				So(mockMongo.GetJobsCalls()[0].States, ShouldResemble, states)
+				So(mockMongo.GetJobsCalls()[0].Filter.States, ShouldResemble, states)
 				So(mockMongo.GetJobsCalls()[0].Limit, ShouldEqual, 20)
 				So(mockMongo.GetJobsCalls()[0].Offset, ShouldEqual, 5)
 
@@ -1010,12 +1677,12 @@ func TestGetJobs(t *testing.T) {
 			})
 		})
 
-		Convey("When GetJobs is called with nil states", func() {
+		Convey("When GetJobs is called with a nil filter", func() {
 			jobs, total, err := jobService.GetJobs(ctx, "", "", nil, 10, 0)
 
-			Convey("Then the store should be called with nil states", func() {
+			Convey("Then the store should be called with a nil filter", func() {
 				So(len(mockMongo.GetJobsCalls()), ShouldEqual, 1)
-				So(mockMongo.GetJobsCalls()[0].States, ShouldBeNil)
+				So(mockMongo.GetJobsCalls()[0].Filter, ShouldBeNil)
 				So(mockMongo.GetJobsCalls()[0].Limit, ShouldEqual, 10)
 				So(mockMongo.GetJobsCalls()[0].Offset, ShouldEqual, 0)
 
@@ -1032,11 +1699,11 @@ func TestGetJobs(t *testing.T) {
 
 		Convey("When GetJobs is called with empty states slice", func() {
 			emptyStates := []domain.State{}
-			jobs, total, err := jobService.GetJobs(ctx, "", "", emptyStates, 10, 0)
+			jobs, total, err := jobService.GetJobs(ctx, "", "", &domain.JobFilter{States: emptyStates}, 10, 0)
 
 			Convey("Then the store should be called with empty states", func() {
 				So(len(mockMongo.GetJobsCalls()), ShouldEqual, 1)
-				So(mockMongo.GetJobsCalls()[0].States, ShouldResemble, emptyStates)
+				So(mockMongo.GetJobsCalls()[0].Filter.States, ShouldResemble, emptyStates)
 
 				Convey("Then no error should be returned", func() {
 					So(err, ShouldBeNil)
@@ -1073,7 +1740,7 @@ func TestGetJobs(t *testing.T) {
 
 	Convey("Given a job service and store that returns an error when getting jobs", t, func() {
 		mockMongo := &storeMocks.MongoDBMock{
-			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 				return nil, 0, errors.New("fake error for testing")
 			},
 		}
@@ -1083,7 +1750,7 @@ func TestGetJobs(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1107,7 +1774,7 @@ func TestGetJobs(t *testing.T) {
 
 	Convey("Given a job service and store that returns no jobs", t, func() {
 		mockMongo := &storeMocks.MongoDBMock{
-			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 				return []*domain.Job{}, 0, nil
 			},
 		}
@@ -1117,7 +1784,7 @@ func TestGetJobs(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1141,6 +1808,48 @@ func TestGetJobs(t *testing.T) {
 	})
 }
 
+func TestGetJobsAfter(t *testing.T) {
+	Convey("Given a job service and store that has a final page of jobs after a cursor", t, func() {
+		mockJobs := []*domain.Job{
+			{ID: "job1", JobNumber: 2},
+			{ID: "job2", JobNumber: 1},
+		}
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobsAfterFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error) {
+				return mockJobs, nil, nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		Convey("When GetJobsAfter is called", func() {
+			filter := &domain.JobFilter{States: []domain.State{domain.StateSubmitted}}
+			after := &domain.Cursor{Value: "3", ID: "job0"}
+
+			jobs, next, err := jobService.GetJobsAfter(context.Background(), sort.SortParameterFieldJobNumber, sort.SortParameterDirectionDesc, filter, after, 10)
+
+			Convey("Then the store should be called with correct parameters", func() {
+				So(len(mockMongo.GetJobsAfterCalls()), ShouldEqual, 1)
+				So(mockMongo.GetJobsAfterCalls()[0].Field, ShouldEqual, sort.SortParameterFieldJobNumber)
+				So(mockMongo.GetJobsAfterCalls()[0].Direction, ShouldEqual, sort.SortParameterDirectionDesc)
+				So(mockMongo.GetJobsAfterCalls()[0].Filter, ShouldEqual, filter)
+				So(mockMongo.GetJobsAfterCalls()[0].After, ShouldEqual, after)
+				So(mockMongo.GetJobsAfterCalls()[0].Limit, ShouldEqual, 10)
+
+				Convey("And the jobs should be returned with no next cursor", func() {
+					So(err, ShouldBeNil)
+					So(jobs, ShouldResemble, mockJobs)
+					So(next, ShouldBeNil)
+				})
+			})
+		})
+	})
+}
+
 func TestGetJobStatesSummary(t *testing.T) {
 	Convey("Given a job service and store that returns a summary of job states", t, func() {
 		mockMongo := &storeMocks.MongoDBMock{
@@ -1298,6 +2007,7 @@ func TestCreateTask(t *testing.T) {
 				return &domain.Job{
 					JobNumber: jobNumber,
 					State:     domain.StateSubmitted,
+					Priority:  5,
 				}, nil
 			},
 			CreateTaskFunc: func(ctx context.Context, task *domain.Task) error {
@@ -1310,7 +2020,7 @@ func TestCreateTask(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1349,6 +2059,10 @@ func TestCreateTask(t *testing.T) {
 							So(createdTask.Type, ShouldEqual, domain.TaskTypeDatasetSeries)
 							So(createdTask.State, ShouldEqual, domain.StateSubmitted)
 						})
+
+						Convey("And the task should inherit the job's priority", func() {
+							So(createdTask.Priority, ShouldEqual, 5)
+						})
 					})
 				})
 			})
@@ -1367,7 +2081,7 @@ func TestCreateTask(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1417,7 +2131,7 @@ func TestCreateTask(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1460,6 +2174,9 @@ func TestUpdateTaskState(t *testing.T) {
 			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, lastUpdated time.Time) error {
 				return nil
 			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
 		}
 
 		mockStore := store.Datastore{
@@ -1467,14 +2184,14 @@ func TestUpdateTaskState(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 		newState := domain.StateMigrating
 
 		Convey("When a task state is updated", func() {
-			err := jobService.UpdateTaskState(ctx, fakeTask.ID, newState)
+			err := jobService.UpdateTaskState(ctx, fakeTask.ID, newState, "")
 
 			Convey("Then the store should be called to update the task state", func() {
 				So(len(mockMongo.UpdateTaskStateCalls()), ShouldEqual, 1)
@@ -1489,10 +2206,67 @@ func TestUpdateTaskState(t *testing.T) {
 		})
 	})
 
-	Convey("Given a job service and store that returns an error when getting a task", t, func() {
+	Convey("Given a job service and a task that is migrating", t, func() {
+		fakeTask := &domain.Task{
+			ID:        "task-123",
+			JobNumber: testJobNumber,
+			State:     domain.StateMigrating,
+		}
+
 		mockMongo := &storeMocks.MongoDBMock{
 			GetTaskFunc: func(ctx context.Context, taskID string) (*domain.Task, error) {
-				return nil, fmt.Errorf("fake error for testing")
+				return fakeTask, nil
+			},
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, lastUpdated time.Time) error {
+				return nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+			GetDependentTasksFunc: func(ctx context.Context, taskID string) ([]*domain.Task, error) {
+				return nil, nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		Convey("When the task is failed with a reason", func() {
+			err := jobService.UpdateTaskState(context.Background(), fakeTask.ID, domain.StateFailedMigration, "execution_failed: timeout")
+
+			Convey("Then a task event is logged with the transition and reason", func() {
+				So(err, ShouldBeNil)
+				So(len(mockMongo.CreateEventCalls()), ShouldEqual, 1)
+				event := mockMongo.CreateEventCalls()[0].Event
+				So(event.JobNumber, ShouldEqual, testJobNumber)
+				So(event.TaskID, ShouldEqual, fakeTask.ID)
+				So(event.FromState, ShouldEqual, domain.StateMigrating)
+				So(event.ToState, ShouldEqual, domain.StateFailedMigration)
+				So(event.RequestedBy.ID, ShouldEqual, domain.SystemUserID)
+				So(event.Reason, ShouldEqual, "execution_failed: timeout")
+			})
+		})
+
+		Convey("When the event cannot be stored", func() {
+			mockMongo.CreateEventFunc = func(ctx context.Context, event *domain.Event) error {
+				return errors.New("fake error for testing")
+			}
+
+			err := jobService.UpdateTaskState(context.Background(), fakeTask.ID, domain.StateInReview, "")
+
+			Convey("Then the task state is still updated without error", func() {
+				So(err, ShouldBeNil)
+				So(len(mockMongo.UpdateTaskStateCalls()), ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a job service and store that returns an error when getting a task", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetTaskFunc: func(ctx context.Context, taskID string) (*domain.Task, error) {
+				return nil, fmt.Errorf("fake error for testing")
 			},
 		}
 
@@ -1501,7 +2275,7 @@ func TestUpdateTaskState(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1509,7 +2283,7 @@ func TestUpdateTaskState(t *testing.T) {
 		newState := domain.StateCompleted
 
 		Convey("When a task state is updated", func() {
-			err := jobService.UpdateTaskState(ctx, taskID, newState)
+			err := jobService.UpdateTaskState(ctx, taskID, newState, "")
 
 			Convey("Then an error should be returned", func() {
 				So(len(mockMongo.GetTaskCalls()), ShouldEqual, 1)
@@ -1536,14 +2310,14 @@ func TestUpdateTaskState(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 		invalidNewState := domain.StateSubmitted
 
 		Convey("When attempting an invalid state transition", func() {
-			err := jobService.UpdateTaskState(ctx, fakeTask.ID, invalidNewState)
+			err := jobService.UpdateTaskState(ctx, fakeTask.ID, invalidNewState, "")
 
 			Convey("Then an error should be returned", func() {
 				So(len(mockMongo.GetTaskCalls()), ShouldEqual, 1)
@@ -1574,14 +2348,14 @@ func TestUpdateTaskState(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 		newState := domain.StateMigrating
 
 		Convey("When a task state is updated", func() {
-			err := jobService.UpdateTaskState(ctx, fakeTask.ID, newState)
+			err := jobService.UpdateTaskState(ctx, fakeTask.ID, newState, "")
 
 			Convey("Then an error should be returned", func() {
 				So(len(mockMongo.UpdateTaskStateCalls()), ShouldEqual, 1)
@@ -1594,21 +2368,19 @@ func TestUpdateTaskState(t *testing.T) {
 
 func TestUpdateJobCollectionID(t *testing.T) {
 	Convey("Given a job service and store with an existing job", t, func() {
-		oldTime := time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC)
 		fakeJob := &domain.Job{
 			ID:        "test-job-id",
 			JobNumber: testJobNumber,
 			Config: &domain.JobConfig{
 				CollectionID: "old-collection-id",
 			},
-			LastUpdated: oldTime,
 		}
 
 		mockMongo := &storeMocks.MongoDBMock{
 			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 				return fakeJob, nil
 			},
-			UpdateJobFunc: func(ctx context.Context, job *domain.Job) error {
+			UpdateJobCollectionIDFunc: func(ctx context.Context, jobID, collectionID string) error {
 				return nil
 			},
 		}
@@ -1618,7 +2390,7 @@ func TestUpdateJobCollectionID(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1627,10 +2399,11 @@ func TestUpdateJobCollectionID(t *testing.T) {
 		Convey("When UpdateJobCollectionID is called", func() {
 			err := jobService.UpdateJobCollectionID(ctx, fakeJob.JobNumber, newCollectionID)
 
-			Convey("Then the store should be called to update the job", func() {
-				So(len(mockMongo.UpdateJobCalls()), ShouldEqual, 1)
-				So(mockMongo.UpdateJobCalls()[0].Job.Config.CollectionID, ShouldEqual, newCollectionID)
-				So(mockMongo.UpdateJobCalls()[0].Job.LastUpdated.After(oldTime), ShouldBeTrue)
+			Convey("Then the store should be called to set only the job's collection ID", func() {
+				So(len(mockMongo.UpdateJobCollectionIDCalls()), ShouldEqual, 1)
+				So(mockMongo.UpdateJobCollectionIDCalls()[0].JobID, ShouldEqual, fakeJob.ID)
+				So(mockMongo.UpdateJobCollectionIDCalls()[0].CollectionID, ShouldEqual, newCollectionID)
+				So(mockMongo.UpdateJobCalls(), ShouldBeEmpty)
 
 				Convey("And no error should be returned", func() {
 					So(err, ShouldBeNil)
@@ -1651,7 +2424,7 @@ func TestUpdateJobCollectionID(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1661,7 +2434,7 @@ func TestUpdateJobCollectionID(t *testing.T) {
 
 			Convey("Then the error should be returned and no update performed", func() {
 				So(err, ShouldEqual, appErrors.ErrJobNotFound)
-				So(len(mockMongo.UpdateJobCalls()), ShouldEqual, 0)
+				So(len(mockMongo.UpdateJobCollectionIDCalls()), ShouldEqual, 0)
 			})
 		})
 	})
@@ -1679,7 +2452,7 @@ func TestUpdateJobCollectionID(t *testing.T) {
 			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 				return fakeJob, nil
 			},
-			UpdateJobFunc: func(ctx context.Context, job *domain.Job) error {
+			UpdateJobCollectionIDFunc: func(ctx context.Context, jobID, collectionID string) error {
 				return fmt.Errorf("fake error for testing")
 			},
 		}
@@ -1689,7 +2462,7 @@ func TestUpdateJobCollectionID(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1698,7 +2471,7 @@ func TestUpdateJobCollectionID(t *testing.T) {
 			err := jobService.UpdateJobCollectionID(ctx, testJobNumber, "new-collection-id")
 
 			Convey("Then an error should be returned", func() {
-				So(len(mockMongo.UpdateJobCalls()), ShouldEqual, 1)
+				So(len(mockMongo.UpdateJobCollectionIDCalls()), ShouldEqual, 1)
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldContainSubstring, "failed to update job collection ID")
 			})
@@ -1706,6 +2479,74 @@ func TestUpdateJobCollectionID(t *testing.T) {
 	})
 }
 
+func TestUpdateJobNotificationThread(t *testing.T) {
+	thread := &domain.NotificationThread{Channel: "C123", Timestamp: "1700000000.000100"}
+
+	Convey("Given a job service and store with an existing job", t, func() {
+		fakeJob := &domain.Job{
+			ID:        "test-job-id",
+			JobNumber: testJobNumber,
+		}
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return fakeJob, nil
+			},
+			UpdateJobNotificationThreadFunc: func(ctx context.Context, jobID string, thread *domain.NotificationThread) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When UpdateJobNotificationThread is called", func() {
+			err := jobService.UpdateJobNotificationThread(ctx, testJobNumber, thread)
+
+			Convey("Then the store should be called to update the job's thread", func() {
+				So(err, ShouldBeNil)
+				So(len(mockMongo.UpdateJobNotificationThreadCalls()), ShouldEqual, 1)
+				So(mockMongo.UpdateJobNotificationThreadCalls()[0].JobID, ShouldEqual, fakeJob.ID)
+				So(mockMongo.UpdateJobNotificationThreadCalls()[0].Thread, ShouldEqual, thread)
+			})
+		})
+	})
+
+	Convey("Given a job service and store that returns an error when getting a job", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return nil, appErrors.ErrJobNotFound
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When UpdateJobNotificationThread is called", func() {
+			err := jobService.UpdateJobNotificationThread(ctx, testJobNumber, thread)
+
+			Convey("Then the error should be returned and no update performed", func() {
+				So(err, ShouldEqual, appErrors.ErrJobNotFound)
+				So(len(mockMongo.UpdateJobNotificationThreadCalls()), ShouldEqual, 0)
+			})
+		})
+	})
+}
+
 func TestGetJobTasks(t *testing.T) {
 	Convey("Given a job service and store that has stored tasks for a job", t, func() {
 		mockMongo := &storeMocks.MongoDBMock{
@@ -1758,7 +2599,7 @@ func TestGetJobTasks(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1854,7 +2695,7 @@ func TestGetJobTasks(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1891,7 +2732,7 @@ func TestGetJobTasks(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1913,6 +2754,143 @@ func TestGetJobTasks(t *testing.T) {
 	})
 }
 
+func TestGetJobTasksAfter(t *testing.T) {
+	Convey("Given a job service and store that has a page of tasks after a cursor", t, func() {
+		nextCursor := &domain.Cursor{ID: "task2"}
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobTasksAfterFunc: func(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error) {
+				return []*domain.Task{
+					{ID: "task1", JobNumber: testJobNumber},
+					{ID: "task2", JobNumber: testJobNumber},
+				}, nextCursor, nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When GetJobTasksAfter is called with a cursor", func() {
+			states := []domain.State{domain.StateMigrating}
+			after := &domain.Cursor{ID: "task0"}
+
+			tasks, next, err := jobService.GetJobTasksAfter(ctx, states, testJobNumber, after, 2)
+
+			Convey("Then the store should be called with correct parameters", func() {
+				So(len(mockMongo.GetJobTasksAfterCalls()), ShouldEqual, 1)
+				So(mockMongo.GetJobTasksAfterCalls()[0].States, ShouldResemble, states)
+				So(mockMongo.GetJobTasksAfterCalls()[0].JobNumber, ShouldEqual, testJobNumber)
+				So(mockMongo.GetJobTasksAfterCalls()[0].After, ShouldEqual, after)
+				So(mockMongo.GetJobTasksAfterCalls()[0].Limit, ShouldEqual, 2)
+
+				Convey("And the tasks and next cursor should be returned", func() {
+					So(err, ShouldBeNil)
+					So(len(tasks), ShouldEqual, 2)
+					So(next, ShouldEqual, nextCursor)
+				})
+			})
+		})
+	})
+
+	Convey("Given a job service and store that returns an error", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobTasksAfterFunc: func(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error) {
+				return nil, nil, appErrors.ErrInternalServerError
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		Convey("When GetJobTasksAfter is called", func() {
+			tasks, next, err := jobService.GetJobTasksAfter(context.Background(), nil, testJobNumber, &domain.Cursor{}, 10)
+
+			Convey("Then the error should be returned", func() {
+				So(err, ShouldEqual, appErrors.ErrInternalServerError)
+				So(tasks, ShouldBeNil)
+				So(next, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestGetJobTaskTree(t *testing.T) {
+	Convey("Given a job service and store that returns a job's tasks over two pages", t, func() {
+		series := &domain.Task{ID: "series", JobNumber: testJobNumber, State: domain.StateInReview}
+		edition := &domain.Task{ID: "edition", JobNumber: testJobNumber, State: domain.StateMigrating}
+		edition.SetParent(series)
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobTasksAfterFunc: func(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error) {
+				if after.IsStart() {
+					return []*domain.Task{edition}, &domain.Cursor{ID: edition.ID}, nil
+				}
+				return []*domain.Task{series}, nil, nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		Convey("When GetJobTaskTree is called", func() {
+			nodes, totalCount, err := jobService.GetJobTaskTree(context.Background(), testJobNumber)
+
+			Convey("Then every page of tasks is read from the store", func() {
+				So(err, ShouldBeNil)
+				So(len(mockMongo.GetJobTasksAfterCalls()), ShouldEqual, 2)
+				So(mockMongo.GetJobTasksAfterCalls()[0].JobNumber, ShouldEqual, testJobNumber)
+				So(mockMongo.GetJobTasksAfterCalls()[0].States, ShouldBeNil)
+				So(mockMongo.GetJobTasksAfterCalls()[1].After.ID, ShouldEqual, edition.ID)
+
+				Convey("And the tasks are returned as a tree with rolled up states", func() {
+					So(totalCount, ShouldEqual, 2)
+					So(len(nodes), ShouldEqual, 1)
+					So(nodes[0].Task, ShouldEqual, series)
+					So(nodes[0].RolledUpState, ShouldEqual, domain.StateMigrating)
+					So(len(nodes[0].Children), ShouldEqual, 1)
+					So(nodes[0].Children[0].Task, ShouldEqual, edition)
+				})
+			})
+		})
+	})
+
+	Convey("Given a job service and store that returns an error", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobTasksAfterFunc: func(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error) {
+				return nil, nil, appErrors.ErrInternalServerError
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		Convey("When GetJobTaskTree is called", func() {
+			nodes, totalCount, err := jobService.GetJobTaskTree(context.Background(), testJobNumber)
+
+			Convey("Then the error is returned", func() {
+				So(err, ShouldEqual, appErrors.ErrInternalServerError)
+				So(nodes, ShouldBeNil)
+				So(totalCount, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
 func TestCountTasksByJobNumber(t *testing.T) {
 	Convey("Given a job service and store that has tasks for a job", t, func() {
 		mockMongo := &storeMocks.MongoDBMock{
@@ -1926,7 +2904,7 @@ func TestCountTasksByJobNumber(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1962,7 +2940,7 @@ func TestCountTasksByJobNumber(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -1997,7 +2975,7 @@ func TestCountTasksByJobNumber(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -2037,7 +3015,7 @@ func TestCreateEvent(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -2045,12 +3023,11 @@ func TestCreateEvent(t *testing.T) {
 
 		Convey("When an event is created", func() {
 			createdAtTime := time.Now().UTC()
-			createdAtStr := createdAtTime.Format(time.RFC3339)
 
 			event := &domain.Event{
 				ID:        "event-123",
-				Action:    "approved",
-				CreatedAt: createdAtStr,
+				Action:    domain.EventActionStateChanged,
+				CreatedAt: createdAtTime,
 				RequestedBy: &domain.User{
 					ID:    "user-123",
 					Email: "publisher@ons.gov.uk",
@@ -2072,7 +3049,7 @@ func TestCreateEvent(t *testing.T) {
 						Convey("And the event should be returned with job ID set", func() {
 							So(createdEvent, ShouldNotBeNil)
 							So(createdEvent.ID, ShouldEqual, "event-123")
-							So(createdEvent.Action, ShouldEqual, string(domain.StateApproved))
+							So(createdEvent.Action, ShouldEqual, domain.EventActionStateChanged)
 							So(createdEvent.RequestedBy.ID, ShouldEqual, "user-123")
 							So(createdEvent.RequestedBy.Email, ShouldEqual, "publisher@ons.gov.uk")
 						})
@@ -2094,7 +3071,7 @@ func TestCreateEvent(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -2103,7 +3080,7 @@ func TestCreateEvent(t *testing.T) {
 		Convey("When an event is created for that job", func() {
 			event := &domain.Event{
 				ID:     "event-123",
-				Action: "approved",
+				Action: domain.EventActionStateChanged,
 				RequestedBy: &domain.User{
 					ID:    "user-123",
 					Email: "publisher@ons.gov.uk",
@@ -2142,7 +3119,7 @@ func TestCreateEvent(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -2151,7 +3128,7 @@ func TestCreateEvent(t *testing.T) {
 		Convey("When an event is created", func() {
 			event := &domain.Event{
 				ID:     "event-123",
-				Action: "approved",
+				Action: domain.EventActionStateChanged,
 				RequestedBy: &domain.User{
 					ID:    "user-123",
 					Email: "publisher@ons.gov.uk",
@@ -2185,7 +3162,7 @@ func TestCreateEvent(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -2194,7 +3171,7 @@ func TestCreateEvent(t *testing.T) {
 		Convey("When an event is created without an email", func() {
 			event := &domain.Event{
 				ID:     "event-123",
-				Action: "migrating",
+				Action: domain.EventActionStateChanged,
 				RequestedBy: &domain.User{
 					ID: "user-456",
 				},
@@ -2216,16 +3193,15 @@ func TestCreateEvent(t *testing.T) {
 
 	Convey("Given a job service and store with different action states", t, func() {
 		testCases := []struct {
-			action string
+			action domain.EventAction
 			name   string
 		}{
-			{"submitted", "submitted"},
-			{"approved", "approved"},
-			{"migrating", "migrating"},
-			{"publishing", "publishing"},
-			{"completed", "completed"},
-			{"failed_migration", "failed_migration"},
-			{"cancelled", "cancelled"},
+			{domain.EventActionJobCreated, "job_created"},
+			{domain.EventActionStateChanged, "state_changed"},
+			{domain.EventActionTaskStateChanged, "task_state_changed"},
+			{domain.EventActionTaskFailed, "task_failed"},
+			{domain.EventActionRetryRequested, "retry_requested"},
+			{domain.EventActionCommentAdded, "comment_added"},
 		}
 
 		for _, tc := range testCases {
@@ -2250,7 +3226,7 @@ func TestCreateEvent(t *testing.T) {
 				}
 
 				mockClients := clients.ClientList{}
-				cfg := &config.Config{EnableEventLogging: false}
+				cfg := &config.Config{}
 				jobService := Setup(&mockStore, &mockClients, cfg)
 
 				ctx := context.Background()
@@ -2259,7 +3235,7 @@ func TestCreateEvent(t *testing.T) {
 				event := &domain.Event{
 					ID:        fmt.Sprintf("event-%s", testCase.name),
 					Action:    testCase.action, // Use the correct action from test case
-					CreatedAt: time.Now().UTC().Format(time.RFC3339),
+					CreatedAt: time.Now().UTC(),
 					RequestedBy: &domain.User{
 						ID:    "user-123",
 						Email: "publisher@ons.gov.uk",
@@ -2270,7 +3246,7 @@ func TestCreateEvent(t *testing.T) {
 
 				Convey("Then the event should be created with the correct action", func() {
 					So(err, ShouldBeNil)
-					So(createdEvent.Action, ShouldEqual, testCase.action) // Compare with string directly
+					So(createdEvent.Action, ShouldEqual, testCase.action)
 				})
 			})
 		}
@@ -2287,8 +3263,8 @@ func TestGetJobEvents(t *testing.T) {
 				{
 					ID:        "event-1",
 					JobNumber: jobNumber,
-					CreatedAt: "2025-11-19T13:30:00Z",
-					Action:    "submitted",
+					CreatedAt: time.Date(2025, 11, 19, 13, 30, 0, 0, time.UTC),
+					Action:    domain.EventActionJobCreated,
 					RequestedBy: &domain.User{
 						ID:    "user-1",
 						Email: "user1@ons.gov.uk",
@@ -2297,8 +3273,8 @@ func TestGetJobEvents(t *testing.T) {
 				{
 					ID:        "event-2",
 					JobNumber: jobNumber,
-					CreatedAt: "2025-11-19T13:35:00Z",
-					Action:    "approved",
+					CreatedAt: time.Date(2025, 11, 19, 13, 35, 0, 0, time.UTC),
+					Action:    domain.EventActionStateChanged,
 					RequestedBy: &domain.User{
 						ID:    "user-2",
 						Email: "user2@ons.gov.uk",
@@ -2307,17 +3283,17 @@ func TestGetJobEvents(t *testing.T) {
 			}
 
 			mockMongo := &storeMocks.MongoDBMock{
-				GetJobEventsFunc: func(ctx context.Context, jobNumber int, limit, offset int) ([]*domain.Event, int, error) {
+				GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
 					return mockEvents, len(mockEvents), nil
 				},
 			}
 
 			mockStore := store.Datastore{Backend: mockMongo}
 			mockClients := clients.ClientList{}
-			cfg := &config.Config{EnableEventLogging: false}
+			cfg := &config.Config{}
 			jobService := Setup(&mockStore, &mockClients, cfg)
 
-			events, total, err := jobService.GetJobEvents(ctx, jobNumber, 10, 0)
+			events, total, err := jobService.GetJobEvents(ctx, jobNumber, nil, 10, 0)
 
 			Convey("Then the store GetJobEvents method should be called with correct parameters", func() {
 				So(len(mockMongo.GetJobEventsCalls()), ShouldEqual, 1)
@@ -2342,17 +3318,17 @@ func TestGetJobEvents(t *testing.T) {
 			customOffset := 10
 
 			mockMongo := &storeMocks.MongoDBMock{
-				GetJobEventsFunc: func(ctx context.Context, jobNumber int, limit, offset int) ([]*domain.Event, int, error) {
+				GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
 					return []*domain.Event{{ID: "event-1", JobNumber: jobNumber}}, 20, nil
 				},
 			}
 
 			mockStore := store.Datastore{Backend: mockMongo}
 			mockClients := clients.ClientList{}
-			cfg := &config.Config{EnableEventLogging: false}
+			cfg := &config.Config{}
 			jobService := Setup(&mockStore, &mockClients, cfg)
 
-			events, total, err := jobService.GetJobEvents(ctx, jobNumber, customLimit, customOffset)
+			events, total, err := jobService.GetJobEvents(ctx, jobNumber, nil, customLimit, customOffset)
 
 			Convey("Then the store should be called with the custom pagination parameters", func() {
 				So(len(mockMongo.GetJobEventsCalls()), ShouldEqual, 1)
@@ -2375,17 +3351,17 @@ func TestGetJobEvents(t *testing.T) {
 			expectedErr := errors.New("database connection failed")
 
 			mockMongo := &storeMocks.MongoDBMock{
-				GetJobEventsFunc: func(ctx context.Context, jobNumber int, limit, offset int) ([]*domain.Event, int, error) {
+				GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
 					return nil, 0, expectedErr
 				},
 			}
 
 			mockStore := store.Datastore{Backend: mockMongo}
 			mockClients := clients.ClientList{}
-			cfg := &config.Config{EnableEventLogging: false}
+			cfg := &config.Config{}
 			jobService := Setup(&mockStore, &mockClients, cfg)
 
-			events, total, err := jobService.GetJobEvents(ctx, jobNumber, 10, 0)
+			events, total, err := jobService.GetJobEvents(ctx, jobNumber, nil, 10, 0)
 
 			Convey("Then the store should be called", func() {
 				So(len(mockMongo.GetJobEventsCalls()), ShouldEqual, 1)
@@ -2400,17 +3376,17 @@ func TestGetJobEvents(t *testing.T) {
 
 		Convey("When the store returns an empty list", func() {
 			mockMongo := &storeMocks.MongoDBMock{
-				GetJobEventsFunc: func(ctx context.Context, jobNumber int, limit, offset int) ([]*domain.Event, int, error) {
+				GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
 					return []*domain.Event{}, 0, nil
 				},
 			}
 
 			mockStore := store.Datastore{Backend: mockMongo}
 			mockClients := clients.ClientList{}
-			cfg := &config.Config{EnableEventLogging: false}
+			cfg := &config.Config{}
 			jobService := Setup(&mockStore, &mockClients, cfg)
 
-			events, total, err := jobService.GetJobEvents(ctx, jobNumber, 10, 0)
+			events, total, err := jobService.GetJobEvents(ctx, jobNumber, nil, 10, 0)
 
 			Convey("Then the store should be called", func() {
 				So(len(mockMongo.GetJobEventsCalls()), ShouldEqual, 1)
@@ -2428,6 +3404,45 @@ func TestGetJobEvents(t *testing.T) {
 	})
 }
 
+func TestGetJobEventsAfter(t *testing.T) {
+	Convey("Given a job service and store that has a page of events after a cursor", t, func() {
+		mockEvents := []*domain.Event{
+			{ID: "event-2", JobNumber: testJobNumber, CreatedAt: time.Date(2025, 11, 19, 13, 35, 0, 0, time.UTC)},
+			{ID: "event-1", JobNumber: testJobNumber, CreatedAt: time.Date(2025, 11, 19, 13, 30, 0, 0, time.UTC)},
+		}
+		nextCursor := &domain.Cursor{Value: "2025-11-19T13:30:00Z", ID: "event-1"}
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobEventsAfterFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error) {
+				return mockEvents, nextCursor, nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		Convey("When GetJobEventsAfter is called from the start", func() {
+			after := &domain.Cursor{}
+			events, next, err := jobService.GetJobEventsAfter(context.Background(), testJobNumber, nil, after, 2)
+
+			Convey("Then the store should be called with correct parameters", func() {
+				So(len(mockMongo.GetJobEventsAfterCalls()), ShouldEqual, 1)
+				So(mockMongo.GetJobEventsAfterCalls()[0].JobNumber, ShouldEqual, testJobNumber)
+				So(mockMongo.GetJobEventsAfterCalls()[0].After, ShouldEqual, after)
+				So(mockMongo.GetJobEventsAfterCalls()[0].Limit, ShouldEqual, 2)
+
+				Convey("And the events and next cursor should be returned", func() {
+					So(err, ShouldBeNil)
+					So(events, ShouldResemble, mockEvents)
+					So(next, ShouldEqual, nextCursor)
+				})
+			})
+		})
+	})
+}
+
 func TestCountEventsByJobNumber(t *testing.T) {
 	Convey("Given a job service and store that has events for a job", t, func() {
 		mockMongo := &storeMocks.MongoDBMock{
@@ -2441,7 +3456,7 @@ func TestCountEventsByJobNumber(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -2478,7 +3493,7 @@ func TestCountEventsByJobNumber(t *testing.T) {
 
 		mockClients := clients.ClientList{}
 
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -2514,7 +3529,7 @@ func TestCountEventsByJobNumber(t *testing.T) {
 
 		mockClients := clients.ClientList{}
 
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -2547,7 +3562,7 @@ func TestCountEventsByJobNumber(t *testing.T) {
 
 		mockClients := clients.ClientList{}
 
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -2582,7 +3597,7 @@ func TestCountEventsByJobNumber(t *testing.T) {
 
 		mockClients := clients.ClientList{}
 
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -2607,7 +3622,7 @@ func TestClaimJob(t *testing.T) {
 			ClaimJobFunc: func(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Job, error) {
 				return nil, nil
 			},
-			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 				return nil, 0, nil
 			},
 		}
@@ -2617,7 +3632,7 @@ func TestClaimJob(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -2656,6 +3671,9 @@ func TestClaimJob(t *testing.T) {
 			ClaimJobFunc: func(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Job, error) {
 				return claimedJob, nil
 			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
 		}
 
 		mockStore := store.Datastore{
@@ -2663,7 +3681,7 @@ func TestClaimJob(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
@@ -2709,13 +3727,13 @@ func TestClaimJob(t *testing.T) {
 
 		mockStore := store.Datastore{Backend: mockMongo}
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: true}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 
 		Convey("When the user requests rejected state", func() {
-			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, domain.StateRejected, "user-123")
+			err := jobService.UpdateJobState(ctx, fakeJob.JobNumber, domain.StateRejected, "user-123", "", nil)
 
 			Convey("Then the job should be transitioned directly to rejected", func() {
 				So(err, ShouldBeNil)
@@ -2730,44 +3748,218 @@ func TestClaimJob(t *testing.T) {
 	})
 }
 
-func TestClaimTask(t *testing.T) {
-	Convey("Given a job service and store with no tasks to be claimed", t, func() {
+func TestClaimJobEventLogging(t *testing.T) {
+	Convey("Given a job service and a store with an approved job to be claimed", t, func() {
+		claimedJob := &domain.Job{
+			ID:        "job-123",
+			JobNumber: testJobNumber,
+			State:     domain.StatePublishing,
+		}
+
 		mockMongo := &storeMocks.MongoDBMock{
-			ClaimTaskFunc: func(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Task, error) {
+			ClaimJobFunc: func(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Job, error) {
+				if pendingState == domain.StateApproved {
+					return claimedJob, nil
+				}
 				return nil, nil
 			},
-			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
-				return nil, 0, nil
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
 			},
 		}
 
-		mockStore := store.Datastore{
-			Backend: mockMongo,
-		}
-
+		mockStore := store.Datastore{Backend: mockMongo}
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
-		ctx := context.Background()
-
-		Convey("When a task tries to be claimed", func() {
-			task, err := jobService.ClaimTask(ctx)
-
-			Convey("Then the store should be called to claim a task", func() {
-				So(len(mockMongo.ClaimTaskCalls()), ShouldEqual, 4)
-				So(len(mockMongo.GetJobsCalls()), ShouldEqual, 0)
-				So(mockMongo.ClaimTaskCalls()[0].PendingState, ShouldEqual, domain.StateSubmitted)
-				So(mockMongo.ClaimTaskCalls()[0].ActiveState, ShouldEqual, domain.StateMigrating)
-				So(mockMongo.ClaimTaskCalls()[1].PendingState, ShouldEqual, domain.StateApproved)
-				So(mockMongo.ClaimTaskCalls()[1].ActiveState, ShouldEqual, domain.StatePublishing)
-				So(mockMongo.ClaimTaskCalls()[2].PendingState, ShouldEqual, domain.StatePendingPostPublish)
-				So(mockMongo.ClaimTaskCalls()[2].ActiveState, ShouldEqual, domain.StatePostPublishing)
-				So(mockMongo.ClaimTaskCalls()[3].PendingState, ShouldEqual, domain.StateRejected)
-				So(mockMongo.ClaimTaskCalls()[3].ActiveState, ShouldEqual, domain.StateReverting)
-			})
+		Convey("When a job is claimed", func() {
+			job, err := jobService.ClaimJob(context.Background())
 
-			Convey("And no error should be returned", func() {
+			Convey("Then a system event is logged for the claim", func() {
+				So(err, ShouldBeNil)
+				So(job, ShouldEqual, claimedJob)
+				So(len(mockMongo.CreateEventCalls()), ShouldEqual, 1)
+				event := mockMongo.CreateEventCalls()[0].Event
+				So(event.JobNumber, ShouldEqual, testJobNumber)
+				So(event.FromState, ShouldEqual, domain.StateApproved)
+				So(event.ToState, ShouldEqual, domain.StatePublishing)
+				So(event.RequestedBy.ID, ShouldEqual, domain.SystemUserID)
+			})
+		})
+	})
+}
+
+func TestUpdateTaskStateCascadesToDependants(t *testing.T) {
+	Convey("Given a job service and a migrating task with dependants", t, func() {
+		tasks := map[string]*domain.Task{
+			"task-1": {ID: "task-1", JobNumber: testJobNumber, State: domain.StateMigrating},
+			"task-2": {ID: "task-2", JobNumber: testJobNumber, State: domain.StateSubmitted, DependsOn: []string{"task-1"}},
+			"task-3": {ID: "task-3", JobNumber: testJobNumber, State: domain.StateSubmitted, DependsOn: []string{"task-2"}},
+			"task-4": {ID: "task-4", JobNumber: testJobNumber, State: domain.StateInReview, DependsOn: []string{"task-1"}},
+		}
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetTaskFunc: func(ctx context.Context, taskID string) (*domain.Task, error) {
+				return tasks[taskID], nil
+			},
+			GetDependentTasksFunc: func(ctx context.Context, taskID string) ([]*domain.Task, error) {
+				var dependants []*domain.Task
+				for _, id := range []string{"task-2", "task-3", "task-4"} {
+					if tasks[id].DependsOn[0] == taskID {
+						dependants = append(dependants, tasks[id])
+					}
+				}
+				return dependants, nil
+			},
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, lastUpdated time.Time) error {
+				return nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		ctx := context.Background()
+
+		Convey("When the task fails", func() {
+			err := jobService.UpdateTaskState(ctx, "task-1", domain.StateFailedMigration, "execution_failed: timeout")
+			So(err, ShouldBeNil)
+
+			Convey("Then the dependants still waiting for it, and theirs, fail too", func() {
+				So(mockMongo.UpdateTaskStateCalls(), ShouldHaveLength, 3)
+				So(mockMongo.UpdateTaskStateCalls()[1].TaskID, ShouldEqual, "task-2")
+				So(mockMongo.UpdateTaskStateCalls()[1].NewState, ShouldEqual, domain.StateFailedMigration)
+				So(mockMongo.UpdateTaskStateCalls()[2].TaskID, ShouldEqual, "task-3")
+				So(mockMongo.UpdateTaskStateCalls()[2].NewState, ShouldEqual, domain.StateFailedMigration)
+
+				Convey("And each dependant is read so that its transition is validated", func() {
+					So(mockMongo.GetTaskCalls(), ShouldHaveLength, 3)
+					So(mockMongo.GetTaskCalls()[1].TaskID, ShouldEqual, "task-2")
+					So(mockMongo.GetTaskCalls()[2].TaskID, ShouldEqual, "task-3")
+				})
+			})
+
+			Convey("And an event is logged for each dependant with the reason", func() {
+				So(mockMongo.CreateEventCalls(), ShouldHaveLength, 3)
+				event := mockMongo.CreateEventCalls()[1].Event
+				So(event.TaskID, ShouldEqual, "task-2")
+				So(event.FromState, ShouldEqual, domain.StateSubmitted)
+				So(event.ToState, ShouldEqual, domain.StateFailedMigration)
+				So(event.Reason, ShouldEqual, "dependency task-1 moved to failed_migration")
+			})
+		})
+
+		Convey("When the task finishes migrating", func() {
+			err := jobService.UpdateTaskState(ctx, "task-1", domain.StateInReview, "")
+			So(err, ShouldBeNil)
+
+			Convey("Then its dependants are left to be claimed", func() {
+				So(mockMongo.GetDependentTasksCalls(), ShouldBeEmpty)
+				So(mockMongo.UpdateTaskStateCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a task is cancelled before it starts", func() {
+			err := jobService.UpdateTaskState(ctx, "task-2", domain.StateCancelled, "")
+			So(err, ShouldBeNil)
+
+			Convey("Then its dependants are cancelled too", func() {
+				So(mockMongo.UpdateTaskStateCalls(), ShouldHaveLength, 2)
+				So(mockMongo.UpdateTaskStateCalls()[1].TaskID, ShouldEqual, "task-3")
+				So(mockMongo.UpdateTaskStateCalls()[1].NewState, ShouldEqual, domain.StateCancelled)
+			})
+		})
+	})
+}
+
+func TestClaimTaskEventLogging(t *testing.T) {
+	Convey("Given a job service and a store with a submitted task to be claimed", t, func() {
+		claimedTask := &domain.Task{
+			ID:        "task-123",
+			JobNumber: testJobNumber,
+			State:     domain.StateMigrating,
+		}
+
+		mockMongo := &storeMocks.MongoDBMock{
+			ClaimTaskFunc: func(ctx context.Context, pendingState domain.State, activeState domain.State, dependencyState domain.State, afterJobNumber int, excludedTypes []domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
+				return claimedTask, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		Convey("When a task is claimed", func() {
+			task, err := jobService.ClaimTask(context.Background(), nil, nil)
+
+			Convey("Then a system task event is logged for the claim", func() {
+				So(err, ShouldBeNil)
+				So(task, ShouldEqual, claimedTask)
+				So(len(mockMongo.CreateEventCalls()), ShouldEqual, 1)
+				event := mockMongo.CreateEventCalls()[0].Event
+				So(event.TaskID, ShouldEqual, claimedTask.ID)
+				So(event.FromState, ShouldEqual, domain.StateSubmitted)
+				So(event.ToState, ShouldEqual, domain.StateMigrating)
+				So(event.RequestedBy.ID, ShouldEqual, domain.SystemUserID)
+			})
+		})
+	})
+}
+
+func TestClaimTask(t *testing.T) {
+	Convey("Given a job service and store with no tasks to be claimed", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			ClaimTaskFunc: func(ctx context.Context, pendingState domain.State, activeState domain.State, dependencyState domain.State, afterJobNumber int, excludedTypes []domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
+				return nil, nil
+			},
+			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
+				return nil, 0, nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When a task tries to be claimed", func() {
+			task, err := jobService.ClaimTask(ctx, nil, nil)
+
+			Convey("Then the store should be called to claim a task", func() {
+				So(len(mockMongo.ClaimTaskCalls()), ShouldEqual, 4)
+				So(len(mockMongo.GetJobsCalls()), ShouldEqual, 0)
+				So(mockMongo.ClaimTaskCalls()[0].PendingState, ShouldEqual, domain.StateSubmitted)
+				So(mockMongo.ClaimTaskCalls()[0].ActiveState, ShouldEqual, domain.StateMigrating)
+				So(mockMongo.ClaimTaskCalls()[1].PendingState, ShouldEqual, domain.StateApproved)
+				So(mockMongo.ClaimTaskCalls()[1].ActiveState, ShouldEqual, domain.StatePublishing)
+				So(mockMongo.ClaimTaskCalls()[2].PendingState, ShouldEqual, domain.StatePendingPostPublish)
+				So(mockMongo.ClaimTaskCalls()[2].ActiveState, ShouldEqual, domain.StatePostPublishing)
+				So(mockMongo.ClaimTaskCalls()[3].PendingState, ShouldEqual, domain.StateRejected)
+				So(mockMongo.ClaimTaskCalls()[3].ActiveState, ShouldEqual, domain.StateReverting)
+			})
+
+			Convey("And tasks should wait for their dependencies to finish the same stage", func() {
+				So(mockMongo.ClaimTaskCalls()[0].DependencyState, ShouldEqual, domain.StateInReview)
+				So(mockMongo.ClaimTaskCalls()[1].DependencyState, ShouldEqual, domain.StatePublished)
+				So(mockMongo.ClaimTaskCalls()[2].DependencyState, ShouldEqual, domain.StateCompleted)
+				So(mockMongo.ClaimTaskCalls()[3].DependencyState, ShouldBeEmpty)
+			})
+
+			Convey("And no error should be returned", func() {
 				So(err, ShouldBeNil)
 			})
 
@@ -2784,9 +3976,12 @@ func TestClaimTask(t *testing.T) {
 		}
 
 		mockMongo := &storeMocks.MongoDBMock{
-			ClaimTaskFunc: func(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Task, error) {
+			ClaimTaskFunc: func(ctx context.Context, pendingState domain.State, activeState domain.State, dependencyState domain.State, afterJobNumber int, excludedTypes []domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
 				return claimedTask, nil
 			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
 		}
 
 		mockStore := store.Datastore{
@@ -2794,16 +3989,18 @@ func TestClaimTask(t *testing.T) {
 		}
 
 		mockClients := clients.ClientList{}
-		cfg := &config.Config{EnableEventLogging: false}
+		cfg := &config.Config{}
 		jobService := Setup(&mockStore, &mockClients, cfg)
 
 		ctx := context.Background()
 
 		Convey("When a task tries to be claimed", func() {
-			task, err := jobService.ClaimTask(ctx)
+			task, err := jobService.ClaimTask(ctx, map[domain.State][]domain.TaskType{domain.StateSubmitted: {domain.TaskTypeDatasetDownload}}, []int{7})
 
-			Convey("Then the store should be called to claim a task", func() {
+			Convey("Then the store should be called to claim a task without the excluded types and jobs", func() {
 				So(len(mockMongo.ClaimTaskCalls()), ShouldEqual, 1)
+				So(mockMongo.ClaimTaskCalls()[0].ExcludedTypes, ShouldResemble, []domain.TaskType{domain.TaskTypeDatasetDownload})
+				So(mockMongo.ClaimTaskCalls()[0].ExcludedJobNumbers, ShouldResemble, []int{7})
 			})
 
 			Convey("And no error should be returned", func() {
@@ -2815,4 +4012,1338 @@ func TestClaimTask(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a job service and a store with tasks from more than one job to be claimed", t, func() {
+		jobNumbers := []int{1, 2}
+
+		mockMongo := &storeMocks.MongoDBMock{
+			ClaimTaskFunc: func(ctx context.Context, pendingState domain.State, activeState domain.State, dependencyState domain.State, afterJobNumber int, excludedTypes []domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
+				jobNumber := jobNumbers[0]
+				jobNumbers = jobNumbers[1:]
+				return &domain.Task{ID: "task-123", JobNumber: jobNumber, State: activeState}, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When tasks are claimed in turn", func() {
+			_, err := jobService.ClaimTask(ctx, nil, nil)
+			So(err, ShouldBeNil)
+			_, err = jobService.ClaimTask(ctx, nil, nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then each claim should start after the job of the previously claimed task", func() {
+				So(len(mockMongo.ClaimTaskCalls()), ShouldEqual, 2)
+				So(mockMongo.ClaimTaskCalls()[0].AfterJobNumber, ShouldEqual, 0)
+				So(mockMongo.ClaimTaskCalls()[1].AfterJobNumber, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestUpdateJobPriority(t *testing.T) {
+	Convey("Given a job service and a store with a migrating job", t, func() {
+		var updates []string
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{
+					ID:        "job-123",
+					JobNumber: jobNumber,
+					State:     domain.StateMigrating,
+				}, nil
+			},
+			UpdateJobPriorityFunc: func(ctx context.Context, jobID string, priority int) error {
+				updates = append(updates, "job")
+				return nil
+			},
+			UpdateJobTasksPriorityFunc: func(ctx context.Context, jobNumber int, priority int) error {
+				updates = append(updates, "tasks")
+				return nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When the job's priority is changed", func() {
+			err := jobService.UpdateJobPriority(ctx, testJobNumber, 7, "user-123")
+
+			Convey("Then no error should be returned", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("And the job and then its tasks should be updated with the new priority", func() {
+				So(updates, ShouldResemble, []string{"job", "tasks"})
+				So(len(mockMongo.UpdateJobPriorityCalls()), ShouldEqual, 1)
+				So(mockMongo.UpdateJobPriorityCalls()[0].JobID, ShouldEqual, "job-123")
+				So(mockMongo.UpdateJobPriorityCalls()[0].Priority, ShouldEqual, 7)
+				So(len(mockMongo.UpdateJobTasksPriorityCalls()), ShouldEqual, 1)
+				So(mockMongo.UpdateJobTasksPriorityCalls()[0].JobNumber, ShouldEqual, testJobNumber)
+				So(mockMongo.UpdateJobTasksPriorityCalls()[0].Priority, ShouldEqual, 7)
+			})
+
+			Convey("And a priority changed event should be logged", func() {
+				So(len(mockMongo.CreateEventCalls()), ShouldEqual, 1)
+				event := mockMongo.CreateEventCalls()[0].Event
+				So(event.Action, ShouldEqual, domain.EventActionPriorityChanged)
+				So(event.Payload["from"], ShouldEqual, 0)
+				So(event.Payload["to"], ShouldEqual, 7)
+			})
+		})
+
+		Convey("When the job's priority is changed to its current priority", func() {
+			err := jobService.UpdateJobPriority(ctx, testJobNumber, domain.DefaultJobPriority, "user-123")
+
+			Convey("Then no error should be returned and only the tasks should be updated, in case an earlier change did not reach them", func() {
+				So(err, ShouldBeNil)
+				So(len(mockMongo.UpdateJobPriorityCalls()), ShouldEqual, 0)
+				So(len(mockMongo.UpdateJobTasksPriorityCalls()), ShouldEqual, 1)
+				So(len(mockMongo.CreateEventCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When updating the priority of the job fails", func() {
+			mockMongo.UpdateJobPriorityFunc = func(ctx context.Context, jobID string, priority int) error {
+				return appErrors.ErrInternalServerError
+			}
+
+			err := jobService.UpdateJobPriority(ctx, testJobNumber, 7, "user-123")
+
+			Convey("Then the error is returned and the tasks are not updated", func() {
+				So(err, ShouldEqual, appErrors.ErrInternalServerError)
+				So(len(mockMongo.UpdateJobTasksPriorityCalls()), ShouldEqual, 0)
+				So(len(mockMongo.CreateEventCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When updating the priority of the job's tasks fails", func() {
+			mockMongo.UpdateJobTasksPriorityFunc = func(ctx context.Context, jobNumber int, priority int) error {
+				return appErrors.ErrInternalServerError
+			}
+
+			err := jobService.UpdateJobPriority(ctx, testJobNumber, 7, "user-123")
+
+			Convey("Then the error is returned after the job is updated and no event is logged", func() {
+				So(err, ShouldEqual, appErrors.ErrInternalServerError)
+				So(len(mockMongo.UpdateJobPriorityCalls()), ShouldEqual, 1)
+				So(len(mockMongo.CreateEventCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the job's priority is changed to an invalid priority", func() {
+			err := jobService.UpdateJobPriority(ctx, testJobNumber, domain.MaxJobPriority+1, "user-123")
+
+			Convey("Then an invalid priority error should be returned", func() {
+				So(err, ShouldEqual, appErrors.ErrJobPriorityInvalid)
+				So(len(mockMongo.GetJobCalls()), ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given a job service and a store with a published job", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{
+					ID:        "job-123",
+					JobNumber: jobNumber,
+					State:     domain.StateCompleted,
+				}, nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the job's priority is changed", func() {
+			err := jobService.UpdateJobPriority(context.Background(), testJobNumber, 7, "user-123")
+
+			Convey("Then a priority not allowed error should be returned", func() {
+				So(err, ShouldEqual, appErrors.ErrJobPriorityNotAllowed)
+			})
+		})
+	})
+}
+
+func TestCreateBatch(t *testing.T) {
+	Convey("Given a job service and a store that has no stored jobs", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			CreateBatchFunc: func(ctx context.Context, batch *domain.Batch) error {
+				return nil
+			},
+			UpdateBatchFunc: func(ctx context.Context, batch *domain.Batch) error {
+				return nil
+			},
+			GetJobsBySourceOrTargetAndStateFunc: func(ctx context.Context, jc *domain.JobConfig, states []domain.State, limit, offset int) ([]*domain.Job, error) {
+				return nil, nil
+			},
+			CreateJobFunc: func(ctx context.Context, job *domain.Job) error {
+				return nil
+			},
+			GetNextJobNumberCounterFunc: func(ctx context.Context) (*domain.Counter, error) {
+				return &domain.Counter{CounterName: testJobNumberCounterName, CounterValue: testJobNumberCounterValue}, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockValidator := &domainMocks.JobValidatorMock{
+			ValidateSourceIDFunc: func(sourceID string) error {
+				return nil
+			},
+			ValidateTargetIDFunc: func(targetID string) error {
+				return nil
+			},
+			ValidateSourceIDWithExternalFunc: func(ctx context.Context, sourceID string, appClients *clients.ClientList, userAuthToken string) (string, error) {
+				return testDatasetTitle, nil
+			},
+			ValidateTargetIDWithExternalFunc: func(ctx context.Context, targetID string, appClients *clients.ClientList, userAuthToken string) error {
+				return nil
+			},
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{BatchMaxSize: 2}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When a batch is created with one valid and one invalid row", func() {
+			jobConfigs := []*domain.JobConfig{
+				{
+					SourceID:  "/source-id",
+					TargetID:  "target-id",
+					Type:      domain.JobTypeStaticDataset,
+					Validator: mockValidator,
+				},
+				{
+					SourceID:  "/source-id",
+					TargetID:  "target-id",
+					Validator: mockValidator,
+				},
+			}
+
+			batch, err := jobService.CreateBatch(ctx, jobConfigs, "test-user", testUserAuthToken, nil)
+
+			Convey("Then no error should be returned", func() {
+				So(err, ShouldBeNil)
+
+				Convey("And the batch should be stored before and after the jobs are created", func() {
+					So(len(mockMongo.CreateBatchCalls()), ShouldEqual, 1)
+					So(len(mockMongo.UpdateBatchCalls()), ShouldEqual, 1)
+					So(mockMongo.UpdateBatchCalls()[0].Batch.ID, ShouldEqual, batch.ID)
+					So(batch.Status, ShouldEqual, domain.BatchStatusCompleted)
+				})
+
+				Convey("And a job should only be created for the valid row", func() {
+					So(len(mockMongo.CreateJobCalls()), ShouldEqual, 1)
+					So(mockMongo.CreateJobCalls()[0].Job.BatchID, ShouldEqual, batch.ID)
+				})
+
+				Convey("And the result of each row should be recorded", func() {
+					So(batch.TotalRows, ShouldEqual, 2)
+					So(batch.JobsCreated, ShouldEqual, 1)
+					So(batch.RowsFailed, ShouldEqual, 1)
+					So(batch.Results, ShouldHaveLength, 2)
+					So(batch.Results[0].Row, ShouldEqual, 1)
+					So(batch.Results[0].JobNumber, ShouldEqual, testJobNumberCounterValue)
+					So(batch.Results[1].Row, ShouldEqual, 2)
+					So(batch.Results[1].Errors, ShouldContain, appErrors.ErrJobTypeNotProvided.Error())
+				})
+			})
+		})
+
+		Convey("When a batch is created but its results cannot be stored", func() {
+			mockMongo.UpdateBatchFunc = func(ctx context.Context, batch *domain.Batch) error {
+				return errors.New("fake store error")
+			}
+
+			jobConfigs := []*domain.JobConfig{
+				{
+					SourceID:  "/source-id",
+					TargetID:  "target-id",
+					Type:      domain.JobTypeStaticDataset,
+					Validator: mockValidator,
+				},
+			}
+
+			batch, err := jobService.CreateBatch(ctx, jobConfigs, "test-user", testUserAuthToken, nil)
+
+			Convey("Then the batch is returned with a partial status rather than an error", func() {
+				So(err, ShouldBeNil)
+				So(batch.Status, ShouldEqual, domain.BatchStatusPartial)
+
+				Convey("And the result of the job created is returned", func() {
+					So(len(mockMongo.CreateJobCalls()), ShouldEqual, 1)
+					So(batch.JobsCreated, ShouldEqual, 1)
+					So(batch.Results[0].JobNumber, ShouldEqual, testJobNumberCounterValue)
+				})
+			})
+		})
+
+		Convey("When a batch is created with no rows", func() {
+			batch, err := jobService.CreateBatch(ctx, []*domain.JobConfig{}, "test-user", testUserAuthToken, nil)
+
+			Convey("Then an empty batch error should be returned", func() {
+				So(batch, ShouldBeNil)
+				So(err, ShouldEqual, appErrors.ErrBatchEmpty)
+				So(len(mockMongo.CreateBatchCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When a batch is created with more rows than the maximum batch size", func() {
+			jobConfigs := []*domain.JobConfig{{}, {}, {}}
+
+			batch, err := jobService.CreateBatch(ctx, jobConfigs, "test-user", testUserAuthToken, nil)
+
+			Convey("Then a batch too large error should be returned", func() {
+				So(batch, ShouldBeNil)
+				So(err, ShouldEqual, appErrors.ErrBatchTooLarge)
+				So(len(mockMongo.CreateBatchCalls()), ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given a job service with rows that are slow to check against Zebedee", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			CreateBatchFunc: func(ctx context.Context, batch *domain.Batch) error {
+				return nil
+			},
+			UpdateBatchFunc: func(ctx context.Context, batch *domain.Batch) error {
+				return nil
+			},
+			GetJobsBySourceOrTargetAndStateFunc: func(ctx context.Context, jc *domain.JobConfig, states []domain.State, limit, offset int) ([]*domain.Job, error) {
+				return nil, nil
+			},
+			CreateJobFunc: func(ctx context.Context, job *domain.Job) error {
+				return nil
+			},
+			GetNextJobNumberCounterFunc: func(ctx context.Context) (*domain.Counter, error) {
+				return &domain.Counter{CounterName: testJobNumberCounterName, CounterValue: testJobNumberCounterValue}, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		var checking, maxChecking atomic.Int32
+		slowValidator := &domainMocks.JobValidatorMock{
+			ValidateSourceIDFunc: func(sourceID string) error {
+				return nil
+			},
+			ValidateTargetIDFunc: func(targetID string) error {
+				return nil
+			},
+			ValidateSourceIDWithExternalFunc: func(ctx context.Context, sourceID string, appClients *clients.ClientList, userAuthToken string) (string, error) {
+				n := checking.Add(1)
+				defer checking.Add(-1)
+				for {
+					current := maxChecking.Load()
+					if n <= current || maxChecking.CompareAndSwap(current, n) {
+						break
+					}
+				}
+				time.Sleep(100 * time.Millisecond)
+				return testDatasetTitle, nil
+			},
+			ValidateTargetIDWithExternalFunc: func(ctx context.Context, targetID string, appClients *clients.ClientList, userAuthToken string) error {
+				return nil
+			},
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{BatchMaxSize: 8, BatchMaxConcurrentRows: 4}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		Convey("When a batch of rows is created", func() {
+			jobConfigs := make([]*domain.JobConfig, 8)
+			for i := range jobConfigs {
+				jobConfigs[i] = &domain.JobConfig{
+					SourceID:  fmt.Sprintf("/source-id-%d", i),
+					TargetID:  fmt.Sprintf("target-id-%d", i),
+					Type:      domain.JobTypeStaticDataset,
+					Validator: slowValidator,
+				}
+			}
+
+			rowsDone := 0
+			start := time.Now()
+			batch, err := jobService.CreateBatch(context.Background(), jobConfigs, "test-user", testUserAuthToken, func() {
+				rowsDone++
+			})
+			elapsed := time.Since(start)
+
+			Convey("Then the rows are checked concurrently, up to the configured limit", func() {
+				So(err, ShouldBeNil)
+				So(maxChecking.Load(), ShouldEqual, 4)
+				So(elapsed, ShouldBeLessThan, 8*100*time.Millisecond)
+
+				Convey("And the caller is told as each row is processed", func() {
+					So(rowsDone, ShouldEqual, 8)
+				})
+
+				Convey("And a job is created for every row, with the results in row order", func() {
+					So(len(mockMongo.CreateJobCalls()), ShouldEqual, 8)
+					So(batch.JobsCreated, ShouldEqual, 8)
+					So(batch.Results, ShouldHaveLength, 8)
+					for i, result := range batch.Results {
+						So(result.Row, ShouldEqual, i+1)
+						So(result.Config.SourceID, ShouldEqual, jobConfigs[i].SourceID)
+					}
+				})
+			})
+		})
+	})
+
+	Convey("Given a job service and a store that fails to create batches", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			CreateBatchFunc: func(ctx context.Context, batch *domain.Batch) error {
+				return errors.New("fake store error")
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When a batch is created", func() {
+			batch, err := jobService.CreateBatch(ctx, []*domain.JobConfig{{}}, "test-user", testUserAuthToken, nil)
+
+			Convey("Then an internal server error should be returned", func() {
+				So(batch, ShouldBeNil)
+				So(err, ShouldEqual, appErrors.ErrInternalServerError)
+			})
+		})
+	})
+}
+
+func TestGetBatch(t *testing.T) {
+	Convey("Given a job service and a store that has the requested batch", t, func() {
+		expectedBatch := &domain.Batch{
+			ID:        "test-batch-id",
+			TotalRows: 3,
+		}
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetBatchFunc: func(ctx context.Context, batchID string) (*domain.Batch, error) {
+				return expectedBatch, nil
+			},
+			GetBatchJobStateCountsFunc: func(ctx context.Context, batchID string) ([]mongo.StateCountResult, error) {
+				return []mongo.StateCountResult{
+					{State: domain.StateSubmitted, Count: 2},
+					{State: domain.StateCompleted, Count: 1},
+				}, nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When GetBatch is called", func() {
+			batch, err := jobService.GetBatch(ctx, expectedBatch.ID)
+
+			Convey("Then the batch should be returned with a summary of its job states", func() {
+				So(err, ShouldBeNil)
+				So(batch.ID, ShouldEqual, expectedBatch.ID)
+				So(mockMongo.GetBatchJobStateCountsCalls()[0].BatchID, ShouldEqual, expectedBatch.ID)
+				So(batch.States, ShouldHaveLength, 2)
+			})
+		})
+	})
+
+	Convey("Given a job service and a store that does not have the requested batch", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetBatchFunc: func(ctx context.Context, batchID string) (*domain.Batch, error) {
+				return nil, appErrors.ErrBatchNotFound
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When GetBatch is called", func() {
+			batch, err := jobService.GetBatch(ctx, "missing-batch-id")
+
+			Convey("Then ErrBatchNotFound should be returned", func() {
+				So(batch, ShouldBeNil)
+				So(err, ShouldEqual, appErrors.ErrBatchNotFound)
+				So(len(mockMongo.GetBatchJobStateCountsCalls()), ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestStreamJob(t *testing.T) {
+	Convey("Given a job service and store that has a submitted job", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{ID: "job-id", JobNumber: jobNumber, State: domain.StateSubmitted}, nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, id string, oldState, newState domain.State, lastUpdated time.Time) error {
+				return nil
+			},
+			GetTaskFunc: func(ctx context.Context, taskID string) (*domain.Task, error) {
+				return &domain.Task{ID: taskID, JobNumber: testJobNumber, State: domain.StateMigrating}, nil
+			},
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, lastUpdated time.Time) error {
+				return nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{StreamHistorySize: 10}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		ctx := context.Background()
+
+		Convey("When StreamJob is called without a Last-Event-ID", func() {
+			sub, initial, err := jobService.StreamJob(ctx, testJobNumber, "")
+			So(err, ShouldBeNil)
+			defer sub.Close()
+
+			Convey("Then a snapshot of the job is returned", func() {
+				So(initial, ShouldHaveLength, 1)
+				So(initial[0].Type, ShouldEqual, stream.MessageTypeJob)
+				So(initial[0].Data.(*domain.Job).State, ShouldEqual, domain.StateSubmitted)
+			})
+
+			Convey("And when the job and one of its tasks change state", func() {
+				So(jobService.UpdateJobState(ctx, testJobNumber, domain.StateMigrating, "user-123", "", nil), ShouldBeNil)
+				So(jobService.UpdateTaskState(ctx, "task-1", domain.StateInReview, ""), ShouldBeNil)
+
+				Convey("Then the subscription receives both changes, each followed by its event", func() {
+					jobMsg := <-sub.Messages()
+					So(jobMsg.Type, ShouldEqual, stream.MessageTypeJobStateChanged)
+					So(jobMsg.Data.(*stream.StateChange).ToState, ShouldEqual, domain.StateMigrating)
+
+					jobEventMsg := <-sub.Messages()
+					So(jobEventMsg.Type, ShouldEqual, stream.MessageTypeEvent)
+					So(jobEventMsg.Data.(*domain.Event).Action, ShouldEqual, domain.EventActionStateChanged)
+
+					taskMsg := <-sub.Messages()
+					So(taskMsg.Type, ShouldEqual, stream.MessageTypeTaskStateChanged)
+					So(taskMsg.Data.(*stream.StateChange).TaskID, ShouldEqual, "task-1")
+
+					taskEventMsg := <-sub.Messages()
+					So(taskEventMsg.Type, ShouldEqual, stream.MessageTypeEvent)
+					So(taskEventMsg.Data.(*domain.Event).TaskID, ShouldEqual, "task-1")
+				})
+
+				Convey("And a new stream can resume after the first change", func() {
+					first := <-sub.Messages()
+					resumed, missed, err := jobService.StreamJob(ctx, testJobNumber, first.ID)
+					So(err, ShouldBeNil)
+					defer resumed.Close()
+
+					So(missed, ShouldHaveLength, 3)
+					So(missed[1].Type, ShouldEqual, stream.MessageTypeTaskStateChanged)
+				})
+			})
+		})
+	})
+
+	Convey("Given a job service and store that does not have the job", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return nil, appErrors.ErrJobNotFound
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockClients := clients.ClientList{}
+		cfg := &config.Config{}
+		jobService := Setup(&mockStore, &mockClients, cfg)
+
+		Convey("When StreamJob is called", func() {
+			sub, initial, err := jobService.StreamJob(context.Background(), nonExistentJobNumber, "")
+
+			Convey("Then ErrJobNotFound should be returned", func() {
+				So(err, ShouldEqual, appErrors.ErrJobNotFound)
+				So(sub, ShouldBeNil)
+				So(initial, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestNotifyWebhooks(t *testing.T) {
+	job := &domain.Job{
+		JobNumber: testJobNumber,
+		Label:     "Test Job",
+		State:     domain.StateApproved,
+		Config: &domain.JobConfig{
+			SourceID: "/source-id",
+			TargetID: "target-id",
+			Type:     domain.JobTypeStaticDataset,
+		},
+	}
+
+	Convey("Given a job service with webhooks enabled and two subscribed webhooks", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetWebhooksForEventFunc: func(ctx context.Context, eventType domain.WebhookEventType, jobType domain.JobType) ([]*domain.Webhook, error) {
+				return []*domain.Webhook{{ID: "webhook-1"}, {ID: "webhook-2"}}, nil
+			},
+			CreateWebhookDeliveryFunc: func(ctx context.Context, delivery *domain.WebhookDelivery) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{EnableWebhooks: true})
+
+		Convey("When a job is published", func() {
+			err := jobService.NotifyWebhooks(context.Background(), job, domain.StateApproved, domain.StatePublished, "all tasks published")
+
+			Convey("Then webhooks subscribed to the event and job type are looked up", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.GetWebhooksForEventCalls(), ShouldHaveLength, 1)
+				So(mockMongo.GetWebhooksForEventCalls()[0].EventType, ShouldEqual, domain.WebhookEventType("job.published"))
+				So(mockMongo.GetWebhooksForEventCalls()[0].JobType, ShouldEqual, domain.JobTypeStaticDataset)
+			})
+
+			Convey("And a pending delivery is created for each webhook", func() {
+				So(mockMongo.CreateWebhookDeliveryCalls(), ShouldHaveLength, 2)
+
+				delivery := mockMongo.CreateWebhookDeliveryCalls()[1].Delivery
+				So(delivery.WebhookID, ShouldEqual, "webhook-2")
+				So(delivery.Status, ShouldEqual, domain.WebhookDeliveryStatusPending)
+				So(delivery.JobNumber, ShouldEqual, testJobNumber)
+				So(string(delivery.Payload), ShouldContainSubstring, `"previous_state":"approved"`)
+				So(string(delivery.Payload), ShouldContainSubstring, `"reason":"all tasks published"`)
+			})
+		})
+	})
+
+	Convey("Given a job service with webhooks disabled", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When a job is published", func() {
+			err := jobService.NotifyWebhooks(context.Background(), job, domain.StateApproved, domain.StatePublished, "")
+
+			Convey("Then no deliveries are created", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.GetWebhooksForEventCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	Convey("Given a job service and a store without the requested webhook", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetWebhookFunc: func(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+				return nil, appErrors.ErrWebhookNotFound
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When its deliveries are requested", func() {
+			_, _, err := jobService.GetWebhookDeliveries(context.Background(), "unknown", "", 10, 0)
+
+			Convey("Then a webhook not found error is returned", func() {
+				So(err, ShouldEqual, appErrors.ErrWebhookNotFound)
+				So(mockMongo.GetWebhookDeliveriesCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestCreateComment(t *testing.T) {
+	Convey("Given a job service and store where a job exists", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{JobNumber: jobNumber, State: domain.StateInReview}, nil
+			},
+			CreateCommentFunc: func(ctx context.Context, comment *domain.Comment) error {
+				return nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When a comment is created", func() {
+			comment := domain.NewComment(testJobNumber, "Mapped output looks right", "user-123")
+
+			createdComment, err := jobService.CreateComment(context.Background(), comment)
+
+			Convey("Then the comment is stored and returned", func() {
+				So(err, ShouldBeNil)
+				So(createdComment, ShouldEqual, comment)
+				So(mockMongo.CreateCommentCalls(), ShouldHaveLength, 1)
+				So(mockMongo.CreateCommentCalls()[0].Comment, ShouldEqual, comment)
+
+				Convey("And a comment added event is logged for the user", func() {
+					So(mockMongo.CreateEventCalls(), ShouldHaveLength, 1)
+					event := mockMongo.CreateEventCalls()[0].Event
+					So(event.Action, ShouldEqual, domain.EventActionCommentAdded)
+					So(event.JobNumber, ShouldEqual, testJobNumber)
+					So(event.RequestedBy.ID, ShouldEqual, "user-123")
+					So(event.Payload["comment_id"], ShouldEqual, comment.ID)
+				})
+			})
+		})
+	})
+
+	Convey("Given a job service and store where a job does not exist", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return nil, appErrors.ErrJobNotFound
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When a comment is created", func() {
+			comment := domain.NewComment(testJobNumber, "text", "user-123")
+
+			createdComment, err := jobService.CreateComment(context.Background(), comment)
+
+			Convey("Then a job not found error is returned and nothing is stored", func() {
+				So(createdComment, ShouldBeNil)
+				So(err, ShouldEqual, appErrors.ErrJobNotFound)
+				So(mockMongo.CreateCommentCalls(), ShouldBeEmpty)
+				So(mockMongo.CreateEventCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a job service and store that fails to create comments", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{JobNumber: jobNumber}, nil
+			},
+			CreateCommentFunc: func(ctx context.Context, comment *domain.Comment) error {
+				return appErrors.ErrInternalServerError
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When a comment is created", func() {
+			_, err := jobService.CreateComment(context.Background(), domain.NewComment(testJobNumber, "text", "user-123"))
+
+			Convey("Then the error is returned and no event is logged", func() {
+				So(err, ShouldEqual, appErrors.ErrInternalServerError)
+				So(mockMongo.CreateEventCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetDigest(t *testing.T) {
+	Convey("Given a job service and store with recent activity, jobs awaiting approval and stuck tasks", t, func() {
+		now := time.Now().UTC()
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobStateCountsFunc: func(ctx context.Context) ([]mongo.StateCountResult, error) {
+				return []mongo.StateCountResult{
+					{State: domain.StateInReview, Count: 3},
+					{State: domain.StateMigrating, Count: 1},
+				}, nil
+			},
+			GetJobEventStateCountsFunc: func(ctx context.Context, action domain.EventAction, since time.Time) ([]mongo.StateCountResult, error) {
+				if action == domain.EventActionJobCreated {
+					return []mongo.StateCountResult{
+						{State: domain.StateSubmitted, Count: 4},
+					}, nil
+				}
+				return []mongo.StateCountResult{
+					{State: domain.StateMigrating, Count: 4},
+					{State: domain.StateCompleted, Count: 2},
+					{State: domain.StateFailedMigration, Count: 1},
+					{State: domain.StateFailedPublish, Count: 1},
+				}, nil
+			},
+			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
+				return []*domain.Job{
+					{JobNumber: 3, Label: "Oldest", State: domain.StateInReview, LastUpdated: now.Add(-30 * time.Hour)},
+					{JobNumber: 8, Label: "Newer", State: domain.StateInReview, LastUpdated: now.Add(-2 * time.Hour)},
+				}, 3, nil
+			},
+			GetStaleTasksFunc: func(ctx context.Context, states []domain.State, updatedBefore time.Time, limit int) ([]*domain.Task, int, error) {
+				return []*domain.Task{
+					{ID: "stuck-task", JobNumber: 5, Type: domain.TaskTypeDatasetVersion, State: domain.StateMigrating, LastUpdated: now.Add(-3 * time.Hour)},
+				}, 1, nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		cfg := &config.Config{
+			DigestMaxItems:           2,
+			DigestPeriod:             24 * time.Hour,
+			DigestStuckTaskThreshold: time.Hour,
+		}
+		jobService := Setup(&mockStore, &clients.ClientList{}, cfg)
+
+		Convey("When GetDigest is called", func() {
+			digest, err := jobService.GetDigest(context.Background())
+
+			Convey("Then no error is returned", func() {
+				So(err, ShouldBeNil)
+				So(digest.PeriodStart, ShouldEqual, digest.GeneratedAt.Add(-24*time.Hour))
+			})
+
+			Convey("And jobs created, completed and failed are counted from events since the start of the period", func() {
+				So(mockMongo.GetJobEventStateCountsCalls(), ShouldHaveLength, 2)
+				So(mockMongo.GetJobEventStateCountsCalls()[0].Since, ShouldEqual, digest.PeriodStart)
+				So(digest.JobsCreated, ShouldEqual, 4)
+				So(digest.JobsCompleted, ShouldEqual, 2)
+				So(digest.JobsFailed, ShouldEqual, 2)
+			})
+
+			Convey("And the current job states are included", func() {
+				So(digest.JobStates, ShouldHaveLength, 2)
+				So(digest.JobStates[0].ID, ShouldEqual, domain.StateInReview)
+			})
+
+			Convey("And the longest waiting jobs in review are listed", func() {
+				So(mockMongo.GetJobsCalls(), ShouldHaveLength, 1)
+				call := mockMongo.GetJobsCalls()[0]
+				So(call.Field, ShouldEqual, sort.SortParameterFieldLastUpdated)
+				So(call.Direction, ShouldEqual, sort.SortParameterDirectionAsc)
+				So(call.Filter.States, ShouldResemble, []domain.State{domain.StateInReview})
+				So(call.Limit, ShouldEqual, 2)
+
+				So(digest.AwaitingApproval.Count, ShouldEqual, 3)
+				So(digest.AwaitingApproval.Jobs, ShouldHaveLength, 2)
+				So(digest.AwaitingApproval.Jobs[0].JobNumber, ShouldEqual, 3)
+				So(digest.AwaitingApproval.Jobs[0].WaitingFor, ShouldEqual, "30h0m0s")
+			})
+
+			Convey("And tasks active for longer than the stuck task threshold are listed", func() {
+				So(mockMongo.GetStaleTasksCalls(), ShouldHaveLength, 1)
+				call := mockMongo.GetStaleTasksCalls()[0]
+				So(call.States, ShouldResemble, domain.GetActiveTaskStates())
+				So(call.UpdatedBefore, ShouldEqual, digest.GeneratedAt.Add(-time.Hour))
+
+				So(digest.StuckTasks.Count, ShouldEqual, 1)
+				So(digest.StuckTasks.Tasks[0].ID, ShouldEqual, "stuck-task")
+				So(digest.StuckTasks.Tasks[0].WaitingFor, ShouldEqual, "3h0m0s")
+			})
+		})
+	})
+
+	Convey("Given a job service and store that fails to find stuck tasks", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobStateCountsFunc: func(ctx context.Context) ([]mongo.StateCountResult, error) {
+				return []mongo.StateCountResult{}, nil
+			},
+			GetJobEventStateCountsFunc: func(ctx context.Context, action domain.EventAction, since time.Time) ([]mongo.StateCountResult, error) {
+				return []mongo.StateCountResult{}, nil
+			},
+			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
+				return []*domain.Job{}, 0, nil
+			},
+			GetStaleTasksFunc: func(ctx context.Context, states []domain.State, updatedBefore time.Time, limit int) ([]*domain.Task, int, error) {
+				return nil, 0, appErrors.ErrInternalServerError
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When GetDigest is called", func() {
+			digest, err := jobService.GetDigest(context.Background())
+
+			Convey("Then the error is returned", func() {
+				So(digest, ShouldBeNil)
+				So(errors.Is(err, appErrors.ErrInternalServerError), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestCreateReleaseGroup(t *testing.T) {
+	Convey("Given a job service and store with two jobs that can be grouped", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{JobNumber: jobNumber, State: domain.StateInReview}, nil
+			},
+			AddJobsToReleaseGroupFunc: func(ctx context.Context, groupID string, jobNumbers []int, states []domain.State) (int, error) {
+				return len(jobNumbers), nil
+			},
+			CreateReleaseGroupFunc: func(ctx context.Context, group *domain.ReleaseGroup) error {
+				return nil
+			},
+			GetReleaseGroupJobsFunc: func(ctx context.Context, groupID string) ([]*domain.Job, error) {
+				return []*domain.Job{{JobNumber: 1, State: domain.StateInReview}, {JobNumber: 2, State: domain.StateInReview}}, nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When a release group is created", func() {
+			group := domain.NewReleaseGroup("Regional series", []int{1, 2}, "user-123")
+
+			createdGroup, err := jobService.CreateReleaseGroup(context.Background(), group)
+
+			Convey("Then the jobs are added to the group and it is stored", func() {
+				So(err, ShouldBeNil)
+				So(createdGroup, ShouldEqual, group)
+
+				So(mockMongo.AddJobsToReleaseGroupCalls(), ShouldHaveLength, 1)
+				call := mockMongo.AddJobsToReleaseGroupCalls()[0]
+				So(call.GroupID, ShouldEqual, group.ID)
+				So(call.JobNumbers, ShouldResemble, []int{1, 2})
+				So(call.States, ShouldResemble, domain.GroupableStates)
+
+				So(mockMongo.CreateReleaseGroupCalls(), ShouldHaveLength, 1)
+
+				Convey("And the jobs are not released as they are not approved", func() {
+					So(mockMongo.ReleaseGroupJobsCalls(), ShouldBeEmpty)
+				})
+			})
+		})
+	})
+
+	Convey("Given a job service and store with two approved jobs", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{JobNumber: jobNumber, State: domain.StateApproved}, nil
+			},
+			AddJobsToReleaseGroupFunc: func(ctx context.Context, groupID string, jobNumbers []int, states []domain.State) (int, error) {
+				return len(jobNumbers), nil
+			},
+			CreateReleaseGroupFunc: func(ctx context.Context, group *domain.ReleaseGroup) error {
+				return nil
+			},
+			GetReleaseGroupJobsFunc: func(ctx context.Context, groupID string) ([]*domain.Job, error) {
+				return []*domain.Job{{JobNumber: 1, State: domain.StateApproved}, {JobNumber: 2, State: domain.StateApproved}}, nil
+			},
+			ReleaseGroupJobsFunc: func(ctx context.Context, groupID string, state domain.State) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When a release group is created", func() {
+			group := domain.NewReleaseGroup("Regional series", []int{1, 2}, "user-123")
+
+			_, err := jobService.CreateReleaseGroup(context.Background(), group)
+
+			Convey("Then the jobs are released for publishing together", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.ReleaseGroupJobsCalls(), ShouldHaveLength, 1)
+				So(mockMongo.ReleaseGroupJobsCalls()[0].GroupID, ShouldEqual, group.ID)
+				So(mockMongo.ReleaseGroupJobsCalls()[0].State, ShouldEqual, domain.StateApproved)
+			})
+		})
+	})
+
+	Convey("Given a job service and store where a job cannot be grouped", t, func() {
+		jobs := map[int]*domain.Job{
+			1: {JobNumber: 1, State: domain.StateApproved},
+			2: {JobNumber: 2, State: domain.StatePublishing},
+			3: {JobNumber: 3, State: domain.StateApproved, ReleaseGroupID: "other-group"},
+		}
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				job, ok := jobs[jobNumber]
+				if !ok {
+					return nil, appErrors.ErrJobNotFound
+				}
+				return job, nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When a group is created with a job that has started publishing", func() {
+			_, err := jobService.CreateReleaseGroup(context.Background(), domain.NewReleaseGroup("Group", []int{1, 2}, "user-123"))
+
+			Convey("Then a job not groupable error is returned", func() {
+				So(err, ShouldEqual, appErrors.ErrJobNotGroupable)
+				So(mockMongo.AddJobsToReleaseGroupCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a group is created with a job in another group", func() {
+			_, err := jobService.CreateReleaseGroup(context.Background(), domain.NewReleaseGroup("Group", []int{1, 3}, "user-123"))
+
+			Convey("Then a job already in release group error is returned", func() {
+				So(err, ShouldEqual, appErrors.ErrJobAlreadyInReleaseGroup)
+			})
+		})
+
+		Convey("When a group is created with a job that does not exist", func() {
+			_, err := jobService.CreateReleaseGroup(context.Background(), domain.NewReleaseGroup("Group", []int{1, 99}, "user-123"))
+
+			Convey("Then a release group job not found error is returned", func() {
+				So(err, ShouldEqual, appErrors.ErrReleaseGroupJobNotFound)
+			})
+		})
+	})
+
+	Convey("Given a job service and store where a job changes state while being grouped", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{JobNumber: jobNumber, State: domain.StateApproved}, nil
+			},
+			AddJobsToReleaseGroupFunc: func(ctx context.Context, groupID string, jobNumbers []int, states []domain.State) (int, error) {
+				return 1, nil
+			},
+			RemoveJobsFromReleaseGroupFunc: func(ctx context.Context, groupID string) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When a release group is created", func() {
+			group := domain.NewReleaseGroup("Group", []int{1, 2}, "user-123")
+			_, err := jobService.CreateReleaseGroup(context.Background(), group)
+
+			Convey("Then the jobs that were added are removed and the group is not stored", func() {
+				So(err, ShouldEqual, appErrors.ErrJobNotGroupable)
+				So(mockMongo.RemoveJobsFromReleaseGroupCalls(), ShouldHaveLength, 1)
+				So(mockMongo.RemoveJobsFromReleaseGroupCalls()[0].GroupID, ShouldEqual, group.ID)
+				So(mockMongo.CreateReleaseGroupCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetReleaseGroup(t *testing.T) {
+	Convey("Given a job service and store with a release group", t, func() {
+		group := domain.NewReleaseGroup("Regional series", []int{1, 2}, "user-123")
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetReleaseGroupFunc: func(ctx context.Context, groupID string) (*domain.ReleaseGroup, error) {
+				return group, nil
+			},
+			GetReleaseGroupJobsFunc: func(ctx context.Context, groupID string) ([]*domain.Job, error) {
+				return []*domain.Job{{JobNumber: 1, State: domain.StatePublished}, {JobNumber: 2, State: domain.StateFailedPublish}}, nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the release group is retrieved", func() {
+			result, err := jobService.GetReleaseGroup(context.Background(), group.ID)
+
+			Convey("Then its status is set from its jobs", func() {
+				So(err, ShouldBeNil)
+				So(result.Status, ShouldEqual, domain.ReleaseGroupStatusHeld)
+				So(result.HeldBy, ShouldResemble, []int{2})
+				So(result.Jobs, ShouldHaveLength, 2)
+			})
+		})
+	})
+
+	Convey("Given a job service and store without the release group", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetReleaseGroupFunc: func(ctx context.Context, groupID string) (*domain.ReleaseGroup, error) {
+				return nil, appErrors.ErrReleaseGroupNotFound
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the release group is retrieved", func() {
+			_, err := jobService.GetReleaseGroup(context.Background(), "missing")
+
+			Convey("Then a release group not found error is returned", func() {
+				So(err, ShouldEqual, appErrors.ErrReleaseGroupNotFound)
+			})
+		})
+	})
+}
+
+func TestDeleteReleaseGroup(t *testing.T) {
+	Convey("Given a job service and store with a release group", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			GetReleaseGroupFunc: func(ctx context.Context, groupID string) (*domain.ReleaseGroup, error) {
+				return &domain.ReleaseGroup{ID: groupID}, nil
+			},
+			RemoveJobsFromReleaseGroupFunc: func(ctx context.Context, groupID string) error {
+				return nil
+			},
+			DeleteReleaseGroupFunc: func(ctx context.Context, groupID string) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the release group is deleted", func() {
+			err := jobService.DeleteReleaseGroup(context.Background(), "group-id")
+
+			Convey("Then its jobs are removed from it before it is deleted", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.RemoveJobsFromReleaseGroupCalls(), ShouldHaveLength, 1)
+				So(mockMongo.DeleteReleaseGroupCalls(), ShouldHaveLength, 1)
+				So(mockMongo.DeleteReleaseGroupCalls()[0].GroupID, ShouldEqual, "group-id")
+			})
+		})
+	})
+}
+
+func TestUpdateJobStateReleaseGroup(t *testing.T) {
+	Convey("Given a job service and a job in review in a release group", t, func() {
+		otherJobState := domain.StateApproved
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return &domain.Job{ID: "job-1", JobNumber: 1, State: domain.StateInReview, ReleaseGroupID: "group-id"}, nil
+			},
+			ApproveJobFunc: func(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error) {
+				return &domain.Job{ID: jobID, JobNumber: 1, State: domain.StateApproved, ReleaseGroupID: "group-id"}, nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, id string, oldState, newState domain.State, lastUpdated time.Time) error {
+				return nil
+			},
+			GetReleaseGroupJobsFunc: func(ctx context.Context, groupID string) ([]*domain.Job, error) {
+				return []*domain.Job{{JobNumber: 1, State: domain.StateApproved}, {JobNumber: 2, State: otherJobState}}, nil
+			},
+			ReleaseGroupJobsFunc: func(ctx context.Context, groupID string, state domain.State) error {
+				return nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{Backend: mockMongo}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the job is approved and the other job in the group is already approved", func() {
+			err := jobService.UpdateJobState(context.Background(), 1, domain.StateApproved, "reviewer", "", nil)
+
+			Convey("Then the group's jobs are released from approved", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.ReleaseGroupJobsCalls(), ShouldHaveLength, 1)
+				So(mockMongo.ReleaseGroupJobsCalls()[0].GroupID, ShouldEqual, "group-id")
+				So(mockMongo.ReleaseGroupJobsCalls()[0].State, ShouldEqual, domain.StateApproved)
+			})
+		})
+
+		Convey("When the job is approved and the other job in the group is still in review", func() {
+			otherJobState = domain.StateInReview
+			err := jobService.UpdateJobState(context.Background(), 1, domain.StateApproved, "reviewer", "", nil)
+
+			Convey("Then the group's jobs are held", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.ReleaseGroupJobsCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the job is rejected", func() {
+			err := jobService.UpdateJobState(context.Background(), 1, domain.StateRejected, "reviewer", "", nil)
+
+			Convey("Then the group is not checked", func() {
+				So(err, ShouldBeNil)
+				So(mockMongo.GetReleaseGroupJobsCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestUpdateJobStateInvalidatesPageCache(t *testing.T) {
+	Convey("Given a job service whose Zebedee pages are cached and a migrating job", t, func() {
+		fakeJob := &domain.Job{
+			ID:        "test-job-id",
+			JobNumber: testJobNumber,
+			State:     domain.StateMigrating,
+		}
+
+		mockMongo := &storeMocks.MongoDBMock{
+			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
+				return fakeJob, nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, id string, oldState, newState domain.State, lastUpdated time.Time) error {
+				return nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+
+		mockZebedee := &clientMocks.ZebedeeClientMock{
+			GetDatasetLandingPageFunc: func(ctx context.Context, authToken, collectionID, lang, path string) (zebedee.DatasetLandingPage, error) {
+				return zebedee.DatasetLandingPage{}, nil
+			},
+		}
+		mockClients := clients.WithPageCache(&clients.ClientList{Zebedee: mockZebedee}, clients.NewPageCache(10, 10, time.Hour))
+
+		jobService := Setup(&mockStore, mockClients, &config.Config{})
+
+		jobCtx := clients.WithJobNumber(context.Background(), testJobNumber)
+		_, err := mockClients.Zebedee.GetDatasetLandingPage(jobCtx, "", "", "en", "/a/page")
+		So(err, ShouldBeNil)
+
+		Convey("When the job finishes migrating", func() {
+			err := jobService.UpdateJobState(context.Background(), testJobNumber, domain.StateInReview, "", "", nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then the job's cached pages are invalidated", func() {
+				_, err := mockClients.Zebedee.GetDatasetLandingPage(jobCtx, "", "", "en", "/a/page")
+				So(err, ShouldBeNil)
+				So(mockZebedee.GetDatasetLandingPageCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+}
+
+func TestClaimDigestRun(t *testing.T) {
+	scheduledAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	Convey("Given a job service and a store where the digest run has not been claimed", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			ClaimDigestRunFunc: func(ctx context.Context, run *domain.DigestRun) error {
+				return nil
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the digest run is claimed", func() {
+			claimed, err := jobService.ClaimDigestRun(context.Background(), scheduledAt)
+
+			Convey("Then it is claimed for the scheduled date", func() {
+				So(err, ShouldBeNil)
+				So(claimed, ShouldBeTrue)
+				So(mockMongo.ClaimDigestRunCalls(), ShouldHaveLength, 1)
+				So(mockMongo.ClaimDigestRunCalls()[0].Run.Date, ShouldEqual, "2026-10-18")
+			})
+		})
+	})
+
+	Convey("Given a job service and a store where the digest run has already been claimed", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			ClaimDigestRunFunc: func(ctx context.Context, run *domain.DigestRun) error {
+				return appErrors.ErrDigestRunClaimed
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the digest run is claimed", func() {
+			claimed, err := jobService.ClaimDigestRun(context.Background(), scheduledAt)
+
+			Convey("Then it is not claimed again", func() {
+				So(err, ShouldBeNil)
+				So(claimed, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a job service and a store that fails to claim the digest run", t, func() {
+		mockMongo := &storeMocks.MongoDBMock{
+			ClaimDigestRunFunc: func(ctx context.Context, run *domain.DigestRun) error {
+				return errors.New("fake store error")
+			},
+		}
+
+		mockStore := store.Datastore{
+			Backend: mockMongo,
+		}
+		jobService := Setup(&mockStore, &clients.ClientList{}, &config.Config{})
+
+		Convey("When the digest run is claimed", func() {
+			claimed, err := jobService.ClaimDigestRun(context.Background(), scheduledAt)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(claimed, ShouldBeFalse)
+			})
+		})
+	})
 }
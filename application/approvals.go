@@ -0,0 +1,50 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+)
+
+// approveJob checks that a user may approve a job in review and approves
+// it, recording their approval when more than one approval is required and
+// scheduling the job to publish at publishAt if it is not nil. The approval,
+// schedule and change of state are stored together, so none of them is
+// stored if the job has left review. The submitter of a job may only
+// approve it with the four eyes override. It returns whether the job has
+// enough approvals to have moved to approved.
+func (js *jobService) approveJob(ctx context.Context, job *domain.Job, userID, reason string, fourEyesOverride bool, publishAt *time.Time) (bool, error) {
+	if job.IsSubmittedBy(userID) && !fourEyesOverride {
+		return false, appErrors.ErrApproverIsSubmitter
+	}
+
+	var approval *domain.Approval
+	if js.config.RequiredApprovals > 1 {
+		approval = domain.NewApproval(userID)
+	}
+
+	approvedJob, err := js.store.ApproveJob(ctx, job.ID, approval, js.config.RequiredApprovals, publishAt)
+	if err != nil {
+		return false, err
+	}
+
+	if publishAt != nil {
+		js.logScheduleChange(ctx, job.JobNumber, publishAt, userID)
+	}
+
+	if approvedJob.State == domain.StateApproved {
+		return true, nil
+	}
+
+	event := domain.NewEvent(job.JobNumber, domain.EventActionApprovalAdded, userID)
+	event.Reason = reason
+	event.Payload = map[string]interface{}{
+		"approvals":          len(approvedJob.Approvals),
+		"required_approvals": js.config.RequiredApprovals,
+	}
+	js.logEvent(ctx, event)
+
+	return false, nil
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// dependantPendingState returns the state that the dependants of a task
+// moving between the provided states are waiting in, if the move means
+// they can never run. A task that fails a stage stops its dependants
+// waiting for that stage, and a task that is cancelled before it starts
+// stops its dependants from starting.
+func dependantPendingState(fromState, toState domain.State) (domain.State, bool) {
+	if toState == domain.StateCancelled && fromState == domain.StateSubmitted {
+		return domain.StateSubmitted, true
+	}
+
+	for _, stage := range domain.GetTaskStages() {
+		if stage.DependencyState != "" && toState == stage.FailureState {
+			return stage.PendingState, true
+		}
+	}
+
+	return "", false
+}
+
+// cascadeTaskState moves the tasks that depend on a task, and are still
+// waiting for it, to the state it moved to. Each dependant is moved through
+// UpdateTaskState, so its own dependants follow. Failures are logged rather
+// than returned, as the task itself has already moved.
+func (js *jobService) cascadeTaskState(ctx context.Context, task *domain.Task, fromState, toState domain.State) {
+	pendingState, ok := dependantPendingState(fromState, toState)
+	if !ok {
+		return
+	}
+
+	logData := log.Data{"task_id": task.ID, "job_number": task.JobNumber, "state": toState}
+
+	dependants, err := js.store.GetDependentTasks(ctx, task.ID)
+	if err != nil {
+		log.Error(ctx, "failed to get dependants of task", err, logData)
+		return
+	}
+
+	reason := fmt.Sprintf("dependency %s moved to %s", task.ID, toState)
+
+	for _, dependant := range dependants {
+		if dependant.State != pendingState {
+			continue
+		}
+
+		if err := js.UpdateTaskState(ctx, dependant.ID, toState, reason); err != nil {
+			logData["dependant_task_id"] = dependant.ID
+			log.Error(ctx, "failed to update state of dependant task", err, logData)
+		}
+	}
+}
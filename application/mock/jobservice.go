@@ -8,7 +8,9 @@ import (
 	sort "github.com/ONSdigital/dis-migration-service/api/sort"
 	"github.com/ONSdigital/dis-migration-service/application"
 	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/stream"
 	"sync"
+	"time"
 )
 
 // Ensure, that JobServiceMock does implement application.JobService.
@@ -21,57 +23,138 @@ var _ application.JobService = &JobServiceMock{}
 //
 //		// make and configure a mocked application.JobService
 //		mockedJobService := &JobServiceMock{
+//			ClaimDigestRunFunc: func(ctx context.Context, scheduledAt time.Time) (bool, error) {
+//				panic("mock out the ClaimDigestRun method")
+//			},
 //			ClaimJobFunc: func(ctx context.Context) (*domain.Job, error) {
 //				panic("mock out the ClaimJob method")
 //			},
-//			ClaimTaskFunc: func(ctx context.Context) (*domain.Task, error) {
+//			ClaimTaskFunc: func(ctx context.Context, excludedTypes map[domain.State][]domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
 //				panic("mock out the ClaimTask method")
 //			},
+//			ClaimWebhookDeliveryFunc: func(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error) {
+//				panic("mock out the ClaimWebhookDelivery method")
+//			},
 //			CountEventsByJobNumberFunc: func(ctx context.Context, jobNumber int) (int, error) {
 //				panic("mock out the CountEventsByJobNumber method")
 //			},
 //			CountTasksByJobNumberFunc: func(ctx context.Context, jobNumber int) (int, error) {
 //				panic("mock out the CountTasksByJobNumber method")
 //			},
+//			CreateBatchFunc: func(ctx context.Context, jobConfigs []*domain.JobConfig, userID string, userAuthToken string, rowDone func()) (*domain.Batch, error) {
+//				panic("mock out the CreateBatch method")
+//			},
+//			CreateCommentFunc: func(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+//				panic("mock out the CreateComment method")
+//			},
 //			CreateEventFunc: func(ctx context.Context, jobNumber int, event *domain.Event) (*domain.Event, error) {
 //				panic("mock out the CreateEvent method")
 //			},
-//			CreateJobFunc: func(ctx context.Context, jobConfig *domain.JobConfig, userID string, userAuthToken string) (*domain.Job, error) {
+//			CreateJobFunc: func(ctx context.Context, jobConfig *domain.JobConfig, userID string, userAuthToken string, idempotencyKey string) (*domain.Job, error) {
 //				panic("mock out the CreateJob method")
 //			},
+//			CreateReleaseGroupFunc: func(ctx context.Context, group *domain.ReleaseGroup) (*domain.ReleaseGroup, error) {
+//				panic("mock out the CreateReleaseGroup method")
+//			},
 //			CreateTaskFunc: func(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error) {
 //				panic("mock out the CreateTask method")
 //			},
+//			CreateWebhookFunc: func(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+//				panic("mock out the CreateWebhook method")
+//			},
+//			DeleteReleaseGroupFunc: func(ctx context.Context, groupID string) error {
+//				panic("mock out the DeleteReleaseGroup method")
+//			},
+//			DeleteWebhookFunc: func(ctx context.Context, webhookID string) error {
+//				panic("mock out the DeleteWebhook method")
+//			},
+//			GetBatchFunc: func(ctx context.Context, batchID string) (*domain.Batch, error) {
+//				panic("mock out the GetBatch method")
+//			},
+//			GetDigestFunc: func(ctx context.Context) (*domain.Digest, error) {
+//				panic("mock out the GetDigest method")
+//			},
 //			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 //				panic("mock out the GetJob method")
 //			},
-//			GetJobEventsFunc: func(ctx context.Context, jobNumber int, limit int, offset int) ([]*domain.Event, int, error) {
+//			GetJobCommentsFunc: func(ctx context.Context, jobNumber int, limit int, offset int) ([]*domain.Comment, int, error) {
+//				panic("mock out the GetJobComments method")
+//			},
+//			GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit int, offset int) ([]*domain.Event, int, error) {
 //				panic("mock out the GetJobEvents method")
 //			},
+//			GetJobEventsAfterFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error) {
+//				panic("mock out the GetJobEventsAfter method")
+//			},
 //			GetJobStatesSummaryFunc: func(ctx context.Context) ([]domain.StateSummary, error) {
 //				panic("mock out the GetJobStatesSummary method")
 //			},
+//			GetJobTaskTreeFunc: func(ctx context.Context, jobNumber int) ([]*domain.TaskNode, int, error) {
+//				panic("mock out the GetJobTaskTree method")
+//			},
 //			GetJobTasksFunc: func(ctx context.Context, states []domain.State, jobNumber int, limit int, offset int) ([]*domain.Task, int, error) {
 //				panic("mock out the GetJobTasks method")
 //			},
-//			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit int, offset int) ([]*domain.Job, int, error) {
+//			GetJobTasksAfterFunc: func(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error) {
+//				panic("mock out the GetJobTasksAfter method")
+//			},
+//			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit int, offset int) ([]*domain.Job, int, error) {
 //				panic("mock out the GetJobs method")
 //			},
+//			GetJobsAfterFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error) {
+//				panic("mock out the GetJobsAfter method")
+//			},
 //			GetNextJobNumberFunc: func(ctx context.Context) (*domain.Counter, error) {
 //				panic("mock out the GetNextJobNumber method")
 //			},
+//			GetReleaseGroupFunc: func(ctx context.Context, groupID string) (*domain.ReleaseGroup, error) {
+//				panic("mock out the GetReleaseGroup method")
+//			},
+//			GetWebhookFunc: func(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+//				panic("mock out the GetWebhook method")
+//			},
+//			GetWebhookDeliveriesFunc: func(ctx context.Context, webhookID string, status domain.WebhookDeliveryStatus, limit int, offset int) ([]*domain.WebhookDelivery, int, error) {
+//				panic("mock out the GetWebhookDeliveries method")
+//			},
+//			GetWebhooksFunc: func(ctx context.Context, limit int, offset int) ([]*domain.Webhook, int, error) {
+//				panic("mock out the GetWebhooks method")
+//			},
+//			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState domain.State, toState domain.State, reason string) error {
+//				panic("mock out the NotifyWebhooks method")
+//			},
+//			ScheduleJobFunc: func(ctx context.Context, jobNumber int, publishAt *time.Time, userID string) error {
+//				panic("mock out the ScheduleJob method")
+//			},
+//			StreamJobFunc: func(ctx context.Context, jobNumber int, lastEventID string) (*stream.Subscription, []*stream.Message, error) {
+//				panic("mock out the StreamJob method")
+//			},
 //			UpdateJobCollectionIDFunc: func(ctx context.Context, jobNumber int, collectionID string) error {
 //				panic("mock out the UpdateJobCollectionID method")
 //			},
-//			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID string) error {
+//			UpdateJobNotificationThreadFunc: func(ctx context.Context, jobNumber int, thread *domain.NotificationThread) error {
+//				panic("mock out the UpdateJobNotificationThread method")
+//			},
+//			UpdateJobPriorityFunc: func(ctx context.Context, jobNumber int, priority int, userID string) error {
+//				panic("mock out the UpdateJobPriority method")
+//			},
+//			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID string, reason string, opts *domain.JobStateOptions) error {
 //				panic("mock out the UpdateJobState method")
 //			},
 //			UpdateTaskFunc: func(ctx context.Context, task *domain.Task) error {
 //				panic("mock out the UpdateTask method")
 //			},
-//			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State) error {
+//			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, reason string) (error) {
 //				panic("mock out the UpdateTaskState method")
 //			},
+//			UpdateWebhookFunc: func(ctx context.Context, webhook *domain.Webhook) error {
+//				panic("mock out the UpdateWebhook method")
+//			},
+//			UpdateWebhookDeliveryFunc: func(ctx context.Context, delivery *domain.WebhookDelivery) error {
+//				panic("mock out the UpdateWebhookDelivery method")
+//			},
+//			WatchWorkFunc: func(ctx context.Context, notify func()) error {
+//				panic("mock out the WatchWork method")
+//			},
 //		}
 //
 //		// use mockedJobService in code that requires application.JobService
@@ -79,11 +162,17 @@ var _ application.JobService = &JobServiceMock{}
 //
 //	}
 type JobServiceMock struct {
+	// ClaimDigestRunFunc mocks the ClaimDigestRun method.
+	ClaimDigestRunFunc func(ctx context.Context, scheduledAt time.Time) (bool, error)
+
 	// ClaimJobFunc mocks the ClaimJob method.
 	ClaimJobFunc func(ctx context.Context) (*domain.Job, error)
 
 	// ClaimTaskFunc mocks the ClaimTask method.
-	ClaimTaskFunc func(ctx context.Context) (*domain.Task, error)
+	ClaimTaskFunc func(ctx context.Context, excludedTypes map[domain.State][]domain.TaskType, excludedJobNumbers []int) (*domain.Task, error)
+
+	// ClaimWebhookDeliveryFunc mocks the ClaimWebhookDelivery method.
+	ClaimWebhookDeliveryFunc func(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error)
 
 	// CountEventsByJobNumberFunc mocks the CountEventsByJobNumber method.
 	CountEventsByJobNumberFunc func(ctx context.Context, jobNumber int) (int, error)
@@ -91,47 +180,129 @@ type JobServiceMock struct {
 	// CountTasksByJobNumberFunc mocks the CountTasksByJobNumber method.
 	CountTasksByJobNumberFunc func(ctx context.Context, jobNumber int) (int, error)
 
+	// CreateBatchFunc mocks the CreateBatch method.
+	CreateBatchFunc func(ctx context.Context, jobConfigs []*domain.JobConfig, userID string, userAuthToken string, rowDone func()) (*domain.Batch, error)
+
+	// CreateCommentFunc mocks the CreateComment method.
+	CreateCommentFunc func(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
+
 	// CreateEventFunc mocks the CreateEvent method.
 	CreateEventFunc func(ctx context.Context, jobNumber int, event *domain.Event) (*domain.Event, error)
 
 	// CreateJobFunc mocks the CreateJob method.
-	CreateJobFunc func(ctx context.Context, jobConfig *domain.JobConfig, userID string, userAuthToken string) (*domain.Job, error)
+	CreateJobFunc func(ctx context.Context, jobConfig *domain.JobConfig, userID string, userAuthToken string, idempotencyKey string) (*domain.Job, error)
+
+	// CreateReleaseGroupFunc mocks the CreateReleaseGroup method.
+	CreateReleaseGroupFunc func(ctx context.Context, group *domain.ReleaseGroup) (*domain.ReleaseGroup, error)
 
 	// CreateTaskFunc mocks the CreateTask method.
 	CreateTaskFunc func(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error)
 
+	// CreateWebhookFunc mocks the CreateWebhook method.
+	CreateWebhookFunc func(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+
+	// DeleteReleaseGroupFunc mocks the DeleteReleaseGroup method.
+	DeleteReleaseGroupFunc func(ctx context.Context, groupID string) error
+
+	// DeleteWebhookFunc mocks the DeleteWebhook method.
+	DeleteWebhookFunc func(ctx context.Context, webhookID string) error
+
+	// GetBatchFunc mocks the GetBatch method.
+	GetBatchFunc func(ctx context.Context, batchID string) (*domain.Batch, error)
+
+	// GetDigestFunc mocks the GetDigest method.
+	GetDigestFunc func(ctx context.Context) (*domain.Digest, error)
+
 	// GetJobFunc mocks the GetJob method.
 	GetJobFunc func(ctx context.Context, jobNumber int) (*domain.Job, error)
 
+	// GetJobCommentsFunc mocks the GetJobComments method.
+	GetJobCommentsFunc func(ctx context.Context, jobNumber int, limit int, offset int) ([]*domain.Comment, int, error)
+
 	// GetJobEventsFunc mocks the GetJobEvents method.
-	GetJobEventsFunc func(ctx context.Context, jobNumber int, limit int, offset int) ([]*domain.Event, int, error)
+	GetJobEventsFunc func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit int, offset int) ([]*domain.Event, int, error)
+
+	// GetJobEventsAfterFunc mocks the GetJobEventsAfter method.
+	GetJobEventsAfterFunc func(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error)
 
 	// GetJobStatesSummaryFunc mocks the GetJobStatesSummary method.
 	GetJobStatesSummaryFunc func(ctx context.Context) ([]domain.StateSummary, error)
 
+	// GetJobTaskTreeFunc mocks the GetJobTaskTree method.
+	GetJobTaskTreeFunc func(ctx context.Context, jobNumber int) ([]*domain.TaskNode, int, error)
+
 	// GetJobTasksFunc mocks the GetJobTasks method.
 	GetJobTasksFunc func(ctx context.Context, states []domain.State, jobNumber int, limit int, offset int) ([]*domain.Task, int, error)
 
+	// GetJobTasksAfterFunc mocks the GetJobTasksAfter method.
+	GetJobTasksAfterFunc func(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error)
+
 	// GetJobsFunc mocks the GetJobs method.
-	GetJobsFunc func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit int, offset int) ([]*domain.Job, int, error)
+	GetJobsFunc func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit int, offset int) ([]*domain.Job, int, error)
+
+	// GetJobsAfterFunc mocks the GetJobsAfter method.
+	GetJobsAfterFunc func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error)
 
 	// GetNextJobNumberFunc mocks the GetNextJobNumber method.
 	GetNextJobNumberFunc func(ctx context.Context) (*domain.Counter, error)
 
+	// GetReleaseGroupFunc mocks the GetReleaseGroup method.
+	GetReleaseGroupFunc func(ctx context.Context, groupID string) (*domain.ReleaseGroup, error)
+
+	// GetWebhookFunc mocks the GetWebhook method.
+	GetWebhookFunc func(ctx context.Context, webhookID string) (*domain.Webhook, error)
+
+	// GetWebhookDeliveriesFunc mocks the GetWebhookDeliveries method.
+	GetWebhookDeliveriesFunc func(ctx context.Context, webhookID string, status domain.WebhookDeliveryStatus, limit int, offset int) ([]*domain.WebhookDelivery, int, error)
+
+	// GetWebhooksFunc mocks the GetWebhooks method.
+	GetWebhooksFunc func(ctx context.Context, limit int, offset int) ([]*domain.Webhook, int, error)
+
+	// NotifyWebhooksFunc mocks the NotifyWebhooks method.
+	NotifyWebhooksFunc func(ctx context.Context, job *domain.Job, fromState domain.State, toState domain.State, reason string) error
+
+	// ScheduleJobFunc mocks the ScheduleJob method.
+	ScheduleJobFunc func(ctx context.Context, jobNumber int, publishAt *time.Time, userID string) error
+
+	// StreamJobFunc mocks the StreamJob method.
+	StreamJobFunc func(ctx context.Context, jobNumber int, lastEventID string) (*stream.Subscription, []*stream.Message, error)
+
 	// UpdateJobCollectionIDFunc mocks the UpdateJobCollectionID method.
 	UpdateJobCollectionIDFunc func(ctx context.Context, jobNumber int, collectionID string) error
 
+	// UpdateJobNotificationThreadFunc mocks the UpdateJobNotificationThread method.
+	UpdateJobNotificationThreadFunc func(ctx context.Context, jobNumber int, thread *domain.NotificationThread) error
+
+	// UpdateJobPriorityFunc mocks the UpdateJobPriority method.
+	UpdateJobPriorityFunc func(ctx context.Context, jobNumber int, priority int, userID string) error
+
 	// UpdateJobStateFunc mocks the UpdateJobState method.
-	UpdateJobStateFunc func(ctx context.Context, jobNumber int, newState domain.State, userID string) error
+	UpdateJobStateFunc func(ctx context.Context, jobNumber int, newState domain.State, userID string, reason string, opts *domain.JobStateOptions) error
 
 	// UpdateTaskFunc mocks the UpdateTask method.
 	UpdateTaskFunc func(ctx context.Context, task *domain.Task) error
 
 	// UpdateTaskStateFunc mocks the UpdateTaskState method.
-	UpdateTaskStateFunc func(ctx context.Context, taskID string, newState domain.State) error
+	UpdateTaskStateFunc func(ctx context.Context, taskID string, newState domain.State, reason string) error
+
+	// UpdateWebhookFunc mocks the UpdateWebhook method.
+	UpdateWebhookFunc func(ctx context.Context, webhook *domain.Webhook) error
+
+	// UpdateWebhookDeliveryFunc mocks the UpdateWebhookDelivery method.
+	UpdateWebhookDeliveryFunc func(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// WatchWorkFunc mocks the WatchWork method.
+	WatchWorkFunc func(ctx context.Context, notify func()) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ClaimDigestRun holds details about calls to the ClaimDigestRun method.
+		ClaimDigestRun []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ScheduledAt is the scheduledAt argument value.
+			ScheduledAt time.Time
+		}
 		// ClaimJob holds details about calls to the ClaimJob method.
 		ClaimJob []struct {
 			// Ctx is the ctx argument value.
@@ -141,6 +312,17 @@ type JobServiceMock struct {
 		ClaimTask []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// ExcludedTypes is the excludedTypes argument value.
+			ExcludedTypes map[domain.State][]domain.TaskType
+			// ExcludedJobNumbers is the excludedJobNumbers argument value.
+			ExcludedJobNumbers []int
+		}
+		// ClaimWebhookDelivery holds details about calls to the ClaimWebhookDelivery method.
+		ClaimWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// LeaseUntil is the leaseUntil argument value.
+			LeaseUntil time.Time
 		}
 		// CountEventsByJobNumber holds details about calls to the CountEventsByJobNumber method.
 		CountEventsByJobNumber []struct {
@@ -156,6 +338,26 @@ type JobServiceMock struct {
 			// JobNumber is the jobNumber argument value.
 			JobNumber int
 		}
+		// CreateBatch holds details about calls to the CreateBatch method.
+		CreateBatch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobConfigs is the jobConfigs argument value.
+			JobConfigs []*domain.JobConfig
+			// UserID is the userID argument value.
+			UserID string
+			// UserAuthToken is the userAuthToken argument value.
+			UserAuthToken string
+			// RowDone is the rowDone argument value.
+			RowDone func()
+		}
+		// CreateComment holds details about calls to the CreateComment method.
+		CreateComment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Comment is the comment argument value.
+			Comment *domain.Comment
+		}
 		// CreateEvent holds details about calls to the CreateEvent method.
 		CreateEvent []struct {
 			// Ctx is the ctx argument value.
@@ -175,6 +377,15 @@ type JobServiceMock struct {
 			UserID string
 			// UserAuthToken is the userAuthToken argument value.
 			UserAuthToken string
+			// IdempotencyKey is the idempotencyKey argument value.
+			IdempotencyKey string
+		}
+		// CreateReleaseGroup holds details about calls to the CreateReleaseGroup method.
+		CreateReleaseGroup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Group is the group argument value.
+			Group *domain.ReleaseGroup
 		}
 		// CreateTask holds details about calls to the CreateTask method.
 		CreateTask []struct {
@@ -185,6 +396,39 @@ type JobServiceMock struct {
 			// Task is the task argument value.
 			Task *domain.Task
 		}
+		// CreateWebhook holds details about calls to the CreateWebhook method.
+		CreateWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Webhook is the webhook argument value.
+			Webhook *domain.Webhook
+		}
+		// DeleteReleaseGroup holds details about calls to the DeleteReleaseGroup method.
+		DeleteReleaseGroup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GroupID is the groupID argument value.
+			GroupID string
+		}
+		// DeleteWebhook holds details about calls to the DeleteWebhook method.
+		DeleteWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WebhookID is the webhookID argument value.
+			WebhookID string
+		}
+		// GetBatch holds details about calls to the GetBatch method.
+		GetBatch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BatchID is the batchID argument value.
+			BatchID string
+		}
+		// GetDigest holds details about calls to the GetDigest method.
+		GetDigest []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetJob holds details about calls to the GetJob method.
 		GetJob []struct {
 			// Ctx is the ctx argument value.
@@ -192,22 +436,55 @@ type JobServiceMock struct {
 			// JobNumber is the jobNumber argument value.
 			JobNumber int
 		}
+		// GetJobComments holds details about calls to the GetJobComments method.
+		GetJobComments []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
 		// GetJobEvents holds details about calls to the GetJobEvents method.
 		GetJobEvents []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// JobNumber is the jobNumber argument value.
 			JobNumber int
+			// Filter is the filter argument value.
+			Filter *domain.EventFilter
 			// Limit is the limit argument value.
 			Limit int
 			// Offset is the offset argument value.
 			Offset int
 		}
+		// GetJobEventsAfter holds details about calls to the GetJobEventsAfter method.
+		GetJobEventsAfter []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+			// Filter is the filter argument value.
+			Filter *domain.EventFilter
+			// After is the after argument value.
+			After *domain.Cursor
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetJobStatesSummary holds details about calls to the GetJobStatesSummary method.
 		GetJobStatesSummary []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// GetJobTaskTree holds details about calls to the GetJobTaskTree method.
+		GetJobTaskTree []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+		}
 		// GetJobTasks holds details about calls to the GetJobTasks method.
 		GetJobTasks []struct {
 			// Ctx is the ctx argument value.
@@ -221,6 +498,19 @@ type JobServiceMock struct {
 			// Offset is the offset argument value.
 			Offset int
 		}
+		// GetJobTasksAfter holds details about calls to the GetJobTasksAfter method.
+		GetJobTasksAfter []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// States is the states argument value.
+			States []domain.State
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+			// After is the after argument value.
+			After *domain.Cursor
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetJobs holds details about calls to the GetJobs method.
 		GetJobs []struct {
 			// Ctx is the ctx argument value.
@@ -229,18 +519,102 @@ type JobServiceMock struct {
 			Field sort.SortParameterField
 			// Direction is the direction argument value.
 			Direction sort.SortParameterDirection
-			// States is the states argument value.
-			States []domain.State
+			// Filter is the filter argument value.
+			Filter *domain.JobFilter
 			// Limit is the limit argument value.
 			Limit int
 			// Offset is the offset argument value.
 			Offset int
 		}
+		// GetJobsAfter holds details about calls to the GetJobsAfter method.
+		GetJobsAfter []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Field is the field argument value.
+			Field sort.SortParameterField
+			// Direction is the direction argument value.
+			Direction sort.SortParameterDirection
+			// Filter is the filter argument value.
+			Filter *domain.JobFilter
+			// After is the after argument value.
+			After *domain.Cursor
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetNextJobNumber holds details about calls to the GetNextJobNumber method.
 		GetNextJobNumber []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// GetReleaseGroup holds details about calls to the GetReleaseGroup method.
+		GetReleaseGroup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GroupID is the groupID argument value.
+			GroupID string
+		}
+		// GetWebhook holds details about calls to the GetWebhook method.
+		GetWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WebhookID is the webhookID argument value.
+			WebhookID string
+		}
+		// GetWebhookDeliveries holds details about calls to the GetWebhookDeliveries method.
+		GetWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WebhookID is the webhookID argument value.
+			WebhookID string
+			// Status is the status argument value.
+			Status domain.WebhookDeliveryStatus
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
+		// GetWebhooks holds details about calls to the GetWebhooks method.
+		GetWebhooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
+		// NotifyWebhooks holds details about calls to the NotifyWebhooks method.
+		NotifyWebhooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Job is the job argument value.
+			Job *domain.Job
+			// FromState is the fromState argument value.
+			FromState domain.State
+			// ToState is the toState argument value.
+			ToState domain.State
+			// Reason is the reason argument value.
+			Reason string
+		}
+		// ScheduleJob holds details about calls to the ScheduleJob method.
+		ScheduleJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+			// PublishAt is the publishAt argument value.
+			PublishAt *time.Time
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// StreamJob holds details about calls to the StreamJob method.
+		StreamJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+			// LastEventID is the lastEventID argument value.
+			LastEventID string
+		}
 		// UpdateJobCollectionID holds details about calls to the UpdateJobCollectionID method.
 		UpdateJobCollectionID []struct {
 			// Ctx is the ctx argument value.
@@ -250,6 +624,26 @@ type JobServiceMock struct {
 			// CollectionID is the collectionID argument value.
 			CollectionID string
 		}
+		// UpdateJobNotificationThread holds details about calls to the UpdateJobNotificationThread method.
+		UpdateJobNotificationThread []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+			// Thread is the thread argument value.
+			Thread *domain.NotificationThread
+		}
+		// UpdateJobPriority holds details about calls to the UpdateJobPriority method.
+		UpdateJobPriority []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+			// Priority is the priority argument value.
+			Priority int
+			// UserID is the userID argument value.
+			UserID string
+		}
 		// UpdateJobState holds details about calls to the UpdateJobState method.
 		UpdateJobState []struct {
 			// Ctx is the ctx argument value.
@@ -260,6 +654,10 @@ type JobServiceMock struct {
 			NewState domain.State
 			// UserID is the userID argument value.
 			UserID string
+			// Reason is the reason argument value.
+			Reason string
+			// Opts is the opts argument value.
+			Opts *domain.JobStateOptions
 		}
 		// UpdateTask holds details about calls to the UpdateTask method.
 		UpdateTask []struct {
@@ -276,25 +674,111 @@ type JobServiceMock struct {
 			TaskID string
 			// NewState is the newState argument value.
 			NewState domain.State
+			// Reason is the reason argument value.
+			Reason string
 		}
+		// UpdateWebhook holds details about calls to the UpdateWebhook method.
+		UpdateWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Webhook is the webhook argument value.
+			Webhook *domain.Webhook
+		}
+		// UpdateWebhookDelivery holds details about calls to the UpdateWebhookDelivery method.
+		UpdateWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Delivery is the delivery argument value.
+			Delivery *domain.WebhookDelivery
+		}
+		// WatchWork holds details about calls to the WatchWork method.
+		WatchWork []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Notify is the notify argument value.
+			Notify func()
+		}
+	}
+	lockClaimDigestRun              sync.RWMutex
+	lockClaimJob                    sync.RWMutex
+	lockClaimTask                   sync.RWMutex
+	lockClaimWebhookDelivery        sync.RWMutex
+	lockCountEventsByJobNumber      sync.RWMutex
+	lockCountTasksByJobNumber       sync.RWMutex
+	lockCreateBatch                 sync.RWMutex
+	lockCreateComment               sync.RWMutex
+	lockCreateEvent                 sync.RWMutex
+	lockCreateJob                   sync.RWMutex
+	lockCreateReleaseGroup          sync.RWMutex
+	lockCreateTask                  sync.RWMutex
+	lockCreateWebhook               sync.RWMutex
+	lockDeleteReleaseGroup          sync.RWMutex
+	lockDeleteWebhook               sync.RWMutex
+	lockGetBatch                    sync.RWMutex
+	lockGetDigest                   sync.RWMutex
+	lockGetJob                      sync.RWMutex
+	lockGetJobComments              sync.RWMutex
+	lockGetJobEvents                sync.RWMutex
+	lockGetJobEventsAfter           sync.RWMutex
+	lockGetJobStatesSummary         sync.RWMutex
+	lockGetJobTaskTree              sync.RWMutex
+	lockGetJobTasks                 sync.RWMutex
+	lockGetJobTasksAfter            sync.RWMutex
+	lockGetJobs                     sync.RWMutex
+	lockGetJobsAfter                sync.RWMutex
+	lockGetNextJobNumber            sync.RWMutex
+	lockGetReleaseGroup             sync.RWMutex
+	lockGetWebhook                  sync.RWMutex
+	lockGetWebhookDeliveries        sync.RWMutex
+	lockGetWebhooks                 sync.RWMutex
+	lockNotifyWebhooks              sync.RWMutex
+	lockScheduleJob                 sync.RWMutex
+	lockStreamJob                   sync.RWMutex
+	lockUpdateJobCollectionID       sync.RWMutex
+	lockUpdateJobNotificationThread sync.RWMutex
+	lockUpdateJobPriority           sync.RWMutex
+	lockUpdateJobState              sync.RWMutex
+	lockUpdateTask                  sync.RWMutex
+	lockUpdateTaskState             sync.RWMutex
+	lockUpdateWebhook               sync.RWMutex
+	lockUpdateWebhookDelivery       sync.RWMutex
+	lockWatchWork                   sync.RWMutex
+}
+
+// ClaimDigestRun calls ClaimDigestRunFunc.
+func (mock *JobServiceMock) ClaimDigestRun(ctx context.Context, scheduledAt time.Time) (bool, error) {
+	if mock.ClaimDigestRunFunc == nil {
+		panic("JobServiceMock.ClaimDigestRunFunc: method is nil but JobService.ClaimDigestRun was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ScheduledAt time.Time
+	}{
+		Ctx:         ctx,
+		ScheduledAt: scheduledAt,
+	}
+	mock.lockClaimDigestRun.Lock()
+	mock.calls.ClaimDigestRun = append(mock.calls.ClaimDigestRun, callInfo)
+	mock.lockClaimDigestRun.Unlock()
+	return mock.ClaimDigestRunFunc(ctx, scheduledAt)
+}
+
+// ClaimDigestRunCalls gets all the calls that were made to ClaimDigestRun.
+// Check the length with:
+//
+//	len(mockedJobService.ClaimDigestRunCalls())
+func (mock *JobServiceMock) ClaimDigestRunCalls() []struct {
+	Ctx         context.Context
+	ScheduledAt time.Time
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ScheduledAt time.Time
 	}
-	lockClaimJob               sync.RWMutex
-	lockClaimTask              sync.RWMutex
-	lockCountEventsByJobNumber sync.RWMutex
-	lockCountTasksByJobNumber  sync.RWMutex
-	lockCreateEvent            sync.RWMutex
-	lockCreateJob              sync.RWMutex
-	lockCreateTask             sync.RWMutex
-	lockGetJob                 sync.RWMutex
-	lockGetJobEvents           sync.RWMutex
-	lockGetJobStatesSummary    sync.RWMutex
-	lockGetJobTasks            sync.RWMutex
-	lockGetJobs                sync.RWMutex
-	lockGetNextJobNumber       sync.RWMutex
-	lockUpdateJobCollectionID  sync.RWMutex
-	lockUpdateJobState         sync.RWMutex
-	lockUpdateTask             sync.RWMutex
-	lockUpdateTaskState        sync.RWMutex
+	mock.lockClaimDigestRun.RLock()
+	calls = mock.calls.ClaimDigestRun
+	mock.lockClaimDigestRun.RUnlock()
+	return calls
 }
 
 // ClaimJob calls ClaimJobFunc.
@@ -330,19 +814,23 @@ func (mock *JobServiceMock) ClaimJobCalls() []struct {
 }
 
 // ClaimTask calls ClaimTaskFunc.
-func (mock *JobServiceMock) ClaimTask(ctx context.Context) (*domain.Task, error) {
+func (mock *JobServiceMock) ClaimTask(ctx context.Context, excludedTypes map[domain.State][]domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
 	if mock.ClaimTaskFunc == nil {
 		panic("JobServiceMock.ClaimTaskFunc: method is nil but JobService.ClaimTask was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx                context.Context
+		ExcludedTypes      map[domain.State][]domain.TaskType
+		ExcludedJobNumbers []int
 	}{
-		Ctx: ctx,
+		Ctx:                ctx,
+		ExcludedTypes:      excludedTypes,
+		ExcludedJobNumbers: excludedJobNumbers,
 	}
 	mock.lockClaimTask.Lock()
 	mock.calls.ClaimTask = append(mock.calls.ClaimTask, callInfo)
 	mock.lockClaimTask.Unlock()
-	return mock.ClaimTaskFunc(ctx)
+	return mock.ClaimTaskFunc(ctx, excludedTypes, excludedJobNumbers)
 }
 
 // ClaimTaskCalls gets all the calls that were made to ClaimTask.
@@ -350,10 +838,14 @@ func (mock *JobServiceMock) ClaimTask(ctx context.Context) (*domain.Task, error)
 //
 //	len(mockedJobService.ClaimTaskCalls())
 func (mock *JobServiceMock) ClaimTaskCalls() []struct {
-	Ctx context.Context
+	Ctx                context.Context
+	ExcludedTypes      map[domain.State][]domain.TaskType
+	ExcludedJobNumbers []int
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx                context.Context
+		ExcludedTypes      map[domain.State][]domain.TaskType
+		ExcludedJobNumbers []int
 	}
 	mock.lockClaimTask.RLock()
 	calls = mock.calls.ClaimTask
@@ -361,6 +853,42 @@ func (mock *JobServiceMock) ClaimTaskCalls() []struct {
 	return calls
 }
 
+// ClaimWebhookDelivery calls ClaimWebhookDeliveryFunc.
+func (mock *JobServiceMock) ClaimWebhookDelivery(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error) {
+	if mock.ClaimWebhookDeliveryFunc == nil {
+		panic("JobServiceMock.ClaimWebhookDeliveryFunc: method is nil but JobService.ClaimWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		LeaseUntil time.Time
+	}{
+		Ctx:        ctx,
+		LeaseUntil: leaseUntil,
+	}
+	mock.lockClaimWebhookDelivery.Lock()
+	mock.calls.ClaimWebhookDelivery = append(mock.calls.ClaimWebhookDelivery, callInfo)
+	mock.lockClaimWebhookDelivery.Unlock()
+	return mock.ClaimWebhookDeliveryFunc(ctx, leaseUntil)
+}
+
+// ClaimWebhookDeliveryCalls gets all the calls that were made to ClaimWebhookDelivery.
+// Check the length with:
+//
+//	len(mockedJobService.ClaimWebhookDeliveryCalls())
+func (mock *JobServiceMock) ClaimWebhookDeliveryCalls() []struct {
+	Ctx        context.Context
+	LeaseUntil time.Time
+} {
+	var calls []struct {
+		Ctx        context.Context
+		LeaseUntil time.Time
+	}
+	mock.lockClaimWebhookDelivery.RLock()
+	calls = mock.calls.ClaimWebhookDelivery
+	mock.lockClaimWebhookDelivery.RUnlock()
+	return calls
+}
+
 // CountEventsByJobNumber calls CountEventsByJobNumberFunc.
 func (mock *JobServiceMock) CountEventsByJobNumber(ctx context.Context, jobNumber int) (int, error) {
 	if mock.CountEventsByJobNumberFunc == nil {
@@ -433,66 +961,152 @@ func (mock *JobServiceMock) CountTasksByJobNumberCalls() []struct {
 	return calls
 }
 
-// CreateEvent calls CreateEventFunc.
-func (mock *JobServiceMock) CreateEvent(ctx context.Context, jobNumber int, event *domain.Event) (*domain.Event, error) {
-	if mock.CreateEventFunc == nil {
-		panic("JobServiceMock.CreateEventFunc: method is nil but JobService.CreateEvent was just called")
+// CreateBatch calls CreateBatchFunc.
+func (mock *JobServiceMock) CreateBatch(ctx context.Context, jobConfigs []*domain.JobConfig, userID string, userAuthToken string, rowDone func()) (*domain.Batch, error) {
+	if mock.CreateBatchFunc == nil {
+		panic("JobServiceMock.CreateBatchFunc: method is nil but JobService.CreateBatch was just called")
 	}
 	callInfo := struct {
-		Ctx       context.Context
-		JobNumber int
-		Event     *domain.Event
+		Ctx           context.Context
+		JobConfigs    []*domain.JobConfig
+		UserID        string
+		UserAuthToken string
+		RowDone       func()
 	}{
-		Ctx:       ctx,
-		JobNumber: jobNumber,
-		Event:     event,
+		Ctx:           ctx,
+		JobConfigs:    jobConfigs,
+		UserID:        userID,
+		UserAuthToken: userAuthToken,
+		RowDone:       rowDone,
 	}
-	mock.lockCreateEvent.Lock()
-	mock.calls.CreateEvent = append(mock.calls.CreateEvent, callInfo)
-	mock.lockCreateEvent.Unlock()
-	return mock.CreateEventFunc(ctx, jobNumber, event)
+	mock.lockCreateBatch.Lock()
+	mock.calls.CreateBatch = append(mock.calls.CreateBatch, callInfo)
+	mock.lockCreateBatch.Unlock()
+	return mock.CreateBatchFunc(ctx, jobConfigs, userID, userAuthToken, rowDone)
 }
 
-// CreateEventCalls gets all the calls that were made to CreateEvent.
+// CreateBatchCalls gets all the calls that were made to CreateBatch.
 // Check the length with:
 //
-//	len(mockedJobService.CreateEventCalls())
-func (mock *JobServiceMock) CreateEventCalls() []struct {
-	Ctx       context.Context
-	JobNumber int
-	Event     *domain.Event
+//	len(mockedJobService.CreateBatchCalls())
+func (mock *JobServiceMock) CreateBatchCalls() []struct {
+	Ctx           context.Context
+	JobConfigs    []*domain.JobConfig
+	UserID        string
+	UserAuthToken string
+	RowDone       func()
 } {
 	var calls []struct {
-		Ctx       context.Context
-		JobNumber int
-		Event     *domain.Event
+		Ctx           context.Context
+		JobConfigs    []*domain.JobConfig
+		UserID        string
+		UserAuthToken string
+		RowDone       func()
 	}
-	mock.lockCreateEvent.RLock()
-	calls = mock.calls.CreateEvent
-	mock.lockCreateEvent.RUnlock()
+	mock.lockCreateBatch.RLock()
+	calls = mock.calls.CreateBatch
+	mock.lockCreateBatch.RUnlock()
 	return calls
 }
 
-// CreateJob calls CreateJobFunc.
-func (mock *JobServiceMock) CreateJob(ctx context.Context, jobConfig *domain.JobConfig, userID string, userAuthToken string) (*domain.Job, error) {
+// CreateComment calls CreateCommentFunc.
+func (mock *JobServiceMock) CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	if mock.CreateCommentFunc == nil {
+		panic("JobServiceMock.CreateCommentFunc: method is nil but JobService.CreateComment was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Comment *domain.Comment
+	}{
+		Ctx:     ctx,
+		Comment: comment,
+	}
+	mock.lockCreateComment.Lock()
+	mock.calls.CreateComment = append(mock.calls.CreateComment, callInfo)
+	mock.lockCreateComment.Unlock()
+	return mock.CreateCommentFunc(ctx, comment)
+}
+
+// CreateCommentCalls gets all the calls that were made to CreateComment.
+// Check the length with:
+//
+//	len(mockedJobService.CreateCommentCalls())
+func (mock *JobServiceMock) CreateCommentCalls() []struct {
+	Ctx     context.Context
+	Comment *domain.Comment
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Comment *domain.Comment
+	}
+	mock.lockCreateComment.RLock()
+	calls = mock.calls.CreateComment
+	mock.lockCreateComment.RUnlock()
+	return calls
+}
+
+// CreateEvent calls CreateEventFunc.
+func (mock *JobServiceMock) CreateEvent(ctx context.Context, jobNumber int, event *domain.Event) (*domain.Event, error) {
+	if mock.CreateEventFunc == nil {
+		panic("JobServiceMock.CreateEventFunc: method is nil but JobService.CreateEvent was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+		Event     *domain.Event
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+		Event:     event,
+	}
+	mock.lockCreateEvent.Lock()
+	mock.calls.CreateEvent = append(mock.calls.CreateEvent, callInfo)
+	mock.lockCreateEvent.Unlock()
+	return mock.CreateEventFunc(ctx, jobNumber, event)
+}
+
+// CreateEventCalls gets all the calls that were made to CreateEvent.
+// Check the length with:
+//
+//	len(mockedJobService.CreateEventCalls())
+func (mock *JobServiceMock) CreateEventCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+	Event     *domain.Event
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+		Event     *domain.Event
+	}
+	mock.lockCreateEvent.RLock()
+	calls = mock.calls.CreateEvent
+	mock.lockCreateEvent.RUnlock()
+	return calls
+}
+
+// CreateJob calls CreateJobFunc.
+func (mock *JobServiceMock) CreateJob(ctx context.Context, jobConfig *domain.JobConfig, userID string, userAuthToken string, idempotencyKey string) (*domain.Job, error) {
 	if mock.CreateJobFunc == nil {
 		panic("JobServiceMock.CreateJobFunc: method is nil but JobService.CreateJob was just called")
 	}
 	callInfo := struct {
-		Ctx           context.Context
-		JobConfig     *domain.JobConfig
-		UserID        string
-		UserAuthToken string
+		Ctx            context.Context
+		JobConfig      *domain.JobConfig
+		UserID         string
+		UserAuthToken  string
+		IdempotencyKey string
 	}{
-		Ctx:           ctx,
-		JobConfig:     jobConfig,
-		UserID:        userID,
-		UserAuthToken: userAuthToken,
+		Ctx:            ctx,
+		JobConfig:      jobConfig,
+		UserID:         userID,
+		UserAuthToken:  userAuthToken,
+		IdempotencyKey: idempotencyKey,
 	}
 	mock.lockCreateJob.Lock()
 	mock.calls.CreateJob = append(mock.calls.CreateJob, callInfo)
 	mock.lockCreateJob.Unlock()
-	return mock.CreateJobFunc(ctx, jobConfig, userID, userAuthToken)
+	return mock.CreateJobFunc(ctx, jobConfig, userID, userAuthToken, idempotencyKey)
 }
 
 // CreateJobCalls gets all the calls that were made to CreateJob.
@@ -500,16 +1114,18 @@ func (mock *JobServiceMock) CreateJob(ctx context.Context, jobConfig *domain.Job
 //
 //	len(mockedJobService.CreateJobCalls())
 func (mock *JobServiceMock) CreateJobCalls() []struct {
-	Ctx           context.Context
-	JobConfig     *domain.JobConfig
-	UserID        string
-	UserAuthToken string
+	Ctx            context.Context
+	JobConfig      *domain.JobConfig
+	UserID         string
+	UserAuthToken  string
+	IdempotencyKey string
 } {
 	var calls []struct {
-		Ctx           context.Context
-		JobConfig     *domain.JobConfig
-		UserID        string
-		UserAuthToken string
+		Ctx            context.Context
+		JobConfig      *domain.JobConfig
+		UserID         string
+		UserAuthToken  string
+		IdempotencyKey string
 	}
 	mock.lockCreateJob.RLock()
 	calls = mock.calls.CreateJob
@@ -517,287 +1133,1023 @@ func (mock *JobServiceMock) CreateJobCalls() []struct {
 	return calls
 }
 
+// CreateReleaseGroup calls CreateReleaseGroupFunc.
+func (mock *JobServiceMock) CreateReleaseGroup(ctx context.Context, group *domain.ReleaseGroup) (*domain.ReleaseGroup, error) {
+	if mock.CreateReleaseGroupFunc == nil {
+		panic("JobServiceMock.CreateReleaseGroupFunc: method is nil but JobService.CreateReleaseGroup was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Group *domain.ReleaseGroup
+	}{
+		Ctx:   ctx,
+		Group: group,
+	}
+	mock.lockCreateReleaseGroup.Lock()
+	mock.calls.CreateReleaseGroup = append(mock.calls.CreateReleaseGroup, callInfo)
+	mock.lockCreateReleaseGroup.Unlock()
+	return mock.CreateReleaseGroupFunc(ctx, group)
+}
+
+// CreateReleaseGroupCalls gets all the calls that were made to CreateReleaseGroup.
+// Check the length with:
+//
+//	len(mockedJobService.CreateReleaseGroupCalls())
+func (mock *JobServiceMock) CreateReleaseGroupCalls() []struct {
+	Ctx   context.Context
+	Group *domain.ReleaseGroup
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Group *domain.ReleaseGroup
+	}
+	mock.lockCreateReleaseGroup.RLock()
+	calls = mock.calls.CreateReleaseGroup
+	mock.lockCreateReleaseGroup.RUnlock()
+	return calls
+}
+
 // CreateTask calls CreateTaskFunc.
 func (mock *JobServiceMock) CreateTask(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error) {
 	if mock.CreateTaskFunc == nil {
 		panic("JobServiceMock.CreateTaskFunc: method is nil but JobService.CreateTask was just called")
 	}
 	callInfo := struct {
-		Ctx       context.Context
-		JobNumber int
-		Task      *domain.Task
+		Ctx       context.Context
+		JobNumber int
+		Task      *domain.Task
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+		Task:      task,
+	}
+	mock.lockCreateTask.Lock()
+	mock.calls.CreateTask = append(mock.calls.CreateTask, callInfo)
+	mock.lockCreateTask.Unlock()
+	return mock.CreateTaskFunc(ctx, jobNumber, task)
+}
+
+// CreateTaskCalls gets all the calls that were made to CreateTask.
+// Check the length with:
+//
+//	len(mockedJobService.CreateTaskCalls())
+func (mock *JobServiceMock) CreateTaskCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+	Task      *domain.Task
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+		Task      *domain.Task
+	}
+	mock.lockCreateTask.RLock()
+	calls = mock.calls.CreateTask
+	mock.lockCreateTask.RUnlock()
+	return calls
+}
+
+// CreateWebhook calls CreateWebhookFunc.
+func (mock *JobServiceMock) CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	if mock.CreateWebhookFunc == nil {
+		panic("JobServiceMock.CreateWebhookFunc: method is nil but JobService.CreateWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Webhook *domain.Webhook
+	}{
+		Ctx:     ctx,
+		Webhook: webhook,
+	}
+	mock.lockCreateWebhook.Lock()
+	mock.calls.CreateWebhook = append(mock.calls.CreateWebhook, callInfo)
+	mock.lockCreateWebhook.Unlock()
+	return mock.CreateWebhookFunc(ctx, webhook)
+}
+
+// CreateWebhookCalls gets all the calls that were made to CreateWebhook.
+// Check the length with:
+//
+//	len(mockedJobService.CreateWebhookCalls())
+func (mock *JobServiceMock) CreateWebhookCalls() []struct {
+	Ctx     context.Context
+	Webhook *domain.Webhook
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Webhook *domain.Webhook
+	}
+	mock.lockCreateWebhook.RLock()
+	calls = mock.calls.CreateWebhook
+	mock.lockCreateWebhook.RUnlock()
+	return calls
+}
+
+// DeleteReleaseGroup calls DeleteReleaseGroupFunc.
+func (mock *JobServiceMock) DeleteReleaseGroup(ctx context.Context, groupID string) error {
+	if mock.DeleteReleaseGroupFunc == nil {
+		panic("JobServiceMock.DeleteReleaseGroupFunc: method is nil but JobService.DeleteReleaseGroup was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		GroupID string
+	}{
+		Ctx:     ctx,
+		GroupID: groupID,
+	}
+	mock.lockDeleteReleaseGroup.Lock()
+	mock.calls.DeleteReleaseGroup = append(mock.calls.DeleteReleaseGroup, callInfo)
+	mock.lockDeleteReleaseGroup.Unlock()
+	return mock.DeleteReleaseGroupFunc(ctx, groupID)
+}
+
+// DeleteReleaseGroupCalls gets all the calls that were made to DeleteReleaseGroup.
+// Check the length with:
+//
+//	len(mockedJobService.DeleteReleaseGroupCalls())
+func (mock *JobServiceMock) DeleteReleaseGroupCalls() []struct {
+	Ctx     context.Context
+	GroupID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		GroupID string
+	}
+	mock.lockDeleteReleaseGroup.RLock()
+	calls = mock.calls.DeleteReleaseGroup
+	mock.lockDeleteReleaseGroup.RUnlock()
+	return calls
+}
+
+// DeleteWebhook calls DeleteWebhookFunc.
+func (mock *JobServiceMock) DeleteWebhook(ctx context.Context, webhookID string) error {
+	if mock.DeleteWebhookFunc == nil {
+		panic("JobServiceMock.DeleteWebhookFunc: method is nil but JobService.DeleteWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		WebhookID string
+	}{
+		Ctx:       ctx,
+		WebhookID: webhookID,
+	}
+	mock.lockDeleteWebhook.Lock()
+	mock.calls.DeleteWebhook = append(mock.calls.DeleteWebhook, callInfo)
+	mock.lockDeleteWebhook.Unlock()
+	return mock.DeleteWebhookFunc(ctx, webhookID)
+}
+
+// DeleteWebhookCalls gets all the calls that were made to DeleteWebhook.
+// Check the length with:
+//
+//	len(mockedJobService.DeleteWebhookCalls())
+func (mock *JobServiceMock) DeleteWebhookCalls() []struct {
+	Ctx       context.Context
+	WebhookID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		WebhookID string
+	}
+	mock.lockDeleteWebhook.RLock()
+	calls = mock.calls.DeleteWebhook
+	mock.lockDeleteWebhook.RUnlock()
+	return calls
+}
+
+// GetBatch calls GetBatchFunc.
+func (mock *JobServiceMock) GetBatch(ctx context.Context, batchID string) (*domain.Batch, error) {
+	if mock.GetBatchFunc == nil {
+		panic("JobServiceMock.GetBatchFunc: method is nil but JobService.GetBatch was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		BatchID string
+	}{
+		Ctx:     ctx,
+		BatchID: batchID,
+	}
+	mock.lockGetBatch.Lock()
+	mock.calls.GetBatch = append(mock.calls.GetBatch, callInfo)
+	mock.lockGetBatch.Unlock()
+	return mock.GetBatchFunc(ctx, batchID)
+}
+
+// GetBatchCalls gets all the calls that were made to GetBatch.
+// Check the length with:
+//
+//	len(mockedJobService.GetBatchCalls())
+func (mock *JobServiceMock) GetBatchCalls() []struct {
+	Ctx     context.Context
+	BatchID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		BatchID string
+	}
+	mock.lockGetBatch.RLock()
+	calls = mock.calls.GetBatch
+	mock.lockGetBatch.RUnlock()
+	return calls
+}
+
+// GetDigest calls GetDigestFunc.
+func (mock *JobServiceMock) GetDigest(ctx context.Context) (*domain.Digest, error) {
+	if mock.GetDigestFunc == nil {
+		panic("JobServiceMock.GetDigestFunc: method is nil but JobService.GetDigest was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetDigest.Lock()
+	mock.calls.GetDigest = append(mock.calls.GetDigest, callInfo)
+	mock.lockGetDigest.Unlock()
+	return mock.GetDigestFunc(ctx)
+}
+
+// GetDigestCalls gets all the calls that were made to GetDigest.
+// Check the length with:
+//
+//	len(mockedJobService.GetDigestCalls())
+func (mock *JobServiceMock) GetDigestCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetDigest.RLock()
+	calls = mock.calls.GetDigest
+	mock.lockGetDigest.RUnlock()
+	return calls
+}
+
+// GetJob calls GetJobFunc.
+func (mock *JobServiceMock) GetJob(ctx context.Context, jobNumber int) (*domain.Job, error) {
+	if mock.GetJobFunc == nil {
+		panic("JobServiceMock.GetJobFunc: method is nil but JobService.GetJob was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+	}
+	mock.lockGetJob.Lock()
+	mock.calls.GetJob = append(mock.calls.GetJob, callInfo)
+	mock.lockGetJob.Unlock()
+	return mock.GetJobFunc(ctx, jobNumber)
+}
+
+// GetJobCalls gets all the calls that were made to GetJob.
+// Check the length with:
+//
+//	len(mockedJobService.GetJobCalls())
+func (mock *JobServiceMock) GetJobCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+	}
+	mock.lockGetJob.RLock()
+	calls = mock.calls.GetJob
+	mock.lockGetJob.RUnlock()
+	return calls
+}
+
+// GetJobComments calls GetJobCommentsFunc.
+func (mock *JobServiceMock) GetJobComments(ctx context.Context, jobNumber int, limit int, offset int) ([]*domain.Comment, int, error) {
+	if mock.GetJobCommentsFunc == nil {
+		panic("JobServiceMock.GetJobCommentsFunc: method is nil but JobService.GetJobComments was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+		Limit     int
+		Offset    int
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	mock.lockGetJobComments.Lock()
+	mock.calls.GetJobComments = append(mock.calls.GetJobComments, callInfo)
+	mock.lockGetJobComments.Unlock()
+	return mock.GetJobCommentsFunc(ctx, jobNumber, limit, offset)
+}
+
+// GetJobCommentsCalls gets all the calls that were made to GetJobComments.
+// Check the length with:
+//
+//	len(mockedJobService.GetJobCommentsCalls())
+func (mock *JobServiceMock) GetJobCommentsCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+	Limit     int
+	Offset    int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+		Limit     int
+		Offset    int
+	}
+	mock.lockGetJobComments.RLock()
+	calls = mock.calls.GetJobComments
+	mock.lockGetJobComments.RUnlock()
+	return calls
+}
+
+// GetJobEvents calls GetJobEventsFunc.
+func (mock *JobServiceMock) GetJobEvents(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit int, offset int) ([]*domain.Event, int, error) {
+	if mock.GetJobEventsFunc == nil {
+		panic("JobServiceMock.GetJobEventsFunc: method is nil but JobService.GetJobEvents was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+		Filter    *domain.EventFilter
+		Limit     int
+		Offset    int
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+		Filter:    filter,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	mock.lockGetJobEvents.Lock()
+	mock.calls.GetJobEvents = append(mock.calls.GetJobEvents, callInfo)
+	mock.lockGetJobEvents.Unlock()
+	return mock.GetJobEventsFunc(ctx, jobNumber, filter, limit, offset)
+}
+
+// GetJobEventsCalls gets all the calls that were made to GetJobEvents.
+// Check the length with:
+//
+//	len(mockedJobService.GetJobEventsCalls())
+func (mock *JobServiceMock) GetJobEventsCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+	Filter    *domain.EventFilter
+	Limit     int
+	Offset    int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+		Filter    *domain.EventFilter
+		Limit     int
+		Offset    int
+	}
+	mock.lockGetJobEvents.RLock()
+	calls = mock.calls.GetJobEvents
+	mock.lockGetJobEvents.RUnlock()
+	return calls
+}
+
+// GetJobEventsAfter calls GetJobEventsAfterFunc.
+func (mock *JobServiceMock) GetJobEventsAfter(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error) {
+	if mock.GetJobEventsAfterFunc == nil {
+		panic("JobServiceMock.GetJobEventsAfterFunc: method is nil but JobService.GetJobEventsAfter was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+		Filter    *domain.EventFilter
+		After     *domain.Cursor
+		Limit     int
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+		Filter:    filter,
+		After:     after,
+		Limit:     limit,
+	}
+	mock.lockGetJobEventsAfter.Lock()
+	mock.calls.GetJobEventsAfter = append(mock.calls.GetJobEventsAfter, callInfo)
+	mock.lockGetJobEventsAfter.Unlock()
+	return mock.GetJobEventsAfterFunc(ctx, jobNumber, filter, after, limit)
+}
+
+// GetJobEventsAfterCalls gets all the calls that were made to GetJobEventsAfter.
+// Check the length with:
+//
+//	len(mockedJobService.GetJobEventsAfterCalls())
+func (mock *JobServiceMock) GetJobEventsAfterCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+	Filter    *domain.EventFilter
+	After     *domain.Cursor
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+		Filter    *domain.EventFilter
+		After     *domain.Cursor
+		Limit     int
+	}
+	mock.lockGetJobEventsAfter.RLock()
+	calls = mock.calls.GetJobEventsAfter
+	mock.lockGetJobEventsAfter.RUnlock()
+	return calls
+}
+
+// GetJobStatesSummary calls GetJobStatesSummaryFunc.
+func (mock *JobServiceMock) GetJobStatesSummary(ctx context.Context) ([]domain.StateSummary, error) {
+	if mock.GetJobStatesSummaryFunc == nil {
+		panic("JobServiceMock.GetJobStatesSummaryFunc: method is nil but JobService.GetJobStatesSummary was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetJobStatesSummary.Lock()
+	mock.calls.GetJobStatesSummary = append(mock.calls.GetJobStatesSummary, callInfo)
+	mock.lockGetJobStatesSummary.Unlock()
+	return mock.GetJobStatesSummaryFunc(ctx)
+}
+
+// GetJobStatesSummaryCalls gets all the calls that were made to GetJobStatesSummary.
+// Check the length with:
+//
+//	len(mockedJobService.GetJobStatesSummaryCalls())
+func (mock *JobServiceMock) GetJobStatesSummaryCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetJobStatesSummary.RLock()
+	calls = mock.calls.GetJobStatesSummary
+	mock.lockGetJobStatesSummary.RUnlock()
+	return calls
+}
+
+// GetJobTaskTree calls GetJobTaskTreeFunc.
+func (mock *JobServiceMock) GetJobTaskTree(ctx context.Context, jobNumber int) ([]*domain.TaskNode, int, error) {
+	if mock.GetJobTaskTreeFunc == nil {
+		panic("JobServiceMock.GetJobTaskTreeFunc: method is nil but JobService.GetJobTaskTree was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+	}
+	mock.lockGetJobTaskTree.Lock()
+	mock.calls.GetJobTaskTree = append(mock.calls.GetJobTaskTree, callInfo)
+	mock.lockGetJobTaskTree.Unlock()
+	return mock.GetJobTaskTreeFunc(ctx, jobNumber)
+}
+
+// GetJobTaskTreeCalls gets all the calls that were made to GetJobTaskTree.
+// Check the length with:
+//
+//	len(mockedJobService.GetJobTaskTreeCalls())
+func (mock *JobServiceMock) GetJobTaskTreeCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+	}
+	mock.lockGetJobTaskTree.RLock()
+	calls = mock.calls.GetJobTaskTree
+	mock.lockGetJobTaskTree.RUnlock()
+	return calls
+}
+
+// GetJobTasks calls GetJobTasksFunc.
+func (mock *JobServiceMock) GetJobTasks(ctx context.Context, states []domain.State, jobNumber int, limit int, offset int) ([]*domain.Task, int, error) {
+	if mock.GetJobTasksFunc == nil {
+		panic("JobServiceMock.GetJobTasksFunc: method is nil but JobService.GetJobTasks was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		States    []domain.State
+		JobNumber int
+		Limit     int
+		Offset    int
+	}{
+		Ctx:       ctx,
+		States:    states,
+		JobNumber: jobNumber,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	mock.lockGetJobTasks.Lock()
+	mock.calls.GetJobTasks = append(mock.calls.GetJobTasks, callInfo)
+	mock.lockGetJobTasks.Unlock()
+	return mock.GetJobTasksFunc(ctx, states, jobNumber, limit, offset)
+}
+
+// GetJobTasksCalls gets all the calls that were made to GetJobTasks.
+// Check the length with:
+//
+//	len(mockedJobService.GetJobTasksCalls())
+func (mock *JobServiceMock) GetJobTasksCalls() []struct {
+	Ctx       context.Context
+	States    []domain.State
+	JobNumber int
+	Limit     int
+	Offset    int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		States    []domain.State
+		JobNumber int
+		Limit     int
+		Offset    int
+	}
+	mock.lockGetJobTasks.RLock()
+	calls = mock.calls.GetJobTasks
+	mock.lockGetJobTasks.RUnlock()
+	return calls
+}
+
+// GetJobTasksAfter calls GetJobTasksAfterFunc.
+func (mock *JobServiceMock) GetJobTasksAfter(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error) {
+	if mock.GetJobTasksAfterFunc == nil {
+		panic("JobServiceMock.GetJobTasksAfterFunc: method is nil but JobService.GetJobTasksAfter was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		States    []domain.State
+		JobNumber int
+		After     *domain.Cursor
+		Limit     int
+	}{
+		Ctx:       ctx,
+		States:    states,
+		JobNumber: jobNumber,
+		After:     after,
+		Limit:     limit,
+	}
+	mock.lockGetJobTasksAfter.Lock()
+	mock.calls.GetJobTasksAfter = append(mock.calls.GetJobTasksAfter, callInfo)
+	mock.lockGetJobTasksAfter.Unlock()
+	return mock.GetJobTasksAfterFunc(ctx, states, jobNumber, after, limit)
+}
+
+// GetJobTasksAfterCalls gets all the calls that were made to GetJobTasksAfter.
+// Check the length with:
+//
+//	len(mockedJobService.GetJobTasksAfterCalls())
+func (mock *JobServiceMock) GetJobTasksAfterCalls() []struct {
+	Ctx       context.Context
+	States    []domain.State
+	JobNumber int
+	After     *domain.Cursor
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		States    []domain.State
+		JobNumber int
+		After     *domain.Cursor
+		Limit     int
+	}
+	mock.lockGetJobTasksAfter.RLock()
+	calls = mock.calls.GetJobTasksAfter
+	mock.lockGetJobTasksAfter.RUnlock()
+	return calls
+}
+
+// GetJobs calls GetJobsFunc.
+func (mock *JobServiceMock) GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit int, offset int) ([]*domain.Job, int, error) {
+	if mock.GetJobsFunc == nil {
+		panic("JobServiceMock.GetJobsFunc: method is nil but JobService.GetJobs was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Field     sort.SortParameterField
+		Direction sort.SortParameterDirection
+		Filter    *domain.JobFilter
+		Limit     int
+		Offset    int
+	}{
+		Ctx:       ctx,
+		Field:     field,
+		Direction: direction,
+		Filter:    filter,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	mock.lockGetJobs.Lock()
+	mock.calls.GetJobs = append(mock.calls.GetJobs, callInfo)
+	mock.lockGetJobs.Unlock()
+	return mock.GetJobsFunc(ctx, field, direction, filter, limit, offset)
+}
+
+// GetJobsCalls gets all the calls that were made to GetJobs.
+// Check the length with:
+//
+//	len(mockedJobService.GetJobsCalls())
+func (mock *JobServiceMock) GetJobsCalls() []struct {
+	Ctx       context.Context
+	Field     sort.SortParameterField
+	Direction sort.SortParameterDirection
+	Filter    *domain.JobFilter
+	Limit     int
+	Offset    int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Field     sort.SortParameterField
+		Direction sort.SortParameterDirection
+		Filter    *domain.JobFilter
+		Limit     int
+		Offset    int
+	}
+	mock.lockGetJobs.RLock()
+	calls = mock.calls.GetJobs
+	mock.lockGetJobs.RUnlock()
+	return calls
+}
+
+// GetJobsAfter calls GetJobsAfterFunc.
+func (mock *JobServiceMock) GetJobsAfter(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error) {
+	if mock.GetJobsAfterFunc == nil {
+		panic("JobServiceMock.GetJobsAfterFunc: method is nil but JobService.GetJobsAfter was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Field     sort.SortParameterField
+		Direction sort.SortParameterDirection
+		Filter    *domain.JobFilter
+		After     *domain.Cursor
+		Limit     int
+	}{
+		Ctx:       ctx,
+		Field:     field,
+		Direction: direction,
+		Filter:    filter,
+		After:     after,
+		Limit:     limit,
+	}
+	mock.lockGetJobsAfter.Lock()
+	mock.calls.GetJobsAfter = append(mock.calls.GetJobsAfter, callInfo)
+	mock.lockGetJobsAfter.Unlock()
+	return mock.GetJobsAfterFunc(ctx, field, direction, filter, after, limit)
+}
+
+// GetJobsAfterCalls gets all the calls that were made to GetJobsAfter.
+// Check the length with:
+//
+//	len(mockedJobService.GetJobsAfterCalls())
+func (mock *JobServiceMock) GetJobsAfterCalls() []struct {
+	Ctx       context.Context
+	Field     sort.SortParameterField
+	Direction sort.SortParameterDirection
+	Filter    *domain.JobFilter
+	After     *domain.Cursor
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Field     sort.SortParameterField
+		Direction sort.SortParameterDirection
+		Filter    *domain.JobFilter
+		After     *domain.Cursor
+		Limit     int
+	}
+	mock.lockGetJobsAfter.RLock()
+	calls = mock.calls.GetJobsAfter
+	mock.lockGetJobsAfter.RUnlock()
+	return calls
+}
+
+// GetNextJobNumber calls GetNextJobNumberFunc.
+func (mock *JobServiceMock) GetNextJobNumber(ctx context.Context) (*domain.Counter, error) {
+	if mock.GetNextJobNumberFunc == nil {
+		panic("JobServiceMock.GetNextJobNumberFunc: method is nil but JobService.GetNextJobNumber was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetNextJobNumber.Lock()
+	mock.calls.GetNextJobNumber = append(mock.calls.GetNextJobNumber, callInfo)
+	mock.lockGetNextJobNumber.Unlock()
+	return mock.GetNextJobNumberFunc(ctx)
+}
+
+// GetNextJobNumberCalls gets all the calls that were made to GetNextJobNumber.
+// Check the length with:
+//
+//	len(mockedJobService.GetNextJobNumberCalls())
+func (mock *JobServiceMock) GetNextJobNumberCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetNextJobNumber.RLock()
+	calls = mock.calls.GetNextJobNumber
+	mock.lockGetNextJobNumber.RUnlock()
+	return calls
+}
+
+// GetReleaseGroup calls GetReleaseGroupFunc.
+func (mock *JobServiceMock) GetReleaseGroup(ctx context.Context, groupID string) (*domain.ReleaseGroup, error) {
+	if mock.GetReleaseGroupFunc == nil {
+		panic("JobServiceMock.GetReleaseGroupFunc: method is nil but JobService.GetReleaseGroup was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		GroupID string
 	}{
-		Ctx:       ctx,
-		JobNumber: jobNumber,
-		Task:      task,
+		Ctx:     ctx,
+		GroupID: groupID,
 	}
-	mock.lockCreateTask.Lock()
-	mock.calls.CreateTask = append(mock.calls.CreateTask, callInfo)
-	mock.lockCreateTask.Unlock()
-	return mock.CreateTaskFunc(ctx, jobNumber, task)
+	mock.lockGetReleaseGroup.Lock()
+	mock.calls.GetReleaseGroup = append(mock.calls.GetReleaseGroup, callInfo)
+	mock.lockGetReleaseGroup.Unlock()
+	return mock.GetReleaseGroupFunc(ctx, groupID)
 }
 
-// CreateTaskCalls gets all the calls that were made to CreateTask.
+// GetReleaseGroupCalls gets all the calls that were made to GetReleaseGroup.
 // Check the length with:
 //
-//	len(mockedJobService.CreateTaskCalls())
-func (mock *JobServiceMock) CreateTaskCalls() []struct {
-	Ctx       context.Context
-	JobNumber int
-	Task      *domain.Task
+//	len(mockedJobService.GetReleaseGroupCalls())
+func (mock *JobServiceMock) GetReleaseGroupCalls() []struct {
+	Ctx     context.Context
+	GroupID string
 } {
 	var calls []struct {
-		Ctx       context.Context
-		JobNumber int
-		Task      *domain.Task
+		Ctx     context.Context
+		GroupID string
 	}
-	mock.lockCreateTask.RLock()
-	calls = mock.calls.CreateTask
-	mock.lockCreateTask.RUnlock()
+	mock.lockGetReleaseGroup.RLock()
+	calls = mock.calls.GetReleaseGroup
+	mock.lockGetReleaseGroup.RUnlock()
 	return calls
 }
 
-// GetJob calls GetJobFunc.
-func (mock *JobServiceMock) GetJob(ctx context.Context, jobNumber int) (*domain.Job, error) {
-	if mock.GetJobFunc == nil {
-		panic("JobServiceMock.GetJobFunc: method is nil but JobService.GetJob was just called")
+// GetWebhook calls GetWebhookFunc.
+func (mock *JobServiceMock) GetWebhook(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+	if mock.GetWebhookFunc == nil {
+		panic("JobServiceMock.GetWebhookFunc: method is nil but JobService.GetWebhook was just called")
 	}
 	callInfo := struct {
 		Ctx       context.Context
-		JobNumber int
+		WebhookID string
 	}{
 		Ctx:       ctx,
-		JobNumber: jobNumber,
+		WebhookID: webhookID,
 	}
-	mock.lockGetJob.Lock()
-	mock.calls.GetJob = append(mock.calls.GetJob, callInfo)
-	mock.lockGetJob.Unlock()
-	return mock.GetJobFunc(ctx, jobNumber)
+	mock.lockGetWebhook.Lock()
+	mock.calls.GetWebhook = append(mock.calls.GetWebhook, callInfo)
+	mock.lockGetWebhook.Unlock()
+	return mock.GetWebhookFunc(ctx, webhookID)
 }
 
-// GetJobCalls gets all the calls that were made to GetJob.
+// GetWebhookCalls gets all the calls that were made to GetWebhook.
 // Check the length with:
 //
-//	len(mockedJobService.GetJobCalls())
-func (mock *JobServiceMock) GetJobCalls() []struct {
+//	len(mockedJobService.GetWebhookCalls())
+func (mock *JobServiceMock) GetWebhookCalls() []struct {
 	Ctx       context.Context
-	JobNumber int
+	WebhookID string
 } {
 	var calls []struct {
 		Ctx       context.Context
-		JobNumber int
+		WebhookID string
 	}
-	mock.lockGetJob.RLock()
-	calls = mock.calls.GetJob
-	mock.lockGetJob.RUnlock()
+	mock.lockGetWebhook.RLock()
+	calls = mock.calls.GetWebhook
+	mock.lockGetWebhook.RUnlock()
 	return calls
 }
 
-// GetJobEvents calls GetJobEventsFunc.
-func (mock *JobServiceMock) GetJobEvents(ctx context.Context, jobNumber int, limit int, offset int) ([]*domain.Event, int, error) {
-	if mock.GetJobEventsFunc == nil {
-		panic("JobServiceMock.GetJobEventsFunc: method is nil but JobService.GetJobEvents was just called")
+// GetWebhookDeliveries calls GetWebhookDeliveriesFunc.
+func (mock *JobServiceMock) GetWebhookDeliveries(ctx context.Context, webhookID string, status domain.WebhookDeliveryStatus, limit int, offset int) ([]*domain.WebhookDelivery, int, error) {
+	if mock.GetWebhookDeliveriesFunc == nil {
+		panic("JobServiceMock.GetWebhookDeliveriesFunc: method is nil but JobService.GetWebhookDeliveries was just called")
 	}
 	callInfo := struct {
 		Ctx       context.Context
-		JobNumber int
+		WebhookID string
+		Status    domain.WebhookDeliveryStatus
 		Limit     int
 		Offset    int
 	}{
 		Ctx:       ctx,
-		JobNumber: jobNumber,
+		WebhookID: webhookID,
+		Status:    status,
 		Limit:     limit,
 		Offset:    offset,
 	}
-	mock.lockGetJobEvents.Lock()
-	mock.calls.GetJobEvents = append(mock.calls.GetJobEvents, callInfo)
-	mock.lockGetJobEvents.Unlock()
-	return mock.GetJobEventsFunc(ctx, jobNumber, limit, offset)
+	mock.lockGetWebhookDeliveries.Lock()
+	mock.calls.GetWebhookDeliveries = append(mock.calls.GetWebhookDeliveries, callInfo)
+	mock.lockGetWebhookDeliveries.Unlock()
+	return mock.GetWebhookDeliveriesFunc(ctx, webhookID, status, limit, offset)
 }
 
-// GetJobEventsCalls gets all the calls that were made to GetJobEvents.
+// GetWebhookDeliveriesCalls gets all the calls that were made to GetWebhookDeliveries.
 // Check the length with:
 //
-//	len(mockedJobService.GetJobEventsCalls())
-func (mock *JobServiceMock) GetJobEventsCalls() []struct {
+//	len(mockedJobService.GetWebhookDeliveriesCalls())
+func (mock *JobServiceMock) GetWebhookDeliveriesCalls() []struct {
 	Ctx       context.Context
-	JobNumber int
+	WebhookID string
+	Status    domain.WebhookDeliveryStatus
 	Limit     int
 	Offset    int
 } {
 	var calls []struct {
 		Ctx       context.Context
-		JobNumber int
+		WebhookID string
+		Status    domain.WebhookDeliveryStatus
 		Limit     int
 		Offset    int
 	}
-	mock.lockGetJobEvents.RLock()
-	calls = mock.calls.GetJobEvents
-	mock.lockGetJobEvents.RUnlock()
+	mock.lockGetWebhookDeliveries.RLock()
+	calls = mock.calls.GetWebhookDeliveries
+	mock.lockGetWebhookDeliveries.RUnlock()
 	return calls
 }
 
-// GetJobStatesSummary calls GetJobStatesSummaryFunc.
-func (mock *JobServiceMock) GetJobStatesSummary(ctx context.Context) ([]domain.StateSummary, error) {
-	if mock.GetJobStatesSummaryFunc == nil {
-		panic("JobServiceMock.GetJobStatesSummaryFunc: method is nil but JobService.GetJobStatesSummary was just called")
+// GetWebhooks calls GetWebhooksFunc.
+func (mock *JobServiceMock) GetWebhooks(ctx context.Context, limit int, offset int) ([]*domain.Webhook, int, error) {
+	if mock.GetWebhooksFunc == nil {
+		panic("JobServiceMock.GetWebhooksFunc: method is nil but JobService.GetWebhooks was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx    context.Context
+		Limit  int
+		Offset int
 	}{
-		Ctx: ctx,
+		Ctx:    ctx,
+		Limit:  limit,
+		Offset: offset,
 	}
-	mock.lockGetJobStatesSummary.Lock()
-	mock.calls.GetJobStatesSummary = append(mock.calls.GetJobStatesSummary, callInfo)
-	mock.lockGetJobStatesSummary.Unlock()
-	return mock.GetJobStatesSummaryFunc(ctx)
+	mock.lockGetWebhooks.Lock()
+	mock.calls.GetWebhooks = append(mock.calls.GetWebhooks, callInfo)
+	mock.lockGetWebhooks.Unlock()
+	return mock.GetWebhooksFunc(ctx, limit, offset)
 }
 
-// GetJobStatesSummaryCalls gets all the calls that were made to GetJobStatesSummary.
+// GetWebhooksCalls gets all the calls that were made to GetWebhooks.
 // Check the length with:
 //
-//	len(mockedJobService.GetJobStatesSummaryCalls())
-func (mock *JobServiceMock) GetJobStatesSummaryCalls() []struct {
-	Ctx context.Context
+//	len(mockedJobService.GetWebhooksCalls())
+func (mock *JobServiceMock) GetWebhooksCalls() []struct {
+	Ctx    context.Context
+	Limit  int
+	Offset int
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx    context.Context
+		Limit  int
+		Offset int
 	}
-	mock.lockGetJobStatesSummary.RLock()
-	calls = mock.calls.GetJobStatesSummary
-	mock.lockGetJobStatesSummary.RUnlock()
+	mock.lockGetWebhooks.RLock()
+	calls = mock.calls.GetWebhooks
+	mock.lockGetWebhooks.RUnlock()
 	return calls
 }
 
-// GetJobTasks calls GetJobTasksFunc.
-func (mock *JobServiceMock) GetJobTasks(ctx context.Context, states []domain.State, jobNumber int, limit int, offset int) ([]*domain.Task, int, error) {
-	if mock.GetJobTasksFunc == nil {
-		panic("JobServiceMock.GetJobTasksFunc: method is nil but JobService.GetJobTasks was just called")
+// NotifyWebhooks calls NotifyWebhooksFunc.
+func (mock *JobServiceMock) NotifyWebhooks(ctx context.Context, job *domain.Job, fromState domain.State, toState domain.State, reason string) error {
+	if mock.NotifyWebhooksFunc == nil {
+		panic("JobServiceMock.NotifyWebhooksFunc: method is nil but JobService.NotifyWebhooks was just called")
 	}
 	callInfo := struct {
 		Ctx       context.Context
-		States    []domain.State
-		JobNumber int
-		Limit     int
-		Offset    int
+		Job       *domain.Job
+		FromState domain.State
+		ToState   domain.State
+		Reason    string
 	}{
 		Ctx:       ctx,
-		States:    states,
-		JobNumber: jobNumber,
-		Limit:     limit,
-		Offset:    offset,
+		Job:       job,
+		FromState: fromState,
+		ToState:   toState,
+		Reason:    reason,
 	}
-	mock.lockGetJobTasks.Lock()
-	mock.calls.GetJobTasks = append(mock.calls.GetJobTasks, callInfo)
-	mock.lockGetJobTasks.Unlock()
-	return mock.GetJobTasksFunc(ctx, states, jobNumber, limit, offset)
+	mock.lockNotifyWebhooks.Lock()
+	mock.calls.NotifyWebhooks = append(mock.calls.NotifyWebhooks, callInfo)
+	mock.lockNotifyWebhooks.Unlock()
+	return mock.NotifyWebhooksFunc(ctx, job, fromState, toState, reason)
 }
 
-// GetJobTasksCalls gets all the calls that were made to GetJobTasks.
+// NotifyWebhooksCalls gets all the calls that were made to NotifyWebhooks.
 // Check the length with:
 //
-//	len(mockedJobService.GetJobTasksCalls())
-func (mock *JobServiceMock) GetJobTasksCalls() []struct {
+//	len(mockedJobService.NotifyWebhooksCalls())
+func (mock *JobServiceMock) NotifyWebhooksCalls() []struct {
 	Ctx       context.Context
-	States    []domain.State
-	JobNumber int
-	Limit     int
-	Offset    int
+	Job       *domain.Job
+	FromState domain.State
+	ToState   domain.State
+	Reason    string
 } {
 	var calls []struct {
 		Ctx       context.Context
-		States    []domain.State
-		JobNumber int
-		Limit     int
-		Offset    int
+		Job       *domain.Job
+		FromState domain.State
+		ToState   domain.State
+		Reason    string
 	}
-	mock.lockGetJobTasks.RLock()
-	calls = mock.calls.GetJobTasks
-	mock.lockGetJobTasks.RUnlock()
+	mock.lockNotifyWebhooks.RLock()
+	calls = mock.calls.NotifyWebhooks
+	mock.lockNotifyWebhooks.RUnlock()
 	return calls
 }
 
-// GetJobs calls GetJobsFunc.
-func (mock *JobServiceMock) GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit int, offset int) ([]*domain.Job, int, error) {
-	if mock.GetJobsFunc == nil {
-		panic("JobServiceMock.GetJobsFunc: method is nil but JobService.GetJobs was just called")
+// ScheduleJob calls ScheduleJobFunc.
+func (mock *JobServiceMock) ScheduleJob(ctx context.Context, jobNumber int, publishAt *time.Time, userID string) error {
+	if mock.ScheduleJobFunc == nil {
+		panic("JobServiceMock.ScheduleJobFunc: method is nil but JobService.ScheduleJob was just called")
 	}
 	callInfo := struct {
 		Ctx       context.Context
-		Field     sort.SortParameterField
-		Direction sort.SortParameterDirection
-		States    []domain.State
-		Limit     int
-		Offset    int
+		JobNumber int
+		PublishAt *time.Time
+		UserID    string
 	}{
 		Ctx:       ctx,
-		Field:     field,
-		Direction: direction,
-		States:    states,
-		Limit:     limit,
-		Offset:    offset,
+		JobNumber: jobNumber,
+		PublishAt: publishAt,
+		UserID:    userID,
 	}
-	mock.lockGetJobs.Lock()
-	mock.calls.GetJobs = append(mock.calls.GetJobs, callInfo)
-	mock.lockGetJobs.Unlock()
-	return mock.GetJobsFunc(ctx, field, direction, states, limit, offset)
+	mock.lockScheduleJob.Lock()
+	mock.calls.ScheduleJob = append(mock.calls.ScheduleJob, callInfo)
+	mock.lockScheduleJob.Unlock()
+	return mock.ScheduleJobFunc(ctx, jobNumber, publishAt, userID)
 }
 
-// GetJobsCalls gets all the calls that were made to GetJobs.
+// ScheduleJobCalls gets all the calls that were made to ScheduleJob.
 // Check the length with:
 //
-//	len(mockedJobService.GetJobsCalls())
-func (mock *JobServiceMock) GetJobsCalls() []struct {
+//	len(mockedJobService.ScheduleJobCalls())
+func (mock *JobServiceMock) ScheduleJobCalls() []struct {
 	Ctx       context.Context
-	Field     sort.SortParameterField
-	Direction sort.SortParameterDirection
-	States    []domain.State
-	Limit     int
-	Offset    int
+	JobNumber int
+	PublishAt *time.Time
+	UserID    string
 } {
 	var calls []struct {
 		Ctx       context.Context
-		Field     sort.SortParameterField
-		Direction sort.SortParameterDirection
-		States    []domain.State
-		Limit     int
-		Offset    int
+		JobNumber int
+		PublishAt *time.Time
+		UserID    string
 	}
-	mock.lockGetJobs.RLock()
-	calls = mock.calls.GetJobs
-	mock.lockGetJobs.RUnlock()
+	mock.lockScheduleJob.RLock()
+	calls = mock.calls.ScheduleJob
+	mock.lockScheduleJob.RUnlock()
 	return calls
 }
 
-// GetNextJobNumber calls GetNextJobNumberFunc.
-func (mock *JobServiceMock) GetNextJobNumber(ctx context.Context) (*domain.Counter, error) {
-	if mock.GetNextJobNumberFunc == nil {
-		panic("JobServiceMock.GetNextJobNumberFunc: method is nil but JobService.GetNextJobNumber was just called")
+// StreamJob calls StreamJobFunc.
+func (mock *JobServiceMock) StreamJob(ctx context.Context, jobNumber int, lastEventID string) (*stream.Subscription, []*stream.Message, error) {
+	if mock.StreamJobFunc == nil {
+		panic("JobServiceMock.StreamJobFunc: method is nil but JobService.StreamJob was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx         context.Context
+		JobNumber   int
+		LastEventID string
 	}{
-		Ctx: ctx,
+		Ctx:         ctx,
+		JobNumber:   jobNumber,
+		LastEventID: lastEventID,
 	}
-	mock.lockGetNextJobNumber.Lock()
-	mock.calls.GetNextJobNumber = append(mock.calls.GetNextJobNumber, callInfo)
-	mock.lockGetNextJobNumber.Unlock()
-	return mock.GetNextJobNumberFunc(ctx)
+	mock.lockStreamJob.Lock()
+	mock.calls.StreamJob = append(mock.calls.StreamJob, callInfo)
+	mock.lockStreamJob.Unlock()
+	return mock.StreamJobFunc(ctx, jobNumber, lastEventID)
 }
 
-// GetNextJobNumberCalls gets all the calls that were made to GetNextJobNumber.
+// StreamJobCalls gets all the calls that were made to StreamJob.
 // Check the length with:
 //
-//	len(mockedJobService.GetNextJobNumberCalls())
-func (mock *JobServiceMock) GetNextJobNumberCalls() []struct {
-	Ctx context.Context
+//	len(mockedJobService.StreamJobCalls())
+func (mock *JobServiceMock) StreamJobCalls() []struct {
+	Ctx         context.Context
+	JobNumber   int
+	LastEventID string
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx         context.Context
+		JobNumber   int
+		LastEventID string
 	}
-	mock.lockGetNextJobNumber.RLock()
-	calls = mock.calls.GetNextJobNumber
-	mock.lockGetNextJobNumber.RUnlock()
+	mock.lockStreamJob.RLock()
+	calls = mock.calls.StreamJob
+	mock.lockStreamJob.RUnlock()
 	return calls
 }
 
@@ -841,8 +2193,92 @@ func (mock *JobServiceMock) UpdateJobCollectionIDCalls() []struct {
 	return calls
 }
 
+// UpdateJobNotificationThread calls UpdateJobNotificationThreadFunc.
+func (mock *JobServiceMock) UpdateJobNotificationThread(ctx context.Context, jobNumber int, thread *domain.NotificationThread) error {
+	if mock.UpdateJobNotificationThreadFunc == nil {
+		panic("JobServiceMock.UpdateJobNotificationThreadFunc: method is nil but JobService.UpdateJobNotificationThread was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+		Thread    *domain.NotificationThread
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+		Thread:    thread,
+	}
+	mock.lockUpdateJobNotificationThread.Lock()
+	mock.calls.UpdateJobNotificationThread = append(mock.calls.UpdateJobNotificationThread, callInfo)
+	mock.lockUpdateJobNotificationThread.Unlock()
+	return mock.UpdateJobNotificationThreadFunc(ctx, jobNumber, thread)
+}
+
+// UpdateJobNotificationThreadCalls gets all the calls that were made to UpdateJobNotificationThread.
+// Check the length with:
+//
+//	len(mockedJobService.UpdateJobNotificationThreadCalls())
+func (mock *JobServiceMock) UpdateJobNotificationThreadCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+	Thread    *domain.NotificationThread
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+		Thread    *domain.NotificationThread
+	}
+	mock.lockUpdateJobNotificationThread.RLock()
+	calls = mock.calls.UpdateJobNotificationThread
+	mock.lockUpdateJobNotificationThread.RUnlock()
+	return calls
+}
+
+// UpdateJobPriority calls UpdateJobPriorityFunc.
+func (mock *JobServiceMock) UpdateJobPriority(ctx context.Context, jobNumber int, priority int, userID string) error {
+	if mock.UpdateJobPriorityFunc == nil {
+		panic("JobServiceMock.UpdateJobPriorityFunc: method is nil but JobService.UpdateJobPriority was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+		Priority  int
+		UserID    string
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+		Priority:  priority,
+		UserID:    userID,
+	}
+	mock.lockUpdateJobPriority.Lock()
+	mock.calls.UpdateJobPriority = append(mock.calls.UpdateJobPriority, callInfo)
+	mock.lockUpdateJobPriority.Unlock()
+	return mock.UpdateJobPriorityFunc(ctx, jobNumber, priority, userID)
+}
+
+// UpdateJobPriorityCalls gets all the calls that were made to UpdateJobPriority.
+// Check the length with:
+//
+//	len(mockedJobService.UpdateJobPriorityCalls())
+func (mock *JobServiceMock) UpdateJobPriorityCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+	Priority  int
+	UserID    string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+		Priority  int
+		UserID    string
+	}
+	mock.lockUpdateJobPriority.RLock()
+	calls = mock.calls.UpdateJobPriority
+	mock.lockUpdateJobPriority.RUnlock()
+	return calls
+}
+
 // UpdateJobState calls UpdateJobStateFunc.
-func (mock *JobServiceMock) UpdateJobState(ctx context.Context, jobNumber int, newState domain.State, userID string) error {
+func (mock *JobServiceMock) UpdateJobState(ctx context.Context, jobNumber int, newState domain.State, userID string, reason string, opts *domain.JobStateOptions) error {
 	if mock.UpdateJobStateFunc == nil {
 		panic("JobServiceMock.UpdateJobStateFunc: method is nil but JobService.UpdateJobState was just called")
 	}
@@ -851,16 +2287,20 @@ func (mock *JobServiceMock) UpdateJobState(ctx context.Context, jobNumber int, n
 		JobNumber int
 		NewState  domain.State
 		UserID    string
+		Reason    string
+		Opts      *domain.JobStateOptions
 	}{
 		Ctx:       ctx,
 		JobNumber: jobNumber,
 		NewState:  newState,
 		UserID:    userID,
+		Reason:    reason,
+		Opts:      opts,
 	}
 	mock.lockUpdateJobState.Lock()
 	mock.calls.UpdateJobState = append(mock.calls.UpdateJobState, callInfo)
 	mock.lockUpdateJobState.Unlock()
-	return mock.UpdateJobStateFunc(ctx, jobNumber, newState, userID)
+	return mock.UpdateJobStateFunc(ctx, jobNumber, newState, userID, reason, opts)
 }
 
 // UpdateJobStateCalls gets all the calls that were made to UpdateJobState.
@@ -872,12 +2312,16 @@ func (mock *JobServiceMock) UpdateJobStateCalls() []struct {
 	JobNumber int
 	NewState  domain.State
 	UserID    string
+	Reason    string
+	Opts      *domain.JobStateOptions
 } {
 	var calls []struct {
 		Ctx       context.Context
 		JobNumber int
 		NewState  domain.State
 		UserID    string
+		Reason    string
+		Opts      *domain.JobStateOptions
 	}
 	mock.lockUpdateJobState.RLock()
 	calls = mock.calls.UpdateJobState
@@ -922,7 +2366,7 @@ func (mock *JobServiceMock) UpdateTaskCalls() []struct {
 }
 
 // UpdateTaskState calls UpdateTaskStateFunc.
-func (mock *JobServiceMock) UpdateTaskState(ctx context.Context, taskID string, newState domain.State) error {
+func (mock *JobServiceMock) UpdateTaskState(ctx context.Context, taskID string, newState domain.State, reason string) error {
 	if mock.UpdateTaskStateFunc == nil {
 		panic("JobServiceMock.UpdateTaskStateFunc: method is nil but JobService.UpdateTaskState was just called")
 	}
@@ -930,15 +2374,17 @@ func (mock *JobServiceMock) UpdateTaskState(ctx context.Context, taskID string,
 		Ctx      context.Context
 		TaskID   string
 		NewState domain.State
+		Reason   string
 	}{
 		Ctx:      ctx,
 		TaskID:   taskID,
 		NewState: newState,
+		Reason:   reason,
 	}
 	mock.lockUpdateTaskState.Lock()
 	mock.calls.UpdateTaskState = append(mock.calls.UpdateTaskState, callInfo)
 	mock.lockUpdateTaskState.Unlock()
-	return mock.UpdateTaskStateFunc(ctx, taskID, newState)
+	return mock.UpdateTaskStateFunc(ctx, taskID, newState, reason)
 }
 
 // UpdateTaskStateCalls gets all the calls that were made to UpdateTaskState.
@@ -949,14 +2395,124 @@ func (mock *JobServiceMock) UpdateTaskStateCalls() []struct {
 	Ctx      context.Context
 	TaskID   string
 	NewState domain.State
+	Reason   string
 } {
 	var calls []struct {
 		Ctx      context.Context
 		TaskID   string
 		NewState domain.State
+		Reason   string
 	}
 	mock.lockUpdateTaskState.RLock()
 	calls = mock.calls.UpdateTaskState
 	mock.lockUpdateTaskState.RUnlock()
 	return calls
 }
+
+// UpdateWebhook calls UpdateWebhookFunc.
+func (mock *JobServiceMock) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	if mock.UpdateWebhookFunc == nil {
+		panic("JobServiceMock.UpdateWebhookFunc: method is nil but JobService.UpdateWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Webhook *domain.Webhook
+	}{
+		Ctx:     ctx,
+		Webhook: webhook,
+	}
+	mock.lockUpdateWebhook.Lock()
+	mock.calls.UpdateWebhook = append(mock.calls.UpdateWebhook, callInfo)
+	mock.lockUpdateWebhook.Unlock()
+	return mock.UpdateWebhookFunc(ctx, webhook)
+}
+
+// UpdateWebhookCalls gets all the calls that were made to UpdateWebhook.
+// Check the length with:
+//
+//	len(mockedJobService.UpdateWebhookCalls())
+func (mock *JobServiceMock) UpdateWebhookCalls() []struct {
+	Ctx     context.Context
+	Webhook *domain.Webhook
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Webhook *domain.Webhook
+	}
+	mock.lockUpdateWebhook.RLock()
+	calls = mock.calls.UpdateWebhook
+	mock.lockUpdateWebhook.RUnlock()
+	return calls
+}
+
+// UpdateWebhookDelivery calls UpdateWebhookDeliveryFunc.
+func (mock *JobServiceMock) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if mock.UpdateWebhookDeliveryFunc == nil {
+		panic("JobServiceMock.UpdateWebhookDeliveryFunc: method is nil but JobService.UpdateWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Delivery *domain.WebhookDelivery
+	}{
+		Ctx:      ctx,
+		Delivery: delivery,
+	}
+	mock.lockUpdateWebhookDelivery.Lock()
+	mock.calls.UpdateWebhookDelivery = append(mock.calls.UpdateWebhookDelivery, callInfo)
+	mock.lockUpdateWebhookDelivery.Unlock()
+	return mock.UpdateWebhookDeliveryFunc(ctx, delivery)
+}
+
+// UpdateWebhookDeliveryCalls gets all the calls that were made to UpdateWebhookDelivery.
+// Check the length with:
+//
+//	len(mockedJobService.UpdateWebhookDeliveryCalls())
+func (mock *JobServiceMock) UpdateWebhookDeliveryCalls() []struct {
+	Ctx      context.Context
+	Delivery *domain.WebhookDelivery
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Delivery *domain.WebhookDelivery
+	}
+	mock.lockUpdateWebhookDelivery.RLock()
+	calls = mock.calls.UpdateWebhookDelivery
+	mock.lockUpdateWebhookDelivery.RUnlock()
+	return calls
+}
+
+// WatchWork calls WatchWorkFunc.
+func (mock *JobServiceMock) WatchWork(ctx context.Context, notify func()) error {
+	if mock.WatchWorkFunc == nil {
+		panic("JobServiceMock.WatchWorkFunc: method is nil but JobService.WatchWork was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Notify func()
+	}{
+		Ctx:    ctx,
+		Notify: notify,
+	}
+	mock.lockWatchWork.Lock()
+	mock.calls.WatchWork = append(mock.calls.WatchWork, callInfo)
+	mock.lockWatchWork.Unlock()
+	return mock.WatchWorkFunc(ctx, notify)
+}
+
+// WatchWorkCalls gets all the calls that were made to WatchWork.
+// Check the length with:
+//
+//	len(mockedJobService.WatchWorkCalls())
+func (mock *JobServiceMock) WatchWorkCalls() []struct {
+	Ctx    context.Context
+	Notify func()
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Notify func()
+	}
+	mock.lockWatchWork.RLock()
+	calls = mock.calls.WatchWork
+	mock.lockWatchWork.RUnlock()
+	return calls
+}
@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/dis-migration-service/statemachine"
+)
+
+// UpdateJobPriority changes the priority of a migration job and its tasks
+// and logs an event with the requesting user's ID. Tasks belonging to
+// higher priority jobs are claimed first.
+func (js *jobService) UpdateJobPriority(ctx context.Context, jobNumber, priority int, userID string) error {
+	if err := domain.ValidateJobPriority(priority); err != nil {
+		return err
+	}
+
+	job, err := js.store.GetJob(ctx, jobNumber)
+	if err != nil {
+		return err
+	}
+
+	if statemachine.IsTerminalState(job.State) {
+		return appErrors.ErrJobPriorityNotAllowed
+	}
+
+	// The job is updated before its tasks, so that tasks created in the
+	// meantime take the new priority. The tasks are updated even if the job
+	// already has the priority, so that a change whose task update failed
+	// can be made again.
+	if job.Priority != priority {
+		err = js.store.UpdateJobPriority(ctx, job.ID, priority)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = js.store.UpdateJobTasksPriority(ctx, jobNumber, priority)
+	if err != nil {
+		return err
+	}
+
+	if job.Priority == priority {
+		return nil
+	}
+
+	event := domain.NewEvent(jobNumber, domain.EventActionPriorityChanged, userID)
+	event.Payload = map[string]interface{}{
+		"from": job.Priority,
+		"to":   priority,
+	}
+	js.logEvent(ctx, event)
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// CreateReleaseGroup groups migration jobs so that they are published
+// together. Every job must exist, must not have started publishing and
+// must not already be in a release group.
+func (js *jobService) CreateReleaseGroup(ctx context.Context, group *domain.ReleaseGroup) (*domain.ReleaseGroup, error) {
+	for _, jobNumber := range group.JobNumbers {
+		job, err := js.store.GetJob(ctx, jobNumber)
+		if err != nil {
+			if errors.Is(err, appErrors.ErrJobNotFound) {
+				return nil, appErrors.ErrReleaseGroupJobNotFound
+			}
+			return nil, err
+		}
+
+		if job.ReleaseGroupID != "" {
+			return nil, appErrors.ErrJobAlreadyInReleaseGroup
+		}
+
+		if !job.IsGroupable() {
+			return nil, appErrors.ErrJobNotGroupable
+		}
+	}
+
+	added, err := js.store.AddJobsToReleaseGroup(ctx, group.ID, group.JobNumbers, domain.GroupableStates)
+	if err != nil {
+		return nil, err
+	}
+
+	// A job may have started publishing or joined another group since it
+	// was checked, in which case the group is not created
+	if added < len(group.JobNumbers) {
+		js.removeReleaseGroupJobs(ctx, group.ID)
+		return nil, appErrors.ErrJobNotGroupable
+	}
+
+	if err := js.store.CreateReleaseGroup(ctx, group); err != nil {
+		js.removeReleaseGroupJobs(ctx, group.ID)
+		return nil, err
+	}
+
+	// The jobs may all have been approved already
+	js.releaseGroupIfReady(ctx, group.ID)
+
+	return group, nil
+}
+
+// GetReleaseGroup retrieves a release group by its ID, along with its
+// status and the state of each of its jobs.
+func (js *jobService) GetReleaseGroup(ctx context.Context, groupID string) (*domain.ReleaseGroup, error) {
+	group, err := js.store.GetReleaseGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := js.store.GetReleaseGroupJobs(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	group.SetStatus(jobs)
+
+	return group, nil
+}
+
+// DeleteReleaseGroup disbands a release group, so that its jobs are
+// published independently.
+func (js *jobService) DeleteReleaseGroup(ctx context.Context, groupID string) error {
+	if _, err := js.store.GetReleaseGroup(ctx, groupID); err != nil {
+		return err
+	}
+
+	if err := js.store.RemoveJobsFromReleaseGroup(ctx, groupID); err != nil {
+		return err
+	}
+
+	return js.store.DeleteReleaseGroup(ctx, groupID)
+}
+
+// releaseGroupIfReady releases the jobs in a release group from each
+// release gate that every job in the group has reached. Jobs held by a
+// failed or rejected job in the group are not released.
+func (js *jobService) releaseGroupIfReady(ctx context.Context, groupID string) {
+	logData := log.Data{"release_group_id": groupID}
+
+	jobs, err := js.store.GetReleaseGroupJobs(ctx, groupID)
+	if err != nil {
+		log.Error(ctx, "failed to get release group jobs", err, logData)
+		return
+	}
+
+	for _, gate := range domain.ReleaseGates {
+		if !domain.AllJobsReachedState(jobs, gate) || allJobsReleasedFrom(jobs, gate) {
+			continue
+		}
+
+		logData["release_gate"] = gate
+		if err := js.store.ReleaseGroupJobs(ctx, groupID, gate); err != nil {
+			log.Error(ctx, "failed to release release group jobs", err, logData)
+			return
+		}
+		log.Info(ctx, "released release group jobs", logData)
+	}
+}
+
+// removeReleaseGroupJobs removes every job from a release group that could
+// not be created.
+func (js *jobService) removeReleaseGroupJobs(ctx context.Context, groupID string) {
+	if err := js.store.RemoveJobsFromReleaseGroup(ctx, groupID); err != nil {
+		log.Error(ctx, "failed to remove jobs from release group", err, log.Data{"release_group_id": groupID})
+	}
+}
+
+// allJobsReleasedFrom checks if every one of the jobs has already been
+// released from the provided release gate
+func allJobsReleasedFrom(jobs []*domain.Job, gate domain.State) bool {
+	return !slices.ContainsFunc(jobs, func(job *domain.Job) bool {
+		return !job.IsReleasedFrom(gate)
+	})
+}
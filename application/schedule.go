@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+)
+
+// ScheduleJob sets when a migration job in review or approved is
+// published, or cancels its schedule if publishAt is nil, and logs an event
+// with the requesting user's ID. Approved jobs are not claimed for
+// publishing until their scheduled time.
+func (js *jobService) ScheduleJob(ctx context.Context, jobNumber int, publishAt *time.Time, userID string) error {
+	job, err := js.store.GetJob(ctx, jobNumber)
+	if err != nil {
+		return err
+	}
+
+	return js.scheduleJob(ctx, job, publishAt, userID)
+}
+
+// scheduleJob stores the publish time of a job and logs a schedule changed
+// event.
+func (js *jobService) scheduleJob(ctx context.Context, job *domain.Job, publishAt *time.Time, userID string) error {
+	publishAt, err := validatePublishAt(job, publishAt)
+	if err != nil {
+		return err
+	}
+
+	err = js.store.UpdateJobPublishAt(ctx, job.ID, []domain.State{domain.StateInReview, domain.StateApproved}, publishAt)
+	if err != nil {
+		return err
+	}
+
+	js.logScheduleChange(ctx, job.JobNumber, publishAt, userID)
+
+	return nil
+}
+
+// logScheduleChange logs a schedule changed event for a job, with the time
+// it is to be published unless its schedule was cancelled.
+func (js *jobService) logScheduleChange(ctx context.Context, jobNumber int, publishAt *time.Time, userID string) {
+	event := domain.NewEvent(jobNumber, domain.EventActionScheduleChanged, userID)
+	if publishAt != nil {
+		event.Payload = map[string]interface{}{
+			"publish_at": publishAt.Format(time.RFC3339),
+		}
+	}
+	js.logEvent(ctx, event)
+}
+
+// validatePublishAt checks that a job can be scheduled to publish at
+// publishAt, returning the time in UTC.
+func validatePublishAt(job *domain.Job, publishAt *time.Time) (*time.Time, error) {
+	if !job.IsSchedulable() {
+		return nil, appErrors.ErrJobScheduleNotAllowed
+	}
+
+	if publishAt == nil {
+		return nil, nil
+	}
+
+	if !publishAt.After(time.Now()) {
+		return nil, appErrors.ErrPublishAtInPast
+	}
+
+	utc := publishAt.UTC()
+	return &utc, nil
+}
@@ -15,4 +15,10 @@ type ClientList struct {
 	TopicAPI      topicAPI.Clienter
 	UploadService uploadService.Clienter
 	Zebedee       ZebedeeClient
+	// Guards rate limit and circuit break calls to Zebedee and the
+	// dataset API. It is nil if the clients are not guarded.
+	Guards *Guards
+	// PageCache caches the Zebedee pages read for each job. It is nil if
+	// pages are not cached.
+	PageCache *PageCache
 }
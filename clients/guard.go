@@ -0,0 +1,272 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DependencyZebedee is the name of the Zebedee dependency
+	DependencyZebedee = "zebedee"
+	// DependencyDatasetAPI is the name of the dataset API dependency
+	DependencyDatasetAPI = "dataset-api"
+)
+
+// ErrCircuitOpen is returned instead of calling a dependency while its
+// circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// GuardConfig holds the rate limit and circuit breaker settings for calls
+// to a dependency.
+type GuardConfig struct {
+	// RateLimit is the number of calls allowed per second. Calls are not
+	// rate limited if it is 0 or less.
+	RateLimit float64
+	// RateBurst is the number of calls that can be made at once before
+	// the rate limit applies.
+	RateBurst int
+	// FailureThreshold is the number of consecutive failed calls that
+	// opens the circuit breaker. The circuit breaker is disabled if it is
+	// 0 or less.
+	FailureThreshold int
+	// OpenDuration is how long the circuit breaker stays open before a
+	// call is allowed through to test the dependency, and how long a test
+	// call offered by IsOpen is waited for before another is offered.
+	OpenDuration time.Duration
+}
+
+// breakerState is the state of a guard's circuit breaker
+type breakerState int
+
+const (
+	// breakerClosed allows calls to the dependency
+	breakerClosed breakerState = iota
+	// breakerOpen refuses calls to the dependency until OpenDuration has
+	// passed
+	breakerOpen
+	// breakerHalfOpen allows a single trial call to test the dependency
+	breakerHalfOpen
+)
+
+// Guard rate limits calls to a dependency and stops calling it while it is
+// failing.
+type Guard struct {
+	name      string
+	cfg       GuardConfig
+	isFailure func(error) bool
+
+	mu       sync.Mutex
+	tokens   float64
+	refilled time.Time
+	state    breakerState
+	failures int
+	openedAt time.Time
+	// probeOfferedAt is when IsOpen last reported the breaker as closed
+	// so that a trial call could be made
+	probeOfferedAt time.Time
+}
+
+// NewGuard creates a guard for the named dependency. isFailure decides
+// whether an error returned by the dependency counts towards opening the
+// circuit breaker. If it is nil, every error counts.
+func NewGuard(name string, cfg GuardConfig, isFailure func(error) bool) *Guard {
+	if cfg.RateBurst < 1 {
+		cfg.RateBurst = 1
+	}
+	return &Guard{
+		name:      name,
+		cfg:       cfg,
+		isFailure: isFailure,
+		tokens:    float64(cfg.RateBurst),
+		refilled:  time.Now(),
+	}
+}
+
+// Name returns the name of the guarded dependency
+func (g *Guard) Name() string {
+	return g.name
+}
+
+// IsOpen returns true if calls to the dependency are being refused by the
+// circuit breaker. Once the breaker has been open for OpenDuration, it
+// returns false a single time so that work making the trial call can be
+// started, and then true until the trial call succeeds. If no trial call is
+// made within OpenDuration of it being offered, another is offered. A nil
+// guard is never open.
+func (g *Guard) IsOpen() bool {
+	if g == nil {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch g.state {
+	case breakerOpen:
+		if time.Since(g.openedAt) < g.cfg.OpenDuration {
+			return true
+		}
+		if !g.probeOfferedAt.IsZero() && time.Since(g.probeOfferedAt) < g.cfg.OpenDuration {
+			return true
+		}
+		g.probeOfferedAt = time.Now()
+		return false
+	case breakerHalfOpen:
+		return true
+	default:
+		return false
+	}
+}
+
+// Do waits for the rate limit and then makes the call, unless the circuit
+// breaker is open in which case ErrCircuitOpen is returned.
+func (g *Guard) Do(ctx context.Context, call func() error) error {
+	if g == nil {
+		return call()
+	}
+
+	if err := g.allow(); err != nil {
+		return err
+	}
+
+	if err := g.wait(ctx); err != nil {
+		g.record(err)
+		return err
+	}
+
+	err := call()
+	g.record(err)
+	return err
+}
+
+// allow returns ErrCircuitOpen if the circuit breaker is refusing calls.
+// Once the breaker has been open for OpenDuration, a single trial call is
+// allowed through.
+func (g *Guard) allow() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch g.state {
+	case breakerOpen:
+		if time.Since(g.openedAt) < g.cfg.OpenDuration {
+			return fmt.Errorf("%s: %w", g.name, ErrCircuitOpen)
+		}
+		g.state = breakerHalfOpen
+	case breakerHalfOpen:
+		return fmt.Errorf("%s: %w", g.name, ErrCircuitOpen)
+	}
+	return nil
+}
+
+// record updates the circuit breaker with the result of a call. Calls that
+// were cancelled say nothing about the dependency, and errors that isFailure
+// rejects show the dependency is responding.
+func (g *Guard) record(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		// A cancelled trial call is offered again straight away
+		if g.state == breakerHalfOpen {
+			g.state = breakerOpen
+			g.probeOfferedAt = time.Time{}
+		}
+	case err == nil, g.isFailure != nil && !g.isFailure(err):
+		g.state = breakerClosed
+		g.failures = 0
+		g.probeOfferedAt = time.Time{}
+	default:
+		g.failures++
+		if g.state == breakerHalfOpen || (g.cfg.FailureThreshold > 0 && g.failures >= g.cfg.FailureThreshold) {
+			g.state = breakerOpen
+			g.openedAt = time.Now()
+			g.probeOfferedAt = time.Time{}
+		}
+	}
+}
+
+// wait blocks until the rate limit allows a call or ctx is done
+func (g *Guard) wait(ctx context.Context) error {
+	if g.cfg.RateLimit <= 0 {
+		return nil
+	}
+
+	for {
+		g.mu.Lock()
+		now := time.Now()
+		g.tokens += now.Sub(g.refilled).Seconds() * g.cfg.RateLimit
+		if g.tokens > float64(g.cfg.RateBurst) {
+			g.tokens = float64(g.cfg.RateBurst)
+		}
+		g.refilled = now
+
+		if g.tokens >= 1 {
+			g.tokens--
+			g.mu.Unlock()
+			return nil
+		}
+
+		delay := time.Duration((1 - g.tokens) / g.cfg.RateLimit * float64(time.Second))
+		g.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Guards holds the guard of each dependency whose calls are rate limited
+// and circuit broken.
+type Guards struct {
+	Zebedee    *Guard
+	DatasetAPI *Guard
+}
+
+// NewGuards creates guards for Zebedee and the dataset API. Errors that
+// carry a response status code below 500 do not count towards opening a
+// circuit breaker, as they show the dependency is responding.
+func NewGuards(zebedeeCfg, datasetAPICfg GuardConfig) *Guards {
+	return &Guards{
+		Zebedee:    NewGuard(DependencyZebedee, zebedeeCfg, isServerFailure),
+		DatasetAPI: NewGuard(DependencyDatasetAPI, datasetAPICfg, isServerFailure),
+	}
+}
+
+// IsOpen returns true if the circuit breaker of the named dependency is
+// open. Dependencies without a guard are never open.
+func (g *Guards) IsOpen(dependency string) bool {
+	if g == nil {
+		return false
+	}
+
+	switch dependency {
+	case DependencyZebedee:
+		return g.Zebedee.IsOpen()
+	case DependencyDatasetAPI:
+		return g.DatasetAPI.IsOpen()
+	default:
+		return false
+	}
+}
+
+// WithGuards returns a copy of the client list whose Zebedee and dataset
+// API clients make their calls through the provided guards.
+func WithGuards(list *ClientList, guards *Guards) *ClientList {
+	guarded := *list
+	guarded.Guards = guards
+
+	if list.Zebedee != nil && guards.Zebedee != nil {
+		guarded.Zebedee = &guardedZebedeeClient{client: list.Zebedee, guard: guards.Zebedee}
+	}
+	if list.DatasetAPI != nil && guards.DatasetAPI != nil {
+		guarded.DatasetAPI = &guardedDatasetAPIClient{Clienter: list.DatasetAPI, guard: guards.DatasetAPI}
+	}
+
+	return &guarded
+}
@@ -0,0 +1,202 @@
+package clients_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/clients"
+	"github.com/ONSdigital/dis-migration-service/clients/mock"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var errTestDependency = errors.New("dependency unavailable")
+
+func TestGuardCircuitBreaker(t *testing.T) {
+	Convey("Given a guard that opens after 2 consecutive failures", t, func() {
+		guard := clients.NewGuards(clients.GuardConfig{FailureThreshold: 2, OpenDuration: time.Hour}, clients.GuardConfig{}).Zebedee
+		ctx := context.Background()
+
+		failing := func() error { return errTestDependency }
+
+		Convey("When a single call fails", func() {
+			err := guard.Do(ctx, failing)
+
+			Convey("Then the error is returned and the breaker stays closed", func() {
+				So(err, ShouldEqual, errTestDependency)
+				So(guard.IsOpen(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When two consecutive calls fail", func() {
+			_ = guard.Do(ctx, failing)
+			_ = guard.Do(ctx, failing)
+
+			Convey("Then the breaker is open", func() {
+				So(guard.IsOpen(), ShouldBeTrue)
+			})
+
+			Convey("And further calls are refused without calling the dependency", func() {
+				called := false
+				err := guard.Do(ctx, func() error {
+					called = true
+					return nil
+				})
+				So(errors.Is(err, clients.ErrCircuitOpen), ShouldBeTrue)
+				So(called, ShouldBeFalse)
+			})
+		})
+
+		Convey("When calls fail with responses that are not server errors", func() {
+			notFound := func() error {
+				return zebedee.ErrInvalidZebedeeResponse{ActualCode: http.StatusNotFound}
+			}
+			_ = guard.Do(ctx, notFound)
+			_ = guard.Do(ctx, notFound)
+
+			Convey("Then the breaker stays closed", func() {
+				So(guard.IsOpen(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When a failed call is followed by a successful one", func() {
+			_ = guard.Do(ctx, failing)
+			_ = guard.Do(ctx, func() error { return nil })
+			_ = guard.Do(ctx, failing)
+
+			Convey("Then the failures are not consecutive and the breaker stays closed", func() {
+				So(guard.IsOpen(), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a guard whose breaker has been open for its open duration", t, func() {
+		guard := clients.NewGuard(clients.DependencyDatasetAPI, clients.GuardConfig{FailureThreshold: 1, OpenDuration: 20 * time.Millisecond}, nil)
+		ctx := context.Background()
+
+		_ = guard.Do(ctx, func() error { return errTestDependency })
+		time.Sleep(30 * time.Millisecond)
+
+		Convey("Then the breaker is reported as closed only once, so that a single trial call is started", func() {
+			So(guard.IsOpen(), ShouldBeFalse)
+			So(guard.IsOpen(), ShouldBeTrue)
+		})
+
+		Convey("When the trial call offered is not made within the open duration", func() {
+			So(guard.IsOpen(), ShouldBeFalse)
+			time.Sleep(30 * time.Millisecond)
+
+			Convey("Then another trial call is offered", func() {
+				So(guard.IsOpen(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When a trial call is in progress", func() {
+			started := make(chan struct{})
+			finish := make(chan struct{})
+			done := make(chan error)
+			go func() {
+				done <- guard.Do(ctx, func() error {
+					close(started)
+					<-finish
+					return nil
+				})
+			}()
+			<-started
+
+			Convey("Then the breaker is reported as open and other calls are refused", func() {
+				So(guard.IsOpen(), ShouldBeTrue)
+				So(errors.Is(guard.Do(ctx, func() error { return nil }), clients.ErrCircuitOpen), ShouldBeTrue)
+
+				close(finish)
+				So(<-done, ShouldBeNil)
+				So(guard.IsOpen(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When a trial call succeeds", func() {
+			err := guard.Do(ctx, func() error { return nil })
+
+			Convey("Then the breaker is closed", func() {
+				So(err, ShouldBeNil)
+				So(guard.IsOpen(), ShouldBeFalse)
+				So(guard.Do(ctx, func() error { return nil }), ShouldBeNil)
+			})
+		})
+
+		Convey("When a trial call fails", func() {
+			_ = guard.Do(ctx, func() error { return errTestDependency })
+
+			Convey("Then the breaker opens again", func() {
+				So(guard.IsOpen(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestGuardRateLimit(t *testing.T) {
+	Convey("Given a guard allowing 1 call at a time at 10 calls per second", t, func() {
+		guard := clients.NewGuard(clients.DependencyZebedee, clients.GuardConfig{RateLimit: 10, RateBurst: 1}, nil)
+		ctx := context.Background()
+
+		Convey("When two calls are made", func() {
+			start := time.Now()
+			_ = guard.Do(ctx, func() error { return nil })
+			_ = guard.Do(ctx, func() error { return nil })
+
+			Convey("Then the second call waits for the rate limit", func() {
+				So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 90*time.Millisecond)
+			})
+		})
+
+		Convey("When a call is made with a context that is done while waiting", func() {
+			_ = guard.Do(ctx, func() error { return nil })
+
+			cancelledCtx, cancel := context.WithCancel(ctx)
+			cancel()
+			called := false
+			err := guard.Do(cancelledCtx, func() error {
+				called = true
+				return nil
+			})
+
+			Convey("Then the context error is returned without calling the dependency", func() {
+				So(err, ShouldEqual, context.Canceled)
+				So(called, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestWithGuards(t *testing.T) {
+	Convey("Given a client list guarded by a Zebedee guard whose breaker is open", t, func() {
+		zebedeeClient := &mock.ZebedeeClientMock{
+			PublishCollectionFunc: func(ctx context.Context, authToken, collectionID string) error {
+				return errTestDependency
+			},
+		}
+		guards := clients.NewGuards(clients.GuardConfig{FailureThreshold: 1, OpenDuration: time.Hour}, clients.GuardConfig{})
+		list := clients.WithGuards(&clients.ClientList{Zebedee: zebedeeClient}, guards)
+
+		err := list.Zebedee.PublishCollection(context.Background(), "token", "collection")
+		So(err, ShouldEqual, errTestDependency)
+
+		Convey("Then the breaker of Zebedee is reported as open", func() {
+			So(list.Guards.IsOpen(clients.DependencyZebedee), ShouldBeTrue)
+			So(list.Guards.IsOpen(clients.DependencyDatasetAPI), ShouldBeFalse)
+		})
+
+		Convey("When Zebedee is called again", func() {
+			err := list.Zebedee.PublishCollection(context.Background(), "token", "collection")
+
+			Convey("Then the call is refused without calling Zebedee", func() {
+				So(errors.Is(err, clients.ErrCircuitOpen), ShouldBeTrue)
+				So(zebedeeClient.PublishCollectionCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
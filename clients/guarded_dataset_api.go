@@ -0,0 +1,76 @@
+package clients
+
+import (
+	"context"
+
+	datasetModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPI "github.com/ONSdigital/dp-dataset-api/sdk"
+)
+
+// guardedDatasetAPIClient is a dataset API client whose calls used by the
+// service are made through a Guard. Any other calls go straight to the
+// embedded client.
+type guardedDatasetAPIClient struct {
+	datasetAPI.Clienter
+	guard *Guard
+}
+
+func (c *guardedDatasetAPIClient) CreateDataset(ctx context.Context, headers datasetAPI.Headers, dataset datasetModels.Dataset) (update datasetModels.DatasetUpdate, err error) {
+	err = c.guard.Do(ctx, func() error {
+		update, err = c.Clienter.CreateDataset(ctx, headers, dataset)
+		return err
+	})
+	return update, err
+}
+
+func (c *guardedDatasetAPIClient) DeleteDataset(ctx context.Context, headers datasetAPI.Headers, datasetID string) error {
+	return c.guard.Do(ctx, func() error {
+		return c.Clienter.DeleteDataset(ctx, headers, datasetID)
+	})
+}
+
+func (c *guardedDatasetAPIClient) GetDataset(ctx context.Context, headers datasetAPI.Headers, datasetID string) (dataset datasetModels.Dataset, err error) {
+	err = c.guard.Do(ctx, func() error {
+		dataset, err = c.Clienter.GetDataset(ctx, headers, datasetID)
+		return err
+	})
+	return dataset, err
+}
+
+func (c *guardedDatasetAPIClient) GetVersion(ctx context.Context, headers datasetAPI.Headers, datasetID, editionID, versionID string) (version datasetModels.Version, err error) {
+	err = c.guard.Do(ctx, func() error {
+		version, err = c.Clienter.GetVersion(ctx, headers, datasetID, editionID, versionID)
+		return err
+	})
+	return version, err
+}
+
+func (c *guardedDatasetAPIClient) GetVersionWithHeaders(ctx context.Context, headers datasetAPI.Headers, datasetID, edition, version string) (v datasetModels.Version, respHeaders datasetAPI.ResponseHeaders, err error) {
+	err = c.guard.Do(ctx, func() error {
+		v, respHeaders, err = c.Clienter.GetVersionWithHeaders(ctx, headers, datasetID, edition, version)
+		return err
+	})
+	return v, respHeaders, err
+}
+
+func (c *guardedDatasetAPIClient) PostVersion(ctx context.Context, headers datasetAPI.Headers, datasetID, editionID, versionID string, version datasetModels.Version, isLatest bool) (created *datasetModels.Version, err error) {
+	err = c.guard.Do(ctx, func() error {
+		created, err = c.Clienter.PostVersion(ctx, headers, datasetID, editionID, versionID, version, isLatest)
+		return err
+	})
+	return created, err
+}
+
+func (c *guardedDatasetAPIClient) PutVersion(ctx context.Context, headers datasetAPI.Headers, datasetID, editionID, versionID string, version datasetModels.Version) (updated datasetModels.Version, err error) {
+	err = c.guard.Do(ctx, func() error {
+		updated, err = c.Clienter.PutVersion(ctx, headers, datasetID, editionID, versionID, version)
+		return err
+	})
+	return updated, err
+}
+
+func (c *guardedDatasetAPIClient) PutVersionState(ctx context.Context, headers datasetAPI.Headers, datasetID, editionID, versionID, state string) error {
+	return c.guard.Do(ctx, func() error {
+		return c.Clienter.PutVersionState(ctx, headers, datasetID, editionID, versionID, state)
+	})
+}
@@ -0,0 +1,114 @@
+package clients
+
+import (
+	"context"
+	"io"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+)
+
+// guardedZebedeeClient is a ZebedeeClient that makes its calls through a
+// Guard.
+type guardedZebedeeClient struct {
+	client ZebedeeClient
+	guard  *Guard
+}
+
+func (c *guardedZebedeeClient) ApproveCollection(ctx context.Context, authToken, collectionID string) error {
+	return c.guard.Do(ctx, func() error {
+		return c.client.ApproveCollection(ctx, authToken, collectionID)
+	})
+}
+
+func (c *guardedZebedeeClient) ApproveCollectionContent(ctx context.Context, authToken, collectionID, lang, pagePath string) error {
+	return c.guard.Do(ctx, func() error {
+		return c.client.ApproveCollectionContent(ctx, authToken, collectionID, lang, pagePath)
+	})
+}
+
+func (c *guardedZebedeeClient) CompleteCollectionContent(ctx context.Context, authToken, collectionID, lang, pagePath string) error {
+	return c.guard.Do(ctx, func() error {
+		return c.client.CompleteCollectionContent(ctx, authToken, collectionID, lang, pagePath)
+	})
+}
+
+func (c *guardedZebedeeClient) CreateCollection(ctx context.Context, authToken string, collection zebedee.Collection) (created zebedee.Collection, err error) {
+	err = c.guard.Do(ctx, func() error {
+		created, err = c.client.CreateCollection(ctx, authToken, collection)
+		return err
+	})
+	return created, err
+}
+
+func (c *guardedZebedeeClient) DeleteCollection(ctx context.Context, userAuthToken, collectionID string) error {
+	return c.guard.Do(ctx, func() error {
+		return c.client.DeleteCollection(ctx, userAuthToken, collectionID)
+	})
+}
+
+func (c *guardedZebedeeClient) DeleteCollectionContent(ctx context.Context, userAuthToken, collectionID, path string) error {
+	return c.guard.Do(ctx, func() error {
+		return c.client.DeleteCollectionContent(ctx, userAuthToken, collectionID, path)
+	})
+}
+
+func (c *guardedZebedeeClient) GetCollection(ctx context.Context, authToken, collectionID string) (collection zebedee.Collection, err error) {
+	err = c.guard.Do(ctx, func() error {
+		collection, err = c.client.GetCollection(ctx, authToken, collectionID)
+		return err
+	})
+	return collection, err
+}
+
+func (c *guardedZebedeeClient) GetDataset(ctx context.Context, authToken, collectionID, lang, path string) (d zebedee.Dataset, err error) {
+	err = c.guard.Do(ctx, func() error {
+		d, err = c.client.GetDataset(ctx, authToken, collectionID, lang, path)
+		return err
+	})
+	return d, err
+}
+
+func (c *guardedZebedeeClient) GetDatasetLandingPage(ctx context.Context, authToken, collectionID, lang, path string) (d zebedee.DatasetLandingPage, err error) {
+	err = c.guard.Do(ctx, func() error {
+		d, err = c.client.GetDatasetLandingPage(ctx, authToken, collectionID, lang, path)
+		return err
+	})
+	return d, err
+}
+
+func (c *guardedZebedeeClient) GetFileSize(ctx context.Context, authToken, collectionID, lang, uri string) (f zebedee.FileSize, err error) {
+	err = c.guard.Do(ctx, func() error {
+		f, err = c.client.GetFileSize(ctx, authToken, collectionID, lang, uri)
+		return err
+	})
+	return f, err
+}
+
+func (c *guardedZebedeeClient) GetPageData(ctx context.Context, authToken, collectionID, lang, path string) (m zebedee.PageData, err error) {
+	err = c.guard.Do(ctx, func() error {
+		m, err = c.client.GetPageData(ctx, authToken, collectionID, lang, path)
+		return err
+	})
+	return m, err
+}
+
+// GetResourceStream only guards opening the stream, not reading from it
+func (c *guardedZebedeeClient) GetResourceStream(ctx context.Context, authToken, collectionID, lang, path string) (s io.ReadCloser, err error) {
+	err = c.guard.Do(ctx, func() error {
+		s, err = c.client.GetResourceStream(ctx, authToken, collectionID, lang, path)
+		return err
+	})
+	return s, err
+}
+
+func (c *guardedZebedeeClient) PublishCollection(ctx context.Context, authToken, collectionID string) error {
+	return c.guard.Do(ctx, func() error {
+		return c.client.PublishCollection(ctx, authToken, collectionID)
+	})
+}
+
+func (c *guardedZebedeeClient) SaveContentToCollection(ctx context.Context, authToken, collectionID, path string, content interface{}) error {
+	return c.guard.Do(ctx, func() error {
+		return c.client.SaveContentToCollection(ctx, authToken, collectionID, path, content)
+	})
+}
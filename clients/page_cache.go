@@ -0,0 +1,319 @@
+package clients
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// jobNumberKey is the context key holding the number of the job a call is
+// made for
+type jobNumberKey struct{}
+
+// WithJobNumber returns a copy of ctx recording that calls made with it
+// are for the job with the given number, so that Zebedee pages read for
+// the job can be cached.
+func WithJobNumber(ctx context.Context, jobNumber int) context.Context {
+	return context.WithValue(ctx, jobNumberKey{}, jobNumber)
+}
+
+// jobNumberFromContext returns the number of the job calls made with ctx
+// are for, if there is one
+func jobNumberFromContext(ctx context.Context) (int, bool) {
+	jobNumber, ok := ctx.Value(jobNumberKey{}).(int)
+	return jobNumber, ok
+}
+
+// PageCacheStats holds the number of reads of Zebedee pages served from
+// the cache and the number that had to be read from Zebedee
+type PageCacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// PageCache caches the Zebedee pages read for each job while it is being
+// migrated, so that pages shared by many tasks, such as the landing page
+// of a series, are only read once. At most maxJobs jobs are cached, each
+// holding at most maxEntries pages, and pages expire after ttl. A job's
+// pages should be invalidated when the job has finished with them, but as
+// that only happens on the instance that finished the job, jobs whose
+// pages have not been read for ttl are also swept from the cache.
+type PageCache struct {
+	maxJobs    int
+	maxEntries int
+	ttl        time.Duration
+	hits       atomic.Int64
+	misses     atomic.Int64
+
+	mu        sync.Mutex
+	jobs      map[int]*jobPages
+	lastSwept time.Time
+}
+
+// jobPages holds the cached pages of a single job, least recently used
+// last
+type jobPages struct {
+	entries  map[pageKey]*list.Element
+	order    *list.List
+	stats    PageCacheStats
+	lastUsed time.Time
+}
+
+// pageKey identifies a page read from Zebedee. The kind separates the
+// different responses that can be read for the same path.
+type pageKey struct {
+	kind         string
+	collectionID string
+	lang         string
+	path         string
+}
+
+// pageEntry is a cached page. ready is closed once the page has been read,
+// so concurrent reads of the same page wait for the first rather than
+// reading it again.
+type pageEntry struct {
+	key     pageKey
+	ready   chan struct{}
+	value   interface{}
+	err     error
+	expires time.Time
+}
+
+// NewPageCache creates a cache holding up to maxEntries pages for each of
+// up to maxJobs jobs, each for up to ttl
+func NewPageCache(maxJobs, maxEntries int, ttl time.Duration) *PageCache {
+	return &PageCache{
+		maxJobs:    maxJobs,
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		jobs:       make(map[int]*jobPages),
+	}
+}
+
+// Stats returns the number of hits and misses across all jobs
+func (c *PageCache) Stats() PageCacheStats {
+	if c == nil {
+		return PageCacheStats{}
+	}
+	return PageCacheStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+// Jobs returns the number of jobs that have pages in the cache
+func (c *PageCache) Jobs() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.jobs)
+}
+
+// Checker is called by the healthcheck library to report the number of
+// jobs cached and the hits and misses across all jobs. The cache only
+// saves calls to Zebedee, so it is always healthy.
+func (c *PageCache) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	stats := c.Stats()
+	message := fmt.Sprintf("%d jobs cached, %d hits, %d misses", c.Jobs(), stats.Hits, stats.Misses)
+	return state.Update(healthcheck.StatusOK, message, 0)
+}
+
+// Invalidate removes the cached pages of a job, logging the job's hits and
+// misses. A nil cache has nothing to invalidate.
+func (c *PageCache) Invalidate(ctx context.Context, jobNumber int) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	pages, ok := c.jobs[jobNumber]
+	delete(c.jobs, jobNumber)
+	c.mu.Unlock()
+
+	if ok {
+		log.Info(ctx, "invalidated zebedee page cache for job", log.Data{
+			"job_number": jobNumber,
+			"pages":      pages.order.Len(),
+			"hits":       pages.stats.Hits,
+			"misses":     pages.stats.Misses,
+		})
+	}
+}
+
+// get returns the page with the given key for the job in ctx, reading it
+// with read and caching it on a miss. Calls made without a job are not
+// cached.
+func (c *PageCache) get(ctx context.Context, key pageKey, read func() (interface{}, error)) (interface{}, error) {
+	jobNumber, ok := jobNumberFromContext(ctx)
+	if !ok || c == nil || c.maxJobs <= 0 || c.maxEntries <= 0 {
+		return read()
+	}
+
+	entry, hit := c.entry(jobNumber, key)
+	if !hit {
+		entry.value, entry.err = read()
+		c.done(jobNumber, entry)
+		return entry.value, entry.err
+	}
+
+	select {
+	case <-entry.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if entry.err != nil {
+		// The first read failed, so read the page again rather than
+		// sharing its error
+		return read()
+	}
+	return entry.value, nil
+}
+
+// entry returns the job's entry for the key, creating one to be read if
+// the page is not cached or has expired
+func (c *PageCache) entry(jobNumber int, key pageKey) (*pageEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.sweep(now)
+
+	pages, ok := c.jobs[jobNumber]
+	if !ok {
+		if len(c.jobs) >= c.maxJobs {
+			c.evictLeastRecentlyUsedJob()
+		}
+		pages = &jobPages{entries: make(map[pageKey]*list.Element), order: list.New()}
+		c.jobs[jobNumber] = pages
+	}
+	pages.lastUsed = now
+
+	if element, ok := pages.entries[key]; ok {
+		entry := element.Value.(*pageEntry)
+		if entry.expires.IsZero() || now.Before(entry.expires) {
+			pages.order.MoveToFront(element)
+			pages.stats.Hits++
+			c.hits.Add(1)
+			return entry, true
+		}
+		pages.order.Remove(element)
+		delete(pages.entries, key)
+	}
+
+	entry := &pageEntry{key: key, ready: make(chan struct{})}
+	pages.entries[key] = pages.order.PushFront(entry)
+	pages.stats.Misses++
+	c.misses.Add(1)
+
+	for pages.order.Len() > c.maxEntries {
+		oldest := pages.order.Back()
+		pages.order.Remove(oldest)
+		delete(pages.entries, oldest.Value.(*pageEntry).key)
+	}
+
+	return entry, false
+}
+
+// sweep removes the jobs whose pages have not been read for ttl, as all of
+// their pages have expired. Jobs are swept at most once every ttl. The
+// caller must hold the lock.
+func (c *PageCache) sweep(now time.Time) {
+	if c.ttl <= 0 || now.Sub(c.lastSwept) < c.ttl {
+		return
+	}
+	c.lastSwept = now
+
+	for jobNumber, pages := range c.jobs {
+		if now.Sub(pages.lastUsed) >= c.ttl {
+			delete(c.jobs, jobNumber)
+		}
+	}
+}
+
+// evictLeastRecentlyUsedJob removes the job whose pages were read least
+// recently, to make room for another job. The caller must hold the lock.
+func (c *PageCache) evictLeastRecentlyUsedJob() {
+	var (
+		oldest   int
+		oldestAt time.Time
+		found    bool
+	)
+	for jobNumber, pages := range c.jobs {
+		if !found || pages.lastUsed.Before(oldestAt) {
+			oldest, oldestAt, found = jobNumber, pages.lastUsed, true
+		}
+	}
+	if found {
+		delete(c.jobs, oldest)
+	}
+}
+
+// done marks an entry as read. Failed reads are removed from the cache so
+// that the page is read again next time.
+func (c *PageCache) done(jobNumber int, entry *pageEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.expires = time.Now().Add(c.ttl)
+	close(entry.ready)
+
+	pages, ok := c.jobs[jobNumber]
+	if !ok || entry.err == nil {
+		return
+	}
+	if element, ok := pages.entries[entry.key]; ok && element.Value == entry {
+		pages.order.Remove(element)
+		delete(pages.entries, entry.key)
+	}
+}
+
+// cachingZebedeeClient is a ZebedeeClient that caches the dataset landing
+// pages and datasets read for each job. Any other calls go straight to the
+// embedded client.
+type cachingZebedeeClient struct {
+	ZebedeeClient
+	cache *PageCache
+}
+
+func (c *cachingZebedeeClient) GetDataset(ctx context.Context, authToken, collectionID, lang, path string) (zebedee.Dataset, error) {
+	key := pageKey{kind: "dataset", collectionID: collectionID, lang: lang, path: path}
+	value, err := c.cache.get(ctx, key, func() (interface{}, error) {
+		return c.ZebedeeClient.GetDataset(ctx, authToken, collectionID, lang, path)
+	})
+	if err != nil {
+		return zebedee.Dataset{}, err
+	}
+	return value.(zebedee.Dataset), nil
+}
+
+func (c *cachingZebedeeClient) GetDatasetLandingPage(ctx context.Context, authToken, collectionID, lang, path string) (zebedee.DatasetLandingPage, error) {
+	key := pageKey{kind: "dataset_landing_page", collectionID: collectionID, lang: lang, path: path}
+	value, err := c.cache.get(ctx, key, func() (interface{}, error) {
+		return c.ZebedeeClient.GetDatasetLandingPage(ctx, authToken, collectionID, lang, path)
+	})
+	if err != nil {
+		return zebedee.DatasetLandingPage{}, err
+	}
+	return value.(zebedee.DatasetLandingPage), nil
+}
+
+// WithPageCache returns a copy of the client list whose Zebedee client
+// caches the dataset landing pages and datasets read for each job.
+func WithPageCache(list *ClientList, cache *PageCache) *ClientList {
+	cached := *list
+	cached.PageCache = cache
+
+	if list.Zebedee != nil {
+		cached.Zebedee = &cachingZebedeeClient{ZebedeeClient: list.Zebedee, cache: cache}
+	}
+
+	return &cached
+}
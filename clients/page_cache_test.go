@@ -0,0 +1,198 @@
+package clients_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/clients"
+	"github.com/ONSdigital/dis-migration-service/clients/mock"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPageCache(t *testing.T) {
+	Convey("Given a Zebedee client wrapped in a page cache", t, func() {
+		zebedeeClient := &mock.ZebedeeClientMock{
+			GetDatasetLandingPageFunc: func(ctx context.Context, authToken, collectionID, lang, path string) (zebedee.DatasetLandingPage, error) {
+				return zebedee.DatasetLandingPage{URI: path}, nil
+			},
+			GetDatasetFunc: func(ctx context.Context, authToken, collectionID, lang, path string) (zebedee.Dataset, error) {
+				return zebedee.Dataset{URI: path}, nil
+			},
+		}
+		cache := clients.NewPageCache(2, 2, time.Hour)
+		list := clients.WithPageCache(&clients.ClientList{Zebedee: zebedeeClient}, cache)
+		jobCtx := clients.WithJobNumber(context.Background(), 1)
+
+		Convey("When the same landing page is read twice for a job", func() {
+			first, err := list.Zebedee.GetDatasetLandingPage(jobCtx, "token", "", "en", "/a/page")
+			So(err, ShouldBeNil)
+			second, err := list.Zebedee.GetDatasetLandingPage(jobCtx, "token", "", "en", "/a/page")
+			So(err, ShouldBeNil)
+
+			Convey("Then Zebedee is only called once", func() {
+				So(zebedeeClient.GetDatasetLandingPageCalls(), ShouldHaveLength, 1)
+				So(second, ShouldResemble, first)
+			})
+
+			Convey("And the hit and miss are counted", func() {
+				So(cache.Stats(), ShouldResemble, clients.PageCacheStats{Hits: 1, Misses: 1})
+			})
+
+			Convey("And the hit and miss are reported through the healthcheck", func() {
+				state := healthcheck.NewCheckState("Zebedee page cache")
+				err := cache.Checker(context.Background(), state)
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+				So(state.Message(), ShouldEqual, "1 jobs cached, 1 hits, 1 misses")
+			})
+
+			Convey("And once the job's pages are invalidated the page is read again", func() {
+				cache.Invalidate(jobCtx, 1)
+				_, err := list.Zebedee.GetDatasetLandingPage(jobCtx, "token", "", "en", "/a/page")
+				So(err, ShouldBeNil)
+				So(zebedeeClient.GetDatasetLandingPageCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When the same path is read in a different language, collection or job", func() {
+			_, _ = list.Zebedee.GetDatasetLandingPage(jobCtx, "token", "", "en", "/a/page")
+			_, _ = list.Zebedee.GetDatasetLandingPage(jobCtx, "token", "", "cy", "/a/page")
+			_, _ = list.Zebedee.GetDatasetLandingPage(jobCtx, "token", "collection", "en", "/a/page")
+			_, _ = list.Zebedee.GetDatasetLandingPage(clients.WithJobNumber(context.Background(), 2), "token", "", "en", "/a/page")
+
+			Convey("Then each page is read from Zebedee", func() {
+				So(zebedeeClient.GetDatasetLandingPageCalls(), ShouldHaveLength, 4)
+				So(cache.Stats().Misses, ShouldEqual, 4)
+			})
+		})
+
+		Convey("When a dataset and a landing page are read from the same path", func() {
+			_, _ = list.Zebedee.GetDatasetLandingPage(jobCtx, "token", "", "en", "/a/page")
+			dataset, err := list.Zebedee.GetDataset(jobCtx, "token", "", "en", "/a/page")
+
+			Convey("Then they are cached separately", func() {
+				So(err, ShouldBeNil)
+				So(dataset.URI, ShouldEqual, "/a/page")
+				So(zebedeeClient.GetDatasetCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When more pages are read for a job than the cache holds", func() {
+			_, _ = list.Zebedee.GetDataset(jobCtx, "token", "", "en", "/a")
+			_, _ = list.Zebedee.GetDataset(jobCtx, "token", "", "en", "/b")
+			_, _ = list.Zebedee.GetDataset(jobCtx, "token", "", "en", "/a")
+			_, _ = list.Zebedee.GetDataset(jobCtx, "token", "", "en", "/c")
+
+			Convey("Then the least recently used page is evicted", func() {
+				_, _ = list.Zebedee.GetDataset(jobCtx, "token", "", "en", "/a")
+				So(zebedeeClient.GetDatasetCalls(), ShouldHaveLength, 3)
+
+				_, _ = list.Zebedee.GetDataset(jobCtx, "token", "", "en", "/b")
+				So(zebedeeClient.GetDatasetCalls(), ShouldHaveLength, 4)
+			})
+		})
+
+		Convey("When pages are read for more jobs than the cache holds", func() {
+			_, _ = list.Zebedee.GetDataset(clients.WithJobNumber(context.Background(), 1), "token", "", "en", "/a")
+			_, _ = list.Zebedee.GetDataset(clients.WithJobNumber(context.Background(), 2), "token", "", "en", "/a")
+			_, _ = list.Zebedee.GetDataset(clients.WithJobNumber(context.Background(), 1), "token", "", "en", "/a")
+			_, _ = list.Zebedee.GetDataset(clients.WithJobNumber(context.Background(), 3), "token", "", "en", "/a")
+
+			Convey("Then the pages of the least recently used job are evicted", func() {
+				So(cache.Jobs(), ShouldEqual, 2)
+
+				_, _ = list.Zebedee.GetDataset(clients.WithJobNumber(context.Background(), 1), "token", "", "en", "/a")
+				So(zebedeeClient.GetDatasetCalls(), ShouldHaveLength, 3)
+
+				_, _ = list.Zebedee.GetDataset(clients.WithJobNumber(context.Background(), 2), "token", "", "en", "/a")
+				So(zebedeeClient.GetDatasetCalls(), ShouldHaveLength, 4)
+			})
+		})
+
+		Convey("When a page is read without a job", func() {
+			_, _ = list.Zebedee.GetDatasetLandingPage(context.Background(), "token", "", "en", "/a/page")
+			_, _ = list.Zebedee.GetDatasetLandingPage(context.Background(), "token", "", "en", "/a/page")
+
+			Convey("Then it is not cached", func() {
+				So(zebedeeClient.GetDatasetLandingPageCalls(), ShouldHaveLength, 2)
+				So(cache.Stats(), ShouldResemble, clients.PageCacheStats{})
+			})
+		})
+	})
+
+	Convey("Given a page cache whose reads fail", t, func() {
+		zebedeeClient := &mock.ZebedeeClientMock{
+			GetDatasetLandingPageFunc: func(ctx context.Context, authToken, collectionID, lang, path string) (zebedee.DatasetLandingPage, error) {
+				return zebedee.DatasetLandingPage{}, errTestDependency
+			},
+		}
+		list := clients.WithPageCache(&clients.ClientList{Zebedee: zebedeeClient}, clients.NewPageCache(10, 10, time.Hour))
+		jobCtx := clients.WithJobNumber(context.Background(), 1)
+
+		Convey("When the same page is read twice", func() {
+			_, err := list.Zebedee.GetDatasetLandingPage(jobCtx, "token", "", "en", "/a/page")
+			So(err, ShouldEqual, errTestDependency)
+			_, err = list.Zebedee.GetDatasetLandingPage(jobCtx, "token", "", "en", "/a/page")
+			So(err, ShouldEqual, errTestDependency)
+
+			Convey("Then the failure is not cached", func() {
+				So(zebedeeClient.GetDatasetLandingPageCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+
+	Convey("Given a page cache whose pages have expired", t, func() {
+		zebedeeClient := &mock.ZebedeeClientMock{
+			GetDatasetFunc: func(ctx context.Context, authToken, collectionID, lang, path string) (zebedee.Dataset, error) {
+				return zebedee.Dataset{URI: path}, nil
+			},
+		}
+		list := clients.WithPageCache(&clients.ClientList{Zebedee: zebedeeClient}, clients.NewPageCache(10, 10, 0))
+		jobCtx := clients.WithJobNumber(context.Background(), 1)
+
+		Convey("When the same page is read twice", func() {
+			_, _ = list.Zebedee.GetDataset(jobCtx, "token", "", "en", "/a")
+			_, _ = list.Zebedee.GetDataset(jobCtx, "token", "", "en", "/a")
+
+			Convey("Then it is read from Zebedee each time", func() {
+				So(zebedeeClient.GetDatasetCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+
+	Convey("Given a page cache with a job that is no longer read", t, func() {
+		zebedeeClient := &mock.ZebedeeClientMock{
+			GetDatasetFunc: func(ctx context.Context, authToken, collectionID, lang, path string) (zebedee.Dataset, error) {
+				return zebedee.Dataset{URI: path}, nil
+			},
+		}
+		cache := clients.NewPageCache(10, 10, 10*time.Millisecond)
+		list := clients.WithPageCache(&clients.ClientList{Zebedee: zebedeeClient}, cache)
+
+		_, _ = list.Zebedee.GetDataset(clients.WithJobNumber(context.Background(), 1), "token", "", "en", "/a")
+		So(cache.Jobs(), ShouldEqual, 1)
+
+		Convey("When another job's pages are read after the job has been idle for longer than the TTL", func() {
+			time.Sleep(20 * time.Millisecond)
+			_, _ = list.Zebedee.GetDataset(clients.WithJobNumber(context.Background(), 2), "token", "", "en", "/a")
+
+			Convey("Then the idle job is swept from the cache without being invalidated", func() {
+				So(cache.Jobs(), ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a nil page cache", t, func() {
+		var cache *clients.PageCache
+
+		Convey("Then it has no stats and can be invalidated", func() {
+			So(cache.Stats(), ShouldResemble, clients.PageCacheStats{})
+			So(cache.Jobs(), ShouldEqual, 0)
+			So(func() { cache.Invalidate(context.Background(), 1) }, ShouldNotPanic)
+		})
+	})
+}
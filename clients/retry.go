@@ -0,0 +1,175 @@
+package clients
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RetryConfig holds the settings for retrying failed calls to a
+// dependency.
+type RetryConfig struct {
+	// MaxAttempts is the most times a call is made, including the first
+	// attempt
+	MaxAttempts int
+	// BaseDelay is the upper bound of the delay before the first retry.
+	// It doubles for each retry after that.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay before any retry
+	MaxDelay time.Duration
+}
+
+// retryableStatusCodes are the response status codes that show a request
+// may succeed if it is made again
+var retryableStatusCodes = map[int]bool{
+	http.StatusRequestTimeout:      true,
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+// isRetryable returns true if a call that failed with err may succeed if
+// made again. Errors with a status code are retried if the status shows the
+// failure may be temporary. Errors without one are only retried if they
+// show the dependency could not be reached or the connection failed, and
+// not if the call was cancelled or refused by a circuit breaker.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+
+	if code, ok := StatusCode(err); ok {
+		return retryableStatusCodes[code]
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// isNotFound returns true if err is from a response showing the requested
+// resource does not exist
+func isNotFound(err error) bool {
+	code, ok := StatusCode(err)
+	return ok && code == http.StatusNotFound
+}
+
+// retry makes a call to a dependency, making it again with jittered
+// exponential backoff while it fails with a retryable error. It gives up
+// early rather than wait past the context's deadline. The number of
+// attempts is logged and recorded on the current trace span.
+func retry(ctx context.Context, cfg RetryConfig, dependency, operation string, call func() error) error {
+	span := trace.SpanFromContext(ctx)
+	logData := log.Data{"dependency": dependency, "operation": operation}
+
+	var err error
+	attempt := 1
+	for ; ; attempt++ {
+		err = call()
+		if err == nil || !isRetryable(err) || attempt >= cfg.MaxAttempts {
+			break
+		}
+
+		delay := backoff(cfg, attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			break
+		}
+
+		logData["attempt"] = attempt
+		logData["delay"] = delay.String()
+		logData["error"] = err.Error()
+		log.Info(ctx, "retrying failed call to dependency", logData)
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.String("dependency", dependency),
+			attribute.String("operation", operation),
+			attribute.Int("attempt", attempt),
+		))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+
+	if attempt > 1 {
+		span.SetAttributes(attribute.Int(dependency+"."+operation+".attempts", attempt))
+		delete(logData, "delay")
+		logData["attempt"] = attempt
+		if err != nil {
+			logData["error"] = err.Error()
+			log.Info(ctx, "call to dependency failed after retrying", logData)
+		} else {
+			delete(logData, "error")
+			log.Info(ctx, "call to dependency succeeded after retrying", logData)
+		}
+	}
+
+	return err
+}
+
+// retryDelete makes a call to delete a resource from a dependency, retrying
+// it as retry does. A retried delete that finds the resource does not exist
+// succeeds, as an earlier attempt that appeared to fail may have deleted it.
+func retryDelete(ctx context.Context, cfg RetryConfig, dependency, operation string, call func() error) error {
+	attempts := 0
+	return retry(ctx, cfg, dependency, operation, func() error {
+		attempts++
+		err := call()
+		if attempts > 1 && isNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+// backoff returns a random delay of up to BaseDelay doubled for each
+// previous retry, capped at MaxDelay
+func backoff(cfg RetryConfig, attempt int) time.Duration {
+	maxDelay := cfg.BaseDelay
+	for i := 1; i < attempt; i++ {
+		maxDelay *= 2
+	}
+	if cfg.MaxDelay > 0 && maxDelay > cfg.MaxDelay {
+		maxDelay = cfg.MaxDelay
+	}
+	if maxDelay <= 0 {
+		return 0
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(maxDelay)))
+	if err != nil {
+		return maxDelay
+	}
+	return time.Duration(n.Int64())
+}
+
+// WithRetries returns a copy of the client list whose Zebedee and dataset
+// API clients retry reads and idempotent writes that fail with a retryable
+// error. Other writes are not retried, as they may have taken effect.
+func WithRetries(list *ClientList, cfg RetryConfig) *ClientList {
+	retrying := *list
+
+	if list.Zebedee != nil {
+		retrying.Zebedee = &retryingZebedeeClient{client: list.Zebedee, cfg: cfg}
+	}
+	if list.DatasetAPI != nil {
+		retrying.DatasetAPI = &retryingDatasetAPIClient{Clienter: list.DatasetAPI, cfg: cfg}
+	}
+
+	return &retrying
+}
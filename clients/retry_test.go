@@ -0,0 +1,250 @@
+package clients_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/clients"
+	"github.com/ONSdigital/dis-migration-service/clients/mock"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func zebedeeResponseError(code int) error {
+	return zebedee.ErrInvalidZebedeeResponse{ActualCode: code}
+}
+
+func TestWithRetries(t *testing.T) {
+	retryCfg := clients.RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	Convey("Given a Zebedee client that is unavailable for its first call", t, func() {
+		calls := 0
+		zebedeeClient := &mock.ZebedeeClientMock{
+			GetPageDataFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedee.PageData, error) {
+				calls++
+				if calls == 1 {
+					return zebedee.PageData{}, zebedeeResponseError(http.StatusServiceUnavailable)
+				}
+				return zebedee.PageData{Type: "dataset_landing_page"}, nil
+			},
+			PublishCollectionFunc: func(ctx context.Context, authToken, collectionID string) error {
+				return zebedeeResponseError(http.StatusServiceUnavailable)
+			},
+		}
+		list := clients.WithRetries(&clients.ClientList{Zebedee: zebedeeClient}, retryCfg)
+
+		Convey("When a page is read", func() {
+			pageData, err := list.Zebedee.GetPageData(context.Background(), "token", "", "en", "/a/page")
+
+			Convey("Then the read is retried and succeeds", func() {
+				So(err, ShouldBeNil)
+				So(pageData.Type, ShouldEqual, "dataset_landing_page")
+				So(zebedeeClient.GetPageDataCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When a collection is published", func() {
+			err := list.Zebedee.PublishCollection(context.Background(), "token", "collection")
+
+			Convey("Then the publish is not retried as it may have taken effect", func() {
+				So(err, ShouldNotBeNil)
+				So(zebedeeClient.PublishCollectionCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a Zebedee client that is always unavailable", t, func() {
+		zebedeeClient := &mock.ZebedeeClientMock{
+			GetPageDataFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedee.PageData, error) {
+				return zebedee.PageData{}, zebedeeResponseError(http.StatusBadGateway)
+			},
+		}
+		list := clients.WithRetries(&clients.ClientList{Zebedee: zebedeeClient}, retryCfg)
+
+		Convey("When a page is read", func() {
+			_, err := list.Zebedee.GetPageData(context.Background(), "token", "", "en", "/a/page")
+
+			Convey("Then the read is made the maximum number of times and the last error is returned", func() {
+				code, ok := clients.StatusCode(err)
+				So(ok, ShouldBeTrue)
+				So(code, ShouldEqual, http.StatusBadGateway)
+				So(zebedeeClient.GetPageDataCalls(), ShouldHaveLength, 3)
+			})
+		})
+
+		Convey("When a page is read with a deadline shorter than the retry delay", func() {
+			slowRetries := clients.WithRetries(&clients.ClientList{Zebedee: zebedeeClient}, clients.RetryConfig{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour})
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+			defer cancel()
+
+			_, err := slowRetries.Zebedee.GetPageData(ctx, "token", "", "en", "/a/page")
+
+			Convey("Then the read is not retried", func() {
+				So(err, ShouldNotBeNil)
+				So(zebedeeClient.GetPageDataCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a Zebedee client that does not have the requested page", t, func() {
+		zebedeeClient := &mock.ZebedeeClientMock{
+			GetPageDataFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedee.PageData, error) {
+				return zebedee.PageData{}, zebedeeResponseError(http.StatusNotFound)
+			},
+		}
+		list := clients.WithRetries(&clients.ClientList{Zebedee: zebedeeClient}, retryCfg)
+
+		Convey("When the page is read", func() {
+			_, err := list.Zebedee.GetPageData(context.Background(), "token", "", "en", "/a/page")
+
+			Convey("Then the read is not retried", func() {
+				So(err, ShouldNotBeNil)
+				So(zebedeeClient.GetPageDataCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestWithRetriesErrorsWithoutStatus(t *testing.T) {
+	retryCfg := clients.RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	Convey("Given a Zebedee client that cannot be connected to for its first call", t, func() {
+		calls := 0
+		zebedeeClient := &mock.ZebedeeClientMock{
+			GetPageDataFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedee.PageData, error) {
+				calls++
+				if calls == 1 {
+					return zebedee.PageData{}, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+				}
+				return zebedee.PageData{Type: "dataset_landing_page"}, nil
+			},
+		}
+		list := clients.WithRetries(&clients.ClientList{Zebedee: zebedeeClient}, retryCfg)
+
+		Convey("When a page is read", func() {
+			_, err := list.Zebedee.GetPageData(context.Background(), "token", "", "en", "/a/page")
+
+			Convey("Then the read is retried and succeeds", func() {
+				So(err, ShouldBeNil)
+				So(zebedeeClient.GetPageDataCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+
+	Convey("Given a Zebedee client whose connection is closed part way through its first response", t, func() {
+		calls := 0
+		zebedeeClient := &mock.ZebedeeClientMock{
+			GetPageDataFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedee.PageData, error) {
+				calls++
+				if calls == 1 {
+					return zebedee.PageData{}, fmt.Errorf("failed to read response: %w", io.ErrUnexpectedEOF)
+				}
+				return zebedee.PageData{Type: "dataset_landing_page"}, nil
+			},
+		}
+		list := clients.WithRetries(&clients.ClientList{Zebedee: zebedeeClient}, retryCfg)
+
+		Convey("When a page is read", func() {
+			_, err := list.Zebedee.GetPageData(context.Background(), "token", "", "en", "/a/page")
+
+			Convey("Then the read is retried and succeeds", func() {
+				So(err, ShouldBeNil)
+				So(zebedeeClient.GetPageDataCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+
+	Convey("Given a Zebedee client that fails with an error that does not show a connection failure", t, func() {
+		zebedeeClient := &mock.ZebedeeClientMock{
+			GetPageDataFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (zebedee.PageData, error) {
+				return zebedee.PageData{}, errors.New("failed to unmarshal page data")
+			},
+		}
+		list := clients.WithRetries(&clients.ClientList{Zebedee: zebedeeClient}, retryCfg)
+
+		Convey("When a page is read", func() {
+			_, err := list.Zebedee.GetPageData(context.Background(), "token", "", "en", "/a/page")
+
+			Convey("Then the read is not retried", func() {
+				So(err, ShouldNotBeNil)
+				So(zebedeeClient.GetPageDataCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestWithRetriesDelete(t *testing.T) {
+	retryCfg := clients.RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	Convey("Given a Zebedee client that deletes a collection but times out responding", t, func() {
+		calls := 0
+		zebedeeClient := &mock.ZebedeeClientMock{
+			DeleteCollectionFunc: func(ctx context.Context, userAuthToken, collectionID string) error {
+				calls++
+				if calls == 1 {
+					return zebedeeResponseError(http.StatusGatewayTimeout)
+				}
+				return zebedeeResponseError(http.StatusNotFound)
+			},
+		}
+		list := clients.WithRetries(&clients.ClientList{Zebedee: zebedeeClient}, retryCfg)
+
+		Convey("When the collection is deleted", func() {
+			err := list.Zebedee.DeleteCollection(context.Background(), "token", "collection")
+
+			Convey("Then the retry finding the collection does not exist succeeds", func() {
+				So(err, ShouldBeNil)
+				So(zebedeeClient.DeleteCollectionCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+
+	Convey("Given a Zebedee client that does not have the collection", t, func() {
+		zebedeeClient := &mock.ZebedeeClientMock{
+			DeleteCollectionFunc: func(ctx context.Context, userAuthToken, collectionID string) error {
+				return zebedeeResponseError(http.StatusNotFound)
+			},
+		}
+		list := clients.WithRetries(&clients.ClientList{Zebedee: zebedeeClient}, retryCfg)
+
+		Convey("When the collection is deleted", func() {
+			err := list.Zebedee.DeleteCollection(context.Background(), "token", "collection")
+
+			Convey("Then the first attempt's not found error is returned", func() {
+				code, ok := clients.StatusCode(err)
+				So(ok, ShouldBeTrue)
+				So(code, ShouldEqual, http.StatusNotFound)
+				So(zebedeeClient.DeleteCollectionCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestStatusCode(t *testing.T) {
+	Convey("Given an error from a Zebedee response", t, func() {
+		err := zebedeeResponseError(http.StatusConflict)
+
+		Convey("Then its status code is returned", func() {
+			code, ok := clients.StatusCode(err)
+			So(ok, ShouldBeTrue)
+			So(code, ShouldEqual, http.StatusConflict)
+		})
+	})
+
+	Convey("Given an error that does not carry a status code", t, func() {
+		err := errors.New("connection refused")
+
+		Convey("Then no status code is returned", func() {
+			_, ok := clients.StatusCode(err)
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,63 @@
+package clients
+
+import (
+	"context"
+
+	datasetModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPI "github.com/ONSdigital/dp-dataset-api/sdk"
+)
+
+// retryingDatasetAPIClient is a dataset API client that retries the reads
+// and idempotent writes used by the service. Creating datasets and
+// versions is not retried, and any other calls go straight to the
+// embedded client.
+type retryingDatasetAPIClient struct {
+	datasetAPI.Clienter
+	cfg RetryConfig
+}
+
+func (c *retryingDatasetAPIClient) DeleteDataset(ctx context.Context, headers datasetAPI.Headers, datasetID string) error {
+	return retryDelete(ctx, c.cfg, DependencyDatasetAPI, "DeleteDataset", func() error {
+		return c.Clienter.DeleteDataset(ctx, headers, datasetID)
+	})
+}
+
+func (c *retryingDatasetAPIClient) GetDataset(ctx context.Context, headers datasetAPI.Headers, datasetID string) (dataset datasetModels.Dataset, err error) {
+	err = retry(ctx, c.cfg, DependencyDatasetAPI, "GetDataset", func() error {
+		dataset, err = c.Clienter.GetDataset(ctx, headers, datasetID)
+		return err
+	})
+	return dataset, err
+}
+
+func (c *retryingDatasetAPIClient) GetVersion(ctx context.Context, headers datasetAPI.Headers, datasetID, editionID, versionID string) (version datasetModels.Version, err error) {
+	err = retry(ctx, c.cfg, DependencyDatasetAPI, "GetVersion", func() error {
+		version, err = c.Clienter.GetVersion(ctx, headers, datasetID, editionID, versionID)
+		return err
+	})
+	return version, err
+}
+
+func (c *retryingDatasetAPIClient) GetVersionWithHeaders(ctx context.Context, headers datasetAPI.Headers, datasetID, edition, version string) (v datasetModels.Version, respHeaders datasetAPI.ResponseHeaders, err error) {
+	err = retry(ctx, c.cfg, DependencyDatasetAPI, "GetVersionWithHeaders", func() error {
+		v, respHeaders, err = c.Clienter.GetVersionWithHeaders(ctx, headers, datasetID, edition, version)
+		return err
+	})
+	return v, respHeaders, err
+}
+
+// PutVersion is retried as it replaces the version, and requests made with
+// an If-Match header are refused rather than applied twice
+func (c *retryingDatasetAPIClient) PutVersion(ctx context.Context, headers datasetAPI.Headers, datasetID, editionID, versionID string, version datasetModels.Version) (updated datasetModels.Version, err error) {
+	err = retry(ctx, c.cfg, DependencyDatasetAPI, "PutVersion", func() error {
+		updated, err = c.Clienter.PutVersion(ctx, headers, datasetID, editionID, versionID, version)
+		return err
+	})
+	return updated, err
+}
+
+func (c *retryingDatasetAPIClient) PutVersionState(ctx context.Context, headers datasetAPI.Headers, datasetID, editionID, versionID, state string) error {
+	return retry(ctx, c.cfg, DependencyDatasetAPI, "PutVersionState", func() error {
+		return c.Clienter.PutVersionState(ctx, headers, datasetID, editionID, versionID, state)
+	})
+}
@@ -0,0 +1,105 @@
+package clients
+
+import (
+	"context"
+	"io"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+)
+
+// retryingZebedeeClient is a ZebedeeClient that retries reads and
+// idempotent writes. Calls that change a collection's state are not
+// retried.
+type retryingZebedeeClient struct {
+	client ZebedeeClient
+	cfg    RetryConfig
+}
+
+func (c *retryingZebedeeClient) ApproveCollection(ctx context.Context, authToken, collectionID string) error {
+	return c.client.ApproveCollection(ctx, authToken, collectionID)
+}
+
+func (c *retryingZebedeeClient) ApproveCollectionContent(ctx context.Context, authToken, collectionID, lang, pagePath string) error {
+	return c.client.ApproveCollectionContent(ctx, authToken, collectionID, lang, pagePath)
+}
+
+func (c *retryingZebedeeClient) CompleteCollectionContent(ctx context.Context, authToken, collectionID, lang, pagePath string) error {
+	return c.client.CompleteCollectionContent(ctx, authToken, collectionID, lang, pagePath)
+}
+
+func (c *retryingZebedeeClient) CreateCollection(ctx context.Context, authToken string, collection zebedee.Collection) (zebedee.Collection, error) {
+	return c.client.CreateCollection(ctx, authToken, collection)
+}
+
+func (c *retryingZebedeeClient) DeleteCollection(ctx context.Context, userAuthToken, collectionID string) error {
+	return retryDelete(ctx, c.cfg, DependencyZebedee, "DeleteCollection", func() error {
+		return c.client.DeleteCollection(ctx, userAuthToken, collectionID)
+	})
+}
+
+func (c *retryingZebedeeClient) DeleteCollectionContent(ctx context.Context, userAuthToken, collectionID, path string) error {
+	return retryDelete(ctx, c.cfg, DependencyZebedee, "DeleteCollectionContent", func() error {
+		return c.client.DeleteCollectionContent(ctx, userAuthToken, collectionID, path)
+	})
+}
+
+func (c *retryingZebedeeClient) GetCollection(ctx context.Context, authToken, collectionID string) (collection zebedee.Collection, err error) {
+	err = retry(ctx, c.cfg, DependencyZebedee, "GetCollection", func() error {
+		collection, err = c.client.GetCollection(ctx, authToken, collectionID)
+		return err
+	})
+	return collection, err
+}
+
+func (c *retryingZebedeeClient) GetDataset(ctx context.Context, authToken, collectionID, lang, path string) (d zebedee.Dataset, err error) {
+	err = retry(ctx, c.cfg, DependencyZebedee, "GetDataset", func() error {
+		d, err = c.client.GetDataset(ctx, authToken, collectionID, lang, path)
+		return err
+	})
+	return d, err
+}
+
+func (c *retryingZebedeeClient) GetDatasetLandingPage(ctx context.Context, authToken, collectionID, lang, path string) (d zebedee.DatasetLandingPage, err error) {
+	err = retry(ctx, c.cfg, DependencyZebedee, "GetDatasetLandingPage", func() error {
+		d, err = c.client.GetDatasetLandingPage(ctx, authToken, collectionID, lang, path)
+		return err
+	})
+	return d, err
+}
+
+func (c *retryingZebedeeClient) GetFileSize(ctx context.Context, authToken, collectionID, lang, uri string) (f zebedee.FileSize, err error) {
+	err = retry(ctx, c.cfg, DependencyZebedee, "GetFileSize", func() error {
+		f, err = c.client.GetFileSize(ctx, authToken, collectionID, lang, uri)
+		return err
+	})
+	return f, err
+}
+
+func (c *retryingZebedeeClient) GetPageData(ctx context.Context, authToken, collectionID, lang, path string) (m zebedee.PageData, err error) {
+	err = retry(ctx, c.cfg, DependencyZebedee, "GetPageData", func() error {
+		m, err = c.client.GetPageData(ctx, authToken, collectionID, lang, path)
+		return err
+	})
+	return m, err
+}
+
+// GetResourceStream only retries opening the stream, not reading from it
+func (c *retryingZebedeeClient) GetResourceStream(ctx context.Context, authToken, collectionID, lang, path string) (s io.ReadCloser, err error) {
+	err = retry(ctx, c.cfg, DependencyZebedee, "GetResourceStream", func() error {
+		s, err = c.client.GetResourceStream(ctx, authToken, collectionID, lang, path)
+		return err
+	})
+	return s, err
+}
+
+func (c *retryingZebedeeClient) PublishCollection(ctx context.Context, authToken, collectionID string) error {
+	return c.client.PublishCollection(ctx, authToken, collectionID)
+}
+
+// SaveContentToCollection is retried as it replaces the content at the
+// path, so saving it again has the same effect
+func (c *retryingZebedeeClient) SaveContentToCollection(ctx context.Context, authToken, collectionID, path string, content interface{}) error {
+	return retry(ctx, c.cfg, DependencyZebedee, "SaveContentToCollection", func() error {
+		return c.client.SaveContentToCollection(ctx, authToken, collectionID, path, content)
+	})
+}
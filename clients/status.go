@@ -0,0 +1,33 @@
+package clients
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+)
+
+// StatusCode returns the HTTP status code of the response that caused a
+// client error, if the error carries one.
+func StatusCode(err error) (int, bool) {
+	var zebedeeErr zebedee.ErrInvalidZebedeeResponse
+	if errors.As(err, &zebedeeErr) {
+		return zebedeeErr.ActualCode, true
+	}
+
+	var coder interface{ Code() int }
+	if errors.As(err, &coder) {
+		return coder.Code(), true
+	}
+
+	return 0, false
+}
+
+// isServerFailure returns false for errors from responses that are not
+// server errors, as they show the dependency is responding.
+func isServerFailure(err error) bool {
+	if code, ok := StatusCode(err); ok {
+		return code >= http.StatusInternalServerError
+	}
+	return true
+}
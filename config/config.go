@@ -3,6 +3,7 @@ package config
 import (
 	"time"
 
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	"github.com/ONSdigital/dis-migration-service/slack"
 	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
 	dpMongo "github.com/ONSdigital/dp-mongodb/v3/mongodb"
@@ -16,33 +17,70 @@ type MongoConfig struct {
 
 // Config represents service configuration for dis-migration-service
 type Config struct {
-	BindAddr                        string        `envconfig:"BIND_ADDR"`
-	DatasetAPIURL                   string        `envconfig:"DATASET_API_URL"`
-	DefaultLimit                    int           `envconfig:"DEFAULT_LIMIT"`
-	DefaultOffset                   int           `envconfig:"DEFAULT_OFFSET"`
-	DefaultMaxLimit                 int           `envconfig:"DEFAULT_MAX_LIMIT"`
-	EnableEventLogging              bool          `envconfig:"ENABLE_EVENT_LOGGING"`
-	EnableMockClients               bool          `envconfig:"ENABLE_MOCK_CLIENTS"`
-	FilesAPIURL                     string        `envconfig:"FILES_API_URL"`
-	GracefulShutdownTimeout         time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
-	HealthCheckInterval             time.Duration `envconfig:"HEALTHCHECK_INTERVAL"`
-	HealthCheckCriticalTimeout      time.Duration `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
-	MigratorMaxConcurrentExecutions int           `envconfig:"MIGRATOR_MAX_CONCURRENT_EXECUTIONS"`
-	MigratorPollInterval            time.Duration `envconfig:"MIGRATOR_POLL_INTERVAL"`
-	OTBatchTimeout                  time.Duration `envconfig:"OTEL_BATCH_TIMEOUT"`
-	OTExporterOTLPEndpoint          string        `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
-	OTServiceName                   string        `envconfig:"OTEL_SERVICE_NAME"`
-	OtelEnabled                     bool          `envconfig:"OTEL_ENABLED"`
-	RedirectAPIURL                  string        `envconfig:"REDIRECT_API_URL"`
-	ServiceAuthToken                string        `envconfig:"SERVICE_AUTH_TOKEN" json:"-"`
-	TopicAPIURL                     string        `envconfig:"TOPIC_API_URL"`
-	TopicCacheUpdateInterval        time.Duration `envconfig:"TOPIC_CACHE_UPDATE_INTERVAL"`
-	EnableTopicCache                bool          `envconfig:"ENABLE_TOPIC_CACHE"`
-	UploadServiceURL                string        `envconfig:"UPLOAD_SERVICE_URL"`
-	ZebedeeURL                      string        `envconfig:"ZEBEDEE_URL"`
+	BatchMaxConcurrentRows          int            `envconfig:"BATCH_MAX_CONCURRENT_ROWS"`
+	BatchMaxSize                    int            `envconfig:"BATCH_MAX_SIZE"`
+	BindAddr                        string         `envconfig:"BIND_ADDR"`
+	CircuitBreakerFailureThreshold  int            `envconfig:"CIRCUIT_BREAKER_FAILURE_THRESHOLD"`
+	CircuitBreakerOpenDuration      time.Duration  `envconfig:"CIRCUIT_BREAKER_OPEN_DURATION"`
+	ClientRetryBaseDelay            time.Duration  `envconfig:"CLIENT_RETRY_BASE_DELAY"`
+	ClientRetryMaxAttempts          int            `envconfig:"CLIENT_RETRY_MAX_ATTEMPTS"`
+	ClientRetryMaxDelay             time.Duration  `envconfig:"CLIENT_RETRY_MAX_DELAY"`
+	DatasetAPIURL                   string         `envconfig:"DATASET_API_URL"`
+	DatasetAPIRateBurst             int            `envconfig:"DATASET_API_RATE_BURST"`
+	DatasetAPIRateLimit             float64        `envconfig:"DATASET_API_RATE_LIMIT"`
+	DefaultLimit                    int            `envconfig:"DEFAULT_LIMIT"`
+	DefaultOffset                   int            `envconfig:"DEFAULT_OFFSET"`
+	DefaultMaxLimit                 int            `envconfig:"DEFAULT_MAX_LIMIT"`
+	DigestEnabled                   bool           `envconfig:"DIGEST_ENABLED"`
+	DigestMaxItems                  int            `envconfig:"DIGEST_MAX_ITEMS"`
+	DigestPeriod                    time.Duration  `envconfig:"DIGEST_PERIOD"`
+	DigestStuckTaskThreshold        time.Duration  `envconfig:"DIGEST_STUCK_TASK_THRESHOLD"`
+	DigestTime                      string         `envconfig:"DIGEST_TIME"`
+	EnableClientRetries             bool           `envconfig:"ENABLE_CLIENT_RETRIES"`
+	EnableMockClients               bool           `envconfig:"ENABLE_MOCK_CLIENTS"`
+	EnableWebhooks                  bool           `envconfig:"ENABLE_WEBHOOKS"`
+	EnableZebedeePageCache          bool           `envconfig:"ENABLE_ZEBEDEE_PAGE_CACHE"`
+	FilesAPIURL                     string         `envconfig:"FILES_API_URL"`
+	FourEyesOverridePermission      string         `envconfig:"FOUR_EYES_OVERRIDE_PERMISSION"`
+	GracefulShutdownTimeout         time.Duration  `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
+	HealthCheckInterval             time.Duration  `envconfig:"HEALTHCHECK_INTERVAL"`
+	HealthCheckCriticalTimeout      time.Duration  `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
+	IdempotencyKeyExpiry            time.Duration  `envconfig:"IDEMPOTENCY_KEY_EXPIRY"`
+	IdempotencyKeyPendingExpiry     time.Duration  `envconfig:"IDEMPOTENCY_KEY_PENDING_EXPIRY"`
+	MigratorChangeStreamsEnabled    bool           `envconfig:"MIGRATOR_CHANGE_STREAMS_ENABLED"`
+	MigratorMaxConcurrentExecutions int            `envconfig:"MIGRATOR_MAX_CONCURRENT_EXECUTIONS"`
+	MigratorMaxExecutionsPerJob     int            `envconfig:"MIGRATOR_MAX_EXECUTIONS_PER_JOB"`
+	MigratorMaxJobExecutions        int            `envconfig:"MIGRATOR_MAX_JOB_EXECUTIONS"`
+	MigratorMaxTaskExecutions       map[string]int `envconfig:"MIGRATOR_MAX_TASK_EXECUTIONS"`
+	MigratorPollInterval            time.Duration  `envconfig:"MIGRATOR_POLL_INTERVAL"`
+	OTBatchTimeout                  time.Duration  `envconfig:"OTEL_BATCH_TIMEOUT"`
+	OTExporterOTLPEndpoint          string         `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTServiceName                   string         `envconfig:"OTEL_SERVICE_NAME"`
+	OtelEnabled                     bool           `envconfig:"OTEL_ENABLED"`
+	RedirectAPIURL                  string         `envconfig:"REDIRECT_API_URL"`
+	RequiredApprovals               int            `envconfig:"REQUIRED_APPROVALS"`
+	ServiceAuthToken                string         `envconfig:"SERVICE_AUTH_TOKEN" json:"-"`
+	StreamHeartbeatInterval         time.Duration  `envconfig:"STREAM_HEARTBEAT_INTERVAL"`
+	StreamHistorySize               int            `envconfig:"STREAM_HISTORY_SIZE"`
+	TopicAPIURL                     string         `envconfig:"TOPIC_API_URL"`
+	TopicCacheUpdateInterval        time.Duration  `envconfig:"TOPIC_CACHE_UPDATE_INTERVAL"`
+	EnableTopicCache                bool           `envconfig:"ENABLE_TOPIC_CACHE"`
+	UploadServiceURL                string         `envconfig:"UPLOAD_SERVICE_URL"`
+	WebhookMaxAttempts              int            `envconfig:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookMaxConcurrentDeliveries  int            `envconfig:"WEBHOOK_MAX_CONCURRENT_DELIVERIES"`
+	WebhookPollInterval             time.Duration  `envconfig:"WEBHOOK_POLL_INTERVAL"`
+	WebhookRetryBackoff             time.Duration  `envconfig:"WEBHOOK_RETRY_BACKOFF"`
+	WebhookTimeout                  time.Duration  `envconfig:"WEBHOOK_TIMEOUT"`
+	ZebedeeURL                      string         `envconfig:"ZEBEDEE_URL"`
+	ZebedeePageCacheMaxEntries      int            `envconfig:"ZEBEDEE_PAGE_CACHE_MAX_ENTRIES"`
+	ZebedeePageCacheMaxJobs         int            `envconfig:"ZEBEDEE_PAGE_CACHE_MAX_JOBS"`
+	ZebedeePageCacheTTL             time.Duration  `envconfig:"ZEBEDEE_PAGE_CACHE_TTL"`
+	ZebedeeRateBurst                int            `envconfig:"ZEBEDEE_RATE_BURST"`
+	ZebedeeRateLimit                float64        `envconfig:"ZEBEDEE_RATE_LIMIT"`
 	MongoConfig
-	AuthConfig  *authorisation.Config
-	SlackConfig *slack.Config
+	AuthConfig     *authorisation.Config
+	NotifierConfig *notifier.Config
+	SlackConfig    *slack.Config
 }
 
 var cfg *Config
@@ -72,6 +110,48 @@ const (
 	// TasksCollectionName is the actual name of the MongoDB collection for
 	// migration tasks.
 	TasksCollectionName = "tasks"
+	// BatchesCollectionTitle is the well known name of the MongoDB collection
+	// for migration batches.
+	BatchesCollectionTitle = "MigrationsBatchesCollection"
+	// BatchesCollectionName is the actual name of the MongoDB collection for
+	// migration batches.
+	BatchesCollectionName = "batches"
+	// IdempotencyKeysCollectionTitle is the well known name of the MongoDB
+	// collection for job creation idempotency keys.
+	IdempotencyKeysCollectionTitle = "MigrationsIdempotencyKeysCollection"
+	// IdempotencyKeysCollectionName is the actual name of the MongoDB
+	// collection for job creation idempotency keys.
+	IdempotencyKeysCollectionName = "idempotency_keys"
+	// WebhooksCollectionTitle is the well known name of the MongoDB
+	// collection for webhook subscriptions.
+	WebhooksCollectionTitle = "MigrationsWebhooksCollection"
+	// WebhooksCollectionName is the actual name of the MongoDB collection
+	// for webhook subscriptions.
+	WebhooksCollectionName = "webhooks"
+	// WebhookDeliveriesCollectionTitle is the well known name of the
+	// MongoDB collection for webhook deliveries.
+	WebhookDeliveriesCollectionTitle = "MigrationsWebhookDeliveriesCollection"
+	// WebhookDeliveriesCollectionName is the actual name of the MongoDB
+	// collection for webhook deliveries.
+	WebhookDeliveriesCollectionName = "webhook_deliveries"
+	// CommentsCollectionTitle is the well known name of the MongoDB
+	// collection for job comments.
+	CommentsCollectionTitle = "MigrationsCommentsCollection"
+	// CommentsCollectionName is the actual name of the MongoDB collection
+	// for job comments.
+	CommentsCollectionName = "comments"
+	// ReleaseGroupsCollectionTitle is the well known name of the MongoDB
+	// collection for release groups.
+	ReleaseGroupsCollectionTitle = "MigrationsReleaseGroupsCollection"
+	// ReleaseGroupsCollectionName is the actual name of the MongoDB
+	// collection for release groups.
+	ReleaseGroupsCollectionName = "release_groups"
+	// DigestRunsCollectionTitle is the well known name of the MongoDB
+	// collection for the daily digests that have been claimed.
+	DigestRunsCollectionTitle = "MigrationsDigestRunsCollection"
+	// DigestRunsCollectionName is the actual name of the MongoDB
+	// collection for the daily digests that have been claimed.
+	DigestRunsCollectionName = "digest_runs"
 )
 
 // Get returns the default config with any modifications through environment
@@ -82,30 +162,56 @@ func Get() (*Config, error) {
 	}
 
 	cfg = &Config{
+		BatchMaxConcurrentRows:          10,
+		BatchMaxSize:                    100,
 		BindAddr:                        "localhost:30100",
+		CircuitBreakerFailureThreshold:  5,
+		CircuitBreakerOpenDuration:      30 * time.Second,
+		ClientRetryBaseDelay:            100 * time.Millisecond,
+		ClientRetryMaxAttempts:          3,
+		ClientRetryMaxDelay:             2 * time.Second,
 		DatasetAPIURL:                   "http://localhost:22000",
+		DatasetAPIRateBurst:             20,
+		DatasetAPIRateLimit:             50,
 		DefaultLimit:                    10,
 		DefaultOffset:                   0,
 		DefaultMaxLimit:                 100,
-		EnableEventLogging:              false,
+		DigestEnabled:                   false,
+		DigestMaxItems:                  10,
+		DigestPeriod:                    24 * time.Hour,
+		DigestStuckTaskThreshold:        time.Hour,
+		DigestTime:                      "09:00",
+		EnableClientRetries:             true,
 		EnableMockClients:               false,
+		EnableWebhooks:                  false,
+		EnableZebedeePageCache:          true,
 		FilesAPIURL:                     "http://localhost:26900",
+		FourEyesOverridePermission:      "migrations:admin",
 		GracefulShutdownTimeout:         5 * time.Second,
 		HealthCheckInterval:             30 * time.Second,
 		HealthCheckCriticalTimeout:      90 * time.Second,
+		IdempotencyKeyExpiry:            24 * time.Hour,
+		IdempotencyKeyPendingExpiry:     5 * time.Minute,
+		MigratorChangeStreamsEnabled:    false,
 		MigratorMaxConcurrentExecutions: 5,
+		MigratorMaxExecutionsPerJob:     3,
+		MigratorMaxJobExecutions:        5,
+		MigratorMaxTaskExecutions:       map[string]int{"dataset_download": 2},
 		MigratorPollInterval:            5 * time.Second,
 		OTBatchTimeout:                  5 * time.Second,
 		OTExporterOTLPEndpoint:          "localhost:4317",
 		OTServiceName:                   "dis-migration-service",
 		OtelEnabled:                     false,
+		RequiredApprovals:               1,
+		StreamHeartbeatInterval:         15 * time.Second,
+		StreamHistorySize:               1000,
 		MongoConfig: MongoConfig{
 			MongoDriverConfig: dpMongo.MongoDriverConfig{
 				ClusterEndpoint:               "localhost:27017",
 				Username:                      "",
 				Password:                      "",
 				Database:                      "migrations",
-				Collections:                   map[string]string{CountersCollectionTitle: CountersCollectionName, JobsCollectionTitle: JobsCollectionName, EventsCollectionTitle: EventsCollectionName, TasksCollectionTitle: TasksCollectionName},
+				Collections:                   map[string]string{CountersCollectionTitle: CountersCollectionName, JobsCollectionTitle: JobsCollectionName, EventsCollectionTitle: EventsCollectionName, TasksCollectionTitle: TasksCollectionName, BatchesCollectionTitle: BatchesCollectionName, IdempotencyKeysCollectionTitle: IdempotencyKeysCollectionName, WebhooksCollectionTitle: WebhooksCollectionName, WebhookDeliveriesCollectionTitle: WebhookDeliveriesCollectionName, CommentsCollectionTitle: CommentsCollectionName, ReleaseGroupsCollectionTitle: ReleaseGroupsCollectionName, DigestRunsCollectionTitle: DigestRunsCollectionName},
 				ReplicaSet:                    "",
 				IsStrongReadConcernEnabled:    false,
 				IsWriteConcernMajorityEnabled: true,
@@ -116,15 +222,31 @@ func Get() (*Config, error) {
 				},
 			},
 		},
-		AuthConfig:               authorisation.NewDefaultConfig(),
-		SlackConfig:              &slack.Config{},
-		ServiceAuthToken:         "migrationservicetestauthtoken",
-		RedirectAPIURL:           "http://localhost:29900",
-		TopicAPIURL:              "http://localhost:25300",
-		TopicCacheUpdateInterval: 10 * time.Minute,
-		EnableTopicCache:         false,
-		UploadServiceURL:         "http://localhost:25100",
-		ZebedeeURL:               "http://localhost:8082",
+		AuthConfig: authorisation.NewDefaultConfig(),
+		NotifierConfig: &notifier.Config{
+			Routes:         notifier.DefaultRoutes,
+			WebhookTimeout: 10 * time.Second,
+			EmailSMTPAddr:  "localhost:1025",
+			EmailFrom:      "dis-migration-service@localhost",
+		},
+		SlackConfig:                    &slack.Config{},
+		ServiceAuthToken:               "migrationservicetestauthtoken",
+		RedirectAPIURL:                 "http://localhost:29900",
+		TopicAPIURL:                    "http://localhost:25300",
+		TopicCacheUpdateInterval:       10 * time.Minute,
+		EnableTopicCache:               false,
+		UploadServiceURL:               "http://localhost:25100",
+		WebhookMaxAttempts:             5,
+		WebhookMaxConcurrentDeliveries: 5,
+		WebhookPollInterval:            5 * time.Second,
+		WebhookRetryBackoff:            30 * time.Second,
+		WebhookTimeout:                 10 * time.Second,
+		ZebedeeURL:                     "http://localhost:8082",
+		ZebedeePageCacheMaxEntries:     100,
+		ZebedeePageCacheMaxJobs:        20,
+		ZebedeePageCacheTTL:            10 * time.Minute,
+		ZebedeeRateBurst:               10,
+		ZebedeeRateLimit:               20,
 	}
 
 	return cfg, envconfig.Process("", cfg)
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	"github.com/ONSdigital/dis-migration-service/slack"
 	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
 	dpMongo "github.com/ONSdigital/dp-mongodb/v3/mongodb"
@@ -27,23 +28,48 @@ func TestConfig(t *testing.T) {
 				So(err, ShouldBeNil)
 				So(configuration, ShouldResemble, &Config{
 					AuthConfig:                      authorisation.NewDefaultConfig(),
+					BatchMaxConcurrentRows:          10,
+					BatchMaxSize:                    100,
 					BindAddr:                        "localhost:30100",
+					CircuitBreakerFailureThreshold:  5,
+					CircuitBreakerOpenDuration:      30 * time.Second,
+					ClientRetryBaseDelay:            100 * time.Millisecond,
+					ClientRetryMaxAttempts:          3,
+					ClientRetryMaxDelay:             2 * time.Second,
 					DatasetAPIURL:                   "http://localhost:22000",
+					DatasetAPIRateBurst:             20,
+					DatasetAPIRateLimit:             50,
 					DefaultLimit:                    10,
 					DefaultOffset:                   0,
 					DefaultMaxLimit:                 100,
-					EnableEventLogging:              false,
+					DigestEnabled:                   false,
+					DigestMaxItems:                  10,
+					DigestPeriod:                    24 * time.Hour,
+					DigestStuckTaskThreshold:        time.Hour,
+					DigestTime:                      "09:00",
+					EnableClientRetries:             true,
 					EnableMockClients:               false,
+					EnableWebhooks:                  false,
+					EnableZebedeePageCache:          true,
 					EnableTopicCache:                false,
 					FilesAPIURL:                     "http://localhost:26900",
+					FourEyesOverridePermission:      "migrations:admin",
 					GracefulShutdownTimeout:         5 * time.Second,
 					HealthCheckInterval:             30 * time.Second,
 					HealthCheckCriticalTimeout:      90 * time.Second,
+					IdempotencyKeyExpiry:            24 * time.Hour,
+					IdempotencyKeyPendingExpiry:     5 * time.Minute,
 					OTBatchTimeout:                  5 * time.Second,
 					OTExporterOTLPEndpoint:          "localhost:4317",
 					OTServiceName:                   "dis-migration-service",
 					OtelEnabled:                     false,
+					StreamHeartbeatInterval:         15 * time.Second,
+					StreamHistorySize:               1000,
+					MigratorChangeStreamsEnabled:    false,
 					MigratorMaxConcurrentExecutions: 5,
+					MigratorMaxExecutionsPerJob:     3,
+					MigratorMaxJobExecutions:        5,
+					MigratorMaxTaskExecutions:       map[string]int{"dataset_download": 2},
 					MigratorPollInterval:            5 * time.Second,
 					MongoConfig: MongoConfig{
 						MongoDriverConfig: dpMongo.MongoDriverConfig{
@@ -51,7 +77,7 @@ func TestConfig(t *testing.T) {
 							Username:                      "",
 							Password:                      "",
 							Database:                      "migrations",
-							Collections:                   map[string]string{CountersCollectionTitle: CountersCollectionName, JobsCollectionTitle: JobsCollectionName, EventsCollectionTitle: EventsCollectionName, TasksCollectionTitle: TasksCollectionName},
+							Collections:                   map[string]string{CountersCollectionTitle: CountersCollectionName, JobsCollectionTitle: JobsCollectionName, EventsCollectionTitle: EventsCollectionName, TasksCollectionTitle: TasksCollectionName, BatchesCollectionTitle: BatchesCollectionName, IdempotencyKeysCollectionTitle: IdempotencyKeysCollectionName, WebhooksCollectionTitle: WebhooksCollectionName, WebhookDeliveriesCollectionTitle: WebhookDeliveriesCollectionName, CommentsCollectionTitle: CommentsCollectionName, ReleaseGroupsCollectionTitle: ReleaseGroupsCollectionName, DigestRunsCollectionTitle: DigestRunsCollectionName},
 							ReplicaSet:                    "",
 							IsStrongReadConcernEnabled:    false,
 							IsWriteConcernMajorityEnabled: true,
@@ -62,13 +88,30 @@ func TestConfig(t *testing.T) {
 							},
 						},
 					},
-					RedirectAPIURL:           "http://localhost:29900",
-					ServiceAuthToken:         "migrationservicetestauthtoken",
-					SlackConfig:              &slack.Config{},
-					TopicAPIURL:              "http://localhost:25300",
-					TopicCacheUpdateInterval: 10 * time.Minute,
-					UploadServiceURL:         "http://localhost:25100",
-					ZebedeeURL:               "http://localhost:8082",
+					NotifierConfig: &notifier.Config{
+						Routes:         notifier.DefaultRoutes,
+						WebhookTimeout: 10 * time.Second,
+						EmailSMTPAddr:  "localhost:1025",
+						EmailFrom:      "dis-migration-service@localhost",
+					},
+					RedirectAPIURL:                 "http://localhost:29900",
+					RequiredApprovals:              1,
+					ServiceAuthToken:               "migrationservicetestauthtoken",
+					SlackConfig:                    &slack.Config{},
+					TopicAPIURL:                    "http://localhost:25300",
+					TopicCacheUpdateInterval:       10 * time.Minute,
+					UploadServiceURL:               "http://localhost:25100",
+					WebhookMaxAttempts:             5,
+					WebhookMaxConcurrentDeliveries: 5,
+					WebhookPollInterval:            5 * time.Second,
+					WebhookRetryBackoff:            30 * time.Second,
+					WebhookTimeout:                 10 * time.Second,
+					ZebedeeURL:                     "http://localhost:8082",
+					ZebedeePageCacheMaxEntries:     100,
+					ZebedeePageCacheMaxJobs:        20,
+					ZebedeePageCacheTTL:            10 * time.Minute,
+					ZebedeeRateBurst:               10,
+					ZebedeeRateLimit:               20,
 				})
 			})
 
@@ -0,0 +1,228 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/application"
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/slack"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Summary is the summary of the Slack message a digest is sent as
+const Summary = "Daily migration digest"
+
+// timeOfDayLayout is the layout of the configured time of day to send the
+// digest at
+const timeOfDayLayout = "15:04"
+
+// Scheduler sends a digest of recent migration activity, and of the jobs
+// and tasks that need attention, to Slack at the same time every day.
+type Scheduler struct {
+	jobService  application.JobService
+	slackClient slack.Clienter
+	hour        int
+	minute      int
+	wg          sync.WaitGroup
+	stopFunc    context.CancelFunc
+}
+
+// NewScheduler creates a new Scheduler that sends digests with the
+// provided Slack client at the configured time of day, in UTC.
+func NewScheduler(cfg *config.Config, jobService application.JobService, slackClient slack.Clienter) (*Scheduler, error) {
+	at, err := time.Parse(timeOfDayLayout, cfg.DigestTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid digest time %q, expected HH:MM: %w", cfg.DigestTime, err)
+	}
+
+	return &Scheduler{
+		jobService:  jobService,
+		slackClient: slackClient,
+		hour:        at.Hour(),
+		minute:      at.Minute(),
+	}, nil
+}
+
+// Start begins sending a digest every day at the scheduled time
+func (s *Scheduler) Start(ctx context.Context) {
+	log.Info(ctx, "starting digest scheduler")
+	ctx, cancel := context.WithCancel(context.Background()) //nolint:gosec // Context is cancelled on shutdown
+	s.stopFunc = cancel
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(ctx)
+	}()
+}
+
+// Shutdown waits for any digest being sent to complete or times out
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	log.Info(ctx, "shutting down digest scheduler")
+
+	if s.stopFunc != nil {
+		s.stopFunc()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Info(ctx, "digest scheduler shut down completed successfully")
+		return nil
+	case <-ctx.Done():
+		err := fmt.Errorf("timed out waiting for digest to be sent")
+		log.Error(ctx, "error shutting down digest scheduler", err)
+		return err
+	}
+}
+
+// run waits until each scheduled time and sends a digest
+func (s *Scheduler) run(ctx context.Context) {
+	for {
+		next := nextRun(time.Now().UTC(), s.hour, s.minute)
+		log.Info(ctx, "next migration digest scheduled", log.Data{"next_run": next})
+
+		select {
+		case <-ctx.Done():
+			log.Info(ctx, "stopping digest scheduler")
+			return
+		case <-time.After(time.Until(next)):
+			s.sendScheduled(ctx, next)
+		}
+	}
+}
+
+// sendScheduled sends the digest scheduled at the given time if this
+// instance of the service claims it, so that it is only sent once however
+// many instances are running
+func (s *Scheduler) sendScheduled(ctx context.Context, scheduledAt time.Time) {
+	logData := log.Data{"scheduled_at": scheduledAt}
+
+	claimed, err := s.jobService.ClaimDigestRun(ctx, scheduledAt)
+	if err != nil {
+		log.Error(ctx, "failed to claim scheduled migration digest", err, logData)
+		return
+	}
+	if !claimed {
+		log.Info(ctx, "scheduled migration digest already claimed", logData)
+		return
+	}
+
+	if _, err := s.Send(ctx); err != nil {
+		log.Error(ctx, "failed to send scheduled migration digest", err, logData)
+	}
+}
+
+// Send builds a digest and sends it to the Slack info channel, returning
+// the digest that was sent. The message is marked as unsuccessful if any
+// jobs failed during the digest period or any tasks are stuck.
+func (s *Scheduler) Send(ctx context.Context) (*domain.Digest, error) {
+	digest, err := s.jobService.GetDigest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	healthy := digest.JobsFailed == 0 && digest.StuckTasks.Count == 0
+
+	if err := s.slackClient.SendInfo(ctx, Summary, details(digest), healthy); err != nil {
+		return nil, fmt.Errorf("failed to send migration digest: %w", err)
+	}
+
+	log.Info(ctx, "migration digest sent", log.Data{
+		"jobs_created":      digest.JobsCreated,
+		"jobs_completed":    digest.JobsCompleted,
+		"jobs_failed":       digest.JobsFailed,
+		"awaiting_approval": digest.AwaitingApproval.Count,
+		"stuck_tasks":       digest.StuckTasks.Count,
+	})
+
+	return digest, nil
+}
+
+// nextRun returns the first time after now at the given hour and minute,
+// in UTC
+func nextRun(now time.Time, hour, minute int) time.Time {
+	now = now.UTC()
+
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next
+}
+
+// details formats a digest as the fields of a Slack message
+func details(digest *domain.Digest) slack.SlackDetails {
+	return slack.SlackDetails{
+		"Period": fmt.Sprintf("%s to %s",
+			digest.PeriodStart.Format(time.RFC3339),
+			digest.GeneratedAt.Format(time.RFC3339),
+		),
+		"Jobs created":      digest.JobsCreated,
+		"Jobs completed":    digest.JobsCompleted,
+		"Jobs failed":       digest.JobsFailed,
+		"Job states":        formatJobStates(digest.JobStates),
+		"Awaiting approval": formatJobs(digest.AwaitingApproval),
+		"Stuck tasks":       formatTasks(digest.StuckTasks),
+	}
+}
+
+// formatJobStates lists the number of jobs in each state, one per line
+func formatJobStates(stateSummaries []domain.StateSummary) string {
+	if len(stateSummaries) == 0 {
+		return "None"
+	}
+
+	lines := make([]string, len(stateSummaries))
+	for i, summary := range stateSummaries {
+		lines[i] = fmt.Sprintf("%s: %d", summary.Label, summary.Count)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatJobs lists the jobs of a digest one per line, noting how many more
+// there are than are listed
+func formatJobs(jobs domain.DigestJobs) string {
+	lines := make([]string, 0, len(jobs.Jobs)+1)
+	for _, job := range jobs.Jobs {
+		lines = append(lines, fmt.Sprintf("#%d %s (waiting %s)", job.JobNumber, job.Label, job.WaitingFor))
+	}
+
+	return joinLines(lines, jobs.Count)
+}
+
+// formatTasks lists the tasks of a digest one per line, noting how many
+// more there are than are listed
+func formatTasks(tasks domain.DigestTasks) string {
+	lines := make([]string, 0, len(tasks.Tasks)+1)
+	for _, task := range tasks.Tasks {
+		lines = append(lines, fmt.Sprintf("%s %s of job #%d (%s for %s)", task.Type, task.ID, task.JobNumber, task.State, task.WaitingFor))
+	}
+
+	return joinLines(lines, tasks.Count)
+}
+
+// joinLines joins the listed lines of a total number of items, adding a
+// line for any items that were not listed
+func joinLines(lines []string, total int) string {
+	if total == 0 {
+		return "None"
+	}
+
+	if more := total - len(lines); more > 0 {
+		lines = append(lines, fmt.Sprintf("and %d more", more))
+	}
+
+	return strings.Join(lines, "\n")
+}
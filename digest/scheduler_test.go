@@ -0,0 +1,286 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	applicationMock "github.com/ONSdigital/dis-migration-service/application/mock"
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/slack"
+	slackMocks "github.com/ONSdigital/dis-migration-service/slack/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newTestDigest() *domain.Digest {
+	generatedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	return &domain.Digest{
+		GeneratedAt:   generatedAt,
+		PeriodStart:   generatedAt.Add(-24 * time.Hour),
+		JobsCreated:   4,
+		JobsCompleted: 2,
+		JobStates: []domain.StateSummary{
+			{ID: domain.StateInReview, Label: "In review", Count: 3},
+			{ID: domain.StateCompleted, Label: "Completed", Count: 2},
+		},
+		AwaitingApproval: domain.DigestJobs{
+			Count: 3,
+			Jobs: []*domain.DigestJob{
+				{JobNumber: 3, Label: "Oldest", WaitingFor: "30h0m0s"},
+				{JobNumber: 8, Label: "Newer", WaitingFor: "2h0m0s"},
+			},
+		},
+		StuckTasks: domain.DigestTasks{
+			Tasks: []*domain.DigestTask{},
+		},
+	}
+}
+
+func newMockSlackClient() *slackMocks.ClienterMock {
+	return &slackMocks.ClienterMock{
+		SendInfoFunc: func(ctx context.Context, summary string, details slack.SlackDetails, success bool) error {
+			return nil
+		},
+	}
+}
+
+func TestNewScheduler(t *testing.T) {
+	Convey("Given a configured digest time", t, func() {
+		cfg := &config.Config{DigestTime: "17:45"}
+
+		Convey("When NewScheduler is called", func() {
+			scheduler, err := NewScheduler(cfg, &applicationMock.JobServiceMock{}, newMockSlackClient())
+
+			Convey("Then the scheduler sends the digest at that time", func() {
+				So(err, ShouldBeNil)
+				So(scheduler.hour, ShouldEqual, 17)
+				So(scheduler.minute, ShouldEqual, 45)
+			})
+		})
+	})
+
+	Convey("Given a digest time that is not in HH:MM format", t, func() {
+		cfg := &config.Config{DigestTime: "9am"}
+
+		Convey("When NewScheduler is called", func() {
+			scheduler, err := NewScheduler(cfg, &applicationMock.JobServiceMock{}, newMockSlackClient())
+
+			Convey("Then an error is returned", func() {
+				So(scheduler, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestNextRun(t *testing.T) {
+	Convey("Given a digest scheduled for 09:00", t, func() {
+		Convey("When it is before 09:00", func() {
+			next := nextRun(time.Date(2026, 10, 18, 8, 59, 0, 0, time.UTC), 9, 0)
+
+			Convey("Then the digest is next sent later the same day", func() {
+				So(next, ShouldEqual, time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC))
+			})
+		})
+
+		Convey("When it is exactly 09:00", func() {
+			next := nextRun(time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC), 9, 0)
+
+			Convey("Then the digest is next sent the following day", func() {
+				So(next, ShouldEqual, time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC))
+			})
+		})
+
+		Convey("When it is after 09:00 on the last day of the month", func() {
+			next := nextRun(time.Date(2026, 10, 31, 15, 0, 0, 0, time.UTC), 9, 0)
+
+			Convey("Then the digest is next sent on the first day of the next month", func() {
+				So(next, ShouldEqual, time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC))
+			})
+		})
+	})
+}
+
+func TestSend(t *testing.T) {
+	Convey("Given a scheduler and a job service that builds a digest with no failures or stuck tasks", t, func() {
+		digest := newTestDigest()
+		jobService := &applicationMock.JobServiceMock{
+			GetDigestFunc: func(ctx context.Context) (*domain.Digest, error) {
+				return digest, nil
+			},
+		}
+		slackClient := newMockSlackClient()
+		scheduler, err := NewScheduler(&config.Config{DigestTime: "09:00"}, jobService, slackClient)
+		So(err, ShouldBeNil)
+
+		Convey("When Send is called", func() {
+			sent, err := scheduler.Send(context.Background())
+
+			Convey("Then the digest is sent to Slack as a successful info message", func() {
+				So(err, ShouldBeNil)
+				So(sent, ShouldEqual, digest)
+
+				So(slackClient.SendInfoCalls(), ShouldHaveLength, 1)
+				call := slackClient.SendInfoCalls()[0]
+				So(call.Summary, ShouldEqual, Summary)
+				So(call.Success, ShouldBeTrue)
+			})
+
+			Convey("And the message details summarise the digest", func() {
+				details := slackClient.SendInfoCalls()[0].Details
+				So(details["Period"], ShouldEqual, "2026-10-17T09:00:00Z to 2026-10-18T09:00:00Z")
+				So(details["Jobs created"], ShouldEqual, 4)
+				So(details["Jobs completed"], ShouldEqual, 2)
+				So(details["Jobs failed"], ShouldEqual, 0)
+				So(details["Job states"], ShouldEqual, "In review: 3\nCompleted: 2")
+				So(details["Awaiting approval"], ShouldEqual, "#3 Oldest (waiting 30h0m0s)\n#8 Newer (waiting 2h0m0s)\nand 1 more")
+				So(details["Stuck tasks"], ShouldEqual, "None")
+			})
+		})
+	})
+
+	Convey("Given a scheduler and a job service that builds a digest with stuck tasks", t, func() {
+		digest := newTestDigest()
+		digest.StuckTasks = domain.DigestTasks{
+			Count: 1,
+			Tasks: []*domain.DigestTask{
+				{ID: "task-1", JobNumber: 5, Type: domain.TaskTypeDatasetVersion, State: domain.StateMigrating, WaitingFor: "3h0m0s"},
+			},
+		}
+		jobService := &applicationMock.JobServiceMock{
+			GetDigestFunc: func(ctx context.Context) (*domain.Digest, error) {
+				return digest, nil
+			},
+		}
+		slackClient := newMockSlackClient()
+		scheduler, err := NewScheduler(&config.Config{DigestTime: "09:00"}, jobService, slackClient)
+		So(err, ShouldBeNil)
+
+		Convey("When Send is called", func() {
+			_, err := scheduler.Send(context.Background())
+
+			Convey("Then the digest is sent as an unsuccessful message listing the stuck tasks", func() {
+				So(err, ShouldBeNil)
+				call := slackClient.SendInfoCalls()[0]
+				So(call.Success, ShouldBeFalse)
+				So(call.Details["Stuck tasks"], ShouldEqual, "dataset_version task-1 of job #5 (migrating for 3h0m0s)")
+			})
+		})
+	})
+
+	Convey("Given a scheduler and a job service that fails to build a digest", t, func() {
+		buildErr := errors.New("database error")
+		jobService := &applicationMock.JobServiceMock{
+			GetDigestFunc: func(ctx context.Context) (*domain.Digest, error) {
+				return nil, buildErr
+			},
+		}
+		slackClient := newMockSlackClient()
+		scheduler, err := NewScheduler(&config.Config{DigestTime: "09:00"}, jobService, slackClient)
+		So(err, ShouldBeNil)
+
+		Convey("When Send is called", func() {
+			sent, err := scheduler.Send(context.Background())
+
+			Convey("Then the error is returned and nothing is sent", func() {
+				So(sent, ShouldBeNil)
+				So(err, ShouldEqual, buildErr)
+				So(slackClient.SendInfoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a scheduler and a Slack client that fails to send", t, func() {
+		jobService := &applicationMock.JobServiceMock{
+			GetDigestFunc: func(ctx context.Context) (*domain.Digest, error) {
+				return newTestDigest(), nil
+			},
+		}
+		slackClient := &slackMocks.ClienterMock{
+			SendInfoFunc: func(ctx context.Context, summary string, details slack.SlackDetails, success bool) error {
+				return errors.New("slack unavailable")
+			},
+		}
+		scheduler, err := NewScheduler(&config.Config{DigestTime: "09:00"}, jobService, slackClient)
+		So(err, ShouldBeNil)
+
+		Convey("When Send is called", func() {
+			sent, err := scheduler.Send(context.Background())
+
+			Convey("Then an error is returned", func() {
+				So(sent, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestSendScheduled(t *testing.T) {
+	scheduledAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	Convey("Given a scheduler and a job service where the scheduled digest has not been claimed", t, func() {
+		jobService := &applicationMock.JobServiceMock{
+			ClaimDigestRunFunc: func(ctx context.Context, scheduledAt time.Time) (bool, error) {
+				return true, nil
+			},
+			GetDigestFunc: func(ctx context.Context) (*domain.Digest, error) {
+				return newTestDigest(), nil
+			},
+		}
+		slackClient := newMockSlackClient()
+		scheduler, err := NewScheduler(&config.Config{DigestTime: "09:00"}, jobService, slackClient)
+		So(err, ShouldBeNil)
+
+		Convey("When the scheduled digest is sent", func() {
+			scheduler.sendScheduled(context.Background(), scheduledAt)
+
+			Convey("Then the digest is claimed for its scheduled time and sent", func() {
+				So(jobService.ClaimDigestRunCalls(), ShouldHaveLength, 1)
+				So(jobService.ClaimDigestRunCalls()[0].ScheduledAt, ShouldEqual, scheduledAt)
+				So(slackClient.SendInfoCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a scheduler and a job service where the scheduled digest has been claimed by another instance", t, func() {
+		jobService := &applicationMock.JobServiceMock{
+			ClaimDigestRunFunc: func(ctx context.Context, scheduledAt time.Time) (bool, error) {
+				return false, nil
+			},
+		}
+		slackClient := newMockSlackClient()
+		scheduler, err := NewScheduler(&config.Config{DigestTime: "09:00"}, jobService, slackClient)
+		So(err, ShouldBeNil)
+
+		Convey("When the scheduled digest is sent", func() {
+			scheduler.sendScheduled(context.Background(), scheduledAt)
+
+			Convey("Then the digest is not sent again", func() {
+				So(jobService.GetDigestCalls(), ShouldBeEmpty)
+				So(slackClient.SendInfoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a scheduler and a job service that fails to claim the scheduled digest", t, func() {
+		jobService := &applicationMock.JobServiceMock{
+			ClaimDigestRunFunc: func(ctx context.Context, scheduledAt time.Time) (bool, error) {
+				return false, errors.New("store unavailable")
+			},
+		}
+		slackClient := newMockSlackClient()
+		scheduler, err := NewScheduler(&config.Config{DigestTime: "09:00"}, jobService, slackClient)
+		So(err, ShouldBeNil)
+
+		Convey("When the scheduled digest is sent", func() {
+			scheduler.sendScheduled(context.Background(), scheduledAt)
+
+			Convey("Then the digest is not sent", func() {
+				So(slackClient.SendInfoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/google/uuid"
+)
+
+// Batch represents a group of migration jobs submitted together from a
+// single manifest
+type Batch struct {
+	ID          string         `json:"id" bson:"_id"`
+	CreatedAt   time.Time      `json:"created_at" bson:"created_at"`
+	LastUpdated time.Time      `json:"last_updated" bson:"last_updated"`
+	RequestedBy *User          `json:"requested_by,omitempty" bson:"requested_by,omitempty"`
+	Status      BatchStatus    `json:"status" bson:"status"`
+	TotalRows   int            `json:"total_rows" bson:"total_rows"`
+	JobsCreated int            `json:"jobs_created" bson:"jobs_created"`
+	RowsFailed  int            `json:"rows_failed" bson:"rows_failed"`
+	Results     []BatchResult  `json:"results" bson:"results"`
+	States      []StateSummary `json:"states,omitempty" bson:"-"`
+	Links       BatchLinks     `json:"links" bson:"links"`
+}
+
+// BatchStatus represents whether the jobs of a batch have been created and
+// its results stored
+type BatchStatus string
+
+const (
+	// BatchStatusCreating indicates the batch's jobs are being created. A
+	// batch left in this status was interrupted before its results were
+	// stored, though the jobs it created are still counted in its states.
+	BatchStatusCreating BatchStatus = "creating"
+	// BatchStatusCompleted indicates every row of the batch has been
+	// processed and the results stored
+	BatchStatusCompleted BatchStatus = "completed"
+	// BatchStatusPartial indicates every row of the batch has been
+	// processed but the results could not be stored, so they are only
+	// returned to the request that created the batch
+	BatchStatusPartial BatchStatus = "partial"
+)
+
+// BatchResult represents the outcome of a single row of a batch manifest
+type BatchResult struct {
+	Row       int        `json:"row" bson:"row"`
+	Config    *JobConfig `json:"config" bson:"config"`
+	JobNumber int        `json:"job_number,omitempty" bson:"job_number,omitempty"`
+	Errors    []string   `json:"errors,omitempty" bson:"errors,omitempty"`
+}
+
+// BatchLinks contains HATEOS links for a migration batch
+type BatchLinks struct {
+	Self *LinkObject `bson:"self,omitempty" json:"self,omitempty"`
+}
+
+// NewBatch creates a new Batch instance for the given number of rows,
+// requested by the provided user
+func NewBatch(totalRows int, userID string) Batch {
+	id := uuid.New().String()
+	now := time.Now().UTC()
+
+	if userID == "" {
+		userID = SystemUserID
+	}
+
+	return Batch{
+		ID:          id,
+		CreatedAt:   now,
+		LastUpdated: now,
+		RequestedBy: &User{
+			ID: userID,
+		},
+		Status:    BatchStatusCreating,
+		TotalRows: totalRows,
+		Results:   []BatchResult{},
+		Links:     NewBatchLinks(id),
+	}
+}
+
+// NewBatchLinks creates BatchLinks for a batch with the given ID
+func NewBatchLinks(id string) BatchLinks {
+	return BatchLinks{
+		Self: &LinkObject{
+			HRef: fmt.Sprintf("/v1/migration-batches/%s", id),
+		},
+	}
+}
+
+// AddJob records that a job was created for the given row of the batch
+func (b *Batch) AddJob(row int, cfg *JobConfig, jobNumber int) {
+	b.Results = append(b.Results, BatchResult{
+		Row:       row,
+		Config:    cfg,
+		JobNumber: jobNumber,
+	})
+	b.JobsCreated++
+}
+
+// AddErrors records that the given row of the batch failed with the
+// provided errors. Errors are redacted in the same way as API errors so
+// that internal details are not exposed.
+func (b *Batch) AddErrors(row int, cfg *JobConfig, errs ...error) {
+	descriptions := make([]string, 0, len(errs))
+	for _, err := range errs {
+		descriptions = append(descriptions, appErrors.New(err).Description)
+	}
+
+	b.Results = append(b.Results, BatchResult{
+		Row:    row,
+		Config: cfg,
+		Errors: descriptions,
+	})
+	b.RowsFailed++
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/google/uuid"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewBatch(t *testing.T) {
+	Convey("Given a number of rows and a user ID", t, func() {
+		totalRows := 3
+		userID := "test-user-id"
+
+		Convey("When a batch is created", func() {
+			batch := NewBatch(totalRows, userID)
+
+			Convey("Then a valid batch should be returned", func() {
+				So(uuid.Validate(batch.ID), ShouldBeNil)
+				So(batch.TotalRows, ShouldEqual, totalRows)
+				So(batch.RequestedBy.ID, ShouldEqual, userID)
+				So(batch.Status, ShouldEqual, BatchStatusCreating)
+				So(batch.Results, ShouldBeEmpty)
+				So(batch.Links.Self.HRef, ShouldEqual, fmt.Sprintf("/v1/migration-batches/%s", batch.ID))
+				So(batch.CreatedAt, ShouldHappenOnOrBetween, time.Now().Add(-5*time.Second), time.Now())
+				So(batch.LastUpdated, ShouldEqual, batch.CreatedAt)
+			})
+		})
+
+		Convey("When a batch is created without a user ID", func() {
+			batch := NewBatch(totalRows, "")
+
+			Convey("Then the system user should be recorded as the requester", func() {
+				So(batch.RequestedBy.ID, ShouldEqual, SystemUserID)
+			})
+		})
+	})
+}
+
+func TestBatchResults(t *testing.T) {
+	Convey("Given a new batch", t, func() {
+		batch := NewBatch(2, "test-user-id")
+		jobConfig := &JobConfig{
+			SourceID: "/source-id",
+			TargetID: "target-id",
+			Type:     JobTypeStaticDataset,
+		}
+
+		Convey("When a job is added to the batch", func() {
+			batch.AddJob(1, jobConfig, 12)
+
+			Convey("Then the result should record the job number", func() {
+				So(batch.Results, ShouldHaveLength, 1)
+				So(batch.Results[0].Row, ShouldEqual, 1)
+				So(batch.Results[0].Config, ShouldEqual, jobConfig)
+				So(batch.Results[0].JobNumber, ShouldEqual, 12)
+				So(batch.Results[0].Errors, ShouldBeNil)
+				So(batch.JobsCreated, ShouldEqual, 1)
+				So(batch.RowsFailed, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When errors are added to the batch", func() {
+			batch.AddErrors(2, jobConfig, appErrors.ErrSourceIDNotProvided, errors.New("some internal failure"))
+
+			Convey("Then the result should record the redacted errors", func() {
+				So(batch.Results, ShouldHaveLength, 1)
+				So(batch.Results[0].Row, ShouldEqual, 2)
+				So(batch.Results[0].JobNumber, ShouldEqual, 0)
+				So(batch.Results[0].Errors, ShouldResemble, []string{
+					appErrors.ErrSourceIDNotProvided.Error(),
+					appErrors.ErrInternalServerError.Error(),
+				})
+				So(batch.JobsCreated, ShouldEqual, 0)
+				So(batch.RowsFailed, ShouldEqual, 1)
+			})
+		})
+	})
+}
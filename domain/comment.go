@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/google/uuid"
+)
+
+// MaxCommentLength is the maximum number of characters in a comment
+const MaxCommentLength = 5000
+
+// Comment represents a reviewer's comment on a migration job
+type Comment struct {
+	ID        string       `json:"id" bson:"_id"`
+	JobNumber int          `json:"job_number" bson:"job_number"`
+	Text      string       `json:"text" bson:"text"`
+	CreatedBy *User        `json:"created_by" bson:"created_by"`
+	CreatedAt time.Time    `json:"created_at" bson:"created_at"`
+	Links     CommentLinks `json:"links" bson:"links"`
+}
+
+// CommentLinks contains HATEOAS links for a comment
+type CommentLinks struct {
+	Self *LinkObject `bson:"self,omitempty" json:"self,omitempty"`
+	Job  *LinkObject `bson:"job,omitempty" json:"job,omitempty"`
+}
+
+// NewComment creates a new Comment on a job made by the provided user
+func NewComment(jobNumber int, text, userID string) *Comment {
+	if userID == "" {
+		userID = SystemUserID
+	}
+
+	id := uuid.New().String()
+
+	return &Comment{
+		ID:        id,
+		JobNumber: jobNumber,
+		Text:      strings.TrimSpace(text),
+		CreatedBy: &User{ID: userID},
+		CreatedAt: time.Now().UTC(),
+		Links:     NewCommentLinks(id, strconv.Itoa(jobNumber)),
+	}
+}
+
+// NewCommentLinks creates CommentLinks for a comment with the given ID and
+// job number
+func NewCommentLinks(id, jobNumber string) CommentLinks {
+	return CommentLinks{
+		Self: &LinkObject{
+			HRef: fmt.Sprintf("/v1/migration-jobs/%s/comments/%s", jobNumber, id),
+		},
+		Job: &LinkObject{
+			HRef: fmt.Sprintf("/v1/migration-jobs/%s", jobNumber),
+		},
+	}
+}
+
+// Validate checks that the comment has text within the maximum length
+func (c *Comment) Validate() []error {
+	var errs []error
+
+	if c.Text == "" {
+		errs = append(errs, appErrors.ErrCommentTextNotProvided)
+	}
+
+	if utf8.RuneCountInString(c.Text) > MaxCommentLength {
+		errs = append(errs, appErrors.ErrCommentTextTooLong)
+	}
+
+	return errs
+}
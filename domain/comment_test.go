@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/google/uuid"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewComment(t *testing.T) {
+	Convey("Given a job number, text and user", t, func() {
+		Convey("When a comment is created", func() {
+			comment := NewComment(12, "  Looks good to me  ", "test-user-id")
+
+			Convey("Then the comment is attributed to the user with links", func() {
+				So(uuid.Validate(comment.ID), ShouldBeNil)
+				So(comment.JobNumber, ShouldEqual, 12)
+				So(comment.Text, ShouldEqual, "Looks good to me")
+				So(comment.CreatedBy.ID, ShouldEqual, "test-user-id")
+				So(comment.CreatedAt.IsZero(), ShouldBeFalse)
+				So(comment.Links.Self.HRef, ShouldEqual, "/v1/migration-jobs/12/comments/"+comment.ID)
+				So(comment.Links.Job.HRef, ShouldEqual, "/v1/migration-jobs/12")
+			})
+		})
+
+		Convey("When a comment is created without a user", func() {
+			comment := NewComment(12, "text", "")
+
+			Convey("Then it is attributed to the system user", func() {
+				So(comment.CreatedBy.ID, ShouldEqual, SystemUserID)
+			})
+		})
+	})
+}
+
+func TestCommentValidate(t *testing.T) {
+	Convey("Given a comment with text", t, func() {
+		comment := NewComment(1, "text", "test-user-id")
+
+		Convey("Then it is valid", func() {
+			So(comment.Validate(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a comment with only whitespace", t, func() {
+		comment := NewComment(1, "   ", "test-user-id")
+
+		Convey("Then text not provided is returned", func() {
+			So(comment.Validate(), ShouldResemble, []error{appErrors.ErrCommentTextNotProvided})
+		})
+	})
+
+	Convey("Given a comment longer than the maximum length", t, func() {
+		comment := NewComment(1, strings.Repeat("a", MaxCommentLength+1), "test-user-id")
+
+		Convey("Then text too long is returned", func() {
+			So(comment.Validate(), ShouldResemble, []error{appErrors.ErrCommentTextTooLong})
+		})
+	})
+}
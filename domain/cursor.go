@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+)
+
+// Cursor represents a position in a list of items sorted by stable keys. It
+// holds the sort value and ID of the last item of a page, so that the next
+// page starts after it regardless of any changes made to earlier items.
+type Cursor struct {
+	Value string `json:"v,omitempty"`
+	ID    string `json:"id,omitempty"`
+}
+
+// Encode returns the cursor as an opaque, URL safe string
+func (c *Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// IsStart returns true if the cursor is at the start of the list
+func (c *Cursor) IsStart() bool {
+	return c.ID == ""
+}
+
+// DecodeCursor decodes a cursor previously returned by Encode. An empty
+// string decodes to a cursor at the start of the list.
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return &Cursor{}, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, appErrors.ErrCursorInvalid
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(b, &cursor); err != nil || cursor.ID == "" {
+		return nil, appErrors.ErrCursorInvalid
+	}
+
+	return &cursor, nil
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCursor(t *testing.T) {
+	Convey("Given a cursor", t, func() {
+		cursor := &Cursor{Value: "2025-01-01T00:00:00Z", ID: "test-id"}
+
+		Convey("When it is encoded and decoded", func() {
+			decoded, err := DecodeCursor(cursor.Encode())
+
+			Convey("Then the original cursor is returned", func() {
+				So(err, ShouldBeNil)
+				So(decoded, ShouldResemble, cursor)
+				So(decoded.IsStart(), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given an empty cursor string", t, func() {
+		Convey("When it is decoded", func() {
+			decoded, err := DecodeCursor("")
+
+			Convey("Then a cursor at the start of the list is returned", func() {
+				So(err, ShouldBeNil)
+				So(decoded.IsStart(), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given an invalid cursor string", t, func() {
+		Convey("When it is decoded", func() {
+			decoded, err := DecodeCursor("not-a-cursor!")
+
+			Convey("Then an invalid cursor error is returned", func() {
+				So(decoded, ShouldBeNil)
+				So(err, ShouldEqual, appErrors.ErrCursorInvalid)
+			})
+		})
+	})
+}
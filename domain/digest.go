@@ -0,0 +1,100 @@
+package domain
+
+import "time"
+
+// Digest summarises recent migration activity along with the jobs and
+// tasks that need attention.
+type Digest struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	PeriodStart time.Time `json:"period_start"`
+	// JobsCreated, JobsCompleted and JobsFailed count the jobs created,
+	// completed and failed since the start of the period
+	JobsCreated      int            `json:"jobs_created"`
+	JobsCompleted    int            `json:"jobs_completed"`
+	JobsFailed       int            `json:"jobs_failed"`
+	JobStates        []StateSummary `json:"job_states"`
+	AwaitingApproval DigestJobs     `json:"awaiting_approval"`
+	StuckTasks       DigestTasks    `json:"stuck_tasks"`
+}
+
+// DigestJobs lists jobs in a digest. Count is the total number of matching
+// jobs, which may be more than are listed.
+type DigestJobs struct {
+	Count int          `json:"count"`
+	Jobs  []*DigestJob `json:"jobs"`
+}
+
+// DigestJob represents a job waiting in its current state
+type DigestJob struct {
+	JobNumber    int       `json:"job_number"`
+	Label        string    `json:"label"`
+	State        State     `json:"state"`
+	WaitingSince time.Time `json:"waiting_since"`
+	WaitingFor   string    `json:"waiting_for"`
+}
+
+// DigestTasks lists tasks in a digest. Count is the total number of
+// matching tasks, which may be more than are listed.
+type DigestTasks struct {
+	Count int           `json:"count"`
+	Tasks []*DigestTask `json:"tasks"`
+}
+
+// DigestTask represents a task waiting in its current state
+type DigestTask struct {
+	ID           string    `json:"id"`
+	JobNumber    int       `json:"job_number"`
+	Type         TaskType  `json:"type"`
+	State        State     `json:"state"`
+	WaitingSince time.Time `json:"waiting_since"`
+	WaitingFor   string    `json:"waiting_for"`
+}
+
+// NewDigestJob creates a DigestJob for a job, measuring how long it has
+// waited in its current state up to the time provided
+func NewDigestJob(job *Job, now time.Time) *DigestJob {
+	return &DigestJob{
+		JobNumber:    job.JobNumber,
+		Label:        job.Label,
+		State:        job.State,
+		WaitingSince: job.LastUpdated,
+		WaitingFor:   waitingFor(job.LastUpdated, now),
+	}
+}
+
+// NewDigestTask creates a DigestTask for a task, measuring how long it has
+// waited in its current state up to the time provided
+func NewDigestTask(task *Task, now time.Time) *DigestTask {
+	return &DigestTask{
+		ID:           task.ID,
+		JobNumber:    task.JobNumber,
+		Type:         task.Type,
+		State:        task.State,
+		WaitingSince: task.LastUpdated,
+		WaitingFor:   waitingFor(task.LastUpdated, now),
+	}
+}
+
+// waitingFor formats the time between since and now to the minute
+func waitingFor(since, now time.Time) string {
+	return now.Sub(since).Truncate(time.Minute).String()
+}
+
+// digestRunDateLayout is the layout of the date a digest run is keyed by
+const digestRunDateLayout = "2006-01-02"
+
+// DigestRun records that a scheduled digest has been claimed for sending, so
+// that only one instance of the service sends each day's digest
+type DigestRun struct {
+	Date      string    `json:"date" bson:"_id"`
+	ClaimedAt time.Time `json:"claimed_at" bson:"claimed_at"`
+}
+
+// NewDigestRun creates a DigestRun for the digest scheduled at the given
+// time, keyed by its date in UTC
+func NewDigestRun(scheduledAt time.Time) DigestRun {
+	return DigestRun{
+		Date:      scheduledAt.UTC().Format(digestRunDateLayout),
+		ClaimedAt: time.Now().UTC(),
+	}
+}
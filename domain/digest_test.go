@@ -0,0 +1,73 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewDigestJob(t *testing.T) {
+	Convey("Given a job that moved into review 26 and a half hours ago", t, func() {
+		now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+		job := &domain.Job{
+			JobNumber:   12,
+			Label:       "Labour market",
+			State:       domain.StateInReview,
+			LastUpdated: now.Add(-26*time.Hour - 30*time.Minute - 15*time.Second),
+		}
+
+		Convey("When NewDigestJob is called", func() {
+			digestJob := domain.NewDigestJob(job, now)
+
+			Convey("Then the job is described with how long it has waited, to the minute", func() {
+				So(digestJob.JobNumber, ShouldEqual, 12)
+				So(digestJob.Label, ShouldEqual, "Labour market")
+				So(digestJob.State, ShouldEqual, domain.StateInReview)
+				So(digestJob.WaitingSince, ShouldEqual, job.LastUpdated)
+				So(digestJob.WaitingFor, ShouldEqual, "26h30m0s")
+			})
+		})
+	})
+}
+
+func TestNewDigestTask(t *testing.T) {
+	Convey("Given a task that started migrating 90 minutes ago", t, func() {
+		now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+		task := &domain.Task{
+			ID:          "task-id",
+			JobNumber:   7,
+			Type:        domain.TaskTypeDatasetVersion,
+			State:       domain.StateMigrating,
+			LastUpdated: now.Add(-90 * time.Minute),
+		}
+
+		Convey("When NewDigestTask is called", func() {
+			digestTask := domain.NewDigestTask(task, now)
+
+			Convey("Then the task is described with how long it has waited", func() {
+				So(digestTask.ID, ShouldEqual, "task-id")
+				So(digestTask.JobNumber, ShouldEqual, 7)
+				So(digestTask.Type, ShouldEqual, domain.TaskTypeDatasetVersion)
+				So(digestTask.State, ShouldEqual, domain.StateMigrating)
+				So(digestTask.WaitingFor, ShouldEqual, "1h30m0s")
+			})
+		})
+	})
+}
+
+func TestNewDigestRun(t *testing.T) {
+	Convey("Given a digest scheduled shortly after midnight in a UTC+1 timezone", t, func() {
+		scheduledAt := time.Date(2026, 10, 19, 0, 30, 0, 0, time.FixedZone("BST", 60*60))
+
+		Convey("When NewDigestRun is called", func() {
+			run := domain.NewDigestRun(scheduledAt)
+
+			Convey("Then the run is keyed by the scheduled date in UTC", func() {
+				So(run.Date, ShouldEqual, "2026-10-18")
+				So(run.ClaimedAt, ShouldHappenOnOrBetween, time.Now().Add(-5*time.Second), time.Now())
+			})
+		})
+	})
+}
@@ -2,10 +2,12 @@ package domain
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 const (
@@ -15,35 +17,181 @@ const (
 	SystemUserID = "system"
 )
 
-// Event represents a migration job event (state change)
+// EventAction represents the kind of thing an event records
+type EventAction string
+
+// Event actions
+const (
+	EventActionJobCreated       EventAction = "job_created"
+	EventActionStateChanged     EventAction = "state_changed"
+	EventActionTaskStateChanged EventAction = "task_state_changed"
+	EventActionTaskFailed       EventAction = "task_failed"
+	EventActionRetryRequested   EventAction = "retry_requested"
+	EventActionCommentAdded     EventAction = "comment_added"
+	EventActionApprovalAdded    EventAction = "approval_added"
+	EventActionScheduleChanged  EventAction = "schedule_changed"
+	EventActionPriorityChanged  EventAction = "priority_changed"
+)
+
+var validEventActions = map[EventAction]bool{
+	EventActionJobCreated:       true,
+	EventActionStateChanged:     true,
+	EventActionTaskStateChanged: true,
+	EventActionTaskFailed:       true,
+	EventActionRetryRequested:   true,
+	EventActionCommentAdded:     true,
+	EventActionApprovalAdded:    true,
+	EventActionScheduleChanged:  true,
+	EventActionPriorityChanged:  true,
+}
+
+// IsValidEventAction checks if the provided action is a known event action
+func IsValidEventAction(action EventAction) bool {
+	return validEventActions[action]
+}
+
+// GetEventActions returns every known event action in alphabetical order
+func GetEventActions() []EventAction {
+	actions := make([]EventAction, 0, len(validEventActions))
+	for action := range validEventActions {
+		actions = append(actions, action)
+	}
+	slices.Sort(actions)
+	return actions
+}
+
+// Event represents something that happened to a migration job or one of
+// its tasks
 type Event struct {
-	ID          string     `json:"id" bson:"_id"`
-	CreatedAt   string     `json:"created_at" bson:"created_at"`
-	RequestedBy *User      `json:"requested_by" bson:"requested_by"`
-	Action      string     `json:"action" bson:"action"`
-	JobNumber   int        `json:"job_number" bson:"job_number"`
-	Links       EventLinks `json:"links" bson:"links"`
+	ID          string      `json:"id" bson:"_id"`
+	CreatedAt   time.Time   `json:"created_at" bson:"created_at"`
+	RequestedBy *User       `json:"requested_by" bson:"requested_by"`
+	Action      EventAction `json:"action" bson:"action"`
+	JobNumber   int         `json:"job_number" bson:"job_number"`
+	Links       EventLinks  `json:"links" bson:"links"`
+	// Payload holds any additional action specific detail
+	Payload map[string]interface{} `json:"payload,omitempty" bson:"payload,omitempty"`
+	// TaskID is set when the event relates to a task rather than the job
+	TaskID    string `json:"task_id,omitempty" bson:"task_id,omitempty"`
+	FromState State  `json:"from_state,omitempty" bson:"from_state,omitempty"`
+	ToState   State  `json:"to_state,omitempty" bson:"to_state,omitempty"`
+	Reason    string `json:"reason,omitempty" bson:"reason,omitempty"`
+}
+
+// eventDocument has the fields of Event without its methods, so that it
+// can be decoded without calling UnmarshalBSON again
+type eventDocument Event
+
+// UnmarshalBSON decodes an event. Events recorded before event actions and
+// timestamps were typed have their created_at stored as an RFC3339 string
+// and the state the job moved to as their action, so these are converted
+// to the current format.
+func (e *Event) UnmarshalBSON(data []byte) error {
+	if bson.Raw(data).Lookup("created_at").Type == bson.TypeString {
+		var err error
+		data, err = upgradeLegacyCreatedAt(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := bson.Unmarshal(data, (*eventDocument)(e)); err != nil {
+		return err
+	}
+
+	if !IsValidEventAction(e.Action) && e.ToState == "" {
+		e.Action, e.ToState = LegacyEventAction(string(e.Action))
+	}
+	return nil
+}
+
+// upgradeLegacyCreatedAt returns the event document with its RFC3339
+// created_at string replaced by a date
+func upgradeLegacyCreatedAt(data []byte) ([]byte, error) {
+	var doc bson.D
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	for i := range doc {
+		if createdAt, ok := doc[i].Value.(string); ok && doc[i].Key == "created_at" {
+			parsed, err := time.Parse(time.RFC3339, createdAt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid event created_at %q: %w", createdAt, err)
+			}
+			doc[i].Value = parsed
+		}
+	}
+
+	return bson.Marshal(doc)
+}
+
+// LegacyEventAction returns the event action and the state moved to for an
+// event recorded before event actions were typed, whose action was the
+// state the job moved to. Jobs were only ever created as submitted, as no
+// other transition leads to that state.
+func LegacyEventAction(action string) (EventAction, State) {
+	toState := State(action)
+	if toState == StateSubmitted {
+		return EventActionJobCreated, toState
+	}
+	return EventActionStateChanged, toState
 }
 
 // NewEvent creates a new Event with the provided parameters
-func NewEvent(jobNumber int, action, userID string) *Event {
+func NewEvent(jobNumber int, action EventAction, userID string) *Event {
 	// Use SystemUserID as default if no user ID provided
 	if userID == "" {
 		userID = SystemUserID
 	}
 
+	id := uuid.New().String()
+
 	return &Event{
-		ID:        uuid.New().String(),
-		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		ID:        id,
+		CreatedAt: time.Now().UTC(),
 		RequestedBy: &User{
 			ID: userID,
 		},
 		Action:    action,
 		JobNumber: jobNumber,
-		Links:     NewEventLinks(uuid.New().String(), strconv.Itoa(jobNumber)),
+		Links:     NewEventLinks(id, strconv.Itoa(jobNumber)),
 	}
 }
 
+// NewStateChangeEvent creates a new Event recording a job moving between
+// states. An empty from state indicates the job has just been created.
+func NewStateChangeEvent(jobNumber int, fromState, toState State, userID, reason string) *Event {
+	action := EventActionStateChanged
+	if fromState == "" {
+		action = EventActionJobCreated
+	}
+
+	event := NewEvent(jobNumber, action, userID)
+	event.FromState = fromState
+	event.ToState = toState
+	event.Reason = reason
+
+	return event
+}
+
+// NewTaskStateChangeEvent creates a new Event recording a task moving
+// between states. Task state changes are always made by the system.
+func NewTaskStateChangeEvent(task *Task, fromState, toState State, reason string) *Event {
+	action := EventActionTaskStateChanged
+	if IsFailedState(toState) {
+		action = EventActionTaskFailed
+	}
+
+	event := NewEvent(task.JobNumber, action, SystemUserID)
+	event.TaskID = task.ID
+	event.FromState = fromState
+	event.ToState = toState
+	event.Reason = reason
+
+	return event
+}
+
 // User represents a user who initiated an action
 type User struct {
 	ID    string `json:"id" bson:"id"`
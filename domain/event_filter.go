@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// EventFilter represents the criteria used to filter a list of migration
+// events. Empty fields are not filtered on.
+type EventFilter struct {
+	Actions       []EventAction
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
@@ -1,16 +1,19 @@
 package domain_test
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/ONSdigital/dis-migration-service/domain"
 	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func TestNewEvent(t *testing.T) {
 	Convey("Given domain event creation", t, func() {
 		jobNumber := 123
-		action := "approved"
+		action := domain.EventActionStateChanged
 		userID := "user-456"
 
 		Convey("When NewEvent is called with valid parameters", func() {
@@ -22,9 +25,13 @@ func TestNewEvent(t *testing.T) {
 				So(event.Action, ShouldEqual, action)
 				So(event.RequestedBy.ID, ShouldEqual, userID)
 				So(event.ID, ShouldNotBeEmpty)
-				So(event.CreatedAt, ShouldNotBeEmpty)
+				So(event.CreatedAt.IsZero(), ShouldBeFalse)
 				So(event.Links, ShouldNotBeNil)
 			})
+
+			Convey("And the self link should point at the event", func() {
+				So(event.Links.Self.HRef, ShouldEqual, fmt.Sprintf("/v1/migration-jobs/%d/events/%s", jobNumber, event.ID))
+			})
 		})
 
 		Convey("When NewEvent is called with empty user ID", func() {
@@ -37,6 +44,77 @@ func TestNewEvent(t *testing.T) {
 	})
 }
 
+func TestNewStateChangeEvent(t *testing.T) {
+	Convey("Given a job moving from in review to rejected", t, func() {
+		Convey("When NewStateChangeEvent is called", func() {
+			event := domain.NewStateChangeEvent(123, domain.StateInReview, domain.StateRejected, "user-456", "wrong dataset")
+
+			Convey("Then the event records the transition, actor and reason", func() {
+				So(event.JobNumber, ShouldEqual, 123)
+				So(event.Action, ShouldEqual, domain.EventActionStateChanged)
+				So(event.FromState, ShouldEqual, domain.StateInReview)
+				So(event.ToState, ShouldEqual, domain.StateRejected)
+				So(event.RequestedBy.ID, ShouldEqual, "user-456")
+				So(event.Reason, ShouldEqual, "wrong dataset")
+				So(event.TaskID, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a job that has just been created", t, func() {
+		Convey("When NewStateChangeEvent is called without a from state", func() {
+			event := domain.NewStateChangeEvent(123, "", domain.StateSubmitted, "user-456", "")
+
+			Convey("Then the event is recorded as a job creation", func() {
+				So(event.Action, ShouldEqual, domain.EventActionJobCreated)
+				So(event.ToState, ShouldEqual, domain.StateSubmitted)
+			})
+		})
+	})
+}
+
+func TestNewTaskStateChangeEvent(t *testing.T) {
+	Convey("Given a task that failed migration", t, func() {
+		task := &domain.Task{ID: "task-1", JobNumber: 123}
+
+		Convey("When NewTaskStateChangeEvent is called", func() {
+			event := domain.NewTaskStateChangeEvent(task, domain.StateMigrating, domain.StateFailedMigration, "timeout")
+
+			Convey("Then the event records the task, transition and reason against the system user", func() {
+				So(event.JobNumber, ShouldEqual, 123)
+				So(event.TaskID, ShouldEqual, "task-1")
+				So(event.FromState, ShouldEqual, domain.StateMigrating)
+				So(event.ToState, ShouldEqual, domain.StateFailedMigration)
+				So(event.RequestedBy.ID, ShouldEqual, domain.SystemUserID)
+				So(event.Reason, ShouldEqual, "timeout")
+				So(event.Action, ShouldEqual, domain.EventActionTaskFailed)
+			})
+		})
+
+		Convey("When NewTaskStateChangeEvent is called for a successful transition", func() {
+			event := domain.NewTaskStateChangeEvent(task, domain.StateMigrating, domain.StateInReview, "")
+
+			Convey("Then the event is recorded as a task state change", func() {
+				So(event.Action, ShouldEqual, domain.EventActionTaskStateChanged)
+			})
+		})
+	})
+}
+
+func TestIsValidEventAction(t *testing.T) {
+	Convey("Given event actions", t, func() {
+		Convey("Then known actions are valid", func() {
+			So(domain.IsValidEventAction(domain.EventActionJobCreated), ShouldBeTrue)
+			So(domain.IsValidEventAction(domain.EventActionCommentAdded), ShouldBeTrue)
+		})
+
+		Convey("Then unknown actions are not valid", func() {
+			So(domain.IsValidEventAction("approved"), ShouldBeFalse)
+			So(domain.IsValidEventAction(""), ShouldBeFalse)
+		})
+	})
+}
+
 func TestNewEventLinks(t *testing.T) {
 	Convey("Given an event ID and job ID", t, func() {
 		id := "event-456"
@@ -59,3 +137,84 @@ func TestNewEventLinks(t *testing.T) {
 		})
 	})
 }
+
+func TestEventUnmarshalBSON(t *testing.T) {
+	Convey("Given an event in the current format", t, func() {
+		event := domain.NewStateChangeEvent(123, domain.StateInReview, domain.StateApproved, "user-456", "looks good")
+		data, err := bson.Marshal(event)
+		So(err, ShouldBeNil)
+
+		Convey("When it is decoded", func() {
+			var decoded domain.Event
+			err := bson.Unmarshal(data, &decoded)
+
+			Convey("Then it is unchanged", func() {
+				So(err, ShouldBeNil)
+				So(decoded.Action, ShouldEqual, domain.EventActionStateChanged)
+				So(decoded.FromState, ShouldEqual, domain.StateInReview)
+				So(decoded.ToState, ShouldEqual, domain.StateApproved)
+				So(decoded.CreatedAt.Equal(event.CreatedAt.Truncate(time.Millisecond)), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given an event recorded before actions and timestamps were typed", t, func() {
+		data, err := bson.Marshal(bson.M{
+			"_id":          "event-1",
+			"created_at":   "2024-01-02T03:04:05Z",
+			"requested_by": bson.M{"id": "user-456"},
+			"action":       "approved",
+			"job_number":   123,
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When it is decoded", func() {
+			var decoded domain.Event
+			err := bson.Unmarshal(data, &decoded)
+
+			Convey("Then its timestamp is parsed and its action is mapped to a state change", func() {
+				So(err, ShouldBeNil)
+				So(decoded.ID, ShouldEqual, "event-1")
+				So(decoded.CreatedAt.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), ShouldBeTrue)
+				So(decoded.Action, ShouldEqual, domain.EventActionStateChanged)
+				So(decoded.ToState, ShouldEqual, domain.StateApproved)
+				So(decoded.RequestedBy.ID, ShouldEqual, "user-456")
+			})
+		})
+	})
+
+	Convey("Given a legacy event recording a job being submitted", t, func() {
+		data, err := bson.Marshal(bson.M{
+			"_id":        "event-1",
+			"created_at": "2024-01-02T03:04:05Z",
+			"action":     "submitted",
+			"job_number": 123,
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When it is decoded", func() {
+			var decoded domain.Event
+			err := bson.Unmarshal(data, &decoded)
+
+			Convey("Then it is mapped to the job being created", func() {
+				So(err, ShouldBeNil)
+				So(decoded.Action, ShouldEqual, domain.EventActionJobCreated)
+				So(decoded.ToState, ShouldEqual, domain.StateSubmitted)
+			})
+		})
+	})
+
+	Convey("Given a legacy event with an invalid timestamp", t, func() {
+		data, err := bson.Marshal(bson.M{"_id": "event-1", "created_at": "yesterday", "action": "approved"})
+		So(err, ShouldBeNil)
+
+		Convey("When it is decoded", func() {
+			var decoded domain.Event
+			err := bson.Unmarshal(data, &decoded)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// IdempotencyRecord represents a job creation request made with an
+// Idempotency-Key, and the job it resulted in. Keys are scoped to the user
+// that made the request.
+type IdempotencyRecord struct {
+	ID          string     `json:"id" bson:"_id"`
+	Key         string     `json:"key" bson:"key"`
+	UserID      string     `json:"user_id" bson:"user_id"`
+	RequestHash string     `json:"request_hash" bson:"request_hash"`
+	Job         *Job       `json:"job,omitempty" bson:"job,omitempty"`
+	CreatedAt   time.Time  `json:"created_at" bson:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	// PendingExpiresAt is when the record expires if its job has not been
+	// created, so that a key reserved by a request that failed part way
+	// through can be used again. It is removed once the job is created.
+	PendingExpiresAt *time.Time `json:"pending_expires_at,omitempty" bson:"pending_expires_at,omitempty"`
+}
+
+// NewIdempotencyRecord creates a new IdempotencyRecord for the given user,
+// key and request hash that expires after the given duration, or after the
+// pending expiry if its job has not been created by then. A non-positive
+// expiry means the record never expires. The job is set once it has been
+// created.
+func NewIdempotencyRecord(userID, key, requestHash string, expiry, pendingExpiry time.Duration) IdempotencyRecord {
+	record := IdempotencyRecord{
+		ID:          IdempotencyRecordID(userID, key),
+		Key:         key,
+		UserID:      userID,
+		RequestHash: requestHash,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if expiry > 0 {
+		expiresAt := record.CreatedAt.Add(expiry)
+		record.ExpiresAt = &expiresAt
+	}
+
+	if pendingExpiry > 0 {
+		pendingExpiresAt := record.CreatedAt.Add(pendingExpiry)
+		record.PendingExpiresAt = &pendingExpiresAt
+	}
+
+	return record
+}
+
+// IdempotencyRecordID returns the ID of the idempotency record for a key
+// sent by the given user, so that users cannot see or collide with each
+// other's keys
+func IdempotencyRecordID(userID, key string) string {
+	b, _ := json.Marshal([]string{userID, key})
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// IsPending returns true if the job for the record has not yet been created
+func (r *IdempotencyRecord) IsPending() bool {
+	return r.Job == nil
+}
+
+// IsExpired returns true if the record's expiry time has passed, or if it
+// is still pending and its pending expiry time has passed. Expired records
+// are removed by the store, but may be seen before they are.
+func (r *IdempotencyRecord) IsExpired() bool {
+	now := time.Now()
+	if r.ExpiresAt != nil && now.After(*r.ExpiresAt) {
+		return true
+	}
+	return r.IsPending() && r.PendingExpiresAt != nil && now.After(*r.PendingExpiresAt)
+}
+
+// HashJobConfig returns a hash of the requested fields of a job config, used
+// to detect an Idempotency-Key being reused with a different request
+func HashJobConfig(jc *JobConfig) (string, error) {
+	b, err := json.Marshal(jc)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIdempotencyRecord(t *testing.T) {
+	Convey("Given a new idempotency record", t, func() {
+		record := NewIdempotencyRecord("test-user", "test-key", "test-hash", time.Hour, time.Minute)
+
+		Convey("Then it is pending and not expired", func() {
+			So(record.ID, ShouldEqual, IdempotencyRecordID("test-user", "test-key"))
+			So(record.Key, ShouldEqual, "test-key")
+			So(record.UserID, ShouldEqual, "test-user")
+			So(record.RequestHash, ShouldEqual, "test-hash")
+			So(*record.ExpiresAt, ShouldEqual, record.CreatedAt.Add(time.Hour))
+			So(*record.PendingExpiresAt, ShouldEqual, record.CreatedAt.Add(time.Minute))
+			So(record.IsPending(), ShouldBeTrue)
+			So(record.IsExpired(), ShouldBeFalse)
+		})
+
+		Convey("When a job is set", func() {
+			record.Job = &Job{JobNumber: 12}
+
+			Convey("Then it is no longer pending", func() {
+				So(record.IsPending(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When its pending expiry time has passed before a job is set", func() {
+			pendingExpiresAt := time.Now().UTC().Add(-time.Second)
+			record.PendingExpiresAt = &pendingExpiresAt
+
+			Convey("Then it is expired", func() {
+				So(record.IsExpired(), ShouldBeTrue)
+			})
+
+			Convey("Then once a job is set it is no longer expired", func() {
+				record.Job = &Job{JobNumber: 12}
+				So(record.IsExpired(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When its expiry time has passed", func() {
+			expiresAt := time.Now().UTC().Add(-time.Minute)
+			record.ExpiresAt = &expiresAt
+
+			Convey("Then it is expired", func() {
+				So(record.IsExpired(), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a new idempotency record with no expiry", t, func() {
+		record := NewIdempotencyRecord("test-user", "test-key", "test-hash", 0, 0)
+
+		Convey("Then it never expires", func() {
+			So(record.ExpiresAt, ShouldBeNil)
+			So(record.PendingExpiresAt, ShouldBeNil)
+			So(record.IsExpired(), ShouldBeFalse)
+		})
+	})
+}
+
+func TestIdempotencyRecordID(t *testing.T) {
+	Convey("Given the same idempotency key sent by two users", t, func() {
+		Convey("Then their record IDs differ", func() {
+			So(IdempotencyRecordID("user-a", "test-key"), ShouldNotEqual, IdempotencyRecordID("user-b", "test-key"))
+		})
+
+		Convey("Then a user's record ID is stable", func() {
+			So(IdempotencyRecordID("user-a", "test-key"), ShouldEqual, IdempotencyRecordID("user-a", "test-key"))
+		})
+
+		Convey("Then the user and key cannot be confused by where they are split", func() {
+			So(IdempotencyRecordID("user:a", "key"), ShouldNotEqual, IdempotencyRecordID("user", "a:key"))
+		})
+	})
+}
+
+func TestHashJobConfig(t *testing.T) {
+	Convey("Given two identical job configs", t, func() {
+		a := &JobConfig{SourceID: "/source-id", TargetID: "target-id", Type: JobTypeStaticDataset}
+		b := &JobConfig{SourceID: "/source-id", TargetID: "target-id", Type: JobTypeStaticDataset}
+
+		Convey("Then their hashes are equal", func() {
+			hashA, err := HashJobConfig(a)
+			So(err, ShouldBeNil)
+			hashB, err := HashJobConfig(b)
+			So(err, ShouldBeNil)
+
+			So(hashA, ShouldEqual, hashB)
+		})
+
+		Convey("When one config is changed", func() {
+			b.TargetID = "other-target-id"
+
+			Convey("Then their hashes differ", func() {
+				hashA, err := HashJobConfig(a)
+				So(err, ShouldBeNil)
+				hashB, err := HashJobConfig(b)
+				So(err, ShouldBeNil)
+
+				So(hashA, ShouldNotEqual, hashB)
+			})
+		})
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"time"
 
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
 	"github.com/google/uuid"
 )
 
@@ -16,7 +17,81 @@ type Job struct {
 	LastUpdated time.Time  `json:"last_updated" bson:"last_updated"`
 	State       State      `json:"state" bson:"state"`
 	Config      *JobConfig `json:"config" bson:"config"`
+	Priority    int        `json:"priority,omitempty" bson:"priority"`
+	BatchID     string     `json:"batch_id,omitempty" bson:"batch_id,omitempty"`
 	Links       JobLinks   `json:"links" bson:"links"`
+	// PublishAt is when an approved job is scheduled to be published. Jobs
+	// without a schedule are published as soon as they are approved.
+	PublishAt *time.Time `json:"publish_at,omitempty" bson:"publish_at,omitempty"`
+	// ReleaseGroupID is the release group the job is published with, if
+	// any. Jobs in a release group are only claimed from the states in
+	// ReleasedStates, which are added once every member has reached them.
+	ReleaseGroupID string  `json:"release_group_id,omitempty" bson:"release_group_id,omitempty"`
+	ReleasedStates []State `json:"-" bson:"released_states,omitempty"`
+	// CreatedBy is stored for filtering but not returned in responses, as
+	// the submitting user is already available from the job's events.
+	CreatedBy *User `json:"-" bson:"created_by,omitempty"`
+	// Approvals are collected while the job is in review when more than
+	// one approval is required before it can be published.
+	Approvals []*Approval `json:"approvals,omitempty" bson:"approvals,omitempty"`
+	// NotificationThread is the parent message that notifications about
+	// the job are sent as replies to. It is internal to the service so is
+	// not returned in responses.
+	NotificationThread *NotificationThread `json:"-" bson:"notification_thread,omitempty"`
+}
+
+const (
+	// DefaultJobPriority is the priority of a job that has not had its
+	// priority changed
+	DefaultJobPriority = 0
+	// MaxJobPriority is the highest priority a job can have. Tasks of jobs
+	// with a higher priority are claimed first.
+	MaxJobPriority = 10
+)
+
+// ValidateJobPriority checks that a job priority is within the allowed
+// range
+func ValidateJobPriority(priority int) error {
+	if priority < DefaultJobPriority || priority > MaxJobPriority {
+		return appErrors.ErrJobPriorityInvalid
+	}
+	return nil
+}
+
+// Approval records a user approving a job in review
+type Approval struct {
+	User       *User     `json:"user" bson:"user"`
+	ApprovedAt time.Time `json:"approved_at" bson:"approved_at"`
+}
+
+// NewApproval creates a new Approval by the provided user
+func NewApproval(userID string) *Approval {
+	if userID == "" {
+		userID = SystemUserID
+	}
+
+	return &Approval{
+		User:       &User{ID: userID},
+		ApprovedAt: time.Now().UTC(),
+	}
+}
+
+// IsSchedulable checks if the publishing of the job can still be scheduled,
+// rescheduled or have its schedule cancelled
+func (j *Job) IsSchedulable() bool {
+	return j.State == StateInReview || j.State == StateApproved
+}
+
+// IsSubmittedBy checks if the job was submitted by the provided user
+func (j *Job) IsSubmittedBy(userID string) bool {
+	return j.CreatedBy != nil && j.CreatedBy.ID == userID
+}
+
+// NotificationThread identifies the parent message of a thread of
+// notifications, such as a Slack message
+type NotificationThread struct {
+	Channel   string `bson:"channel"`
+	Timestamp string `bson:"timestamp"`
 }
 
 // JobLinks contains HATEOS links for a migration job
@@ -58,6 +133,17 @@ func NewJobLinks(jobNumber string) JobLinks {
 	}
 }
 
+// SetCreatedBy records the user who submitted the job, defaulting to the
+// system user if no user ID is provided
+func (j *Job) SetCreatedBy(userID string) {
+	if userID == "" {
+		userID = SystemUserID
+	}
+	j.CreatedBy = &User{
+		ID: userID,
+	}
+}
+
 // SetJobNumber updates the Job, including its JobLinks, with the new job
 // number provided
 // @newJobNumber the new number for the job
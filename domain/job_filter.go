@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// JobFilter represents the criteria used to filter a list of migration
+// jobs. Empty fields are not filtered on.
+type JobFilter struct {
+	States        []State
+	Type          JobType
+	SourceID      string
+	TargetID      string
+	Label         string
+	CreatedBy     string
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// JobStateOptions holds the optional parts of a request to change the state
+// of a migration job.
+type JobStateOptions struct {
+	// PublishAt schedules a job being approved to publish at the given time
+	PublishAt *time.Time
+
+	// FourEyesOverride allows the requesting user to approve a job they
+	// submitted. It must only be set once the user is known to hold the
+	// four eyes override permission.
+	FourEyesOverride bool
+}
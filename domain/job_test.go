@@ -50,3 +50,67 @@ func TestNewJobLinks(t *testing.T) {
 		})
 	})
 }
+
+func TestSetCreatedBy(t *testing.T) {
+	Convey("Given a new job", t, func() {
+		job := NewJob(&JobConfig{}, 1, "test label")
+
+		Convey("When the creating user is set", func() {
+			job.SetCreatedBy("test-user")
+
+			Convey("Then the job records the user", func() {
+				So(job.CreatedBy, ShouldNotBeNil)
+				So(job.CreatedBy.ID, ShouldEqual, "test-user")
+			})
+		})
+
+		Convey("When the creating user is set without a user ID", func() {
+			job.SetCreatedBy("")
+
+			Convey("Then the job records the system user", func() {
+				So(job.CreatedBy, ShouldNotBeNil)
+				So(job.CreatedBy.ID, ShouldEqual, SystemUserID)
+			})
+		})
+	})
+}
+
+func TestIsSubmittedBy(t *testing.T) {
+	Convey("Given a job submitted by a user", t, func() {
+		job := NewJob(&JobConfig{}, 1, "test label")
+		job.SetCreatedBy("test-user")
+
+		Convey("Then it is submitted by that user only", func() {
+			So(job.IsSubmittedBy("test-user"), ShouldBeTrue)
+			So(job.IsSubmittedBy("another-user"), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a job without a recorded submitter", t, func() {
+		job := NewJob(&JobConfig{}, 1, "test label")
+
+		Convey("Then it is not submitted by any user", func() {
+			So(job.IsSubmittedBy("test-user"), ShouldBeFalse)
+			So(job.IsSubmittedBy(""), ShouldBeFalse)
+		})
+	})
+}
+
+func TestNewApproval(t *testing.T) {
+	Convey("When an approval is created for a user", t, func() {
+		approval := NewApproval("test-user")
+
+		Convey("Then the approval records the user and time", func() {
+			So(approval.User.ID, ShouldEqual, "test-user")
+			So(approval.ApprovedAt.IsZero(), ShouldBeFalse)
+		})
+	})
+
+	Convey("When an approval is created without a user ID", t, func() {
+		approval := NewApproval("")
+
+		Convey("Then the approval records the system user", func() {
+			So(approval.User.ID, ShouldEqual, SystemUserID)
+		})
+	})
+}
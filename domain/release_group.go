@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/google/uuid"
+)
+
+// ReleaseGroupStatus represents how far the jobs in a release group have
+// progressed towards being published together
+type ReleaseGroupStatus string
+
+const (
+	// ReleaseGroupStatusAwaitingApproval indicates some jobs in the group
+	// have not yet been approved, so none are published
+	ReleaseGroupStatusAwaitingApproval ReleaseGroupStatus = "awaiting_approval"
+	// ReleaseGroupStatusPublishing indicates every job in the group has
+	// been approved and they are being published
+	ReleaseGroupStatusPublishing ReleaseGroupStatus = "publishing"
+	// ReleaseGroupStatusReleasing indicates every job in the group has been
+	// published and their collections are being released together
+	ReleaseGroupStatusReleasing ReleaseGroupStatus = "releasing"
+	// ReleaseGroupStatusCompleted indicates every job in the group has
+	// completed
+	ReleaseGroupStatusCompleted ReleaseGroupStatus = "completed"
+	// ReleaseGroupStatusHeld indicates a job in the group has failed or been
+	// rejected, so the other jobs are held until it is resolved
+	ReleaseGroupStatusHeld ReleaseGroupStatus = "held"
+)
+
+// ReleaseGates are the states the jobs in a release group wait in until
+// every job in the group has reached them. Jobs are approved together and
+// then have their collections released together.
+var ReleaseGates = []State{StateApproved, StatePublished}
+
+// GroupableStates are the states a job can be added to a release group in,
+// which are those before it starts publishing
+var GroupableStates = []State{StateSubmitted, StateMigrating, StateInReview, StateApproved}
+
+// releaseProgress orders the states a job passes through on its way to
+// being published. States not listed stop a release group progressing.
+var releaseProgress = map[State]int{
+	StateSubmitted:          0,
+	StateMigrating:          1,
+	StateInReview:           2,
+	StateApproved:           3,
+	StatePublishing:         4,
+	StatePublished:          5,
+	StatePendingPostPublish: 6,
+	StatePostPublishing:     6,
+	StateCompleted:          7,
+}
+
+// ReleaseGroup represents a set of migration jobs that are published
+// together
+type ReleaseGroup struct {
+	ID          string             `json:"id" bson:"_id"`
+	Name        string             `json:"name" bson:"name"`
+	JobNumbers  []int              `json:"job_numbers" bson:"job_numbers"`
+	CreatedBy   *User              `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	LastUpdated time.Time          `json:"last_updated" bson:"last_updated"`
+	Status      ReleaseGroupStatus `json:"status,omitempty" bson:"-"`
+	HeldBy      []int              `json:"held_by,omitempty" bson:"-"`
+	Jobs        []ReleaseGroupJob  `json:"jobs,omitempty" bson:"-"`
+	Links       ReleaseGroupLinks  `json:"links" bson:"links"`
+}
+
+// ReleaseGroupJob summarises the state of a job in a release group
+type ReleaseGroupJob struct {
+	JobNumber int   `json:"job_number"`
+	State     State `json:"state"`
+}
+
+// ReleaseGroupLinks contains HATEOAS links for a release group
+type ReleaseGroupLinks struct {
+	Self *LinkObject `bson:"self,omitempty" json:"self,omitempty"`
+}
+
+// NewReleaseGroup creates a new ReleaseGroup of the provided jobs, created
+// by the provided user
+func NewReleaseGroup(name string, jobNumbers []int, userID string) *ReleaseGroup {
+	if userID == "" {
+		userID = SystemUserID
+	}
+
+	id := uuid.New().String()
+	now := time.Now().UTC()
+
+	return &ReleaseGroup{
+		ID:          id,
+		Name:        strings.TrimSpace(name),
+		JobNumbers:  jobNumbers,
+		CreatedBy:   &User{ID: userID},
+		CreatedAt:   now,
+		LastUpdated: now,
+		Links:       NewReleaseGroupLinks(id),
+	}
+}
+
+// NewReleaseGroupLinks creates ReleaseGroupLinks for a release group with
+// the given ID
+func NewReleaseGroupLinks(id string) ReleaseGroupLinks {
+	return ReleaseGroupLinks{
+		Self: &LinkObject{
+			HRef: fmt.Sprintf("/v1/migration-release-groups/%s", id),
+		},
+	}
+}
+
+// Validate checks that the release group has a name and at least two
+// distinct jobs
+func (g *ReleaseGroup) Validate() []error {
+	var errs []error
+
+	if g.Name == "" {
+		errs = append(errs, appErrors.ErrReleaseGroupNameNotProvided)
+	}
+
+	if len(g.JobNumbers) < 2 {
+		errs = append(errs, appErrors.ErrReleaseGroupTooFewJobs)
+	}
+
+	seen := make(map[int]bool, len(g.JobNumbers))
+	for _, jobNumber := range g.JobNumbers {
+		if seen[jobNumber] {
+			errs = append(errs, appErrors.ErrReleaseGroupDuplicateJob)
+			break
+		}
+		seen[jobNumber] = true
+	}
+
+	return errs
+}
+
+// IsGroupable checks if the job can be added to a release group
+func (j *Job) IsGroupable() bool {
+	return slices.Contains(GroupableStates, j.State)
+}
+
+// IsReleasedFrom checks if the job has been released from the provided
+// release gate state
+func (j *Job) IsReleasedFrom(state State) bool {
+	return slices.Contains(j.ReleasedStates, state)
+}
+
+// HasReachedState checks if the job has reached, or progressed past, the
+// provided state on its way to being published
+func (j *Job) HasReachedState(state State) bool {
+	progress, ok := releaseProgress[j.State]
+	return ok && progress >= releaseProgress[state]
+}
+
+// AllJobsReachedState checks if every one of the jobs has reached, or
+// progressed past, the provided state on its way to being published
+func AllJobsReachedState(jobs []*Job, state State) bool {
+	for _, job := range jobs {
+		if !job.HasReachedState(state) {
+			return false
+		}
+	}
+	return len(jobs) > 0
+}
+
+// SetStatus sets the status of the release group, the jobs holding it up
+// and the state of each of the group's jobs
+func (g *ReleaseGroup) SetStatus(jobs []*Job) {
+	g.HeldBy = nil
+	g.Jobs = make([]ReleaseGroupJob, 0, len(jobs))
+
+	for _, job := range jobs {
+		if _, ok := releaseProgress[job.State]; !ok {
+			g.HeldBy = append(g.HeldBy, job.JobNumber)
+		}
+
+		g.Jobs = append(g.Jobs, ReleaseGroupJob{
+			JobNumber: job.JobNumber,
+			State:     job.State,
+		})
+	}
+
+	switch {
+	case len(g.HeldBy) > 0:
+		g.Status = ReleaseGroupStatusHeld
+	case AllJobsReachedState(jobs, StateCompleted):
+		g.Status = ReleaseGroupStatusCompleted
+	case AllJobsReachedState(jobs, StatePublished):
+		g.Status = ReleaseGroupStatusReleasing
+	case AllJobsReachedState(jobs, StateApproved):
+		g.Status = ReleaseGroupStatusPublishing
+	default:
+		g.Status = ReleaseGroupStatusAwaitingApproval
+	}
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"testing"
+
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/google/uuid"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewReleaseGroup(t *testing.T) {
+	Convey("Given a name, job numbers and user", t, func() {
+		Convey("When a release group is created", func() {
+			group := NewReleaseGroup("  Regional series  ", []int{1, 2}, "test-user-id")
+
+			Convey("Then the group is created by the user with links", func() {
+				So(uuid.Validate(group.ID), ShouldBeNil)
+				So(group.Name, ShouldEqual, "Regional series")
+				So(group.JobNumbers, ShouldResemble, []int{1, 2})
+				So(group.CreatedBy.ID, ShouldEqual, "test-user-id")
+				So(group.CreatedAt.IsZero(), ShouldBeFalse)
+				So(group.Links.Self.HRef, ShouldEqual, "/v1/migration-release-groups/"+group.ID)
+			})
+		})
+
+		Convey("When a release group is created without a user", func() {
+			group := NewReleaseGroup("Regional series", []int{1, 2}, "")
+
+			Convey("Then it is created by the system user", func() {
+				So(group.CreatedBy.ID, ShouldEqual, SystemUserID)
+			})
+		})
+	})
+}
+
+func TestReleaseGroupValidate(t *testing.T) {
+	Convey("Given a release group with a name and two jobs", t, func() {
+		group := NewReleaseGroup("Regional series", []int{1, 2}, "test-user-id")
+
+		Convey("Then it is valid", func() {
+			So(group.Validate(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a release group without a name and with one job", t, func() {
+		group := NewReleaseGroup(" ", []int{1}, "test-user-id")
+
+		Convey("Then both errors are returned", func() {
+			So(group.Validate(), ShouldResemble, []error{appErrors.ErrReleaseGroupNameNotProvided, appErrors.ErrReleaseGroupTooFewJobs})
+		})
+	})
+
+	Convey("Given a release group with the same job twice", t, func() {
+		group := NewReleaseGroup("Regional series", []int{1, 2, 1}, "test-user-id")
+
+		Convey("Then a duplicate job error is returned", func() {
+			So(group.Validate(), ShouldResemble, []error{appErrors.ErrReleaseGroupDuplicateJob})
+		})
+	})
+}
+
+func TestJobIsGroupable(t *testing.T) {
+	Convey("Given jobs in each state", t, func() {
+		Convey("Then only jobs that have not started publishing are groupable", func() {
+			So((&Job{State: StateSubmitted}).IsGroupable(), ShouldBeTrue)
+			So((&Job{State: StateInReview}).IsGroupable(), ShouldBeTrue)
+			So((&Job{State: StateApproved}).IsGroupable(), ShouldBeTrue)
+			So((&Job{State: StatePublishing}).IsGroupable(), ShouldBeFalse)
+			So((&Job{State: StateRejected}).IsGroupable(), ShouldBeFalse)
+		})
+	})
+}
+
+func TestAllJobsReachedState(t *testing.T) {
+	Convey("Given an approved job and a published job", t, func() {
+		jobs := []*Job{{State: StateApproved}, {State: StatePublished}}
+
+		Convey("Then they have all reached approved but not published", func() {
+			So(AllJobsReachedState(jobs, StateApproved), ShouldBeTrue)
+			So(AllJobsReachedState(jobs, StatePublished), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a published job and a job that failed to publish", t, func() {
+		jobs := []*Job{{State: StatePublished}, {State: StateFailedPublish}}
+
+		Convey("Then they have not all reached approved", func() {
+			So(AllJobsReachedState(jobs, StateApproved), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given no jobs", t, func() {
+		Convey("Then they have not reached any state", func() {
+			So(AllJobsReachedState(nil, StateApproved), ShouldBeFalse)
+		})
+	})
+}
+
+func TestReleaseGroupSetStatus(t *testing.T) {
+	Convey("Given a release group", t, func() {
+		group := NewReleaseGroup("Regional series", []int{1, 2}, "test-user-id")
+
+		Convey("When a job is still in review", func() {
+			group.SetStatus([]*Job{{JobNumber: 1, State: StateApproved}, {JobNumber: 2, State: StateInReview}})
+
+			Convey("Then the group is awaiting approval with each job's state", func() {
+				So(group.Status, ShouldEqual, ReleaseGroupStatusAwaitingApproval)
+				So(group.HeldBy, ShouldBeEmpty)
+				So(group.Jobs, ShouldResemble, []ReleaseGroupJob{
+					{JobNumber: 1, State: StateApproved},
+					{JobNumber: 2, State: StateInReview},
+				})
+			})
+		})
+
+		Convey("When every job is approved or publishing", func() {
+			group.SetStatus([]*Job{{JobNumber: 1, State: StatePublishing}, {JobNumber: 2, State: StateApproved}})
+
+			Convey("Then the group is publishing", func() {
+				So(group.Status, ShouldEqual, ReleaseGroupStatusPublishing)
+			})
+		})
+
+		Convey("When every job is published", func() {
+			group.SetStatus([]*Job{{JobNumber: 1, State: StatePostPublishing}, {JobNumber: 2, State: StatePublished}})
+
+			Convey("Then the group is releasing", func() {
+				So(group.Status, ShouldEqual, ReleaseGroupStatusReleasing)
+			})
+		})
+
+		Convey("When every job is completed", func() {
+			group.SetStatus([]*Job{{JobNumber: 1, State: StateCompleted}, {JobNumber: 2, State: StateCompleted}})
+
+			Convey("Then the group is completed", func() {
+				So(group.Status, ShouldEqual, ReleaseGroupStatusCompleted)
+			})
+		})
+
+		Convey("When a job has failed to publish", func() {
+			group.SetStatus([]*Job{{JobNumber: 1, State: StatePublished}, {JobNumber: 2, State: StateFailedPublish}})
+
+			Convey("Then the group is held by the failed job", func() {
+				So(group.Status, ShouldEqual, ReleaseGroupStatusHeld)
+				So(group.HeldBy, ShouldResemble, []int{2})
+			})
+		})
+	})
+}
@@ -117,3 +117,38 @@ func GetStateLabel(state State) (string, error) {
 		return "", fmt.Errorf("unknown state label mapping for state: %s", state)
 	}
 }
+
+// GetActiveTaskStates returns the states a task is in while it is being
+// worked on.
+func GetActiveTaskStates() []State {
+	return []State{
+		StateMigrating, StatePublishing, StatePostPublishing, StateReverting,
+	}
+}
+
+// TaskStage describes a stage of migration that tasks are claimed for
+type TaskStage struct {
+	// PendingState is the state tasks wait in to be claimed for the stage
+	PendingState State
+	// ActiveState is the state of a task while the stage runs
+	ActiveState State
+	// DependencyState is the state the tasks a task depends on must be in
+	// before it is claimed for the stage. It is empty if the stage does not
+	// wait for dependencies.
+	DependencyState State
+	// FailureState is the state of a task whose stage failed
+	FailureState State
+}
+
+// GetTaskStages returns the stages tasks are claimed for, in the order
+// they are claimed. A task that depends on other tasks waits for them to
+// finish the same stage, except when reverting, where the order does not
+// matter.
+func GetTaskStages() []TaskStage {
+	return []TaskStage{
+		{PendingState: StateSubmitted, ActiveState: StateMigrating, DependencyState: StateInReview, FailureState: StateFailedMigration},
+		{PendingState: StateApproved, ActiveState: StatePublishing, DependencyState: StatePublished, FailureState: StateFailedPublish},
+		{PendingState: StatePendingPostPublish, ActiveState: StatePostPublishing, DependencyState: StateCompleted, FailureState: StateFailedPostPublish},
+		{PendingState: StateRejected, ActiveState: StateReverting, FailureState: StateFailedReversion},
+	}
+}
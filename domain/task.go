@@ -17,7 +17,18 @@ type Task struct {
 	State       State         `json:"state" bson:"state"`
 	Target      *TaskMetadata `json:"target" bson:"target"`
 	Type        TaskType      `json:"type" bson:"type"`
+	Priority    int           `json:"priority,omitempty" bson:"priority"`
 	Links       TaskLinks     `json:"links" bson:"links"`
+	// ParentTaskID is the ID of the task that created this task. It is
+	// empty for the root task of a job.
+	ParentTaskID string `json:"parent_task_id,omitempty" bson:"parent_task_id,omitempty"`
+	// Depth is the number of ancestors this task has
+	Depth int `json:"depth,omitempty" bson:"depth,omitempty"`
+	// Path contains the IDs of this task's ancestors, starting from the root
+	Path []string `json:"path,omitempty" bson:"path,omitempty"`
+	// DependsOn contains the IDs of the tasks that must finish each stage
+	// of the migration before this task can start it
+	DependsOn []string `json:"depends_on,omitempty" bson:"depends_on,omitempty"`
 }
 
 // NewTask creates a new Task instance with the provided configuration
@@ -35,6 +46,13 @@ func NewTask(jobNumber int) Task {
 	}
 }
 
+// SetParent records the provided task as the parent of this task
+func (t *Task) SetParent(parent *Task) {
+	t.ParentTaskID = parent.ID
+	t.Depth = parent.Depth + 1
+	t.Path = append(append(make([]string, 0, len(parent.Path)+1), parent.Path...), parent.ID)
+}
+
 // TaskMetadata represents metadata about a task's source or target
 type TaskMetadata struct {
 	ID        string `json:"id,omitempty" bson:"id,omitempty"`
@@ -49,3 +49,27 @@ func TestNewTask(t *testing.T) {
 		})
 	})
 }
+
+func TestTaskSetParent(t *testing.T) {
+	Convey("Given a root task and a child of that task", t, func() {
+		root := domain.NewTask(456)
+		child := domain.NewTask(456)
+		child.SetParent(&root)
+
+		Convey("When SetParent is called on a grandchild task", func() {
+			grandchild := domain.NewTask(456)
+			grandchild.SetParent(&child)
+
+			Convey("Then the parent, depth and path are recorded", func() {
+				So(grandchild.ParentTaskID, ShouldEqual, child.ID)
+				So(grandchild.Depth, ShouldEqual, 2)
+				So(grandchild.Path, ShouldResemble, []string{root.ID, child.ID})
+
+				Convey("And the parent's path is not modified", func() {
+					So(child.Depth, ShouldEqual, 1)
+					So(child.Path, ShouldResemble, []string{root.ID})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package domain
+
+// TaskNode represents a task and its child tasks in a job's task tree
+type TaskNode struct {
+	*Task
+	// RolledUpState is the state of the task combined with the states of
+	// all of its descendants. See RollUpState.
+	RolledUpState State       `json:"rolled_up_state"`
+	Children      []*TaskNode `json:"children"`
+}
+
+// BuildTaskTree arranges the tasks of a job into trees using their parent
+// task IDs. Tasks without a parent, or whose parent is not in the provided
+// tasks, are returned as roots. The order of the provided tasks is kept
+// for roots and for the children of each node.
+func BuildTaskTree(tasks []*Task) []*TaskNode {
+	nodes := make(map[string]*TaskNode, len(tasks))
+	for _, task := range tasks {
+		nodes[task.ID] = &TaskNode{
+			Task:     task,
+			Children: []*TaskNode{},
+		}
+	}
+
+	roots := []*TaskNode{}
+	for _, task := range tasks {
+		node := nodes[task.ID]
+
+		parent, ok := nodes[task.ParentTaskID]
+		if task.ParentTaskID == "" || !ok {
+			roots = append(roots, node)
+			continue
+		}
+
+		parent.Children = append(parent.Children, node)
+	}
+
+	for _, root := range roots {
+		root.rollUp()
+	}
+
+	return roots
+}
+
+// rollUp sets the rolled up state of the node and its descendants
+func (n *TaskNode) rollUp() State {
+	states := make([]State, 0, len(n.Children)+1)
+	states = append(states, n.State)
+
+	for _, child := range n.Children {
+		states = append(states, child.rollUp())
+	}
+
+	n.RolledUpState = RollUpState(states...)
+	return n.RolledUpState
+}
+
+// rollUpPriority orders states by how much attention they need, most
+// first. Failures are surfaced above everything else, followed by work in
+// progress and then work that is waiting to progress.
+var rollUpPriority = []State{
+	StateFailedMigration,
+	StateFailedPublish,
+	StateFailedPostPublish,
+	StateFailedReversion,
+	StateReverting,
+	StateMigrating,
+	StatePublishing,
+	StatePostPublishing,
+	StatePendingPostPublish,
+	StateSubmitted,
+	StateInReview,
+	StateApproved,
+	StatePublished,
+	StateCompleted,
+	StateRejected,
+	StateCancelled,
+}
+
+// RollUpState combines the states of a group of tasks into a single state,
+// returning the state that needs the most attention. For example, a failure
+// anywhere in the group is reported over the group being complete.
+func RollUpState(states ...State) State {
+	var (
+		rolledUp State
+		best     = len(rollUpPriority)
+	)
+
+	for _, state := range states {
+		for i, s := range rollUpPriority {
+			if s == state && i < best {
+				rolledUp, best = state, i
+				break
+			}
+		}
+	}
+
+	return rolledUp
+}
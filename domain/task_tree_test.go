@@ -0,0 +1,101 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildTaskTree(t *testing.T) {
+	Convey("Given the tasks of a job created by fan-out from a series task", t, func() {
+		series := &domain.Task{ID: "series", State: domain.StateInReview, Type: domain.TaskTypeDatasetSeries}
+		edition := &domain.Task{ID: "edition", State: domain.StateInReview, Type: domain.TaskTypeDatasetEdition}
+		edition.SetParent(series)
+		version := &domain.Task{ID: "version", State: domain.StateInReview, Type: domain.TaskTypeDatasetVersion}
+		version.SetParent(edition)
+		download1 := &domain.Task{ID: "download-1", State: domain.StateInReview, Type: domain.TaskTypeDatasetDownload}
+		download1.SetParent(version)
+		download2 := &domain.Task{ID: "download-2", State: domain.StateFailedMigration, Type: domain.TaskTypeDatasetDownload}
+		download2.SetParent(version)
+
+		Convey("When BuildTaskTree is called with the tasks in any order", func() {
+			roots := domain.BuildTaskTree([]*domain.Task{download1, version, series, download2, edition})
+
+			Convey("Then a single tree is returned, rooted at the series task", func() {
+				So(len(roots), ShouldEqual, 1)
+				So(roots[0].Task, ShouldEqual, series)
+				So(len(roots[0].Children), ShouldEqual, 1)
+				So(roots[0].Children[0].Task, ShouldEqual, edition)
+				So(len(roots[0].Children[0].Children), ShouldEqual, 1)
+
+				versionNode := roots[0].Children[0].Children[0]
+				So(versionNode.Task, ShouldEqual, version)
+				So(len(versionNode.Children), ShouldEqual, 2)
+				So(versionNode.Children[0].Task, ShouldEqual, download1)
+				So(versionNode.Children[1].Task, ShouldEqual, download2)
+
+				Convey("And the failed download is rolled up to its ancestors", func() {
+					So(versionNode.Children[0].RolledUpState, ShouldEqual, domain.StateInReview)
+					So(versionNode.Children[1].RolledUpState, ShouldEqual, domain.StateFailedMigration)
+					So(versionNode.RolledUpState, ShouldEqual, domain.StateFailedMigration)
+					So(roots[0].Children[0].RolledUpState, ShouldEqual, domain.StateFailedMigration)
+					So(roots[0].RolledUpState, ShouldEqual, domain.StateFailedMigration)
+				})
+			})
+		})
+	})
+
+	Convey("Given tasks with no parent and a task whose parent is missing", t, func() {
+		legacy := &domain.Task{ID: "legacy", State: domain.StateCompleted}
+		orphan := &domain.Task{ID: "orphan", ParentTaskID: "missing", State: domain.StateMigrating}
+
+		Convey("When BuildTaskTree is called", func() {
+			roots := domain.BuildTaskTree([]*domain.Task{legacy, orphan})
+
+			Convey("Then each task is returned as a root", func() {
+				So(len(roots), ShouldEqual, 2)
+				So(roots[0].Task, ShouldEqual, legacy)
+				So(roots[0].Children, ShouldBeEmpty)
+				So(roots[0].RolledUpState, ShouldEqual, domain.StateCompleted)
+				So(roots[1].Task, ShouldEqual, orphan)
+				So(roots[1].RolledUpState, ShouldEqual, domain.StateMigrating)
+			})
+		})
+	})
+
+	Convey("Given no tasks", t, func() {
+		Convey("When BuildTaskTree is called", func() {
+			roots := domain.BuildTaskTree(nil)
+
+			Convey("Then an empty list is returned", func() {
+				So(roots, ShouldNotBeNil)
+				So(roots, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestRollUpState(t *testing.T) {
+	Convey("Given a group of states", t, func() {
+		Convey("Then a failure takes precedence over any other state", func() {
+			So(domain.RollUpState(domain.StateCompleted, domain.StateMigrating, domain.StateFailedPublish), ShouldEqual, domain.StateFailedPublish)
+		})
+
+		Convey("Then work in progress takes precedence over waiting and finished work", func() {
+			So(domain.RollUpState(domain.StateCompleted, domain.StatePublishing, domain.StateApproved), ShouldEqual, domain.StatePublishing)
+		})
+
+		Convey("Then the least progressed waiting state is returned", func() {
+			So(domain.RollUpState(domain.StateApproved, domain.StateInReview, domain.StateCompleted), ShouldEqual, domain.StateInReview)
+		})
+
+		Convey("Then cancelled tasks do not hold back completed tasks", func() {
+			So(domain.RollUpState(domain.StateCompleted, domain.StateCancelled), ShouldEqual, domain.StateCompleted)
+		})
+
+		Convey("Then no states roll up to an empty state", func() {
+			So(domain.RollUpState(), ShouldEqual, domain.State(""))
+		})
+	})
+}
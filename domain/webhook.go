@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/google/uuid"
+)
+
+// webhookEventTypePrefix prefixes the state a job reached to give the type
+// of a job lifecycle event, for example job.published.
+const webhookEventTypePrefix = "job."
+
+// WebhookEventType identifies a job lifecycle event that webhooks can
+// subscribe to
+type WebhookEventType string
+
+// WebhookEventTypeForState returns the event type for a job reaching the
+// given state
+func WebhookEventTypeForState(state State) WebhookEventType {
+	return WebhookEventType(webhookEventTypePrefix + string(state))
+}
+
+// IsValidWebhookEventType checks if the provided event type is for a known
+// job state
+func IsValidWebhookEventType(eventType WebhookEventType) bool {
+	state, found := strings.CutPrefix(string(eventType), webhookEventTypePrefix)
+	return found && IsValidState(State(state))
+}
+
+// Webhook represents a subscription to job lifecycle events, which are
+// delivered as signed HTTP POST requests to its URL
+type Webhook struct {
+	ID          string             `json:"id" bson:"_id"`
+	URL         string             `json:"url" bson:"url"`
+	Secret      string             `json:"-" bson:"secret"`
+	EventTypes  []WebhookEventType `json:"event_types" bson:"event_types"`
+	JobTypes    []JobType          `json:"job_types,omitempty" bson:"job_types,omitempty"`
+	Enabled     bool               `json:"enabled" bson:"enabled"`
+	CreatedBy   *User              `json:"created_by,omitempty" bson:"created_by,omitempty"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	LastUpdated time.Time          `json:"last_updated" bson:"last_updated"`
+	Links       WebhookLinks       `json:"links" bson:"links"`
+}
+
+// WebhookLinks contains HATEOS links for a webhook
+type WebhookLinks struct {
+	Self       *LinkObject `bson:"self,omitempty" json:"self,omitempty"`
+	Deliveries *LinkObject `bson:"deliveries,omitempty" json:"deliveries,omitempty"`
+}
+
+// NewWebhook creates a new enabled Webhook created by the provided user
+func NewWebhook(webhookURL, secret string, eventTypes []WebhookEventType, jobTypes []JobType, userID string) *Webhook {
+	id := uuid.New().String()
+	now := time.Now().UTC()
+
+	if userID == "" {
+		userID = SystemUserID
+	}
+
+	return &Webhook{
+		ID:          id,
+		URL:         webhookURL,
+		Secret:      secret,
+		EventTypes:  eventTypes,
+		JobTypes:    jobTypes,
+		Enabled:     true,
+		CreatedBy:   &User{ID: userID},
+		CreatedAt:   now,
+		LastUpdated: now,
+		Links:       NewWebhookLinks(id),
+	}
+}
+
+// NewWebhookLinks creates WebhookLinks for the webhook with the given ID
+func NewWebhookLinks(id string) WebhookLinks {
+	return WebhookLinks{
+		Self: &LinkObject{
+			HRef: fmt.Sprintf("/v1/webhooks/%s", id),
+		},
+		Deliveries: &LinkObject{
+			HRef: fmt.Sprintf("/v1/webhooks/%s/deliveries", id),
+		},
+	}
+}
+
+// Validate checks that the webhook has an absolute HTTP(S) URL, a secret
+// to sign deliveries with, and valid event and job types to filter on
+func (w *Webhook) Validate() []error {
+	var errs []error
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, appErrors.ErrWebhookURLInvalid)
+	}
+
+	if w.Secret == "" {
+		errs = append(errs, appErrors.ErrWebhookSecretNotProvided)
+	}
+
+	if len(w.EventTypes) == 0 {
+		errs = append(errs, appErrors.ErrWebhookEventTypesNotProvided)
+	}
+
+	for _, eventType := range w.EventTypes {
+		if !IsValidWebhookEventType(eventType) {
+			errs = append(errs, appErrors.ErrWebhookEventTypeInvalid)
+			break
+		}
+	}
+
+	for _, jobType := range w.JobTypes {
+		if !IsValidJobType(jobType) {
+			errs = append(errs, appErrors.ErrJobTypeInvalid)
+			break
+		}
+	}
+
+	return errs
+}
+
+// WebhookDeliveryStatus represents the progress of delivering an event to
+// a webhook
+type WebhookDeliveryStatus string
+
+// Webhook delivery statuses
+const (
+	// WebhookDeliveryStatusPending indicates the delivery has not yet
+	// succeeded and will be attempted again
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryStatusSucceeded indicates the webhook accepted the
+	// delivery
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryStatusDeadLetter indicates every attempt to deliver
+	// failed and no more will be made
+	WebhookDeliveryStatusDeadLetter WebhookDeliveryStatus = "dead_letter"
+)
+
+// IsValidWebhookDeliveryStatus checks if the provided status is a known
+// webhook delivery status
+func IsValidWebhookDeliveryStatus(status WebhookDeliveryStatus) bool {
+	switch status {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusDeadLetter:
+		return true
+	default:
+		return false
+	}
+}
+
+// WebhookDelivery represents an event to be delivered to a webhook and
+// the outcome of each attempt to deliver it
+type WebhookDelivery struct {
+	ID            string                `json:"id" bson:"_id"`
+	WebhookID     string                `json:"webhook_id" bson:"webhook_id"`
+	EventType     WebhookEventType      `json:"event_type" bson:"event_type"`
+	JobNumber     int                   `json:"job_number" bson:"job_number"`
+	Payload       json.RawMessage       `json:"payload" bson:"payload"`
+	Status        WebhookDeliveryStatus `json:"status" bson:"status"`
+	Attempts      int                   `json:"attempts" bson:"attempts"`
+	LastAttemptAt *time.Time            `json:"last_attempt_at,omitempty" bson:"last_attempt_at,omitempty"`
+	NextAttemptAt *time.Time            `json:"next_attempt_at,omitempty" bson:"next_attempt_at,omitempty"`
+	LastStatus    int                   `json:"last_response_status,omitempty" bson:"last_response_status,omitempty"`
+	LastError     string                `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	CreatedAt     time.Time             `json:"created_at" bson:"created_at"`
+}
+
+// WebhookPayload is the body of a webhook delivery
+type WebhookPayload struct {
+	DeliveryID    string           `json:"delivery_id"`
+	EventType     WebhookEventType `json:"event_type"`
+	OccurredAt    time.Time        `json:"occurred_at"`
+	JobNumber     int              `json:"job_number"`
+	JobType       JobType          `json:"job_type"`
+	Label         string           `json:"label"`
+	SourceID      string           `json:"source_id"`
+	TargetID      string           `json:"target_id"`
+	PreviousState State            `json:"previous_state"`
+	State         State            `json:"state"`
+	Reason        string           `json:"reason,omitempty"`
+	Links         JobLinks         `json:"links"`
+}
+
+// NewWebhookDelivery creates a pending delivery to the webhook of a job
+// moving from one state to another, due to be attempted straight away
+func NewWebhookDelivery(webhook *Webhook, job *Job, fromState, toState State, reason string) (*WebhookDelivery, error) {
+	id := uuid.New().String()
+	now := time.Now().UTC()
+	eventType := WebhookEventTypeForState(toState)
+
+	payload := WebhookPayload{
+		DeliveryID:    id,
+		EventType:     eventType,
+		OccurredAt:    now,
+		JobNumber:     job.JobNumber,
+		Label:         job.Label,
+		PreviousState: fromState,
+		State:         toState,
+		Reason:        reason,
+		Links:         job.Links,
+	}
+
+	if job.Config != nil {
+		payload.JobType = job.Config.Type
+		payload.SourceID = job.Config.SourceID
+		payload.TargetID = job.Config.TargetID
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhook.ID,
+		EventType:     eventType,
+		JobNumber:     job.JobNumber,
+		Payload:       body,
+		Status:        WebhookDeliveryStatusPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+	}, nil
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/google/uuid"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIsValidWebhookEventType(t *testing.T) {
+	Convey("Given webhook event types", t, func() {
+		Convey("Then event types for known job states are valid", func() {
+			So(IsValidWebhookEventType(WebhookEventTypeForState(StatePublished)), ShouldBeTrue)
+			So(IsValidWebhookEventType("job.failed_migration"), ShouldBeTrue)
+		})
+
+		Convey("Then event types without the prefix or for unknown states are invalid", func() {
+			So(IsValidWebhookEventType("published"), ShouldBeFalse)
+			So(IsValidWebhookEventType("job.unknown"), ShouldBeFalse)
+			So(IsValidWebhookEventType("task.published"), ShouldBeFalse)
+		})
+	})
+}
+
+func TestNewWebhook(t *testing.T) {
+	Convey("Given a webhook URL, secret and filters", t, func() {
+		eventTypes := []WebhookEventType{"job.published"}
+
+		Convey("When a webhook is created", func() {
+			webhook := NewWebhook("https://example.com/hook", "secret", eventTypes, nil, "test-user-id")
+
+			Convey("Then an enabled webhook with links is returned", func() {
+				So(uuid.Validate(webhook.ID), ShouldBeNil)
+				So(webhook.Enabled, ShouldBeTrue)
+				So(webhook.CreatedBy.ID, ShouldEqual, "test-user-id")
+				So(webhook.LastUpdated, ShouldEqual, webhook.CreatedAt)
+				So(webhook.Links.Self.HRef, ShouldEqual, fmt.Sprintf("/v1/webhooks/%s", webhook.ID))
+				So(webhook.Links.Deliveries.HRef, ShouldEqual, fmt.Sprintf("/v1/webhooks/%s/deliveries", webhook.ID))
+			})
+
+			Convey("And its secret is not included in JSON", func() {
+				body, err := json.Marshal(webhook)
+				So(err, ShouldBeNil)
+				So(string(body), ShouldNotContainSubstring, "secret")
+			})
+		})
+	})
+}
+
+func TestWebhookValidate(t *testing.T) {
+	Convey("Given a valid webhook", t, func() {
+		webhook := NewWebhook("https://example.com/hook", "secret", []WebhookEventType{"job.published"}, []JobType{JobTypeStaticDataset}, "")
+
+		Convey("Then it has no validation errors", func() {
+			So(webhook.Validate(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a webhook with a relative URL, no secret and no event types", t, func() {
+		webhook := NewWebhook("/hook", "", nil, nil, "")
+
+		Convey("Then each problem is reported", func() {
+			So(webhook.Validate(), ShouldResemble, []error{
+				appErrors.ErrWebhookURLInvalid,
+				appErrors.ErrWebhookSecretNotProvided,
+				appErrors.ErrWebhookEventTypesNotProvided,
+			})
+		})
+	})
+
+	Convey("Given a webhook with an unknown event type and job type", t, func() {
+		webhook := NewWebhook("ftp://example.com/hook", "secret", []WebhookEventType{"job.unknown"}, []JobType{"unknown"}, "")
+
+		Convey("Then each problem is reported", func() {
+			So(webhook.Validate(), ShouldResemble, []error{
+				appErrors.ErrWebhookURLInvalid,
+				appErrors.ErrWebhookEventTypeInvalid,
+				appErrors.ErrJobTypeInvalid,
+			})
+		})
+	})
+}
+
+func TestNewWebhookDelivery(t *testing.T) {
+	Convey("Given a webhook and a job that has been published", t, func() {
+		webhook := &Webhook{ID: "webhook-1"}
+		job := &Job{
+			JobNumber: 18,
+			Label:     "Test Job",
+			Config: &JobConfig{
+				SourceID: "/source-id",
+				TargetID: "target-id",
+				Type:     JobTypeStaticDataset,
+			},
+			Links: NewJobLinks("18"),
+		}
+
+		Convey("When a delivery is created", func() {
+			delivery, err := NewWebhookDelivery(webhook, job, StateApproved, StatePublished, "all tasks published")
+			So(err, ShouldBeNil)
+
+			Convey("Then a pending delivery due now is returned", func() {
+				So(uuid.Validate(delivery.ID), ShouldBeNil)
+				So(delivery.WebhookID, ShouldEqual, "webhook-1")
+				So(delivery.EventType, ShouldEqual, WebhookEventType("job.published"))
+				So(delivery.JobNumber, ShouldEqual, 18)
+				So(delivery.Status, ShouldEqual, WebhookDeliveryStatusPending)
+				So(delivery.Attempts, ShouldEqual, 0)
+				So(*delivery.NextAttemptAt, ShouldEqual, delivery.CreatedAt)
+			})
+
+			Convey("And the payload describes the transition", func() {
+				var payload WebhookPayload
+				So(json.Unmarshal(delivery.Payload, &payload), ShouldBeNil)
+				So(payload.DeliveryID, ShouldEqual, delivery.ID)
+				So(payload.JobType, ShouldEqual, JobTypeStaticDataset)
+				So(payload.SourceID, ShouldEqual, "/source-id")
+				So(payload.PreviousState, ShouldEqual, StateApproved)
+				So(payload.State, ShouldEqual, StatePublished)
+				So(payload.Reason, ShouldEqual, "all tasks published")
+				So(payload.Links.Self.HRef, ShouldEqual, "/v1/migration-jobs/18")
+			})
+		})
+	})
+}
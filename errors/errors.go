@@ -120,12 +120,27 @@ var (
 	ErrJobStateNotAllowed           = errors.New("state not allowed for this endpoint")
 	ErrJobStateTransitionNotAllowed = errors.New("state change is not allowed")
 	ErrTaskNotFound                 = errors.New("task not found")
+	ErrTaskDependencyNotFound       = errors.New("task depends on a task that does not exist")
 	ErrOffsetInvalid                = errors.New("offset parameter is invalid")
 	ErrLimitInvalid                 = errors.New("limit parameter is invalid")
 	ErrLimitExceeded                = errors.New("limit parameter exceeds maximum allowed")
 	ErrUnauthorized                 = errors.New("unauthorized")
 	ErrSortFieldInvalid             = errors.New("field is invalid in sort parameter")
 	ErrSortDirectionInvalid         = errors.New("direction is invalid in sort parameter")
+	ErrUpdatedAfterInvalid          = errors.New("updated_after parameter must be an RFC3339 timestamp")
+	ErrUpdatedBeforeInvalid         = errors.New("updated_before parameter must be an RFC3339 timestamp")
+	ErrCursorInvalid                = errors.New("after parameter is invalid")
+	ErrCursorWithOffset             = errors.New("offset parameter cannot be used with the after parameter")
+	ErrCursorSortInvalid            = errors.New("sort parameter cannot be last_updated when the after parameter is used")
+	ErrEventActionInvalid           = errors.New("action parameter is invalid")
+	ErrCreatedAfterInvalid          = errors.New("created_after parameter must be an RFC3339 timestamp")
+	ErrCreatedBeforeInvalid         = errors.New("created_before parameter must be an RFC3339 timestamp")
+	ErrWebhookNotFound              = errors.New("webhook not found")
+	ErrWebhookURLInvalid            = errors.New("webhook url must be an absolute http or https URL")
+	ErrWebhookSecretNotProvided     = errors.New("webhook secret not provided")
+	ErrWebhookEventTypesNotProvided = errors.New("webhook event types not provided")
+	ErrWebhookEventTypeInvalid      = errors.New("webhook event type is invalid")
+	ErrWebhookDeliveryStatusInvalid = errors.New("delivery status parameter is invalid")
 
 	ErrSourceIDZebedeeURIInvalid = errors.New("source ID URI path must start with '/', not end with '/', not contain query strings or hashbangs")
 	ErrTargetIDDatasetIDInvalid  = errors.New("target id must be lowercase alphanumeric with optional hyphen separators")
@@ -141,6 +156,46 @@ var (
 
 	ErrFailedToParseAuthEntityData = errors.New("failed to parse auth entity data")
 
+	ErrBatchNotFound = errors.New("batch not found")
+	ErrBatchEmpty    = errors.New("batch must contain at least one row")
+	ErrBatchTooLarge = errors.New("batch contains more rows than allowed")
+
+	ErrIdempotencyKeyNotFound   = errors.New("idempotency key not found")
+	ErrIdempotencyKeyExists     = errors.New("idempotency key already exists")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key has already been used for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
+
+	ErrDigestUnavailable = errors.New("digests cannot be sent by this service")
+	ErrDigestRunClaimed  = errors.New("digest run has already been claimed")
+
+	ErrSlackApprovalsUnavailable = errors.New("slack approvals are not enabled for this service")
+	ErrSlackRequestUnverified    = errors.New("slack request signature could not be verified")
+	ErrSlackInteractionInvalid   = errors.New("slack interaction is invalid")
+	ErrSlackUserNotPermitted     = errors.New("slack user is not permitted to approve or reject jobs")
+
+	ErrCommentTextNotProvided = errors.New("comment text not provided")
+	ErrCommentTextTooLong     = errors.New("comment text exceeds maximum length")
+
+	ErrApproverIsSubmitter      = errors.New("job cannot be approved by the user who submitted it")
+	ErrJobAlreadyApprovedByUser = errors.New("job has already been approved by this user")
+
+	ErrPublishAtNotProvided  = errors.New("publish_at not provided")
+	ErrPublishAtInPast       = errors.New("publish_at must be in the future")
+	ErrPublishAtNotAllowed   = errors.New("publish_at can only be set when approving a job")
+	ErrJobScheduleNotAllowed = errors.New("job publishing can only be scheduled while the job is in review or approved")
+
+	ErrReleaseGroupNotFound        = errors.New("release group not found")
+	ErrReleaseGroupNameNotProvided = errors.New("release group name not provided")
+	ErrReleaseGroupTooFewJobs      = errors.New("release group must contain at least two jobs")
+	ErrReleaseGroupDuplicateJob    = errors.New("release group contains the same job more than once")
+	ErrReleaseGroupJobNotFound     = errors.New("release group job not found")
+	ErrJobAlreadyInReleaseGroup    = errors.New("job is already in a release group")
+	ErrJobNotGroupable             = errors.New("only jobs that have not started publishing can be added to a release group")
+
+	ErrJobPriorityNotProvided = errors.New("priority not provided")
+	ErrJobPriorityInvalid     = errors.New("priority must be between 0 and 10")
+	ErrJobPriorityNotAllowed  = errors.New("priority cannot be changed once a job has finished")
+
 	StatusCodeMap = map[error]int{
 		ErrJobNotFound:                  http.StatusNotFound,
 		ErrTaskNotFound:                 http.StatusNotFound,
@@ -155,6 +210,7 @@ var (
 		ErrSourceIDValidation:           http.StatusInternalServerError,
 		ErrTargetIDValidation:           http.StatusInternalServerError,
 		ErrJobNumberCounterNotFound:     http.StatusInternalServerError,
+		ErrTaskDependencyNotFound:       http.StatusInternalServerError,
 		ErrJobAlreadyRunning:            http.StatusConflict,
 		ErrSourceDoesNotExist:           http.StatusBadRequest,
 		ErrTargetAlreadyExists:          http.StatusBadRequest,
@@ -172,7 +228,49 @@ var (
 		ErrJobStateNotAllowed:           http.StatusBadRequest,
 		ErrSortFieldInvalid:             http.StatusBadRequest,
 		ErrSortDirectionInvalid:         http.StatusBadRequest,
+		ErrUpdatedAfterInvalid:          http.StatusBadRequest,
+		ErrUpdatedBeforeInvalid:         http.StatusBadRequest,
+		ErrCursorInvalid:                http.StatusBadRequest,
+		ErrEventActionInvalid:           http.StatusBadRequest,
+		ErrCreatedAfterInvalid:          http.StatusBadRequest,
+		ErrCreatedBeforeInvalid:         http.StatusBadRequest,
+		ErrWebhookNotFound:              http.StatusNotFound,
+		ErrWebhookURLInvalid:            http.StatusBadRequest,
+		ErrWebhookSecretNotProvided:     http.StatusBadRequest,
+		ErrWebhookEventTypesNotProvided: http.StatusBadRequest,
+		ErrWebhookEventTypeInvalid:      http.StatusBadRequest,
+		ErrWebhookDeliveryStatusInvalid: http.StatusBadRequest,
+		ErrCursorWithOffset:             http.StatusBadRequest,
+		ErrCursorSortInvalid:            http.StatusBadRequest,
 		ErrUnauthorized:                 http.StatusUnauthorized,
 		ErrFailedToParseAuthEntityData:  http.StatusInternalServerError,
+		ErrBatchNotFound:                http.StatusNotFound,
+		ErrBatchEmpty:                   http.StatusBadRequest,
+		ErrBatchTooLarge:                http.StatusBadRequest,
+		ErrIdempotencyKeyReused:         http.StatusUnprocessableEntity,
+		ErrIdempotencyKeyInProgress:     http.StatusConflict,
+		ErrDigestUnavailable:            http.StatusServiceUnavailable,
+		ErrSlackApprovalsUnavailable:    http.StatusServiceUnavailable,
+		ErrSlackRequestUnverified:       http.StatusUnauthorized,
+		ErrSlackInteractionInvalid:      http.StatusBadRequest,
+		ErrSlackUserNotPermitted:        http.StatusForbidden,
+		ErrCommentTextNotProvided:       http.StatusBadRequest,
+		ErrCommentTextTooLong:           http.StatusBadRequest,
+		ErrApproverIsSubmitter:          http.StatusForbidden,
+		ErrJobAlreadyApprovedByUser:     http.StatusConflict,
+		ErrPublishAtNotProvided:         http.StatusBadRequest,
+		ErrPublishAtInPast:              http.StatusBadRequest,
+		ErrPublishAtNotAllowed:          http.StatusBadRequest,
+		ErrJobScheduleNotAllowed:        http.StatusConflict,
+		ErrReleaseGroupNotFound:         http.StatusNotFound,
+		ErrReleaseGroupNameNotProvided:  http.StatusBadRequest,
+		ErrReleaseGroupTooFewJobs:       http.StatusBadRequest,
+		ErrReleaseGroupDuplicateJob:     http.StatusBadRequest,
+		ErrReleaseGroupJobNotFound:      http.StatusBadRequest,
+		ErrJobAlreadyInReleaseGroup:     http.StatusConflict,
+		ErrJobNotGroupable:              http.StatusConflict,
+		ErrJobPriorityNotProvided:       http.StatusBadRequest,
+		ErrJobPriorityInvalid:           http.StatusBadRequest,
+		ErrJobPriorityNotAllowed:        http.StatusConflict,
 	}
 )
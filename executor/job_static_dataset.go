@@ -119,7 +119,7 @@ func (e *StaticDatasetJobExecutor) Publish(ctx context.Context, job *domain.Job)
 	log.Info(ctx, "successfully got all job tasks", logData)
 
 	for _, task := range tasks {
-		err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateApproved)
+		err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateApproved, "")
 		if err != nil {
 			log.Error(ctx, "failed to update task state", err, logData)
 			return err
@@ -159,7 +159,7 @@ func (e *StaticDatasetJobExecutor) PostPublish(ctx context.Context, job *domain.
 	log.Info(ctx, "successfully got all job tasks", logData)
 
 	for _, task := range publishedTasks {
-		err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StatePendingPostPublish)
+		err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StatePendingPostPublish, "")
 		if err != nil {
 			log.Error(ctx, "failed to update task state", err, logData)
 			return err
@@ -210,7 +210,7 @@ func (e *StaticDatasetJobExecutor) Revert(ctx context.Context, job *domain.Job)
 	log.Info(ctx, "successfully got all job tasks", logData)
 
 	for _, task := range tasks {
-		err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateRejected)
+		err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateRejected, "")
 		if err != nil {
 			log.Error(ctx, "failed to update task state", err, logData)
 			return err
@@ -41,10 +41,10 @@ func TestJobStaticDataset(t *testing.T) {
 					{ID: "task-2", State: domain.StateInReview},
 				}, 2, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return nil
 			},
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID string) error {
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 		}
@@ -167,7 +167,7 @@ func TestJobStaticDataset(t *testing.T) {
 			CreateTaskFunc: func(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error) {
 				return nil, errTest
 			},
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID string) error {
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 			UpdateJobCollectionIDFunc: func(ctx context.Context, jobNumber int, collectionID string) error {
@@ -210,7 +210,7 @@ func TestJobStaticDataset(t *testing.T) {
 			CreateTaskFunc: func(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error) {
 				return &domain.Task{}, nil
 			},
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID string) error {
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 		}
@@ -323,7 +323,7 @@ func TestJobStaticDataset(t *testing.T) {
 					{ID: "task-1", State: domain.StateInReview},
 				}, 1, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return nil
 			},
 		}
@@ -437,7 +437,7 @@ func TestJobStaticDataset(t *testing.T) {
 					{ID: "task-2", State: domain.StateInReview},
 				}, 2, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return errTest
 			},
 		}
@@ -562,7 +562,7 @@ func TestJobStaticDataset(t *testing.T) {
 					{ID: "task-2", State: domain.StatePublished},
 				}, 2, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return nil
 			},
 		}
@@ -616,7 +616,7 @@ func TestJobStaticDataset(t *testing.T) {
 					},
 				}, 1, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 
 		executor := NewStaticDatasetJobExecutor(
@@ -671,7 +671,7 @@ func TestJobStaticDataset(t *testing.T) {
 					},
 				}, 2, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 
 		mockZebedeeClient := &clientMocks.ZebedeeClientMock{
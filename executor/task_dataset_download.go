@@ -6,8 +6,6 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/ONSdigital/dis-migration-service/application"
@@ -123,7 +121,7 @@ func (e *DatasetDownloadTaskExecutor) Migrate(ctx context.Context, task *domain.
 	}
 
 	// Update task state to in review
-	err = e.jobService.UpdateTaskState(ctx, task.ID, domain.StateInReview)
+	err = e.jobService.UpdateTaskState(ctx, task.ID, domain.StateInReview, "")
 	if err != nil {
 		log.Error(ctx, "failed to update migration task", err, logData)
 		return err
@@ -139,7 +137,7 @@ func (e *DatasetDownloadTaskExecutor) Publish(ctx context.Context, task *domain.
 	logData := log.Data{"task_id": task.ID, "job_number": task.JobNumber}
 	log.Info(ctx, "starting publish for dataset download task", logData)
 
-	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StatePublished)
+	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StatePublished, "")
 	if err != nil {
 		log.Error(ctx, "failed to update publish task", err, logData)
 		return err
@@ -154,7 +152,7 @@ func (e *DatasetDownloadTaskExecutor) PostPublish(ctx context.Context, task *dom
 	logData := log.Data{"task_id": task.ID, "job_number": task.JobNumber}
 	log.Info(ctx, "updating task state to completed", logData)
 
-	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCompleted)
+	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCompleted, "")
 	if err != nil {
 		log.Error(ctx, "failed to update task state to completed", err, logData)
 		return err
@@ -181,7 +179,7 @@ func (e *DatasetDownloadTaskExecutor) Revert(ctx context.Context, task *domain.T
 
 	log.Info(ctx, "starting revert for dataset download task", logData)
 
-	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCancelled)
+	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCancelled, "")
 	if err != nil {
 		log.Error(ctx, "failed to update migration task", err, logData)
 		return err
@@ -311,12 +309,11 @@ func (e *DatasetDownloadTaskExecutor) applyDistributionUpdate(ctx context.Contex
 	}
 }
 
-// isConflictError checks if the error is an HTTP 409 Conflict.
+// isConflictError checks if the error is an HTTP 409 Conflict. Errors
+// that do not carry a status code are never conflicts.
 func isConflictError(err error) bool {
-	if err == nil {
-		return false
-	}
-	return strings.Contains(err.Error(), strconv.Itoa(http.StatusConflict))
+	code, ok := clients.StatusCode(err)
+	return ok && code == http.StatusConflict
 }
 
 // findDistributionIndexByTitle finds the index of a distribution in the slice
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
 	"testing"
 
 	applicationMocks "github.com/ONSdigital/dis-migration-service/application/mock"
@@ -48,7 +50,7 @@ var (
 func TestDatasetDownloadTaskExecutor(t *testing.T) {
 	Convey("Given a dataset download task executor when revert is called", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 
 		executor := NewDatasetDownloadTaskExecutor(
@@ -72,7 +74,7 @@ func TestDatasetDownloadTaskExecutor(t *testing.T) {
 			CreateTaskFunc: func(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error) {
 				return &domain.Task{}, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 			UpdateTaskFunc:      func(ctx context.Context, task *domain.Task) error { return nil },
 		}
 
@@ -407,7 +409,7 @@ func TestDatasetDownloadTaskExecutor(t *testing.T) {
 				return &domain.Task{}, nil
 			},
 			UpdateTaskFunc: func(ctx context.Context, task *domain.Task) error { return nil },
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return errors.New("failed to update task")
 			},
 		}
@@ -452,7 +454,7 @@ func TestDatasetDownloadTaskExecutor(t *testing.T) {
 
 	Convey("Given a dataset download task executor and a dataset client that returns a 409 Conflict then succeeds", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 		putVersionCalls := 0
 
@@ -466,7 +468,7 @@ func TestDatasetDownloadTaskExecutor(t *testing.T) {
 				PutVersionFunc: func(ctx context.Context, headers sdk.Headers, datasetID, editionID, versionID string, version datasetModels.Version) (datasetModels.Version, error) {
 					putVersionCalls++
 					if putVersionCalls == 1 {
-						return datasetModels.Version{}, errors.New("Etag conflict, received status 409")
+						return datasetModels.Version{}, statusCodeError{code: http.StatusConflict}
 					}
 					return datasetModels.Version{}, nil
 				},
@@ -498,4 +500,91 @@ func TestDatasetDownloadTaskExecutor(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a dataset download task executor and a dataset client that returns an error without a status code mentioning 409", t, func() {
+		mockJobService := &applicationMocks.JobServiceMock{
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
+		}
+		putVersionCalls := 0
+
+		mockClientList := &clients.ClientList{
+			DatasetAPI: &datasetSDKMock.ClienterMock{
+				GetVersionWithHeadersFunc: func(ctx context.Context, headers sdk.Headers, datasetID, edition, version string) (datasetModels.Version, sdk.ResponseHeaders, error) {
+					return datasetModels.Version{
+						Distributions: &[]datasetModels.Distribution{},
+					}, sdk.ResponseHeaders{ETag: "etag-1"}, nil
+				},
+				PutVersionFunc: func(ctx context.Context, headers sdk.Headers, datasetID, editionID, versionID string, version datasetModels.Version) (datasetModels.Version, error) {
+					putVersionCalls++
+					return datasetModels.Version{}, errors.New("failed to write 4096 bytes to /datasets/cpih01/editions/time-series/versions/409")
+				},
+			},
+			UploadService: &uploadSDKMock.ClienterMock{
+				UploadFunc: func(ctx context.Context, fileContent io.ReadCloser, metadata api.Metadata, headers uploadSDK.Headers) error {
+					return nil
+				},
+			},
+			Zebedee: &clientMocks.ZebedeeClientMock{
+				GetResourceStreamFunc: func(ctx context.Context, userAuthToken, collectionID, lang, path string) (io.ReadCloser, error) {
+					return io.NopCloser(bytes.NewReader([]byte(testFileData))), nil
+				},
+				GetFileSizeFunc: func(ctx context.Context, userAccessToken, collectionID, lang, uri string) (zebedee.FileSize, error) {
+					return zebedee.FileSize{Size: len(testFileData)}, nil
+				},
+			},
+		}
+
+		ctx := context.Background()
+
+		executor := NewDatasetDownloadTaskExecutor(mockJobService, mockClientList, testServiceAuthToken)
+
+		Convey("When migrate is called for a task", func() {
+			err := executor.Migrate(ctx, testDownloadTask)
+
+			Convey("Then it is not treated as a conflict and is not retried", func() {
+				So(err, ShouldNotBeNil)
+				So(putVersionCalls, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestIsConflictError(t *testing.T) {
+	Convey("Given an error with a 409 status code", t, func() {
+		err := statusCodeError{code: http.StatusConflict}
+
+		Convey("Then it is a conflict", func() {
+			So(isConflictError(err), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given an error with another status code", t, func() {
+		err := statusCodeError{code: http.StatusBadRequest}
+
+		Convey("Then it is not a conflict", func() {
+			So(isConflictError(err), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given an error without a status code whose message contains 409", t, func() {
+		err := errors.New("Etag conflict, received status 409")
+
+		Convey("Then it is not a conflict", func() {
+			So(isConflictError(err), ShouldBeFalse)
+		})
+	})
+}
+
+// statusCodeError is an error carrying the status code of the response
+// that caused it
+type statusCodeError struct {
+	code int
+}
+
+func (e statusCodeError) Error() string {
+	return fmt.Sprintf("received status %d", e.code)
+}
+
+func (e statusCodeError) Code() int {
+	return e.code
 }
@@ -68,17 +68,15 @@ func (e *DatasetEditionTaskExecutor) Migrate(ctx context.Context, task *domain.T
 		return err
 	}
 
-	currentVersionTask := createVersionTask(task.JobNumber, sourceData.URI, task.Source.DatasetID, task.Source.ID, task.Target.DatasetID, editionID)
-
-	_, err = e.jobService.CreateTask(ctx, task.JobNumber, &currentVersionTask)
-	if err != nil {
-		logData["version_uri"] = currentVersionTask.Source.ID
-		log.Error(ctx, "failed to create migration version task for edition", err, logData)
-		return err
-	}
+	// Versions are migrated in order, oldest first, so each version task
+	// depends on the task for the version before it. The current version
+	// is the latest.
+	var previousVersionTaskID string
 
 	for _, previousVersion := range sourceData.Versions {
 		versionTask := createVersionTask(task.JobNumber, previousVersion.URI, task.Source.DatasetID, task.Source.ID, task.Target.DatasetID, editionID)
+		versionTask.SetParent(task)
+		versionTask.DependsOn = dependsOn(previousVersionTaskID)
 
 		_, err := e.jobService.CreateTask(ctx, task.JobNumber, &versionTask)
 		if err != nil {
@@ -86,9 +84,21 @@ func (e *DatasetEditionTaskExecutor) Migrate(ctx context.Context, task *domain.T
 			log.Error(ctx, "failed to create migration task for previous version of edition", err, logData)
 			return err
 		}
+		previousVersionTaskID = versionTask.ID
+	}
+
+	currentVersionTask := createVersionTask(task.JobNumber, sourceData.URI, task.Source.DatasetID, task.Source.ID, task.Target.DatasetID, editionID)
+	currentVersionTask.SetParent(task)
+	currentVersionTask.DependsOn = dependsOn(previousVersionTaskID)
+
+	_, err = e.jobService.CreateTask(ctx, task.JobNumber, &currentVersionTask)
+	if err != nil {
+		logData["version_uri"] = currentVersionTask.Source.ID
+		log.Error(ctx, "failed to create migration version task for edition", err, logData)
+		return err
 	}
 
-	err = e.jobService.UpdateTaskState(ctx, task.ID, domain.StateInReview)
+	err = e.jobService.UpdateTaskState(ctx, task.ID, domain.StateInReview, "")
 	if err != nil {
 		log.Error(ctx, "failed to update migration task", err, logData)
 		return err
@@ -103,7 +113,7 @@ func (e *DatasetEditionTaskExecutor) Publish(ctx context.Context, task *domain.T
 	logData := log.Data{"task_id": task.ID, "job_number": task.JobNumber}
 	log.Info(ctx, "starting publish for dataset edition task", logData)
 
-	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StatePublished)
+	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StatePublished, "")
 	if err != nil {
 		log.Error(ctx, "failed to update publish task", err, logData)
 		return err
@@ -118,7 +128,7 @@ func (e *DatasetEditionTaskExecutor) PostPublish(ctx context.Context, task *doma
 	logData := log.Data{"task_id": task.ID, "job_number": task.JobNumber}
 	log.Info(ctx, "updating task state to completed", logData)
 
-	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCompleted)
+	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCompleted, "")
 	if err != nil {
 		log.Error(ctx, "failed to update task state to completed", err, logData)
 		return err
@@ -133,7 +143,7 @@ func (e *DatasetEditionTaskExecutor) Revert(ctx context.Context, task *domain.Ta
 
 	log.Info(ctx, "starting reversion for dataset edition task", logData)
 
-	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCancelled)
+	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCancelled, "")
 	if err != nil {
 		log.Error(ctx, "failed to update migration task", err, logData)
 		return err
@@ -158,3 +168,12 @@ func createVersionTask(jobNumber int, sourceURI, sourceDatasetID, sourceEditionI
 	}
 	return versionTask
 }
+
+// dependsOn returns the dependencies of a task that must run after the task
+// with the provided ID, if there is one
+func dependsOn(taskID string) []string {
+	if taskID == "" {
+		return nil
+	}
+	return []string{taskID}
+}
@@ -46,7 +46,7 @@ func TestDatasetEditionTaskExecutor(t *testing.T) {
 			CreateTaskFunc: func(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error) {
 				return &domain.Task{}, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 			UpdateTaskFunc:      func(ctx context.Context, task *domain.Task) error { return nil },
 		}
 
@@ -77,6 +77,10 @@ func TestDatasetEditionTaskExecutor(t *testing.T) {
 					So(mockJobService.CreateTaskCalls()[0].Task.Source.ID, ShouldEqual, testEditionURI)
 					So(mockJobService.CreateTaskCalls()[0].Task.Target.DatasetID, ShouldEqual, testDatasetSeriesID)
 					So(mockJobService.CreateTaskCalls()[0].Task.Target.EditionID, ShouldEqual, testEditionID)
+					So(mockJobService.CreateTaskCalls()[0].Task.ParentTaskID, ShouldEqual, testEditionTaskID)
+					So(mockJobService.CreateTaskCalls()[0].Task.Depth, ShouldEqual, 1)
+					So(mockJobService.CreateTaskCalls()[0].Task.Path, ShouldResemble, []string{testEditionTaskID})
+					So(mockJobService.CreateTaskCalls()[0].Task.DependsOn, ShouldBeEmpty)
 
 					Convey("And the task is updated", func() {
 						So(len(mockJobService.UpdateTaskCalls()), ShouldEqual, 1)
@@ -123,7 +127,7 @@ func TestDatasetEditionTaskExecutor(t *testing.T) {
 			CreateTaskFunc: func(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error) {
 				return &domain.Task{}, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 			UpdateTaskFunc:      func(ctx context.Context, task *domain.Task) error { return nil },
 		}
 
@@ -156,23 +160,29 @@ func TestDatasetEditionTaskExecutor(t *testing.T) {
 			Convey("Then no error is returned", func() {
 				So(err, ShouldBeNil)
 
-				Convey("And version tasks are created", func() {
+				Convey("And version tasks are created, oldest first", func() {
 					So(len(mockJobService.CreateTaskCalls()), ShouldEqual, 3)
 					So(mockJobService.CreateTaskCalls()[0].Task.Type, ShouldEqual, domain.TaskTypeDatasetVersion)
-					So(mockJobService.CreateTaskCalls()[0].Task.Source.ID, ShouldEqual, testEditionURI)
+					So(mockJobService.CreateTaskCalls()[0].Task.Source.ID, ShouldEqual, generatePreviousVersionURI(testEditionURI, 1))
 					So(mockJobService.CreateTaskCalls()[0].Task.Target.DatasetID, ShouldEqual, testDatasetSeriesID)
 					So(mockJobService.CreateTaskCalls()[0].Task.Target.EditionID, ShouldEqual, testEditionID)
 
 					So(mockJobService.CreateTaskCalls()[1].Task.Type, ShouldEqual, domain.TaskTypeDatasetVersion)
-					So(mockJobService.CreateTaskCalls()[1].Task.Source.ID, ShouldEqual, generatePreviousVersionURI(testEditionURI, 1))
+					So(mockJobService.CreateTaskCalls()[1].Task.Source.ID, ShouldEqual, generatePreviousVersionURI(testEditionURI, 2))
 					So(mockJobService.CreateTaskCalls()[1].Task.Target.DatasetID, ShouldEqual, testDatasetSeriesID)
 					So(mockJobService.CreateTaskCalls()[1].Task.Target.EditionID, ShouldEqual, testEditionID)
 
 					So(mockJobService.CreateTaskCalls()[2].Task.Type, ShouldEqual, domain.TaskTypeDatasetVersion)
-					So(mockJobService.CreateTaskCalls()[2].Task.Source.ID, ShouldEqual, generatePreviousVersionURI(testEditionURI, 2))
+					So(mockJobService.CreateTaskCalls()[2].Task.Source.ID, ShouldEqual, testEditionURI)
 					So(mockJobService.CreateTaskCalls()[2].Task.Target.DatasetID, ShouldEqual, testDatasetSeriesID)
 					So(mockJobService.CreateTaskCalls()[2].Task.Target.EditionID, ShouldEqual, testEditionID)
 
+					Convey("And each version task depends on the one before it", func() {
+						So(mockJobService.CreateTaskCalls()[0].Task.DependsOn, ShouldBeEmpty)
+						So(mockJobService.CreateTaskCalls()[1].Task.DependsOn, ShouldResemble, []string{mockJobService.CreateTaskCalls()[0].Task.ID})
+						So(mockJobService.CreateTaskCalls()[2].Task.DependsOn, ShouldResemble, []string{mockJobService.CreateTaskCalls()[1].Task.ID})
+					})
+
 					Convey("And the task is updated", func() {
 						So(len(mockJobService.UpdateTaskCalls()), ShouldEqual, 1)
 						So(mockJobService.UpdateTaskCalls()[0].Task.Target.ID, ShouldEqual, testEditionID)
@@ -255,7 +265,7 @@ func TestDatasetEditionTaskExecutor(t *testing.T) {
 				return &domain.Task{}, nil
 			},
 			UpdateTaskFunc: func(ctx context.Context, task *domain.Task) error { return nil },
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return errors.New("failed to update task")
 			},
 		}
@@ -295,7 +305,7 @@ func TestDatasetEditionTaskExecutor(t *testing.T) {
 				return nil, errors.New("failed to create task")
 			},
 			UpdateTaskFunc:      func(ctx context.Context, task *domain.Task) error { return nil },
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 		mockClientList := &clients.ClientList{
 			DatasetAPI: &datasetSDKMock.ClienterMock{
@@ -342,7 +352,7 @@ func TestDatasetEditionTaskExecutor(t *testing.T) {
 				return &domain.Task{}, nil
 			},
 			UpdateTaskFunc:      func(ctx context.Context, task *domain.Task) error { return nil },
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 
 		mockClientList := &clients.ClientList{
@@ -115,6 +115,7 @@ func (e *DatasetSeriesTaskExecutor) Migrate(ctx context.Context, task *domain.Ta
 
 	for _, edition := range sourceData.Datasets {
 		editionTask := domain.NewTask(task.JobNumber)
+		editionTask.SetParent(task)
 
 		editionTask.Type = domain.TaskTypeDatasetEdition
 		editionTask.Source = &domain.TaskMetadata{
@@ -133,7 +134,7 @@ func (e *DatasetSeriesTaskExecutor) Migrate(ctx context.Context, task *domain.Ta
 		}
 	}
 
-	err = e.jobService.UpdateTaskState(ctx, task.ID, domain.StateInReview)
+	err = e.jobService.UpdateTaskState(ctx, task.ID, domain.StateInReview, "")
 	if err != nil {
 		log.Error(ctx, "failed to update migration task", err, logData)
 		return err
@@ -148,7 +149,7 @@ func (e *DatasetSeriesTaskExecutor) Publish(ctx context.Context, task *domain.Ta
 	logData := log.Data{"task_id": task.ID, "job_number": task.JobNumber}
 	log.Info(ctx, "starting publish for dataset series task", logData)
 
-	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StatePublished)
+	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StatePublished, "")
 	if err != nil {
 		log.Error(ctx, "failed to update publish task", err, logData)
 		return err
@@ -163,7 +164,7 @@ func (e *DatasetSeriesTaskExecutor) PostPublish(ctx context.Context, task *domai
 	logData := log.Data{"task_id": task.ID, "job_number": task.JobNumber}
 	log.Info(ctx, "updating task state to completed", logData)
 
-	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCompleted)
+	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCompleted, "")
 	if err != nil {
 		log.Error(ctx, "failed to update task state to completed", err, logData)
 		return err
@@ -182,7 +183,7 @@ func (e *DatasetSeriesTaskExecutor) Revert(ctx context.Context, task *domain.Tas
 		log.Error(ctx, "failed to delete dataset during task revert", err, logData)
 	}
 
-	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCancelled)
+	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCancelled, "")
 	if err != nil {
 		log.Error(ctx, "failed to update migration task", err, logData)
 		return err
@@ -54,7 +54,7 @@ func TestDatasetSeriesTaskExecutor(t *testing.T) {
 					},
 				}, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 		mockDatasetClient := &datasetSDKMock.ClienterMock{
 			CreateDatasetFunc: func(ctx context.Context, headers sdk.Headers, dataset models.Dataset) (models.DatasetUpdate, error) {
@@ -131,6 +131,14 @@ func TestDatasetSeriesTaskExecutor(t *testing.T) {
 						So(mockJobService.CreateTaskCalls()[1].Task.Source.ID, ShouldEqual, getEditionURI(testDatasetSeriesURI, "2022"))
 						So(mockJobService.CreateTaskCalls()[1].Task.Target.DatasetID, ShouldEqual, testDatasetSeriesID)
 
+						Convey("And each edition task records the series task as its parent", func() {
+							for _, call := range mockJobService.CreateTaskCalls() {
+								So(call.Task.ParentTaskID, ShouldEqual, testSeriesTask.ID)
+								So(call.Task.Depth, ShouldEqual, testSeriesTask.Depth+1)
+								So(call.Task.Path, ShouldResemble, append(testSeriesTask.Path, testSeriesTask.ID))
+							}
+						})
+
 						Convey("And the task state is updated to InReview", func() {
 							So(len(mockJobService.UpdateTaskStateCalls()), ShouldEqual, 1)
 							So(mockJobService.UpdateTaskStateCalls()[0].TaskID, ShouldEqual, testSeriesTask.ID)
@@ -461,7 +469,7 @@ func TestDatasetSeriesTaskExecutor(t *testing.T) {
 			CreateTaskFunc: func(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error) {
 				return &domain.Task{}, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return errTest
 			},
 			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
@@ -528,7 +536,7 @@ func TestDatasetSeriesTaskExecutor(t *testing.T) {
 					},
 				}, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 		mockClientList := &clients.ClientList{
 			DatasetAPI: &datasetSDKMock.ClienterMock{
@@ -599,7 +607,7 @@ func TestDatasetSeriesTaskExecutor(t *testing.T) {
 					},
 				}, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 		mockDatasetClient := &datasetSDKMock.ClienterMock{
 			CreateDatasetFunc: func(ctx context.Context, headers sdk.Headers, dataset models.Dataset) (models.DatasetUpdate, error) {
@@ -678,7 +686,7 @@ func TestDatasetSeriesTaskExecutor(t *testing.T) {
 					},
 				}, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 		mockDatasetClient := &datasetSDKMock.ClienterMock{
 			CreateDatasetFunc: func(ctx context.Context, headers sdk.Headers, dataset models.Dataset) (models.DatasetUpdate, error) {
@@ -723,7 +731,7 @@ func TestDatasetSeriesTaskExecutor(t *testing.T) {
 func TestDatasetSeriesTaskExecutor_Revert(t *testing.T) {
 	Convey("Given a dataset series task executor with a dataset API client that successfully deletes datasets", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return nil
 			},
 		}
@@ -764,7 +772,7 @@ func TestDatasetSeriesTaskExecutor_Revert(t *testing.T) {
 
 	Convey("Given a dataset series task executor with a dataset API client that fails to delete datasets", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return nil
 			},
 		}
@@ -802,7 +810,7 @@ func TestDatasetSeriesTaskExecutor_Revert(t *testing.T) {
 
 	Convey("Given a dataset series task executor with a jobService that fails to update task state", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return errTest
 			},
 		}
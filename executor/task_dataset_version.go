@@ -158,9 +158,12 @@ func (e *DatasetVersionTaskExecutor) Migrate(ctx context.Context, task *domain.T
 		return err
 	}
 
-	// Create download tasks for each download in the source data
+	// Create download tasks for each download in the source data. Each
+	// waits for this task, so that its version exists when it runs.
 	for _, download := range sourceData.Downloads {
 		downloadTask := domain.NewTask(task.JobNumber)
+		downloadTask.SetParent(task)
+		downloadTask.DependsOn = dependsOn(task.ID)
 
 		downloadTask.Type = domain.TaskTypeDatasetDownload
 		downloadTask.Source = &domain.TaskMetadata{
@@ -181,7 +184,7 @@ func (e *DatasetVersionTaskExecutor) Migrate(ctx context.Context, task *domain.T
 	}
 
 	// Mark task as complete
-	err = e.jobService.UpdateTaskState(ctx, task.ID, domain.StateInReview)
+	err = e.jobService.UpdateTaskState(ctx, task.ID, domain.StateInReview, "")
 	if err != nil {
 		log.Error(ctx, "failed to update migration task", err, logData)
 		return err
@@ -238,7 +241,7 @@ func (e *DatasetVersionTaskExecutor) Publish(ctx context.Context, task *domain.T
 	}
 
 	// Mark task as complete
-	err = e.jobService.UpdateTaskState(ctx, task.ID, domain.StatePublished)
+	err = e.jobService.UpdateTaskState(ctx, task.ID, domain.StatePublished, "")
 	if err != nil {
 		log.Error(ctx, "failed to update migration task", err, logData)
 		return err
@@ -253,7 +256,7 @@ func (e *DatasetVersionTaskExecutor) PostPublish(ctx context.Context, task *doma
 	logData := log.Data{"task_id": task.ID, "job_number": task.JobNumber}
 	log.Info(ctx, "updating task state to completed", logData)
 
-	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCompleted)
+	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCompleted, "")
 	if err != nil {
 		log.Error(ctx, "failed to update task state to completed", err, logData)
 		return err
@@ -268,7 +271,7 @@ func (e *DatasetVersionTaskExecutor) Revert(ctx context.Context, task *domain.Ta
 
 	log.Info(ctx, "starting reversion for dataset version task", logData)
 
-	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCancelled)
+	err := e.jobService.UpdateTaskState(ctx, task.ID, domain.StateCancelled, "")
 	if err != nil {
 		log.Error(ctx, "failed to update migration task", err, logData)
 		return err
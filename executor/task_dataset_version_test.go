@@ -50,7 +50,7 @@ func TestDatasetVersionTaskExecutorMigrate(t *testing.T) {
 					},
 				}, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 			UpdateTaskFunc:      func(ctx context.Context, task *domain.Task) error { return nil },
 		}
 
@@ -186,6 +186,9 @@ func TestDatasetVersionTaskExecutorMigrate(t *testing.T) {
 						So(mockJobService.CreateTaskCalls()[1].Task.Target.DatasetID, ShouldEqual, testDatasetSeriesID)
 						So(mockJobService.CreateTaskCalls()[1].Task.Target.EditionID, ShouldEqual, testEditionID)
 						So(mockJobService.CreateTaskCalls()[1].Task.Target.VersionID, ShouldEqual, "1")
+						So(mockJobService.CreateTaskCalls()[1].Task.ParentTaskID, ShouldEqual, testVersionTask.ID)
+						So(mockJobService.CreateTaskCalls()[1].Task.Depth, ShouldEqual, testVersionTask.Depth+1)
+						So(mockJobService.CreateTaskCalls()[1].Task.DependsOn, ShouldResemble, []string{testVersionTask.ID})
 
 						Convey("And the task is updated", func() {
 							So(len(mockJobService.UpdateTaskCalls()), ShouldEqual, 1)
@@ -660,7 +663,7 @@ func TestDatasetVersionTaskExecutorMigrate(t *testing.T) {
 				}, nil
 			},
 			UpdateTaskFunc: func(ctx context.Context, task *domain.Task) error { return nil },
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return errTest
 			},
 		}
@@ -728,7 +731,7 @@ func TestDatasetVersionTaskExecutorMigrate(t *testing.T) {
 				}, nil
 			},
 			UpdateTaskFunc:      func(ctx context.Context, task *domain.Task) error { return nil },
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 		mockClientList := &clients.ClientList{
 			DatasetAPI: &datasetSDKMock.ClienterMock{
@@ -789,7 +792,7 @@ func TestDatasetVersionTaskExecutorMigrate(t *testing.T) {
 func TestDatasetVersionTaskExecutorPublish(t *testing.T) {
 	Convey("Given a dataset version task executor and a dataset API client that succeeds on first poll", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 
 		mockDatasetClient := &datasetSDKMock.ClienterMock{
@@ -823,7 +826,7 @@ func TestDatasetVersionTaskExecutorPublish(t *testing.T) {
 
 	Convey("Given a dataset version task executor and a dataset API client that succeeds on the 3rd poll", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return nil },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return nil },
 		}
 
 		callCount := 0
@@ -931,7 +934,7 @@ func TestDatasetVersionTaskExecutorPublish(t *testing.T) {
 
 	Convey("Given a dataset version task executor and a store that fails to update task state", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error { return errTest },
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error { return errTest },
 		}
 		mockDatasetClient := &datasetSDKMock.ClienterMock{
 			PutVersionStateFunc: func(ctx context.Context, headers sdk.Headers, datasetID, editionID, versionID, state string) error {
@@ -961,7 +964,7 @@ func TestDatasetVersionTaskExecutorPublish(t *testing.T) {
 func TestDatasetVersionTaskExecutorPostPublish(t *testing.T) {
 	Convey("Given a job service that does not error", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return nil
 			},
 		}
@@ -987,7 +990,7 @@ func TestDatasetVersionTaskExecutorPostPublish(t *testing.T) {
 
 	Convey("Given a job service that errors when updating task state", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return errTest
 			},
 		}
@@ -0,0 +1,140 @@
+@Migrator @ClaimJobs
+Feature: Claim approved migration jobs for publishing
+
+  Background:
+    Given an admin user has the "migrations:edit" permission
+    And I am an admin user
+
+  Scenario: A job scheduled for publishing is not published before its publish time
+    Given the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-50",
+        "job_number": 50,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "approved",
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-scheduled-dataset",
+          "target_id": "test-scheduled-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-scheduled",
+        "job_number": 50,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "in_review",
+        "type": "dataset_series"
+      }
+      """
+    And job 50 is scheduled to publish 60 minutes from now
+    When the migration service is running
+    And I wait 3 seconds for the job processor to process tasks and jobs
+    Then job 50 should be in the "approved" state
+    And task "task-scheduled" should be in the "in_review" state
+    And no tasks should have been claimed from the "approved" state
+
+  Scenario: A job scheduled for publishing is published once its publish time has passed
+    Given the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-51",
+        "job_number": 51,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "approved",
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-scheduled-dataset",
+          "target_id": "test-scheduled-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-scheduled",
+        "job_number": 51,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "in_review",
+        "type": "dataset_series"
+      }
+      """
+    And job 51 is scheduled to publish 1 minute ago
+    When the migration service is running
+    And I wait 5 seconds for the job processor to process tasks and jobs
+    Then job 51 should be in the "completed" state
+    And the tasks claimed from the "approved" state should have been, in order:
+      | task-scheduled |
+
+  Scenario: Jobs in a release group are not published until every job in the group is approved
+    Given the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-60",
+        "job_number": 60,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "approved",
+        "release_group_id": "release-group-1",
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-first-grouped-dataset",
+          "target_id": "test-first-grouped-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-61",
+        "job_number": 61,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "in_review",
+        "release_group_id": "release-group-1",
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-second-grouped-dataset",
+          "target_id": "test-second-grouped-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-first-grouped",
+        "job_number": 60,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "in_review",
+        "type": "dataset_series"
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-second-grouped",
+        "job_number": 61,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "in_review",
+        "type": "dataset_series"
+      }
+      """
+    And the migration service is running
+    And I wait 2 seconds for the job processor to process tasks and jobs
+    Then job 60 should be in the "approved" state
+    And no tasks should have been claimed from the "approved" state
+    When I PUT "/v1/migration-jobs/61/state"
+      """
+      {
+        "state": "approved"
+      }
+      """
+    Then the HTTP status code should be "204"
+    And I wait 8 seconds for the job processor to process tasks and jobs
+    And job 60 should be in the "completed" state
+    And job 61 should be in the "completed" state
@@ -0,0 +1,371 @@
+@Migrator @ClaimTasks
+Feature: Claim migration tasks in order
+
+  Scenario: Tasks of higher priority jobs are claimed first
+    Given the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-1",
+        "job_number": 1,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "publishing",
+        "priority": 0,
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-low-priority-dataset",
+          "target_id": "test-low-priority-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-2",
+        "job_number": 2,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "publishing",
+        "priority": 10,
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-high-priority-dataset",
+          "target_id": "test-high-priority-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-3",
+        "job_number": 3,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "publishing",
+        "priority": 5,
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-medium-priority-dataset",
+          "target_id": "test-medium-priority-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-low",
+        "job_number": 1,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "approved",
+        "type": "dataset_series",
+        "priority": 0
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-high",
+        "job_number": 2,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "approved",
+        "type": "dataset_series",
+        "priority": 10
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-medium",
+        "job_number": 3,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "approved",
+        "type": "dataset_series",
+        "priority": 5
+      }
+      """
+    When the migration service is running
+    And I wait 3 seconds for the job processor to process tasks and jobs
+    Then the tasks claimed from the "approved" state should have been, in order:
+      | task-high   |
+      | task-medium |
+      | task-low    |
+
+  Scenario: Tasks created before priorities were added are claimed with the default priority
+    Given the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-1",
+        "job_number": 1,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "publishing",
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-legacy-dataset",
+          "target_id": "test-legacy-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-2",
+        "job_number": 2,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "publishing",
+        "priority": 0,
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-default-priority-dataset",
+          "target_id": "test-default-priority-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-3",
+        "job_number": 3,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "publishing",
+        "priority": 1,
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-raised-priority-dataset",
+          "target_id": "test-raised-priority-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-legacy",
+        "job_number": 1,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "approved",
+        "type": "dataset_series"
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-default",
+        "job_number": 2,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "approved",
+        "type": "dataset_series",
+        "priority": 0
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-raised",
+        "job_number": 3,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "approved",
+        "type": "dataset_series",
+        "priority": 1
+      }
+      """
+    When the migration service is running
+    And I wait 3 seconds for the job processor to process tasks and jobs
+    Then the tasks claimed from the "approved" state should have been, in order:
+      | task-raised  |
+      | task-legacy  |
+      | task-default |
+
+  Scenario: Jobs of the same priority take turns to have their tasks claimed
+    Given the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-1",
+        "job_number": 1,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "publishing",
+        "priority": 0,
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-first-dataset",
+          "target_id": "test-first-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-2",
+        "job_number": 2,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "publishing",
+        "priority": 0,
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-second-dataset",
+          "target_id": "test-second-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-3",
+        "job_number": 3,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "publishing",
+        "priority": 0,
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-third-dataset",
+          "target_id": "test-third-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-1-first",
+        "job_number": 1,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "approved",
+        "type": "dataset_series",
+        "priority": 0
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-1-second",
+        "job_number": 1,
+        "last_updated": "2025-11-19T13:31:00Z",
+        "state": "approved",
+        "type": "dataset_edition",
+        "priority": 0
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-2-first",
+        "job_number": 2,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "approved",
+        "type": "dataset_series",
+        "priority": 0
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-2-second",
+        "job_number": 2,
+        "last_updated": "2025-11-19T13:31:00Z",
+        "state": "approved",
+        "type": "dataset_edition",
+        "priority": 0
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-3-first",
+        "job_number": 3,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "approved",
+        "type": "dataset_series",
+        "priority": 0
+      }
+      """
+    When the migration service is running
+    And I wait 3 seconds for the job processor to process tasks and jobs
+    Then the tasks claimed from the "approved" state should have been, in order:
+      | task-1-first  |
+      | task-2-first  |
+      | task-3-first  |
+      | task-1-second |
+      | task-2-second |
+
+  Scenario: A task is not claimed until the task it depends on has reached each stage
+    Given the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-40",
+        "job_number": 40,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "approved",
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-dependent-dataset",
+          "target_id": "test-dependent-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-edition",
+        "job_number": 40,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "in_review",
+        "type": "dataset_edition",
+        "depends_on": ["task-series"]
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-series",
+        "job_number": 40,
+        "last_updated": "2025-11-19T13:31:00Z",
+        "state": "in_review",
+        "type": "dataset_series"
+      }
+      """
+    When the migration service is running
+    And I wait 5 seconds for the job processor to process tasks and jobs
+    Then the tasks claimed from the "approved" state should have been, in order:
+      | task-series  |
+      | task-edition |
+    And the tasks claimed from the "pending_post_publish" state should have been, in order:
+      | task-series  |
+      | task-edition |
+    And job 40 should be in the "completed" state
+
+  Scenario: A task that depends on a task that does not exist fails its job
+    Given the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "job-41",
+        "job_number": 41,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "approved",
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-missing-dependency-dataset",
+          "target_id": "test-missing-dependency-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-edition",
+        "job_number": 41,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "in_review",
+        "type": "dataset_edition",
+        "depends_on": ["task-missing"]
+      }
+      """
+    When the migration service is running
+    And I wait 3 seconds for the job processor to process tasks and jobs
+    Then job 41 should be in the "failed_publish" state
+    And task "task-edition" should be in the "in_review" state
+    And no tasks should have been claimed from the "approved" state
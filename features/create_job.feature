@@ -323,6 +323,101 @@ Feature: Create a Job
         """
 
     @StoreError
+    Scenario: Retry a job creation request with the same idempotency key
+      Given a get page data request to zebedee for "/test-source-id" returns with status 200 and payload:
+        """
+        {
+          "type": "dataset_landing_page",
+          "description": {
+            "title": "Test Dataset Series"
+          }
+        }
+        """
+      And a get dataset request to the dataset API for "test-target-id" returns with status 404
+      And I set the "Idempotency-Key" header to "test-idempotency-key"
+      And I POST "/v1/migration-jobs"
+        """
+        {
+          "source_id": "/test-source-id",
+          "target_id": "test-target-id",
+          "type": "static_dataset"
+        }
+        """
+      When I POST "/v1/migration-jobs"
+        """
+        {
+          "source_id": "/test-source-id",
+          "target_id": "test-target-id",
+          "type": "static_dataset"
+        }
+        """
+      Then I should receive the following JSON response with status "202":
+        """
+        {
+          "id": "{{DYNAMIC_UUID}}",
+          "job_number":1,
+          "last_updated": "{{DYNAMIC_RECENT_TIMESTAMP}}",
+          "label": "Test Dataset Series",
+          "state": "submitted",
+          "config": {
+            "source_id": "/test-source-id",
+            "target_id": "test-target-id",
+            "type": "static_dataset"
+          },
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/1"
+            },
+            "tasks": {
+              "href": "/v1/migration-jobs/1/tasks"
+            },
+            "events": {
+              "href": "/v1/migration-jobs/1/events"
+            }
+          }
+        }
+        """
+
+    Scenario: Reuse an idempotency key for a different job creation request
+      Given a get page data request to zebedee for "/test-source-id" returns with status 200 and payload:
+        """
+        {
+          "type": "dataset_landing_page",
+          "description": {
+            "title": "Test Dataset Series"
+          }
+        }
+        """
+      And a get dataset request to the dataset API for "test-target-id" returns with status 404
+      And I set the "Idempotency-Key" header to "test-idempotency-key"
+      And I POST "/v1/migration-jobs"
+        """
+        {
+          "source_id": "/test-source-id",
+          "target_id": "test-target-id",
+          "type": "static_dataset"
+        }
+        """
+      When I POST "/v1/migration-jobs"
+        """
+        {
+          "source_id": "/test-source-id",
+          "target_id": "another-test-target-id",
+          "type": "static_dataset"
+        }
+        """
+      Then I should receive the following JSON response with status "422":
+        """
+        {
+          "errors": [
+            {
+              "code": 422,
+              "description": "idempotency key has already been used for a different request"
+            }
+          ]
+        }
+        """
+
     Scenario: Create a job which is already running
       Given the following document exists in the "jobs" collection:
         """
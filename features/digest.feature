@@ -0,0 +1,67 @@
+@Digest
+Feature: Migration digest
+
+  Rule: User that is authorised to read migrations
+    Background:
+      Given an admin user has the "migrations:read" permission
+      And I am an admin user
+      And the migration service is running
+      And the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "2874ee9e-1cec-44f8-9b6d-998cf2062791",
+          "job_number": 12,
+          "label": "Labour Market statistics",
+          "last_updated": "2025-11-19T13:28:00Z",
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/12"
+            }
+          },
+          "state": "in_review",
+          "config": {
+            "source_id": "test-source-id",
+            "target_id": "test-target-id",
+            "type": "static_dataset"
+          }
+        }
+        """
+
+    Scenario: Preview the digest without sending it
+      When I GET "/v1/migration-digest"
+      Then the HTTP status code should be "200"
+      And no Slack notifications should have been sent
+
+  Rule: User that is authorised to administer migrations
+    Background:
+      Given an admin user has the "migrations:admin" permission
+      And I am an admin user
+      And the migration service is running
+      And the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "2874ee9e-1cec-44f8-9b6d-998cf2062791",
+          "job_number": 12,
+          "label": "Labour Market statistics",
+          "last_updated": "2025-11-19T13:28:00Z",
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/12"
+            }
+          },
+          "state": "in_review",
+          "config": {
+            "source_id": "test-source-id",
+            "target_id": "test-target-id",
+            "type": "static_dataset"
+          }
+        }
+        """
+
+    Scenario: Trigger the digest to be sent to Slack
+      When I POST "/v1/migration-digest"
+        """
+        """
+      Then the HTTP status code should be "200"
+      And a Slack info notification with summary "Daily migration digest" should have been sent
+      And a Slack info notification with detail "Jobs created" = 0 should have been sent
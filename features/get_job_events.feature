@@ -40,7 +40,7 @@ Feature: Get list of job events
           "_id": "event-123e4567-e89b-12d3-a456-426614174000",
           "job_number": 18,
           "created_at": "2025-11-19T13:30:00Z",
-          "action": "submitted",
+          "action": "job_created",
           "requested_by": {
             "id": "user-123",
             "email": "publisher@ons.gov.uk"
@@ -65,7 +65,7 @@ Feature: Get list of job events
               "id": "event-123e4567-e89b-12d3-a456-426614174000",
               "job_number": 18,
               "created_at": "2025-11-19T13:30:00Z",
-              "action": "submitted",
+              "action": "job_created",
               "requested_by": {
                 "id": "user-123",
                 "email": "publisher@ons.gov.uk"
@@ -179,6 +179,148 @@ Feature: Get list of job events
         }
         """
 
+    Scenario: Get a list of events filtered by action
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "2874ee9e-1cec-44f8-9b6d-998cf2062791",
+          "job_number": 18,
+          "last_updated": "2025-11-19T13:28:00Z",
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/18"
+            },
+            "events": {
+              "href": "/v1/migration-jobs/18/events"
+            },
+            "tasks": {
+              "href": "/v1/migration-jobs/18/tasks"
+            }
+          },
+          "state": "submitted",
+          "type": "static_dataset",
+          "config": {
+            "source_id": "test-source-id",
+            "target_id": "test-target-id",
+            "type": "static_dataset"
+          }
+        }
+        """
+      And the following document exists in the "events" collection:
+        """
+        {
+          "_id": "event-1",
+          "job_number": 18,
+          "created_at": "2025-11-19T13:30:00Z",
+          "action": "job_created",
+          "requested_by": {
+            "id": "user-123"
+          },
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/18/events/event-1"
+            },
+            "job": {
+              "href": "/v1/migration-jobs/18"
+            }
+          }
+        }
+        """
+      And the following document exists in the "events" collection:
+        """
+        {
+          "_id": "event-2",
+          "job_number": 18,
+          "created_at": "2025-11-19T13:35:00Z",
+          "action": "state_changed",
+          "from_state": "submitted",
+          "to_state": "approved",
+          "requested_by": {
+            "id": "user-123"
+          },
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/18/events/event-2"
+            },
+            "job": {
+              "href": "/v1/migration-jobs/18"
+            }
+          }
+        }
+        """
+      When I GET "/v1/migration-jobs/18/events?action=state_changed"
+      Then I should receive the following JSON response with status "200":
+        """
+        {
+          "count": 1,
+          "items": [
+            {
+              "id": "event-2",
+              "job_number": 18,
+              "created_at": "2025-11-19T13:35:00Z",
+              "action": "state_changed",
+              "from_state": "submitted",
+              "to_state": "approved",
+              "requested_by": {
+                "id": "user-123"
+              },
+              "links": {
+                "self": {
+                  "href": "/v1/migration-jobs/18/events/event-2"
+                },
+                "job": {
+                  "href": "/v1/migration-jobs/18"
+                }
+              }
+            }
+          ],
+          "limit": 10,
+          "offset": 0,
+          "total_count": 1
+        }
+        """
+
+    @InvalidInput
+    Scenario: Get a list of events with an invalid action filter
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "2874ee9e-1cec-44f8-9b6d-998cf2062791",
+          "job_number": 18,
+          "last_updated": "2025-11-19T13:28:00Z",
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/18"
+            },
+            "events": {
+              "href": "/v1/migration-jobs/18/events"
+            },
+            "tasks": {
+              "href": "/v1/migration-jobs/18/tasks"
+            }
+          },
+          "state": "submitted",
+          "type": "static_dataset",
+          "config": {
+            "source_id": "test-source-id",
+            "target_id": "test-target-id",
+            "type": "static_dataset"
+          }
+        }
+        """
+      When I GET "/v1/migration-jobs/18/events?action=approved"
+      Then I should receive the following JSON response with status "400":
+        """
+        {
+          "errors": [
+            {
+              "code": 400,
+              "description": "action parameter is invalid"
+            }
+          ]
+        }
+        """
+
     @InvalidInput
     Scenario: Get a list of events with an invalid job number
       When I GET "/v1/migration-jobs/invalid-job-number/events"
@@ -96,6 +96,122 @@ Feature: Get list of job tasks
         }
         """
 
+    Scenario: Get a list of tasks with their dependencies
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "6a1f4d2e-3b7c-4e8a-9f10-2c5d8e7b4a31",
+          "job_number": 9,
+          "last_updated": "2025-11-19T13:28:00Z",
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/9"
+            }
+          },
+          "state": "in_review",
+          "type": "static_dataset",
+          "config": {
+            "source_id": "test-source-id",
+            "target_id": "test-target-id",
+            "type": "static_dataset"
+          }
+        }
+        """
+      And the following document exists in the "tasks" collection:
+        """
+        {
+          "_id": "task-version-1",
+          "job_number": 9,
+          "last_updated": "2025-11-19T13:30:00Z",
+          "state": "in_review",
+          "type": "dataset_version",
+          "source": {
+            "id": "/economy/datasets/test/current/previous/v1"
+          },
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/9/tasks/task-version-1"
+            },
+            "job": {
+              "href": "/v1/migration-jobs/9"
+            }
+          }
+        }
+        """
+      And the following document exists in the "tasks" collection:
+        """
+        {
+          "_id": "task-version-2",
+          "job_number": 9,
+          "last_updated": "2025-11-19T13:31:00Z",
+          "state": "in_review",
+          "type": "dataset_version",
+          "source": {
+            "id": "/economy/datasets/test/current/previous/v2"
+          },
+          "depends_on": ["task-version-1"],
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/9/tasks/task-version-2"
+            },
+            "job": {
+              "href": "/v1/migration-jobs/9"
+            }
+          }
+        }
+        """
+      When I GET "/v1/migration-jobs/9/tasks"
+      Then I should receive the following JSON response with status "200":
+        """
+        {
+          "count": 2,
+          "items": [
+            {
+              "id": "task-version-2",
+              "job_number": 9,
+              "last_updated": "2025-11-19T13:31:00Z",
+              "state": "in_review",
+              "type": "dataset_version",
+              "source": {
+                "id": "/economy/datasets/test/current/previous/v2"
+              },
+              "target": null,
+              "depends_on": ["task-version-1"],
+              "links": {
+                "self": {
+                  "href": "/v1/migration-jobs/9/tasks/task-version-2"
+                },
+                "job": {
+                  "href": "/v1/migration-jobs/9"
+                }
+              }
+            },
+            {
+              "id": "task-version-1",
+              "job_number": 9,
+              "last_updated": "2025-11-19T13:30:00Z",
+              "state": "in_review",
+              "type": "dataset_version",
+              "source": {
+                "id": "/economy/datasets/test/current/previous/v1"
+              },
+              "target": null,
+              "links": {
+                "self": {
+                  "href": "/v1/migration-jobs/9/tasks/task-version-1"
+                },
+                "job": {
+                  "href": "/v1/migration-jobs/9"
+                }
+              }
+            }
+          ],
+          "limit": 10,
+          "offset": 0,
+          "total_count": 2
+        }
+        """
+
     Scenario: Get a list of 2 tasks using limit and offset to paginate
       Given the following document exists in the "jobs" collection:
         """
@@ -269,6 +385,328 @@ Feature: Get list of job tasks
         }
         """
 
+    Scenario: Get a list of 2 tasks using a cursor to paginate
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "2874ee9e-1cec-44f8-9b6d-998cf2062791",
+          "job_number": 17,
+          "last_updated": "2025-11-19T13:28:00Z",
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/17"
+            },
+            "events": {
+              "href": "/v1/migration-jobs/17/events"
+            },
+            "tasks": {
+              "href": "/v1/migration-jobs/17/tasks"
+            }
+          },
+          "state": "migrating",
+          "type": "static_dataset",
+          "config": {
+            "source_id": "test-source-id",
+            "target_id": "test-target-id",
+            "type": "static_dataset"
+          }
+        }
+        """
+      And the following document exists in the "tasks" collection:
+        """
+        {
+          "_id": "task-123e4567-e89b-12d3-a456-426614174000",
+          "job_number": 17,
+          "last_updated": "2025-11-19T13:30:00Z",
+          "state": "migrating",
+          "type": "dataset",
+          "source": {
+            "id": "source-dataset-1",
+            "label": "Source Dataset 1"
+          },
+          "target": {
+            "id": "target-dataset-1",
+            "label": "Target Dataset 1"
+          },
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/17/tasks/task-123e4567-e89b-12d3-a456-426614174000"
+            },
+            "job": {
+              "href": "/v1/migration-jobs/17"
+            }
+          }
+        }
+        """
+      And the following document exists in the "tasks" collection:
+        """
+        {
+          "_id": "task-456e7890-e89b-12d3-a456-426614174001",
+          "job_number": 17,
+          "last_updated": "2025-11-19T13:35:00Z",
+          "state": "publishing",
+          "type": "dataset_edition",
+          "source": {
+            "id": "source-edition-2",
+            "label": "Source Edition 2"
+          },
+          "target": {
+            "id": "target-edition-2",
+            "label": "Target Edition 2"
+          },
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/17/tasks/task-456e7890-e89b-12d3-a456-426614174001"
+            },
+            "job": {
+              "href": "/v1/migration-jobs/17"
+            }
+          }
+        }
+        """
+      When I GET "/v1/migration-jobs/17/tasks?limit=1&after="
+      Then I should receive the following JSON response with status "200":
+        """
+        {
+          "count": 1,
+          "items": [
+            {
+              "id": "task-123e4567-e89b-12d3-a456-426614174000",
+              "job_number": 17,
+              "last_updated": "2025-11-19T13:30:00Z",
+              "state": "migrating",
+              "type": "dataset",
+              "source": {
+                "id": "source-dataset-1",
+                "label": "Source Dataset 1"
+              },
+              "target": {
+                "id": "target-dataset-1",
+                "label": "Target Dataset 1"
+              },
+              "links": {
+                "self": {
+                  "href": "/v1/migration-jobs/17/tasks/task-123e4567-e89b-12d3-a456-426614174000"
+                },
+                "job": {
+                  "href": "/v1/migration-jobs/17"
+                }
+              }
+            }
+          ],
+          "limit": 1,
+          "links": {
+            "next": {
+              "href": "/v1/migration-jobs/17/tasks?after=eyJpZCI6InRhc2stMTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDAwIn0&limit=1"
+            }
+          }
+        }
+        """
+      When I GET "/v1/migration-jobs/17/tasks?after=eyJpZCI6InRhc2stMTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDAwIn0&limit=1"
+      Then I should receive the following JSON response with status "200":
+        """
+        {
+          "count": 1,
+          "items": [
+            {
+              "id": "task-456e7890-e89b-12d3-a456-426614174001",
+              "job_number": 17,
+              "last_updated": "2025-11-19T13:35:00Z",
+              "state": "publishing",
+              "type": "dataset_edition",
+              "source": {
+                "id": "source-edition-2",
+                "label": "Source Edition 2"
+              },
+              "target": {
+                "id": "target-edition-2",
+                "label": "Target Edition 2"
+              },
+              "links": {
+                "self": {
+                  "href": "/v1/migration-jobs/17/tasks/task-456e7890-e89b-12d3-a456-426614174001"
+                },
+                "job": {
+                  "href": "/v1/migration-jobs/17"
+                }
+              }
+            }
+          ],
+          "limit": 1,
+          "links": {}
+        }
+        """
+
+    Scenario: Get a list of tasks using both a cursor and an offset
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "2874ee9e-1cec-44f8-9b6d-998cf2062791",
+          "job_number": 17,
+          "last_updated": "2025-11-19T13:28:00Z",
+          "state": "migrating",
+          "type": "static_dataset",
+          "config": {
+            "source_id": "test-source-id",
+            "target_id": "test-target-id",
+            "type": "static_dataset"
+          }
+        }
+        """
+      When I GET "/v1/migration-jobs/17/tasks?after=&offset=1"
+      Then I should receive the following JSON response with status "400":
+        """
+        {
+          "errors": [
+            {
+              "code": 400,
+              "description": "offset parameter cannot be used with the after parameter"
+            }
+          ]
+        }
+        """
+
+    Scenario: Get the tasks of a job as a tree with rolled up states
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "2874ee9e-1cec-44f8-9b6d-998cf2062791",
+          "job_number": 31,
+          "last_updated": "2025-11-19T13:28:00Z",
+          "state": "migrating",
+          "type": "static_dataset",
+          "config": {
+            "source_id": "test-source-id",
+            "target_id": "test-target-id",
+            "type": "static_dataset"
+          }
+        }
+        """
+      And the following document exists in the "tasks" collection:
+        """
+        {
+          "_id": "task-series",
+          "job_number": 31,
+          "last_updated": "2025-11-19T13:30:00Z",
+          "state": "in_review",
+          "type": "dataset_series",
+          "source": {
+            "id": "source-series"
+          },
+          "target": {
+            "id": "target-series"
+          },
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/31/tasks/task-series"
+            },
+            "job": {
+              "href": "/v1/migration-jobs/31"
+            }
+          }
+        }
+        """
+      And the following document exists in the "tasks" collection:
+        """
+        {
+          "_id": "task-edition",
+          "job_number": 31,
+          "last_updated": "2025-11-19T13:35:00Z",
+          "state": "failed_migration",
+          "type": "dataset_edition",
+          "source": {
+            "id": "source-edition"
+          },
+          "target": {
+            "dataset_id": "target-series"
+          },
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/31/tasks/task-edition"
+            },
+            "job": {
+              "href": "/v1/migration-jobs/31"
+            }
+          },
+          "parent_task_id": "task-series",
+          "depth": 1,
+          "path": ["task-series"]
+        }
+        """
+      When I GET "/v1/migration-jobs/31/tasks/tree"
+      Then I should receive the following JSON response with status "200":
+        """
+        {
+          "items": [
+            {
+              "id": "task-series",
+              "job_number": 31,
+              "last_updated": "2025-11-19T13:30:00Z",
+              "state": "in_review",
+              "type": "dataset_series",
+              "source": {
+                "id": "source-series"
+              },
+              "target": {
+                "id": "target-series"
+              },
+              "links": {
+                "self": {
+                  "href": "/v1/migration-jobs/31/tasks/task-series"
+                },
+                "job": {
+                  "href": "/v1/migration-jobs/31"
+                }
+              },
+              "rolled_up_state": "failed_migration",
+              "children": [
+                {
+                  "id": "task-edition",
+                  "job_number": 31,
+                  "last_updated": "2025-11-19T13:35:00Z",
+                  "state": "failed_migration",
+                  "type": "dataset_edition",
+                  "source": {
+                    "id": "source-edition"
+                  },
+                  "target": {
+                    "dataset_id": "target-series"
+                  },
+                  "links": {
+                    "self": {
+                      "href": "/v1/migration-jobs/31/tasks/task-edition"
+                    },
+                    "job": {
+                      "href": "/v1/migration-jobs/31"
+                    }
+                  },
+                  "parent_task_id": "task-series",
+                  "depth": 1,
+                  "path": ["task-series"],
+                  "rolled_up_state": "failed_migration",
+                  "children": []
+                }
+              ]
+            }
+          ],
+          "total_count": 2
+        }
+        """
+
+    Scenario: Get the task tree of a job that does not exist
+      When I GET "/v1/migration-jobs/999/tasks/tree"
+      Then I should receive the following JSON response with status "404":
+        """
+        {
+          "errors": [
+            {
+              "code": 404,
+              "description": "job not found"
+            }
+          ]
+        }
+        """
+
     Scenario: Get a list of tasks when no tasks are available for existing job
       Given the following document exists in the "jobs" collection:
         """
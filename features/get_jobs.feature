@@ -480,6 +480,76 @@ Feature: Get list of jobs
           "total_count": 2
         }
         """
+    Scenario: Get a list of jobs filtered by source, label and submitting user
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "job-submitted-1",
+          "job_number": 23,
+          "label": "Consumer Price Inflation",
+          "last_updated": "2025-11-19T13:28:00Z",
+          "state": "submitted",
+          "created_by": {
+            "id": "user-123"
+          },
+          "config": {
+            "source_id": "s1",
+            "target_id": "t1",
+            "type": "static_dataset"
+          }
+        }
+        """
+      And the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "job-submitted-2",
+          "job_number": 24,
+          "label": "Consumer Price Inflation",
+          "last_updated": "2025-11-19T14:00:00Z",
+          "state": "submitted",
+          "created_by": {
+            "id": "user-456"
+          },
+          "config": {
+            "source_id": "s2",
+            "target_id": "t2",
+            "type": "static_dataset"
+          }
+        }
+        """
+      When I GET "/v1/migration-jobs?source_id=s1&label=price&created_by=user-123"
+      Then I should receive the following JSON response with status "200":
+        """
+        {
+          "count": 1,
+          "items": [
+            {
+              "id": "job-submitted-1",
+              "job_number": 23,
+              "label": "Consumer Price Inflation",
+              "state": "submitted",
+              "config": {
+                "source_id": "s1",
+                "target_id": "t1",
+                "type": "static_dataset"
+              },
+              "last_updated": "2025-11-19T13:28:00Z",
+              "links": {}
+            }
+          ],
+          "states": [
+            {
+              "id": "submitted",
+              "label": "Submitted",
+              "count": 2
+            }
+          ],
+          "limit": 10,
+          "offset": 0,
+          "total_count": 1
+        }
+        """
+
     Scenario: Get a list of 2 jobs using valid sort parameters
       Given the following document exists in the "jobs" collection:
         """
@@ -799,6 +869,21 @@ Feature: Get list of jobs
         }
         """
 
+    @InvalidInput
+    Scenario: Get a list of jobs with an invalid updated_after timestamp
+      When I GET "/v1/migration-jobs?updated_after=yesterday"
+      Then I should receive the following JSON response with status "400":
+        """
+        {
+          "errors": [
+            {
+              "code": 400,
+              "description": "updated_after parameter must be an RFC3339 timestamp"
+            }
+          ]
+        }
+        """
+
     @InvalidInput
     Scenario: Get a list of jobs with limit exceeding maximum allowed
       When I GET "/v1/migration-jobs?limit=2000"
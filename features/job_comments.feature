@@ -0,0 +1,217 @@
+@Job @JobComments
+Feature: Comment on migration jobs
+
+  Rule: User that is authorised to edit jobs
+    Background:
+      Given an admin user has the "migrations:edit" permission
+      And I am an admin user
+      And the migration service is running
+
+    Scenario: Comment on a job successfully
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "in_review"
+        }
+        """
+      When I POST "/v1/migration-jobs/1/comments"
+        """
+        {
+          "text": "Dimension labels do not match the source"
+        }
+        """
+      Then the HTTP status code should be "201"
+
+    @InvalidInput
+    Scenario: Comment on a job without any text
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "in_review"
+        }
+        """
+      When I POST "/v1/migration-jobs/1/comments"
+        """
+        {
+          "text": "   "
+        }
+        """
+      Then I should receive the following JSON response with status "400":
+        """
+        {
+          "errors": [
+            {
+              "code": 400,
+              "description": "comment text not provided"
+            }
+          ]
+        }
+        """
+
+    Scenario: Comment on a job that does not exist
+      When I POST "/v1/migration-jobs/99/comments"
+        """
+        {
+          "text": "Dimension labels do not match the source"
+        }
+        """
+      Then I should receive the following JSON response with status "404":
+        """
+        {
+          "errors": [
+            {
+              "code": 404,
+              "description": "job not found"
+            }
+          ]
+        }
+        """
+
+    Scenario: Reject a job with a reason
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "in_review"
+        }
+        """
+      When I PUT "/v1/migration-jobs/1/state"
+        """
+        {
+          "state": "rejected",
+          "reason": "Dimension labels do not match the source"
+        }
+        """
+      Then the HTTP status code should be "204"
+
+  Rule: User that is authorised to read jobs
+    Background:
+      Given an admin user has the "migrations:read" permission
+      And I am an admin user
+      And the migration service is running
+
+    Scenario: Get the comments on a job oldest first
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "in_review"
+        }
+        """
+      And the following document exists in the "comments" collection:
+        """
+        {
+          "_id": "comment-2",
+          "job_number": 1,
+          "text": "Fixed in the source, please re-run",
+          "created_by": {
+            "id": "user-456"
+          },
+          "created_at": "2025-11-19T14:00:00Z",
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/1/comments/comment-2"
+            },
+            "job": {
+              "href": "/v1/migration-jobs/1"
+            }
+          }
+        }
+        """
+      And the following document exists in the "comments" collection:
+        """
+        {
+          "_id": "comment-1",
+          "job_number": 1,
+          "text": "Dimension labels do not match the source",
+          "created_by": {
+            "id": "user-123"
+          },
+          "created_at": "2025-11-19T13:30:00Z",
+          "links": {
+            "self": {
+              "href": "/v1/migration-jobs/1/comments/comment-1"
+            },
+            "job": {
+              "href": "/v1/migration-jobs/1"
+            }
+          }
+        }
+        """
+      When I GET "/v1/migration-jobs/1/comments"
+      Then I should receive the following JSON response with status "200":
+        """
+        {
+          "count": 2,
+          "items": [
+            {
+              "id": "comment-1",
+              "job_number": 1,
+              "text": "Dimension labels do not match the source",
+              "created_by": {
+                "id": "user-123"
+              },
+              "created_at": "2025-11-19T13:30:00Z",
+              "links": {
+                "self": {
+                  "href": "/v1/migration-jobs/1/comments/comment-1"
+                },
+                "job": {
+                  "href": "/v1/migration-jobs/1"
+                }
+              }
+            },
+            {
+              "id": "comment-2",
+              "job_number": 1,
+              "text": "Fixed in the source, please re-run",
+              "created_by": {
+                "id": "user-456"
+              },
+              "created_at": "2025-11-19T14:00:00Z",
+              "links": {
+                "self": {
+                  "href": "/v1/migration-jobs/1/comments/comment-2"
+                },
+                "job": {
+                  "href": "/v1/migration-jobs/1"
+                }
+              }
+            }
+          ],
+          "limit": 10,
+          "offset": 0,
+          "total_count": 2
+        }
+        """
+
+    Scenario: Get the comments on a job that does not exist
+      When I GET "/v1/migration-jobs/99/comments"
+      Then the HTTP status code should be "404"
+
+  Rule: Users that are not authorised or authenticated
+    Background:
+      Given an admin user has the "incorrect" permission
+      And the migration service is running
+
+    Scenario: User that is not authenticated
+      Given I am not authorised
+      And the migration service is running
+      When I POST "/v1/migration-jobs/1/comments"
+        """
+        {
+          "text": "Dimension labels do not match the source"
+        }
+        """
+      Then the HTTP status code should be "401"
+
+    Scenario: User that is not authorised
+      Given I am an admin user
+      When I GET "/v1/migration-jobs/1/comments"
+      Then the HTTP status code should be "403"
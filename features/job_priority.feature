@@ -0,0 +1,89 @@
+@Job @JobPriority
+Feature: Change the priority of migration jobs
+
+  Rule: User that is authorised to edit jobs
+    Background:
+      Given an admin user has the "migrations:edit" permission
+      And I am an admin user
+      And the migration service is running
+
+    Scenario: Change the priority of a migrating job successfully
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "migrating"
+        }
+        """
+      When I PUT "/v1/migration-jobs/1/priority"
+        """
+        {
+          "priority": 5
+        }
+        """
+      Then the HTTP status code should be "204"
+
+    @InvalidInput
+    Scenario: Change the priority of a job to a priority that is out of range
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "migrating"
+        }
+        """
+      When I PUT "/v1/migration-jobs/1/priority"
+        """
+        {
+          "priority": 11
+        }
+        """
+      Then I should receive the following JSON response with status "400":
+        """
+        {
+          "errors": [
+            {
+              "code": 400,
+              "description": "priority must be between 0 and 10"
+            }
+          ]
+        }
+        """
+
+    Scenario: Change the priority of a job that has finished
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "completed"
+        }
+        """
+      When I PUT "/v1/migration-jobs/1/priority"
+        """
+        {
+          "priority": 5
+        }
+        """
+      Then I should receive the following JSON response with status "409":
+        """
+        {
+          "errors": [
+            {
+              "code": 409,
+              "description": "priority cannot be changed once a job has finished"
+            }
+          ]
+        }
+        """
+
+    Scenario: Change the priority of a job that does not exist
+      When I PUT "/v1/migration-jobs/1/priority"
+        """
+        {
+          "priority": 5
+        }
+        """
+      Then the HTTP status code should be "404"
@@ -145,3 +145,73 @@ Feature: Migrate a static dataset job
       }
       """
     And 1 Slack info notifications should have been sent
+
+  Scenario: A task is not migrated until the task it depends on is in review
+    Given a get page data request to zebedee for "/test-dependency-dataset" returns with status 200 and payload:
+      """
+      {
+        "type": "dataset_landing_page",
+        "uri": "/economy/datasets/test-dependency-dataset",
+        "description": {
+          "title": "Test Dependency Dataset Series"
+        }
+      }
+      """
+    And the Dataset API responds successfully to create dataset requests
+    And the following document exists in the "jobs" collection:
+      """
+      {
+        "_id": "4a96cc1f-3e57-4d2b-9c8e-0f1d2a3b4c5d",
+        "job_number": 22,
+        "last_updated": "2025-11-19T13:28:00Z",
+        "state": "migrating",
+        "label": "Test Dependency Dataset Series",
+        "config": {
+          "collection_id": "migration-job-test-collection",
+          "source_id": "/test-dependency-dataset",
+          "target_id": "test-dependency-dataset",
+          "type": "static_dataset"
+        }
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-edition",
+        "job_number": 22,
+        "last_updated": "2025-11-19T13:30:00Z",
+        "state": "submitted",
+        "type": "dataset_edition",
+        "source": {
+          "id": "/economy/datasets/test-dependency-dataset/2025",
+          "dataset_id": "/test-dependency-dataset"
+        },
+        "target": {
+          "dataset_id": "test-dependency-dataset"
+        },
+        "depends_on": ["task-series"],
+        "unmet_dependencies": ["task-series"],
+        "blocked": true
+      }
+      """
+    And the following document exists in the "tasks" collection:
+      """
+      {
+        "_id": "task-series",
+        "job_number": 22,
+        "last_updated": "2025-11-19T13:31:00Z",
+        "state": "submitted",
+        "type": "dataset_series",
+        "source": {
+          "id": "/test-dependency-dataset"
+        },
+        "target": {
+          "id": "test-dependency-dataset"
+        }
+      }
+      """
+    And I wait 3 seconds for the job processor to process tasks and jobs
+    Then task "task-series" should be in the "in_review" state
+    And the tasks claimed from the "submitted" state should have been, in order:
+      | task-series  |
+      | task-edition |
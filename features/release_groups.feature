@@ -0,0 +1,111 @@
+@ReleaseGroup
+Feature: Publish migration jobs together in release groups
+
+  Rule: User that is authorised to edit jobs
+    Background:
+      Given an admin user has the "migrations:edit" permission
+      And I am an admin user
+      And the migration service is running
+
+    Scenario: Create a release group successfully
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "in_review"
+        }
+        """
+      And the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "2",
+          "job_number": 2,
+          "state": "approved"
+        }
+        """
+      When I POST "/v1/migration-release-groups"
+        """
+        {
+          "name": "Regional series",
+          "job_numbers": [1, 2]
+        }
+        """
+      Then the HTTP status code should be "201"
+
+    @InvalidInput
+    Scenario: Create a release group with a single job
+      When I POST "/v1/migration-release-groups"
+        """
+        {
+          "name": "Regional series",
+          "job_numbers": [1]
+        }
+        """
+      Then I should receive the following JSON response with status "400":
+        """
+        {
+          "errors": [
+            {
+              "code": 400,
+              "description": "release group must contain at least two jobs"
+            }
+          ]
+        }
+        """
+
+    Scenario: Create a release group with a job that has started publishing
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "approved"
+        }
+        """
+      And the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "2",
+          "job_number": 2,
+          "state": "publishing"
+        }
+        """
+      When I POST "/v1/migration-release-groups"
+        """
+        {
+          "name": "Regional series",
+          "job_numbers": [1, 2]
+        }
+        """
+      Then I should receive the following JSON response with status "409":
+        """
+        {
+          "errors": [
+            {
+              "code": 409,
+              "description": "only jobs that have not started publishing can be added to a release group"
+            }
+          ]
+        }
+        """
+
+  Rule: User that is authorised to read jobs
+    Background:
+      Given an admin user has the "migrations:read" permission
+      And I am an admin user
+      And the migration service is running
+
+    Scenario: Get a release group that does not exist
+      When I GET "/v1/migration-release-groups/missing"
+      Then I should receive the following JSON response with status "404":
+        """
+        {
+          "errors": [
+            {
+              "code": 404,
+              "description": "release group not found"
+            }
+          ]
+        }
+        """
@@ -0,0 +1,93 @@
+@Job @ScheduleJob
+Feature: Schedule publishing of migration jobs
+
+  Rule: User that is authorised to edit jobs
+    Background:
+      Given an admin user has the "migrations:edit" permission
+      And I am an admin user
+      And the migration service is running
+
+    Scenario: Schedule an approved job successfully
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "approved"
+        }
+        """
+      When I PUT "/v1/migration-jobs/1/schedule"
+        """
+        {
+          "publish_at": "2099-01-01T09:30:00Z"
+        }
+        """
+      Then the HTTP status code should be "204"
+
+    Scenario: Cancel the schedule of an approved job
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "approved",
+          "publish_at": "2099-01-01T09:30:00Z"
+        }
+        """
+      When I DELETE "/v1/migration-jobs/1/schedule"
+      Then the HTTP status code should be "204"
+
+    @InvalidInput
+    Scenario: Schedule a job for a time in the past
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "approved"
+        }
+        """
+      When I PUT "/v1/migration-jobs/1/schedule"
+        """
+        {
+          "publish_at": "2020-01-01T09:30:00Z"
+        }
+        """
+      Then I should receive the following JSON response with status "400":
+        """
+        {
+          "errors": [
+            {
+              "code": 400,
+              "description": "publish_at must be in the future"
+            }
+          ]
+        }
+        """
+
+    Scenario: Schedule a job that has already been published
+      Given the following document exists in the "jobs" collection:
+        """
+        {
+          "_id": "1",
+          "job_number": 1,
+          "state": "published"
+        }
+        """
+      When I PUT "/v1/migration-jobs/1/schedule"
+        """
+        {
+          "publish_at": "2099-01-01T09:30:00Z"
+        }
+        """
+      Then I should receive the following JSON response with status "409":
+        """
+        {
+          "errors": [
+            {
+              "code": 409,
+              "description": "job publishing can only be scheduled while the job is in review or approved"
+            }
+          ]
+        }
+        """
@@ -0,0 +1,49 @@
+package steps
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/store"
+)
+
+// ClaimRecorder wraps the mongo store used by the service to record the
+// tasks the migrator claims, in the order they are claimed, so that
+// scenarios can assert on the claim order.
+type ClaimRecorder struct {
+	store.MongoDB
+
+	mu     sync.Mutex
+	claims map[domain.State][]string
+}
+
+// NewClaimRecorder creates a ClaimRecorder wrapping the provided store
+func NewClaimRecorder(mongoDB store.MongoDB) *ClaimRecorder {
+	return &ClaimRecorder{
+		MongoDB: mongoDB,
+		claims:  map[domain.State][]string{},
+	}
+}
+
+// ClaimTask claims a task from the wrapped store and records its ID
+// against the pending state it was claimed from
+func (r *ClaimRecorder) ClaimTask(ctx context.Context, pendingState, activeState, dependencyState domain.State, afterJobNumber int, excludedTypes []domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
+	task, err := r.MongoDB.ClaimTask(ctx, pendingState, activeState, dependencyState, afterJobNumber, excludedTypes, excludedJobNumbers)
+	if task != nil {
+		r.mu.Lock()
+		r.claims[pendingState] = append(r.claims[pendingState], task.ID)
+		r.mu.Unlock()
+	}
+
+	return task, err
+}
+
+// Claims returns the IDs of the tasks claimed from the provided pending
+// state, in the order they were claimed
+func (r *ClaimRecorder) Claims(pendingState domain.State) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string{}, r.claims[pendingState]...)
+}
@@ -8,12 +8,14 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/ONSdigital/dis-migration-service/api"
 	"github.com/ONSdigital/dis-migration-service/application"
 	"github.com/ONSdigital/dis-migration-service/cache"
 	"github.com/ONSdigital/dis-migration-service/clients"
 	"github.com/ONSdigital/dis-migration-service/config"
 	"github.com/ONSdigital/dis-migration-service/migrator"
 	"github.com/ONSdigital/dis-migration-service/mongo"
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	"github.com/ONSdigital/dis-migration-service/service"
 	"github.com/ONSdigital/dis-migration-service/service/mock"
 	"github.com/ONSdigital/dis-migration-service/slack"
@@ -43,6 +45,7 @@ type MigrationComponent struct {
 	ServiceRunning          bool
 	apiFeature              *componenttest.APIFeature
 	MongoClient             *mongo.Mongo
+	ClaimRecorder           *ClaimRecorder
 	mongoFeature            *componenttest.MongoFeature
 	authFeature             *componenttest.AuthorizationFeature
 	StartTime               time.Time
@@ -99,6 +102,7 @@ func NewMigrationComponent(mongoFeat *componenttest.MongoFeature, authFeat *comp
 	}
 
 	c.MongoClient = mongodb
+	c.ClaimRecorder = NewClaimRecorder(mongodb)
 
 	c.FakeAPIRouter = NewFakeAPI()
 	c.Config.ZebedeeURL = c.FakeAPIRouter.fakeHTTP.Server.URL
@@ -129,6 +133,11 @@ func NewMigrationComponent(mongoFeat *componenttest.MongoFeature, authFeat *comp
 			})
 			return nil
 		},
+		// Threads are not started, so notifications are sent as top level
+		// messages that the Slack steps can assert on
+		StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+			return nil, nil
+		},
 	}
 
 	initMock := &mock.InitialiserMock{
@@ -139,6 +148,7 @@ func NewMigrationComponent(mongoFeat *componenttest.MongoFeature, authFeat *comp
 		DoGetMigratorFunc:                c.DoGetMigrator,
 		DoGetAppClientsFunc:              c.DoGetAppClients,
 		DoGetAuthorisationMiddlewareFunc: c.DoGetAuthorisationMiddleware,
+		DoGetPermissionsCheckerFunc:      c.DoGetPermissionsChecker,
 		DoGetTopicCacheFunc:              c.DoGetTopicCache,
 	}
 
@@ -196,6 +206,12 @@ func (c *MigrationComponent) SeedDatabase() error {
 		}
 	}
 
+	// The database is dropped between scenarios, so the indexes created
+	// when the service started need creating again
+	if err := c.MongoClient.EnsureIndexes(context.Background()); err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+
 	colls, err := c.MongoClient.Connection.ListCollectionsFor(context.Background(), c.MongoClient.Database)
 	if err != nil {
 		return fmt.Errorf("failed to list collections: %w", err)
@@ -236,7 +252,7 @@ func (c *MigrationComponent) DoGetHTTPServer(bindAddr string, router http.Handle
 }
 
 func (c *MigrationComponent) DoGetMongoDB(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error) {
-	return c.MongoClient, nil
+	return c.ClaimRecorder, nil
 }
 
 func (c *MigrationComponent) DoGetSlackClient(ctx context.Context, cfg *config.Config) (slack.Clienter, error) {
@@ -244,8 +260,8 @@ func (c *MigrationComponent) DoGetSlackClient(ctx context.Context, cfg *config.C
 	return c.MockSlackClient, nil
 }
 
-func (c *MigrationComponent) DoGetMigrator(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, slackClient slack.Clienter, topicCache *cache.TopicCache) (migrator.Migrator, error) {
-	mig, err := migrator.NewDefaultMigrator(cfg, jobService, clientList, slackClient, topicCache)
+func (c *MigrationComponent) DoGetMigrator(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, jobNotifier notifier.Notifier, topicCache *cache.TopicCache) (migrator.Migrator, error) {
+	mig, err := migrator.NewDefaultMigrator(cfg, jobService, clientList, jobNotifier, topicCache)
 	if err != nil {
 		return nil, err
 	}
@@ -269,6 +285,12 @@ func (c *MigrationComponent) DoGetAuthorisationMiddleware(ctx context.Context, c
 	return c.AuthorisationMiddleware, nil
 }
 
+// DoGetPermissionsChecker returns a permissions checker backed by the fake
+// permissions API
+func (c *MigrationComponent) DoGetPermissionsChecker(ctx context.Context, cfg *config.Config) (api.PermissionsChecker, error) {
+	return (&service.Init{}).DoGetPermissionsChecker(ctx, cfg)
+}
+
 func (c *MigrationComponent) GetMockSlackClient() *slackMocks.ClienterMock {
 	return c.MockSlackClient
 }
@@ -361,6 +383,7 @@ func (c *MigrationComponent) ResetMockSlackClient() {
 		SendInfoFunc:    c.MockSlackClient.SendInfoFunc,
 		SendWarningFunc: c.MockSlackClient.SendWarningFunc,
 		SendAlarmFunc:   c.MockSlackClient.SendAlarmFunc,
+		StartThreadFunc: c.MockSlackClient.StartThreadFunc,
 	}
 }
 
@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
+	"time"
 
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
 	"github.com/ONSdigital/log.go/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
 
 	"github.com/cucumber/godog"
 )
@@ -18,6 +23,13 @@ func (c *MigrationComponent) RegisterSteps(ctx *godog.ScenarioContext) {
 	ctx.Step(`^a get page data request to zebedee for "([^"]*)" returns with status (\d+) and payload:$`, c.getPageDataRequestToZebedeeForReturnsWithPayload)
 	ctx.Step(`^a get dataset request to the dataset API for "([^"]*)" returns with status (\d+)$`, c.getDatasetRequestToDatasetAPIForReturnsWithStatus)
 	ctx.Step(`^the Dataset API responds successfully to create dataset requests$`, c.datasetAPIrespondsSuccessfullyToCreateDatasetRequests)
+	ctx.Step(`^job (\d+) is scheduled to publish (\d+) minutes? (ago|from now)$`, c.jobIsScheduledToPublish)
+
+	// Claim assertions
+	ctx.Step(`^the tasks claimed from the "([^"]*)" state should have been, in order:$`, c.theTasksClaimedFromTheStateShouldHaveBeen)
+	ctx.Step(`^no tasks should have been claimed from the "([^"]*)" state$`, c.noTasksShouldHaveBeenClaimedFromTheState)
+	ctx.Step(`^job (\d+) should be in the "([^"]*)" state$`, c.jobShouldBeInTheState)
+	ctx.Step(`^task "([^"]*)" should be in the "([^"]*)" state$`, c.taskShouldBeInTheState)
 
 	// Slack notification assertions
 	ctx.Step(`^(\d+) Slack info notifications? should have been sent$`, c.slackInfoNotificationsShouldHaveBeenSent)
@@ -78,6 +90,63 @@ func (c *MigrationComponent) datasetAPIrespondsSuccessfullyToCreateDatasetReques
 	c.FakeAPIRouter.setJSONResponseForCreateDataset(http.StatusCreated)
 }
 
+func (c *MigrationComponent) jobIsScheduledToPublish(jobNumber, minutes int, when string) error {
+	offset := time.Duration(minutes) * time.Minute
+	if when == "ago" {
+		offset = -offset
+	}
+	publishAt := time.Now().UTC().Add(offset)
+
+	_, err := c.MongoClient.Connection.Collection(c.MongoClient.ActualCollectionName(config.JobsCollectionTitle)).
+		UpdateOne(context.Background(), bson.M{"job_number": jobNumber}, bson.M{"$set": bson.M{"publish_at": publishAt}})
+	return err
+}
+
+func (c *MigrationComponent) theTasksClaimedFromTheStateShouldHaveBeen(state string, table *godog.Table) error {
+	expected := make([]string, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		expected = append(expected, row.Cells[0].Value)
+	}
+
+	claimed := c.ClaimRecorder.Claims(domain.State(state))
+	if !slices.Equal(claimed, expected) {
+		return fmt.Errorf("expected the tasks claimed from the %s state to be %v, but they were %v", state, expected, claimed)
+	}
+	return nil
+}
+
+func (c *MigrationComponent) noTasksShouldHaveBeenClaimedFromTheState(state string) error {
+	claimed := c.ClaimRecorder.Claims(domain.State(state))
+	if len(claimed) != 0 {
+		return fmt.Errorf("expected no tasks to be claimed from the %s state, but %v were", state, claimed)
+	}
+	return nil
+}
+
+func (c *MigrationComponent) jobShouldBeInTheState(jobNumber int, state string) error {
+	job, err := c.MongoClient.GetJob(context.Background(), jobNumber)
+	if err != nil {
+		return err
+	}
+
+	if job.State != domain.State(state) {
+		return fmt.Errorf("expected job %d to be in the %s state, but it was in the %s state", jobNumber, state, job.State)
+	}
+	return nil
+}
+
+func (c *MigrationComponent) taskShouldBeInTheState(taskID, state string) error {
+	task, err := c.MongoClient.GetTask(context.Background(), taskID)
+	if err != nil {
+		return err
+	}
+
+	if task.State != domain.State(state) {
+		return fmt.Errorf("expected task %s to be in the %s state, but it was in the %s state", taskID, state, task.State)
+	}
+	return nil
+}
+
 func (c *MigrationComponent) slackInfoNotificationsShouldHaveBeenSent(count int) error {
 	return c.AssertSlackInfoCalled(count)
 }
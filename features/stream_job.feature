@@ -0,0 +1,54 @@
+@Job @StreamJob
+Feature: Stream the progress of a job
+
+  Rule: User that is authorised and authenticated
+    Background:
+      Given an admin user has the "migrations:read" permission
+      And I am an admin user
+      And the migration service is running
+
+    Scenario: Stream a non-existent job returns 404
+      When I GET "/v1/migration-jobs/102/stream"
+      Then I should receive the following JSON response with status "404":
+        """
+        {
+          "errors": [
+            {
+              "code": 404,
+              "description": "job not found"
+            }
+          ]
+        }
+        """
+
+    @InvalidInput
+    Scenario: Stream a job with an invalid job number
+      When I GET "/v1/migration-jobs/invalid-job-number/stream"
+      Then I should receive the following JSON response with status "400":
+        """
+        {
+          "errors": [
+            {
+              "code": 400,
+              "description": "job number must be an integer"
+            }
+          ]
+        }
+        """
+
+    @Auth
+    Rule: Users that are not authorised or authenticated
+    Background:
+      Given an admin user has the "incorrect" permission
+      And the migration service is running
+
+    Scenario: User that is not authenticated
+      Given I am not authorised
+      And the migration service is running
+      When I GET "/v1/migration-jobs/1/stream"
+      Then the HTTP status code should be "401"
+
+    Scenario: User that is not authorised
+      Given I am an admin user
+      When I GET "/v1/migration-jobs/1/stream"
+      Then the HTTP status code should be "403"
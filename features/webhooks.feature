@@ -0,0 +1,177 @@
+@Webhook
+Feature: Manage webhooks
+
+  Rule: User that is authorised and authenticated
+    Background:
+      Given an admin user has the "migrations:admin" permission
+      And I am an admin user
+      And the migration service is running
+
+    Scenario: Create a webhook successfully
+      When I POST "/v1/webhooks"
+        """
+        {
+          "url": "https://example.com/hooks/migrations",
+          "secret": "s3cret",
+          "event_types": ["job.published", "job.failed_publish"],
+          "job_types": ["static_dataset"]
+        }
+        """
+      Then the HTTP status code should be "201"
+
+    @InvalidInput
+    Scenario: Create a webhook with an invalid URL and no secret
+      When I POST "/v1/webhooks"
+        """
+        {
+          "url": "not-a-url",
+          "event_types": ["job.published"]
+        }
+        """
+      Then I should receive the following JSON response with status "400":
+        """
+        {
+          "errors": [
+            {
+              "code": 400,
+              "description": "webhook url must be an absolute http or https URL"
+            },
+            {
+              "code": 400,
+              "description": "webhook secret not provided"
+            }
+          ]
+        }
+        """
+
+    Scenario: Get a webhook that does not exist returns 404
+      When I GET "/v1/webhooks/unknown-webhook"
+      Then I should receive the following JSON response with status "404":
+        """
+        {
+          "errors": [
+            {
+              "code": 404,
+              "description": "webhook not found"
+            }
+          ]
+        }
+        """
+
+    Scenario: Get the dead-lettered deliveries of a webhook
+      Given the following document exists in the "webhooks" collection:
+        """
+        {
+          "_id": "webhook-1",
+          "url": "https://example.com/hooks/migrations",
+          "secret": "s3cret",
+          "event_types": ["job.published"],
+          "enabled": true,
+          "created_at": "2025-11-19T13:00:00Z",
+          "last_updated": "2025-11-19T13:00:00Z",
+          "links": {
+            "self": {
+              "href": "/v1/webhooks/webhook-1"
+            },
+            "deliveries": {
+              "href": "/v1/webhooks/webhook-1/deliveries"
+            }
+          }
+        }
+        """
+      And the following document exists in the "webhook_deliveries" collection:
+        """
+        {
+          "_id": "delivery-1",
+          "webhook_id": "webhook-1",
+          "event_type": "job.published",
+          "job_number": 18,
+          "status": "dead_letter",
+          "attempts": 5,
+          "last_response_status": 503,
+          "last_error": "webhook responded with status 503",
+          "created_at": "2025-11-19T13:30:00Z"
+        }
+        """
+      And the following document exists in the "webhook_deliveries" collection:
+        """
+        {
+          "_id": "delivery-2",
+          "webhook_id": "webhook-1",
+          "event_type": "job.published",
+          "job_number": 19,
+          "status": "succeeded",
+          "attempts": 1,
+          "last_response_status": 200,
+          "created_at": "2025-11-19T13:35:00Z"
+        }
+        """
+      When I GET "/v1/webhooks/webhook-1/deliveries?status=dead_letter"
+      Then I should receive the following JSON response with status "200":
+        """
+        {
+          "count": 1,
+          "items": [
+            {
+              "id": "delivery-1",
+              "webhook_id": "webhook-1",
+              "event_type": "job.published",
+              "job_number": 18,
+              "payload": null,
+              "status": "dead_letter",
+              "attempts": 5,
+              "last_response_status": 503,
+              "last_error": "webhook responded with status 503",
+              "created_at": "2025-11-19T13:30:00Z"
+            }
+          ],
+          "limit": 10,
+          "offset": 0,
+          "total_count": 1
+        }
+        """
+
+    @InvalidInput
+    Scenario: Get the deliveries of a webhook with an invalid status
+      When I GET "/v1/webhooks/webhook-1/deliveries?status=failed"
+      Then I should receive the following JSON response with status "400":
+        """
+        {
+          "errors": [
+            {
+              "code": 400,
+              "description": "delivery status parameter is invalid"
+            }
+          ]
+        }
+        """
+
+    Scenario: Delete a webhook successfully
+      Given the following document exists in the "webhooks" collection:
+        """
+        {
+          "_id": "webhook-1",
+          "url": "https://example.com/hooks/migrations",
+          "secret": "s3cret",
+          "event_types": ["job.published"],
+          "enabled": true
+        }
+        """
+      When I DELETE "/v1/webhooks/webhook-1"
+      Then the HTTP status code should be "204"
+
+    @Auth
+    Rule: Users that are not authorised or authenticated
+    Background:
+      Given an admin user has the "migrations:read" permission
+      And the migration service is running
+
+    Scenario: User that is not authenticated
+      Given I am not authorised
+      When I GET "/v1/webhooks"
+      Then the HTTP status code should be "401"
+
+    Scenario: User that does not have the admin permission
+      Given I am an admin user
+      When I GET "/v1/webhooks"
+      Then the HTTP status code should be "403"
@@ -0,0 +1,40 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/notifier"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// requestApproval asks for a job that has reached review to be approved
+// or rejected, if approvals are enabled and the notifier supports them.
+// Failures are logged but do not affect the job.
+func (mig *migrator) requestApproval(ctx context.Context, job *domain.Job) {
+	if mig.cfg == nil || mig.cfg.SlackConfig == nil || !mig.cfg.SlackConfig.ApprovalsEnabled {
+		return
+	}
+
+	requester, ok := mig.notifier.(notifier.ApprovalRequester)
+	if !ok {
+		return
+	}
+
+	err := requester.RequestApproval(ctx, &notifier.Notification{
+		Severity: notifier.SeverityInfo,
+		Summary:  fmt.Sprintf("Migration job %d is ready for review", job.JobNumber),
+		Details: notifier.Details{
+			"Job Number": job.JobNumber,
+			"Job Label":  job.Label,
+			"Job Type":   jobType(job),
+		},
+		JobType:  jobType(job),
+		JobState: domain.StateInReview,
+		Thread:   job.NotificationThread,
+	}, job.JobNumber)
+	if err != nil {
+		log.Error(ctx, "failed to request job approval", err, log.Data{"job_number": job.JobNumber})
+	}
+}
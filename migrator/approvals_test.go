@@ -0,0 +1,120 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	applicationMocks "github.com/ONSdigital/dis-migration-service/application/mock"
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/notifier"
+	"github.com/ONSdigital/dis-migration-service/slack"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequestApproval(t *testing.T) {
+	thread := &domain.NotificationThread{Channel: "C123", Timestamp: "1700000000.000100"}
+
+	newJobService := func() *applicationMocks.JobServiceMock {
+		return &applicationMocks.JobServiceMock{
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
+				return nil
+			},
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+		}
+	}
+
+	newJob := func() *domain.Job {
+		return &domain.Job{
+			JobNumber:          12,
+			Label:              "Test Job",
+			State:              domain.StateMigrating,
+			NotificationThread: thread,
+		}
+	}
+
+	Convey("Given a migrator with Slack approvals enabled", t, func() {
+		mockSlackClient := createMockThreadSlackClient()
+		mockSlackClient.SendApprovalRequestFunc = func(ctx context.Context, thread *slack.Thread, summary string, details slack.SlackDetails, jobNumber int) error {
+			return nil
+		}
+
+		mig := &migrator{
+			jobService: newJobService(),
+			notifier:   notifier.NewSlackNotifier(mockSlackClient, nil),
+			cfg:        &config.Config{SlackConfig: &slack.Config{ApprovalsEnabled: true}},
+		}
+
+		Convey("When a job finishes migrating and moves to review", func() {
+			err := mig.transitionJobSuccess(context.Background(), newJob(), mig.GetStateTransitionRules()[domain.StateMigrating])
+
+			Convey("Then approval of the job is requested in its thread", func() {
+				So(err, ShouldBeNil)
+				So(mockSlackClient.SendApprovalRequestCalls(), ShouldHaveLength, 1)
+				call := mockSlackClient.SendApprovalRequestCalls()[0]
+				So(call.JobNumber, ShouldEqual, 12)
+				So(call.Summary, ShouldEqual, "Migration job 12 is ready for review")
+				So(call.Thread.Timestamp, ShouldEqual, thread.Timestamp)
+				So(call.Details["Job Label"], ShouldEqual, "Test Job")
+			})
+		})
+
+		Convey("When a job finishes publishing", func() {
+			job := newJob()
+			job.State = domain.StatePublishing
+
+			err := mig.transitionJobSuccess(context.Background(), job, mig.GetStateTransitionRules()[domain.StatePublishing])
+
+			Convey("Then approval is not requested", func() {
+				So(err, ShouldBeNil)
+				So(mockSlackClient.SendApprovalRequestCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a migrator with Slack approvals enabled and a Slack client that fails to send them", t, func() {
+		mockSlackClient := createMockThreadSlackClient()
+		mockSlackClient.SendApprovalRequestFunc = func(ctx context.Context, thread *slack.Thread, summary string, details slack.SlackDetails, jobNumber int) error {
+			return errors.New("slack unavailable")
+		}
+		mockJobService := newJobService()
+
+		mig := &migrator{
+			jobService: mockJobService,
+			notifier:   notifier.NewSlackNotifier(mockSlackClient, nil),
+			cfg:        &config.Config{SlackConfig: &slack.Config{ApprovalsEnabled: true}},
+		}
+
+		Convey("When a job moves to review", func() {
+			err := mig.transitionJobSuccess(context.Background(), newJob(), mig.GetStateTransitionRules()[domain.StateMigrating])
+
+			Convey("Then the job is still transitioned", func() {
+				So(err, ShouldBeNil)
+				So(mockJobService.UpdateJobStateCalls(), ShouldHaveLength, 1)
+				So(mockJobService.NotifyWebhooksCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a migrator with Slack approvals disabled", t, func() {
+		mockSlackClient := createMockThreadSlackClient()
+
+		mig := &migrator{
+			jobService: newJobService(),
+			notifier:   notifier.NewSlackNotifier(mockSlackClient, nil),
+			cfg:        &config.Config{SlackConfig: &slack.Config{}},
+		}
+
+		Convey("When a job moves to review", func() {
+			err := mig.transitionJobSuccess(context.Background(), newJob(), mig.GetStateTransitionRules()[domain.StateMigrating])
+
+			Convey("Then approval is not requested", func() {
+				So(err, ShouldBeNil)
+				So(mockSlackClient.SendApprovalRequestCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
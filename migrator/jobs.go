@@ -41,6 +41,11 @@ var getJobExecutors = func(jobService application.JobService, appClients *client
 	return jobExecutors
 }
 
+// jobDependencies lists the guarded dependencies called when executing
+// jobs. Every stage of a static dataset job calls Zebedee, so jobs are not
+// claimed while its circuit breaker is open.
+var jobDependencies = []string{clients.DependencyZebedee}
+
 func (mig *migrator) getJobExecutor(job *domain.Job) (executor.JobExecutor, error) {
 	jobExecutor := mig.jobExecutors[job.Config.Type]
 	if jobExecutor == nil {
@@ -58,6 +63,15 @@ func (mig *migrator) monitorJobs(ctx context.Context) {
 			log.Info(ctx, "stopping monitoring jobs")
 			return
 		default:
+			// Jobs are only claimed when they can start straight away
+			if mig.jobSlots.full() || mig.dependencyUnavailable(jobDependencies) {
+				if !mig.waitForWork(ctx, mig.jobsWake) {
+					log.Info(ctx, "stopping monitoring jobs")
+					return
+				}
+				continue
+			}
+
 			job, err := mig.jobService.ClaimJob(ctx)
 			if err != nil && !errors.Is(err, context.Canceled) {
 				log.Error(ctx, "error claiming job", err)
@@ -65,40 +79,41 @@ func (mig *migrator) monitorJobs(ctx context.Context) {
 				continue
 			}
 			if job == nil {
-				select {
-				case <-ctx.Done():
+				if !mig.waitForWork(ctx, mig.jobsWake) {
 					log.Info(ctx, "stopping monitoring jobs")
 					return
-				case <-time.After(mig.pollInterval):
-					continue
 				}
+				continue
 			}
 
 			log.Info(ctx, "claimed job", log.Data{"job_id": job.ID, "job_state": job.State})
+			mig.updateJobThread(ctx, job, job.State)
 			mig.executeJob(ctx, job)
 		}
 	}
 }
 
 // executeJob executes the provided job in a separate goroutine based on
-// it's state
+// it's state. A job execution slot is taken before returning, and is free
+// when the job was claimed by monitorJobs.
 func (mig *migrator) executeJob(ctx context.Context, job *domain.Job) {
 	requestID := dpRequest.NewRequestID(RequestIDLength)
 	ctx = dpRequest.WithRequestId(ctx, requestID)
+	ctx = clients.WithJobNumber(ctx, job.JobNumber)
 	log.Info(ctx, "executing job", log.Data{"job_id": job.ID, "job_state": job.State})
+
+	if !mig.jobSlots.acquire(ctx) {
+		return
+	}
+
 	mig.wg.Add(1)
 	go func() {
 		defer mig.wg.Done()
+		defer mig.wakeJobs()
+		defer mig.jobSlots.release()
 
 		logData := log.Data{"job_id": job.ID, "job_state": job.State}
 
-		select {
-		case mig.semaphore <- struct{}{}:
-			defer func() { <-mig.semaphore }()
-		case <-ctx.Done():
-			return
-		}
-
 		jobExecutor, err := mig.getJobExecutor(job)
 		if err != nil {
 			log.Error(ctx, "failed to get job executor", err, logData)
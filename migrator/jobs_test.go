@@ -15,6 +15,7 @@ import (
 	appErrors "github.com/ONSdigital/dis-migration-service/errors"
 	"github.com/ONSdigital/dis-migration-service/executor"
 	executorMocks "github.com/ONSdigital/dis-migration-service/executor/mock"
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	"github.com/ONSdigital/dis-migration-service/slack"
 	slackMocks "github.com/ONSdigital/dis-migration-service/slack/mocks"
 
@@ -38,6 +39,9 @@ func createMockSlackClient() slack.Clienter {
 		SendAlarmFunc: func(ctx context.Context, summary string, err error, details slack.SlackDetails) error {
 			return nil
 		},
+		StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+			return nil, nil
+		},
 	}
 }
 
@@ -65,7 +69,10 @@ func TestMigratorExecuteJob(t *testing.T) {
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 			CountTasksByJobNumberFunc: func(ctx context.Context, jobNumber int) (int, error) {
@@ -81,7 +88,7 @@ func TestMigratorExecuteJob(t *testing.T) {
 					return nil, 0, nil
 				}
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, state domain.State, reason string) error {
 				return nil
 			},
 			GetNextJobNumberFunc: func(ctx context.Context) (*domain.Counter, error) {
@@ -97,7 +104,7 @@ func TestMigratorExecuteJob(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 
 		Convey("When a job in state migrating is executed", func() {
 			job := &domain.Job{
@@ -198,7 +205,10 @@ func TestMigratorExecuteJob(t *testing.T) {
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 			GetNextJobNumberFunc: func(ctx context.Context) (*domain.Counter, error) {
@@ -214,7 +224,7 @@ func TestMigratorExecuteJob(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 
 		Convey("When a job is executed", func() {
 			job := &domain.Job{
@@ -232,6 +242,7 @@ func TestMigratorExecuteJob(t *testing.T) {
 				So(len(mockJobService.UpdateJobStateCalls()), ShouldEqual, 1)
 				So(mockJobService.UpdateJobStateCalls()[0].JobNumber, ShouldEqual, fakeJobNumber)
 				So(mockJobService.UpdateJobStateCalls()[0].NewState, ShouldEqual, domain.StateFailedMigration)
+				So(mockJobService.UpdateJobStateCalls()[0].Reason, ShouldEqual, failureReasonExecutorMissing)
 			})
 		})
 	})
@@ -250,7 +261,10 @@ func TestMigratorExecuteJob(t *testing.T) {
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 			GetNextJobNumberFunc: func(ctx context.Context) (*domain.Counter, error) {
@@ -266,7 +280,7 @@ func TestMigratorExecuteJob(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 
 		Convey("When a job is executed that errors during migration", func() {
 			job := &domain.Job{
@@ -303,7 +317,10 @@ func TestMigratorExecuteJob(t *testing.T) {
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 			GetNextJobNumberFunc: func(ctx context.Context) (*domain.Counter, error) {
@@ -319,7 +336,7 @@ func TestMigratorExecuteJob(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 
 		Convey("When a job is executed that errors during publishing", func() {
 			job := &domain.Job{
@@ -359,7 +376,10 @@ func TestMigratorExecuteJob(t *testing.T) {
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 			GetNextJobNumberFunc: func(ctx context.Context) (*domain.Counter, error) {
@@ -375,7 +395,7 @@ func TestMigratorExecuteJob(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 
 		Convey("When a job is executed that errors during post-publishing", func() {
 			job := &domain.Job{
@@ -423,7 +443,10 @@ func TestMigratorExecuteJob(t *testing.T) {
 					return nil, 0, nil
 				}
 			},
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				updateStates = append(updateStates, state)
 				if state == domain.StateRejected {
 					return appErrors.ErrJobStateTransitionNotAllowed
@@ -444,7 +467,7 @@ func TestMigratorExecuteJob(t *testing.T) {
 		cfg := &config.Config{MigratorMaxConcurrentExecutions: 1}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 
 		Convey("When the reverting job is executed", func() {
@@ -473,7 +496,10 @@ func TestMigratorExecuteJob(t *testing.T) {
 func TestMigratorFailJob(t *testing.T) {
 	Convey("Given a migrator with a mock job service", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
@@ -490,7 +516,7 @@ func TestMigratorFailJob(t *testing.T) {
 		cfg := &config.Config{}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 
 		Convey("When failJob is called for a job with an active state", func() {
@@ -545,7 +571,10 @@ func TestMigratorFailJob(t *testing.T) {
 
 	Convey("Given a migrator with a mock job service that errors when updating job state", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, state domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 			GetNextJobNumberFunc: func(ctx context.Context) (*domain.Counter, error) {
@@ -561,7 +590,7 @@ func TestMigratorFailJob(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 
 		Convey("When failJob is called for a job", func() {
@@ -602,7 +631,7 @@ func TestGetJobExecutor(t *testing.T) {
 		cfg := &config.Config{}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 
 		Convey("When getJobExecutor is called for a job with a known type", func() {
 			job := &domain.Job{
@@ -652,11 +681,12 @@ func TestMonitorJobs(t *testing.T) {
 		mockClients := &clients.ClientList{}
 		mockSlackClient := createMockSlackClient()
 		cfg := &config.Config{
-			MigratorPollInterval: 10 * time.Millisecond,
+			MigratorPollInterval:            10 * time.Millisecond,
+			MigratorMaxConcurrentExecutions: 1,
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx, cancel := context.WithCancel(context.Background())
 
 		Convey("When monitorJobs is started and runs one iteration", func() {
@@ -718,7 +748,7 @@ func TestMonitorJobs(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx, cancel := context.WithCancel(context.Background())
 
 		Convey("When monitorJobs is started and runs one iteration", func() {
@@ -739,4 +769,74 @@ func TestMonitorJobs(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a migrator whose job execution slots are all taken", t, func() {
+		mockJobService := &applicationMocks.JobServiceMock{
+			ClaimJobFunc: func(ctx context.Context) (*domain.Job, error) {
+				return nil, nil
+			},
+		}
+
+		jobSlots := make(semaphore, 1)
+		jobSlots <- struct{}{}
+
+		mig := &migrator{
+			jobService:   mockJobService,
+			jobSlots:     jobSlots,
+			pollInterval: time.Hour,
+			jobsWake:     make(chan struct{}, 1),
+		}
+
+		Convey("When monitorJobs is started", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go mig.monitorJobs(ctx)
+			time.Sleep(10 * time.Millisecond)
+
+			Convey("Then no jobs are claimed", func() {
+				So(mockJobService.ClaimJobCalls(), ShouldBeEmpty)
+			})
+
+			Convey("And once a slot is released jobs are claimed straight away", func() {
+				jobSlots.release()
+				mig.wakeJobs()
+				time.Sleep(10 * time.Millisecond)
+
+				So(mockJobService.ClaimJobCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a migrator whose Zebedee circuit breaker is open", t, func() {
+		guards := clients.NewGuards(
+			clients.GuardConfig{FailureThreshold: 1, OpenDuration: time.Hour},
+			clients.GuardConfig{FailureThreshold: 1, OpenDuration: time.Hour},
+		)
+		_ = guards.Zebedee.Do(context.Background(), func() error { return errors.New("zebedee unavailable") })
+
+		mockJobService := &applicationMocks.JobServiceMock{
+			ClaimJobFunc: func(ctx context.Context) (*domain.Job, error) {
+				return nil, nil
+			},
+		}
+
+		mig := &migrator{
+			jobService:   mockJobService,
+			jobSlots:     make(semaphore, 1),
+			appClients:   &clients.ClientList{Guards: guards},
+			pollInterval: time.Hour,
+			jobsWake:     make(chan struct{}, 1),
+		}
+
+		Convey("When monitorJobs is started", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go mig.monitorJobs(ctx)
+			time.Sleep(10 * time.Millisecond)
+
+			Convey("Then no jobs are claimed", func() {
+				So(mockJobService.ClaimJobCalls(), ShouldBeEmpty)
+			})
+		})
+	})
 }
@@ -0,0 +1,154 @@
+package migrator
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/executor"
+)
+
+// semaphore limits the number of executions that can run at once
+type semaphore chan struct{}
+
+// acquire waits for a free slot, returning false if ctx is done first
+func (s semaphore) acquire(ctx context.Context) bool {
+	select {
+	case s <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees a slot taken by acquire
+func (s semaphore) release() {
+	<-s
+}
+
+// full reports whether every slot is taken, so that acquire would wait
+func (s semaphore) full() bool {
+	return len(s) >= cap(s)
+}
+
+// jobSemaphores limits the number of task executions that can run at once
+// for each job. A job's semaphore is removed once none of its tasks are
+// using it.
+type jobSemaphores struct {
+	mu    sync.Mutex
+	limit int
+	jobs  map[int]*jobSemaphore
+}
+
+type jobSemaphore struct {
+	slots semaphore
+	users int
+}
+
+// newJobSemaphores creates per-job semaphores of the given size. A limit
+// of 0 or less means task executions are not limited per job.
+func newJobSemaphores(limit int) *jobSemaphores {
+	return &jobSemaphores{
+		limit: limit,
+		jobs:  make(map[int]*jobSemaphore),
+	}
+}
+
+// acquire waits for a free slot for the job, returning false if ctx is
+// done first. If true is returned, release must be called when the
+// execution has finished.
+func (j *jobSemaphores) acquire(ctx context.Context, jobNumber int) bool {
+	if j == nil || j.limit <= 0 {
+		return true
+	}
+
+	j.mu.Lock()
+	sem, ok := j.jobs[jobNumber]
+	if !ok {
+		sem = &jobSemaphore{slots: make(semaphore, j.limit)}
+		j.jobs[jobNumber] = sem
+	}
+	sem.users++
+	j.mu.Unlock()
+
+	if sem.slots.acquire(ctx) {
+		return true
+	}
+
+	j.done(jobNumber, sem)
+	return false
+}
+
+// release frees a slot taken by acquire for the job
+func (j *jobSemaphores) release(jobNumber int) {
+	if j == nil || j.limit <= 0 {
+		return
+	}
+
+	j.mu.Lock()
+	sem := j.jobs[jobNumber]
+	j.mu.Unlock()
+
+	sem.slots.release()
+	j.done(jobNumber, sem)
+}
+
+// fullJobs returns the numbers of the jobs whose slots are all taken, so
+// that their tasks are not claimed until a slot is released
+func (j *jobSemaphores) fullJobs() []int {
+	if j == nil || j.limit <= 0 {
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var jobNumbers []int
+	for jobNumber, sem := range j.jobs {
+		if sem.slots.full() {
+			jobNumbers = append(jobNumbers, jobNumber)
+		}
+	}
+	slices.Sort(jobNumbers)
+	return jobNumbers
+}
+
+// done removes a user of a job's semaphore, removing the semaphore once it
+// has no users left
+func (j *jobSemaphores) done(jobNumber int, sem *jobSemaphore) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	sem.users--
+	if sem.users == 0 {
+		delete(j.jobs, jobNumber)
+	}
+}
+
+// newTaskSemaphores creates a semaphore for each task type with an
+// executor. Task types without a limit configured in
+// MigratorMaxTaskExecutions use MigratorMaxConcurrentExecutions.
+func newTaskSemaphores(cfg *config.Config, taskExecutors map[domain.TaskType]executor.TaskExecutor) map[domain.TaskType]semaphore {
+	semaphores := make(map[domain.TaskType]semaphore, len(taskExecutors))
+	for taskType := range taskExecutors {
+		limit, ok := cfg.MigratorMaxTaskExecutions[string(taskType)]
+		if !ok || limit <= 0 {
+			limit = cfg.MigratorMaxConcurrentExecutions
+		}
+		semaphores[taskType] = make(semaphore, limit)
+	}
+	return semaphores
+}
+
+// newJobExecutorSemaphore creates the semaphore shared by job executions.
+// If MigratorMaxJobExecutions is not set, MigratorMaxConcurrentExecutions
+// is used.
+func newJobExecutorSemaphore(cfg *config.Config) semaphore {
+	limit := cfg.MigratorMaxJobExecutions
+	if limit <= 0 {
+		limit = cfg.MigratorMaxConcurrentExecutions
+	}
+	return make(semaphore, limit)
+}
@@ -0,0 +1,97 @@
+package migrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/executor"
+	executorMocks "github.com/ONSdigital/dis-migration-service/executor/mock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewTaskSemaphores(t *testing.T) {
+	Convey("Given a config with a limit for download tasks only", t, func() {
+		cfg := &config.Config{
+			MigratorMaxConcurrentExecutions: 5,
+			MigratorMaxTaskExecutions:       map[string]int{string(domain.TaskTypeDatasetDownload): 2},
+		}
+		taskExecutors := map[domain.TaskType]executor.TaskExecutor{
+			domain.TaskTypeDatasetDownload: &executorMocks.TaskExecutorMock{},
+			domain.TaskTypeDatasetVersion:  &executorMocks.TaskExecutorMock{},
+		}
+
+		Convey("When the task semaphores are created", func() {
+			semaphores := newTaskSemaphores(cfg, taskExecutors)
+
+			Convey("Then each task type has its own semaphore", func() {
+				So(semaphores, ShouldHaveLength, 2)
+				So(cap(semaphores[domain.TaskTypeDatasetDownload]), ShouldEqual, 2)
+				So(cap(semaphores[domain.TaskTypeDatasetVersion]), ShouldEqual, 5)
+			})
+		})
+	})
+}
+
+func TestNewJobExecutorSemaphore(t *testing.T) {
+	Convey("Given a config with a job execution limit", t, func() {
+		cfg := &config.Config{MigratorMaxConcurrentExecutions: 5, MigratorMaxJobExecutions: 2}
+
+		Convey("Then the job executor semaphore uses that limit", func() {
+			So(cap(newJobExecutorSemaphore(cfg)), ShouldEqual, 2)
+		})
+	})
+
+	Convey("Given a config without a job execution limit", t, func() {
+		cfg := &config.Config{MigratorMaxConcurrentExecutions: 5}
+
+		Convey("Then the job executor semaphore uses the default limit", func() {
+			So(cap(newJobExecutorSemaphore(cfg)), ShouldEqual, 5)
+		})
+	})
+}
+
+func TestJobSemaphores(t *testing.T) {
+	Convey("Given per-job semaphores with a limit of 1", t, func() {
+		semaphores := newJobSemaphores(1)
+		ctx := context.Background()
+
+		Convey("When a slot is taken for a job", func() {
+			So(semaphores.acquire(ctx, 1), ShouldBeTrue)
+
+			Convey("Then a slot can still be taken for another job", func() {
+				So(semaphores.acquire(ctx, 2), ShouldBeTrue)
+			})
+
+			Convey("And the job is reported as full so that its tasks are not claimed", func() {
+				So(semaphores.fullJobs(), ShouldResemble, []int{1})
+			})
+
+			Convey("And another slot for the same job waits until the context is done", func() {
+				cancelledCtx, cancel := context.WithCancel(ctx)
+				cancel()
+				So(semaphores.acquire(cancelledCtx, 1), ShouldBeFalse)
+			})
+
+			Convey("And once the slot is released the job's semaphore is removed", func() {
+				semaphores.release(1)
+				So(semaphores.jobs, ShouldBeEmpty)
+				So(semaphores.acquire(ctx, 1), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given per-job semaphores without a limit", t, func() {
+		semaphores := newJobSemaphores(0)
+
+		Convey("Then any number of slots can be taken for a job", func() {
+			for i := 0; i < 10; i++ {
+				So(semaphores.acquire(context.Background(), 1), ShouldBeTrue)
+			}
+			So(semaphores.jobs, ShouldBeEmpty)
+			So(semaphores.fullJobs(), ShouldBeEmpty)
+		})
+	})
+}
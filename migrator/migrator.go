@@ -12,7 +12,7 @@ import (
 	"github.com/ONSdigital/dis-migration-service/config"
 	"github.com/ONSdigital/dis-migration-service/domain"
 	"github.com/ONSdigital/dis-migration-service/executor"
-	"github.com/ONSdigital/dis-migration-service/slack"
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
@@ -20,38 +20,54 @@ type migrator struct {
 	jobService    application.JobService
 	jobExecutors  map[domain.JobType]executor.JobExecutor
 	taskExecutors map[domain.TaskType]executor.TaskExecutor
-	slackClient   slack.Clienter
+	notifier      notifier.Notifier
 	wg            sync.WaitGroup
-	semaphore     chan struct{}
 	pollInterval  time.Duration
 	stopJobsFunc  context.CancelFunc
 	topicCache    *cache.TopicCache
 	cfg           *config.Config
 	appClients    *clients.ClientList
+
+	// jobSlots limits concurrent job executions, taskSlots limits
+	// concurrent task executions of each task type and jobTaskSlots
+	// limits concurrent task executions of each job, so that a burst of
+	// one kind of execution cannot block the others.
+	jobSlots     semaphore
+	taskSlots    map[domain.TaskType]semaphore
+	jobTaskSlots *jobSemaphores
+
+	// jobsWake and tasksWake wake the job and task monitors before the
+	// poll interval has passed when there may be new work to claim.
+	jobsWake  chan struct{}
+	tasksWake chan struct{}
 }
 
 // NewDefaultMigrator creates a new default migrator with the
 // provided job service and clients. topicCache must not be nil.
-func NewDefaultMigrator(cfg *config.Config, jobService application.JobService, appClients *clients.ClientList, slackClient slack.Clienter, topicCache *cache.TopicCache) (*migrator, error) {
+func NewDefaultMigrator(cfg *config.Config, jobService application.JobService, appClients *clients.ClientList, jobNotifier notifier.Notifier, topicCache *cache.TopicCache) (*migrator, error) {
 	if topicCache == nil {
 		return nil, fmt.Errorf("topicCache is required but was nil - cannot initialize migrator without topic cache")
 	}
 
-	jobExecutors := getJobExecutors(jobService, appClients, cfg)
-	taskExecutors := getTaskExecutors(jobService, appClients, cfg, topicCache)
-
 	mig := &migrator{
-		jobService:    jobService,
-		jobExecutors:  jobExecutors,
-		taskExecutors: taskExecutors,
-		slackClient:   slackClient,
-		pollInterval:  cfg.MigratorPollInterval,
-		cfg:           cfg,
-		appClients:    appClients,
-		topicCache:    topicCache,
-		// Semaphore to limit concurrent migrations
-		semaphore: make(chan struct{}, cfg.MigratorMaxConcurrentExecutions),
+		notifier:     jobNotifier,
+		pollInterval: cfg.MigratorPollInterval,
+		cfg:          cfg,
+		appClients:   appClients,
+		topicCache:   topicCache,
+		jobSlots:     newJobExecutorSemaphore(cfg),
+		jobTaskSlots: newJobSemaphores(cfg.MigratorMaxExecutionsPerJob),
+		jobsWake:     make(chan struct{}, 1),
+		tasksWake:    make(chan struct{}, 1),
 	}
+
+	// Executors share the migrator's job service so that the tasks they
+	// create are claimed straight away.
+	mig.jobService = &wakingJobService{JobService: jobService, wake: mig.wake}
+	mig.jobExecutors = getJobExecutors(mig.jobService, appClients, cfg)
+	mig.taskExecutors = getTaskExecutors(mig.jobService, appClients, cfg, topicCache)
+	mig.taskSlots = newTaskSemaphores(cfg, mig.taskExecutors)
+
 	return mig, nil
 }
 
@@ -69,6 +85,14 @@ func (mig *migrator) Start(ctx context.Context) {
 		defer mig.wg.Done()
 		mig.monitorTasks(ctx)
 	}()
+
+	if mig.cfg != nil && mig.cfg.MigratorChangeStreamsEnabled {
+		mig.wg.Add(1)
+		go func() {
+			defer mig.wg.Done()
+			mig.watchWork(ctx)
+		}()
+	}
 }
 
 // Shutdown waits for all ongoing migrations to complete or times out
@@ -6,7 +6,7 @@ import (
 	"fmt"
 
 	"github.com/ONSdigital/dis-migration-service/domain"
-	"github.com/ONSdigital/dis-migration-service/slack"
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	"github.com/ONSdigital/log.go/v2/log"
 
 	appErrors "github.com/ONSdigital/dis-migration-service/errors"
@@ -119,61 +119,85 @@ func (mig *migrator) transitionJobFailure(ctx context.Context, job *domain.Job,
 		"failure_reason": failureReason,
 	})
 
-	transitioned, err := mig.transitionJob(ctx, job, rule.FailureState)
+	transitioned, err := mig.transitionJob(ctx, job, rule.FailureState, failureReason)
 	if err != nil {
 		log.Error(ctx, "failed to update job state", err)
 		return err
 	}
 
 	if transitioned {
-		log.Info(ctx, "job was transitioned - updating slack", log.Data{
+		log.Info(ctx, "job was transitioned - sending notification", log.Data{
 			"job_number":     job.JobNumber,
 			"job_state":      job.State,
 			"failure_reason": failureReason,
 		})
-		slackDetails := slack.SlackDetails{
-			"Job Number":     job.JobNumber,
-			"Job Label":      job.Label,
-			"Job State":      job.State,
-			"Failure Reason": failureReason,
-		}
-
-		err = mig.slackClient.SendInfo(ctx, mig.getJobCompletionSummary(job.State, rule.FailureState), slackDetails, false)
+		mig.updateJobThread(ctx, job, rule.FailureState)
+
+		err = mig.notifier.Notify(ctx, &notifier.Notification{
+			Severity: notifier.SeverityInfo,
+			Summary:  mig.getJobCompletionSummary(job.State, rule.FailureState),
+			Details: notifier.Details{
+				"Job Number":     job.JobNumber,
+				"Job Label":      job.Label,
+				"Job State":      job.State,
+				"Failure Reason": failureReason,
+			},
+			Success:  false,
+			JobType:  jobType(job),
+			JobState: rule.FailureState,
+			Thread:   job.NotificationThread,
+		})
 		if err != nil {
-			log.Error(ctx, "failed to send slack notification", err)
+			log.Error(ctx, "failed to send notification", err)
 			// Not a critical failure - log and continue
 		}
+
+		mig.notifyWebhooks(ctx, job, rule.FailureState, failureReason)
 	}
 
 	return nil
 }
 
 func (mig *migrator) transitionJobSuccess(ctx context.Context, job *domain.Job, rule StateTransitionRule) error {
-	transitioned, err := mig.transitionJob(ctx, job, rule.TargetState)
+	transitioned, err := mig.transitionJob(ctx, job, rule.TargetState, rule.Description)
 	if err != nil {
 		log.Error(ctx, "failed to update job state", err)
 		return err
 	}
 
 	if transitioned {
-		slackDetails := slack.SlackDetails{
-			"Job Number": job.JobNumber,
-			"Job Label":  job.Label,
-			"Old State":  job.State,
-			"New State":  rule.TargetState,
-		}
-
-		err = mig.slackClient.SendInfo(ctx, mig.getJobCompletionSummary(job.State, rule.TargetState), slackDetails, true)
+		mig.updateJobThread(ctx, job, rule.TargetState)
+
+		err = mig.notifier.Notify(ctx, &notifier.Notification{
+			Severity: notifier.SeverityInfo,
+			Summary:  mig.getJobCompletionSummary(job.State, rule.TargetState),
+			Details: notifier.Details{
+				"Job Number": job.JobNumber,
+				"Job Label":  job.Label,
+				"Old State":  job.State,
+				"New State":  rule.TargetState,
+			},
+			Success:  true,
+			JobType:  jobType(job),
+			JobState: rule.TargetState,
+			Thread:   job.NotificationThread,
+		})
 		if err != nil {
-			log.Error(ctx, "failed to send slack notification", err)
+			log.Error(ctx, "failed to send notification", err)
 			// Not a critical failure - log and continue
 		}
+
+		if rule.TargetState == domain.StateInReview {
+			mig.requestApproval(ctx, job)
+		}
+
+		mig.notifyWebhooks(ctx, job, rule.TargetState, rule.Description)
 	}
 	return nil
 }
 
-func (mig *migrator) transitionJob(ctx context.Context, job *domain.Job, targetState domain.State) (bool, error) {
-	err := mig.jobService.UpdateJobState(ctx, job.JobNumber, targetState, "")
+func (mig *migrator) transitionJob(ctx context.Context, job *domain.Job, targetState domain.State, reason string) (bool, error) {
+	err := mig.jobService.UpdateJobState(ctx, job.JobNumber, targetState, "", reason, nil)
 	if errors.Is(err, appErrors.ErrStateAlreadyAtTarget) {
 		log.Info(ctx, "transitionJob: job is already in the target state, no transition needed", log.Data{
 			"job_number": job.JobNumber,
@@ -182,16 +206,22 @@ func (mig *migrator) transitionJob(ctx context.Context, job *domain.Job, targetS
 		return false, nil // Job is already in the target state, no transition needed
 	} else if err != nil {
 		log.Error(ctx, "failed to update job state", err)
-		slackDetails := slack.SlackDetails{
-			"Job Number":     job.JobNumber,
-			"Job Label":      job.Label,
-			"Old State":      job.State,
-			"New State":      targetState,
-			"Failure reason": fmt.Sprintf("failed to transition job to %s", targetState),
-		}
-		slackErr := mig.slackClient.SendAlarm(ctx, EventUpdateJobStateFailed, nil, slackDetails)
-		if slackErr != nil {
-			log.Error(ctx, "failed to send slack notification", slackErr)
+		notifyErr := mig.notifier.Notify(ctx, &notifier.Notification{
+			Severity: notifier.SeverityAlarm,
+			Summary:  EventUpdateJobStateFailed,
+			Details: notifier.Details{
+				"Job Number":     job.JobNumber,
+				"Job Label":      job.Label,
+				"Old State":      job.State,
+				"New State":      targetState,
+				"Failure reason": fmt.Sprintf("failed to transition job to %s", targetState),
+			},
+			JobType:  jobType(job),
+			JobState: job.State,
+			Thread:   job.NotificationThread,
+		})
+		if notifyErr != nil {
+			log.Error(ctx, "failed to send notification", notifyErr)
 			// Not a critical failure - log and continue
 		}
 		return false, err
@@ -199,6 +229,19 @@ func (mig *migrator) transitionJob(ctx context.Context, job *domain.Job, targetS
 	return true, nil
 }
 
+// notifyWebhooks queues deliveries to the webhooks subscribed to a job
+// reaching the target state
+func (mig *migrator) notifyWebhooks(ctx context.Context, job *domain.Job, targetState domain.State, reason string) {
+	err := mig.jobService.NotifyWebhooks(ctx, job, job.State, targetState, reason)
+	if err != nil {
+		log.Error(ctx, "failed to notify webhooks", err, log.Data{
+			"job_number": job.JobNumber,
+			"state":      targetState,
+		})
+		// Not a critical failure - log and continue
+	}
+}
+
 // countTasksInState counts how many tasks are in a specific state
 func (mig *migrator) countTasksInState(ctx context.Context, jobNumber int, targetState domain.State) (int, error) {
 	// Get count of tasks in the target state
@@ -272,3 +315,12 @@ func isActiveStateCompletion(fromState, toState domain.State) bool {
 		return false
 	}
 }
+
+// jobType returns the type of a job, or an empty type if the job has no
+// config, so that notifications can be routed by job type
+func jobType(job *domain.Job) domain.JobType {
+	if job.Config == nil {
+		return ""
+	}
+	return job.Config.Type
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ONSdigital/dis-migration-service/clients"
 	"github.com/ONSdigital/dis-migration-service/config"
 	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	"github.com/ONSdigital/dis-migration-service/slack"
 	slackMocks "github.com/ONSdigital/dis-migration-service/slack/mocks"
 	. "github.com/smartystreets/goconvey/convey"
@@ -24,6 +25,9 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 			SendAlarmFunc: func(ctx context.Context, summary string, err error, details slack.SlackDetails) error {
 				return nil
 			},
+			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+				return nil, nil
+			},
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
@@ -51,7 +55,10 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 			CountTasksByJobNumberFunc: func(ctx context.Context, jobNumber int) (int, error) {
 				return 3, nil
 			},
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 		}
@@ -62,7 +69,7 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 		rule := StateTransitionRule{
 			TargetState:  domain.StateInReview,
@@ -80,6 +87,7 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 					So(len(mockJobService.UpdateJobStateCalls()), ShouldEqual, 1)
 					So(mockJobService.UpdateJobStateCalls()[0].JobNumber, ShouldEqual, fakeJobNumber)
 					So(mockJobService.UpdateJobStateCalls()[0].NewState, ShouldEqual, domain.StateInReview)
+					So(mockJobService.UpdateJobStateCalls()[0].Reason, ShouldEqual, rule.Description)
 				})
 
 				Convey("And a Slack notification should be sent", func() {
@@ -88,6 +96,13 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 					So(mockSlackClient.SendInfoCalls()[0].Details["Job Label"], ShouldEqual, "Test Job")
 					So(mockSlackClient.SendInfoCalls()[0].Details["Job Number"], ShouldEqual, fakeJobNumber)
 				})
+
+				Convey("And subscribed webhooks should be notified", func() {
+					So(len(mockJobService.NotifyWebhooksCalls()), ShouldEqual, 1)
+					So(mockJobService.NotifyWebhooksCalls()[0].FromState, ShouldEqual, domain.StateMigrating)
+					So(mockJobService.NotifyWebhooksCalls()[0].ToState, ShouldEqual, domain.StateInReview)
+					So(mockJobService.NotifyWebhooksCalls()[0].Reason, ShouldEqual, rule.Description)
+				})
 			})
 		})
 	})
@@ -100,6 +115,9 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 			SendAlarmFunc: func(ctx context.Context, summary string, err error, details slack.SlackDetails) error {
 				return nil
 			},
+			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+				return nil, nil
+			},
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
@@ -126,7 +144,10 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 			CountTasksByJobNumberFunc: func(ctx context.Context, jobNumber int) (int, error) {
 				return 2, nil
 			},
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 		}
@@ -137,7 +158,7 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 		rule := StateTransitionRule{
 			TargetState:  domain.StatePublished,
@@ -169,6 +190,9 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 			SendAlarmFunc: func(ctx context.Context, summary string, err error, details slack.SlackDetails) error {
 				return nil
 			},
+			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+				return nil, nil
+			},
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
@@ -195,7 +219,10 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 			CountTasksByJobNumberFunc: func(ctx context.Context, jobNumber int) (int, error) {
 				return 1, nil
 			},
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 		}
@@ -206,7 +233,7 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 		rule := StateTransitionRule{
 			TargetState:  domain.StateInReview,
@@ -239,6 +266,9 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 			SendAlarmFunc: func(ctx context.Context, summary string, err error, details slack.SlackDetails) error {
 				return nil
 			},
+			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+				return nil, nil
+			},
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
@@ -272,7 +302,7 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 		rule := StateTransitionRule{
 			TargetState:  domain.StateInReview,
@@ -305,6 +335,9 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 			SendAlarmFunc: func(ctx context.Context, summary string, err error, details slack.SlackDetails) error {
 				return nil
 			},
+			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+				return nil, nil
+			},
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
@@ -319,7 +352,7 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 		rule := StateTransitionRule{
 			TargetState:  domain.StateInReview,
@@ -353,6 +386,9 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 			SendAlarmFunc: func(ctx context.Context, summary string, err error, details slack.SlackDetails) error {
 				return nil
 			},
+			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+				return nil, nil
+			},
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
@@ -373,7 +409,7 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 		rule := StateTransitionRule{
 			TargetState:  domain.StateInReview,
@@ -403,6 +439,9 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 			SendAlarmFunc: func(ctx context.Context, summary string, err error, details slack.SlackDetails) error {
 				return nil
 			},
+			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+				return nil, nil
+			},
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
@@ -426,7 +465,7 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 		rule := StateTransitionRule{
 			TargetState:  domain.StateInReview,
@@ -456,6 +495,9 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 			SendAlarmFunc: func(ctx context.Context, summary string, err error, details slack.SlackDetails) error {
 				return nil
 			},
+			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+				return nil, nil
+			},
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
@@ -480,7 +522,10 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 			CountTasksByJobNumberFunc: func(ctx context.Context, jobNumber int) (int, error) {
 				return 1, nil
 			},
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return errors.New("database error")
 			},
 		}
@@ -491,7 +536,7 @@ func TestCheckAndUpdateJobStateBasedOnTasks(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 		rule := StateTransitionRule{
 			TargetState:  domain.StateInReview,
@@ -524,6 +569,9 @@ func TestTriggerJobStateTransitionIfComplete(t *testing.T) {
 			SendInfoFunc: func(ctx context.Context, summary string, details slack.SlackDetails, success bool) error {
 				return nil
 			},
+			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+				return nil, nil
+			},
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
@@ -548,7 +596,7 @@ func TestTriggerJobStateTransitionIfComplete(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 
 		Convey("When triggering job state transition", func() {
@@ -573,6 +621,9 @@ func TestTriggerJobStateTransitionIfComplete(t *testing.T) {
 			SendInfoFunc: func(ctx context.Context, summary string, details slack.SlackDetails, success bool) error {
 				return nil
 			},
+			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+				return nil, nil
+			},
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
@@ -600,7 +651,10 @@ func TestTriggerJobStateTransitionIfComplete(t *testing.T) {
 			CountTasksByJobNumberFunc: func(ctx context.Context, jobNumber int) (int, error) {
 				return 2, nil
 			},
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 		}
@@ -611,7 +665,7 @@ func TestTriggerJobStateTransitionIfComplete(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 
 		Convey("When triggering job state transition", func() {
@@ -638,6 +692,9 @@ func TestTriggerJobStateTransitionIfComplete(t *testing.T) {
 			SendInfoFunc: func(ctx context.Context, summary string, details slack.SlackDetails, success bool) error {
 				return nil
 			},
+			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+				return nil, nil
+			},
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
@@ -658,7 +715,7 @@ func TestTriggerJobStateTransitionIfComplete(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 
 		Convey("When triggering job state transition", func() {
@@ -676,6 +733,9 @@ func TestTriggerJobStateTransitionIfComplete(t *testing.T) {
 			SendInfoFunc: func(ctx context.Context, summary string, details slack.SlackDetails, success bool) error {
 				return nil
 			},
+			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+				return nil, nil
+			},
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
@@ -705,7 +765,10 @@ func TestTriggerJobStateTransitionIfComplete(t *testing.T) {
 			CountTasksByJobNumberFunc: func(ctx context.Context, jobNumber int) (int, error) {
 				return 2, nil
 			},
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 		}
@@ -716,7 +779,7 @@ func TestTriggerJobStateTransitionIfComplete(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 
 		Convey("When triggering job state transition", func() {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	dpRequest "github.com/ONSdigital/dp-net/v3/request"
@@ -14,7 +15,7 @@ import (
 	"github.com/ONSdigital/dis-migration-service/config"
 	"github.com/ONSdigital/dis-migration-service/domain"
 	"github.com/ONSdigital/dis-migration-service/executor"
-	"github.com/ONSdigital/dis-migration-service/slack"
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
@@ -28,6 +29,84 @@ var getTaskExecutors = func(jobService application.JobService, appClients *clien
 	return taskExecutors
 }
 
+// taskDependencies lists the guarded dependencies called when executing
+// each task type, by the pending state of the stage being executed. Tasks
+// are not claimed for a stage while the circuit breaker of one of its
+// dependencies is open.
+var taskDependencies = map[domain.TaskType]map[domain.State][]string{
+	domain.TaskTypeDatasetSeries: {
+		domain.StateSubmitted: {clients.DependencyZebedee, clients.DependencyDatasetAPI},
+		domain.StateRejected:  {clients.DependencyDatasetAPI},
+	},
+	domain.TaskTypeDatasetEdition: {
+		domain.StateSubmitted: {clients.DependencyZebedee},
+	},
+	domain.TaskTypeDatasetVersion: {
+		domain.StateSubmitted: {clients.DependencyZebedee, clients.DependencyDatasetAPI},
+		domain.StateApproved:  {clients.DependencyDatasetAPI},
+	},
+	domain.TaskTypeDatasetDownload: {
+		domain.StateSubmitted: {clients.DependencyZebedee, clients.DependencyDatasetAPI},
+	},
+}
+
+// excludedTaskTypes returns the task types with an executor that should
+// not be claimed, by the pending state of each stage, either because every
+// execution slot of the type is taken or because one of the stage's
+// dependencies is unavailable. Tasks are only claimed when they can start
+// straight away, so that the claim order decides which tasks run first.
+func (mig *migrator) excludedTaskTypes() map[domain.State][]domain.TaskType {
+	excluded := make(map[domain.State][]domain.TaskType)
+	for _, stage := range domain.GetTaskStages() {
+		var stageExcluded []domain.TaskType
+		for taskType := range mig.taskExecutors {
+			if slots, ok := mig.taskSlots[taskType]; ok && slots.full() {
+				stageExcluded = append(stageExcluded, taskType)
+				continue
+			}
+			if mig.dependencyUnavailable(taskDependencies[taskType][stage.PendingState]) {
+				stageExcluded = append(stageExcluded, taskType)
+			}
+		}
+		if len(stageExcluded) > 0 {
+			slices.Sort(stageExcluded)
+			excluded[stage.PendingState] = stageExcluded
+		}
+	}
+	return excluded
+}
+
+// allTaskTypesExcluded reports whether every task type with an executor is
+// excluded from claiming in every stage.
+func (mig *migrator) allTaskTypesExcluded(excluded map[domain.State][]domain.TaskType) bool {
+	if len(mig.taskExecutors) == 0 {
+		return false
+	}
+
+	for _, stage := range domain.GetTaskStages() {
+		if len(excluded[stage.PendingState]) < len(mig.taskExecutors) {
+			return false
+		}
+	}
+	return true
+}
+
+// dependencyUnavailable reports whether the circuit breaker of one of the
+// dependencies is open. A breaker that is ready for a trial call reports
+// itself closed only once, so that a single task is claimed to make it.
+func (mig *migrator) dependencyUnavailable(dependencies []string) bool {
+	if mig.appClients == nil || mig.appClients.Guards == nil {
+		return false
+	}
+
+	for _, dependency := range dependencies {
+		if mig.appClients.Guards.IsOpen(dependency) {
+			return true
+		}
+	}
+	return false
+}
+
 func (mig *migrator) getTaskExecutor(task *domain.Task) (executor.TaskExecutor, error) {
 	taskExecutor := mig.taskExecutors[task.Type]
 	if taskExecutor == nil {
@@ -45,20 +124,28 @@ func (mig *migrator) monitorTasks(ctx context.Context) {
 			log.Info(ctx, "stopping monitoring tasks")
 			return
 		default:
-			task, err := mig.jobService.ClaimTask(ctx)
+			excludedTypes := mig.excludedTaskTypes()
+			if mig.allTaskTypesExcluded(excludedTypes) {
+				log.Info(ctx, "not claiming tasks until a task type has a free slot and its dependencies are available", log.Data{"excluded_task_types": excludedTypes})
+				if !mig.waitForWork(ctx, mig.tasksWake) {
+					log.Info(ctx, "stopping monitoring tasks")
+					return
+				}
+				continue
+			}
+
+			task, err := mig.jobService.ClaimTask(ctx, excludedTypes, mig.jobTaskSlots.fullJobs())
 			if err != nil && !errors.Is(err, context.Canceled) {
 				log.Error(ctx, "error claiming task", err)
 				time.Sleep(mig.pollInterval)
 				continue
 			}
 			if task == nil {
-				select {
-				case <-ctx.Done():
+				if !mig.waitForWork(ctx, mig.tasksWake) {
 					log.Info(ctx, "stopping monitoring tasks")
 					return
-				case <-time.After(mig.pollInterval):
-					continue
 				}
+				continue
 			}
 			log.Info(ctx, "claimed task", log.Data{"task_id": task.ID, "task_state": task.State})
 			mig.executeTask(ctx, task)
@@ -66,24 +153,39 @@ func (mig *migrator) monitorTasks(ctx context.Context) {
 	}
 }
 
-// executeTask executes a task based on its state
+// executeTask executes a task based on its state. The slots of the task's
+// type and job are taken before returning, type first, and are free when
+// the task was claimed by monitorTasks, so the next claim sees the
+// remaining capacity.
 func (mig *migrator) executeTask(ctx context.Context, task *domain.Task) {
 	requestID := dpRequest.NewRequestID(RequestIDLength)
 	ctx = dpRequest.WithRequestId(ctx, requestID)
+	// Zebedee pages read by the task are cached for the rest of its job
+	ctx = clients.WithJobNumber(ctx, task.JobNumber)
 	log.Info(ctx, "executing task", log.Data{"task_id": task.ID, "task_state": task.State})
+
+	slots, limited := mig.taskSlots[task.Type]
+	if limited && !slots.acquire(ctx) {
+		return
+	}
+	if !mig.jobTaskSlots.acquire(ctx, task.JobNumber) {
+		if limited {
+			slots.release()
+		}
+		return
+	}
+
 	mig.wg.Add(1)
 	go func() {
 		defer mig.wg.Done()
+		defer mig.wakeTasks()
+		if limited {
+			defer slots.release()
+		}
+		defer mig.jobTaskSlots.release(task.JobNumber)
 
 		logData := log.Data{"task_id": task.ID, "job_number": task.JobNumber, "task_type": task.Type}
 
-		select {
-		case mig.semaphore <- struct{}{}:
-			defer func() { <-mig.semaphore }()
-		case <-ctx.Done():
-			return
-		}
-
 		taskExecutor, err := mig.getTaskExecutor(task)
 		if err != nil {
 			log.Error(ctx, "failed to get task executor", err, logData)
@@ -126,7 +228,7 @@ func (mig *migrator) executeTask(ctx context.Context, task *domain.Task) {
 
 func (mig *migrator) failTask(ctx context.Context, task *domain.Task, originalErr error, failureReason string) error {
 	logData := log.Data{"task_id": task.ID, "job_number": task.JobNumber, "task_state": task.State}
-	slackDetails := slack.SlackDetails{
+	details := notifier.Details{
 		"Task ID":        task.ID,
 		"Job Number":     task.JobNumber,
 		"Task State":     task.State,
@@ -149,17 +251,22 @@ func (mig *migrator) failTask(ctx context.Context, task *domain.Task, originalEr
 
 	logData["failure_state"] = failureState
 
-	err := mig.jobService.UpdateTaskState(ctx, task.ID, failureState)
+	err := mig.jobService.UpdateTaskState(ctx, task.ID, failureState, fmt.Sprintf("%s: %s", failureReason, originalErr.Error()))
 	if err != nil {
 		log.Error(ctx, "failed to update task state to failed", err, logData)
 
-		slackDetails["Failure State"] = failureState
-		slackDetails["Original Error"] = originalErr.Error()
-		slackDetails["Update Error"] = err.Error()
+		details["Failure State"] = failureState
+		details["Original Error"] = originalErr.Error()
+		details["Update Error"] = err.Error()
 
-		slackErr := mig.slackClient.SendAlarm(ctx, EventUpdateTaskStateFailed, nil, slackDetails)
-		if slackErr != nil {
-			log.Error(ctx, "failed to send slack notification", slackErr, logData)
+		notifyErr := mig.notifier.Notify(ctx, &notifier.Notification{
+			Severity: notifier.SeverityAlarm,
+			Summary:  EventUpdateTaskStateFailed,
+			Details:  details,
+			Thread:   mig.getJobThread(ctx, task.JobNumber),
+		})
+		if notifyErr != nil {
+			log.Error(ctx, "failed to send notification", notifyErr, logData)
 		}
 		return err
 	}
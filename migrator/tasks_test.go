@@ -14,6 +14,7 @@ import (
 	"github.com/ONSdigital/dis-migration-service/domain"
 	"github.com/ONSdigital/dis-migration-service/executor"
 	executorMocks "github.com/ONSdigital/dis-migration-service/executor/mock"
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -42,10 +43,10 @@ func TestMigratorExecuteTask(t *testing.T) {
 		}
 
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, reason string) error {
 				return nil
 			},
-			ClaimTaskFunc: func(ctx context.Context) (*domain.Task, error) {
+			ClaimTaskFunc: func(ctx context.Context, excludedTypes map[domain.State][]domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
 				return &domain.Task{
 					Type: fakeTaskType,
 				}, nil
@@ -69,7 +70,7 @@ func TestMigratorExecuteTask(t *testing.T) {
 			MigratorMaxConcurrentExecutions: 1,
 		}
 
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, mockTopicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), mockTopicCache)
 
 		Convey("When a task in state migrating is executed", func() {
 			task := &domain.Task{
@@ -154,7 +155,7 @@ func TestMigratorExecuteTask(t *testing.T) {
 		mockTopicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
 
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, reason string) error {
 				return nil
 			},
 			GetNextJobNumberFunc: func(ctx context.Context) (*domain.Counter, error) {
@@ -167,7 +168,10 @@ func TestMigratorExecuteTask(t *testing.T) {
 					State:     domain.StateMigrating,
 				}, nil
 			},
-			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID string) error {
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
 				return nil
 			},
 			GetJobTasksFunc: func(ctx context.Context, states []domain.State, jobNumber int, limit, offset int) ([]*domain.Task, int, error) {
@@ -184,7 +188,7 @@ func TestMigratorExecuteTask(t *testing.T) {
 			MigratorMaxConcurrentExecutions: 1,
 		}
 
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, mockTopicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), mockTopicCache)
 
 		Convey("When a task is executed", func() {
 			task := &domain.Task{
@@ -218,7 +222,7 @@ func TestMigratorExecuteTask(t *testing.T) {
 		mockTopicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
 
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, reason string) error {
 				return nil
 			},
 			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
@@ -245,7 +249,7 @@ func TestMigratorExecuteTask(t *testing.T) {
 			MigratorMaxConcurrentExecutions: 1,
 		}
 
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, mockTopicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), mockTopicCache)
 
 		Convey("When a task is executed that errors during migration", func() {
 			task := &domain.Task{
@@ -267,7 +271,7 @@ func TestMigratorExecuteTask(t *testing.T) {
 func TestMigratorFailTask(t *testing.T) {
 	Convey("Given a migrator with a mock job service", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, reason string) error {
 				return nil
 			},
 			GetNextJobNumberFunc: func(ctx context.Context) (*domain.Counter, error) {
@@ -281,7 +285,7 @@ func TestMigratorFailTask(t *testing.T) {
 		cfg := &config.Config{}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 
 		Convey("When failTask is called for a task with an active state", func() {
@@ -297,6 +301,7 @@ func TestMigratorFailTask(t *testing.T) {
 				So(len(mockJobService.UpdateTaskStateCalls()), ShouldEqual, 1)
 				So(mockJobService.UpdateTaskStateCalls()[0].TaskID, ShouldEqual, fakeTaskID)
 				So(mockJobService.UpdateTaskStateCalls()[0].NewState, ShouldEqual, domain.StateFailedMigration)
+				So(mockJobService.UpdateTaskStateCalls()[0].Reason, ShouldEqual, failureReasonExecutionFailed+": test error")
 			})
 		})
 
@@ -333,7 +338,7 @@ func TestMigratorFailTask(t *testing.T) {
 
 	Convey("Given a migrator with a mock job service that errors when updating task state", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, reason string) error {
 				return errors.New("update error")
 			},
 			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
@@ -355,7 +360,7 @@ func TestMigratorFailTask(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx := context.Background()
 
 		Convey("When failTask is called for a task", func() {
@@ -395,7 +400,7 @@ func TestGetTaskExecutor(t *testing.T) {
 		cfg := &config.Config{}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 
 		Convey("When getTaskExecutor is called for a task with a known type", func() {
 			task := &domain.Task{
@@ -429,7 +434,7 @@ func TestGetTaskExecutor(t *testing.T) {
 func TestMonitorTasks(t *testing.T) {
 	Convey("Given a migrator with a mock job service that returns no tasks", t, func() {
 		mockJobService := &applicationMocks.JobServiceMock{
-			ClaimTaskFunc: func(ctx context.Context) (*domain.Task, error) {
+			ClaimTaskFunc: func(ctx context.Context, excludedTypes map[domain.State][]domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
 				return nil, nil
 			},
 			GetNextJobNumberFunc: func(ctx context.Context) (*domain.Counter, error) {
@@ -441,11 +446,12 @@ func TestMonitorTasks(t *testing.T) {
 		mockClients := &clients.ClientList{}
 		mockSlackClient := createMockSlackClient()
 		cfg := &config.Config{
-			MigratorPollInterval: 10 * time.Millisecond,
+			MigratorPollInterval:            10 * time.Millisecond,
+			MigratorMaxConcurrentExecutions: 1,
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx, cancel := context.WithCancel(context.Background())
 
 		Convey("When monitorTasks is started and runs one iteration", func() {
@@ -466,7 +472,7 @@ func TestMonitorTasks(t *testing.T) {
 		requests := 0
 
 		mockJobService := &applicationMocks.JobServiceMock{
-			ClaimTaskFunc: func(ctx context.Context) (*domain.Task, error) {
+			ClaimTaskFunc: func(ctx context.Context, excludedTypes map[domain.State][]domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
 				if requests == 0 {
 					requests += 1
 					return &domain.Task{
@@ -477,7 +483,7 @@ func TestMonitorTasks(t *testing.T) {
 				}
 				return nil, nil
 			},
-			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State) error {
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, reason string) error {
 				return nil
 			},
 			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
@@ -518,7 +524,7 @@ func TestMonitorTasks(t *testing.T) {
 		}
 
 		topicCache, _ := cache.NewPopulatedTopicCacheForTest(context.Background())
-		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, mockSlackClient, topicCache)
+		mig, _ := NewDefaultMigrator(cfg, mockJobService, mockClients, notifier.NewSlackNotifier(mockSlackClient, nil), topicCache)
 		ctx, cancel := context.WithCancel(context.Background())
 
 		Convey("When monitorTasks is started and runs one iteration", func() {
@@ -539,3 +545,174 @@ func TestMonitorTasks(t *testing.T) {
 		})
 	})
 }
+
+func TestExcludedTaskTypes(t *testing.T) {
+	Convey("Given a migrator whose dataset API circuit breaker is open", t, func() {
+		guards := clients.NewGuards(
+			clients.GuardConfig{FailureThreshold: 1, OpenDuration: time.Hour},
+			clients.GuardConfig{FailureThreshold: 1, OpenDuration: time.Hour},
+		)
+		_ = guards.DatasetAPI.Do(context.Background(), func() error { return errors.New("dataset API unavailable") })
+
+		mockJobService := &applicationMocks.JobServiceMock{
+			ClaimTaskFunc: func(ctx context.Context, excludedTypes map[domain.State][]domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
+				return nil, nil
+			},
+		}
+
+		mig := &migrator{
+			jobService: mockJobService,
+			taskExecutors: map[domain.TaskType]executor.TaskExecutor{
+				domain.TaskTypeDatasetEdition: &executorMocks.TaskExecutorMock{},
+				domain.TaskTypeDatasetVersion: &executorMocks.TaskExecutorMock{},
+			},
+			appClients:   &clients.ClientList{Guards: guards},
+			pollInterval: 10 * time.Millisecond,
+		}
+
+		expected := map[domain.State][]domain.TaskType{
+			domain.StateSubmitted: {domain.TaskTypeDatasetVersion},
+			domain.StateApproved:  {domain.TaskTypeDatasetVersion},
+		}
+
+		Convey("Then task types are excluded from claiming for the stages that call the dataset API", func() {
+			So(mig.excludedTaskTypes(), ShouldResemble, expected)
+		})
+
+		Convey("When tasks are monitored", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			go mig.monitorTasks(ctx)
+			time.Sleep(25 * time.Millisecond)
+			cancel()
+
+			Convey("Then tasks are claimed excluding the task types and stages that call the dataset API", func() {
+				So(len(mockJobService.ClaimTaskCalls()), ShouldBeGreaterThanOrEqualTo, 1)
+				So(mockJobService.ClaimTaskCalls()[0].ExcludedTypes, ShouldResemble, expected)
+			})
+		})
+
+		Convey("When the Zebedee circuit breaker is also open", func() {
+			_ = guards.Zebedee.Do(context.Background(), func() error { return errors.New("zebedee unavailable") })
+
+			Convey("Then no tasks are migrated but tasks can still be published and reverted", func() {
+				excluded := mig.excludedTaskTypes()
+				So(excluded, ShouldResemble, map[domain.State][]domain.TaskType{
+					domain.StateSubmitted: {domain.TaskTypeDatasetEdition, domain.TaskTypeDatasetVersion},
+					domain.StateApproved:  {domain.TaskTypeDatasetVersion},
+				})
+				So(mig.allTaskTypesExcluded(excluded), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a migrator whose dataset API circuit breaker has been open for its open duration", t, func() {
+		guards := clients.NewGuards(
+			clients.GuardConfig{FailureThreshold: 1, OpenDuration: time.Hour},
+			clients.GuardConfig{FailureThreshold: 1, OpenDuration: 10 * time.Millisecond},
+		)
+		_ = guards.DatasetAPI.Do(context.Background(), func() error { return errors.New("dataset API unavailable") })
+		time.Sleep(20 * time.Millisecond)
+
+		mig := &migrator{
+			taskExecutors: map[domain.TaskType]executor.TaskExecutor{
+				domain.TaskTypeDatasetEdition: &executorMocks.TaskExecutorMock{},
+				domain.TaskTypeDatasetVersion: &executorMocks.TaskExecutorMock{},
+			},
+			appClients: &clients.ClientList{Guards: guards},
+		}
+
+		countExcluded := func(excluded map[domain.State][]domain.TaskType) int {
+			count := 0
+			for _, taskTypes := range excluded {
+				count += len(taskTypes)
+			}
+			return count
+		}
+
+		Convey("When the excluded task types are worked out twice", func() {
+			first := mig.excludedTaskTypes()
+			second := mig.excludedTaskTypes()
+
+			Convey("Then a single stage that calls the dataset API may be claimed the first time, to make a trial call", func() {
+				So(countExcluded(first), ShouldEqual, 1)
+			})
+
+			Convey("And every stage that calls the dataset API is excluded the second time", func() {
+				So(countExcluded(second), ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given a migrator whose download task slots are all taken", t, func() {
+		mockJobService := &applicationMocks.JobServiceMock{
+			ClaimTaskFunc: func(ctx context.Context, excludedTypes map[domain.State][]domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
+				return nil, nil
+			},
+		}
+
+		downloadSlots := make(semaphore, 1)
+		downloadSlots <- struct{}{}
+		versionSlots := make(semaphore, 1)
+
+		mig := &migrator{
+			jobService: mockJobService,
+			taskExecutors: map[domain.TaskType]executor.TaskExecutor{
+				domain.TaskTypeDatasetDownload: &executorMocks.TaskExecutorMock{},
+				domain.TaskTypeDatasetVersion:  &executorMocks.TaskExecutorMock{},
+			},
+			taskSlots: map[domain.TaskType]semaphore{
+				domain.TaskTypeDatasetDownload: downloadSlots,
+				domain.TaskTypeDatasetVersion:  versionSlots,
+			},
+			pollInterval: time.Hour,
+			tasksWake:    make(chan struct{}, 1),
+		}
+
+		excludedInEveryStage := func(taskType domain.TaskType) map[domain.State][]domain.TaskType {
+			excluded := make(map[domain.State][]domain.TaskType)
+			for _, stage := range domain.GetTaskStages() {
+				excluded[stage.PendingState] = []domain.TaskType{taskType}
+			}
+			return excluded
+		}
+
+		Convey("Then download tasks are excluded from claiming in every stage", func() {
+			So(mig.excludedTaskTypes(), ShouldResemble, excludedInEveryStage(domain.TaskTypeDatasetDownload))
+		})
+
+		Convey("When the version task slots are also taken and tasks are monitored", func() {
+			versionSlots <- struct{}{}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go mig.monitorTasks(ctx)
+			time.Sleep(10 * time.Millisecond)
+
+			Convey("Then no tasks are claimed", func() {
+				So(mockJobService.ClaimTaskCalls(), ShouldBeEmpty)
+			})
+
+			Convey("And once a download slot is released tasks are claimed straight away", func() {
+				downloadSlots.release()
+				mig.wakeTasks()
+				time.Sleep(10 * time.Millisecond)
+
+				So(mockJobService.ClaimTaskCalls(), ShouldHaveLength, 1)
+				So(mockJobService.ClaimTaskCalls()[0].ExcludedTypes, ShouldResemble, excludedInEveryStage(domain.TaskTypeDatasetVersion))
+			})
+		})
+	})
+
+	Convey("Given a migrator whose clients are not guarded", t, func() {
+		mig := &migrator{
+			taskExecutors: map[domain.TaskType]executor.TaskExecutor{
+				domain.TaskTypeDatasetVersion: &executorMocks.TaskExecutorMock{},
+			},
+			appClients: &clients.ClientList{},
+		}
+
+		Convey("Then no task types are excluded from claiming", func() {
+			So(mig.excludedTaskTypes(), ShouldBeEmpty)
+		})
+	})
+}
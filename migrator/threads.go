@@ -0,0 +1,57 @@
+package migrator
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/notifier"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// updateJobThread starts the notification thread for a job if it does not
+// have one yet, as when the job is first claimed, or otherwise updates the
+// thread's parent message to show the job's state. Threads are not started
+// when jobs are created, so that creating jobs does not wait on Slack.
+// Failures are logged but do not affect the job.
+func (mig *migrator) updateJobThread(ctx context.Context, job *domain.Job, state domain.State) {
+	threader, ok := mig.notifier.(notifier.Threader)
+	if !ok {
+		return
+	}
+
+	logData := log.Data{"job_number": job.JobNumber, "state": state}
+	notification := notifier.NewJobThreadNotification(job, state)
+
+	if job.NotificationThread != nil {
+		if err := threader.UpdateThread(ctx, job.NotificationThread, notification); err != nil {
+			log.Error(ctx, "failed to update job notification thread", err, logData)
+		}
+		return
+	}
+
+	thread, err := threader.StartThread(ctx, notification)
+	if err != nil {
+		log.Error(ctx, "failed to start job notification thread", err, logData)
+		return
+	}
+	if thread == nil {
+		return
+	}
+
+	job.NotificationThread = thread
+
+	if err := mig.jobService.UpdateJobNotificationThread(ctx, job.JobNumber, thread); err != nil {
+		log.Error(ctx, "failed to store job notification thread", err, logData)
+	}
+}
+
+// getJobThread returns the notification thread of the job with the given
+// number, or nil if it has none or cannot be found
+func (mig *migrator) getJobThread(ctx context.Context, jobNumber int) *domain.NotificationThread {
+	job, err := mig.jobService.GetJob(ctx, jobNumber)
+	if err != nil {
+		log.Error(ctx, "failed to get job notification thread", err, log.Data{"job_number": jobNumber})
+		return nil
+	}
+	return job.NotificationThread
+}
@@ -0,0 +1,155 @@
+package migrator
+
+import (
+	"context"
+	"testing"
+
+	applicationMocks "github.com/ONSdigital/dis-migration-service/application/mock"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/notifier"
+	notifierMocks "github.com/ONSdigital/dis-migration-service/notifier/mocks"
+	"github.com/ONSdigital/dis-migration-service/slack"
+	slackMocks "github.com/ONSdigital/dis-migration-service/slack/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func createMockThreadSlackClient() *slackMocks.ClienterMock {
+	return &slackMocks.ClienterMock{
+		SendInfoFunc: func(ctx context.Context, summary string, details slack.SlackDetails, success bool) error {
+			return nil
+		},
+		StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+			return &slack.Thread{Channel: "C123", Timestamp: "1700000000.000100"}, nil
+		},
+		UpdateThreadFunc: func(ctx context.Context, thread *slack.Thread, summary string, colour slack.Colour, details slack.SlackDetails) error {
+			return nil
+		},
+		ReplyToThreadFunc: func(ctx context.Context, thread *slack.Thread, colour slack.Colour, emoji slack.Emoji, summary string, err error, details slack.SlackDetails) error {
+			return nil
+		},
+	}
+}
+
+func TestUpdateJobThread(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a migrator that sends notifications to Slack", t, func() {
+		mockSlackClient := createMockThreadSlackClient()
+		mockJobService := &applicationMocks.JobServiceMock{
+			UpdateJobNotificationThreadFunc: func(ctx context.Context, jobNumber int, thread *domain.NotificationThread) error {
+				return nil
+			},
+		}
+
+		mig := &migrator{
+			jobService: mockJobService,
+			notifier:   notifier.NewSlackNotifier(mockSlackClient, nil),
+		}
+
+		Convey("When a job without a notification thread is updated", func() {
+			job := &domain.Job{JobNumber: 12, Label: "Test Job", State: domain.StateMigrating}
+
+			mig.updateJobThread(ctx, job, domain.StateMigrating)
+
+			Convey("Then a thread is started showing the job is in progress", func() {
+				So(mockSlackClient.StartThreadCalls(), ShouldHaveLength, 1)
+				So(mockSlackClient.StartThreadCalls()[0].Summary, ShouldEqual, "Migration job 12: Migrating")
+				So(mockSlackClient.StartThreadCalls()[0].Colour, ShouldEqual, slack.BlueColour)
+				So(mockSlackClient.StartThreadCalls()[0].Details["Job Label"], ShouldEqual, "Test Job")
+			})
+
+			Convey("And the thread is stored on the job", func() {
+				expectedThread := &domain.NotificationThread{Channel: "C123", Timestamp: "1700000000.000100"}
+				So(job.NotificationThread, ShouldResemble, expectedThread)
+				So(mockJobService.UpdateJobNotificationThreadCalls(), ShouldHaveLength, 1)
+				So(mockJobService.UpdateJobNotificationThreadCalls()[0].JobNumber, ShouldEqual, 12)
+				So(mockJobService.UpdateJobNotificationThreadCalls()[0].Thread, ShouldResemble, expectedThread)
+			})
+		})
+
+		Convey("When a job with a notification thread is updated", func() {
+			job := &domain.Job{
+				JobNumber:          12,
+				State:              domain.StateMigrating,
+				NotificationThread: &domain.NotificationThread{Channel: "C123", Timestamp: "1700000000.000100"},
+			}
+
+			mig.updateJobThread(ctx, job, domain.StateFailedMigration)
+
+			Convey("Then the thread's parent message is updated to show the job's state", func() {
+				So(mockSlackClient.StartThreadCalls(), ShouldBeEmpty)
+				So(mockSlackClient.UpdateThreadCalls(), ShouldHaveLength, 1)
+				So(mockSlackClient.UpdateThreadCalls()[0].Thread.Timestamp, ShouldEqual, "1700000000.000100")
+				So(mockSlackClient.UpdateThreadCalls()[0].Summary, ShouldEqual, "Migration job 12: Failed migration")
+				So(mockSlackClient.UpdateThreadCalls()[0].Colour, ShouldEqual, slack.RedColour)
+				So(mockJobService.UpdateJobNotificationThreadCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a migrator with a notifier that does not support threads", t, func() {
+		mockNotifier := &notifierMocks.NotifierMock{}
+		mockJobService := &applicationMocks.JobServiceMock{}
+
+		mig := &migrator{
+			jobService: mockJobService,
+			notifier:   mockNotifier,
+		}
+
+		Convey("When a job is updated", func() {
+			job := &domain.Job{JobNumber: 12, State: domain.StateMigrating}
+
+			mig.updateJobThread(ctx, job, domain.StateMigrating)
+
+			Convey("Then no thread is started", func() {
+				So(job.NotificationThread, ShouldBeNil)
+				So(mockJobService.UpdateJobNotificationThreadCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestTransitionJobSuccessWithThread(t *testing.T) {
+	Convey("Given a migrator and a job with a notification thread", t, func() {
+		mockSlackClient := createMockThreadSlackClient()
+		mockJobService := &applicationMocks.JobServiceMock{
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
+				return nil
+			},
+			NotifyWebhooksFunc: func(ctx context.Context, job *domain.Job, fromState, toState domain.State, reason string) error {
+				return nil
+			},
+		}
+
+		mig := &migrator{
+			jobService: mockJobService,
+			notifier:   notifier.NewSlackNotifier(mockSlackClient, nil),
+		}
+
+		job := &domain.Job{
+			JobNumber:          12,
+			Label:              "Test Job",
+			State:              domain.StateMigrating,
+			NotificationThread: &domain.NotificationThread{Channel: "C123", Timestamp: "1700000000.000100"},
+		}
+
+		Convey("When the job is transitioned to its target state", func() {
+			err := mig.transitionJobSuccess(context.Background(), job, mig.GetStateTransitionRules()[domain.StateMigrating])
+
+			Convey("Then the completion notification is sent as a reply in the thread", func() {
+				So(err, ShouldBeNil)
+				So(mockSlackClient.SendInfoCalls(), ShouldBeEmpty)
+				So(mockSlackClient.ReplyToThreadCalls(), ShouldHaveLength, 1)
+				So(mockSlackClient.ReplyToThreadCalls()[0].Thread.Channel, ShouldEqual, "C123")
+				So(mockSlackClient.ReplyToThreadCalls()[0].Summary, ShouldEqual, "Job migration completed successfully")
+				So(mockSlackClient.ReplyToThreadCalls()[0].Colour, ShouldEqual, slack.GreenColour)
+			})
+
+			Convey("And the thread's parent message shows the new state", func() {
+				So(mockSlackClient.UpdateThreadCalls(), ShouldHaveLength, 1)
+				So(mockSlackClient.UpdateThreadCalls()[0].Summary, ShouldEqual, "Migration job 12: In review")
+				So(mockSlackClient.UpdateThreadCalls()[0].Colour, ShouldEqual, slack.BlueColour)
+			})
+		})
+	})
+}
@@ -0,0 +1,114 @@
+package migrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/application"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// wake wakes the job and task monitors if they are waiting for work. Wakes
+// are coalesced, so a monitor that is busy claims again as soon as it is
+// next idle rather than once per wake.
+func (mig *migrator) wake() {
+	mig.wakeJobs()
+	mig.wakeTasks()
+}
+
+// wakeJobs wakes the job monitor if it is waiting for work.
+func (mig *migrator) wakeJobs() {
+	signal(mig.jobsWake)
+}
+
+// wakeTasks wakes the task monitor if it is waiting for work, such as a
+// free execution slot.
+func (mig *migrator) wakeTasks() {
+	signal(mig.tasksWake)
+}
+
+// signal sends on a wake channel without waiting, dropping the wake if one
+// is already pending.
+func signal(wake chan struct{}) {
+	if wake == nil {
+		return
+	}
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+// waitForWork waits until the monitor is woken or the poll interval has
+// passed, returning false if the context is done first.
+func (mig *migrator) waitForWork(ctx context.Context, wake <-chan struct{}) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-wake:
+		return true
+	case <-time.After(mig.pollInterval):
+		return true
+	}
+}
+
+// watchWork wakes the monitors from the job service's change stream until
+// the context is done. If the stream fails it is reopened after the poll
+// interval, with the monitors falling back to polling in the meantime.
+func (mig *migrator) watchWork(ctx context.Context) {
+	log.Info(ctx, "watching for work")
+
+	for {
+		err := mig.jobService.WatchWork(ctx, mig.wake)
+		if ctx.Err() != nil {
+			log.Info(ctx, "stopping watching for work")
+			return
+		}
+		if err != nil {
+			log.Error(ctx, "error watching for work, falling back to polling", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info(ctx, "stopping watching for work")
+			return
+		case <-time.After(mig.pollInterval):
+		}
+	}
+}
+
+// wakingJobService wakes the monitors when the migrator itself creates a
+// task or changes the state of a job or task, so that follow-on work is
+// claimed without waiting for the next poll.
+type wakingJobService struct {
+	application.JobService
+	wake func()
+}
+
+// CreateTask creates the task and wakes the monitors.
+func (w *wakingJobService) CreateTask(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error) {
+	createdTask, err := w.JobService.CreateTask(ctx, jobNumber, task)
+	if err == nil {
+		w.wake()
+	}
+	return createdTask, err
+}
+
+// UpdateTaskState updates the task state and wakes the monitors.
+func (w *wakingJobService) UpdateTaskState(ctx context.Context, taskID string, newState domain.State, reason string) error {
+	err := w.JobService.UpdateTaskState(ctx, taskID, newState, reason)
+	if err == nil {
+		w.wake()
+	}
+	return err
+}
+
+// UpdateJobState updates the job state and wakes the monitors.
+func (w *wakingJobService) UpdateJobState(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
+	err := w.JobService.UpdateJobState(ctx, jobNumber, newState, userID, reason, opts)
+	if err == nil {
+		w.wake()
+	}
+	return err
+}
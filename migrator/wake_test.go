@@ -0,0 +1,191 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	applicationMocks "github.com/ONSdigital/dis-migration-service/application/mock"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWaitForWork(t *testing.T) {
+	Convey("Given a migrator with a long poll interval", t, func() {
+		mig := &migrator{
+			pollInterval: time.Hour,
+			jobsWake:     make(chan struct{}, 1),
+			tasksWake:    make(chan struct{}, 1),
+		}
+
+		Convey("When the migrator is woken before waiting", func() {
+			mig.wake()
+			mig.wake()
+
+			Convey("Then both monitors stop waiting straight away", func() {
+				So(mig.waitForWork(context.Background(), mig.jobsWake), ShouldBeTrue)
+				So(mig.waitForWork(context.Background(), mig.tasksWake), ShouldBeTrue)
+
+				Convey("And repeated wakes are coalesced", func() {
+					So(mig.jobsWake, ShouldBeEmpty)
+					So(mig.tasksWake, ShouldBeEmpty)
+				})
+			})
+		})
+
+		Convey("When the context is done while waiting", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			Convey("Then waiting returns false", func() {
+				So(mig.waitForWork(ctx, mig.tasksWake), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a migrator without wake channels", t, func() {
+		mig := &migrator{pollInterval: time.Millisecond}
+
+		Convey("When the migrator is woken", func() {
+			mig.wake()
+
+			Convey("Then waiting falls back to the poll interval", func() {
+				So(mig.waitForWork(context.Background(), mig.tasksWake), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestWakingJobService(t *testing.T) {
+	Convey("Given a waking job service", t, func() {
+		var updateErr error
+		mockJobService := &applicationMocks.JobServiceMock{
+			CreateTaskFunc: func(ctx context.Context, jobNumber int, task *domain.Task) (*domain.Task, error) {
+				return task, updateErr
+			},
+			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, reason string) error {
+				return updateErr
+			},
+			UpdateJobStateFunc: func(ctx context.Context, jobNumber int, newState domain.State, userID, reason string, opts *domain.JobStateOptions) error {
+				return updateErr
+			},
+		}
+
+		wakes := 0
+		jobService := &wakingJobService{JobService: mockJobService, wake: func() { wakes++ }}
+
+		Convey("When a task is created and job and task states are updated", func() {
+			_, err := jobService.CreateTask(context.Background(), fakeJobNumber, &domain.Task{ID: fakeTaskID})
+			So(err, ShouldBeNil)
+			So(jobService.UpdateTaskState(context.Background(), fakeTaskID, domain.StateInReview, ""), ShouldBeNil)
+			So(jobService.UpdateJobState(context.Background(), fakeJobNumber, domain.StateInReview, "", "", nil), ShouldBeNil)
+
+			Convey("Then the calls are passed to the job service", func() {
+				So(mockJobService.CreateTaskCalls(), ShouldHaveLength, 1)
+				So(mockJobService.UpdateTaskStateCalls(), ShouldHaveLength, 1)
+				So(mockJobService.UpdateJobStateCalls(), ShouldHaveLength, 1)
+
+				Convey("And the monitors are woken after each", func() {
+					So(wakes, ShouldEqual, 3)
+				})
+			})
+		})
+
+		Convey("When the job service returns an error", func() {
+			updateErr = errors.New("update failed")
+
+			_, err := jobService.CreateTask(context.Background(), fakeJobNumber, &domain.Task{ID: fakeTaskID})
+			So(err, ShouldEqual, updateErr)
+			So(jobService.UpdateTaskState(context.Background(), fakeTaskID, domain.StateInReview, ""), ShouldEqual, updateErr)
+			So(jobService.UpdateJobState(context.Background(), fakeJobNumber, domain.StateInReview, "", "", nil), ShouldEqual, updateErr)
+
+			Convey("Then the monitors are not woken", func() {
+				So(wakes, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestWatchWork(t *testing.T) {
+	Convey("Given a migrator whose job service change stream fails", t, func() {
+		var watches atomic.Int32
+		mockJobService := &applicationMocks.JobServiceMock{
+			WatchWorkFunc: func(ctx context.Context, notify func()) error {
+				watches.Add(1)
+				notify()
+				return errors.New("change streams are not supported")
+			},
+		}
+
+		mig := &migrator{
+			jobService:   mockJobService,
+			pollInterval: 5 * time.Millisecond,
+			jobsWake:     make(chan struct{}, 1),
+			tasksWake:    make(chan struct{}, 1),
+		}
+
+		Convey("When work is watched", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				mig.watchWork(ctx)
+				close(done)
+			}()
+
+			time.Sleep(25 * time.Millisecond)
+			cancel()
+
+			Convey("Then the monitors are woken by the change stream", func() {
+				So(mig.jobsWake, ShouldHaveLength, 1)
+				So(mig.tasksWake, ShouldHaveLength, 1)
+
+				Convey("And the change stream is reopened after the poll interval", func() {
+					So(watches.Load(), ShouldBeGreaterThan, 1)
+
+					Convey("And watching stops when the context is done", func() {
+						stopped := false
+						select {
+						case <-done:
+							stopped = true
+						case <-time.After(time.Second):
+						}
+						So(stopped, ShouldBeTrue)
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestMonitorTasksWoken(t *testing.T) {
+	Convey("Given a migrator with a long poll interval and no tasks to claim", t, func() {
+		mockJobService := &applicationMocks.JobServiceMock{
+			ClaimTaskFunc: func(ctx context.Context, excludedTypes map[domain.State][]domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
+				return nil, nil
+			},
+		}
+
+		mig := &migrator{
+			jobService:   mockJobService,
+			pollInterval: time.Hour,
+			jobsWake:     make(chan struct{}, 1),
+			tasksWake:    make(chan struct{}, 1),
+		}
+
+		Convey("When the migrator is woken while tasks are monitored", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go mig.monitorTasks(ctx)
+
+			time.Sleep(10 * time.Millisecond)
+			mig.wake()
+			time.Sleep(10 * time.Millisecond)
+
+			Convey("Then tasks are claimed again without waiting for the poll interval", func() {
+				So(mockJobService.ClaimTaskCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+}
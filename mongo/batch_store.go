@@ -0,0 +1,54 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateBatch creates a new migration batch.
+func (m *Mongo) CreateBatch(ctx context.Context, batch *domain.Batch) error {
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.BatchesCollectionTitle)).InsertOne(ctx, batch)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// GetBatch retrieves a migration batch by its ID.
+func (m *Mongo) GetBatch(ctx context.Context, batchID string) (*domain.Batch, error) {
+	var batch domain.Batch
+	if err := m.Connection.Collection(m.ActualCollectionName(config.BatchesCollectionTitle)).
+		FindOne(ctx, bson.M{"_id": batchID}, &batch); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) || errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, appErrors.ErrBatchNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+	return &batch, nil
+}
+
+// UpdateBatch updates an existing migration batch.
+func (m *Mongo) UpdateBatch(ctx context.Context, batch *domain.Batch) error {
+	filter := bson.M{"_id": batch.ID}
+	update := bson.M{"$set": batch}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.BatchesCollectionTitle)).
+		UpdateOne(ctx, filter, update)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	if result.MatchedCount == 0 {
+		return appErrors.ErrBatchNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CreateComment creates a new comment on a job.
+func (m *Mongo) CreateComment(ctx context.Context, comment *domain.Comment) error {
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.CommentsCollectionTitle)).InsertOne(ctx, comment)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// GetJobComments retrieves a list of the comments on a job with
+// pagination, oldest first.
+func (m *Mongo) GetJobComments(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Comment, int, error) {
+	var results []*domain.Comment
+
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.CommentsCollectionTitle)).
+		Find(
+			ctx,
+			bson.M{"job_number": jobNumber},
+			&results,
+			mongodriver.Limit(limit),
+			mongodriver.Offset(offset),
+			mongodriver.Sort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}),
+		)
+	if err != nil {
+		return nil, 0, appErrors.ErrInternalServerError
+	}
+
+	return results, totalCount, nil
+}
@@ -0,0 +1,25 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ClaimDigestRun stores a digest run, claiming the scheduled digest for its
+// date. If the digest has already been claimed, ErrDigestRunClaimed is
+// returned.
+func (m *Mongo) ClaimDigestRun(ctx context.Context, run *domain.DigestRun) error {
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.DigestRunsCollectionTitle)).InsertOne(ctx, run)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return appErrors.ErrDigestRunClaimed
+		}
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// migrateLegacyEvents converts events recorded before event actions and
+// timestamps were typed to the current format, so that they can be
+// sorted, paged and filtered alongside newer events. Events stored their
+// created_at as an RFC3339 string and the state the job moved to as their
+// action. Converted events no longer match, so running it again only
+// converts events written since by instances of an older version.
+func (m *Mongo) migrateLegacyEvents(ctx context.Context) error {
+	collection := m.Connection.Collection(m.ActualCollectionName(config.EventsCollectionTitle))
+
+	_, err := collection.UpdateMany(ctx,
+		bson.M{"created_at": bson.M{"$type": "string"}},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"created_at": bson.M{"$dateFromString": bson.M{"dateString": "$created_at"}},
+			}}},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// The same mapping as domain.LegacyEventAction
+	_, err = collection.UpdateMany(ctx,
+		bson.M{
+			"action":   bson.M{"$nin": domain.GetEventActions()},
+			"to_state": bson.M{"$exists": false},
+		},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{
+				"to_state": "$action",
+				"action": bson.M{"$cond": bson.A{
+					bson.M{"$eq": bson.A{"$action", domain.StateSubmitted}},
+					domain.EventActionJobCreated,
+					domain.EventActionStateChanged,
+				}},
+			}}},
+		},
+	)
+	return err
+}
@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"time"
 
 	"github.com/ONSdigital/dis-migration-service/config"
 	"github.com/ONSdigital/dis-migration-service/domain"
@@ -21,10 +22,10 @@ func (m *Mongo) CreateEvent(ctx context.Context, event *domain.Event) error {
 }
 
 // GetJobEvents retrieves a list of migration events for a job with pagination.
-func (m *Mongo) GetJobEvents(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Event, int, error) {
+func (m *Mongo) GetJobEvents(ctx context.Context, jobNumber int, eventFilter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
 	var results []*domain.Event
 
-	filter := bson.M{"job_number": jobNumber}
+	filter := eventFilterQuery(jobNumber, eventFilter)
 
 	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.EventsCollectionTitle)).
 		Find(
@@ -43,6 +44,88 @@ func (m *Mongo) GetJobEvents(ctx context.Context, jobNumber, limit, offset int)
 	return results, totalCount, nil
 }
 
+// GetJobEventsAfter retrieves a page of migration events for a job, newest
+// first, starting after the provided cursor. Events are sorted by creation
+// time and then ID, neither of which change. The returned cursor is nil if
+// there are no more events.
+func (m *Mongo) GetJobEventsAfter(ctx context.Context, jobNumber int, eventFilter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error) {
+	filter := eventFilterQuery(jobNumber, eventFilter)
+
+	if after != nil && !after.IsStart() {
+		createdAt, err := time.Parse(time.RFC3339Nano, after.Value)
+		if err != nil {
+			return nil, nil, appErrors.ErrCursorInvalid
+		}
+
+		filter["$or"] = []bson.M{
+			{"created_at": bson.M{"$lt": createdAt}},
+			{"created_at": createdAt, "_id": bson.M{"$lt": after.ID}},
+		}
+	}
+
+	results, hasMore, err := findPage[domain.Event](
+		ctx,
+		m.Connection.Collection(m.ActualCollectionName(config.EventsCollectionTitle)),
+		filter,
+		bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		limit,
+	)
+	if err != nil {
+		return nil, nil, appErrors.ErrInternalServerError
+	}
+
+	if !hasMore || len(results) == 0 {
+		return results, nil, nil
+	}
+
+	last := results[len(results)-1]
+	return results, &domain.Cursor{Value: last.CreatedAt.UTC().Format(time.RFC3339Nano), ID: last.ID}, nil
+}
+
+// eventFilterQuery builds the mongo query for the events of a job. Each
+// field of the filter that is set narrows the results further.
+func eventFilterQuery(jobNumber int, filter *domain.EventFilter) bson.M {
+	query := bson.M{"job_number": jobNumber}
+	if filter == nil {
+		return query
+	}
+
+	if len(filter.Actions) > 0 {
+		query["action"] = bson.M{"$in": filter.Actions}
+	}
+
+	createdAt := bson.M{}
+	if filter.CreatedAfter != nil {
+		createdAt["$gt"] = *filter.CreatedAfter
+	}
+	if filter.CreatedBefore != nil {
+		createdAt["$lt"] = *filter.CreatedBefore
+	}
+	if len(createdAt) > 0 {
+		query["created_at"] = createdAt
+	}
+
+	return query
+}
+
+// GetJobEventStateCounts counts the job events with the given action
+// created since the provided time, grouped by the state the job moved to.
+// Task events are not counted.
+func (m *Mongo) GetJobEventStateCounts(ctx context.Context, action domain.EventAction, since time.Time) ([]StateCountResult, error) {
+	filter := bson.M{
+		"action":     action,
+		"task_id":    bson.M{"$exists": false},
+		"created_at": bson.M{"$gte": since},
+	}
+
+	results, err := m.getStateCounts(ctx, config.EventsCollectionTitle, filter, "$to_state")
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return results, nil
+}
+
 // CountEventsByJobNumber returns the total count of events for a job.
 func (m *Mongo) CountEventsByJobNumber(ctx context.Context, jobNumber int) (int, error) {
 	filter := bson.M{"job_number": jobNumber}
@@ -0,0 +1,131 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateIdempotencyRecord stores a new idempotency record. If a record
+// already exists with its ID, ErrIdempotencyKeyExists is returned.
+func (m *Mongo) CreateIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error {
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.IdempotencyKeysCollectionTitle)).InsertOne(ctx, record)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return appErrors.ErrIdempotencyKeyExists
+		}
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// GetIdempotencyRecord retrieves an idempotency record by its ID.
+func (m *Mongo) GetIdempotencyRecord(ctx context.Context, id string) (*domain.IdempotencyRecord, error) {
+	var record domain.IdempotencyRecord
+	if err := m.Connection.Collection(m.ActualCollectionName(config.IdempotencyKeysCollectionTitle)).
+		FindOne(ctx, bson.M{"_id": id}, &record); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) || errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, appErrors.ErrIdempotencyKeyNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+	return &record, nil
+}
+
+// CompleteIdempotencyRecord records the job created for an idempotency
+// record, as it was returned to the original request, provided the record
+// has not since been replaced by another request's record with the same ID.
+// The record no longer expires as pending once its job is recorded.
+func (m *Mongo) CompleteIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord, job *domain.Job) error {
+	filter := bson.M{
+		"_id":        record.ID,
+		"created_at": record.CreatedAt,
+	}
+	update := bson.M{
+		"$set":   bson.M{"job": job},
+		"$unset": bson.M{"pending_expires_at": ""},
+	}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.IdempotencyKeysCollectionTitle)).
+		UpdateOne(ctx, filter, update)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	if result.MatchedCount == 0 {
+		return appErrors.ErrIdempotencyKeyNotFound
+	}
+
+	return nil
+}
+
+// ReplaceExpiredIdempotencyRecord replaces the record with the same ID as the
+// given record, provided it has expired, or it is still pending and its
+// pending expiry has passed. If there is no expired record to replace, for
+// example because a concurrent request has already replaced it,
+// ErrIdempotencyKeyExists is returned.
+func (m *Mongo) ReplaceExpiredIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error {
+	now := time.Now().UTC()
+	filter := bson.M{
+		"_id": record.ID,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$lte": now}},
+			bson.M{"job": bson.M{"$exists": false}, "pending_expires_at": bson.M{"$lte": now}},
+		},
+	}
+
+	set := bson.M{
+		"key":          record.Key,
+		"user_id":      record.UserID,
+		"request_hash": record.RequestHash,
+		"created_at":   record.CreatedAt,
+	}
+	unset := bson.M{"job": ""}
+	if record.ExpiresAt != nil {
+		set["expires_at"] = record.ExpiresAt
+	} else {
+		unset["expires_at"] = ""
+	}
+	if record.PendingExpiresAt != nil {
+		set["pending_expires_at"] = record.PendingExpiresAt
+	} else {
+		unset["pending_expires_at"] = ""
+	}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.IdempotencyKeysCollectionTitle)).
+		UpdateOne(ctx, filter, bson.M{"$set": set, "$unset": unset})
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	if result.MatchedCount == 0 {
+		return appErrors.ErrIdempotencyKeyExists
+	}
+
+	return nil
+}
+
+// DeleteIdempotencyRecord removes an idempotency record, provided it has not
+// since been replaced by another request's record with the same ID.
+func (m *Mongo) DeleteIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error {
+	filter := bson.M{
+		"_id":        record.ID,
+		"created_at": record.CreatedAt,
+	}
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.IdempotencyKeysCollectionTitle)).
+		DeleteOne(ctx, filter)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
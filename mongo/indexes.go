@@ -0,0 +1,120 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dis-migration-service/config"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// index represents a mongo index and its name.
+type index struct {
+	name string
+	keys bson.D
+
+	// expires makes the index a TTL index, which removes each document
+	// once the time in its indexed field has passed.
+	expires bool
+}
+
+// jobsIndexes are the indexes on the jobs collection that back the filters
+// and sort fields of the jobs list and summarising the jobs of a batch. The
+// label filter is a text search, which needs the label_text index.
+var jobsIndexes = []index{
+	{name: "state_1_job_number_-1", keys: bson.D{{Key: "state", Value: 1}, {Key: "job_number", Value: -1}}},
+	{name: "config.type_1", keys: bson.D{{Key: "config.type", Value: 1}}},
+	{name: "config.source_id_1", keys: bson.D{{Key: "config.source_id", Value: 1}}},
+	{name: "config.target_id_1", keys: bson.D{{Key: "config.target_id", Value: 1}}},
+	{name: "created_by.id_1", keys: bson.D{{Key: "created_by.id", Value: 1}}},
+	{name: "label_1", keys: bson.D{{Key: "label", Value: 1}}},
+	{name: "label_text", keys: bson.D{{Key: "label", Value: "text"}}},
+	{name: "last_updated_-1", keys: bson.D{{Key: "last_updated", Value: -1}}},
+	{name: "release_group_id_1", keys: bson.D{{Key: "release_group_id", Value: 1}}},
+	{name: "batch_id_1", keys: bson.D{{Key: "batch_id", Value: 1}}},
+}
+
+// tasksIndexes are the indexes on the tasks collection that back the
+// stable sort used for cursor pagination, finding stale tasks, claiming
+// tasks that are not blocked by priority and finding the tasks that depend on a task.
+var tasksIndexes = []index{
+	{name: "job_number_1__id_1", keys: bson.D{{Key: "job_number", Value: 1}, {Key: "_id", Value: 1}}},
+	{name: "state_1_last_updated_1", keys: bson.D{{Key: "state", Value: 1}, {Key: "last_updated", Value: 1}}},
+	{name: "state_1_blocked_1_priority_-1_job_number_1_last_updated_1", keys: bson.D{{Key: "state", Value: 1}, {Key: "blocked", Value: 1}, {Key: "priority", Value: -1}, {Key: "job_number", Value: 1}, {Key: "last_updated", Value: 1}}},
+	{name: "depends_on_1", keys: bson.D{{Key: "depends_on", Value: 1}}},
+}
+
+// eventsIndexes are the indexes on the events collection that back the
+// stable sort used for cursor pagination and counting recent events.
+var eventsIndexes = []index{
+	{name: "job_number_1_created_at_-1__id_-1", keys: bson.D{{Key: "job_number", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+	{name: "action_1_created_at_-1", keys: bson.D{{Key: "action", Value: 1}, {Key: "created_at", Value: -1}}},
+}
+
+// webhooksIndexes are the indexes on the webhooks collection that back
+// finding the subscriptions for an event.
+var webhooksIndexes = []index{
+	{name: "event_types_1", keys: bson.D{{Key: "event_types", Value: 1}}},
+}
+
+// webhookDeliveriesIndexes are the indexes on the webhook deliveries
+// collection that back claiming due deliveries and listing the deliveries
+// of a webhook.
+var webhookDeliveriesIndexes = []index{
+	{name: "status_1_next_attempt_at_1", keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+	{name: "webhook_id_1_created_at_-1", keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}}},
+}
+
+// commentsIndexes are the indexes on the comments collection that back
+// listing the comments on a job in the order they were made.
+var commentsIndexes = []index{
+	{name: "job_number_1_created_at_1__id_1", keys: bson.D{{Key: "job_number", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+}
+
+// idempotencyKeysIndexes are the indexes on the idempotency keys
+// collection that remove expired keys, and keys whose job was never
+// recorded.
+var idempotencyKeysIndexes = []index{
+	{name: "expires_at_1", keys: bson.D{{Key: "expires_at", Value: 1}}, expires: true},
+	{name: "pending_expires_at_1", keys: bson.D{{Key: "pending_expires_at", Value: 1}}, expires: true},
+}
+
+// EnsureIndexes creates any indexes that do not already exist. Creating an
+// index that already exists with the same definition has no effect.
+func (m *Mongo) EnsureIndexes(ctx context.Context) error {
+	collectionIndexes := map[string][]index{
+		config.JobsCollectionTitle:              jobsIndexes,
+		config.TasksCollectionTitle:             tasksIndexes,
+		config.EventsCollectionTitle:            eventsIndexes,
+		config.WebhooksCollectionTitle:          webhooksIndexes,
+		config.WebhookDeliveriesCollectionTitle: webhookDeliveriesIndexes,
+		config.CommentsCollectionTitle:          commentsIndexes,
+		config.IdempotencyKeysCollectionTitle:   idempotencyKeysIndexes,
+	}
+
+	for collectionTitle, indexes := range collectionIndexes {
+		if err := m.createIndexes(ctx, m.ActualCollectionName(collectionTitle), indexes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Mongo) createIndexes(ctx context.Context, collectionName string, indexes []index) error {
+	specs := make(bson.A, 0, len(indexes))
+	for _, index := range indexes {
+		spec := bson.D{
+			{Key: "key", Value: index.keys},
+			{Key: "name", Value: index.name},
+		}
+		if index.expires {
+			spec = append(spec, bson.E{Key: "expireAfterSeconds", Value: 0})
+		}
+		specs = append(specs, spec)
+	}
+
+	return m.Connection.RunCommand(ctx, bson.D{
+		{Key: "createIndexes", Value: collectionName},
+		{Key: "indexes", Value: specs},
+	})
+}
@@ -3,6 +3,9 @@ package mongo
 import (
 	"context"
 	"errors"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	sort "github.com/ONSdigital/dis-migration-service/api/sort"
@@ -45,26 +48,12 @@ func (m *Mongo) GetJob(ctx context.Context, jobNumber int) (*domain.Job, error)
 	return &job, nil
 }
 
-// GetJobs retrieves a list of migration jobs with pagination.
-func (m *Mongo) GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, stateFilter []domain.State, limit, offset int) ([]*domain.Job, int, error) {
+// GetJobs retrieves a list of migration jobs, matching the provided filter,
+// with pagination.
+func (m *Mongo) GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
 	var results []*domain.Job
 
-	filter := bson.M{}
-	if len(stateFilter) > 0 {
-		filter["state"] = bson.M{"$in": stateFilter}
-	}
-
-	// default to descending
-	sortOrder := -1
-	if direction == sort.SortParameterDirectionAsc {
-		sortOrder = 1
-	}
-
-	// default to field being job_number
-	sortField := sort.SortParameterFieldJobNumber
-	if field == sort.SortParameterFieldLabel {
-		sortField = sort.SortParameterFieldLabel
-	}
+	sortField, sortOrder := jobSortKeys(field, direction)
 
 	sortBy := bson.D{
 		{Key: string(sortField), Value: sortOrder},
@@ -73,7 +62,7 @@ func (m *Mongo) GetJobs(ctx context.Context, field sort.SortParameterField, dire
 	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.JobsCollectionTitle)).
 		Find(
 			ctx,
-			filter,
+			jobFilterQuery(filter),
 			&results,
 			mongodriver.Limit(limit),
 			mongodriver.Offset(offset),
@@ -83,15 +72,176 @@ func (m *Mongo) GetJobs(ctx context.Context, field sort.SortParameterField, dire
 	return results, totalCount, err
 }
 
+// GetJobsAfter retrieves a page of migration jobs matching the filter,
+// starting after the provided cursor. Jobs are sorted by the requested field
+// and then by ID, so that jobs sharing a sort value are never skipped or
+// repeated. Jobs cannot be sorted by last updated time, as it changes while
+// the jobs are paged through. The returned cursor is nil if there are no
+// more jobs.
+func (m *Mongo) GetJobsAfter(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error) {
+	if field == sort.SortParameterFieldLastUpdated {
+		return nil, nil, appErrors.ErrCursorSortInvalid
+	}
+
+	sortField, sortOrder := jobSortKeys(field, direction)
+
+	query := jobFilterQuery(filter)
+
+	if after != nil && !after.IsStart() {
+		value, err := jobSortValue(sortField, after.Value)
+		if err != nil {
+			return nil, nil, appErrors.ErrCursorInvalid
+		}
+
+		comparison := "$lt"
+		if sortOrder == 1 {
+			comparison = "$gt"
+		}
+
+		query["$and"] = []bson.M{{
+			"$or": []bson.M{
+				{string(sortField): bson.M{comparison: value}},
+				{string(sortField): value, "_id": bson.M{comparison: after.ID}},
+			},
+		}}
+	}
+
+	results, hasMore, err := findPage[domain.Job](
+		ctx,
+		m.Connection.Collection(m.ActualCollectionName(config.JobsCollectionTitle)),
+		query,
+		bson.D{{Key: string(sortField), Value: sortOrder}, {Key: "_id", Value: sortOrder}},
+		limit,
+	)
+	if err != nil {
+		return nil, nil, appErrors.ErrInternalServerError
+	}
+
+	if !hasMore || len(results) == 0 {
+		return results, nil, nil
+	}
+
+	last := results[len(results)-1]
+	next := &domain.Cursor{ID: last.ID}
+
+	switch sortField {
+	case sort.SortParameterFieldLabel:
+		next.Value = last.Label
+	default:
+		next.Value = strconv.Itoa(last.JobNumber)
+	}
+
+	return results, next, nil
+}
+
+// jobSortKeys returns the field and order used to sort jobs, defaulting to
+// job number descending.
+func jobSortKeys(field sort.SortParameterField, direction sort.SortParameterDirection) (sort.SortParameterField, int) {
+	sortOrder := -1
+	if direction == sort.SortParameterDirectionAsc {
+		sortOrder = 1
+	}
+
+	sortField := sort.SortParameterFieldJobNumber
+	if field == sort.SortParameterFieldLabel || field == sort.SortParameterFieldLastUpdated {
+		sortField = field
+	}
+
+	return sortField, sortOrder
+}
+
+// jobSortValue converts a cursor value back to the type stored for the
+// sort field.
+func jobSortValue(field sort.SortParameterField, value string) (interface{}, error) {
+	switch field {
+	case sort.SortParameterFieldLabel:
+		return value, nil
+	default:
+		return strconv.Atoi(value)
+	}
+}
+
+// jobFilterQuery builds the mongo query for a job filter. Each field that
+// is set narrows the results further.
+func jobFilterQuery(filter *domain.JobFilter) bson.M {
+	query := bson.M{}
+	if filter == nil {
+		return query
+	}
+
+	if len(filter.States) > 0 {
+		query["state"] = bson.M{"$in": filter.States}
+	}
+
+	if filter.Type != "" {
+		query["config.type"] = filter.Type
+	}
+
+	if filter.SourceID != "" {
+		query["config.source_id"] = filter.SourceID
+	}
+
+	if filter.TargetID != "" {
+		query["config.target_id"] = filter.TargetID
+	}
+
+	if filter.Label != "" {
+		query["$text"] = bson.M{"$search": labelSearchPhrase(filter.Label)}
+	}
+
+	if filter.CreatedBy != "" {
+		query["created_by.id"] = filter.CreatedBy
+	}
+
+	lastUpdated := bson.M{}
+	if filter.UpdatedAfter != nil {
+		lastUpdated["$gt"] = *filter.UpdatedAfter
+	}
+	if filter.UpdatedBefore != nil {
+		lastUpdated["$lt"] = *filter.UpdatedBefore
+	}
+	if len(lastUpdated) > 0 {
+		query["last_updated"] = lastUpdated
+	}
+
+	return query
+}
+
+// labelSearchPhrase returns the text search for jobs whose label contains
+// the words of the given label together, in order. Quotes in the label
+// would end the phrase, so they are removed.
+func labelSearchPhrase(label string) string {
+	return `"` + strings.ReplaceAll(label, `"`, "") + `"`
+}
+
 // GetJobStateCounts retrieves a summary of job counts by state, sorted by count
 // descending.
 func (m *Mongo) GetJobStateCounts(ctx context.Context) ([]StateCountResult, error) {
+	return m.getJobStateCounts(ctx, bson.M{})
+}
+
+// GetBatchJobStateCounts retrieves a summary of job counts by state for the
+// jobs created by a batch, sorted by count descending.
+func (m *Mongo) GetBatchJobStateCounts(ctx context.Context, batchID string) ([]StateCountResult, error) {
+	return m.getJobStateCounts(ctx, bson.M{"batch_id": batchID})
+}
+
+func (m *Mongo) getJobStateCounts(ctx context.Context, filter bson.M) ([]StateCountResult, error) {
+	return m.getStateCounts(ctx, config.JobsCollectionTitle, filter, "$state")
+}
+
+// getStateCounts counts the documents in a collection matching the filter,
+// grouped by the state held in stateField, sorted by count descending.
+func (m *Mongo) getStateCounts(ctx context.Context, collectionTitle string, filter bson.M, stateField string) ([]StateCountResult, error) {
 	var results []StateCountResult
 
 	pipeline := mongo.Pipeline{
+		{
+			{Key: "$match", Value: filter},
+		},
 		{
 			{Key: "$group", Value: bson.D{
-				{Key: "_id", Value: "$state"},
+				{Key: "_id", Value: stateField},
 				{Key: "count", Value: bson.D{
 					{Key: "$sum", Value: 1},
 				}},
@@ -105,7 +255,7 @@ func (m *Mongo) GetJobStateCounts(ctx context.Context) ([]StateCountResult, erro
 		},
 	}
 
-	err := m.Connection.Collection(m.ActualCollectionName(config.JobsCollectionTitle)).
+	err := m.Connection.Collection(m.ActualCollectionName(collectionTitle)).
 		Aggregate(ctx, pipeline, &results)
 
 	return results, err
@@ -156,6 +306,31 @@ func (m *Mongo) ClaimJob(ctx context.Context, pendingState, activeState domain.S
 	var job domain.Job
 
 	filter := bson.M{"state": pendingState}
+	var conditions []bson.M
+
+	// Approved jobs scheduled for publishing are not claimed until their
+	// publish time has passed.
+	if pendingState == domain.StateApproved {
+		conditions = append(conditions, bson.M{"$or": []bson.M{
+			{"publish_at": bson.M{"$exists": false}},
+			{"publish_at": nil},
+			{"publish_at": bson.M{"$lte": time.Now().UTC()}},
+		}})
+	}
+
+	// Jobs in a release group are held in each release gate until every
+	// job in the group has reached it.
+	if slices.Contains(domain.ReleaseGates, pendingState) {
+		conditions = append(conditions, bson.M{"$or": []bson.M{
+			{"release_group_id": bson.M{"$exists": false}},
+			{"released_states": pendingState},
+		}})
+	}
+
+	if len(conditions) > 0 {
+		filter["$and"] = conditions
+	}
+
 	update := bson.M{
 		"$set": bson.M{
 			"state":        activeState,
@@ -200,6 +375,47 @@ func (m *Mongo) UpdateJob(ctx context.Context, job *domain.Job) error {
 	return nil
 }
 
+// UpdateJobCollectionID sets the collection ID of a job, without changing
+// any of its other fields
+func (m *Mongo) UpdateJobCollectionID(ctx context.Context, jobID, collectionID string) error {
+	filter := bson.M{"_id": jobID}
+	update := bson.M{"$set": bson.M{
+		"config.collection_id": collectionID,
+		"last_updated":         time.Now(),
+	}}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.JobsCollectionTitle)).
+		UpdateOne(ctx, filter, update)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	if result.MatchedCount == 0 {
+		return appErrors.ErrJobNotFound
+	}
+
+	return nil
+}
+
+// UpdateJobNotificationThread sets the notification thread of a job,
+// without changing any of its other fields
+func (m *Mongo) UpdateJobNotificationThread(ctx context.Context, jobID string, thread *domain.NotificationThread) error {
+	filter := bson.M{"_id": jobID}
+	update := bson.M{"$set": bson.M{"notification_thread": thread}}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.JobsCollectionTitle)).
+		UpdateOne(ctx, filter, update)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	if result.MatchedCount == 0 {
+		return appErrors.ErrJobNotFound
+	}
+
+	return nil
+}
+
 // UpdateJobState updates the state of a job
 func (m *Mongo) UpdateJobState(ctx context.Context, jobID string, oldState, newState domain.State, lastUpdated time.Time) error {
 	collectionName := m.ActualCollectionName(config.JobsCollectionTitle)
@@ -251,3 +467,134 @@ func (m *Mongo) UpdateJobState(ctx context.Context, jobID string, oldState, newS
 
 	return nil
 }
+
+// ApproveJob records a user's approval of a job in review, returning the
+// updated job. The job is moved to approved once it has at least
+// requiredApprovals approvals, or straight away if approval is nil, in
+// which case no approval is recorded. If publishAt is not nil the job is
+// also scheduled to publish at that time. Everything is written in a single
+// update, so nothing is written if the job has left review.
+func (m *Mongo) ApproveJob(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error) {
+	collectionName := m.ActualCollectionName(config.JobsCollectionTitle)
+
+	// Only approve the job if it is still in review and the user has not
+	// already approved it.
+	filter := bson.M{"_id": jobID, "state": domain.StateInReview}
+
+	set := bson.M{"last_updated": time.Now()}
+	if publishAt != nil {
+		set["publish_at"] = publishAt
+	}
+
+	var state interface{} = domain.StateApproved
+	if approval != nil {
+		filter["approvals.user.id"] = bson.M{"$ne": approval.User.ID}
+
+		set["approvals"] = bson.M{"$concatArrays": bson.A{
+			bson.M{"$ifNull": bson.A{"$approvals", bson.A{}}},
+			bson.A{bson.M{"$literal": approval}},
+		}}
+
+		// The approvals are counted once the new approval is added
+		state = bson.M{"$cond": bson.A{
+			bson.M{"$gte": bson.A{bson.M{"$size": "$approvals"}, requiredApprovals}},
+			domain.StateApproved,
+			"$state",
+		}}
+	}
+
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: set}},
+		{{Key: "$set", Value: bson.M{"state": state}}},
+	}
+
+	var job domain.Job
+	err := m.Connection.Collection(collectionName).
+		FindOneAndUpdate(ctx, filter, update, &job, mongodriver.ReturnDocument(options.After))
+	if err == nil {
+		return &job, nil
+	}
+
+	if !errors.Is(err, mongodriver.ErrNoDocumentFound) && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	// Check whether the job exists and why it was not approved
+	var preExistingJob domain.Job
+	if err := m.Connection.Collection(collectionName).FindOne(ctx, bson.M{"_id": jobID}, &preExistingJob); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) || errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, appErrors.ErrJobNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	switch preExistingJob.State {
+	case domain.StateApproved:
+		return nil, appErrors.ErrStateAlreadyAtTarget
+	case domain.StateInReview:
+		return nil, appErrors.ErrJobAlreadyApprovedByUser
+	default:
+		return nil, appErrors.ErrStateUnexpected
+	}
+}
+
+// UpdateJobPublishAt schedules when a job is published, or cancels its
+// schedule if publishAt is nil. The job must be in one of the provided
+// states.
+func (m *Mongo) UpdateJobPublishAt(ctx context.Context, jobID string, states []domain.State, publishAt *time.Time) error {
+	collectionName := m.ActualCollectionName(config.JobsCollectionTitle)
+
+	filter := bson.M{"_id": jobID, "state": bson.M{"$in": states}}
+
+	update := bson.M{
+		"$set": bson.M{"publish_at": publishAt, "last_updated": time.Now()},
+	}
+	if publishAt == nil {
+		update = bson.M{
+			"$set":   bson.M{"last_updated": time.Now()},
+			"$unset": bson.M{"publish_at": ""},
+		}
+	}
+
+	result, err := m.Connection.Collection(collectionName).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	if result.MatchedCount == 0 {
+		var preExistingJob domain.Job
+		if err := m.Connection.Collection(collectionName).FindOne(ctx, bson.M{"_id": jobID}, &preExistingJob); err != nil {
+			if errors.Is(err, mongodriver.ErrNoDocumentFound) || errors.Is(err, mongo.ErrNoDocuments) {
+				return appErrors.ErrJobNotFound
+			}
+			return appErrors.ErrInternalServerError
+		}
+
+		return appErrors.ErrJobScheduleNotAllowed
+	}
+
+	return nil
+}
+
+// UpdateJobPriority sets the priority of a job.
+func (m *Mongo) UpdateJobPriority(ctx context.Context, jobID string, priority int) error {
+	filter := bson.M{"_id": jobID}
+	update := bson.M{
+		"$set": bson.M{
+			"priority":     priority,
+			"last_updated": time.Now(),
+		},
+	}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.JobsCollectionTitle)).
+		UpdateOne(ctx, filter, update)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	if result.MatchedCount == 0 {
+		return appErrors.ErrJobNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestJobFilterQuery(t *testing.T) {
+	Convey("Given no job filter", t, func() {
+		Convey("When the query is built", func() {
+			query := jobFilterQuery(nil)
+
+			Convey("Then every job is matched", func() {
+				So(query, ShouldResemble, bson.M{})
+			})
+		})
+	})
+
+	Convey("Given a job filter with a label", t, func() {
+		filter := &domain.JobFilter{Label: "Consumer Price"}
+
+		Convey("When the query is built", func() {
+			query := jobFilterQuery(filter)
+
+			Convey("Then the label is searched for as a phrase using the text index", func() {
+				So(query, ShouldResemble, bson.M{
+					"$text": bson.M{"$search": `"Consumer Price"`},
+				})
+			})
+
+			Convey("And the label field is not matched with a regular expression", func() {
+				So(query, ShouldNotContainKey, "label")
+			})
+		})
+	})
+
+	Convey("Given a job filter with a label containing quotes", t, func() {
+		filter := &domain.JobFilter{Label: `Consumer "Price`}
+
+		Convey("When the query is built", func() {
+			query := jobFilterQuery(filter)
+
+			Convey("Then the quotes are removed so that the phrase is not ended early", func() {
+				So(query["$text"], ShouldResemble, bson.M{"$search": `"Consumer Price"`})
+			})
+		})
+	})
+
+	Convey("Given a job filter with every field set", t, func() {
+		updatedAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		updatedBefore := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		filter := &domain.JobFilter{
+			States:        []domain.State{domain.StateSubmitted},
+			Type:          domain.JobTypeStaticDataset,
+			SourceID:      "/source-id",
+			TargetID:      "target-id",
+			Label:         "price",
+			CreatedBy:     "user-123",
+			UpdatedAfter:  &updatedAfter,
+			UpdatedBefore: &updatedBefore,
+		}
+
+		Convey("When the query is built", func() {
+			query := jobFilterQuery(filter)
+
+			Convey("Then each field is matched against its indexed field", func() {
+				So(query, ShouldResemble, bson.M{
+					"state":            bson.M{"$in": []domain.State{domain.StateSubmitted}},
+					"config.type":      domain.JobTypeStaticDataset,
+					"config.source_id": "/source-id",
+					"config.target_id": "target-id",
+					"$text":            bson.M{"$search": `"price"`},
+					"created_by.id":    "user-123",
+					"last_updated":     bson.M{"$gt": updatedAfter, "$lt": updatedBefore},
+				})
+			})
+		})
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	mongoHealth "github.com/ONSdigital/dp-mongodb/v3/health"
 	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"github.com/ONSdigital/log.go/v2/log"
 )
 
 // Mongo represents a mongo connection and health client
@@ -32,10 +33,34 @@ func (m *Mongo) Init(ctx context.Context) (err error) {
 			mongoHealth.Collection(m.ActualCollectionName(config.JobsCollectionTitle)),
 			mongoHealth.Collection(m.ActualCollectionName(config.EventsCollectionTitle)),
 			mongoHealth.Collection(m.ActualCollectionName(config.TasksCollectionTitle)),
+			mongoHealth.Collection(m.ActualCollectionName(config.BatchesCollectionTitle)),
+			mongoHealth.Collection(m.ActualCollectionName(config.IdempotencyKeysCollectionTitle)),
+			mongoHealth.Collection(m.ActualCollectionName(config.WebhooksCollectionTitle)),
+			mongoHealth.Collection(m.ActualCollectionName(config.WebhookDeliveriesCollectionTitle)),
+			mongoHealth.Collection(m.ActualCollectionName(config.CommentsCollectionTitle)),
+			mongoHealth.Collection(m.ActualCollectionName(config.ReleaseGroupsCollectionTitle)),
+			mongoHealth.Collection(m.ActualCollectionName(config.DigestRunsCollectionTitle)),
 		},
 	}
 	m.healthClient = mongoHealth.NewClientWithCollections(m.Connection, databaseCollectionBuilder)
 
+	// Queries other than searching job labels still work without the
+	// indexes, so failing to create them should not prevent the service
+	// from starting.
+	if err := m.EnsureIndexes(ctx); err != nil {
+		log.Warn(ctx, "failed to ensure mongo indexes", log.Data{
+			"error": err.Error(),
+		})
+	}
+
+	// Legacy events can still be read, so failing to convert them should
+	// not prevent the service from starting either.
+	if err := m.migrateLegacyEvents(ctx); err != nil {
+		log.Warn(ctx, "failed to migrate legacy events", log.Data{
+			"error": err.Error(),
+		})
+	}
+
 	return nil
 }
 
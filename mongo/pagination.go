@@ -0,0 +1,38 @@
+package mongo
+
+import (
+	"context"
+
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// findPage retrieves up to limit documents matching the filter, in the
+// given sort order, without counting or skipping documents. One more
+// document than requested is read to determine whether there are more
+// documents after the page.
+func findPage[T any](ctx context.Context, collection *mongodriver.Collection, filter bson.M, sortBy bson.D, limit int) (results []*T, hasMore bool, err error) {
+	cursor, err := collection.FindCursor(ctx, filter, mongodriver.Sort(sortBy), mongodriver.Limit(limit+1))
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close(ctx)
+
+	results = make([]*T, 0, limit)
+	for cursor.Next(ctx) {
+		var result T
+		if err := cursor.Decode(&result); err != nil {
+			return nil, false, err
+		}
+		results = append(results, &result)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, false, err
+	}
+
+	if len(results) > limit {
+		return results[:limit], true, nil
+	}
+
+	return results, false, nil
+}
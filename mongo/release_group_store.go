@@ -0,0 +1,128 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateReleaseGroup creates a new release group.
+func (m *Mongo) CreateReleaseGroup(ctx context.Context, group *domain.ReleaseGroup) error {
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.ReleaseGroupsCollectionTitle)).InsertOne(ctx, group)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// GetReleaseGroup retrieves a release group by its ID.
+func (m *Mongo) GetReleaseGroup(ctx context.Context, groupID string) (*domain.ReleaseGroup, error) {
+	var group domain.ReleaseGroup
+	if err := m.Connection.Collection(m.ActualCollectionName(config.ReleaseGroupsCollectionTitle)).
+		FindOne(ctx, bson.M{"_id": groupID}, &group); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) || errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, appErrors.ErrReleaseGroupNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+	return &group, nil
+}
+
+// DeleteReleaseGroup deletes a release group by its ID.
+func (m *Mongo) DeleteReleaseGroup(ctx context.Context, groupID string) error {
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.ReleaseGroupsCollectionTitle)).
+		DeleteOne(ctx, bson.M{"_id": groupID})
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	if result.DeletedCount == 0 {
+		return appErrors.ErrReleaseGroupNotFound
+	}
+
+	return nil
+}
+
+// AddJobsToReleaseGroup adds the jobs with the provided job numbers to a
+// release group, if they are in one of the provided states and not
+// already in a release group. It returns how many jobs were added.
+func (m *Mongo) AddJobsToReleaseGroup(ctx context.Context, groupID string, jobNumbers []int, states []domain.State) (int, error) {
+	filter := bson.M{
+		"job_number":       bson.M{"$in": jobNumbers},
+		"state":            bson.M{"$in": states},
+		"release_group_id": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"release_group_id": groupID,
+			"last_updated":     time.Now(),
+		},
+	}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.JobsCollectionTitle)).
+		UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, appErrors.ErrInternalServerError
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// RemoveJobsFromReleaseGroup removes every job from a release group.
+func (m *Mongo) RemoveJobsFromReleaseGroup(ctx context.Context, groupID string) error {
+	update := bson.M{
+		"$set":   bson.M{"last_updated": time.Now()},
+		"$unset": bson.M{"release_group_id": "", "released_states": ""},
+	}
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.JobsCollectionTitle)).
+		UpdateMany(ctx, bson.M{"release_group_id": groupID}, update)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// GetReleaseGroupJobs retrieves every job in a release group, ordered by
+// job number.
+func (m *Mongo) GetReleaseGroupJobs(ctx context.Context, groupID string) ([]*domain.Job, error) {
+	var results []*domain.Job
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.JobsCollectionTitle)).
+		Find(
+			ctx,
+			bson.M{"release_group_id": groupID},
+			&results,
+			mongodriver.Sort(bson.M{"job_number": 1}),
+		)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return results, nil
+}
+
+// ReleaseGroupJobs releases every job in a release group from the provided
+// release gate state, so that they can be claimed from it.
+func (m *Mongo) ReleaseGroupJobs(ctx context.Context, groupID string, state domain.State) error {
+	update := bson.M{
+		"$addToSet": bson.M{"released_states": state},
+	}
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.JobsCollectionTitle)).
+		UpdateMany(ctx, bson.M{"release_group_id": groupID}, update)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package mongo
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/ONSdigital/dis-migration-service/config"
@@ -14,13 +15,46 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// CreateTask creates a new migration task.
+// taskDocument is a task as it is stored, along with the dependencies it
+// is still waiting on for the stage it is pending. The dependencies are
+// kept up to date as tasks change state, so that claiming a task does not
+// need to look them up.
+type taskDocument struct {
+	domain.Task `bson:",inline"`
+	// UnmetDependencies contains the IDs of the tasks this task depends on
+	// that have not yet reached the dependency state of its stage
+	UnmetDependencies []string `bson:"unmet_dependencies,omitempty"`
+	// Blocked is true while the task has unmet dependencies
+	Blocked bool `bson:"blocked,omitempty"`
+}
+
+// CreateTask creates a new migration task. A task that is created waiting
+// on its dependencies is blocked until they are all in the dependency
+// state of its stage. If any of them does not exist the task is not
+// created.
 func (m *Mongo) CreateTask(ctx context.Context, task *domain.Task) error {
-	_, err := m.Connection.Collection(m.ActualCollectionName(config.TasksCollectionTitle)).InsertOne(ctx, task)
+	stage, waits := dependencyStage(task.State)
+	if waits && len(task.DependsOn) > 0 {
+		if _, err := m.getDependencyStates(ctx, task.DependsOn); err != nil {
+			return err
+		}
+	}
+
+	document := taskDocument{Task: *task}
+	if waits {
+		document.UnmetDependencies = uniqueIDs(task.DependsOn)
+		document.Blocked = len(document.UnmetDependencies) > 0
+	}
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.TasksCollectionTitle)).InsertOne(ctx, document)
 	if err != nil {
 		return appErrors.ErrInternalServerError
 	}
 
+	if document.Blocked {
+		return m.releaseMetDependencies(ctx, task.ID, stage, task.DependsOn)
+	}
+
 	return nil
 }
 
@@ -54,26 +88,43 @@ func (m *Mongo) UpdateTask(ctx context.Context, task *domain.Task) error {
 	return nil
 }
 
-// UpdateTaskState updates the state of a task and returns the updated task.
+// UpdateTaskState updates the state of a task. A task entering the pending
+// state of a stage is blocked until the tasks it depends on are all in the
+// dependency state of the stage, and fails to enter it if any of them does
+// not exist. A task reaching the dependency state of a stage releases the
+// tasks waiting on it.
 func (m *Mongo) UpdateTaskState(ctx context.Context, taskID string, newState domain.State, lastUpdated time.Time) error {
-	collectionName := m.ActualCollectionName(config.TasksCollectionTitle)
+	collection := m.Connection.Collection(m.ActualCollectionName(config.TasksCollectionTitle))
 
-	filter := bson.M{
-		"_id": taskID,
+	set := bson.M{
+		"state":        newState,
+		"last_updated": lastUpdated,
 	}
-	update := bson.M{
-		"$set": bson.M{
-			"state":        newState,
-			"last_updated": lastUpdated,
-		},
+	update := bson.M{"$set": set}
+
+	stage, waits := dependencyStage(newState)
+	var dependsOn []string
+	if waits {
+		task, err := m.GetTask(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		if len(task.DependsOn) > 0 {
+			if _, err := m.getDependencyStates(ctx, task.DependsOn); err != nil {
+				return err
+			}
+		}
+
+		dependsOn = uniqueIDs(task.DependsOn)
+		set["unmet_dependencies"] = dependsOn
+		set["blocked"] = len(dependsOn) > 0
+	} else {
+		update["$unset"] = bson.M{"unmet_dependencies": "", "blocked": ""}
 	}
 
 	// Update the document
-	result, err := m.Connection.Collection(collectionName).UpdateOne(
-		ctx,
-		filter,
-		update,
-	)
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": taskID}, update)
 	if err != nil {
 		return appErrors.ErrInternalServerError
 	}
@@ -83,26 +134,195 @@ func (m *Mongo) UpdateTaskState(ctx context.Context, taskID string, newState dom
 		return appErrors.ErrTaskNotFound
 	}
 
+	if len(dependsOn) > 0 {
+		if err := m.releaseMetDependencies(ctx, taskID, stage, dependsOn); err != nil {
+			return err
+		}
+	}
+
+	for _, stage := range domain.GetTaskStages() {
+		if stage.DependencyState == "" || stage.DependencyState != newState {
+			continue
+		}
+
+		_, err := collection.UpdateMany(ctx,
+			bson.M{"depends_on": taskID, "state": stage.PendingState},
+			releaseDependenciesUpdate([]string{taskID}),
+		)
+		if err != nil {
+			return appErrors.ErrInternalServerError
+		}
+	}
+
 	return nil
 }
 
-// ClaimTask claims a pending task for processing.
-func (m *Mongo) ClaimTask(ctx context.Context, pendingState, activeState domain.State) (*domain.Task, error) {
-	var task domain.Task
+// dependencyStage returns the stage whose pending state is the provided
+// state, if tasks in that state wait on their dependencies.
+func dependencyStage(state domain.State) (domain.TaskStage, bool) {
+	for _, stage := range domain.GetTaskStages() {
+		if stage.PendingState == state && stage.DependencyState != "" {
+			return stage, true
+		}
+	}
+	return domain.TaskStage{}, false
+}
 
-	filter := bson.M{"state": pendingState}
+// getDependencyStates returns the state of each of the provided tasks, or
+// ErrTaskDependencyNotFound if any of them does not exist.
+func (m *Mongo) getDependencyStates(ctx context.Context, taskIDs []string) (map[string]domain.State, error) {
+	var dependencies []domain.Task
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.TasksCollectionTitle)).
+		Find(ctx, bson.M{"_id": bson.M{"$in": taskIDs}}, &dependencies,
+			mongodriver.Projection(bson.M{"state": 1}),
+		)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	states := make(map[string]domain.State, len(dependencies))
+	for _, dependency := range dependencies {
+		states[dependency.ID] = dependency.State
+	}
+
+	for _, taskID := range taskIDs {
+		if _, ok := states[taskID]; !ok {
+			return nil, appErrors.ErrTaskDependencyNotFound
+		}
+	}
+
+	return states, nil
+}
+
+// releaseMetDependencies releases a task from the dependencies that are
+// already in the dependency state of its stage, as long as the task is
+// still waiting in the stage. Dependencies that reach the state later
+// release the task themselves.
+func (m *Mongo) releaseMetDependencies(ctx context.Context, taskID string, stage domain.TaskStage, dependsOn []string) error {
+	states, err := m.getDependencyStates(ctx, dependsOn)
+	if err != nil {
+		return err
+	}
+
+	var met []string
+	for _, dependencyID := range uniqueIDs(dependsOn) {
+		if states[dependencyID] == stage.DependencyState {
+			met = append(met, dependencyID)
+		}
+	}
+
+	if len(met) == 0 {
+		return nil
+	}
+
+	_, err = m.Connection.Collection(m.ActualCollectionName(config.TasksCollectionTitle)).
+		UpdateOne(ctx, bson.M{"_id": taskID, "state": stage.PendingState}, releaseDependenciesUpdate(met))
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// releaseDependenciesUpdate removes the provided dependencies from the
+// unmet dependencies of a task, and unblocks the task once none are left.
+// Removing a dependency that has already been removed has no effect.
+func releaseDependenciesUpdate(dependencyIDs []string) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"unmet_dependencies": bson.M{"$setDifference": bson.A{
+				bson.M{"$ifNull": bson.A{"$unmet_dependencies", bson.A{}}},
+				bson.M{"$literal": dependencyIDs},
+			}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"blocked": bson.M{"$gt": bson.A{bson.M{"$size": "$unmet_dependencies"}, 0}},
+		}}},
+	}
+}
+
+// uniqueIDs returns the provided IDs without duplicates, in order.
+func uniqueIDs(ids []string) []string {
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// ClaimTask claims a pending task for processing. Tasks with the highest
+// priority are claimed first. Within a priority, jobs take turns: the task
+// is claimed from the first job numbered after afterJobNumber, wrapping
+// around to the lowest job number. Tasks of the excluded types or jobs are
+// not claimed. If a dependency state is provided, tasks are only claimed
+// once they are no longer blocked on the tasks they depend on.
+func (m *Mongo) ClaimTask(ctx context.Context, pendingState, activeState, dependencyState domain.State, afterJobNumber int, excludedTypes []domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
+	collection := m.Connection.Collection(m.ActualCollectionName(config.TasksCollectionTitle))
+
+	if excludedJobNumbers == nil {
+		excludedJobNumbers = []int{}
+	}
+
+	filter := bson.M{
+		"state":      pendingState,
+		"job_number": bson.M{"$nin": excludedJobNumbers},
+	}
+	if dependencyState != "" {
+		filter["blocked"] = bson.M{"$ne": true}
+	}
+	if len(excludedTypes) > 0 {
+		filter["type"] = bson.M{"$nin": excludedTypes}
+	}
+
+	var next domain.Task
+	err := collection.FindOne(ctx, filter, &next,
+		mongodriver.Sort(bson.D{{Key: "priority", Value: -1}}),
+		mongodriver.Projection(bson.M{"priority": 1}),
+	)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) || errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	filter["priority"] = next.Priority
+	if next.Priority == domain.DefaultJobPriority {
+		// Tasks created before priorities were added have no priority
+		filter["priority"] = bson.M{"$in": bson.A{next.Priority, nil}}
+	}
+
+	filter["job_number"] = bson.M{"$gt": afterJobNumber, "$nin": excludedJobNumbers}
+	task, err := m.claimTask(ctx, filter, activeState)
+	if task != nil || err != nil {
+		return task, err
+	}
+
+	filter["job_number"] = bson.M{"$nin": excludedJobNumbers}
+	return m.claimTask(ctx, filter, activeState)
+}
+
+// claimTask claims the task matching the filter from the lowest job
+// number, oldest first, or returns nil if there is none.
+func (m *Mongo) claimTask(ctx context.Context, filter bson.M, activeState domain.State) (*domain.Task, error) {
 	update := bson.M{
 		"$set": bson.M{
 			"state":        activeState,
 			"last_updated": time.Now(),
 		},
+		"$unset": bson.M{"unmet_dependencies": "", "blocked": ""},
 	}
 
+	var task domain.Task
 	err := m.Connection.Collection(m.ActualCollectionName(config.TasksCollectionTitle)).
-		FindOneAndUpdate(ctx, filter, update, &task, mongodriver.ReturnDocument(options.After))
+		FindOneAndUpdate(ctx, filter, update, &task,
+			mongodriver.Sort(bson.D{{Key: "job_number", Value: 1}, {Key: "last_updated", Value: 1}}),
+			mongodriver.ReturnDocument(options.After),
+		)
 	if err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) || errors.Is(err, mongo.ErrNoDocuments) {
-			// If no pending jobs, no error.
 			return nil, nil
 		}
 		return nil, err
@@ -111,6 +331,17 @@ func (m *Mongo) ClaimTask(ctx context.Context, pendingState, activeState domain.
 	return &task, nil
 }
 
+// UpdateJobTasksPriority sets the priority of every task of a job.
+func (m *Mongo) UpdateJobTasksPriority(ctx context.Context, jobNumber, priority int) error {
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.TasksCollectionTitle)).
+		UpdateMany(ctx, bson.M{"job_number": jobNumber}, bson.M{"$set": bson.M{"priority": priority}})
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
+
 // GetJobTasks retrieves a list of migration tasks for a job with pagination.
 func (m *Mongo) GetJobTasks(ctx context.Context, stateFilter []domain.State, jobNumber, limit, offset int) ([]*domain.Task, int, error) {
 	var results []*domain.Task
@@ -138,6 +369,80 @@ func (m *Mongo) GetJobTasks(ctx context.Context, stateFilter []domain.State, job
 	return results, totalCount, nil
 }
 
+// GetJobTasksAfter retrieves a page of migration tasks for a job, sorted by
+// task ID, starting after the provided cursor. Unlike last_updated, the task
+// ID never changes, so pages are stable while tasks are being updated. The
+// returned cursor is nil if there are no more tasks.
+func (m *Mongo) GetJobTasksAfter(ctx context.Context, stateFilter []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error) {
+	filter := bson.M{"job_number": jobNumber}
+
+	if len(stateFilter) > 0 {
+		filter["state"] = bson.M{"$in": stateFilter}
+	}
+
+	if after != nil && !after.IsStart() {
+		filter["_id"] = bson.M{"$gt": after.ID}
+	}
+
+	results, hasMore, err := findPage[domain.Task](
+		ctx,
+		m.Connection.Collection(m.ActualCollectionName(config.TasksCollectionTitle)),
+		filter,
+		bson.D{{Key: "_id", Value: 1}},
+		limit,
+	)
+	if err != nil {
+		return nil, nil, appErrors.ErrInternalServerError
+	}
+
+	if !hasMore || len(results) == 0 {
+		return results, nil, nil
+	}
+
+	last := results[len(results)-1]
+	return results, &domain.Cursor{ID: last.ID}, nil
+}
+
+// GetStaleTasks retrieves the tasks in any of the given states that were
+// last updated before the provided time, longest waiting first, along with
+// the total count of such tasks.
+func (m *Mongo) GetStaleTasks(ctx context.Context, states []domain.State, updatedBefore time.Time, limit int) ([]*domain.Task, int, error) {
+	var results []*domain.Task
+
+	filter := bson.M{
+		"state":        bson.M{"$in": states},
+		"last_updated": bson.M{"$lt": updatedBefore},
+	}
+
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.TasksCollectionTitle)).
+		Find(
+			ctx,
+			filter,
+			&results,
+			mongodriver.Limit(limit),
+			mongodriver.Sort(bson.M{"last_updated": 1}),
+		)
+
+	if err != nil {
+		return nil, 0, appErrors.ErrInternalServerError
+	}
+
+	return results, totalCount, nil
+}
+
+// GetDependentTasks retrieves the tasks that depend on a task.
+func (m *Mongo) GetDependentTasks(ctx context.Context, taskID string) ([]*domain.Task, error) {
+	var results []*domain.Task
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.TasksCollectionTitle)).
+		Find(ctx, bson.M{"depends_on": taskID}, &results)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return results, nil
+}
+
 // CountTasksByJobNumber returns the total count of tasks for a job.
 func (m *Mongo) CountTasksByJobNumber(ctx context.Context, jobNumber int) (int, error) {
 	filter := bson.M{"job_number": jobNumber}
@@ -0,0 +1,84 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDependencyStage(t *testing.T) {
+	Convey("Given the pending state of a stage that waits on dependencies", t, func() {
+		Convey("When its stage is looked up", func() {
+			stage, waits := dependencyStage(domain.StateApproved)
+
+			Convey("Then the stage is returned along with its dependency state", func() {
+				So(waits, ShouldBeTrue)
+				So(stage.PendingState, ShouldEqual, domain.StateApproved)
+				So(stage.DependencyState, ShouldEqual, domain.StatePublished)
+			})
+		})
+	})
+
+	Convey("Given the pending state of a stage that does not wait on dependencies", t, func() {
+		Convey("When its stage is looked up", func() {
+			_, waits := dependencyStage(domain.StateRejected)
+
+			Convey("Then tasks in it do not wait", func() {
+				So(waits, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a state that is not the pending state of a stage", t, func() {
+		Convey("When its stage is looked up", func() {
+			_, waits := dependencyStage(domain.StateMigrating)
+
+			Convey("Then tasks in it do not wait", func() {
+				So(waits, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestReleaseDependenciesUpdate(t *testing.T) {
+	Convey("Given dependencies that have reached the dependency state", t, func() {
+		dependencyIDs := []string{"task-1", "task-2"}
+
+		Convey("When the update releasing them is built", func() {
+			update := releaseDependenciesUpdate(dependencyIDs)
+
+			Convey("Then they are removed from the unmet dependencies first", func() {
+				So(update, ShouldHaveLength, 2)
+				So(update[0], ShouldResemble, bson.D{{Key: "$set", Value: bson.M{
+					"unmet_dependencies": bson.M{"$setDifference": bson.A{
+						bson.M{"$ifNull": bson.A{"$unmet_dependencies", bson.A{}}},
+						bson.M{"$literal": dependencyIDs},
+					}},
+				}}})
+			})
+
+			Convey("And the task is only blocked while unmet dependencies remain", func() {
+				So(update[1], ShouldResemble, bson.D{{Key: "$set", Value: bson.M{
+					"blocked": bson.M{"$gt": bson.A{bson.M{"$size": "$unmet_dependencies"}, 0}},
+				}}})
+			})
+		})
+	})
+}
+
+func TestUniqueIDs(t *testing.T) {
+	Convey("Given IDs containing duplicates", t, func() {
+		ids := []string{"task-2", "task-1", "task-2"}
+
+		Convey("When they are made unique", func() {
+			unique := uniqueIDs(ids)
+
+			Convey("Then each ID is kept once in its first position", func() {
+				So(unique, ShouldResemble, []string{"task-2", "task-1"})
+			})
+		})
+	})
+}
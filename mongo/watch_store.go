@@ -0,0 +1,70 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dis-migration-service/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WatchWork opens a change stream on the jobs and tasks collections and
+// calls notify for each insert, state change or task being unblocked. It returns nil once the
+// context is done. Change streams need a replica set and are not exposed
+// by the shared connection, so the stream has a client of its own.
+func (m *Mongo) WatchWork(ctx context.Context, notify func()) error {
+	uri, err := m.GetConnectionURI()
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := m.GetTLSConfig()
+	if err != nil {
+		return err
+	}
+
+	clientOptions := options.Client().
+		ApplyURI(uri).
+		SetTLSConfig(tlsConfig).
+		SetConnectTimeout(m.ConnectTimeout)
+
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(context.WithoutCancel(ctx)) //nolint:errcheck // the stream has already stopped
+
+	// Only changes that can make a job or task claimable are watched,
+	// rather than every update to last_updated or links
+	pipeline := mongo.Pipeline{
+		{
+			{Key: "$match", Value: bson.M{
+				"ns.coll": bson.M{"$in": bson.A{
+					m.ActualCollectionName(config.JobsCollectionTitle),
+					m.ActualCollectionName(config.TasksCollectionTitle),
+				}},
+				"$or": bson.A{
+					bson.M{"operationType": bson.M{"$in": bson.A{"insert", "replace"}}},
+					bson.M{"operationType": "update", "updateDescription.updatedFields.state": bson.M{"$exists": true}},
+					bson.M{"operationType": "update", "updateDescription.updatedFields.blocked": false},
+				},
+			}},
+		},
+	}
+
+	stream, err := client.Database(m.Database).Watch(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.WithoutCancel(ctx)) //nolint:errcheck // the stream has already stopped
+
+	for stream.Next(ctx) {
+		notify()
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return stream.Err()
+}
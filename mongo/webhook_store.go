@@ -0,0 +1,201 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateWebhook creates a new webhook subscription.
+func (m *Mongo) CreateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.WebhooksCollectionTitle)).InsertOne(ctx, webhook)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// GetWebhook retrieves a webhook subscription by its ID.
+func (m *Mongo) GetWebhook(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	if err := m.Connection.Collection(m.ActualCollectionName(config.WebhooksCollectionTitle)).
+		FindOne(ctx, bson.M{"_id": webhookID}, &webhook); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) || errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, appErrors.ErrWebhookNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+	return &webhook, nil
+}
+
+// GetWebhooks retrieves a list of webhook subscriptions with pagination,
+// oldest first.
+func (m *Mongo) GetWebhooks(ctx context.Context, limit, offset int) ([]*domain.Webhook, int, error) {
+	var results []*domain.Webhook
+
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.WebhooksCollectionTitle)).
+		Find(
+			ctx,
+			bson.M{},
+			&results,
+			mongodriver.Limit(limit),
+			mongodriver.Offset(offset),
+			mongodriver.Sort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}),
+		)
+	if err != nil {
+		return nil, 0, appErrors.ErrInternalServerError
+	}
+
+	return results, totalCount, nil
+}
+
+// GetWebhooksForEvent retrieves the enabled webhook subscriptions for an
+// event type that either have no job type filter or include the job type.
+func (m *Mongo) GetWebhooksForEvent(ctx context.Context, eventType domain.WebhookEventType, jobType domain.JobType) ([]*domain.Webhook, error) {
+	var results []*domain.Webhook
+
+	filter := bson.M{
+		"enabled":     true,
+		"event_types": eventType,
+		"$or": []bson.M{
+			{"job_types": bson.M{"$exists": false}},
+			{"job_types": bson.M{"$size": 0}},
+			{"job_types": jobType},
+		},
+	}
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.WebhooksCollectionTitle)).
+		Find(ctx, filter, &results)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return results, nil
+}
+
+// UpdateWebhook updates an existing webhook subscription.
+func (m *Mongo) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	filter := bson.M{"_id": webhook.ID}
+	update := bson.M{"$set": webhook}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.WebhooksCollectionTitle)).
+		UpdateOne(ctx, filter, update)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	if result.MatchedCount == 0 {
+		return appErrors.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// DeleteWebhook deletes a webhook subscription.
+func (m *Mongo) DeleteWebhook(ctx context.Context, webhookID string) error {
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.WebhooksCollectionTitle)).
+		DeleteOne(ctx, bson.M{"_id": webhookID})
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	if result.DeletedCount == 0 {
+		return appErrors.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// CreateWebhookDelivery creates a new webhook delivery.
+func (m *Mongo) CreateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.WebhookDeliveriesCollectionTitle)).InsertOne(ctx, delivery)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries retrieves a list of the deliveries to a webhook
+// with pagination, newest first. An empty status returns deliveries in
+// any status.
+func (m *Mongo) GetWebhookDeliveries(ctx context.Context, webhookID string, status domain.WebhookDeliveryStatus, limit, offset int) ([]*domain.WebhookDelivery, int, error) {
+	var results []*domain.WebhookDelivery
+
+	filter := bson.M{"webhook_id": webhookID}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.WebhookDeliveriesCollectionTitle)).
+		Find(
+			ctx,
+			filter,
+			&results,
+			mongodriver.Limit(limit),
+			mongodriver.Offset(offset),
+			mongodriver.Sort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}),
+		)
+	if err != nil {
+		return nil, 0, appErrors.ErrInternalServerError
+	}
+
+	return results, totalCount, nil
+}
+
+// ClaimWebhookDelivery claims the pending webhook delivery that has been
+// due the longest, leasing it until the given time. A delivery that is not
+// updated before its lease expires, for example because the service
+// stopped while delivering it, can be claimed again.
+func (m *Mongo) ClaimWebhookDelivery(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+
+	filter := bson.M{
+		"status":          domain.WebhookDeliveryStatusPending,
+		"next_attempt_at": bson.M{"$lte": time.Now().UTC()},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"next_attempt_at": leaseUntil,
+		},
+	}
+
+	err := m.Connection.Collection(m.ActualCollectionName(config.WebhookDeliveriesCollectionTitle)).
+		FindOneAndUpdate(ctx, filter, update, &delivery, mongodriver.ReturnDocument(options.After), mongodriver.Sort(bson.M{"next_attempt_at": 1}))
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) || errors.Is(err, mongo.ErrNoDocuments) {
+			// If no pending deliveries, no error.
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// UpdateWebhookDelivery updates an existing webhook delivery.
+func (m *Mongo) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	filter := bson.M{"_id": delivery.ID}
+	update := bson.M{"$set": delivery}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.WebhookDeliveriesCollectionTitle)).
+		UpdateOne(ctx, filter, update)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	if result.MatchedCount == 0 {
+		return appErrors.ErrWebhookNotFound
+	}
+
+	return nil
+}
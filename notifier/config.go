@@ -0,0 +1,79 @@
+package notifier
+
+import "time"
+
+// DefaultRoutes sends every notification to Slack
+const DefaultRoutes = "*:*:slack"
+
+// Config holds configuration for routing notifications to sinks
+type Config struct {
+	// Routes are the routing rules, separated by semicolons, each in the
+	// form severity:job_type:sinks. A severity or job type of * matches any,
+	// and sinks is a comma separated list of sink names, each optionally
+	// followed by =target to override the sink's default destination. The
+	// first rule that matches a notification is used. Defaults to
+	// DefaultRoutes if empty.
+	Routes string `envconfig:"NOTIFIER_ROUTES"`
+
+	// WebhookURL is the default URL notifications are posted to by the
+	// webhook sink
+	WebhookURL string `envconfig:"NOTIFIER_WEBHOOK_URL"`
+
+	// WebhookTimeout is the timeout for posting a notification to a webhook
+	WebhookTimeout time.Duration `envconfig:"NOTIFIER_WEBHOOK_TIMEOUT"`
+
+	// EmailSMTPAddr is the host and port of the SMTP server used by the
+	// email sink
+	EmailSMTPAddr string `envconfig:"NOTIFIER_EMAIL_SMTP_ADDR"`
+
+	// EmailFrom is the sender address of notification emails
+	EmailFrom string `envconfig:"NOTIFIER_EMAIL_FROM"`
+
+	// EmailTo is the default list of recipients of notification emails
+	EmailTo []string `envconfig:"NOTIFIER_EMAIL_TO"`
+}
+
+// Validate checks that the routes can be parsed and that every sink they
+// use has the configuration it needs
+func (c *Config) Validate() error {
+	if c == nil {
+		return errNilNotifierConfig
+	}
+
+	routes, err := ParseRoutes(c.routes())
+	if err != nil {
+		return err
+	}
+
+	for _, route := range routes {
+		for _, sink := range route.Sinks {
+			switch sink.Name {
+			case SinkWebhook:
+				if sink.Target == "" && c.WebhookURL == "" {
+					return errMissingWebhookURL
+				}
+			case SinkEmail:
+				if c.EmailSMTPAddr == "" {
+					return errMissingEmailSMTPAddr
+				}
+				if c.EmailFrom == "" {
+					return errMissingEmailSender
+				}
+				if sink.Target == "" && len(c.EmailTo) == 0 {
+					return errMissingEmailRecipient
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// routes returns the configured routing rules, or the default rules if
+// none are configured
+func (c *Config) routes() string {
+	if c.Routes == "" {
+		return DefaultRoutes
+	}
+	return c.Routes
+}
@@ -0,0 +1,100 @@
+package notifier
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfigValidate(t *testing.T) {
+	Convey("Given a nil notifier config", t, func() {
+		var cfg *Config
+
+		Convey("Then validating it returns an error", func() {
+			So(cfg.Validate(), ShouldEqual, errNilNotifierConfig)
+		})
+	})
+
+	Convey("Given a notifier config with no routes", t, func() {
+		cfg := &Config{}
+
+		Convey("Then it is valid and uses the default routes", func() {
+			So(cfg.Validate(), ShouldBeNil)
+			So(cfg.routes(), ShouldEqual, DefaultRoutes)
+		})
+	})
+
+	Convey("Given a notifier config with invalid routes", t, func() {
+		cfg := &Config{Routes: "alarm:slack"}
+
+		Convey("Then validating it returns an error", func() {
+			So(cfg.Validate(), ShouldWrap, errInvalidRoute)
+		})
+	})
+
+	Convey("Given a notifier config routing to the webhook sink", t, func() {
+		cfg := &Config{Routes: "alarm:*:webhook"}
+
+		Convey("When no webhook URL is configured", func() {
+			Convey("Then validating it returns an error", func() {
+				So(cfg.Validate(), ShouldEqual, errMissingWebhookURL)
+			})
+		})
+
+		Convey("When a webhook URL is configured", func() {
+			cfg.WebhookURL = "http://localhost:9999/hook"
+
+			Convey("Then it is valid", func() {
+				So(cfg.Validate(), ShouldBeNil)
+			})
+		})
+
+		Convey("When the route has a webhook URL target", func() {
+			cfg.Routes = "alarm:*:webhook=http://localhost:9999/hook"
+
+			Convey("Then it is valid", func() {
+				So(cfg.Validate(), ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a notifier config routing to the email sink", t, func() {
+		cfg := &Config{
+			Routes:        "*:*:email",
+			EmailSMTPAddr: "localhost:1025",
+			EmailFrom:     "dis-migration-service@localhost",
+		}
+
+		Convey("When no email recipients are configured", func() {
+			Convey("Then validating it returns an error", func() {
+				So(cfg.Validate(), ShouldEqual, errMissingEmailRecipient)
+			})
+		})
+
+		Convey("When no SMTP address is configured", func() {
+			cfg.EmailTo = []string{"team@example.com"}
+			cfg.EmailSMTPAddr = ""
+
+			Convey("Then validating it returns an error", func() {
+				So(cfg.Validate(), ShouldEqual, errMissingEmailSMTPAddr)
+			})
+		})
+
+		Convey("When no sender address is configured", func() {
+			cfg.EmailTo = []string{"team@example.com"}
+			cfg.EmailFrom = ""
+
+			Convey("Then validating it returns an error", func() {
+				So(cfg.Validate(), ShouldEqual, errMissingEmailSender)
+			})
+		})
+
+		Convey("When email recipients are configured", func() {
+			cfg.EmailTo = []string{"team@example.com"}
+
+			Convey("Then it is valid", func() {
+				So(cfg.Validate(), ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/smtp"
+	"slices"
+	"strings"
+)
+
+// SendMailFunc sends an email, with the signature of smtp.SendMail
+type SendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// EmailNotifier is a Notifier that sends notifications as plain text
+// emails through an SMTP server. It does not authenticate, so it is
+// intended for a local SMTP relay or stand-in rather than a public server.
+type EmailNotifier struct {
+	addr     string
+	from     string
+	to       []string
+	sendMail SendMailFunc
+}
+
+// NewEmailNotifier creates an EmailNotifier that sends from the address to
+// the recipients through the SMTP server at addr, unless a notification is
+// routed to a different recipient
+func NewEmailNotifier(addr, from string, to []string) *EmailNotifier {
+	return &EmailNotifier{
+		addr:     addr,
+		from:     from,
+		to:       to,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// Notify sends the notification as an email
+func (e *EmailNotifier) Notify(ctx context.Context, notification *Notification) error {
+	to := e.to
+	if notification.Target != "" {
+		to = []string{notification.Target}
+	}
+
+	if len(to) == 0 {
+		return errMissingEmailRecipient
+	}
+
+	if err := e.sendMail(e.addr, nil, e.from, to, e.buildMessage(to, notification)); err != nil {
+		return fmt.Errorf("failed to send email notification: %w", err)
+	}
+
+	return nil
+}
+
+// buildMessage constructs the email for a notification, with the severity
+// in the subject and the error and details in the body
+func (e *EmailNotifier) buildMessage(to []string, notification *Notification) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\r\n", e.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: [%s] %s\r\n", strings.ToUpper(string(notification.Severity)), notification.Summary)
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+
+	fmt.Fprintf(&b, "%s\r\n", notification.Summary)
+
+	if notification.Err != nil {
+		fmt.Fprintf(&b, "\r\nError: %s\r\n", notification.Err.Error())
+	}
+
+	if len(notification.Details) > 0 {
+		b.WriteString("\r\n")
+		// Sort details keys for consistent ordering
+		for _, key := range slices.Sorted(maps.Keys(notification.Details)) {
+			fmt.Fprintf(&b, "%s: %v\r\n", key, notification.Details[key])
+		}
+	}
+
+	return []byte(b.String())
+}
@@ -0,0 +1,86 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type sentMail struct {
+	addr string
+	from string
+	to   []string
+	msg  string
+}
+
+func TestEmailNotifierNotify(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given an email notifier", t, func() {
+		var sent []sentMail
+		var sendErr error
+
+		n := NewEmailNotifier("localhost:1025", "migrations@example.com", []string{"team@example.com"})
+		n.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			sent = append(sent, sentMail{addr: addr, from: from, to: to, msg: string(msg)})
+			return sendErr
+		}
+
+		Convey("When a notification is sent", func() {
+			err := n.Notify(ctx, &Notification{
+				Severity: SeverityAlarm,
+				Summary:  "job failed",
+				Err:      errors.New("update failed"),
+				Details:  Details{"Job Number": 7, "Job Label": "Test Job"},
+			})
+
+			Convey("Then it is emailed to the default recipients", func() {
+				So(err, ShouldBeNil)
+				So(sent, ShouldHaveLength, 1)
+				So(sent[0].addr, ShouldEqual, "localhost:1025")
+				So(sent[0].from, ShouldEqual, "migrations@example.com")
+				So(sent[0].to, ShouldResemble, []string{"team@example.com"})
+			})
+
+			Convey("And the email has the severity, summary, error and details", func() {
+				So(sent[0].msg, ShouldContainSubstring, "Subject: [ALARM] job failed\r\n")
+				So(sent[0].msg, ShouldContainSubstring, "Error: update failed\r\n")
+				So(sent[0].msg, ShouldContainSubstring, "Job Label: Test Job\r\nJob Number: 7\r\n")
+			})
+		})
+
+		Convey("When a notification is routed to another recipient", func() {
+			err := n.Notify(ctx, &Notification{Severity: SeverityInfo, Summary: "job completed", Target: "oncall@example.com"})
+
+			Convey("Then it is emailed to that recipient", func() {
+				So(err, ShouldBeNil)
+				So(sent[0].to, ShouldResemble, []string{"oncall@example.com"})
+			})
+		})
+
+		Convey("When sending the email fails", func() {
+			sendErr = errors.New("connection refused")
+
+			err := n.Notify(ctx, &Notification{Severity: SeverityInfo, Summary: "job completed"})
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldWrap, sendErr)
+			})
+		})
+	})
+
+	Convey("Given an email notifier with no default recipients", t, func() {
+		n := NewEmailNotifier("localhost:1025", "migrations@example.com", nil)
+
+		Convey("When a notification is sent", func() {
+			err := n.Notify(ctx, &Notification{Severity: SeverityInfo, Summary: "job completed"})
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldEqual, errMissingEmailRecipient)
+			})
+		})
+	})
+}
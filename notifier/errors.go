@@ -0,0 +1,22 @@
+package notifier
+
+import "errors"
+
+// Configuration validation errors
+var (
+	errNilNotifierConfig     = errors.New("notifier configuration is nil")
+	errInvalidRoute          = errors.New("notifier route must be in the form severity:job_type:sinks")
+	errInvalidRouteSeverity  = errors.New("notifier route severity is invalid")
+	errInvalidRouteJobType   = errors.New("notifier route job type is invalid")
+	errMissingRouteSinks     = errors.New("notifier route has no sinks")
+	errUnknownSink           = errors.New("notifier sink is unknown")
+	errMissingWebhookURL     = errors.New("notifier webhook URL is missing")
+	errMissingEmailRecipient = errors.New("notifier email recipient is missing")
+	errMissingEmailSMTPAddr  = errors.New("notifier email SMTP address is missing")
+	errMissingEmailSender    = errors.New("notifier email sender is missing")
+)
+
+// Runtime errors
+var (
+	errEmptySummary = errors.New("summary cannot be empty")
+)
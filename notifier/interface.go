@@ -0,0 +1,36 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+)
+
+//go:generate moq -out mocks/notifier.go -pkg mocks . Notifier
+
+// Notifier represents an interface for sending notifications, implemented
+// by each sink and by the Router that sends to them.
+type Notifier interface {
+	// Notify sends a notification
+	Notify(ctx context.Context, notification *Notification) error
+}
+
+// Threader is implemented by notifiers that can group the notifications
+// about a job under a parent message. A notification is sent as a reply
+// in a thread when its Thread is set.
+type Threader interface {
+	// StartThread sends the parent message of a thread, returning a nil
+	// thread if no sink the notification is routed to supports threads
+	StartThread(ctx context.Context, notification *Notification) (*domain.NotificationThread, error)
+
+	// UpdateThread replaces the parent message of a thread
+	UpdateThread(ctx context.Context, thread *domain.NotificationThread, notification *Notification) error
+}
+
+// ApprovalRequester is implemented by notifiers that can ask for a job to
+// be approved or rejected from the notification itself.
+type ApprovalRequester interface {
+	// RequestApproval sends a notification with controls to approve or
+	// reject the job with the given number
+	RequestApproval(ctx context.Context, notification *Notification, jobNumber int) error
+}
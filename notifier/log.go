@@ -0,0 +1,44 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// LogNotifier is a Notifier that only writes notifications to the service
+// log, at a level matching their severity
+type LogNotifier struct{}
+
+// NewLogNotifier creates a LogNotifier
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// Notify logs the notification
+func (l *LogNotifier) Notify(ctx context.Context, notification *Notification) error {
+	logData := log.Data{
+		"severity": notification.Severity,
+		"details":  notification.Details,
+	}
+	if notification.JobType != "" {
+		logData["job_type"] = notification.JobType
+	}
+
+	switch notification.Severity {
+	case SeverityAlarm:
+		err := notification.Err
+		if err == nil {
+			err = errors.New(notification.Summary)
+		}
+		log.Error(ctx, notification.Summary, err, logData)
+	case SeverityWarning:
+		log.Warn(ctx, notification.Summary, logData)
+	default:
+		logData["success"] = notification.Success
+		log.Info(ctx, notification.Summary, logData)
+	}
+
+	return nil
+}
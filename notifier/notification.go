@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+)
+
+// Severity represents how urgently a notification needs attention
+type Severity string
+
+// Notification severities
+const (
+	// SeverityInfo is for notifications that need no action, such as a job
+	// completing
+	SeverityInfo Severity = "info"
+	// SeverityWarning is for notifications of problems that may need action
+	SeverityWarning Severity = "warning"
+	// SeverityAlarm is for notifications of failures that need action
+	SeverityAlarm Severity = "alarm"
+)
+
+// IsValidSeverity checks if the provided severity is a known severity
+func IsValidSeverity(severity Severity) bool {
+	switch severity {
+	case SeverityInfo, SeverityWarning, SeverityAlarm:
+		return true
+	default:
+		return false
+	}
+}
+
+// Details is a map of key-value pairs containing details to include in a
+// notification
+type Details map[string]interface{}
+
+// Notification is a message to be sent to the sinks routed to for its
+// severity and job type
+type Notification struct {
+	Severity Severity
+	Summary  string
+	// Err is the error that caused an alarm, if any
+	Err     error
+	Details Details
+	// Success reports whether an info notification is for an operation
+	// that succeeded
+	Success bool
+	// JobType is the type of the job the notification is about, if known,
+	// used to route notifications for particular job types
+	JobType domain.JobType
+	// JobState is the current state of the job the notification is about,
+	// if known, used to show the job's progress on its thread
+	JobState domain.State
+	// Thread is the thread to send the notification as a reply in, if
+	// any. Sinks that do not support threads ignore it.
+	Thread *domain.NotificationThread
+	// Target is a sink specific destination, such as a Slack channel, that
+	// overrides the sink's default. It is set by routing.
+	Target string
+}
+
+// NewJobThreadNotification builds the parent message of a job's
+// notification thread, summarising the job and its state
+func NewJobThreadNotification(job *domain.Job, state domain.State) *Notification {
+	stateLabel, err := domain.GetStateLabel(state)
+	if err != nil {
+		stateLabel = string(state)
+	}
+
+	var jobType domain.JobType
+	if job.Config != nil {
+		jobType = job.Config.Type
+	}
+
+	return &Notification{
+		Severity: SeverityInfo,
+		Summary:  fmt.Sprintf("Migration job %d: %s", job.JobNumber, stateLabel),
+		Details: Details{
+			"Job Number": job.JobNumber,
+			"Job Label":  job.Label,
+			"Job Type":   jobType,
+			"State":      state,
+		},
+		JobType:  jobType,
+		JobState: state,
+	}
+}
@@ -0,0 +1,72 @@
+package notifier
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/dis-migration-service/slack"
+)
+
+// New returns a Router that sends notifications to the sinks configured
+// for them. Notifications routed to the slack sink are sent with
+// slackClient, or with a client created from slackCfg when they are routed
+// to a particular channel. The config is validated before creating the
+// Router.
+func New(cfg *Config, slackCfg *slack.Config, slackClient slack.Clienter) (*Router, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid notifier configuration: %w", err)
+	}
+
+	routes, err := ParseRoutes(cfg.routes())
+	if err != nil {
+		return nil, err
+	}
+
+	sinks := map[string]Notifier{}
+
+	resolve := func(name string) (Notifier, error) {
+		if sink, ok := sinks[name]; ok {
+			return sink, nil
+		}
+
+		var sink Notifier
+		switch name {
+		case SinkSlack:
+			sink = NewSlackNotifier(slackClient, slackChannelClient(slackCfg))
+		case SinkWebhook:
+			sink = NewWebhookNotifier(cfg.WebhookURL, &http.Client{Timeout: cfg.WebhookTimeout})
+		case SinkEmail:
+			sink = NewEmailNotifier(cfg.EmailSMTPAddr, cfg.EmailFrom, cfg.EmailTo)
+		case SinkLog:
+			sink = NewLogNotifier()
+		default:
+			return nil, errUnknownSink
+		}
+
+		sinks[name] = sink
+		return sink, nil
+	}
+
+	return NewRouter(routes, resolve)
+}
+
+// slackChannelClient returns a ChannelClientFunc that creates Slack
+// clients from the config, with every severity sent to the channel
+func slackChannelClient(slackCfg *slack.Config) ChannelClientFunc {
+	if slackCfg == nil {
+		return nil
+	}
+
+	return func(channel string) (slack.Clienter, error) {
+		return slack.New(&slack.Config{
+			Enabled:  slackCfg.Enabled,
+			APIToken: slackCfg.APIToken,
+			APIURL:   slackCfg.APIURL,
+			Channels: slack.Channels{
+				PublishChannel: channel,
+				WarningChannel: channel,
+				AlarmChannel:   channel,
+			},
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dis-migration-service/slack"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the default notifier config", t, func() {
+		cfg := &Config{Routes: DefaultRoutes}
+		slackClient := newMockSlackClient()
+
+		Convey("When a notifier is created", func() {
+			n, err := New(cfg, &slack.Config{}, slackClient)
+			So(err, ShouldBeNil)
+
+			Convey("Then notifications are sent to Slack", func() {
+				So(n.Notify(ctx, &Notification{Severity: SeverityWarning, Summary: "job slow"}), ShouldBeNil)
+				So(slackClient.SendWarningCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a notifier config routing to the same sink twice", t, func() {
+		cfg := &Config{Routes: "alarm:*:log;*:*:log"}
+
+		Convey("When a notifier is created", func() {
+			n, err := New(cfg, nil, nil)
+
+			Convey("Then both routes share the sink", func() {
+				So(err, ShouldBeNil)
+				So(n.routes, ShouldHaveLength, 2)
+				So(n.routes[0].notifiers[0], ShouldEqual, n.routes[1].notifiers[0])
+				So(n.Notify(ctx, &Notification{Severity: SeverityAlarm, Summary: "job failed"}), ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given an invalid notifier config", t, func() {
+		cfg := &Config{Routes: "*:*:webhook"}
+
+		Convey("When a notifier is created", func() {
+			n, err := New(cfg, nil, nil)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldWrap, errMissingWebhookURL)
+				So(n, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,248 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+)
+
+// Sink names that can be used in routes
+const (
+	SinkSlack   = "slack"
+	SinkWebhook = "webhook"
+	SinkEmail   = "email"
+	SinkLog     = "log"
+)
+
+const (
+	routeSeparator  = ";"
+	fieldSeparator  = ":"
+	sinkSeparator   = ","
+	targetSeparator = "="
+	wildcard        = "*"
+)
+
+// SinkSpec names a sink and an optional destination overriding the sink's
+// default
+type SinkSpec struct {
+	Name   string
+	Target string
+}
+
+// Route sends notifications matching its severity and job type to its
+// sinks. An empty severity or job type matches any.
+type Route struct {
+	Severity Severity
+	JobType  domain.JobType
+	Sinks    []SinkSpec
+}
+
+// Matches returns true if the notification has the route's severity and
+// job type
+func (r Route) Matches(notification *Notification) bool {
+	if r.Severity != "" && r.Severity != notification.Severity {
+		return false
+	}
+	if r.JobType != "" && r.JobType != notification.JobType {
+		return false
+	}
+	return true
+}
+
+// ParseRoutes parses routing rules in the form described by Config.Routes
+func ParseRoutes(rules string) ([]Route, error) {
+	var routes []Route
+
+	for _, rule := range strings.Split(rules, routeSeparator) {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		fields := strings.SplitN(rule, fieldSeparator, 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%w: %q", errInvalidRoute, rule)
+		}
+
+		var route Route
+
+		if severity := strings.TrimSpace(fields[0]); severity != wildcard {
+			route.Severity = Severity(severity)
+			if !IsValidSeverity(route.Severity) {
+				return nil, fmt.Errorf("%w: %q", errInvalidRouteSeverity, severity)
+			}
+		}
+
+		if jobType := strings.TrimSpace(fields[1]); jobType != wildcard {
+			route.JobType = domain.JobType(jobType)
+			if !domain.IsValidJobType(route.JobType) {
+				return nil, fmt.Errorf("%w: %q", errInvalidRouteJobType, jobType)
+			}
+		}
+
+		for _, sink := range strings.Split(fields[2], sinkSeparator) {
+			name, target, _ := strings.Cut(strings.TrimSpace(sink), targetSeparator)
+			if name == "" {
+				continue
+			}
+
+			switch name {
+			case SinkSlack, SinkWebhook, SinkEmail, SinkLog:
+			default:
+				return nil, fmt.Errorf("%w: %q", errUnknownSink, name)
+			}
+
+			route.Sinks = append(route.Sinks, SinkSpec{Name: name, Target: strings.TrimSpace(target)})
+		}
+
+		if len(route.Sinks) == 0 {
+			return nil, fmt.Errorf("%w: %q", errMissingRouteSinks, rule)
+		}
+
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+// SinkResolver returns the notifier for a sink name
+type SinkResolver func(name string) (Notifier, error)
+
+type resolvedRoute struct {
+	Route
+	notifiers []Notifier
+}
+
+// Router is a Notifier that sends each notification to the sinks of the
+// first route that matches it. Notifications that match no route are not
+// sent.
+type Router struct {
+	routes []resolvedRoute
+}
+
+// NewRouter creates a Router for the routes, resolving the notifier for
+// each sink with the resolver
+func NewRouter(routes []Route, resolve SinkResolver) (*Router, error) {
+	router := &Router{}
+
+	for _, route := range routes {
+		resolved := resolvedRoute{Route: route}
+		for _, sink := range route.Sinks {
+			n, err := resolve(sink.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve notifier sink %q: %w", sink.Name, err)
+			}
+			resolved.notifiers = append(resolved.notifiers, n)
+		}
+		router.routes = append(router.routes, resolved)
+	}
+
+	return router, nil
+}
+
+// Notify sends the notification to every sink of the first matching
+// route, returning the errors from any sinks that failed
+func (r *Router) Notify(ctx context.Context, notification *Notification) error {
+	if notification.Summary == "" {
+		return errEmptySummary
+	}
+
+	route := r.match(notification)
+	if route == nil {
+		return nil
+	}
+
+	var errs []error
+	for i, n := range route.notifiers {
+		if err := n.Notify(ctx, route.routed(i, notification)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", route.Sinks[i].Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// StartThread sends the parent message of a thread with the first sink of
+// the first matching route that supports threads
+func (r *Router) StartThread(ctx context.Context, notification *Notification) (*domain.NotificationThread, error) {
+	if notification.Summary == "" {
+		return nil, errEmptySummary
+	}
+
+	route := r.match(notification)
+	if route == nil {
+		return nil, nil
+	}
+
+	for i, n := range route.notifiers {
+		if threader, ok := n.(Threader); ok {
+			return threader.StartThread(ctx, route.routed(i, notification))
+		}
+	}
+
+	return nil, nil
+}
+
+// UpdateThread replaces the parent message of a thread with the first
+// sink of the first matching route that supports threads
+func (r *Router) UpdateThread(ctx context.Context, thread *domain.NotificationThread, notification *Notification) error {
+	if notification.Summary == "" {
+		return errEmptySummary
+	}
+
+	route := r.match(notification)
+	if route == nil {
+		return nil
+	}
+
+	for i, n := range route.notifiers {
+		if threader, ok := n.(Threader); ok {
+			return threader.UpdateThread(ctx, thread, route.routed(i, notification))
+		}
+	}
+
+	return nil
+}
+
+// RequestApproval sends the notification with controls to approve or reject
+// the job with the first sink of the first matching route that supports
+// approvals. It is not sent if no such sink is routed to.
+func (r *Router) RequestApproval(ctx context.Context, notification *Notification, jobNumber int) error {
+	if notification.Summary == "" {
+		return errEmptySummary
+	}
+
+	route := r.match(notification)
+	if route == nil {
+		return nil
+	}
+
+	for i, n := range route.notifiers {
+		if requester, ok := n.(ApprovalRequester); ok {
+			return requester.RequestApproval(ctx, route.routed(i, notification), jobNumber)
+		}
+	}
+
+	return nil
+}
+
+// match returns the first route that matches the notification, or nil if
+// none match
+func (r *Router) match(notification *Notification) *resolvedRoute {
+	for i := range r.routes {
+		if r.routes[i].Matches(notification) {
+			return &r.routes[i]
+		}
+	}
+	return nil
+}
+
+// routed returns a copy of the notification with the target of the route's
+// sink at index i
+func (r *resolvedRoute) routed(i int, notification *Notification) *Notification {
+	routed := *notification
+	routed.Target = r.Sinks[i].Target
+	return &routed
+}
@@ -0,0 +1,270 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// recordingNotifier records the notifications it is sent
+type recordingNotifier struct {
+	err           error
+	notifications []*Notification
+}
+
+func (r *recordingNotifier) Notify(ctx context.Context, notification *Notification) error {
+	r.notifications = append(r.notifications, notification)
+	return r.err
+}
+
+func TestParseRoutes(t *testing.T) {
+	Convey("Given valid routing rules", t, func() {
+		rules := "alarm:static_dataset:slack=#dataset-alarms,email=team@example.com; warning:*:log ;*:*:slack"
+
+		Convey("When the rules are parsed", func() {
+			routes, err := ParseRoutes(rules)
+
+			Convey("Then a route is returned for each rule in order", func() {
+				So(err, ShouldBeNil)
+				So(routes, ShouldResemble, []Route{
+					{
+						Severity: SeverityAlarm,
+						JobType:  domain.JobTypeStaticDataset,
+						Sinks: []SinkSpec{
+							{Name: SinkSlack, Target: "#dataset-alarms"},
+							{Name: SinkEmail, Target: "team@example.com"},
+						},
+					},
+					{
+						Severity: SeverityWarning,
+						Sinks:    []SinkSpec{{Name: SinkLog}},
+					},
+					{
+						Sinks: []SinkSpec{{Name: SinkSlack}},
+					},
+				})
+			})
+		})
+	})
+
+	Convey("Given invalid routing rules", t, func() {
+		tests := []struct {
+			rules       string
+			expectedErr error
+		}{
+			{rules: "alarm:slack", expectedErr: errInvalidRoute},
+			{rules: "critical:*:slack", expectedErr: errInvalidRouteSeverity},
+			{rules: "alarm:unknown_type:slack", expectedErr: errInvalidRouteJobType},
+			{rules: "alarm:*:pager", expectedErr: errUnknownSink},
+			{rules: "alarm:*:", expectedErr: errMissingRouteSinks},
+		}
+
+		for _, tt := range tests {
+			Convey("When the rules "+tt.rules+" are parsed", func() {
+				routes, err := ParseRoutes(tt.rules)
+
+				Convey("Then the expected error is returned", func() {
+					So(err, ShouldWrap, tt.expectedErr)
+					So(routes, ShouldBeNil)
+				})
+			})
+		}
+	})
+}
+
+func TestRouterNotify(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a router with a job type specific route and a catch all route", t, func() {
+		slackSink := &recordingNotifier{}
+		emailSink := &recordingNotifier{}
+		logSink := &recordingNotifier{}
+		sinks := map[string]Notifier{SinkSlack: slackSink, SinkEmail: emailSink, SinkLog: logSink}
+
+		routes, err := ParseRoutes("alarm:static_dataset:slack=#dataset-alarms,email;*:*:log")
+		So(err, ShouldBeNil)
+
+		router, err := NewRouter(routes, func(name string) (Notifier, error) {
+			return sinks[name], nil
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When an alarm for the job type is sent", func() {
+			err := router.Notify(ctx, &Notification{
+				Severity: SeverityAlarm,
+				Summary:  "job failed",
+				JobType:  domain.JobTypeStaticDataset,
+			})
+
+			Convey("Then it is sent to every sink of the matching route with its target", func() {
+				So(err, ShouldBeNil)
+				So(slackSink.notifications, ShouldHaveLength, 1)
+				So(slackSink.notifications[0].Summary, ShouldEqual, "job failed")
+				So(slackSink.notifications[0].Target, ShouldEqual, "#dataset-alarms")
+				So(emailSink.notifications, ShouldHaveLength, 1)
+				So(emailSink.notifications[0].Target, ShouldBeEmpty)
+			})
+
+			Convey("And it is not sent to the sinks of later routes", func() {
+				So(logSink.notifications, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When an alarm without a job type is sent", func() {
+			err := router.Notify(ctx, &Notification{
+				Severity: SeverityAlarm,
+				Summary:  "topic cache update failed",
+			})
+
+			Convey("Then it is sent to the catch all route", func() {
+				So(err, ShouldBeNil)
+				So(slackSink.notifications, ShouldBeEmpty)
+				So(logSink.notifications, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a sink fails", func() {
+			slackSink.err = errors.New("slack unavailable")
+
+			err := router.Notify(ctx, &Notification{
+				Severity: SeverityAlarm,
+				Summary:  "job failed",
+				JobType:  domain.JobTypeStaticDataset,
+			})
+
+			Convey("Then the error is returned and the other sinks are still sent the notification", func() {
+				So(err, ShouldWrap, slackSink.err)
+				So(err.Error(), ShouldContainSubstring, SinkSlack)
+				So(emailSink.notifications, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a notification without a summary is sent", func() {
+			err := router.Notify(ctx, &Notification{Severity: SeverityInfo})
+
+			Convey("Then an error is returned and nothing is sent", func() {
+				So(err, ShouldEqual, errEmptySummary)
+				So(logSink.notifications, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a router with no catch all route", t, func() {
+		logSink := &recordingNotifier{}
+
+		routes, err := ParseRoutes("alarm:*:log")
+		So(err, ShouldBeNil)
+
+		router, err := NewRouter(routes, func(name string) (Notifier, error) {
+			return logSink, nil
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When a notification matching no route is sent", func() {
+			err := router.Notify(ctx, &Notification{Severity: SeverityInfo, Summary: "job completed"})
+
+			Convey("Then it is not sent", func() {
+				So(err, ShouldBeNil)
+				So(logSink.notifications, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a sink that cannot be resolved", t, func() {
+		routes, err := ParseRoutes("*:*:webhook")
+		So(err, ShouldBeNil)
+
+		resolveErr := errors.New("no webhook URL")
+
+		Convey("When a router is created", func() {
+			router, err := NewRouter(routes, func(name string) (Notifier, error) {
+				return nil, resolveErr
+			})
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldWrap, resolveErr)
+				So(router, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestRouterThreads(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a router with Slack and log sinks", t, func() {
+		slackClient := newMockSlackClient()
+		logSink := &recordingNotifier{}
+		sinks := map[string]Notifier{SinkSlack: NewSlackNotifier(slackClient, nil), SinkLog: logSink}
+
+		routes, err := ParseRoutes("*:static_dataset:log,slack=#datasets;*:*:log")
+		So(err, ShouldBeNil)
+
+		router, err := NewRouter(routes, func(name string) (Notifier, error) {
+			return sinks[name], nil
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When a thread is started for a notification routed to Slack", func() {
+			thread, err := router.StartThread(ctx, &Notification{
+				Severity: SeverityInfo,
+				Summary:  "Migration job 1: Migrating",
+				JobType:  domain.JobTypeStaticDataset,
+			})
+
+			Convey("Then it is started by the Slack sink", func() {
+				So(err, ShouldBeNil)
+				So(thread, ShouldNotBeNil)
+				So(slackClient.StartThreadCalls(), ShouldHaveLength, 1)
+				So(logSink.notifications, ShouldBeEmpty)
+			})
+
+			Convey("And the thread can be updated", func() {
+				err := router.UpdateThread(ctx, thread, &Notification{
+					Severity: SeverityInfo,
+					Summary:  "Migration job 1: In review",
+					JobType:  domain.JobTypeStaticDataset,
+				})
+
+				So(err, ShouldBeNil)
+				So(slackClient.UpdateThreadCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a thread is started for a notification not routed to Slack", func() {
+			thread, err := router.StartThread(ctx, &Notification{Severity: SeverityInfo, Summary: "Migration job 1: Migrating"})
+
+			Convey("Then no thread is returned", func() {
+				So(err, ShouldBeNil)
+				So(thread, ShouldBeNil)
+				So(slackClient.StartThreadCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When approval is requested for a notification routed to Slack", func() {
+			err := router.RequestApproval(ctx, &Notification{
+				Severity: SeverityInfo,
+				Summary:  "Migration job 1 is ready for review",
+				JobType:  domain.JobTypeStaticDataset,
+			}, 1)
+
+			Convey("Then it is requested by the Slack sink", func() {
+				So(err, ShouldBeNil)
+				So(slackClient.SendApprovalRequestCalls(), ShouldHaveLength, 1)
+				So(logSink.notifications, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When approval is requested for a notification not routed to Slack", func() {
+			err := router.RequestApproval(ctx, &Notification{Severity: SeverityInfo, Summary: "Migration job 1 is ready for review"}, 1)
+
+			Convey("Then no approval request is sent", func() {
+				So(err, ShouldBeNil)
+				So(slackClient.SendApprovalRequestCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,172 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/slack"
+)
+
+// ChannelClientFunc returns a Slack client that sends every notification
+// to the given channel
+type ChannelClientFunc func(channel string) (slack.Clienter, error)
+
+// SlackNotifier is a Notifier that sends notifications with a Slack
+// client, to the client's channel for their severity or to the channel
+// they are routed to
+type SlackNotifier struct {
+	client        slack.Clienter
+	channelClient ChannelClientFunc
+
+	mu             sync.Mutex
+	channelClients map[string]slack.Clienter
+}
+
+// NewSlackNotifier creates a SlackNotifier that sends notifications with
+// the client. If channelClient is nil, notifications routed to a particular
+// channel are sent to the client's channel for their severity instead.
+func NewSlackNotifier(client slack.Clienter, channelClient ChannelClientFunc) *SlackNotifier {
+	return &SlackNotifier{
+		client:         client,
+		channelClient:  channelClient,
+		channelClients: map[string]slack.Clienter{},
+	}
+}
+
+// Notify sends the notification as a Slack alarm, warning or info message
+// depending on its severity, or as a reply if it has a thread. Alarms need
+// action, so are sent to the alarm channel as well as to their thread.
+func (s *SlackNotifier) Notify(ctx context.Context, notification *Notification) error {
+	client, err := s.clientFor(notification.Target)
+	if err != nil {
+		return err
+	}
+
+	details := slack.SlackDetails(notification.Details)
+
+	if notification.Thread != nil {
+		colour, emoji := replyStyle(notification)
+		err := client.ReplyToThread(ctx, toSlackThread(notification.Thread), colour, emoji, notification.Summary, notification.Err, details)
+		if notification.Severity != SeverityAlarm {
+			return err
+		}
+		return errors.Join(err, client.SendAlarm(ctx, notification.Summary, notification.Err, details))
+	}
+
+	switch notification.Severity {
+	case SeverityAlarm:
+		return client.SendAlarm(ctx, notification.Summary, notification.Err, details)
+	case SeverityWarning:
+		return client.SendWarning(ctx, notification.Summary, details)
+	default:
+		return client.SendInfo(ctx, notification.Summary, details, notification.Success)
+	}
+}
+
+// StartThread sends the parent message of a thread, coloured to show the
+// state of the job
+func (s *SlackNotifier) StartThread(ctx context.Context, notification *Notification) (*domain.NotificationThread, error) {
+	client, err := s.clientFor(notification.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	thread, err := client.StartThread(ctx, notification.Summary, stateColour(notification.JobState), slack.SlackDetails(notification.Details))
+	if err != nil || thread == nil {
+		return nil, err
+	}
+
+	return &domain.NotificationThread{
+		Channel:   thread.Channel,
+		Timestamp: thread.Timestamp,
+	}, nil
+}
+
+// UpdateThread replaces the parent message of a thread, coloured to show
+// the state of the job
+func (s *SlackNotifier) UpdateThread(ctx context.Context, thread *domain.NotificationThread, notification *Notification) error {
+	client, err := s.clientFor(notification.Target)
+	if err != nil {
+		return err
+	}
+
+	return client.UpdateThread(ctx, toSlackThread(thread), notification.Summary, stateColour(notification.JobState), slack.SlackDetails(notification.Details))
+}
+
+// RequestApproval sends the notification with buttons to approve or reject
+// the job, as a reply if it has a thread
+func (s *SlackNotifier) RequestApproval(ctx context.Context, notification *Notification, jobNumber int) error {
+	client, err := s.clientFor(notification.Target)
+	if err != nil {
+		return err
+	}
+
+	return client.SendApprovalRequest(ctx, toSlackThread(notification.Thread), notification.Summary, slack.SlackDetails(notification.Details), jobNumber)
+}
+
+// clientFor returns the client for a channel, creating it on first use
+func (s *SlackNotifier) clientFor(channel string) (slack.Clienter, error) {
+	if channel == "" || s.channelClient == nil {
+		return s.client, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if client, ok := s.channelClients[channel]; ok {
+		return client, nil
+	}
+
+	client, err := s.channelClient(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	s.channelClients[channel] = client
+	return client, nil
+}
+
+// toSlackThread converts a notification thread to a Slack thread
+func toSlackThread(thread *domain.NotificationThread) *slack.Thread {
+	if thread == nil {
+		return nil
+	}
+	return &slack.Thread{
+		Channel:   thread.Channel,
+		Timestamp: thread.Timestamp,
+	}
+}
+
+// replyStyle returns the colour and emoji of a thread reply, matching the
+// messages sent for each severity outside of a thread
+func replyStyle(notification *Notification) (slack.Colour, slack.Emoji) {
+	switch notification.Severity {
+	case SeverityAlarm:
+		return slack.RedColour, slack.AlarmEmoji
+	case SeverityWarning:
+		return slack.YellowColour, slack.WarningEmoji
+	default:
+		if notification.Success {
+			return slack.GreenColour, slack.InfoEmoji
+		}
+		return slack.RedColour, slack.InfoEmoji
+	}
+}
+
+// stateColour returns the colour of a thread's parent message for the
+// state of its job: red if it failed, green if it completed, grey if it
+// was cancelled and blue while it is in progress
+func stateColour(state domain.State) slack.Colour {
+	switch {
+	case domain.IsFailedState(state):
+		return slack.RedColour
+	case state == domain.StateCompleted:
+		return slack.GreenColour
+	case state == domain.StateCancelled:
+		return slack.GreyColour
+	default:
+		return slack.BlueColour
+	}
+}
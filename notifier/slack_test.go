@@ -0,0 +1,211 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	"github.com/ONSdigital/dis-migration-service/slack"
+	slackMocks "github.com/ONSdigital/dis-migration-service/slack/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newMockSlackClient() *slackMocks.ClienterMock {
+	return &slackMocks.ClienterMock{
+		SendInfoFunc: func(ctx context.Context, summary string, details slack.SlackDetails, success bool) error {
+			return nil
+		},
+		SendWarningFunc: func(ctx context.Context, summary string, details slack.SlackDetails) error {
+			return nil
+		},
+		SendAlarmFunc: func(ctx context.Context, summary string, err error, details slack.SlackDetails) error {
+			return nil
+		},
+		StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+			return &slack.Thread{Channel: "C123", Timestamp: "1700000000.000100"}, nil
+		},
+		UpdateThreadFunc: func(ctx context.Context, thread *slack.Thread, summary string, colour slack.Colour, details slack.SlackDetails) error {
+			return nil
+		},
+		ReplyToThreadFunc: func(ctx context.Context, thread *slack.Thread, colour slack.Colour, emoji slack.Emoji, summary string, err error, details slack.SlackDetails) error {
+			return nil
+		},
+		SendApprovalRequestFunc: func(ctx context.Context, thread *slack.Thread, summary string, details slack.SlackDetails, jobNumber int) error {
+			return nil
+		},
+	}
+}
+
+func TestSlackNotifierNotify(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a Slack notifier", t, func() {
+		client := newMockSlackClient()
+		channelClients := map[string]*slackMocks.ClienterMock{}
+
+		n := NewSlackNotifier(client, func(channel string) (slack.Clienter, error) {
+			channelClients[channel] = newMockSlackClient()
+			return channelClients[channel], nil
+		})
+
+		Convey("When an alarm is sent", func() {
+			alarmErr := errors.New("update failed")
+			err := n.Notify(ctx, &Notification{
+				Severity: SeverityAlarm,
+				Summary:  "job failed",
+				Err:      alarmErr,
+				Details:  Details{"Job Number": 1},
+			})
+
+			Convey("Then it is sent as a Slack alarm", func() {
+				So(err, ShouldBeNil)
+				So(client.SendAlarmCalls(), ShouldHaveLength, 1)
+				So(client.SendAlarmCalls()[0].Summary, ShouldEqual, "job failed")
+				So(client.SendAlarmCalls()[0].Err, ShouldEqual, alarmErr)
+				So(client.SendAlarmCalls()[0].Details, ShouldResemble, slack.SlackDetails{"Job Number": 1})
+			})
+		})
+
+		Convey("When a warning is sent", func() {
+			err := n.Notify(ctx, &Notification{Severity: SeverityWarning, Summary: "job slow"})
+
+			Convey("Then it is sent as a Slack warning", func() {
+				So(err, ShouldBeNil)
+				So(client.SendWarningCalls(), ShouldHaveLength, 1)
+				So(client.SendWarningCalls()[0].Summary, ShouldEqual, "job slow")
+			})
+		})
+
+		Convey("When an info notification is sent", func() {
+			err := n.Notify(ctx, &Notification{Severity: SeverityInfo, Summary: "job completed", Success: true})
+
+			Convey("Then it is sent as a Slack info message", func() {
+				So(err, ShouldBeNil)
+				So(client.SendInfoCalls(), ShouldHaveLength, 1)
+				So(client.SendInfoCalls()[0].Success, ShouldBeTrue)
+			})
+		})
+
+		Convey("When notifications are routed to a channel", func() {
+			So(n.Notify(ctx, &Notification{Severity: SeverityAlarm, Summary: "first", Target: "#alarms"}), ShouldBeNil)
+			So(n.Notify(ctx, &Notification{Severity: SeverityAlarm, Summary: "second", Target: "#alarms"}), ShouldBeNil)
+
+			Convey("Then they are sent with a single client for the channel", func() {
+				So(channelClients, ShouldHaveLength, 1)
+				So(channelClients["#alarms"].SendAlarmCalls(), ShouldHaveLength, 2)
+				So(client.SendAlarmCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a Slack notifier without a channel client func", t, func() {
+		client := newMockSlackClient()
+		n := NewSlackNotifier(client, nil)
+
+		Convey("When a notification is routed to a channel", func() {
+			err := n.Notify(ctx, &Notification{Severity: SeverityAlarm, Summary: "job failed", Target: "#alarms"})
+
+			Convey("Then it is sent with the default client", func() {
+				So(err, ShouldBeNil)
+				So(client.SendAlarmCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestSlackNotifierThreads(t *testing.T) {
+	ctx := context.Background()
+	thread := &domain.NotificationThread{Channel: "C123", Timestamp: "1700000000.000100"}
+
+	Convey("Given a Slack notifier", t, func() {
+		client := newMockSlackClient()
+		n := NewSlackNotifier(client, nil)
+
+		Convey("When a thread is started for a job in progress", func() {
+			started, err := n.StartThread(ctx, &Notification{
+				Severity: SeverityInfo,
+				Summary:  "Migration job 1: Migrating",
+				JobState: domain.StateMigrating,
+			})
+
+			Convey("Then a blue parent message is sent and its thread returned", func() {
+				So(err, ShouldBeNil)
+				So(started, ShouldResemble, thread)
+				So(client.StartThreadCalls(), ShouldHaveLength, 1)
+				So(client.StartThreadCalls()[0].Colour, ShouldEqual, slack.BlueColour)
+			})
+		})
+
+		Convey("When a thread is updated", func() {
+			tests := []struct {
+				state  domain.State
+				colour slack.Colour
+			}{
+				{state: domain.StateInReview, colour: slack.BlueColour},
+				{state: domain.StateFailedPublish, colour: slack.RedColour},
+				{state: domain.StateCompleted, colour: slack.GreenColour},
+				{state: domain.StateCancelled, colour: slack.GreyColour},
+			}
+
+			for _, tt := range tests {
+				So(n.UpdateThread(ctx, thread, &Notification{Summary: "Migration job 1", JobState: tt.state}), ShouldBeNil)
+			}
+
+			Convey("Then the parent message is coloured for the job's state", func() {
+				calls := client.UpdateThreadCalls()
+				So(calls, ShouldHaveLength, len(tests))
+				for i, tt := range tests {
+					So(calls[i].Thread.Timestamp, ShouldEqual, thread.Timestamp)
+					So(calls[i].Colour, ShouldEqual, tt.colour)
+				}
+			})
+		})
+
+		Convey("When an alarm with a thread is sent", func() {
+			err := n.Notify(ctx, &Notification{Severity: SeverityAlarm, Summary: "task failed", Thread: thread})
+
+			Convey("Then it is sent as a reply in the thread", func() {
+				So(err, ShouldBeNil)
+				So(client.ReplyToThreadCalls(), ShouldHaveLength, 1)
+				So(client.ReplyToThreadCalls()[0].Thread.Channel, ShouldEqual, "C123")
+				So(client.ReplyToThreadCalls()[0].Colour, ShouldEqual, slack.RedColour)
+				So(client.ReplyToThreadCalls()[0].Emoji, ShouldEqual, slack.AlarmEmoji)
+
+				Convey("And to the alarm channel", func() {
+					So(client.SendAlarmCalls(), ShouldHaveLength, 1)
+					So(client.SendAlarmCalls()[0].Summary, ShouldEqual, "task failed")
+				})
+			})
+		})
+
+		Convey("When an info notification with a thread is sent", func() {
+			err := n.Notify(ctx, &Notification{Severity: SeverityInfo, Summary: "task completed", Success: true, Thread: thread})
+
+			Convey("Then it is only sent as a reply in the thread", func() {
+				So(err, ShouldBeNil)
+				So(client.ReplyToThreadCalls(), ShouldHaveLength, 1)
+				So(client.ReplyToThreadCalls()[0].Colour, ShouldEqual, slack.GreenColour)
+				So(client.SendInfoCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When approval of a job with a thread is requested", func() {
+			err := n.RequestApproval(ctx, &Notification{
+				Severity: SeverityInfo,
+				Summary:  "Migration job 1 is ready for review",
+				Details:  Details{"Job Label": "CPI"},
+				Thread:   thread,
+			}, 1)
+
+			Convey("Then an approval request is sent as a reply in the thread", func() {
+				So(err, ShouldBeNil)
+				So(client.SendApprovalRequestCalls(), ShouldHaveLength, 1)
+				call := client.SendApprovalRequestCalls()[0]
+				So(call.Thread.Timestamp, ShouldEqual, thread.Timestamp)
+				So(call.JobNumber, ShouldEqual, 1)
+				So(call.Details["Job Label"], ShouldEqual, "CPI")
+			})
+		})
+	})
+}
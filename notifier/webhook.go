@@ -0,0 +1,88 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+)
+
+// HTTPClient is the client used to post notifications to webhooks
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// webhookBody is the JSON body of a notification posted to a webhook
+type webhookBody struct {
+	Severity Severity       `json:"severity"`
+	Summary  string         `json:"summary"`
+	Error    string         `json:"error,omitempty"`
+	Details  Details        `json:"details,omitempty"`
+	Success  bool           `json:"success"`
+	JobType  domain.JobType `json:"job_type,omitempty"`
+}
+
+// WebhookNotifier is a Notifier that posts notifications as JSON to a
+// generic webhook, such as a chat or incident management integration
+type WebhookNotifier struct {
+	url    string
+	client HTTPClient
+}
+
+// NewWebhookNotifier creates a WebhookNotifier that posts to the URL,
+// unless a notification is routed to a different URL
+func NewWebhookNotifier(url string, client HTTPClient) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: client,
+	}
+}
+
+// Notify posts the notification to the webhook. Any response other than a
+// 2xx is an error.
+func (w *WebhookNotifier) Notify(ctx context.Context, notification *Notification) error {
+	url := w.url
+	if notification.Target != "" {
+		url = notification.Target
+	}
+
+	body := webhookBody{
+		Severity: notification.Severity,
+		Summary:  notification.Summary,
+		Details:  notification.Details,
+		Success:  notification.Success,
+		JobType:  notification.JobType,
+	}
+	if notification.Err != nil {
+		body.Error = notification.Err.Error()
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook notification responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
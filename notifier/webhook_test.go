@@ -0,0 +1,77 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWebhookNotifierNotify(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a webhook notifier", t, func() {
+		status := http.StatusOK
+		var received []webhookBody
+		var paths []string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body webhookBody
+			payload, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(payload, &body)
+			received = append(received, body)
+			paths = append(paths, r.URL.Path)
+			w.WriteHeader(status)
+		}))
+		defer server.Close()
+
+		n := NewWebhookNotifier(server.URL+"/default", server.Client())
+
+		Convey("When a notification is sent", func() {
+			err := n.Notify(ctx, &Notification{
+				Severity: SeverityAlarm,
+				Summary:  "job failed",
+				Err:      errors.New("update failed"),
+				Details:  Details{"Job Label": "Test Job"},
+				JobType:  domain.JobTypeStaticDataset,
+			})
+
+			Convey("Then it is posted as JSON to the default URL", func() {
+				So(err, ShouldBeNil)
+				So(paths, ShouldResemble, []string{"/default"})
+				So(received, ShouldHaveLength, 1)
+				So(received[0].Severity, ShouldEqual, SeverityAlarm)
+				So(received[0].Summary, ShouldEqual, "job failed")
+				So(received[0].Error, ShouldEqual, "update failed")
+				So(received[0].Details["Job Label"], ShouldEqual, "Test Job")
+				So(received[0].JobType, ShouldEqual, domain.JobTypeStaticDataset)
+			})
+		})
+
+		Convey("When a notification is routed to another URL", func() {
+			err := n.Notify(ctx, &Notification{Severity: SeverityInfo, Summary: "job completed", Target: server.URL + "/routed"})
+
+			Convey("Then it is posted to that URL", func() {
+				So(err, ShouldBeNil)
+				So(paths, ShouldResemble, []string{"/routed"})
+			})
+		})
+
+		Convey("When the webhook responds with an error status", func() {
+			status = http.StatusBadGateway
+
+			err := n.Notify(ctx, &Notification{Severity: SeverityInfo, Summary: "job completed"})
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "502")
+			})
+		})
+	})
+}
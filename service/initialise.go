@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ONSdigital/dis-migration-service/api"
 	"github.com/ONSdigital/dis-migration-service/application"
 	"github.com/ONSdigital/dis-migration-service/cache"
 	"github.com/ONSdigital/dis-migration-service/clients"
@@ -13,12 +14,14 @@ import (
 	"github.com/ONSdigital/dis-migration-service/config"
 	"github.com/ONSdigital/dis-migration-service/migrator"
 	"github.com/ONSdigital/dis-migration-service/mongo"
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	"github.com/ONSdigital/dis-migration-service/slack"
 	"github.com/ONSdigital/dis-migration-service/store"
 	redirectAPI "github.com/ONSdigital/dis-redirect-api/sdk/go"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/dp-authorisation/v2/permissions"
 	datasetErrors "github.com/ONSdigital/dp-dataset-api/apierrors"
 	datasetModels "github.com/ONSdigital/dp-dataset-api/models"
 	datasetAPI "github.com/ONSdigital/dp-dataset-api/sdk"
@@ -27,6 +30,7 @@ import (
 	filesAPIMocks "github.com/ONSdigital/dp-files-api/sdk/mocks"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
 	topicAPI "github.com/ONSdigital/dp-topic-api/sdk"
 	uploadSDK "github.com/ONSdigital/dp-upload-service/sdk"
 	uploadSDKMocks "github.com/ONSdigital/dp-upload-service/sdk/mocks"
@@ -95,8 +99,8 @@ func (e *ExternalServiceList) GetSlackClient(ctx context.Context, cfg *config.Co
 }
 
 // GetMigrator returns the background migrator
-func (e *ExternalServiceList) GetMigrator(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, slackClient slack.Clienter, topicCache *cache.TopicCache) (migrator.Migrator, error) {
-	mig, err := e.Init.DoGetMigrator(ctx, cfg, jobService, clientList, slackClient, topicCache)
+func (e *ExternalServiceList) GetMigrator(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, jobNotifier notifier.Notifier, topicCache *cache.TopicCache) (migrator.Migrator, error) {
+	mig, err := e.Init.DoGetMigrator(ctx, cfg, jobService, clientList, jobNotifier, topicCache)
 	if err != nil {
 		return nil, err
 	}
@@ -156,11 +160,14 @@ func (e *Init) DoGetSlackClient(ctx context.Context, cfg *config.Config) (slack.
 	slackCfg := &slack.Config{
 		Enabled:  cfg.SlackConfig.Enabled,
 		APIToken: cfg.SlackConfig.APIToken,
+		APIURL:   cfg.SlackConfig.APIURL,
 		Channels: slack.Channels{
 			PublishChannel: cfg.SlackConfig.Channels.PublishChannel,
 			WarningChannel: cfg.SlackConfig.Channels.WarningChannel,
 			AlarmChannel:   cfg.SlackConfig.Channels.AlarmChannel,
 		},
+		ApprovalsEnabled: cfg.SlackConfig.ApprovalsEnabled,
+		SigningSecret:    cfg.SlackConfig.SigningSecret,
 	}
 
 	slackClient, err := slack.New(slackCfg)
@@ -178,8 +185,8 @@ func (e *Init) DoGetSlackClient(ctx context.Context, cfg *config.Config) (slack.
 }
 
 // DoGetMigrator returns a Migrator
-func (e *Init) DoGetMigrator(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, slackClient slack.Clienter, topicCache *cache.TopicCache) (migrator.Migrator, error) {
-	mig, err := migrator.NewDefaultMigrator(cfg, jobService, clientList, slackClient, topicCache)
+func (e *Init) DoGetMigrator(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, jobNotifier notifier.Notifier, topicCache *cache.TopicCache) (migrator.Migrator, error) {
+	mig, err := migrator.NewDefaultMigrator(cfg, jobService, clientList, jobNotifier, topicCache)
 	if err != nil {
 		log.Error(ctx, "failed to create migrator", err)
 		return nil, err
@@ -309,7 +316,7 @@ func (e *Init) DoGetAppClients(ctx context.Context, cfg *config.Config) *clients
 	uploadServiceClient.SetTimeout(60 * time.Second)
 	uploadServiceHC := health.NewClientWithClienter("upload-service", cfg.UploadServiceURL, uploadServiceClient)
 
-	return &clients.ClientList{
+	appClients := &clients.ClientList{
 		DatasetAPI:    datasetAPI.New(cfg.DatasetAPIURL),
 		FilesAPI:      filesAPI.New(cfg.FilesAPIURL),
 		RedirectAPI:   redirectAPI.NewClient(cfg.RedirectAPIURL),
@@ -317,6 +324,40 @@ func (e *Init) DoGetAppClients(ctx context.Context, cfg *config.Config) *clients
 		UploadService: uploadSDK.NewWithHealthClient(uploadServiceHC),
 		Zebedee:       zebedee.New(cfg.ZebedeeURL),
 	}
+
+	// Calls to Zebedee and the dataset API are rate limited and circuit
+	// broken for each attempt, so retries are wrapped around the guards
+	guards := clients.NewGuards(
+		clients.GuardConfig{
+			RateLimit:        cfg.ZebedeeRateLimit,
+			RateBurst:        cfg.ZebedeeRateBurst,
+			FailureThreshold: cfg.CircuitBreakerFailureThreshold,
+			OpenDuration:     cfg.CircuitBreakerOpenDuration,
+		},
+		clients.GuardConfig{
+			RateLimit:        cfg.DatasetAPIRateLimit,
+			RateBurst:        cfg.DatasetAPIRateBurst,
+			FailureThreshold: cfg.CircuitBreakerFailureThreshold,
+			OpenDuration:     cfg.CircuitBreakerOpenDuration,
+		},
+	)
+	appClients = clients.WithGuards(appClients, guards)
+
+	if cfg.EnableClientRetries {
+		appClients = clients.WithRetries(appClients, clients.RetryConfig{
+			MaxAttempts: cfg.ClientRetryMaxAttempts,
+			BaseDelay:   cfg.ClientRetryBaseDelay,
+			MaxDelay:    cfg.ClientRetryMaxDelay,
+		})
+	}
+
+	// Cached pages are served without calling Zebedee, so the cache is
+	// wrapped around the retries
+	if cfg.EnableZebedeePageCache {
+		appClients = clients.WithPageCache(appClients, clients.NewPageCache(cfg.ZebedeePageCacheMaxJobs, cfg.ZebedeePageCacheMaxEntries, cfg.ZebedeePageCacheTTL))
+	}
+
+	return appClients
 }
 
 // DoGetAuthorisationMiddleware creates authorisation middleware for the given
@@ -329,3 +370,42 @@ func (e *Init) DoGetAuthorisationMiddleware(ctx context.Context, authorisationCo
 func (e *ExternalServiceList) GetAuthorisationMiddleware(ctx context.Context, authorisationConfig *authorisation.Config) (authorisation.Middleware, error) {
 	return e.Init.DoGetAuthorisationMiddleware(ctx, authorisationConfig)
 }
+
+// DoGetPermissionsChecker returns a checker of the permissions held by
+// identities, such as whether users may approve jobs they submitted or the
+// identities Slack users approve jobs as. When authorisation is disabled
+// every permission is granted, as it is by the authorisation middleware,
+// except the four eyes override, so that no user may approve their own jobs.
+func (e *Init) DoGetPermissionsChecker(ctx context.Context, cfg *config.Config) (api.PermissionsChecker, error) {
+	authorisationConfig := cfg.AuthConfig
+	if !authorisationConfig.Enabled {
+		log.Warn(ctx, "authorisation is disabled so every permission is granted except the four eyes override", log.Data{
+			"four_eyes_override_permission": cfg.FourEyesOverridePermission,
+		})
+		return authDisabledPermissionsChecker{deniedPermission: cfg.FourEyesOverridePermission}, nil
+	}
+
+	return permissions.NewChecker(
+		ctx,
+		authorisationConfig.PermissionsAPIURL,
+		authorisationConfig.PermissionsCacheUpdateInterval,
+		authorisationConfig.PermissionsMaxCacheTime,
+	), nil
+}
+
+// GetPermissionsChecker gets the permissions checker for the service
+func (e *ExternalServiceList) GetPermissionsChecker(ctx context.Context, cfg *config.Config) (api.PermissionsChecker, error) {
+	return e.Init.DoGetPermissionsChecker(ctx, cfg)
+}
+
+// authDisabledPermissionsChecker is a permissions checker used when
+// authorisation is disabled, which grants every permission but one
+type authDisabledPermissionsChecker struct {
+	deniedPermission string
+}
+
+// HasPermission returns true for any entity and any permission other than
+// the denied permission
+func (c authDisabledPermissionsChecker) HasPermission(ctx context.Context, entityData permsdk.EntityData, permission string, attributes map[string]string) (bool, error) {
+	return permission != c.deniedPermission, nil
+}
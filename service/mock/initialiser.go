@@ -5,11 +5,13 @@ package mock
 
 import (
 	"context"
+	"github.com/ONSdigital/dis-migration-service/api"
 	"github.com/ONSdigital/dis-migration-service/application"
 	"github.com/ONSdigital/dis-migration-service/cache"
 	"github.com/ONSdigital/dis-migration-service/clients"
 	"github.com/ONSdigital/dis-migration-service/config"
 	"github.com/ONSdigital/dis-migration-service/migrator"
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	"github.com/ONSdigital/dis-migration-service/service"
 	"github.com/ONSdigital/dis-migration-service/slack"
 	"github.com/ONSdigital/dis-migration-service/store"
@@ -40,12 +42,15 @@ var _ service.Initialiser = &InitialiserMock{}
 //			DoGetHealthCheckFunc: func(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
 //				panic("mock out the DoGetHealthCheck method")
 //			},
-//			DoGetMigratorFunc: func(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, slackClient slack.Clienter, topicCache *cache.TopicCache) (migrator.Migrator, error) {
+//			DoGetMigratorFunc: func(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, jobNotifier notifier.Notifier, topicCache *cache.TopicCache) (migrator.Migrator, error) {
 //				panic("mock out the DoGetMigrator method")
 //			},
 //			DoGetMongoDBFunc: func(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error) {
 //				panic("mock out the DoGetMongoDB method")
 //			},
+//			DoGetPermissionsCheckerFunc: func(ctx context.Context, cfg *config.Config) (api.PermissionsChecker, error) {
+//				panic("mock out the DoGetPermissionsChecker method")
+//			},
 //			DoGetSlackClientFunc: func(ctx context.Context, cfg *config.Config) (slack.Clienter, error) {
 //				panic("mock out the DoGetSlackClient method")
 //			},
@@ -72,11 +77,14 @@ type InitialiserMock struct {
 	DoGetHealthCheckFunc func(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error)
 
 	// DoGetMigratorFunc mocks the DoGetMigrator method.
-	DoGetMigratorFunc func(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, slackClient slack.Clienter, topicCache *cache.TopicCache) (migrator.Migrator, error)
+	DoGetMigratorFunc func(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, jobNotifier notifier.Notifier, topicCache *cache.TopicCache) (migrator.Migrator, error)
 
 	// DoGetMongoDBFunc mocks the DoGetMongoDB method.
 	DoGetMongoDBFunc func(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error)
 
+	// DoGetPermissionsCheckerFunc mocks the DoGetPermissionsChecker method.
+	DoGetPermissionsCheckerFunc func(ctx context.Context, cfg *config.Config) (api.PermissionsChecker, error)
+
 	// DoGetSlackClientFunc mocks the DoGetSlackClient method.
 	DoGetSlackClientFunc func(ctx context.Context, cfg *config.Config) (slack.Clienter, error)
 
@@ -127,8 +135,8 @@ type InitialiserMock struct {
 			JobService application.JobService
 			// ClientList is the clientList argument value.
 			ClientList *clients.ClientList
-			// SlackClient is the slackClient argument value.
-			SlackClient slack.Clienter
+			// JobNotifier is the jobNotifier argument value.
+			JobNotifier notifier.Notifier
 			// TopicCache is the topicCache argument value.
 			TopicCache *cache.TopicCache
 		}
@@ -139,6 +147,13 @@ type InitialiserMock struct {
 			// Cfg is the cfg argument value.
 			Cfg config.MongoConfig
 		}
+		// DoGetPermissionsChecker holds details about calls to the DoGetPermissionsChecker method.
+		DoGetPermissionsChecker []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cfg is the cfg argument value.
+			Cfg *config.Config
+		}
 		// DoGetSlackClient holds details about calls to the DoGetSlackClient method.
 		DoGetSlackClient []struct {
 			// Ctx is the ctx argument value.
@@ -162,6 +177,7 @@ type InitialiserMock struct {
 	lockDoGetHealthCheck             sync.RWMutex
 	lockDoGetMigrator                sync.RWMutex
 	lockDoGetMongoDB                 sync.RWMutex
+	lockDoGetPermissionsChecker      sync.RWMutex
 	lockDoGetSlackClient             sync.RWMutex
 	lockDoGetTopicCache              sync.RWMutex
 }
@@ -319,7 +335,7 @@ func (mock *InitialiserMock) DoGetHealthCheckCalls() []struct {
 }
 
 // DoGetMigrator calls DoGetMigratorFunc.
-func (mock *InitialiserMock) DoGetMigrator(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, slackClient slack.Clienter, topicCache *cache.TopicCache) (migrator.Migrator, error) {
+func (mock *InitialiserMock) DoGetMigrator(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, jobNotifier notifier.Notifier, topicCache *cache.TopicCache) (migrator.Migrator, error) {
 	if mock.DoGetMigratorFunc == nil {
 		panic("InitialiserMock.DoGetMigratorFunc: method is nil but Initialiser.DoGetMigrator was just called")
 	}
@@ -328,20 +344,20 @@ func (mock *InitialiserMock) DoGetMigrator(ctx context.Context, cfg *config.Conf
 		Cfg         *config.Config
 		JobService  application.JobService
 		ClientList  *clients.ClientList
-		SlackClient slack.Clienter
+		JobNotifier notifier.Notifier
 		TopicCache  *cache.TopicCache
 	}{
 		Ctx:         ctx,
 		Cfg:         cfg,
 		JobService:  jobService,
 		ClientList:  clientList,
-		SlackClient: slackClient,
+		JobNotifier: jobNotifier,
 		TopicCache:  topicCache,
 	}
 	mock.lockDoGetMigrator.Lock()
 	mock.calls.DoGetMigrator = append(mock.calls.DoGetMigrator, callInfo)
 	mock.lockDoGetMigrator.Unlock()
-	return mock.DoGetMigratorFunc(ctx, cfg, jobService, clientList, slackClient, topicCache)
+	return mock.DoGetMigratorFunc(ctx, cfg, jobService, clientList, jobNotifier, topicCache)
 }
 
 // DoGetMigratorCalls gets all the calls that were made to DoGetMigrator.
@@ -353,7 +369,7 @@ func (mock *InitialiserMock) DoGetMigratorCalls() []struct {
 	Cfg         *config.Config
 	JobService  application.JobService
 	ClientList  *clients.ClientList
-	SlackClient slack.Clienter
+	JobNotifier notifier.Notifier
 	TopicCache  *cache.TopicCache
 } {
 	var calls []struct {
@@ -361,7 +377,7 @@ func (mock *InitialiserMock) DoGetMigratorCalls() []struct {
 		Cfg         *config.Config
 		JobService  application.JobService
 		ClientList  *clients.ClientList
-		SlackClient slack.Clienter
+		JobNotifier notifier.Notifier
 		TopicCache  *cache.TopicCache
 	}
 	mock.lockDoGetMigrator.RLock()
@@ -406,6 +422,42 @@ func (mock *InitialiserMock) DoGetMongoDBCalls() []struct {
 	return calls
 }
 
+// DoGetPermissionsChecker calls DoGetPermissionsCheckerFunc.
+func (mock *InitialiserMock) DoGetPermissionsChecker(ctx context.Context, cfg *config.Config) (api.PermissionsChecker, error) {
+	if mock.DoGetPermissionsCheckerFunc == nil {
+		panic("InitialiserMock.DoGetPermissionsCheckerFunc: method is nil but Initialiser.DoGetPermissionsChecker was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Cfg *config.Config
+	}{
+		Ctx: ctx,
+		Cfg: cfg,
+	}
+	mock.lockDoGetPermissionsChecker.Lock()
+	mock.calls.DoGetPermissionsChecker = append(mock.calls.DoGetPermissionsChecker, callInfo)
+	mock.lockDoGetPermissionsChecker.Unlock()
+	return mock.DoGetPermissionsCheckerFunc(ctx, cfg)
+}
+
+// DoGetPermissionsCheckerCalls gets all the calls that were made to DoGetPermissionsChecker.
+// Check the length with:
+//
+//	len(mockedInitialiser.DoGetPermissionsCheckerCalls())
+func (mock *InitialiserMock) DoGetPermissionsCheckerCalls() []struct {
+	Ctx context.Context
+	Cfg *config.Config
+} {
+	var calls []struct {
+		Ctx context.Context
+		Cfg *config.Config
+	}
+	mock.lockDoGetPermissionsChecker.RLock()
+	calls = mock.calls.DoGetPermissionsChecker
+	mock.lockDoGetPermissionsChecker.RUnlock()
+	return calls
+}
+
 // DoGetSlackClient calls DoGetSlackClientFunc.
 func (mock *InitialiserMock) DoGetSlackClient(ctx context.Context, cfg *config.Config) (slack.Clienter, error) {
 	if mock.DoGetSlackClientFunc == nil {
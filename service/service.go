@@ -9,9 +9,11 @@ import (
 	"github.com/ONSdigital/dis-migration-service/cache"
 	"github.com/ONSdigital/dis-migration-service/clients"
 	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/digest"
 	"github.com/ONSdigital/dis-migration-service/migrator"
-	"github.com/ONSdigital/dis-migration-service/slack"
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	"github.com/ONSdigital/dis-migration-service/store"
+	"github.com/ONSdigital/dis-migration-service/webhook"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 	"github.com/justinas/alice"
@@ -30,9 +32,11 @@ type Service struct {
 	ServiceList *ExternalServiceList
 	mongoDB     store.MongoDB
 	migrator    migrator.Migrator
-	slackClient slack.Clienter
+	notifier    notifier.Notifier
 	clients     *clients.ClientList
 	topicCache  *cache.TopicCache
+	dispatcher  *webhook.Dispatcher
+	digest      *digest.Scheduler
 }
 
 // MigrationServiceStore wraps the MongoDB client to implement
@@ -69,16 +73,24 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 	// Get app clients
 	svc.clients = svc.ServiceList.GetAppClients(ctx, svc.Config)
 
-	// Get job service
-	svc.JobService = application.Setup(&datastore, svc.clients, svc.Config)
-
 	// Initialize Slack client
-	svc.slackClient, err = svc.ServiceList.GetSlackClient(ctx, svc.Config)
+	slackClient, err := svc.ServiceList.GetSlackClient(ctx, svc.Config)
 	if err != nil {
 		log.Fatal(ctx, "failed to initialise slack client", err)
 		return err
 	}
 
+	// Initialize notifier, routing notifications to Slack and the other
+	// configured sinks
+	svc.notifier, err = notifier.New(svc.Config.NotifierConfig, svc.Config.SlackConfig, slackClient)
+	if err != nil {
+		log.Fatal(ctx, "failed to initialise notifier", err)
+		return err
+	}
+
+	// Get job service
+	svc.JobService = application.Setup(&datastore, svc.clients, svc.Config)
+
 	// Get topic cache (critical - service will fail to start if initial population fails)
 	var topicCacheErrChan chan error
 	svc.topicCache, topicCacheErrChan, err = svc.ServiceList.GetTopicCache(ctx, svc.Config, svc.clients)
@@ -87,21 +99,24 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 		return err
 	}
 
-	// Monitor topic cache update errors and send Slack alarms
+	// Monitor topic cache update errors and send alarms
 	// Only start monitoring if error channel is not nil (i.e., topic cache is enabled)
 	if topicCacheErrChan != nil {
 		go func() {
 			for err := range topicCacheErrChan {
 				if err != nil {
 					log.Error(ctx, "topic cache update error", err)
-					// Send Slack alarm for topic cache update failure
-					slackDetails := slack.SlackDetails{
-						"Error":  err.Error(),
-						"Source": "Topic Cache Update",
-					}
-					slackErr := svc.slackClient.SendAlarm(ctx, "TopicCacheUpdateFailed", nil, slackDetails)
-					if slackErr != nil {
-						log.Error(ctx, "failed to send slack alarm for topic cache update failure", slackErr)
+					// Send alarm for topic cache update failure
+					notifyErr := svc.notifier.Notify(ctx, &notifier.Notification{
+						Severity: notifier.SeverityAlarm,
+						Summary:  "TopicCacheUpdateFailed",
+						Details: notifier.Details{
+							"Error":  err.Error(),
+							"Source": "Topic Cache Update",
+						},
+					})
+					if notifyErr != nil {
+						log.Error(ctx, "failed to send alarm for topic cache update failure", notifyErr)
 					}
 				}
 			}
@@ -109,12 +124,25 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 	}
 
 	// Get Migrator
-	svc.migrator, err = svc.ServiceList.GetMigrator(ctx, svc.Config, svc.JobService, svc.clients, svc.slackClient, svc.topicCache)
+	svc.migrator, err = svc.ServiceList.GetMigrator(ctx, svc.Config, svc.JobService, svc.clients, svc.notifier, svc.topicCache)
 	if err != nil {
 		log.Fatal(ctx, "failed to initialise migrator", err)
 		return err
 	}
 
+	// Get webhook dispatcher
+	if svc.Config.EnableWebhooks {
+		svc.dispatcher = webhook.NewDispatcher(svc.Config, svc.JobService, webhook.NewHTTPClient(svc.Config.WebhookTimeout))
+	}
+
+	// Get digest scheduler, which also sends digests triggered through the
+	// API when the daily schedule is disabled
+	svc.digest, err = digest.NewScheduler(svc.Config, svc.JobService, slackClient)
+	if err != nil {
+		log.Fatal(ctx, "failed to initialise digest scheduler", err)
+		return err
+	}
+
 	// Setup healthcheck
 	svc.HealthCheck, err = svc.ServiceList.GetHealthCheck(svc.Config, buildTime, gitCommit, version)
 	if err != nil {
@@ -122,7 +150,7 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 		return err
 	}
 
-	if err := registerCheckers(ctx, svc.HealthCheck, svc.mongoDB); err != nil {
+	if err := registerCheckers(ctx, svc.HealthCheck, svc.mongoDB, svc.clients.PageCache); err != nil {
 		return errors.Wrap(err, "unable to register checkers")
 	}
 
@@ -147,12 +175,40 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 		return err
 	}
 
+	permissionsChecker, err := svc.ServiceList.GetPermissionsChecker(ctx, svc.Config)
+	if err != nil {
+		log.Fatal(ctx, "could not instantiate permissions checker", err)
+		return err
+	}
+
 	// Set up the API
 	svc.API = api.Setup(ctx, svc.Config, r, svc.JobService, authorisation)
+	svc.API.Digester = svc.digest
+	svc.API.Permissions = permissionsChecker
+
+	// Allow jobs to be approved and rejected from Slack
+	if svc.Config.SlackConfig.ApprovalsEnabled {
+		svc.API.SlackApprovals = &api.SlackApprovals{
+			SigningSecret:  svc.Config.SlackConfig.SigningSecret,
+			UserIdentities: svc.Config.SlackConfig.UserIdentities,
+			Permissions:    permissionsChecker,
+			Client:         slackClient,
+		}
+	}
 
 	// Start the migrator
 	svc.migrator.Start(ctx)
 
+	// Start the webhook dispatcher
+	if svc.dispatcher != nil {
+		svc.dispatcher.Start(ctx)
+	}
+
+	// Start the digest scheduler
+	if svc.Config.DigestEnabled {
+		svc.digest.Start(ctx)
+	}
+
 	// Run the http server in a new go-routine
 	go func() {
 		if err := svc.Server.ListenAndServe(); err != nil {
@@ -194,6 +250,22 @@ func (svc *Service) Close(ctx context.Context) error {
 			}
 		}
 
+		// Close webhook dispatcher
+		if svc.dispatcher != nil {
+			if err := svc.dispatcher.Shutdown(shutdownContext); err != nil {
+				log.Error(shutdownContext, "failed to close webhook dispatcher", err)
+				hasShutdownError = true
+			}
+		}
+
+		// Close digest scheduler
+		if svc.Config.DigestEnabled && svc.digest != nil {
+			if err := svc.digest.Shutdown(shutdownContext); err != nil {
+				log.Error(shutdownContext, "failed to close digest scheduler", err)
+				hasShutdownError = true
+			}
+		}
+
 		// Close MongoDB (if it exists)
 		if svc.ServiceList.MongoDB {
 			if err := svc.mongoDB.Close(shutdownContext); err != nil {
@@ -248,7 +320,7 @@ func healthcheckMiddleware(healthcheckHandler func(http.ResponseWriter, *http.Re
 	}
 }
 
-func registerCheckers(ctx context.Context, hc HealthChecker, mongoCli store.MongoDB) (err error) {
+func registerCheckers(ctx context.Context, hc HealthChecker, mongoCli store.MongoDB, pageCache *clients.PageCache) (err error) {
 	hasErrors := false
 
 	if err = hc.AddCheck("Mongo DB", mongoCli.Checker); err != nil {
@@ -256,6 +328,14 @@ func registerCheckers(ctx context.Context, hc HealthChecker, mongoCli store.Mong
 		log.Error(ctx, "error adding check for mongo db", err)
 	}
 
+	// The page cache reports its hits and misses through the healthcheck
+	if pageCache != nil {
+		if err = hc.AddCheck("Zebedee page cache", pageCache.Checker); err != nil {
+			hasErrors = true
+			log.Error(ctx, "error adding check for zebedee page cache", err)
+		}
+	}
+
 	if hasErrors {
 		return errors.New("Error(s) registering checkers for healthcheck")
 	}
@@ -8,12 +8,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ONSdigital/dis-migration-service/api"
 	"github.com/ONSdigital/dis-migration-service/application"
 	"github.com/ONSdigital/dis-migration-service/cache"
 	"github.com/ONSdigital/dis-migration-service/clients"
 	"github.com/ONSdigital/dis-migration-service/config"
 	"github.com/ONSdigital/dis-migration-service/migrator"
 	migratorMock "github.com/ONSdigital/dis-migration-service/migrator/mock"
+	"github.com/ONSdigital/dis-migration-service/notifier"
 	"github.com/ONSdigital/dis-migration-service/service"
 	"github.com/ONSdigital/dis-migration-service/service/mock"
 	"github.com/ONSdigital/dis-migration-service/slack"
@@ -23,6 +25,7 @@ import (
 	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
 	authorisationMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -116,7 +119,7 @@ func TestRun(t *testing.T) {
 			return createMockSlackClient(), nil
 		}
 
-		funcDoGetMigrator := func(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, slackClient slack.Clienter, topicCache *cache.TopicCache) (migrator.Migrator, error) {
+		funcDoGetMigrator := func(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, jobNotifier notifier.Notifier, topicCache *cache.TopicCache) (migrator.Migrator, error) {
 			return &migratorMock.MigratorMock{
 				StartFunc: func(ctx context.Context) {},
 			}, nil
@@ -134,6 +137,10 @@ func TestRun(t *testing.T) {
 			return authorisationMiddleware, nil
 		}
 
+		funcDoGetPermissionsCheckerOk := func(ctx context.Context, cfg *config.Config) (api.PermissionsChecker, error) {
+			return nil, nil
+		}
+
 		Convey("Given that initialising healthcheck returns an error", func() {
 			// setup (run before each `Convey` at this scope / indentation):
 			initMock := &mock.InitialiserMock{
@@ -172,6 +179,7 @@ func TestRun(t *testing.T) {
 				DoGetAppClientsFunc:              funcDoGetAppClientsOk,
 				DoGetTopicCacheFunc:              funcDoGetTopicCacheOk,
 				DoGetAuthorisationMiddlewareFunc: funcDoGetAuthOk,
+				DoGetPermissionsCheckerFunc:      funcDoGetPermissionsCheckerOk,
 			}
 			svcErrors := make(chan error, 1)
 			svcList := service.NewServiceList(initMock)
@@ -184,6 +192,7 @@ func TestRun(t *testing.T) {
 				So(err, ShouldBeNil)
 				So(svcList.HealthCheck, ShouldBeTrue)
 				So(svcList.SlackClient, ShouldBeTrue)
+				So(initMock.DoGetPermissionsCheckerCalls(), ShouldHaveLength, 1)
 			})
 
 			Convey("The checkers are registered and the healthcheck and http server started", func() {
@@ -201,6 +210,35 @@ func TestRun(t *testing.T) {
 			})
 		})
 
+		Convey("Given that all dependencies are successfully initialised with a Zebedee page cache", func() {
+			initMock := &mock.InitialiserMock{
+				DoGetHTTPServerFunc:  funcDoGetHTTPServer,
+				DoGetHealthCheckFunc: funcDoGetHealthcheckOk,
+				DoGetSlackClientFunc: funcDoGetSlackClient,
+				DoGetMigratorFunc:    funcDoGetMigrator,
+				DoGetMongoDBFunc:     funcDoGetMongoDBOk,
+				DoGetAppClientsFunc: func(context.Context, *config.Config) *clients.ClientList {
+					return &clients.ClientList{PageCache: clients.NewPageCache(1, 1, time.Minute)}
+				},
+				DoGetTopicCacheFunc:              funcDoGetTopicCacheOk,
+				DoGetAuthorisationMiddlewareFunc: funcDoGetAuthOk,
+				DoGetPermissionsCheckerFunc:      funcDoGetPermissionsCheckerOk,
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			serverWg.Add(1)
+
+			svc := service.New(cfg, svcList)
+			err := svc.Run(ctx, testBuildTime, testGitCommit, testVersion, svcErrors)
+			serverWg.Wait()
+
+			Convey("Then a check reporting the page cache's hits and misses is registered", func() {
+				So(err, ShouldBeNil)
+				So(hcMock.AddCheckCalls(), ShouldHaveLength, 2)
+				So(hcMock.AddCheckCalls()[1].Name, ShouldEqual, "Zebedee page cache")
+			})
+		})
+
 		Convey("Given that Checkers cannot be registered", func() {
 			// setup (run before each `Convey` at this scope / indentation):
 			errAddCheckFail := errors.New("Error(s) registering checkers for healthcheck")
@@ -220,6 +258,7 @@ func TestRun(t *testing.T) {
 				DoGetAppClientsFunc:              funcDoGetAppClientsOk,
 				DoGetTopicCacheFunc:              funcDoGetTopicCacheOk,
 				DoGetAuthorisationMiddlewareFunc: funcDoGetAuthOk,
+				DoGetPermissionsCheckerFunc:      funcDoGetPermissionsCheckerOk,
 			}
 			svcErrors := make(chan error, 1)
 			svcList := service.NewServiceList(initMock)
@@ -250,6 +289,7 @@ func TestRun(t *testing.T) {
 				DoGetAppClientsFunc:              funcDoGetAppClientsOk,
 				DoGetTopicCacheFunc:              funcDoGetTopicCacheOk,
 				DoGetAuthorisationMiddlewareFunc: funcDoGetAuthOk,
+				DoGetPermissionsCheckerFunc:      funcDoGetPermissionsCheckerOk,
 			}
 			svcErrors := make(chan error, 1)
 			svcList := service.NewServiceList(initMock)
@@ -323,7 +363,7 @@ func TestClose(t *testing.T) {
 			return createMockSlackClient(), nil
 		}
 
-		funcDoGetMigrator := func(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, slackClient slack.Clienter, topicCache *cache.TopicCache) (migrator.Migrator, error) {
+		funcDoGetMigrator := func(ctx context.Context, cfg *config.Config, jobService application.JobService, clientList *clients.ClientList, jobNotifier notifier.Notifier, topicCache *cache.TopicCache) (migrator.Migrator, error) {
 			return &migratorMock.MigratorMock{
 				StartFunc:    func(ctx context.Context) {},
 				ShutdownFunc: funcClose,
@@ -348,6 +388,10 @@ func TestClose(t *testing.T) {
 			return authorisationMiddleware, nil
 		}
 
+		funcDoGetPermissionsCheckerOk := func(ctx context.Context, cfg *config.Config) (api.PermissionsChecker, error) {
+			return nil, nil
+		}
+
 		Convey("Closing the service results in all the dependencies being closed in the expected order", func() {
 			initMock := &mock.InitialiserMock{
 				DoGetHTTPServerFunc: func(bindAddr string, router http.Handler) service.HTTPServer { return serverMock },
@@ -360,6 +404,7 @@ func TestClose(t *testing.T) {
 				DoGetAppClientsFunc:              funcDoGetAppClientsOk,
 				DoGetTopicCacheFunc:              funcDoGetTopicCacheOk,
 				DoGetAuthorisationMiddlewareFunc: funcDoGetAuthOk,
+				DoGetPermissionsCheckerFunc:      funcDoGetPermissionsCheckerOk,
 			}
 
 			svcErrors := make(chan error, 1)
@@ -395,6 +440,7 @@ func TestClose(t *testing.T) {
 				DoGetAppClientsFunc:              funcDoGetAppClientsOk,
 				DoGetTopicCacheFunc:              funcDoGetTopicCacheOk,
 				DoGetAuthorisationMiddlewareFunc: funcDoGetAuthOk,
+				DoGetPermissionsCheckerFunc:      funcDoGetPermissionsCheckerOk,
 			}
 
 			svcErrors := make(chan error, 1)
@@ -439,3 +485,29 @@ func TestClose(t *testing.T) {
 		})
 	})
 }
+
+func TestDoGetPermissionsChecker(t *testing.T) {
+	Convey("Given a config with authorisation disabled", t, func() {
+		cfg := &config.Config{
+			AuthConfig:                 &authorisation.Config{Enabled: false},
+			FourEyesOverridePermission: "migrations:admin",
+		}
+
+		Convey("When the permissions checker is created", func() {
+			checker, err := (&service.Init{}).DoGetPermissionsChecker(ctx, cfg)
+			So(err, ShouldBeNil)
+
+			Convey("Then the four eyes override permission is denied", func() {
+				permitted, err := checker.HasPermission(ctx, permsdk.EntityData{UserID: "user"}, "migrations:admin", nil)
+				So(err, ShouldBeNil)
+				So(permitted, ShouldBeFalse)
+			})
+
+			Convey("And every other permission is granted", func() {
+				permitted, err := checker.HasPermission(ctx, permsdk.EntityData{UserID: "user"}, "migrations:edit", nil)
+				So(err, ShouldBeNil)
+				So(permitted, ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"strconv"
 
 	"github.com/slack-go/slack"
 )
@@ -38,8 +39,13 @@ func New(cfg *Config) (Clienter, error) {
 		return &NoopClient{}, nil
 	}
 
+	var options []slack.Option
+	if cfg.APIURL != "" {
+		options = append(options, slack.OptionAPIURL(cfg.APIURL))
+	}
+
 	return &Client{
-		client:   slack.New(cfg.APIToken),
+		client:   slack.New(cfg.APIToken, options...),
 		channels: cfg.Channels,
 	}, nil
 }
@@ -111,6 +117,124 @@ func (c *Client) validateInput(ctx context.Context, summary string) error {
 	return nil
 }
 
+// StartThread sends a parent message to the configured Slack info channel
+// and returns the thread that replies to it can be sent in.
+func (c *Client) StartThread(ctx context.Context, summary string, colour Colour, details SlackDetails) (*Thread, error) {
+	if err := c.validateInput(ctx, summary); err != nil {
+		return nil, err
+	}
+
+	channel, timestamp, err := c.client.PostMessageContext(
+		ctx,
+		c.channels.PublishChannel,
+		messageOptions(colour, InfoEmoji, summary, buildAttachmentFields(nil, details))...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send slack message: %w", err)
+	}
+
+	return &Thread{
+		Channel:   channel,
+		Timestamp: timestamp,
+	}, nil
+}
+
+// UpdateThread replaces the summary, colour and details of a thread's
+// parent message.
+func (c *Client) UpdateThread(ctx context.Context, thread *Thread, summary string, colour Colour, details SlackDetails) error {
+	if err := c.validateInput(ctx, summary); err != nil {
+		return err
+	}
+	if thread == nil {
+		return errNilThread
+	}
+
+	_, _, _, err := c.client.UpdateMessageContext(
+		ctx,
+		thread.Channel,
+		thread.Timestamp,
+		messageOptions(colour, InfoEmoji, summary, buildAttachmentFields(nil, details))...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update slack message: %w", err)
+	}
+
+	return nil
+}
+
+// ReplyToThread sends a notification as a reply in a thread. The error
+// will be included in the message fields if provided.
+func (c *Client) ReplyToThread(ctx context.Context, thread *Thread, colour Colour, emoji Emoji, summary string, err error, details SlackDetails) error {
+	if err := c.validateInput(ctx, summary); err != nil {
+		return err
+	}
+	if thread == nil {
+		return errNilThread
+	}
+
+	options := append(
+		messageOptions(colour, emoji, summary, buildAttachmentFields(err, details)),
+		slack.MsgOptionTS(thread.Timestamp),
+	)
+
+	if _, _, err := c.client.PostMessageContext(ctx, thread.Channel, options...); err != nil {
+		return fmt.Errorf("failed to send slack message: %w", err)
+	}
+
+	return nil
+}
+
+// SendApprovalRequest sends a message with buttons to approve or reject a
+// job to the configured Slack info channel, or as a reply in the thread if
+// one is provided. Each button's value is the job number.
+func (c *Client) SendApprovalRequest(ctx context.Context, thread *Thread, summary string, details SlackDetails, jobNumber int) error {
+	if err := c.validateInput(ctx, summary); err != nil {
+		return err
+	}
+
+	channel := c.channels.PublishChannel
+	options := approvalRequestOptions(summary, details, jobNumber)
+
+	if thread != nil {
+		channel = thread.Channel
+		options = append(options, slack.MsgOptionTS(thread.Timestamp))
+	}
+
+	if _, _, err := c.client.PostMessageContext(ctx, channel, options...); err != nil {
+		return fmt.Errorf("failed to send slack message: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveApprovalRequest replaces an approval request message, removing
+// its buttons so that it cannot be acted on again, with its outcome.
+func (c *Client) ResolveApprovalRequest(ctx context.Context, message *Thread, outcome string) error {
+	if err := c.validateInput(ctx, outcome); err != nil {
+		return err
+	}
+	if message == nil {
+		return errNilMessage
+	}
+
+	text := fmt.Sprintf("%s %s", ReviewEmoji.String(), outcome)
+
+	_, _, _, err := c.client.UpdateMessageContext(
+		ctx,
+		message.Channel,
+		message.Timestamp,
+		slack.MsgOptionText(text, false),
+		slack.MsgOptionBlocks(
+			slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil),
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update slack message: %w", err)
+	}
+
+	return nil
+}
+
 // doSendMessage is a helper function to send a message to a specified
 // Slack channel with given parameters
 func (c *Client) doSendMessage(
@@ -121,26 +245,62 @@ func (c *Client) doSendMessage(
 	summary string,
 	fields []slack.AttachmentField,
 ) error {
+	_, _, err := c.client.PostMessageContext(
+		ctx,
+		channel,
+		messageOptions(color, emoji, summary, fields)...,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to send slack message: %w", err)
+	}
+
+	return nil
+}
+
+// messageOptions builds the text and attachment options of a message
+func messageOptions(color Colour, emoji Emoji, summary string, fields []slack.AttachmentField) []slack.MsgOption {
 	attachment := slack.Attachment{
 		Color:  color.String(),
 		Fields: fields,
 	}
 
-	_, _, err := c.client.PostMessageContext(
-		ctx,
-		channel,
+	return []slack.MsgOption{
 		slack.MsgOptionText(
 			fmt.Sprintf("%s %s", emoji.String(), summary),
 			false,
 		),
 		slack.MsgOptionAttachments(attachment),
-	)
+	}
+}
 
-	if err != nil {
-		return fmt.Errorf("failed to send slack message: %w", err)
+// approvalRequestOptions builds the text and block options of an approval
+// request, with the details as fields and buttons to approve or reject the
+// job
+func approvalRequestOptions(summary string, details SlackDetails, jobNumber int) []slack.MsgOption {
+	text := fmt.Sprintf("%s %s", ReviewEmoji.String(), summary)
+	value := strconv.Itoa(jobNumber)
+
+	var fields []*slack.TextBlockObject
+	for _, key := range slices.Sorted(maps.Keys(details)) {
+		fields = append(fields, slack.NewTextBlockObject(
+			slack.MarkdownType,
+			fmt.Sprintf("*%s*\n%v", key, details[key]),
+			false,
+			false,
+		))
 	}
 
-	return nil
+	approve := slack.NewButtonBlockElement(ActionApproveJob, value, slack.NewTextBlockObject(slack.PlainTextType, "Approve", false, false))
+	reject := slack.NewButtonBlockElement(ActionRejectJob, value, slack.NewTextBlockObject(slack.PlainTextType, "Reject", false, false))
+
+	return []slack.MsgOption{
+		slack.MsgOptionText(text, false),
+		slack.MsgOptionBlocks(
+			slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), fields, nil),
+			slack.NewActionBlock(approvalBlockID, approve.WithStyle(slack.StylePrimary), reject.WithStyle(slack.StyleDanger)),
+		),
+	}
 }
 
 // buildAttachmentFields constructs Slack attachment fields from the
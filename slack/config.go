@@ -10,6 +10,22 @@ type Config struct {
 
 	// Channels holds the Slack channel names for different notification levels
 	Channels Channels
+
+	// APIURL overrides the URL of the Slack API, such as to use a local
+	// stand-in for Slack. Slack's own API is used if it is not set.
+	APIURL string `envconfig:"SLACK_API_URL"`
+
+	// ApprovalsEnabled determines whether jobs awaiting review are sent to
+	// Slack with buttons to approve or reject them
+	ApprovalsEnabled bool `envconfig:"SLACK_APPROVALS_ENABLED" default:"false"`
+
+	// SigningSecret is the secret Slack signs interaction requests with,
+	// used to verify that approvals and rejections came from Slack
+	SigningSecret string `envconfig:"SLACK_SIGNING_SECRET" json:"-"`
+
+	// UserIdentities maps the IDs of Slack users to the identities they
+	// approve and reject jobs as
+	UserIdentities map[string]string `envconfig:"SLACK_USER_IDENTITIES"`
 }
 
 // Channels holds the Slack channel names for different notification levels
@@ -44,6 +60,9 @@ func (c *Config) Validate() error {
 	if c.Channels.AlarmChannel == "" {
 		return errMissingAlarmChannel
 	}
+	if c.ApprovalsEnabled && c.SigningSecret == "" {
+		return errMissingSigningSecret
+	}
 
 	return nil
 }
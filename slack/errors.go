@@ -9,10 +9,20 @@ var (
 	errMissingPublishChannel = errors.New("slack publish channel is missing")
 	errMissingWarningChannel = errors.New("slack warning channel is missing")
 	errMissingAlarmChannel   = errors.New("slack alarm channel is missing")
+	errMissingSigningSecret  = errors.New("slack signing secret is missing")
 )
 
 // Runtime errors
 var (
 	errEmptySummary = errors.New("summary cannot be empty")
 	errNilContext   = errors.New("context cannot be nil")
+	errNilThread    = errors.New("thread cannot be nil")
+	errNilMessage   = errors.New("message cannot be nil")
+)
+
+// Interaction errors
+var (
+	errInvalidSignature       = errors.New("slack request signature is invalid")
+	errMissingPayload         = errors.New("slack interaction payload is missing")
+	errUnsupportedInteraction = errors.New("slack interaction is not a button action")
 )
@@ -0,0 +1,95 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/slack-go/slack"
+)
+
+// Action IDs of the buttons on approval requests
+const (
+	ActionApproveJob = "approve_job"
+	ActionRejectJob  = "reject_job"
+)
+
+// approvalBlockID is the ID of the block holding an approval request's
+// buttons
+const approvalBlockID = "job_approval"
+
+// payloadField is the form field Slack sends interaction payloads in
+const payloadField = "payload"
+
+// Interaction is a button being clicked on a Slack message
+type Interaction struct {
+	// UserID is the ID of the Slack user that clicked the button
+	UserID string
+	// ActionID identifies the button that was clicked
+	ActionID string
+	// Value is the value of the button that was clicked
+	Value string
+	// Message is the message the button is on
+	Message *Thread
+}
+
+// VerifyRequest checks that a request was sent by Slack in the last five
+// minutes, by comparing its signature with one computed from its body and
+// timestamp using the signing secret.
+func VerifyRequest(header http.Header, body []byte, signingSecret string) error {
+	if signingSecret == "" {
+		return errMissingSigningSecret
+	}
+
+	verifier, err := slack.NewSecretsVerifier(header, signingSecret)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidSignature, err)
+	}
+
+	if _, err := verifier.Write(body); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidSignature, err)
+	}
+
+	if err := verifier.Ensure(); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidSignature, err)
+	}
+
+	return nil
+}
+
+// ParseInteraction parses the form encoded body of a Slack interaction
+// request, returning the button that was clicked. Interactions other than
+// clicking a button are not supported.
+func ParseInteraction(body []byte) (*Interaction, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse slack interaction: %w", err)
+	}
+
+	payload := form.Get(payloadField)
+	if payload == "" {
+		return nil, errMissingPayload
+	}
+
+	var callback slack.InteractionCallback
+	if err := json.Unmarshal([]byte(payload), &callback); err != nil {
+		return nil, fmt.Errorf("failed to parse slack interaction: %w", err)
+	}
+
+	if callback.Type != slack.InteractionTypeBlockActions || len(callback.ActionCallback.BlockActions) == 0 {
+		return nil, errUnsupportedInteraction
+	}
+
+	action := callback.ActionCallback.BlockActions[0]
+
+	return &Interaction{
+		UserID:   callback.User.ID,
+		ActionID: action.ActionID,
+		Value:    action.Value,
+		Message: &Thread{
+			Channel:   callback.Container.ChannelID,
+			Timestamp: callback.Container.MessageTs,
+		},
+	}, nil
+}
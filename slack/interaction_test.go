@@ -0,0 +1,132 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testSigningSecret = "test-signing-secret"
+
+// signedHeader returns the headers Slack would send with a request with
+// the body, signed with the secret at the given time
+func signedHeader(secret string, body []byte, at time.Time) http.Header {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("v0:%s:%s", timestamp, body)))
+
+	header := http.Header{}
+	header.Set("X-Slack-Request-Timestamp", timestamp)
+	header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return header
+}
+
+func TestVerifyRequest(t *testing.T) {
+	body := []byte("payload=%7B%7D")
+
+	tests := []struct {
+		name          string
+		header        http.Header
+		signingSecret string
+		wantErr       error
+	}{
+		{
+			name:          "valid signature",
+			header:        signedHeader(testSigningSecret, body, time.Now()),
+			signingSecret: testSigningSecret,
+		},
+		{
+			name:          "signed with another secret",
+			header:        signedHeader("another-secret", body, time.Now()),
+			signingSecret: testSigningSecret,
+			wantErr:       errInvalidSignature,
+		},
+		{
+			name:          "signed too long ago",
+			header:        signedHeader(testSigningSecret, body, time.Now().Add(-10*time.Minute)),
+			signingSecret: testSigningSecret,
+			wantErr:       errInvalidSignature,
+		},
+		{
+			name:          "missing signature headers",
+			header:        http.Header{},
+			signingSecret: testSigningSecret,
+			wantErr:       errInvalidSignature,
+		},
+		{
+			name:          "no signing secret configured",
+			header:        signedHeader(testSigningSecret, body, time.Now()),
+			signingSecret: "",
+			wantErr:       errMissingSigningSecret,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyRequest(tt.header, body, tt.signingSecret)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("VerifyRequest() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyRequest() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseInteraction(t *testing.T) {
+	formBody := func(payload string) []byte {
+		return []byte(url.Values{payloadField: {payload}}.Encode())
+	}
+
+	t.Run("button action", func(t *testing.T) {
+		payload := `{
+			"type": "block_actions",
+			"user": {"id": "U123"},
+			"container": {"type": "message", "channel_id": "C123", "message_ts": "1700000000.000200"},
+			"actions": [{"type": "button", "action_id": "approve_job", "block_id": "job_approval", "value": "7"}]
+		}`
+
+		interaction, err := ParseInteraction(formBody(payload))
+		if err != nil {
+			t.Fatalf("ParseInteraction() error = %v, want nil", err)
+		}
+
+		want := Interaction{
+			UserID:   "U123",
+			ActionID: ActionApproveJob,
+			Value:    "7",
+			Message:  &Thread{Channel: "C123", Timestamp: "1700000000.000200"},
+		}
+		if interaction.UserID != want.UserID || interaction.ActionID != want.ActionID || interaction.Value != want.Value || *interaction.Message != *want.Message {
+			t.Errorf("ParseInteraction() = %+v, want %+v", interaction, want)
+		}
+	})
+
+	t.Run("missing payload", func(t *testing.T) {
+		if _, err := ParseInteraction([]byte("other=value")); !errors.Is(err, errMissingPayload) {
+			t.Errorf("ParseInteraction() error = %v, want %v", err, errMissingPayload)
+		}
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		if _, err := ParseInteraction(formBody("not json")); err == nil {
+			t.Error("ParseInteraction() error = nil, want an error")
+		}
+	})
+
+	t.Run("interaction that is not a button action", func(t *testing.T) {
+		payload := `{"type": "view_submission", "user": {"id": "U123"}}`
+		if _, err := ParseInteraction(formBody(payload)); !errors.Is(err, errUnsupportedInteraction) {
+			t.Errorf("ParseInteraction() error = %v, want %v", err, errUnsupportedInteraction)
+		}
+	})
+}
@@ -17,4 +17,23 @@ type Clienter interface {
 
 	// SendInfo sends an informational notification to the info channel
 	SendInfo(ctx context.Context, summary string, details SlackDetails, success bool) error
+
+	// StartThread sends a parent message to the info channel and returns
+	// the thread that replies to it can be sent in
+	StartThread(ctx context.Context, summary string, colour Colour, details SlackDetails) (*Thread, error)
+
+	// UpdateThread replaces the summary, colour and details of a thread's
+	// parent message
+	UpdateThread(ctx context.Context, thread *Thread, summary string, colour Colour, details SlackDetails) error
+
+	// ReplyToThread sends a notification as a reply in a thread
+	ReplyToThread(ctx context.Context, thread *Thread, colour Colour, emoji Emoji, summary string, err error, details SlackDetails) error
+
+	// SendApprovalRequest sends a message with buttons to approve or
+	// reject a job, to the info channel or as a reply in a thread
+	SendApprovalRequest(ctx context.Context, thread *Thread, summary string, details SlackDetails, jobNumber int) error
+
+	// ResolveApprovalRequest replaces the buttons of an approval request
+	// with its outcome
+	ResolveApprovalRequest(ctx context.Context, message *Thread, outcome string) error
 }
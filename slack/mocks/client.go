@@ -19,15 +19,30 @@ var _ slack.Clienter = &ClienterMock{}
 //
 //		// make and configure a mocked slack.Clienter
 //		mockedClienter := &ClienterMock{
+//			ReplyToThreadFunc: func(ctx context.Context, thread *slack.Thread, colour slack.Colour, emoji slack.Emoji, summary string, err error, details slack.SlackDetails) error {
+//				panic("mock out the ReplyToThread method")
+//			},
+//			ResolveApprovalRequestFunc: func(ctx context.Context, message *slack.Thread, outcome string) error {
+//				panic("mock out the ResolveApprovalRequest method")
+//			},
 //			SendAlarmFunc: func(ctx context.Context, summary string, err error, details slack.SlackDetails) error {
 //				panic("mock out the SendAlarm method")
 //			},
+//			SendApprovalRequestFunc: func(ctx context.Context, thread *slack.Thread, summary string, details slack.SlackDetails, jobNumber int) error {
+//				panic("mock out the SendApprovalRequest method")
+//			},
 //			SendInfoFunc: func(ctx context.Context, summary string, details slack.SlackDetails, success bool) error {
 //				panic("mock out the SendInfo method")
 //			},
 //			SendWarningFunc: func(ctx context.Context, summary string, details slack.SlackDetails) error {
 //				panic("mock out the SendWarning method")
 //			},
+//			StartThreadFunc: func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+//				panic("mock out the StartThread method")
+//			},
+//			UpdateThreadFunc: func(ctx context.Context, thread *slack.Thread, summary string, colour slack.Colour, details slack.SlackDetails) error {
+//				panic("mock out the UpdateThread method")
+//			},
 //		}
 //
 //		// use mockedClienter in code that requires slack.Clienter
@@ -35,17 +50,58 @@ var _ slack.Clienter = &ClienterMock{}
 //
 //	}
 type ClienterMock struct {
+	// ReplyToThreadFunc mocks the ReplyToThread method.
+	ReplyToThreadFunc func(ctx context.Context, thread *slack.Thread, colour slack.Colour, emoji slack.Emoji, summary string, err error, details slack.SlackDetails) error
+
+	// ResolveApprovalRequestFunc mocks the ResolveApprovalRequest method.
+	ResolveApprovalRequestFunc func(ctx context.Context, message *slack.Thread, outcome string) error
+
 	// SendAlarmFunc mocks the SendAlarm method.
 	SendAlarmFunc func(ctx context.Context, summary string, err error, details slack.SlackDetails) error
 
+	// SendApprovalRequestFunc mocks the SendApprovalRequest method.
+	SendApprovalRequestFunc func(ctx context.Context, thread *slack.Thread, summary string, details slack.SlackDetails, jobNumber int) error
+
 	// SendInfoFunc mocks the SendInfo method.
 	SendInfoFunc func(ctx context.Context, summary string, details slack.SlackDetails, success bool) error
 
 	// SendWarningFunc mocks the SendWarning method.
 	SendWarningFunc func(ctx context.Context, summary string, details slack.SlackDetails) error
 
+	// StartThreadFunc mocks the StartThread method.
+	StartThreadFunc func(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error)
+
+	// UpdateThreadFunc mocks the UpdateThread method.
+	UpdateThreadFunc func(ctx context.Context, thread *slack.Thread, summary string, colour slack.Colour, details slack.SlackDetails) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// ReplyToThread holds details about calls to the ReplyToThread method.
+		ReplyToThread []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Thread is the thread argument value.
+			Thread *slack.Thread
+			// Colour is the colour argument value.
+			Colour slack.Colour
+			// Emoji is the emoji argument value.
+			Emoji slack.Emoji
+			// Summary is the summary argument value.
+			Summary string
+			// Err is the err argument value.
+			Err error
+			// Details is the details argument value.
+			Details slack.SlackDetails
+		}
+		// ResolveApprovalRequest holds details about calls to the ResolveApprovalRequest method.
+		ResolveApprovalRequest []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Message is the message argument value.
+			Message *slack.Thread
+			// Outcome is the outcome argument value.
+			Outcome string
+		}
 		// SendAlarm holds details about calls to the SendAlarm method.
 		SendAlarm []struct {
 			// Ctx is the ctx argument value.
@@ -57,6 +113,19 @@ type ClienterMock struct {
 			// Details is the details argument value.
 			Details slack.SlackDetails
 		}
+		// SendApprovalRequest holds details about calls to the SendApprovalRequest method.
+		SendApprovalRequest []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Thread is the thread argument value.
+			Thread *slack.Thread
+			// Summary is the summary argument value.
+			Summary string
+			// Details is the details argument value.
+			Details slack.SlackDetails
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+		}
 		// SendInfo holds details about calls to the SendInfo method.
 		SendInfo []struct {
 			// Ctx is the ctx argument value.
@@ -77,10 +146,135 @@ type ClienterMock struct {
 			// Details is the details argument value.
 			Details slack.SlackDetails
 		}
+		// StartThread holds details about calls to the StartThread method.
+		StartThread []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Summary is the summary argument value.
+			Summary string
+			// Colour is the colour argument value.
+			Colour slack.Colour
+			// Details is the details argument value.
+			Details slack.SlackDetails
+		}
+		// UpdateThread holds details about calls to the UpdateThread method.
+		UpdateThread []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Thread is the thread argument value.
+			Thread *slack.Thread
+			// Summary is the summary argument value.
+			Summary string
+			// Colour is the colour argument value.
+			Colour slack.Colour
+			// Details is the details argument value.
+			Details slack.SlackDetails
+		}
+	}
+	lockReplyToThread          sync.RWMutex
+	lockResolveApprovalRequest sync.RWMutex
+	lockSendAlarm              sync.RWMutex
+	lockSendApprovalRequest    sync.RWMutex
+	lockSendInfo               sync.RWMutex
+	lockSendWarning            sync.RWMutex
+	lockStartThread            sync.RWMutex
+	lockUpdateThread           sync.RWMutex
+}
+
+// ReplyToThread calls ReplyToThreadFunc.
+func (mock *ClienterMock) ReplyToThread(ctx context.Context, thread *slack.Thread, colour slack.Colour, emoji slack.Emoji, summary string, err error, details slack.SlackDetails) error {
+	if mock.ReplyToThreadFunc == nil {
+		panic("ClienterMock.ReplyToThreadFunc: method is nil but Clienter.ReplyToThread was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Thread  *slack.Thread
+		Colour  slack.Colour
+		Emoji   slack.Emoji
+		Summary string
+		Err     error
+		Details slack.SlackDetails
+	}{
+		Ctx:     ctx,
+		Thread:  thread,
+		Colour:  colour,
+		Emoji:   emoji,
+		Summary: summary,
+		Err:     err,
+		Details: details,
 	}
-	lockSendAlarm   sync.RWMutex
-	lockSendInfo    sync.RWMutex
-	lockSendWarning sync.RWMutex
+	mock.lockReplyToThread.Lock()
+	mock.calls.ReplyToThread = append(mock.calls.ReplyToThread, callInfo)
+	mock.lockReplyToThread.Unlock()
+	return mock.ReplyToThreadFunc(ctx, thread, colour, emoji, summary, err, details)
+}
+
+// ReplyToThreadCalls gets all the calls that were made to ReplyToThread.
+// Check the length with:
+//
+//	len(mockedClienter.ReplyToThreadCalls())
+func (mock *ClienterMock) ReplyToThreadCalls() []struct {
+	Ctx     context.Context
+	Thread  *slack.Thread
+	Colour  slack.Colour
+	Emoji   slack.Emoji
+	Summary string
+	Err     error
+	Details slack.SlackDetails
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Thread  *slack.Thread
+		Colour  slack.Colour
+		Emoji   slack.Emoji
+		Summary string
+		Err     error
+		Details slack.SlackDetails
+	}
+	mock.lockReplyToThread.RLock()
+	calls = mock.calls.ReplyToThread
+	mock.lockReplyToThread.RUnlock()
+	return calls
+}
+
+// ResolveApprovalRequest calls ResolveApprovalRequestFunc.
+func (mock *ClienterMock) ResolveApprovalRequest(ctx context.Context, message *slack.Thread, outcome string) error {
+	if mock.ResolveApprovalRequestFunc == nil {
+		panic("ClienterMock.ResolveApprovalRequestFunc: method is nil but Clienter.ResolveApprovalRequest was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Message *slack.Thread
+		Outcome string
+	}{
+		Ctx:     ctx,
+		Message: message,
+		Outcome: outcome,
+	}
+	mock.lockResolveApprovalRequest.Lock()
+	mock.calls.ResolveApprovalRequest = append(mock.calls.ResolveApprovalRequest, callInfo)
+	mock.lockResolveApprovalRequest.Unlock()
+	return mock.ResolveApprovalRequestFunc(ctx, message, outcome)
+}
+
+// ResolveApprovalRequestCalls gets all the calls that were made to ResolveApprovalRequest.
+// Check the length with:
+//
+//	len(mockedClienter.ResolveApprovalRequestCalls())
+func (mock *ClienterMock) ResolveApprovalRequestCalls() []struct {
+	Ctx     context.Context
+	Message *slack.Thread
+	Outcome string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Message *slack.Thread
+		Outcome string
+	}
+	mock.lockResolveApprovalRequest.RLock()
+	calls = mock.calls.ResolveApprovalRequest
+	mock.lockResolveApprovalRequest.RUnlock()
+	return calls
 }
 
 // SendAlarm calls SendAlarmFunc.
@@ -127,6 +321,54 @@ func (mock *ClienterMock) SendAlarmCalls() []struct {
 	return calls
 }
 
+// SendApprovalRequest calls SendApprovalRequestFunc.
+func (mock *ClienterMock) SendApprovalRequest(ctx context.Context, thread *slack.Thread, summary string, details slack.SlackDetails, jobNumber int) error {
+	if mock.SendApprovalRequestFunc == nil {
+		panic("ClienterMock.SendApprovalRequestFunc: method is nil but Clienter.SendApprovalRequest was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Thread    *slack.Thread
+		Summary   string
+		Details   slack.SlackDetails
+		JobNumber int
+	}{
+		Ctx:       ctx,
+		Thread:    thread,
+		Summary:   summary,
+		Details:   details,
+		JobNumber: jobNumber,
+	}
+	mock.lockSendApprovalRequest.Lock()
+	mock.calls.SendApprovalRequest = append(mock.calls.SendApprovalRequest, callInfo)
+	mock.lockSendApprovalRequest.Unlock()
+	return mock.SendApprovalRequestFunc(ctx, thread, summary, details, jobNumber)
+}
+
+// SendApprovalRequestCalls gets all the calls that were made to SendApprovalRequest.
+// Check the length with:
+//
+//	len(mockedClienter.SendApprovalRequestCalls())
+func (mock *ClienterMock) SendApprovalRequestCalls() []struct {
+	Ctx       context.Context
+	Thread    *slack.Thread
+	Summary   string
+	Details   slack.SlackDetails
+	JobNumber int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Thread    *slack.Thread
+		Summary   string
+		Details   slack.SlackDetails
+		JobNumber int
+	}
+	mock.lockSendApprovalRequest.RLock()
+	calls = mock.calls.SendApprovalRequest
+	mock.lockSendApprovalRequest.RUnlock()
+	return calls
+}
+
 // SendInfo calls SendInfoFunc.
 func (mock *ClienterMock) SendInfo(ctx context.Context, summary string, details slack.SlackDetails, success bool) error {
 	if mock.SendInfoFunc == nil {
@@ -210,3 +452,95 @@ func (mock *ClienterMock) SendWarningCalls() []struct {
 	mock.lockSendWarning.RUnlock()
 	return calls
 }
+
+// StartThread calls StartThreadFunc.
+func (mock *ClienterMock) StartThread(ctx context.Context, summary string, colour slack.Colour, details slack.SlackDetails) (*slack.Thread, error) {
+	if mock.StartThreadFunc == nil {
+		panic("ClienterMock.StartThreadFunc: method is nil but Clienter.StartThread was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Summary string
+		Colour  slack.Colour
+		Details slack.SlackDetails
+	}{
+		Ctx:     ctx,
+		Summary: summary,
+		Colour:  colour,
+		Details: details,
+	}
+	mock.lockStartThread.Lock()
+	mock.calls.StartThread = append(mock.calls.StartThread, callInfo)
+	mock.lockStartThread.Unlock()
+	return mock.StartThreadFunc(ctx, summary, colour, details)
+}
+
+// StartThreadCalls gets all the calls that were made to StartThread.
+// Check the length with:
+//
+//	len(mockedClienter.StartThreadCalls())
+func (mock *ClienterMock) StartThreadCalls() []struct {
+	Ctx     context.Context
+	Summary string
+	Colour  slack.Colour
+	Details slack.SlackDetails
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Summary string
+		Colour  slack.Colour
+		Details slack.SlackDetails
+	}
+	mock.lockStartThread.RLock()
+	calls = mock.calls.StartThread
+	mock.lockStartThread.RUnlock()
+	return calls
+}
+
+// UpdateThread calls UpdateThreadFunc.
+func (mock *ClienterMock) UpdateThread(ctx context.Context, thread *slack.Thread, summary string, colour slack.Colour, details slack.SlackDetails) error {
+	if mock.UpdateThreadFunc == nil {
+		panic("ClienterMock.UpdateThreadFunc: method is nil but Clienter.UpdateThread was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Thread  *slack.Thread
+		Summary string
+		Colour  slack.Colour
+		Details slack.SlackDetails
+	}{
+		Ctx:     ctx,
+		Thread:  thread,
+		Summary: summary,
+		Colour:  colour,
+		Details: details,
+	}
+	mock.lockUpdateThread.Lock()
+	mock.calls.UpdateThread = append(mock.calls.UpdateThread, callInfo)
+	mock.lockUpdateThread.Unlock()
+	return mock.UpdateThreadFunc(ctx, thread, summary, colour, details)
+}
+
+// UpdateThreadCalls gets all the calls that were made to UpdateThread.
+// Check the length with:
+//
+//	len(mockedClienter.UpdateThreadCalls())
+func (mock *ClienterMock) UpdateThreadCalls() []struct {
+	Ctx     context.Context
+	Thread  *slack.Thread
+	Summary string
+	Colour  slack.Colour
+	Details slack.SlackDetails
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Thread  *slack.Thread
+		Summary string
+		Colour  slack.Colour
+		Details slack.SlackDetails
+	}
+	mock.lockUpdateThread.RLock()
+	calls = mock.calls.UpdateThread
+	mock.lockUpdateThread.RUnlock()
+	return calls
+}
@@ -21,3 +21,28 @@ func (n *NoopClient) SendWarning(ctx context.Context, summary string, details Sl
 func (n *NoopClient) SendInfo(ctx context.Context, summary string, details SlackDetails, success bool) error {
 	return nil
 }
+
+// StartThread is a no-op implementation that returns no thread
+func (n *NoopClient) StartThread(ctx context.Context, summary string, colour Colour, details SlackDetails) (*Thread, error) {
+	return nil, nil
+}
+
+// UpdateThread is a no-op implementation
+func (n *NoopClient) UpdateThread(ctx context.Context, thread *Thread, summary string, colour Colour, details SlackDetails) error {
+	return nil
+}
+
+// ReplyToThread is a no-op implementation
+func (n *NoopClient) ReplyToThread(ctx context.Context, thread *Thread, colour Colour, emoji Emoji, summary string, err error, details SlackDetails) error {
+	return nil
+}
+
+// SendApprovalRequest is a no-op implementation
+func (n *NoopClient) SendApprovalRequest(ctx context.Context, thread *Thread, summary string, details SlackDetails, jobNumber int) error {
+	return nil
+}
+
+// ResolveApprovalRequest is a no-op implementation
+func (n *NoopClient) ResolveApprovalRequest(ctx context.Context, message *Thread, outcome string) error {
+	return nil
+}
@@ -43,3 +43,75 @@ func TestNoopClient_SendInfo(t *testing.T) {
 		t.Errorf("NoopClient.SendInfo() error = %v, want nil", err)
 	}
 }
+
+func TestNoopClient_StartThread(t *testing.T) {
+	client := &NoopClient{}
+	thread, err := client.StartThread(
+		context.Background(),
+		"test",
+		BlueColour,
+		map[string]interface{}{"key": "value"},
+	)
+	if err != nil {
+		t.Errorf("NoopClient.StartThread() error = %v, want nil", err)
+	}
+	if thread != nil {
+		t.Errorf("NoopClient.StartThread() thread = %v, want nil", thread)
+	}
+}
+
+func TestNoopClient_UpdateThread(t *testing.T) {
+	client := &NoopClient{}
+	err := client.UpdateThread(
+		context.Background(),
+		&Thread{Channel: "C123", Timestamp: "1700000000.000100"},
+		"test",
+		GreenColour,
+		map[string]interface{}{"key": "value"},
+	)
+	if err != nil {
+		t.Errorf("NoopClient.UpdateThread() error = %v, want nil", err)
+	}
+}
+
+func TestNoopClient_ReplyToThread(t *testing.T) {
+	client := &NoopClient{}
+	err := client.ReplyToThread(
+		context.Background(),
+		&Thread{Channel: "C123", Timestamp: "1700000000.000100"},
+		RedColour,
+		AlarmEmoji,
+		"test",
+		errors.New("test error"),
+		map[string]interface{}{"key": "value"},
+	)
+	if err != nil {
+		t.Errorf("NoopClient.ReplyToThread() error = %v, want nil", err)
+	}
+}
+
+func TestNoopClient_SendApprovalRequest(t *testing.T) {
+	client := &NoopClient{}
+	err := client.SendApprovalRequest(
+		context.Background(),
+		nil,
+		"test",
+		map[string]interface{}{"key": "value"},
+		1,
+	)
+	if err != nil {
+		t.Errorf("NoopClient.SendApprovalRequest() error = %v, want nil", err)
+	}
+}
+
+func TestNoopClient_ResolveApprovalRequest(t *testing.T) {
+	client := &NoopClient{}
+	err := client.ResolveApprovalRequest(
+		context.Background(),
+		&Thread{Channel: "C123", Timestamp: "1700000000.000100"},
+		"test",
+	)
+	if err != nil {
+		t.Errorf("NoopClient.ResolveApprovalRequest() error = %v, want nil", err)
+	}
+}
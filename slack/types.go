@@ -13,6 +13,7 @@ const (
 	InfoEmoji    Emoji = ":information_source:"
 	WarningEmoji Emoji = ":warning:"
 	AlarmEmoji   Emoji = ":rotating_light:"
+	ReviewEmoji  Emoji = ":eyes:"
 )
 
 // Colour represents a Slack attachment colour
@@ -28,4 +29,13 @@ const (
 	RedColour    Colour = "danger"
 	YellowColour Colour = "warning"
 	GreenColour  Colour = "good"
+	BlueColour   Colour = "#439FE0"
+	GreyColour   Colour = "#9E9E9E"
 )
+
+// Thread identifies a message, such as the parent message that replies can
+// be threaded under
+type Thread struct {
+	Channel   string
+	Timestamp string
+}
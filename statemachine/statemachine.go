@@ -2,6 +2,7 @@ package statemachine
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/ONSdigital/dis-migration-service/domain"
 )
@@ -46,6 +47,14 @@ var allowedTransitions = map[domain.State][]domain.State{
 	domain.StateCancelled: {},
 }
 
+// taskOnlyTransitions are allowed for tasks as well as allowedTransitions.
+// A task waiting for its dependencies fails with them, as it can never run.
+var taskOnlyTransitions = map[domain.State][]domain.State{
+	domain.StateSubmitted:          {domain.StateFailedMigration},
+	domain.StateApproved:           {domain.StateFailedPublish},
+	domain.StatePendingPostPublish: {domain.StateFailedPostPublish},
+}
+
 // CanTransition returns true if a transition from `from` to `to` is allowed.
 func CanTransition(from, to domain.State) bool {
 	nextStates, ok := allowedTransitions[from]
@@ -60,15 +69,31 @@ func CanTransition(from, to domain.State) bool {
 	return false
 }
 
+// CanTaskTransition returns true if a task is allowed to transition from
+// `from` to `to`.
+func CanTaskTransition(from, to domain.State) bool {
+	return CanTransition(from, to) || slices.Contains(taskOnlyTransitions[from], to)
+}
+
 // ValidateTransition validates a transition or returns a TransitionError.
 func ValidateTransition(from, to domain.State) error {
+	return validateTransition(from, to, CanTransition)
+}
+
+// ValidateTaskTransition validates a task transition or returns a
+// TransitionError.
+func ValidateTaskTransition(from, to domain.State) error {
+	return validateTransition(from, to, CanTaskTransition)
+}
+
+func validateTransition(from, to domain.State, canTransition func(from, to domain.State) bool) error {
 	if !domain.IsValidState(to) {
 		return fmt.Errorf("unknown target state %q", to)
 	}
 	if !domain.IsValidState(from) {
 		return fmt.Errorf("unknown current state %q", from)
 	}
-	if !CanTransition(from, to) {
+	if !canTransition(from, to) {
 		return &TransitionError{From: from, To: to}
 	}
 	return nil
@@ -250,6 +250,64 @@ func TestValidateTransition(t *testing.T) {
 	}
 }
 
+func TestValidateTaskTransition(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        domain.State
+		to          domain.State
+		expectError bool
+	}{
+		{
+			name:        "valid happy path transition",
+			from:        domain.StateSubmitted,
+			to:          domain.StateMigrating,
+			expectError: false,
+		},
+		{
+			name:        "submitted task failing with its dependency",
+			from:        domain.StateSubmitted,
+			to:          domain.StateFailedMigration,
+			expectError: false,
+		},
+		{
+			name:        "approved task failing with its dependency",
+			from:        domain.StateApproved,
+			to:          domain.StateFailedPublish,
+			expectError: false,
+		},
+		{
+			name:        "pending post publish task failing with its dependency",
+			from:        domain.StatePendingPostPublish,
+			to:          domain.StateFailedPostPublish,
+			expectError: false,
+		},
+		{
+			name:        "submitted task failing a later stage",
+			from:        domain.StateSubmitted,
+			to:          domain.StateFailedPublish,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := statemachine.ValidateTaskTransition(tt.from, tt.to)
+			if tt.expectError && err == nil {
+				t.Error("expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	t.Run("job transitions do not include task only transitions", func(t *testing.T) {
+		if err := statemachine.ValidateTransition(domain.StateSubmitted, domain.StateFailedMigration); err == nil {
+			t.Error("expected error but got none")
+		}
+	})
+}
+
 func TestIsAllowedStateForJobUpdate(t *testing.T) {
 	tests := []struct {
 		name     string
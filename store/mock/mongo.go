@@ -24,69 +24,195 @@ var _ store.MongoDB = &MongoDBMock{}
 //
 //		// make and configure a mocked store.MongoDB
 //		mockedMongoDB := &MongoDBMock{
+//			AddJobsToReleaseGroupFunc: func(ctx context.Context, groupID string, jobNumbers []int, states []domain.State) (int, error) {
+//				panic("mock out the AddJobsToReleaseGroup method")
+//			},
+//			ApproveJobFunc: func(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error) {
+//				panic("mock out the ApproveJob method")
+//			},
 //			CheckerFunc: func(contextMoqParam context.Context, checkState *healthcheck.CheckState) error {
 //				panic("mock out the Checker method")
 //			},
+//			ClaimDigestRunFunc: func(ctx context.Context, run *domain.DigestRun) error {
+//				panic("mock out the ClaimDigestRun method")
+//			},
 //			ClaimJobFunc: func(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Job, error) {
 //				panic("mock out the ClaimJob method")
 //			},
-//			ClaimTaskFunc: func(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Task, error) {
+//			ClaimTaskFunc: func(ctx context.Context, pendingState domain.State, activeState domain.State, dependencyState domain.State, afterJobNumber int, excludedTypes []domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
 //				panic("mock out the ClaimTask method")
 //			},
+//			ClaimWebhookDeliveryFunc: func(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error) {
+//				panic("mock out the ClaimWebhookDelivery method")
+//			},
 //			CloseFunc: func(contextMoqParam context.Context) error {
 //				panic("mock out the Close method")
 //			},
+//			CompleteIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord, job *domain.Job) error {
+//				panic("mock out the CompleteIdempotencyRecord method")
+//			},
 //			CountEventsByJobNumberFunc: func(ctx context.Context, jobNumber int) (int, error) {
 //				panic("mock out the CountEventsByJobNumber method")
 //			},
 //			CountTasksByJobNumberFunc: func(ctx context.Context, jobNumber int) (int, error) {
 //				panic("mock out the CountTasksByJobNumber method")
 //			},
+//			CreateBatchFunc: func(ctx context.Context, batch *domain.Batch) error {
+//				panic("mock out the CreateBatch method")
+//			},
+//			CreateCommentFunc: func(ctx context.Context, comment *domain.Comment) error {
+//				panic("mock out the CreateComment method")
+//			},
 //			CreateEventFunc: func(ctx context.Context, event *domain.Event) error {
 //				panic("mock out the CreateEvent method")
 //			},
+//			CreateIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord) error {
+//				panic("mock out the CreateIdempotencyRecord method")
+//			},
 //			CreateJobFunc: func(ctx context.Context, job *domain.Job) error {
 //				panic("mock out the CreateJob method")
 //			},
+//			CreateReleaseGroupFunc: func(ctx context.Context, group *domain.ReleaseGroup) error {
+//				panic("mock out the CreateReleaseGroup method")
+//			},
 //			CreateTaskFunc: func(ctx context.Context, task *domain.Task) error {
 //				panic("mock out the CreateTask method")
 //			},
+//			CreateWebhookFunc: func(ctx context.Context, webhook *domain.Webhook) error {
+//				panic("mock out the CreateWebhook method")
+//			},
+//			CreateWebhookDeliveryFunc: func(ctx context.Context, delivery *domain.WebhookDelivery) error {
+//				panic("mock out the CreateWebhookDelivery method")
+//			},
+//			DeleteIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord) error {
+//				panic("mock out the DeleteIdempotencyRecord method")
+//			},
+//			DeleteReleaseGroupFunc: func(ctx context.Context, groupID string) error {
+//				panic("mock out the DeleteReleaseGroup method")
+//			},
+//			DeleteWebhookFunc: func(ctx context.Context, webhookID string) error {
+//				panic("mock out the DeleteWebhook method")
+//			},
+//			GetBatchFunc: func(ctx context.Context, batchID string) (*domain.Batch, error) {
+//				panic("mock out the GetBatch method")
+//			},
+//			GetBatchJobStateCountsFunc: func(ctx context.Context, batchID string) ([]mongo.StateCountResult, error) {
+//				panic("mock out the GetBatchJobStateCounts method")
+//			},
+//			GetDependentTasksFunc: func(ctx context.Context, taskID string) ([]*domain.Task, error) {
+//				panic("mock out the GetDependentTasks method")
+//			},
+//			GetIdempotencyRecordFunc: func(ctx context.Context, id string) (*domain.IdempotencyRecord, error) {
+//				panic("mock out the GetIdempotencyRecord method")
+//			},
 //			GetJobFunc: func(ctx context.Context, jobNumber int) (*domain.Job, error) {
 //				panic("mock out the GetJob method")
 //			},
-//			GetJobEventsFunc: func(ctx context.Context, jobNumber int, limit int, offset int) ([]*domain.Event, int, error) {
+//			GetJobCommentsFunc: func(ctx context.Context, jobNumber int, limit int, offset int) ([]*domain.Comment, int, error) {
+//				panic("mock out the GetJobComments method")
+//			},
+//			GetJobEventStateCountsFunc: func(ctx context.Context, action domain.EventAction, since time.Time) ([]mongo.StateCountResult, error) {
+//				panic("mock out the GetJobEventStateCounts method")
+//			},
+//			GetJobEventsFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit int, offset int) ([]*domain.Event, int, error) {
 //				panic("mock out the GetJobEvents method")
 //			},
+//			GetJobEventsAfterFunc: func(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error) {
+//				panic("mock out the GetJobEventsAfter method")
+//			},
 //			GetJobStateCountsFunc: func(ctx context.Context) ([]mongo.StateCountResult, error) {
 //				panic("mock out the GetJobStateCounts method")
 //			},
 //			GetJobTasksFunc: func(ctx context.Context, states []domain.State, jobNumber int, limit int, offset int) ([]*domain.Task, int, error) {
 //				panic("mock out the GetJobTasks method")
 //			},
-//			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit int, offset int) ([]*domain.Job, int, error) {
+//			GetJobTasksAfterFunc: func(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error) {
+//				panic("mock out the GetJobTasksAfter method")
+//			},
+//			GetJobsFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit int, offset int) ([]*domain.Job, int, error) {
 //				panic("mock out the GetJobs method")
 //			},
+//			GetJobsAfterFunc: func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error) {
+//				panic("mock out the GetJobsAfter method")
+//			},
 //			GetJobsBySourceOrTargetAndStateFunc: func(ctx context.Context, jc *domain.JobConfig, states []domain.State, limit int, offset int) ([]*domain.Job, error) {
 //				panic("mock out the GetJobsBySourceOrTargetAndState method")
 //			},
 //			GetNextJobNumberCounterFunc: func(ctx context.Context) (*domain.Counter, error) {
 //				panic("mock out the GetNextJobNumberCounter method")
 //			},
+//			GetReleaseGroupFunc: func(ctx context.Context, groupID string) (*domain.ReleaseGroup, error) {
+//				panic("mock out the GetReleaseGroup method")
+//			},
+//			GetReleaseGroupJobsFunc: func(ctx context.Context, groupID string) ([]*domain.Job, error) {
+//				panic("mock out the GetReleaseGroupJobs method")
+//			},
+//			GetStaleTasksFunc: func(ctx context.Context, states []domain.State, updatedBefore time.Time, limit int) ([]*domain.Task, int, error) {
+//				panic("mock out the GetStaleTasks method")
+//			},
 //			GetTaskFunc: func(ctx context.Context, taskID string) (*domain.Task, error) {
 //				panic("mock out the GetTask method")
 //			},
+//			GetWebhookFunc: func(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+//				panic("mock out the GetWebhook method")
+//			},
+//			GetWebhookDeliveriesFunc: func(ctx context.Context, webhookID string, status domain.WebhookDeliveryStatus, limit int, offset int) ([]*domain.WebhookDelivery, int, error) {
+//				panic("mock out the GetWebhookDeliveries method")
+//			},
+//			GetWebhooksFunc: func(ctx context.Context, limit int, offset int) ([]*domain.Webhook, int, error) {
+//				panic("mock out the GetWebhooks method")
+//			},
+//			GetWebhooksForEventFunc: func(ctx context.Context, eventType domain.WebhookEventType, jobType domain.JobType) ([]*domain.Webhook, error) {
+//				panic("mock out the GetWebhooksForEvent method")
+//			},
+//			ReleaseGroupJobsFunc: func(ctx context.Context, groupID string, state domain.State) error {
+//				panic("mock out the ReleaseGroupJobs method")
+//			},
+//			RemoveJobsFromReleaseGroupFunc: func(ctx context.Context, groupID string) error {
+//				panic("mock out the RemoveJobsFromReleaseGroup method")
+//			},
+//			ReplaceExpiredIdempotencyRecordFunc: func(ctx context.Context, record *domain.IdempotencyRecord) error {
+//				panic("mock out the ReplaceExpiredIdempotencyRecord method")
+//			},
+//			UpdateBatchFunc: func(ctx context.Context, batch *domain.Batch) error {
+//				panic("mock out the UpdateBatch method")
+//			},
 //			UpdateJobFunc: func(ctx context.Context, job *domain.Job) error {
 //				panic("mock out the UpdateJob method")
 //			},
+//			UpdateJobCollectionIDFunc: func(ctx context.Context, jobID string, collectionID string) error {
+//				panic("mock out the UpdateJobCollectionID method")
+//			},
+//			UpdateJobNotificationThreadFunc: func(ctx context.Context, jobID string, thread *domain.NotificationThread) error {
+//				panic("mock out the UpdateJobNotificationThread method")
+//			},
+//			UpdateJobPriorityFunc: func(ctx context.Context, jobID string, priority int) error {
+//				panic("mock out the UpdateJobPriority method")
+//			},
+//			UpdateJobPublishAtFunc: func(ctx context.Context, jobID string, states []domain.State, publishAt *time.Time) error {
+//				panic("mock out the UpdateJobPublishAt method")
+//			},
 //			UpdateJobStateFunc: func(ctx context.Context, id string, oldState domain.State, newState domain.State, lastUpdated time.Time) error {
 //				panic("mock out the UpdateJobState method")
 //			},
+//			UpdateJobTasksPriorityFunc: func(ctx context.Context, jobNumber int, priority int) error {
+//				panic("mock out the UpdateJobTasksPriority method")
+//			},
 //			UpdateTaskFunc: func(ctx context.Context, task *domain.Task) error {
 //				panic("mock out the UpdateTask method")
 //			},
 //			UpdateTaskStateFunc: func(ctx context.Context, taskID string, newState domain.State, lastUpdated time.Time) error {
 //				panic("mock out the UpdateTaskState method")
 //			},
+//			UpdateWebhookFunc: func(ctx context.Context, webhook *domain.Webhook) error {
+//				panic("mock out the UpdateWebhook method")
+//			},
+//			UpdateWebhookDeliveryFunc: func(ctx context.Context, delivery *domain.WebhookDelivery) error {
+//				panic("mock out the UpdateWebhookDelivery method")
+//			},
+//			WatchWorkFunc: func(ctx context.Context, notify func()) error {
+//				panic("mock out the WatchWork method")
+//			},
 //		}
 //
 //		// use mockedMongoDB in code that requires store.MongoDB
@@ -94,38 +220,101 @@ var _ store.MongoDB = &MongoDBMock{}
 //
 //	}
 type MongoDBMock struct {
+	// AddJobsToReleaseGroupFunc mocks the AddJobsToReleaseGroup method.
+	AddJobsToReleaseGroupFunc func(ctx context.Context, groupID string, jobNumbers []int, states []domain.State) (int, error)
+
+	// ApproveJobFunc mocks the ApproveJob method.
+	ApproveJobFunc func(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error)
+
 	// CheckerFunc mocks the Checker method.
 	CheckerFunc func(contextMoqParam context.Context, checkState *healthcheck.CheckState) error
 
+	// ClaimDigestRunFunc mocks the ClaimDigestRun method.
+	ClaimDigestRunFunc func(ctx context.Context, run *domain.DigestRun) error
+
 	// ClaimJobFunc mocks the ClaimJob method.
 	ClaimJobFunc func(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Job, error)
 
 	// ClaimTaskFunc mocks the ClaimTask method.
-	ClaimTaskFunc func(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Task, error)
+	ClaimTaskFunc func(ctx context.Context, pendingState domain.State, activeState domain.State, dependencyState domain.State, afterJobNumber int, excludedTypes []domain.TaskType, excludedJobNumbers []int) (*domain.Task, error)
+
+	// ClaimWebhookDeliveryFunc mocks the ClaimWebhookDelivery method.
+	ClaimWebhookDeliveryFunc func(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error)
 
 	// CloseFunc mocks the Close method.
 	CloseFunc func(contextMoqParam context.Context) error
 
+	// CompleteIdempotencyRecordFunc mocks the CompleteIdempotencyRecord method.
+	CompleteIdempotencyRecordFunc func(ctx context.Context, record *domain.IdempotencyRecord, job *domain.Job) error
+
 	// CountEventsByJobNumberFunc mocks the CountEventsByJobNumber method.
 	CountEventsByJobNumberFunc func(ctx context.Context, jobNumber int) (int, error)
 
 	// CountTasksByJobNumberFunc mocks the CountTasksByJobNumber method.
 	CountTasksByJobNumberFunc func(ctx context.Context, jobNumber int) (int, error)
 
+	// CreateBatchFunc mocks the CreateBatch method.
+	CreateBatchFunc func(ctx context.Context, batch *domain.Batch) error
+
+	// CreateCommentFunc mocks the CreateComment method.
+	CreateCommentFunc func(ctx context.Context, comment *domain.Comment) error
+
 	// CreateEventFunc mocks the CreateEvent method.
 	CreateEventFunc func(ctx context.Context, event *domain.Event) error
 
+	// CreateIdempotencyRecordFunc mocks the CreateIdempotencyRecord method.
+	CreateIdempotencyRecordFunc func(ctx context.Context, record *domain.IdempotencyRecord) error
+
 	// CreateJobFunc mocks the CreateJob method.
 	CreateJobFunc func(ctx context.Context, job *domain.Job) error
 
+	// CreateReleaseGroupFunc mocks the CreateReleaseGroup method.
+	CreateReleaseGroupFunc func(ctx context.Context, group *domain.ReleaseGroup) error
+
 	// CreateTaskFunc mocks the CreateTask method.
 	CreateTaskFunc func(ctx context.Context, task *domain.Task) error
 
+	// CreateWebhookFunc mocks the CreateWebhook method.
+	CreateWebhookFunc func(ctx context.Context, webhook *domain.Webhook) error
+
+	// CreateWebhookDeliveryFunc mocks the CreateWebhookDelivery method.
+	CreateWebhookDeliveryFunc func(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// DeleteIdempotencyRecordFunc mocks the DeleteIdempotencyRecord method.
+	DeleteIdempotencyRecordFunc func(ctx context.Context, record *domain.IdempotencyRecord) error
+
+	// DeleteReleaseGroupFunc mocks the DeleteReleaseGroup method.
+	DeleteReleaseGroupFunc func(ctx context.Context, groupID string) error
+
+	// DeleteWebhookFunc mocks the DeleteWebhook method.
+	DeleteWebhookFunc func(ctx context.Context, webhookID string) error
+
+	// GetBatchFunc mocks the GetBatch method.
+	GetBatchFunc func(ctx context.Context, batchID string) (*domain.Batch, error)
+
+	// GetBatchJobStateCountsFunc mocks the GetBatchJobStateCounts method.
+	GetBatchJobStateCountsFunc func(ctx context.Context, batchID string) ([]mongo.StateCountResult, error)
+
+	// GetDependentTasksFunc mocks the GetDependentTasks method.
+	GetDependentTasksFunc func(ctx context.Context, taskID string) ([]*domain.Task, error)
+
+	// GetIdempotencyRecordFunc mocks the GetIdempotencyRecord method.
+	GetIdempotencyRecordFunc func(ctx context.Context, id string) (*domain.IdempotencyRecord, error)
+
 	// GetJobFunc mocks the GetJob method.
 	GetJobFunc func(ctx context.Context, jobNumber int) (*domain.Job, error)
 
+	// GetJobCommentsFunc mocks the GetJobComments method.
+	GetJobCommentsFunc func(ctx context.Context, jobNumber int, limit int, offset int) ([]*domain.Comment, int, error)
+
+	// GetJobEventStateCountsFunc mocks the GetJobEventStateCounts method.
+	GetJobEventStateCountsFunc func(ctx context.Context, action domain.EventAction, since time.Time) ([]mongo.StateCountResult, error)
+
 	// GetJobEventsFunc mocks the GetJobEvents method.
-	GetJobEventsFunc func(ctx context.Context, jobNumber int, limit int, offset int) ([]*domain.Event, int, error)
+	GetJobEventsFunc func(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit int, offset int) ([]*domain.Event, int, error)
+
+	// GetJobEventsAfterFunc mocks the GetJobEventsAfter method.
+	GetJobEventsAfterFunc func(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error)
 
 	// GetJobStateCountsFunc mocks the GetJobStateCounts method.
 	GetJobStateCountsFunc func(ctx context.Context) ([]mongo.StateCountResult, error)
@@ -133,8 +322,14 @@ type MongoDBMock struct {
 	// GetJobTasksFunc mocks the GetJobTasks method.
 	GetJobTasksFunc func(ctx context.Context, states []domain.State, jobNumber int, limit int, offset int) ([]*domain.Task, int, error)
 
+	// GetJobTasksAfterFunc mocks the GetJobTasksAfter method.
+	GetJobTasksAfterFunc func(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error)
+
 	// GetJobsFunc mocks the GetJobs method.
-	GetJobsFunc func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit int, offset int) ([]*domain.Job, int, error)
+	GetJobsFunc func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit int, offset int) ([]*domain.Job, int, error)
+
+	// GetJobsAfterFunc mocks the GetJobsAfter method.
+	GetJobsAfterFunc func(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error)
 
 	// GetJobsBySourceOrTargetAndStateFunc mocks the GetJobsBySourceOrTargetAndState method.
 	GetJobsBySourceOrTargetAndStateFunc func(ctx context.Context, jc *domain.JobConfig, states []domain.State, limit int, offset int) ([]*domain.Job, error)
@@ -142,23 +337,104 @@ type MongoDBMock struct {
 	// GetNextJobNumberCounterFunc mocks the GetNextJobNumberCounter method.
 	GetNextJobNumberCounterFunc func(ctx context.Context) (*domain.Counter, error)
 
+	// GetReleaseGroupFunc mocks the GetReleaseGroup method.
+	GetReleaseGroupFunc func(ctx context.Context, groupID string) (*domain.ReleaseGroup, error)
+
+	// GetReleaseGroupJobsFunc mocks the GetReleaseGroupJobs method.
+	GetReleaseGroupJobsFunc func(ctx context.Context, groupID string) ([]*domain.Job, error)
+
+	// GetStaleTasksFunc mocks the GetStaleTasks method.
+	GetStaleTasksFunc func(ctx context.Context, states []domain.State, updatedBefore time.Time, limit int) ([]*domain.Task, int, error)
+
 	// GetTaskFunc mocks the GetTask method.
 	GetTaskFunc func(ctx context.Context, taskID string) (*domain.Task, error)
 
+	// GetWebhookFunc mocks the GetWebhook method.
+	GetWebhookFunc func(ctx context.Context, webhookID string) (*domain.Webhook, error)
+
+	// GetWebhookDeliveriesFunc mocks the GetWebhookDeliveries method.
+	GetWebhookDeliveriesFunc func(ctx context.Context, webhookID string, status domain.WebhookDeliveryStatus, limit int, offset int) ([]*domain.WebhookDelivery, int, error)
+
+	// GetWebhooksFunc mocks the GetWebhooks method.
+	GetWebhooksFunc func(ctx context.Context, limit int, offset int) ([]*domain.Webhook, int, error)
+
+	// GetWebhooksForEventFunc mocks the GetWebhooksForEvent method.
+	GetWebhooksForEventFunc func(ctx context.Context, eventType domain.WebhookEventType, jobType domain.JobType) ([]*domain.Webhook, error)
+
+	// ReleaseGroupJobsFunc mocks the ReleaseGroupJobs method.
+	ReleaseGroupJobsFunc func(ctx context.Context, groupID string, state domain.State) error
+
+	// RemoveJobsFromReleaseGroupFunc mocks the RemoveJobsFromReleaseGroup method.
+	RemoveJobsFromReleaseGroupFunc func(ctx context.Context, groupID string) error
+
+	// ReplaceExpiredIdempotencyRecordFunc mocks the ReplaceExpiredIdempotencyRecord method.
+	ReplaceExpiredIdempotencyRecordFunc func(ctx context.Context, record *domain.IdempotencyRecord) error
+
+	// UpdateBatchFunc mocks the UpdateBatch method.
+	UpdateBatchFunc func(ctx context.Context, batch *domain.Batch) error
+
 	// UpdateJobFunc mocks the UpdateJob method.
 	UpdateJobFunc func(ctx context.Context, job *domain.Job) error
 
+	// UpdateJobCollectionIDFunc mocks the UpdateJobCollectionID method.
+	UpdateJobCollectionIDFunc func(ctx context.Context, jobID string, collectionID string) error
+
+	// UpdateJobNotificationThreadFunc mocks the UpdateJobNotificationThread method.
+	UpdateJobNotificationThreadFunc func(ctx context.Context, jobID string, thread *domain.NotificationThread) error
+
+	// UpdateJobPriorityFunc mocks the UpdateJobPriority method.
+	UpdateJobPriorityFunc func(ctx context.Context, jobID string, priority int) error
+
+	// UpdateJobPublishAtFunc mocks the UpdateJobPublishAt method.
+	UpdateJobPublishAtFunc func(ctx context.Context, jobID string, states []domain.State, publishAt *time.Time) error
+
 	// UpdateJobStateFunc mocks the UpdateJobState method.
 	UpdateJobStateFunc func(ctx context.Context, id string, oldState domain.State, newState domain.State, lastUpdated time.Time) error
 
+	// UpdateJobTasksPriorityFunc mocks the UpdateJobTasksPriority method.
+	UpdateJobTasksPriorityFunc func(ctx context.Context, jobNumber int, priority int) error
+
 	// UpdateTaskFunc mocks the UpdateTask method.
 	UpdateTaskFunc func(ctx context.Context, task *domain.Task) error
 
 	// UpdateTaskStateFunc mocks the UpdateTaskState method.
 	UpdateTaskStateFunc func(ctx context.Context, taskID string, newState domain.State, lastUpdated time.Time) error
 
+	// UpdateWebhookFunc mocks the UpdateWebhook method.
+	UpdateWebhookFunc func(ctx context.Context, webhook *domain.Webhook) error
+
+	// UpdateWebhookDeliveryFunc mocks the UpdateWebhookDelivery method.
+	UpdateWebhookDeliveryFunc func(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// WatchWorkFunc mocks the WatchWork method.
+	WatchWorkFunc func(ctx context.Context, notify func()) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AddJobsToReleaseGroup holds details about calls to the AddJobsToReleaseGroup method.
+		AddJobsToReleaseGroup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GroupID is the groupID argument value.
+			GroupID string
+			// JobNumbers is the jobNumbers argument value.
+			JobNumbers []int
+			// States is the states argument value.
+			States []domain.State
+		}
+		// ApproveJob holds details about calls to the ApproveJob method.
+		ApproveJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobID is the jobID argument value.
+			JobID string
+			// Approval is the approval argument value.
+			Approval *domain.Approval
+			// RequiredApprovals is the requiredApprovals argument value.
+			RequiredApprovals int
+			// PublishAt is the publishAt argument value.
+			PublishAt *time.Time
+		}
 		// Checker holds details about calls to the Checker method.
 		Checker []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -166,6 +442,13 @@ type MongoDBMock struct {
 			// CheckState is the checkState argument value.
 			CheckState *healthcheck.CheckState
 		}
+		// ClaimDigestRun holds details about calls to the ClaimDigestRun method.
+		ClaimDigestRun []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Run is the run argument value.
+			Run *domain.DigestRun
+		}
 		// ClaimJob holds details about calls to the ClaimJob method.
 		ClaimJob []struct {
 			// Ctx is the ctx argument value.
@@ -183,12 +466,36 @@ type MongoDBMock struct {
 			PendingState domain.State
 			// ActiveState is the activeState argument value.
 			ActiveState domain.State
+			// DependencyState is the dependencyState argument value.
+			DependencyState domain.State
+			// AfterJobNumber is the afterJobNumber argument value.
+			AfterJobNumber int
+			// ExcludedTypes is the excludedTypes argument value.
+			ExcludedTypes []domain.TaskType
+			// ExcludedJobNumbers is the excludedJobNumbers argument value.
+			ExcludedJobNumbers []int
+		}
+		// ClaimWebhookDelivery holds details about calls to the ClaimWebhookDelivery method.
+		ClaimWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// LeaseUntil is the leaseUntil argument value.
+			LeaseUntil time.Time
 		}
 		// Close holds details about calls to the Close method.
 		Close []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
 			ContextMoqParam context.Context
 		}
+		// CompleteIdempotencyRecord holds details about calls to the CompleteIdempotencyRecord method.
+		CompleteIdempotencyRecord []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Record is the record argument value.
+			Record *domain.IdempotencyRecord
+			// Job is the job argument value.
+			Job *domain.Job
+		}
 		// CountEventsByJobNumber holds details about calls to the CountEventsByJobNumber method.
 		CountEventsByJobNumber []struct {
 			// Ctx is the ctx argument value.
@@ -203,6 +510,20 @@ type MongoDBMock struct {
 			// JobNumber is the jobNumber argument value.
 			JobNumber int
 		}
+		// CreateBatch holds details about calls to the CreateBatch method.
+		CreateBatch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Batch is the batch argument value.
+			Batch *domain.Batch
+		}
+		// CreateComment holds details about calls to the CreateComment method.
+		CreateComment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Comment is the comment argument value.
+			Comment *domain.Comment
+		}
 		// CreateEvent holds details about calls to the CreateEvent method.
 		CreateEvent []struct {
 			// Ctx is the ctx argument value.
@@ -210,6 +531,13 @@ type MongoDBMock struct {
 			// Event is the event argument value.
 			Event *domain.Event
 		}
+		// CreateIdempotencyRecord holds details about calls to the CreateIdempotencyRecord method.
+		CreateIdempotencyRecord []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Record is the record argument value.
+			Record *domain.IdempotencyRecord
+		}
 		// CreateJob holds details about calls to the CreateJob method.
 		CreateJob []struct {
 			// Ctx is the ctx argument value.
@@ -217,6 +545,13 @@ type MongoDBMock struct {
 			// Job is the job argument value.
 			Job *domain.Job
 		}
+		// CreateReleaseGroup holds details about calls to the CreateReleaseGroup method.
+		CreateReleaseGroup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Group is the group argument value.
+			Group *domain.ReleaseGroup
+		}
 		// CreateTask holds details about calls to the CreateTask method.
 		CreateTask []struct {
 			// Ctx is the ctx argument value.
@@ -224,6 +559,69 @@ type MongoDBMock struct {
 			// Task is the task argument value.
 			Task *domain.Task
 		}
+		// CreateWebhook holds details about calls to the CreateWebhook method.
+		CreateWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Webhook is the webhook argument value.
+			Webhook *domain.Webhook
+		}
+		// CreateWebhookDelivery holds details about calls to the CreateWebhookDelivery method.
+		CreateWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Delivery is the delivery argument value.
+			Delivery *domain.WebhookDelivery
+		}
+		// DeleteIdempotencyRecord holds details about calls to the DeleteIdempotencyRecord method.
+		DeleteIdempotencyRecord []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Record is the record argument value.
+			Record *domain.IdempotencyRecord
+		}
+		// DeleteReleaseGroup holds details about calls to the DeleteReleaseGroup method.
+		DeleteReleaseGroup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GroupID is the groupID argument value.
+			GroupID string
+		}
+		// DeleteWebhook holds details about calls to the DeleteWebhook method.
+		DeleteWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WebhookID is the webhookID argument value.
+			WebhookID string
+		}
+		// GetBatch holds details about calls to the GetBatch method.
+		GetBatch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BatchID is the batchID argument value.
+			BatchID string
+		}
+		// GetBatchJobStateCounts holds details about calls to the GetBatchJobStateCounts method.
+		GetBatchJobStateCounts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BatchID is the batchID argument value.
+			BatchID string
+		}
+		// GetDependentTasks holds details about calls to the GetDependentTasks method.
+		GetDependentTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TaskID is the taskID argument value.
+			TaskID string
+		}
+		// GetIdempotencyRecord holds details about calls to the GetIdempotencyRecord method.
+		GetIdempotencyRecord []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id string
+		}
 		// GetJob holds details about calls to the GetJob method.
 		GetJob []struct {
 			// Ctx is the ctx argument value.
@@ -231,17 +629,52 @@ type MongoDBMock struct {
 			// JobNumber is the jobNumber argument value.
 			JobNumber int
 		}
+		// GetJobComments holds details about calls to the GetJobComments method.
+		GetJobComments []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
+		// GetJobEventStateCounts holds details about calls to the GetJobEventStateCounts method.
+		GetJobEventStateCounts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Action is the action argument value.
+			Action domain.EventAction
+			// Since is the since argument value.
+			Since time.Time
+		}
 		// GetJobEvents holds details about calls to the GetJobEvents method.
 		GetJobEvents []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// JobNumber is the jobNumber argument value.
 			JobNumber int
+			// Filter is the filter argument value.
+			Filter *domain.EventFilter
 			// Limit is the limit argument value.
 			Limit int
 			// Offset is the offset argument value.
 			Offset int
 		}
+		// GetJobEventsAfter holds details about calls to the GetJobEventsAfter method.
+		GetJobEventsAfter []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+			// Filter is the filter argument value.
+			Filter *domain.EventFilter
+			// After is the after argument value.
+			After *domain.Cursor
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetJobStateCounts holds details about calls to the GetJobStateCounts method.
 		GetJobStateCounts []struct {
 			// Ctx is the ctx argument value.
@@ -260,6 +693,19 @@ type MongoDBMock struct {
 			// Offset is the offset argument value.
 			Offset int
 		}
+		// GetJobTasksAfter holds details about calls to the GetJobTasksAfter method.
+		GetJobTasksAfter []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// States is the states argument value.
+			States []domain.State
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+			// After is the after argument value.
+			After *domain.Cursor
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetJobs holds details about calls to the GetJobs method.
 		GetJobs []struct {
 			// Ctx is the ctx argument value.
@@ -268,13 +714,28 @@ type MongoDBMock struct {
 			Field sort.SortParameterField
 			// Direction is the direction argument value.
 			Direction sort.SortParameterDirection
-			// States is the states argument value.
-			States []domain.State
+			// Filter is the filter argument value.
+			Filter *domain.JobFilter
 			// Limit is the limit argument value.
 			Limit int
 			// Offset is the offset argument value.
 			Offset int
 		}
+		// GetJobsAfter holds details about calls to the GetJobsAfter method.
+		GetJobsAfter []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Field is the field argument value.
+			Field sort.SortParameterField
+			// Direction is the direction argument value.
+			Direction sort.SortParameterDirection
+			// Filter is the filter argument value.
+			Filter *domain.JobFilter
+			// After is the after argument value.
+			After *domain.Cursor
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetJobsBySourceOrTargetAndState holds details about calls to the GetJobsBySourceOrTargetAndState method.
 		GetJobsBySourceOrTargetAndState []struct {
 			// Ctx is the ctx argument value.
@@ -293,6 +754,31 @@ type MongoDBMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// GetReleaseGroup holds details about calls to the GetReleaseGroup method.
+		GetReleaseGroup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GroupID is the groupID argument value.
+			GroupID string
+		}
+		// GetReleaseGroupJobs holds details about calls to the GetReleaseGroupJobs method.
+		GetReleaseGroupJobs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GroupID is the groupID argument value.
+			GroupID string
+		}
+		// GetStaleTasks holds details about calls to the GetStaleTasks method.
+		GetStaleTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// States is the states argument value.
+			States []domain.State
+			// UpdatedBefore is the updatedBefore argument value.
+			UpdatedBefore time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetTask holds details about calls to the GetTask method.
 		GetTask []struct {
 			// Ctx is the ctx argument value.
@@ -300,6 +786,74 @@ type MongoDBMock struct {
 			// TaskID is the taskID argument value.
 			TaskID string
 		}
+		// GetWebhook holds details about calls to the GetWebhook method.
+		GetWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WebhookID is the webhookID argument value.
+			WebhookID string
+		}
+		// GetWebhookDeliveries holds details about calls to the GetWebhookDeliveries method.
+		GetWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WebhookID is the webhookID argument value.
+			WebhookID string
+			// Status is the status argument value.
+			Status domain.WebhookDeliveryStatus
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
+		// GetWebhooks holds details about calls to the GetWebhooks method.
+		GetWebhooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
+		// GetWebhooksForEvent holds details about calls to the GetWebhooksForEvent method.
+		GetWebhooksForEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// EventType is the eventType argument value.
+			EventType domain.WebhookEventType
+			// JobType is the jobType argument value.
+			JobType domain.JobType
+		}
+		// ReleaseGroupJobs holds details about calls to the ReleaseGroupJobs method.
+		ReleaseGroupJobs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GroupID is the groupID argument value.
+			GroupID string
+			// State is the state argument value.
+			State domain.State
+		}
+		// RemoveJobsFromReleaseGroup holds details about calls to the RemoveJobsFromReleaseGroup method.
+		RemoveJobsFromReleaseGroup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GroupID is the groupID argument value.
+			GroupID string
+		}
+		// ReplaceExpiredIdempotencyRecord holds details about calls to the ReplaceExpiredIdempotencyRecord method.
+		ReplaceExpiredIdempotencyRecord []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Record is the record argument value.
+			Record *domain.IdempotencyRecord
+		}
+		// UpdateBatch holds details about calls to the UpdateBatch method.
+		UpdateBatch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Batch is the batch argument value.
+			Batch *domain.Batch
+		}
 		// UpdateJob holds details about calls to the UpdateJob method.
 		UpdateJob []struct {
 			// Ctx is the ctx argument value.
@@ -307,6 +861,44 @@ type MongoDBMock struct {
 			// Job is the job argument value.
 			Job *domain.Job
 		}
+		// UpdateJobCollectionID holds details about calls to the UpdateJobCollectionID method.
+		UpdateJobCollectionID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobID is the jobID argument value.
+			JobID string
+			// CollectionID is the collectionID argument value.
+			CollectionID string
+		}
+		// UpdateJobNotificationThread holds details about calls to the UpdateJobNotificationThread method.
+		UpdateJobNotificationThread []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobID is the jobID argument value.
+			JobID string
+			// Thread is the thread argument value.
+			Thread *domain.NotificationThread
+		}
+		// UpdateJobPriority holds details about calls to the UpdateJobPriority method.
+		UpdateJobPriority []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobID is the jobID argument value.
+			JobID string
+			// Priority is the priority argument value.
+			Priority int
+		}
+		// UpdateJobPublishAt holds details about calls to the UpdateJobPublishAt method.
+		UpdateJobPublishAt []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobID is the jobID argument value.
+			JobID string
+			// States is the states argument value.
+			States []domain.State
+			// PublishAt is the publishAt argument value.
+			PublishAt *time.Time
+		}
 		// UpdateJobState holds details about calls to the UpdateJobState method.
 		UpdateJobState []struct {
 			// Ctx is the ctx argument value.
@@ -320,6 +912,15 @@ type MongoDBMock struct {
 			// LastUpdated is the lastUpdated argument value.
 			LastUpdated time.Time
 		}
+		// UpdateJobTasksPriority holds details about calls to the UpdateJobTasksPriority method.
+		UpdateJobTasksPriority []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobNumber is the jobNumber argument value.
+			JobNumber int
+			// Priority is the priority argument value.
+			Priority int
+		}
 		// UpdateTask holds details about calls to the UpdateTask method.
 		UpdateTask []struct {
 			// Ctx is the ctx argument value.
@@ -338,28 +939,183 @@ type MongoDBMock struct {
 			// LastUpdated is the lastUpdated argument value.
 			LastUpdated time.Time
 		}
+		// UpdateWebhook holds details about calls to the UpdateWebhook method.
+		UpdateWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Webhook is the webhook argument value.
+			Webhook *domain.Webhook
+		}
+		// UpdateWebhookDelivery holds details about calls to the UpdateWebhookDelivery method.
+		UpdateWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Delivery is the delivery argument value.
+			Delivery *domain.WebhookDelivery
+		}
+		// WatchWork holds details about calls to the WatchWork method.
+		WatchWork []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Notify is the notify argument value.
+			Notify func()
+		}
 	}
+	lockAddJobsToReleaseGroup           sync.RWMutex
+	lockApproveJob                      sync.RWMutex
 	lockChecker                         sync.RWMutex
+	lockClaimDigestRun                  sync.RWMutex
 	lockClaimJob                        sync.RWMutex
 	lockClaimTask                       sync.RWMutex
+	lockClaimWebhookDelivery            sync.RWMutex
 	lockClose                           sync.RWMutex
+	lockCompleteIdempotencyRecord       sync.RWMutex
 	lockCountEventsByJobNumber          sync.RWMutex
 	lockCountTasksByJobNumber           sync.RWMutex
+	lockCreateBatch                     sync.RWMutex
+	lockCreateComment                   sync.RWMutex
 	lockCreateEvent                     sync.RWMutex
+	lockCreateIdempotencyRecord         sync.RWMutex
 	lockCreateJob                       sync.RWMutex
+	lockCreateReleaseGroup              sync.RWMutex
 	lockCreateTask                      sync.RWMutex
+	lockCreateWebhook                   sync.RWMutex
+	lockCreateWebhookDelivery           sync.RWMutex
+	lockDeleteIdempotencyRecord         sync.RWMutex
+	lockDeleteReleaseGroup              sync.RWMutex
+	lockDeleteWebhook                   sync.RWMutex
+	lockGetBatch                        sync.RWMutex
+	lockGetBatchJobStateCounts          sync.RWMutex
+	lockGetDependentTasks               sync.RWMutex
+	lockGetIdempotencyRecord            sync.RWMutex
 	lockGetJob                          sync.RWMutex
+	lockGetJobComments                  sync.RWMutex
+	lockGetJobEventStateCounts          sync.RWMutex
 	lockGetJobEvents                    sync.RWMutex
+	lockGetJobEventsAfter               sync.RWMutex
 	lockGetJobStateCounts               sync.RWMutex
 	lockGetJobTasks                     sync.RWMutex
+	lockGetJobTasksAfter                sync.RWMutex
 	lockGetJobs                         sync.RWMutex
+	lockGetJobsAfter                    sync.RWMutex
 	lockGetJobsBySourceOrTargetAndState sync.RWMutex
 	lockGetNextJobNumberCounter         sync.RWMutex
+	lockGetReleaseGroup                 sync.RWMutex
+	lockGetReleaseGroupJobs             sync.RWMutex
+	lockGetStaleTasks                   sync.RWMutex
 	lockGetTask                         sync.RWMutex
+	lockGetWebhook                      sync.RWMutex
+	lockGetWebhookDeliveries            sync.RWMutex
+	lockGetWebhooks                     sync.RWMutex
+	lockGetWebhooksForEvent             sync.RWMutex
+	lockReleaseGroupJobs                sync.RWMutex
+	lockRemoveJobsFromReleaseGroup      sync.RWMutex
+	lockReplaceExpiredIdempotencyRecord sync.RWMutex
+	lockUpdateBatch                     sync.RWMutex
 	lockUpdateJob                       sync.RWMutex
+	lockUpdateJobCollectionID           sync.RWMutex
+	lockUpdateJobNotificationThread     sync.RWMutex
+	lockUpdateJobPriority               sync.RWMutex
+	lockUpdateJobPublishAt              sync.RWMutex
 	lockUpdateJobState                  sync.RWMutex
+	lockUpdateJobTasksPriority          sync.RWMutex
 	lockUpdateTask                      sync.RWMutex
 	lockUpdateTaskState                 sync.RWMutex
+	lockUpdateWebhook                   sync.RWMutex
+	lockUpdateWebhookDelivery           sync.RWMutex
+	lockWatchWork                       sync.RWMutex
+}
+
+// AddJobsToReleaseGroup calls AddJobsToReleaseGroupFunc.
+func (mock *MongoDBMock) AddJobsToReleaseGroup(ctx context.Context, groupID string, jobNumbers []int, states []domain.State) (int, error) {
+	if mock.AddJobsToReleaseGroupFunc == nil {
+		panic("MongoDBMock.AddJobsToReleaseGroupFunc: method is nil but MongoDB.AddJobsToReleaseGroup was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		GroupID    string
+		JobNumbers []int
+		States     []domain.State
+	}{
+		Ctx:        ctx,
+		GroupID:    groupID,
+		JobNumbers: jobNumbers,
+		States:     states,
+	}
+	mock.lockAddJobsToReleaseGroup.Lock()
+	mock.calls.AddJobsToReleaseGroup = append(mock.calls.AddJobsToReleaseGroup, callInfo)
+	mock.lockAddJobsToReleaseGroup.Unlock()
+	return mock.AddJobsToReleaseGroupFunc(ctx, groupID, jobNumbers, states)
+}
+
+// AddJobsToReleaseGroupCalls gets all the calls that were made to AddJobsToReleaseGroup.
+// Check the length with:
+//
+//	len(mockedMongoDB.AddJobsToReleaseGroupCalls())
+func (mock *MongoDBMock) AddJobsToReleaseGroupCalls() []struct {
+	Ctx        context.Context
+	GroupID    string
+	JobNumbers []int
+	States     []domain.State
+} {
+	var calls []struct {
+		Ctx        context.Context
+		GroupID    string
+		JobNumbers []int
+		States     []domain.State
+	}
+	mock.lockAddJobsToReleaseGroup.RLock()
+	calls = mock.calls.AddJobsToReleaseGroup
+	mock.lockAddJobsToReleaseGroup.RUnlock()
+	return calls
+}
+
+// ApproveJob calls ApproveJobFunc.
+func (mock *MongoDBMock) ApproveJob(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error) {
+	if mock.ApproveJobFunc == nil {
+		panic("MongoDBMock.ApproveJobFunc: method is nil but MongoDB.ApproveJob was just called")
+	}
+	callInfo := struct {
+		Ctx               context.Context
+		JobID             string
+		Approval          *domain.Approval
+		RequiredApprovals int
+		PublishAt         *time.Time
+	}{
+		Ctx:               ctx,
+		JobID:             jobID,
+		Approval:          approval,
+		RequiredApprovals: requiredApprovals,
+		PublishAt:         publishAt,
+	}
+	mock.lockApproveJob.Lock()
+	mock.calls.ApproveJob = append(mock.calls.ApproveJob, callInfo)
+	mock.lockApproveJob.Unlock()
+	return mock.ApproveJobFunc(ctx, jobID, approval, requiredApprovals, publishAt)
+}
+
+// ApproveJobCalls gets all the calls that were made to ApproveJob.
+// Check the length with:
+//
+//	len(mockedMongoDB.ApproveJobCalls())
+func (mock *MongoDBMock) ApproveJobCalls() []struct {
+	Ctx               context.Context
+	JobID             string
+	Approval          *domain.Approval
+	RequiredApprovals int
+	PublishAt         *time.Time
+} {
+	var calls []struct {
+		Ctx               context.Context
+		JobID             string
+		Approval          *domain.Approval
+		RequiredApprovals int
+		PublishAt         *time.Time
+	}
+	mock.lockApproveJob.RLock()
+	calls = mock.calls.ApproveJob
+	mock.lockApproveJob.RUnlock()
+	return calls
 }
 
 // Checker calls CheckerFunc.
@@ -398,6 +1154,42 @@ func (mock *MongoDBMock) CheckerCalls() []struct {
 	return calls
 }
 
+// ClaimDigestRun calls ClaimDigestRunFunc.
+func (mock *MongoDBMock) ClaimDigestRun(ctx context.Context, run *domain.DigestRun) error {
+	if mock.ClaimDigestRunFunc == nil {
+		panic("MongoDBMock.ClaimDigestRunFunc: method is nil but MongoDB.ClaimDigestRun was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Run *domain.DigestRun
+	}{
+		Ctx: ctx,
+		Run: run,
+	}
+	mock.lockClaimDigestRun.Lock()
+	mock.calls.ClaimDigestRun = append(mock.calls.ClaimDigestRun, callInfo)
+	mock.lockClaimDigestRun.Unlock()
+	return mock.ClaimDigestRunFunc(ctx, run)
+}
+
+// ClaimDigestRunCalls gets all the calls that were made to ClaimDigestRun.
+// Check the length with:
+//
+//	len(mockedMongoDB.ClaimDigestRunCalls())
+func (mock *MongoDBMock) ClaimDigestRunCalls() []struct {
+	Ctx context.Context
+	Run *domain.DigestRun
+} {
+	var calls []struct {
+		Ctx context.Context
+		Run *domain.DigestRun
+	}
+	mock.lockClaimDigestRun.RLock()
+	calls = mock.calls.ClaimDigestRun
+	mock.lockClaimDigestRun.RUnlock()
+	return calls
+}
+
 // ClaimJob calls ClaimJobFunc.
 func (mock *MongoDBMock) ClaimJob(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Job, error) {
 	if mock.ClaimJobFunc == nil {
@@ -439,23 +1231,31 @@ func (mock *MongoDBMock) ClaimJobCalls() []struct {
 }
 
 // ClaimTask calls ClaimTaskFunc.
-func (mock *MongoDBMock) ClaimTask(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Task, error) {
+func (mock *MongoDBMock) ClaimTask(ctx context.Context, pendingState domain.State, activeState domain.State, dependencyState domain.State, afterJobNumber int, excludedTypes []domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
 	if mock.ClaimTaskFunc == nil {
 		panic("MongoDBMock.ClaimTaskFunc: method is nil but MongoDB.ClaimTask was just called")
 	}
 	callInfo := struct {
-		Ctx          context.Context
-		PendingState domain.State
-		ActiveState  domain.State
+		Ctx                context.Context
+		PendingState       domain.State
+		ActiveState        domain.State
+		DependencyState    domain.State
+		AfterJobNumber     int
+		ExcludedTypes      []domain.TaskType
+		ExcludedJobNumbers []int
 	}{
-		Ctx:          ctx,
-		PendingState: pendingState,
-		ActiveState:  activeState,
+		Ctx:                ctx,
+		PendingState:       pendingState,
+		ActiveState:        activeState,
+		DependencyState:    dependencyState,
+		AfterJobNumber:     afterJobNumber,
+		ExcludedTypes:      excludedTypes,
+		ExcludedJobNumbers: excludedJobNumbers,
 	}
 	mock.lockClaimTask.Lock()
 	mock.calls.ClaimTask = append(mock.calls.ClaimTask, callInfo)
 	mock.lockClaimTask.Unlock()
-	return mock.ClaimTaskFunc(ctx, pendingState, activeState)
+	return mock.ClaimTaskFunc(ctx, pendingState, activeState, dependencyState, afterJobNumber, excludedTypes, excludedJobNumbers)
 }
 
 // ClaimTaskCalls gets all the calls that were made to ClaimTask.
@@ -463,14 +1263,22 @@ func (mock *MongoDBMock) ClaimTask(ctx context.Context, pendingState domain.Stat
 //
 //	len(mockedMongoDB.ClaimTaskCalls())
 func (mock *MongoDBMock) ClaimTaskCalls() []struct {
-	Ctx          context.Context
-	PendingState domain.State
-	ActiveState  domain.State
+	Ctx                context.Context
+	PendingState       domain.State
+	ActiveState        domain.State
+	DependencyState    domain.State
+	AfterJobNumber     int
+	ExcludedTypes      []domain.TaskType
+	ExcludedJobNumbers []int
 } {
 	var calls []struct {
-		Ctx          context.Context
-		PendingState domain.State
-		ActiveState  domain.State
+		Ctx                context.Context
+		PendingState       domain.State
+		ActiveState        domain.State
+		DependencyState    domain.State
+		AfterJobNumber     int
+		ExcludedTypes      []domain.TaskType
+		ExcludedJobNumbers []int
 	}
 	mock.lockClaimTask.RLock()
 	calls = mock.calls.ClaimTask
@@ -478,6 +1286,42 @@ func (mock *MongoDBMock) ClaimTaskCalls() []struct {
 	return calls
 }
 
+// ClaimWebhookDelivery calls ClaimWebhookDeliveryFunc.
+func (mock *MongoDBMock) ClaimWebhookDelivery(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error) {
+	if mock.ClaimWebhookDeliveryFunc == nil {
+		panic("MongoDBMock.ClaimWebhookDeliveryFunc: method is nil but MongoDB.ClaimWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		LeaseUntil time.Time
+	}{
+		Ctx:        ctx,
+		LeaseUntil: leaseUntil,
+	}
+	mock.lockClaimWebhookDelivery.Lock()
+	mock.calls.ClaimWebhookDelivery = append(mock.calls.ClaimWebhookDelivery, callInfo)
+	mock.lockClaimWebhookDelivery.Unlock()
+	return mock.ClaimWebhookDeliveryFunc(ctx, leaseUntil)
+}
+
+// ClaimWebhookDeliveryCalls gets all the calls that were made to ClaimWebhookDelivery.
+// Check the length with:
+//
+//	len(mockedMongoDB.ClaimWebhookDeliveryCalls())
+func (mock *MongoDBMock) ClaimWebhookDeliveryCalls() []struct {
+	Ctx        context.Context
+	LeaseUntil time.Time
+} {
+	var calls []struct {
+		Ctx        context.Context
+		LeaseUntil time.Time
+	}
+	mock.lockClaimWebhookDelivery.RLock()
+	calls = mock.calls.ClaimWebhookDelivery
+	mock.lockClaimWebhookDelivery.RUnlock()
+	return calls
+}
+
 // Close calls CloseFunc.
 func (mock *MongoDBMock) Close(contextMoqParam context.Context) error {
 	if mock.CloseFunc == nil {
@@ -510,6 +1354,46 @@ func (mock *MongoDBMock) CloseCalls() []struct {
 	return calls
 }
 
+// CompleteIdempotencyRecord calls CompleteIdempotencyRecordFunc.
+func (mock *MongoDBMock) CompleteIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord, job *domain.Job) error {
+	if mock.CompleteIdempotencyRecordFunc == nil {
+		panic("MongoDBMock.CompleteIdempotencyRecordFunc: method is nil but MongoDB.CompleteIdempotencyRecord was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Record *domain.IdempotencyRecord
+		Job    *domain.Job
+	}{
+		Ctx:    ctx,
+		Record: record,
+		Job:    job,
+	}
+	mock.lockCompleteIdempotencyRecord.Lock()
+	mock.calls.CompleteIdempotencyRecord = append(mock.calls.CompleteIdempotencyRecord, callInfo)
+	mock.lockCompleteIdempotencyRecord.Unlock()
+	return mock.CompleteIdempotencyRecordFunc(ctx, record, job)
+}
+
+// CompleteIdempotencyRecordCalls gets all the calls that were made to CompleteIdempotencyRecord.
+// Check the length with:
+//
+//	len(mockedMongoDB.CompleteIdempotencyRecordCalls())
+func (mock *MongoDBMock) CompleteIdempotencyRecordCalls() []struct {
+	Ctx    context.Context
+	Record *domain.IdempotencyRecord
+	Job    *domain.Job
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Record *domain.IdempotencyRecord
+		Job    *domain.Job
+	}
+	mock.lockCompleteIdempotencyRecord.RLock()
+	calls = mock.calls.CompleteIdempotencyRecord
+	mock.lockCompleteIdempotencyRecord.RUnlock()
+	return calls
+}
+
 // CountEventsByJobNumber calls CountEventsByJobNumberFunc.
 func (mock *MongoDBMock) CountEventsByJobNumber(ctx context.Context, jobNumber int) (int, error) {
 	if mock.CountEventsByJobNumberFunc == nil {
@@ -582,6 +1466,78 @@ func (mock *MongoDBMock) CountTasksByJobNumberCalls() []struct {
 	return calls
 }
 
+// CreateBatch calls CreateBatchFunc.
+func (mock *MongoDBMock) CreateBatch(ctx context.Context, batch *domain.Batch) error {
+	if mock.CreateBatchFunc == nil {
+		panic("MongoDBMock.CreateBatchFunc: method is nil but MongoDB.CreateBatch was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Batch *domain.Batch
+	}{
+		Ctx:   ctx,
+		Batch: batch,
+	}
+	mock.lockCreateBatch.Lock()
+	mock.calls.CreateBatch = append(mock.calls.CreateBatch, callInfo)
+	mock.lockCreateBatch.Unlock()
+	return mock.CreateBatchFunc(ctx, batch)
+}
+
+// CreateBatchCalls gets all the calls that were made to CreateBatch.
+// Check the length with:
+//
+//	len(mockedMongoDB.CreateBatchCalls())
+func (mock *MongoDBMock) CreateBatchCalls() []struct {
+	Ctx   context.Context
+	Batch *domain.Batch
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Batch *domain.Batch
+	}
+	mock.lockCreateBatch.RLock()
+	calls = mock.calls.CreateBatch
+	mock.lockCreateBatch.RUnlock()
+	return calls
+}
+
+// CreateComment calls CreateCommentFunc.
+func (mock *MongoDBMock) CreateComment(ctx context.Context, comment *domain.Comment) error {
+	if mock.CreateCommentFunc == nil {
+		panic("MongoDBMock.CreateCommentFunc: method is nil but MongoDB.CreateComment was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Comment *domain.Comment
+	}{
+		Ctx:     ctx,
+		Comment: comment,
+	}
+	mock.lockCreateComment.Lock()
+	mock.calls.CreateComment = append(mock.calls.CreateComment, callInfo)
+	mock.lockCreateComment.Unlock()
+	return mock.CreateCommentFunc(ctx, comment)
+}
+
+// CreateCommentCalls gets all the calls that were made to CreateComment.
+// Check the length with:
+//
+//	len(mockedMongoDB.CreateCommentCalls())
+func (mock *MongoDBMock) CreateCommentCalls() []struct {
+	Ctx     context.Context
+	Comment *domain.Comment
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Comment *domain.Comment
+	}
+	mock.lockCreateComment.RLock()
+	calls = mock.calls.CreateComment
+	mock.lockCreateComment.RUnlock()
+	return calls
+}
+
 // CreateEvent calls CreateEventFunc.
 func (mock *MongoDBMock) CreateEvent(ctx context.Context, event *domain.Event) error {
 	if mock.CreateEventFunc == nil {
@@ -618,6 +1574,42 @@ func (mock *MongoDBMock) CreateEventCalls() []struct {
 	return calls
 }
 
+// CreateIdempotencyRecord calls CreateIdempotencyRecordFunc.
+func (mock *MongoDBMock) CreateIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error {
+	if mock.CreateIdempotencyRecordFunc == nil {
+		panic("MongoDBMock.CreateIdempotencyRecordFunc: method is nil but MongoDB.CreateIdempotencyRecord was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Record *domain.IdempotencyRecord
+	}{
+		Ctx:    ctx,
+		Record: record,
+	}
+	mock.lockCreateIdempotencyRecord.Lock()
+	mock.calls.CreateIdempotencyRecord = append(mock.calls.CreateIdempotencyRecord, callInfo)
+	mock.lockCreateIdempotencyRecord.Unlock()
+	return mock.CreateIdempotencyRecordFunc(ctx, record)
+}
+
+// CreateIdempotencyRecordCalls gets all the calls that were made to CreateIdempotencyRecord.
+// Check the length with:
+//
+//	len(mockedMongoDB.CreateIdempotencyRecordCalls())
+func (mock *MongoDBMock) CreateIdempotencyRecordCalls() []struct {
+	Ctx    context.Context
+	Record *domain.IdempotencyRecord
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Record *domain.IdempotencyRecord
+	}
+	mock.lockCreateIdempotencyRecord.RLock()
+	calls = mock.calls.CreateIdempotencyRecord
+	mock.lockCreateIdempotencyRecord.RUnlock()
+	return calls
+}
+
 // CreateJob calls CreateJobFunc.
 func (mock *MongoDBMock) CreateJob(ctx context.Context, job *domain.Job) error {
 	if mock.CreateJobFunc == nil {
@@ -654,6 +1646,42 @@ func (mock *MongoDBMock) CreateJobCalls() []struct {
 	return calls
 }
 
+// CreateReleaseGroup calls CreateReleaseGroupFunc.
+func (mock *MongoDBMock) CreateReleaseGroup(ctx context.Context, group *domain.ReleaseGroup) error {
+	if mock.CreateReleaseGroupFunc == nil {
+		panic("MongoDBMock.CreateReleaseGroupFunc: method is nil but MongoDB.CreateReleaseGroup was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Group *domain.ReleaseGroup
+	}{
+		Ctx:   ctx,
+		Group: group,
+	}
+	mock.lockCreateReleaseGroup.Lock()
+	mock.calls.CreateReleaseGroup = append(mock.calls.CreateReleaseGroup, callInfo)
+	mock.lockCreateReleaseGroup.Unlock()
+	return mock.CreateReleaseGroupFunc(ctx, group)
+}
+
+// CreateReleaseGroupCalls gets all the calls that were made to CreateReleaseGroup.
+// Check the length with:
+//
+//	len(mockedMongoDB.CreateReleaseGroupCalls())
+func (mock *MongoDBMock) CreateReleaseGroupCalls() []struct {
+	Ctx   context.Context
+	Group *domain.ReleaseGroup
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Group *domain.ReleaseGroup
+	}
+	mock.lockCreateReleaseGroup.RLock()
+	calls = mock.calls.CreateReleaseGroup
+	mock.lockCreateReleaseGroup.RUnlock()
+	return calls
+}
+
 // CreateTask calls CreateTaskFunc.
 func (mock *MongoDBMock) CreateTask(ctx context.Context, task *domain.Task) error {
 	if mock.CreateTaskFunc == nil {
@@ -690,168 +1718,676 @@ func (mock *MongoDBMock) CreateTaskCalls() []struct {
 	return calls
 }
 
-// GetJob calls GetJobFunc.
-func (mock *MongoDBMock) GetJob(ctx context.Context, jobNumber int) (*domain.Job, error) {
-	if mock.GetJobFunc == nil {
-		panic("MongoDBMock.GetJobFunc: method is nil but MongoDB.GetJob was just called")
+// CreateWebhook calls CreateWebhookFunc.
+func (mock *MongoDBMock) CreateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	if mock.CreateWebhookFunc == nil {
+		panic("MongoDBMock.CreateWebhookFunc: method is nil but MongoDB.CreateWebhook was just called")
 	}
 	callInfo := struct {
-		Ctx       context.Context
-		JobNumber int
+		Ctx     context.Context
+		Webhook *domain.Webhook
 	}{
-		Ctx:       ctx,
-		JobNumber: jobNumber,
+		Ctx:     ctx,
+		Webhook: webhook,
 	}
-	mock.lockGetJob.Lock()
-	mock.calls.GetJob = append(mock.calls.GetJob, callInfo)
-	mock.lockGetJob.Unlock()
-	return mock.GetJobFunc(ctx, jobNumber)
+	mock.lockCreateWebhook.Lock()
+	mock.calls.CreateWebhook = append(mock.calls.CreateWebhook, callInfo)
+	mock.lockCreateWebhook.Unlock()
+	return mock.CreateWebhookFunc(ctx, webhook)
 }
 
-// GetJobCalls gets all the calls that were made to GetJob.
+// CreateWebhookCalls gets all the calls that were made to CreateWebhook.
 // Check the length with:
 //
-//	len(mockedMongoDB.GetJobCalls())
-func (mock *MongoDBMock) GetJobCalls() []struct {
-	Ctx       context.Context
-	JobNumber int
+//	len(mockedMongoDB.CreateWebhookCalls())
+func (mock *MongoDBMock) CreateWebhookCalls() []struct {
+	Ctx     context.Context
+	Webhook *domain.Webhook
 } {
 	var calls []struct {
-		Ctx       context.Context
-		JobNumber int
+		Ctx     context.Context
+		Webhook *domain.Webhook
 	}
-	mock.lockGetJob.RLock()
-	calls = mock.calls.GetJob
-	mock.lockGetJob.RUnlock()
+	mock.lockCreateWebhook.RLock()
+	calls = mock.calls.CreateWebhook
+	mock.lockCreateWebhook.RUnlock()
 	return calls
 }
 
-// GetJobEvents calls GetJobEventsFunc.
-func (mock *MongoDBMock) GetJobEvents(ctx context.Context, jobNumber int, limit int, offset int) ([]*domain.Event, int, error) {
-	if mock.GetJobEventsFunc == nil {
-		panic("MongoDBMock.GetJobEventsFunc: method is nil but MongoDB.GetJobEvents was just called")
+// CreateWebhookDelivery calls CreateWebhookDeliveryFunc.
+func (mock *MongoDBMock) CreateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if mock.CreateWebhookDeliveryFunc == nil {
+		panic("MongoDBMock.CreateWebhookDeliveryFunc: method is nil but MongoDB.CreateWebhookDelivery was just called")
 	}
 	callInfo := struct {
-		Ctx       context.Context
-		JobNumber int
-		Limit     int
-		Offset    int
+		Ctx      context.Context
+		Delivery *domain.WebhookDelivery
 	}{
-		Ctx:       ctx,
-		JobNumber: jobNumber,
-		Limit:     limit,
-		Offset:    offset,
+		Ctx:      ctx,
+		Delivery: delivery,
 	}
-	mock.lockGetJobEvents.Lock()
-	mock.calls.GetJobEvents = append(mock.calls.GetJobEvents, callInfo)
-	mock.lockGetJobEvents.Unlock()
-	return mock.GetJobEventsFunc(ctx, jobNumber, limit, offset)
+	mock.lockCreateWebhookDelivery.Lock()
+	mock.calls.CreateWebhookDelivery = append(mock.calls.CreateWebhookDelivery, callInfo)
+	mock.lockCreateWebhookDelivery.Unlock()
+	return mock.CreateWebhookDeliveryFunc(ctx, delivery)
 }
 
-// GetJobEventsCalls gets all the calls that were made to GetJobEvents.
+// CreateWebhookDeliveryCalls gets all the calls that were made to CreateWebhookDelivery.
 // Check the length with:
 //
-//	len(mockedMongoDB.GetJobEventsCalls())
-func (mock *MongoDBMock) GetJobEventsCalls() []struct {
-	Ctx       context.Context
-	JobNumber int
-	Limit     int
-	Offset    int
+//	len(mockedMongoDB.CreateWebhookDeliveryCalls())
+func (mock *MongoDBMock) CreateWebhookDeliveryCalls() []struct {
+	Ctx      context.Context
+	Delivery *domain.WebhookDelivery
 } {
 	var calls []struct {
-		Ctx       context.Context
-		JobNumber int
-		Limit     int
-		Offset    int
+		Ctx      context.Context
+		Delivery *domain.WebhookDelivery
 	}
-	mock.lockGetJobEvents.RLock()
-	calls = mock.calls.GetJobEvents
-	mock.lockGetJobEvents.RUnlock()
+	mock.lockCreateWebhookDelivery.RLock()
+	calls = mock.calls.CreateWebhookDelivery
+	mock.lockCreateWebhookDelivery.RUnlock()
 	return calls
 }
 
-// GetJobStateCounts calls GetJobStateCountsFunc.
-func (mock *MongoDBMock) GetJobStateCounts(ctx context.Context) ([]mongo.StateCountResult, error) {
-	if mock.GetJobStateCountsFunc == nil {
-		panic("MongoDBMock.GetJobStateCountsFunc: method is nil but MongoDB.GetJobStateCounts was just called")
+// DeleteIdempotencyRecord calls DeleteIdempotencyRecordFunc.
+func (mock *MongoDBMock) DeleteIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error {
+	if mock.DeleteIdempotencyRecordFunc == nil {
+		panic("MongoDBMock.DeleteIdempotencyRecordFunc: method is nil but MongoDB.DeleteIdempotencyRecord was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx    context.Context
+		Record *domain.IdempotencyRecord
 	}{
-		Ctx: ctx,
+		Ctx:    ctx,
+		Record: record,
 	}
-	mock.lockGetJobStateCounts.Lock()
-	mock.calls.GetJobStateCounts = append(mock.calls.GetJobStateCounts, callInfo)
-	mock.lockGetJobStateCounts.Unlock()
-	return mock.GetJobStateCountsFunc(ctx)
+	mock.lockDeleteIdempotencyRecord.Lock()
+	mock.calls.DeleteIdempotencyRecord = append(mock.calls.DeleteIdempotencyRecord, callInfo)
+	mock.lockDeleteIdempotencyRecord.Unlock()
+	return mock.DeleteIdempotencyRecordFunc(ctx, record)
 }
 
-// GetJobStateCountsCalls gets all the calls that were made to GetJobStateCounts.
+// DeleteIdempotencyRecordCalls gets all the calls that were made to DeleteIdempotencyRecord.
 // Check the length with:
 //
-//	len(mockedMongoDB.GetJobStateCountsCalls())
-func (mock *MongoDBMock) GetJobStateCountsCalls() []struct {
-	Ctx context.Context
+//	len(mockedMongoDB.DeleteIdempotencyRecordCalls())
+func (mock *MongoDBMock) DeleteIdempotencyRecordCalls() []struct {
+	Ctx    context.Context
+	Record *domain.IdempotencyRecord
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx    context.Context
+		Record *domain.IdempotencyRecord
 	}
-	mock.lockGetJobStateCounts.RLock()
-	calls = mock.calls.GetJobStateCounts
-	mock.lockGetJobStateCounts.RUnlock()
+	mock.lockDeleteIdempotencyRecord.RLock()
+	calls = mock.calls.DeleteIdempotencyRecord
+	mock.lockDeleteIdempotencyRecord.RUnlock()
 	return calls
 }
 
-// GetJobTasks calls GetJobTasksFunc.
-func (mock *MongoDBMock) GetJobTasks(ctx context.Context, states []domain.State, jobNumber int, limit int, offset int) ([]*domain.Task, int, error) {
-	if mock.GetJobTasksFunc == nil {
-		panic("MongoDBMock.GetJobTasksFunc: method is nil but MongoDB.GetJobTasks was just called")
+// DeleteReleaseGroup calls DeleteReleaseGroupFunc.
+func (mock *MongoDBMock) DeleteReleaseGroup(ctx context.Context, groupID string) error {
+	if mock.DeleteReleaseGroupFunc == nil {
+		panic("MongoDBMock.DeleteReleaseGroupFunc: method is nil but MongoDB.DeleteReleaseGroup was just called")
 	}
 	callInfo := struct {
-		Ctx       context.Context
-		States    []domain.State
-		JobNumber int
-		Limit     int
-		Offset    int
+		Ctx     context.Context
+		GroupID string
 	}{
-		Ctx:       ctx,
-		States:    states,
-		JobNumber: jobNumber,
-		Limit:     limit,
-		Offset:    offset,
+		Ctx:     ctx,
+		GroupID: groupID,
 	}
-	mock.lockGetJobTasks.Lock()
-	mock.calls.GetJobTasks = append(mock.calls.GetJobTasks, callInfo)
-	mock.lockGetJobTasks.Unlock()
-	return mock.GetJobTasksFunc(ctx, states, jobNumber, limit, offset)
+	mock.lockDeleteReleaseGroup.Lock()
+	mock.calls.DeleteReleaseGroup = append(mock.calls.DeleteReleaseGroup, callInfo)
+	mock.lockDeleteReleaseGroup.Unlock()
+	return mock.DeleteReleaseGroupFunc(ctx, groupID)
 }
 
-// GetJobTasksCalls gets all the calls that were made to GetJobTasks.
+// DeleteReleaseGroupCalls gets all the calls that were made to DeleteReleaseGroup.
 // Check the length with:
 //
-//	len(mockedMongoDB.GetJobTasksCalls())
-func (mock *MongoDBMock) GetJobTasksCalls() []struct {
-	Ctx       context.Context
-	States    []domain.State
-	JobNumber int
-	Limit     int
-	Offset    int
+//	len(mockedMongoDB.DeleteReleaseGroupCalls())
+func (mock *MongoDBMock) DeleteReleaseGroupCalls() []struct {
+	Ctx     context.Context
+	GroupID string
 } {
 	var calls []struct {
-		Ctx       context.Context
-		States    []domain.State
-		JobNumber int
-		Limit     int
+		Ctx     context.Context
+		GroupID string
+	}
+	mock.lockDeleteReleaseGroup.RLock()
+	calls = mock.calls.DeleteReleaseGroup
+	mock.lockDeleteReleaseGroup.RUnlock()
+	return calls
+}
+
+// DeleteWebhook calls DeleteWebhookFunc.
+func (mock *MongoDBMock) DeleteWebhook(ctx context.Context, webhookID string) error {
+	if mock.DeleteWebhookFunc == nil {
+		panic("MongoDBMock.DeleteWebhookFunc: method is nil but MongoDB.DeleteWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		WebhookID string
+	}{
+		Ctx:       ctx,
+		WebhookID: webhookID,
+	}
+	mock.lockDeleteWebhook.Lock()
+	mock.calls.DeleteWebhook = append(mock.calls.DeleteWebhook, callInfo)
+	mock.lockDeleteWebhook.Unlock()
+	return mock.DeleteWebhookFunc(ctx, webhookID)
+}
+
+// DeleteWebhookCalls gets all the calls that were made to DeleteWebhook.
+// Check the length with:
+//
+//	len(mockedMongoDB.DeleteWebhookCalls())
+func (mock *MongoDBMock) DeleteWebhookCalls() []struct {
+	Ctx       context.Context
+	WebhookID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		WebhookID string
+	}
+	mock.lockDeleteWebhook.RLock()
+	calls = mock.calls.DeleteWebhook
+	mock.lockDeleteWebhook.RUnlock()
+	return calls
+}
+
+// GetBatch calls GetBatchFunc.
+func (mock *MongoDBMock) GetBatch(ctx context.Context, batchID string) (*domain.Batch, error) {
+	if mock.GetBatchFunc == nil {
+		panic("MongoDBMock.GetBatchFunc: method is nil but MongoDB.GetBatch was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		BatchID string
+	}{
+		Ctx:     ctx,
+		BatchID: batchID,
+	}
+	mock.lockGetBatch.Lock()
+	mock.calls.GetBatch = append(mock.calls.GetBatch, callInfo)
+	mock.lockGetBatch.Unlock()
+	return mock.GetBatchFunc(ctx, batchID)
+}
+
+// GetBatchCalls gets all the calls that were made to GetBatch.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetBatchCalls())
+func (mock *MongoDBMock) GetBatchCalls() []struct {
+	Ctx     context.Context
+	BatchID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		BatchID string
+	}
+	mock.lockGetBatch.RLock()
+	calls = mock.calls.GetBatch
+	mock.lockGetBatch.RUnlock()
+	return calls
+}
+
+// GetBatchJobStateCounts calls GetBatchJobStateCountsFunc.
+func (mock *MongoDBMock) GetBatchJobStateCounts(ctx context.Context, batchID string) ([]mongo.StateCountResult, error) {
+	if mock.GetBatchJobStateCountsFunc == nil {
+		panic("MongoDBMock.GetBatchJobStateCountsFunc: method is nil but MongoDB.GetBatchJobStateCounts was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		BatchID string
+	}{
+		Ctx:     ctx,
+		BatchID: batchID,
+	}
+	mock.lockGetBatchJobStateCounts.Lock()
+	mock.calls.GetBatchJobStateCounts = append(mock.calls.GetBatchJobStateCounts, callInfo)
+	mock.lockGetBatchJobStateCounts.Unlock()
+	return mock.GetBatchJobStateCountsFunc(ctx, batchID)
+}
+
+// GetBatchJobStateCountsCalls gets all the calls that were made to GetBatchJobStateCounts.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetBatchJobStateCountsCalls())
+func (mock *MongoDBMock) GetBatchJobStateCountsCalls() []struct {
+	Ctx     context.Context
+	BatchID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		BatchID string
+	}
+	mock.lockGetBatchJobStateCounts.RLock()
+	calls = mock.calls.GetBatchJobStateCounts
+	mock.lockGetBatchJobStateCounts.RUnlock()
+	return calls
+}
+
+// GetDependentTasks calls GetDependentTasksFunc.
+func (mock *MongoDBMock) GetDependentTasks(ctx context.Context, taskID string) ([]*domain.Task, error) {
+	if mock.GetDependentTasksFunc == nil {
+		panic("MongoDBMock.GetDependentTasksFunc: method is nil but MongoDB.GetDependentTasks was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		TaskID string
+	}{
+		Ctx:    ctx,
+		TaskID: taskID,
+	}
+	mock.lockGetDependentTasks.Lock()
+	mock.calls.GetDependentTasks = append(mock.calls.GetDependentTasks, callInfo)
+	mock.lockGetDependentTasks.Unlock()
+	return mock.GetDependentTasksFunc(ctx, taskID)
+}
+
+// GetDependentTasksCalls gets all the calls that were made to GetDependentTasks.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetDependentTasksCalls())
+func (mock *MongoDBMock) GetDependentTasksCalls() []struct {
+	Ctx    context.Context
+	TaskID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		TaskID string
+	}
+	mock.lockGetDependentTasks.RLock()
+	calls = mock.calls.GetDependentTasks
+	mock.lockGetDependentTasks.RUnlock()
+	return calls
+}
+
+// GetIdempotencyRecord calls GetIdempotencyRecordFunc.
+func (mock *MongoDBMock) GetIdempotencyRecord(ctx context.Context, id string) (*domain.IdempotencyRecord, error) {
+	if mock.GetIdempotencyRecordFunc == nil {
+		panic("MongoDBMock.GetIdempotencyRecordFunc: method is nil but MongoDB.GetIdempotencyRecord was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Id  string
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	mock.lockGetIdempotencyRecord.Lock()
+	mock.calls.GetIdempotencyRecord = append(mock.calls.GetIdempotencyRecord, callInfo)
+	mock.lockGetIdempotencyRecord.Unlock()
+	return mock.GetIdempotencyRecordFunc(ctx, id)
+}
+
+// GetIdempotencyRecordCalls gets all the calls that were made to GetIdempotencyRecord.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetIdempotencyRecordCalls())
+func (mock *MongoDBMock) GetIdempotencyRecordCalls() []struct {
+	Ctx context.Context
+	Id  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Id  string
+	}
+	mock.lockGetIdempotencyRecord.RLock()
+	calls = mock.calls.GetIdempotencyRecord
+	mock.lockGetIdempotencyRecord.RUnlock()
+	return calls
+}
+
+// GetJob calls GetJobFunc.
+func (mock *MongoDBMock) GetJob(ctx context.Context, jobNumber int) (*domain.Job, error) {
+	if mock.GetJobFunc == nil {
+		panic("MongoDBMock.GetJobFunc: method is nil but MongoDB.GetJob was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+	}
+	mock.lockGetJob.Lock()
+	mock.calls.GetJob = append(mock.calls.GetJob, callInfo)
+	mock.lockGetJob.Unlock()
+	return mock.GetJobFunc(ctx, jobNumber)
+}
+
+// GetJobCalls gets all the calls that were made to GetJob.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetJobCalls())
+func (mock *MongoDBMock) GetJobCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+	}
+	mock.lockGetJob.RLock()
+	calls = mock.calls.GetJob
+	mock.lockGetJob.RUnlock()
+	return calls
+}
+
+// GetJobComments calls GetJobCommentsFunc.
+func (mock *MongoDBMock) GetJobComments(ctx context.Context, jobNumber int, limit int, offset int) ([]*domain.Comment, int, error) {
+	if mock.GetJobCommentsFunc == nil {
+		panic("MongoDBMock.GetJobCommentsFunc: method is nil but MongoDB.GetJobComments was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+		Limit     int
+		Offset    int
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	mock.lockGetJobComments.Lock()
+	mock.calls.GetJobComments = append(mock.calls.GetJobComments, callInfo)
+	mock.lockGetJobComments.Unlock()
+	return mock.GetJobCommentsFunc(ctx, jobNumber, limit, offset)
+}
+
+// GetJobCommentsCalls gets all the calls that were made to GetJobComments.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetJobCommentsCalls())
+func (mock *MongoDBMock) GetJobCommentsCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+	Limit     int
+	Offset    int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+		Limit     int
+		Offset    int
+	}
+	mock.lockGetJobComments.RLock()
+	calls = mock.calls.GetJobComments
+	mock.lockGetJobComments.RUnlock()
+	return calls
+}
+
+// GetJobEventStateCounts calls GetJobEventStateCountsFunc.
+func (mock *MongoDBMock) GetJobEventStateCounts(ctx context.Context, action domain.EventAction, since time.Time) ([]mongo.StateCountResult, error) {
+	if mock.GetJobEventStateCountsFunc == nil {
+		panic("MongoDBMock.GetJobEventStateCountsFunc: method is nil but MongoDB.GetJobEventStateCounts was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Action domain.EventAction
+		Since  time.Time
+	}{
+		Ctx:    ctx,
+		Action: action,
+		Since:  since,
+	}
+	mock.lockGetJobEventStateCounts.Lock()
+	mock.calls.GetJobEventStateCounts = append(mock.calls.GetJobEventStateCounts, callInfo)
+	mock.lockGetJobEventStateCounts.Unlock()
+	return mock.GetJobEventStateCountsFunc(ctx, action, since)
+}
+
+// GetJobEventStateCountsCalls gets all the calls that were made to GetJobEventStateCounts.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetJobEventStateCountsCalls())
+func (mock *MongoDBMock) GetJobEventStateCountsCalls() []struct {
+	Ctx    context.Context
+	Action domain.EventAction
+	Since  time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Action domain.EventAction
+		Since  time.Time
+	}
+	mock.lockGetJobEventStateCounts.RLock()
+	calls = mock.calls.GetJobEventStateCounts
+	mock.lockGetJobEventStateCounts.RUnlock()
+	return calls
+}
+
+// GetJobEvents calls GetJobEventsFunc.
+func (mock *MongoDBMock) GetJobEvents(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit int, offset int) ([]*domain.Event, int, error) {
+	if mock.GetJobEventsFunc == nil {
+		panic("MongoDBMock.GetJobEventsFunc: method is nil but MongoDB.GetJobEvents was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+		Filter    *domain.EventFilter
+		Limit     int
+		Offset    int
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+		Filter:    filter,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	mock.lockGetJobEvents.Lock()
+	mock.calls.GetJobEvents = append(mock.calls.GetJobEvents, callInfo)
+	mock.lockGetJobEvents.Unlock()
+	return mock.GetJobEventsFunc(ctx, jobNumber, filter, limit, offset)
+}
+
+// GetJobEventsCalls gets all the calls that were made to GetJobEvents.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetJobEventsCalls())
+func (mock *MongoDBMock) GetJobEventsCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+	Filter    *domain.EventFilter
+	Limit     int
+	Offset    int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+		Filter    *domain.EventFilter
+		Limit     int
+		Offset    int
+	}
+	mock.lockGetJobEvents.RLock()
+	calls = mock.calls.GetJobEvents
+	mock.lockGetJobEvents.RUnlock()
+	return calls
+}
+
+// GetJobEventsAfter calls GetJobEventsAfterFunc.
+func (mock *MongoDBMock) GetJobEventsAfter(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error) {
+	if mock.GetJobEventsAfterFunc == nil {
+		panic("MongoDBMock.GetJobEventsAfterFunc: method is nil but MongoDB.GetJobEventsAfter was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+		Filter    *domain.EventFilter
+		After     *domain.Cursor
+		Limit     int
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+		Filter:    filter,
+		After:     after,
+		Limit:     limit,
+	}
+	mock.lockGetJobEventsAfter.Lock()
+	mock.calls.GetJobEventsAfter = append(mock.calls.GetJobEventsAfter, callInfo)
+	mock.lockGetJobEventsAfter.Unlock()
+	return mock.GetJobEventsAfterFunc(ctx, jobNumber, filter, after, limit)
+}
+
+// GetJobEventsAfterCalls gets all the calls that were made to GetJobEventsAfter.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetJobEventsAfterCalls())
+func (mock *MongoDBMock) GetJobEventsAfterCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+	Filter    *domain.EventFilter
+	After     *domain.Cursor
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+		Filter    *domain.EventFilter
+		After     *domain.Cursor
+		Limit     int
+	}
+	mock.lockGetJobEventsAfter.RLock()
+	calls = mock.calls.GetJobEventsAfter
+	mock.lockGetJobEventsAfter.RUnlock()
+	return calls
+}
+
+// GetJobStateCounts calls GetJobStateCountsFunc.
+func (mock *MongoDBMock) GetJobStateCounts(ctx context.Context) ([]mongo.StateCountResult, error) {
+	if mock.GetJobStateCountsFunc == nil {
+		panic("MongoDBMock.GetJobStateCountsFunc: method is nil but MongoDB.GetJobStateCounts was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetJobStateCounts.Lock()
+	mock.calls.GetJobStateCounts = append(mock.calls.GetJobStateCounts, callInfo)
+	mock.lockGetJobStateCounts.Unlock()
+	return mock.GetJobStateCountsFunc(ctx)
+}
+
+// GetJobStateCountsCalls gets all the calls that were made to GetJobStateCounts.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetJobStateCountsCalls())
+func (mock *MongoDBMock) GetJobStateCountsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetJobStateCounts.RLock()
+	calls = mock.calls.GetJobStateCounts
+	mock.lockGetJobStateCounts.RUnlock()
+	return calls
+}
+
+// GetJobTasks calls GetJobTasksFunc.
+func (mock *MongoDBMock) GetJobTasks(ctx context.Context, states []domain.State, jobNumber int, limit int, offset int) ([]*domain.Task, int, error) {
+	if mock.GetJobTasksFunc == nil {
+		panic("MongoDBMock.GetJobTasksFunc: method is nil but MongoDB.GetJobTasks was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		States    []domain.State
+		JobNumber int
+		Limit     int
 		Offset    int
+	}{
+		Ctx:       ctx,
+		States:    states,
+		JobNumber: jobNumber,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	mock.lockGetJobTasks.Lock()
+	mock.calls.GetJobTasks = append(mock.calls.GetJobTasks, callInfo)
+	mock.lockGetJobTasks.Unlock()
+	return mock.GetJobTasksFunc(ctx, states, jobNumber, limit, offset)
+}
+
+// GetJobTasksCalls gets all the calls that were made to GetJobTasks.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetJobTasksCalls())
+func (mock *MongoDBMock) GetJobTasksCalls() []struct {
+	Ctx       context.Context
+	States    []domain.State
+	JobNumber int
+	Limit     int
+	Offset    int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		States    []domain.State
+		JobNumber int
+		Limit     int
+		Offset    int
+	}
+	mock.lockGetJobTasks.RLock()
+	calls = mock.calls.GetJobTasks
+	mock.lockGetJobTasks.RUnlock()
+	return calls
+}
+
+// GetJobTasksAfter calls GetJobTasksAfterFunc.
+func (mock *MongoDBMock) GetJobTasksAfter(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error) {
+	if mock.GetJobTasksAfterFunc == nil {
+		panic("MongoDBMock.GetJobTasksAfterFunc: method is nil but MongoDB.GetJobTasksAfter was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		States    []domain.State
+		JobNumber int
+		After     *domain.Cursor
+		Limit     int
+	}{
+		Ctx:       ctx,
+		States:    states,
+		JobNumber: jobNumber,
+		After:     after,
+		Limit:     limit,
 	}
-	mock.lockGetJobTasks.RLock()
-	calls = mock.calls.GetJobTasks
-	mock.lockGetJobTasks.RUnlock()
+	mock.lockGetJobTasksAfter.Lock()
+	mock.calls.GetJobTasksAfter = append(mock.calls.GetJobTasksAfter, callInfo)
+	mock.lockGetJobTasksAfter.Unlock()
+	return mock.GetJobTasksAfterFunc(ctx, states, jobNumber, after, limit)
+}
+
+// GetJobTasksAfterCalls gets all the calls that were made to GetJobTasksAfter.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetJobTasksAfterCalls())
+func (mock *MongoDBMock) GetJobTasksAfterCalls() []struct {
+	Ctx       context.Context
+	States    []domain.State
+	JobNumber int
+	After     *domain.Cursor
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		States    []domain.State
+		JobNumber int
+		After     *domain.Cursor
+		Limit     int
+	}
+	mock.lockGetJobTasksAfter.RLock()
+	calls = mock.calls.GetJobTasksAfter
+	mock.lockGetJobTasksAfter.RUnlock()
 	return calls
 }
 
 // GetJobs calls GetJobsFunc.
-func (mock *MongoDBMock) GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit int, offset int) ([]*domain.Job, int, error) {
+func (mock *MongoDBMock) GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit int, offset int) ([]*domain.Job, int, error) {
 	if mock.GetJobsFunc == nil {
 		panic("MongoDBMock.GetJobsFunc: method is nil but MongoDB.GetJobs was just called")
 	}
@@ -859,21 +2395,21 @@ func (mock *MongoDBMock) GetJobs(ctx context.Context, field sort.SortParameterFi
 		Ctx       context.Context
 		Field     sort.SortParameterField
 		Direction sort.SortParameterDirection
-		States    []domain.State
+		Filter    *domain.JobFilter
 		Limit     int
 		Offset    int
 	}{
 		Ctx:       ctx,
 		Field:     field,
 		Direction: direction,
-		States:    states,
+		Filter:    filter,
 		Limit:     limit,
 		Offset:    offset,
 	}
 	mock.lockGetJobs.Lock()
 	mock.calls.GetJobs = append(mock.calls.GetJobs, callInfo)
 	mock.lockGetJobs.Unlock()
-	return mock.GetJobsFunc(ctx, field, direction, states, limit, offset)
+	return mock.GetJobsFunc(ctx, field, direction, filter, limit, offset)
 }
 
 // GetJobsCalls gets all the calls that were made to GetJobs.
@@ -882,139 +2418,619 @@ func (mock *MongoDBMock) GetJobs(ctx context.Context, field sort.SortParameterFi
 //	len(mockedMongoDB.GetJobsCalls())
 func (mock *MongoDBMock) GetJobsCalls() []struct {
 	Ctx       context.Context
-	Field     sort.SortParameterField
-	Direction sort.SortParameterDirection
-	States    []domain.State
+	Field     sort.SortParameterField
+	Direction sort.SortParameterDirection
+	Filter    *domain.JobFilter
+	Limit     int
+	Offset    int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Field     sort.SortParameterField
+		Direction sort.SortParameterDirection
+		Filter    *domain.JobFilter
+		Limit     int
+		Offset    int
+	}
+	mock.lockGetJobs.RLock()
+	calls = mock.calls.GetJobs
+	mock.lockGetJobs.RUnlock()
+	return calls
+}
+
+// GetJobsAfter calls GetJobsAfterFunc.
+func (mock *MongoDBMock) GetJobsAfter(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error) {
+	if mock.GetJobsAfterFunc == nil {
+		panic("MongoDBMock.GetJobsAfterFunc: method is nil but MongoDB.GetJobsAfter was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Field     sort.SortParameterField
+		Direction sort.SortParameterDirection
+		Filter    *domain.JobFilter
+		After     *domain.Cursor
+		Limit     int
+	}{
+		Ctx:       ctx,
+		Field:     field,
+		Direction: direction,
+		Filter:    filter,
+		After:     after,
+		Limit:     limit,
+	}
+	mock.lockGetJobsAfter.Lock()
+	mock.calls.GetJobsAfter = append(mock.calls.GetJobsAfter, callInfo)
+	mock.lockGetJobsAfter.Unlock()
+	return mock.GetJobsAfterFunc(ctx, field, direction, filter, after, limit)
+}
+
+// GetJobsAfterCalls gets all the calls that were made to GetJobsAfter.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetJobsAfterCalls())
+func (mock *MongoDBMock) GetJobsAfterCalls() []struct {
+	Ctx       context.Context
+	Field     sort.SortParameterField
+	Direction sort.SortParameterDirection
+	Filter    *domain.JobFilter
+	After     *domain.Cursor
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Field     sort.SortParameterField
+		Direction sort.SortParameterDirection
+		Filter    *domain.JobFilter
+		After     *domain.Cursor
+		Limit     int
+	}
+	mock.lockGetJobsAfter.RLock()
+	calls = mock.calls.GetJobsAfter
+	mock.lockGetJobsAfter.RUnlock()
+	return calls
+}
+
+// GetJobsBySourceOrTargetAndState calls GetJobsBySourceOrTargetAndStateFunc.
+func (mock *MongoDBMock) GetJobsBySourceOrTargetAndState(ctx context.Context, jc *domain.JobConfig, states []domain.State, limit int, offset int) ([]*domain.Job, error) {
+	if mock.GetJobsBySourceOrTargetAndStateFunc == nil {
+		panic("MongoDBMock.GetJobsBySourceOrTargetAndStateFunc: method is nil but MongoDB.GetJobsBySourceOrTargetAndState was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Jc     *domain.JobConfig
+		States []domain.State
+		Limit  int
+		Offset int
+	}{
+		Ctx:    ctx,
+		Jc:     jc,
+		States: states,
+		Limit:  limit,
+		Offset: offset,
+	}
+	mock.lockGetJobsBySourceOrTargetAndState.Lock()
+	mock.calls.GetJobsBySourceOrTargetAndState = append(mock.calls.GetJobsBySourceOrTargetAndState, callInfo)
+	mock.lockGetJobsBySourceOrTargetAndState.Unlock()
+	return mock.GetJobsBySourceOrTargetAndStateFunc(ctx, jc, states, limit, offset)
+}
+
+// GetJobsBySourceOrTargetAndStateCalls gets all the calls that were made to GetJobsBySourceOrTargetAndState.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetJobsBySourceOrTargetAndStateCalls())
+func (mock *MongoDBMock) GetJobsBySourceOrTargetAndStateCalls() []struct {
+	Ctx    context.Context
+	Jc     *domain.JobConfig
+	States []domain.State
+	Limit  int
+	Offset int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Jc     *domain.JobConfig
+		States []domain.State
+		Limit  int
+		Offset int
+	}
+	mock.lockGetJobsBySourceOrTargetAndState.RLock()
+	calls = mock.calls.GetJobsBySourceOrTargetAndState
+	mock.lockGetJobsBySourceOrTargetAndState.RUnlock()
+	return calls
+}
+
+// GetNextJobNumberCounter calls GetNextJobNumberCounterFunc.
+func (mock *MongoDBMock) GetNextJobNumberCounter(ctx context.Context) (*domain.Counter, error) {
+	if mock.GetNextJobNumberCounterFunc == nil {
+		panic("MongoDBMock.GetNextJobNumberCounterFunc: method is nil but MongoDB.GetNextJobNumberCounter was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetNextJobNumberCounter.Lock()
+	mock.calls.GetNextJobNumberCounter = append(mock.calls.GetNextJobNumberCounter, callInfo)
+	mock.lockGetNextJobNumberCounter.Unlock()
+	return mock.GetNextJobNumberCounterFunc(ctx)
+}
+
+// GetNextJobNumberCounterCalls gets all the calls that were made to GetNextJobNumberCounter.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetNextJobNumberCounterCalls())
+func (mock *MongoDBMock) GetNextJobNumberCounterCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetNextJobNumberCounter.RLock()
+	calls = mock.calls.GetNextJobNumberCounter
+	mock.lockGetNextJobNumberCounter.RUnlock()
+	return calls
+}
+
+// GetReleaseGroup calls GetReleaseGroupFunc.
+func (mock *MongoDBMock) GetReleaseGroup(ctx context.Context, groupID string) (*domain.ReleaseGroup, error) {
+	if mock.GetReleaseGroupFunc == nil {
+		panic("MongoDBMock.GetReleaseGroupFunc: method is nil but MongoDB.GetReleaseGroup was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		GroupID string
+	}{
+		Ctx:     ctx,
+		GroupID: groupID,
+	}
+	mock.lockGetReleaseGroup.Lock()
+	mock.calls.GetReleaseGroup = append(mock.calls.GetReleaseGroup, callInfo)
+	mock.lockGetReleaseGroup.Unlock()
+	return mock.GetReleaseGroupFunc(ctx, groupID)
+}
+
+// GetReleaseGroupCalls gets all the calls that were made to GetReleaseGroup.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetReleaseGroupCalls())
+func (mock *MongoDBMock) GetReleaseGroupCalls() []struct {
+	Ctx     context.Context
+	GroupID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		GroupID string
+	}
+	mock.lockGetReleaseGroup.RLock()
+	calls = mock.calls.GetReleaseGroup
+	mock.lockGetReleaseGroup.RUnlock()
+	return calls
+}
+
+// GetReleaseGroupJobs calls GetReleaseGroupJobsFunc.
+func (mock *MongoDBMock) GetReleaseGroupJobs(ctx context.Context, groupID string) ([]*domain.Job, error) {
+	if mock.GetReleaseGroupJobsFunc == nil {
+		panic("MongoDBMock.GetReleaseGroupJobsFunc: method is nil but MongoDB.GetReleaseGroupJobs was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		GroupID string
+	}{
+		Ctx:     ctx,
+		GroupID: groupID,
+	}
+	mock.lockGetReleaseGroupJobs.Lock()
+	mock.calls.GetReleaseGroupJobs = append(mock.calls.GetReleaseGroupJobs, callInfo)
+	mock.lockGetReleaseGroupJobs.Unlock()
+	return mock.GetReleaseGroupJobsFunc(ctx, groupID)
+}
+
+// GetReleaseGroupJobsCalls gets all the calls that were made to GetReleaseGroupJobs.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetReleaseGroupJobsCalls())
+func (mock *MongoDBMock) GetReleaseGroupJobsCalls() []struct {
+	Ctx     context.Context
+	GroupID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		GroupID string
+	}
+	mock.lockGetReleaseGroupJobs.RLock()
+	calls = mock.calls.GetReleaseGroupJobs
+	mock.lockGetReleaseGroupJobs.RUnlock()
+	return calls
+}
+
+// GetStaleTasks calls GetStaleTasksFunc.
+func (mock *MongoDBMock) GetStaleTasks(ctx context.Context, states []domain.State, updatedBefore time.Time, limit int) ([]*domain.Task, int, error) {
+	if mock.GetStaleTasksFunc == nil {
+		panic("MongoDBMock.GetStaleTasksFunc: method is nil but MongoDB.GetStaleTasks was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		States        []domain.State
+		UpdatedBefore time.Time
+		Limit         int
+	}{
+		Ctx:           ctx,
+		States:        states,
+		UpdatedBefore: updatedBefore,
+		Limit:         limit,
+	}
+	mock.lockGetStaleTasks.Lock()
+	mock.calls.GetStaleTasks = append(mock.calls.GetStaleTasks, callInfo)
+	mock.lockGetStaleTasks.Unlock()
+	return mock.GetStaleTasksFunc(ctx, states, updatedBefore, limit)
+}
+
+// GetStaleTasksCalls gets all the calls that were made to GetStaleTasks.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetStaleTasksCalls())
+func (mock *MongoDBMock) GetStaleTasksCalls() []struct {
+	Ctx           context.Context
+	States        []domain.State
+	UpdatedBefore time.Time
+	Limit         int
+} {
+	var calls []struct {
+		Ctx           context.Context
+		States        []domain.State
+		UpdatedBefore time.Time
+		Limit         int
+	}
+	mock.lockGetStaleTasks.RLock()
+	calls = mock.calls.GetStaleTasks
+	mock.lockGetStaleTasks.RUnlock()
+	return calls
+}
+
+// GetTask calls GetTaskFunc.
+func (mock *MongoDBMock) GetTask(ctx context.Context, taskID string) (*domain.Task, error) {
+	if mock.GetTaskFunc == nil {
+		panic("MongoDBMock.GetTaskFunc: method is nil but MongoDB.GetTask was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		TaskID string
+	}{
+		Ctx:    ctx,
+		TaskID: taskID,
+	}
+	mock.lockGetTask.Lock()
+	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
+	mock.lockGetTask.Unlock()
+	return mock.GetTaskFunc(ctx, taskID)
+}
+
+// GetTaskCalls gets all the calls that were made to GetTask.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetTaskCalls())
+func (mock *MongoDBMock) GetTaskCalls() []struct {
+	Ctx    context.Context
+	TaskID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		TaskID string
+	}
+	mock.lockGetTask.RLock()
+	calls = mock.calls.GetTask
+	mock.lockGetTask.RUnlock()
+	return calls
+}
+
+// GetWebhook calls GetWebhookFunc.
+func (mock *MongoDBMock) GetWebhook(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+	if mock.GetWebhookFunc == nil {
+		panic("MongoDBMock.GetWebhookFunc: method is nil but MongoDB.GetWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		WebhookID string
+	}{
+		Ctx:       ctx,
+		WebhookID: webhookID,
+	}
+	mock.lockGetWebhook.Lock()
+	mock.calls.GetWebhook = append(mock.calls.GetWebhook, callInfo)
+	mock.lockGetWebhook.Unlock()
+	return mock.GetWebhookFunc(ctx, webhookID)
+}
+
+// GetWebhookCalls gets all the calls that were made to GetWebhook.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetWebhookCalls())
+func (mock *MongoDBMock) GetWebhookCalls() []struct {
+	Ctx       context.Context
+	WebhookID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		WebhookID string
+	}
+	mock.lockGetWebhook.RLock()
+	calls = mock.calls.GetWebhook
+	mock.lockGetWebhook.RUnlock()
+	return calls
+}
+
+// GetWebhookDeliveries calls GetWebhookDeliveriesFunc.
+func (mock *MongoDBMock) GetWebhookDeliveries(ctx context.Context, webhookID string, status domain.WebhookDeliveryStatus, limit int, offset int) ([]*domain.WebhookDelivery, int, error) {
+	if mock.GetWebhookDeliveriesFunc == nil {
+		panic("MongoDBMock.GetWebhookDeliveriesFunc: method is nil but MongoDB.GetWebhookDeliveries was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		WebhookID string
+		Status    domain.WebhookDeliveryStatus
+		Limit     int
+		Offset    int
+	}{
+		Ctx:       ctx,
+		WebhookID: webhookID,
+		Status:    status,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	mock.lockGetWebhookDeliveries.Lock()
+	mock.calls.GetWebhookDeliveries = append(mock.calls.GetWebhookDeliveries, callInfo)
+	mock.lockGetWebhookDeliveries.Unlock()
+	return mock.GetWebhookDeliveriesFunc(ctx, webhookID, status, limit, offset)
+}
+
+// GetWebhookDeliveriesCalls gets all the calls that were made to GetWebhookDeliveries.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetWebhookDeliveriesCalls())
+func (mock *MongoDBMock) GetWebhookDeliveriesCalls() []struct {
+	Ctx       context.Context
+	WebhookID string
+	Status    domain.WebhookDeliveryStatus
 	Limit     int
 	Offset    int
 } {
 	var calls []struct {
 		Ctx       context.Context
-		Field     sort.SortParameterField
-		Direction sort.SortParameterDirection
-		States    []domain.State
+		WebhookID string
+		Status    domain.WebhookDeliveryStatus
 		Limit     int
 		Offset    int
 	}
-	mock.lockGetJobs.RLock()
-	calls = mock.calls.GetJobs
-	mock.lockGetJobs.RUnlock()
+	mock.lockGetWebhookDeliveries.RLock()
+	calls = mock.calls.GetWebhookDeliveries
+	mock.lockGetWebhookDeliveries.RUnlock()
 	return calls
 }
 
-// GetJobsBySourceOrTargetAndState calls GetJobsBySourceOrTargetAndStateFunc.
-func (mock *MongoDBMock) GetJobsBySourceOrTargetAndState(ctx context.Context, jc *domain.JobConfig, states []domain.State, limit int, offset int) ([]*domain.Job, error) {
-	if mock.GetJobsBySourceOrTargetAndStateFunc == nil {
-		panic("MongoDBMock.GetJobsBySourceOrTargetAndStateFunc: method is nil but MongoDB.GetJobsBySourceOrTargetAndState was just called")
+// GetWebhooks calls GetWebhooksFunc.
+func (mock *MongoDBMock) GetWebhooks(ctx context.Context, limit int, offset int) ([]*domain.Webhook, int, error) {
+	if mock.GetWebhooksFunc == nil {
+		panic("MongoDBMock.GetWebhooksFunc: method is nil but MongoDB.GetWebhooks was just called")
 	}
 	callInfo := struct {
 		Ctx    context.Context
-		Jc     *domain.JobConfig
-		States []domain.State
 		Limit  int
 		Offset int
 	}{
 		Ctx:    ctx,
-		Jc:     jc,
-		States: states,
 		Limit:  limit,
 		Offset: offset,
 	}
-	mock.lockGetJobsBySourceOrTargetAndState.Lock()
-	mock.calls.GetJobsBySourceOrTargetAndState = append(mock.calls.GetJobsBySourceOrTargetAndState, callInfo)
-	mock.lockGetJobsBySourceOrTargetAndState.Unlock()
-	return mock.GetJobsBySourceOrTargetAndStateFunc(ctx, jc, states, limit, offset)
+	mock.lockGetWebhooks.Lock()
+	mock.calls.GetWebhooks = append(mock.calls.GetWebhooks, callInfo)
+	mock.lockGetWebhooks.Unlock()
+	return mock.GetWebhooksFunc(ctx, limit, offset)
 }
 
-// GetJobsBySourceOrTargetAndStateCalls gets all the calls that were made to GetJobsBySourceOrTargetAndState.
+// GetWebhooksCalls gets all the calls that were made to GetWebhooks.
 // Check the length with:
 //
-//	len(mockedMongoDB.GetJobsBySourceOrTargetAndStateCalls())
-func (mock *MongoDBMock) GetJobsBySourceOrTargetAndStateCalls() []struct {
+//	len(mockedMongoDB.GetWebhooksCalls())
+func (mock *MongoDBMock) GetWebhooksCalls() []struct {
 	Ctx    context.Context
-	Jc     *domain.JobConfig
-	States []domain.State
 	Limit  int
 	Offset int
 } {
 	var calls []struct {
 		Ctx    context.Context
-		Jc     *domain.JobConfig
-		States []domain.State
 		Limit  int
 		Offset int
 	}
-	mock.lockGetJobsBySourceOrTargetAndState.RLock()
-	calls = mock.calls.GetJobsBySourceOrTargetAndState
-	mock.lockGetJobsBySourceOrTargetAndState.RUnlock()
+	mock.lockGetWebhooks.RLock()
+	calls = mock.calls.GetWebhooks
+	mock.lockGetWebhooks.RUnlock()
 	return calls
 }
 
-// GetNextJobNumberCounter calls GetNextJobNumberCounterFunc.
-func (mock *MongoDBMock) GetNextJobNumberCounter(ctx context.Context) (*domain.Counter, error) {
-	if mock.GetNextJobNumberCounterFunc == nil {
-		panic("MongoDBMock.GetNextJobNumberCounterFunc: method is nil but MongoDB.GetNextJobNumberCounter was just called")
+// GetWebhooksForEvent calls GetWebhooksForEventFunc.
+func (mock *MongoDBMock) GetWebhooksForEvent(ctx context.Context, eventType domain.WebhookEventType, jobType domain.JobType) ([]*domain.Webhook, error) {
+	if mock.GetWebhooksForEventFunc == nil {
+		panic("MongoDBMock.GetWebhooksForEventFunc: method is nil but MongoDB.GetWebhooksForEvent was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx       context.Context
+		EventType domain.WebhookEventType
+		JobType   domain.JobType
 	}{
-		Ctx: ctx,
+		Ctx:       ctx,
+		EventType: eventType,
+		JobType:   jobType,
 	}
-	mock.lockGetNextJobNumberCounter.Lock()
-	mock.calls.GetNextJobNumberCounter = append(mock.calls.GetNextJobNumberCounter, callInfo)
-	mock.lockGetNextJobNumberCounter.Unlock()
-	return mock.GetNextJobNumberCounterFunc(ctx)
+	mock.lockGetWebhooksForEvent.Lock()
+	mock.calls.GetWebhooksForEvent = append(mock.calls.GetWebhooksForEvent, callInfo)
+	mock.lockGetWebhooksForEvent.Unlock()
+	return mock.GetWebhooksForEventFunc(ctx, eventType, jobType)
 }
 
-// GetNextJobNumberCounterCalls gets all the calls that were made to GetNextJobNumberCounter.
+// GetWebhooksForEventCalls gets all the calls that were made to GetWebhooksForEvent.
 // Check the length with:
 //
-//	len(mockedMongoDB.GetNextJobNumberCounterCalls())
-func (mock *MongoDBMock) GetNextJobNumberCounterCalls() []struct {
-	Ctx context.Context
+//	len(mockedMongoDB.GetWebhooksForEventCalls())
+func (mock *MongoDBMock) GetWebhooksForEventCalls() []struct {
+	Ctx       context.Context
+	EventType domain.WebhookEventType
+	JobType   domain.JobType
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx       context.Context
+		EventType domain.WebhookEventType
+		JobType   domain.JobType
 	}
-	mock.lockGetNextJobNumberCounter.RLock()
-	calls = mock.calls.GetNextJobNumberCounter
-	mock.lockGetNextJobNumberCounter.RUnlock()
+	mock.lockGetWebhooksForEvent.RLock()
+	calls = mock.calls.GetWebhooksForEvent
+	mock.lockGetWebhooksForEvent.RUnlock()
 	return calls
 }
 
-// GetTask calls GetTaskFunc.
-func (mock *MongoDBMock) GetTask(ctx context.Context, taskID string) (*domain.Task, error) {
-	if mock.GetTaskFunc == nil {
-		panic("MongoDBMock.GetTaskFunc: method is nil but MongoDB.GetTask was just called")
+// ReleaseGroupJobs calls ReleaseGroupJobsFunc.
+func (mock *MongoDBMock) ReleaseGroupJobs(ctx context.Context, groupID string, state domain.State) error {
+	if mock.ReleaseGroupJobsFunc == nil {
+		panic("MongoDBMock.ReleaseGroupJobsFunc: method is nil but MongoDB.ReleaseGroupJobs was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		GroupID string
+		State   domain.State
+	}{
+		Ctx:     ctx,
+		GroupID: groupID,
+		State:   state,
+	}
+	mock.lockReleaseGroupJobs.Lock()
+	mock.calls.ReleaseGroupJobs = append(mock.calls.ReleaseGroupJobs, callInfo)
+	mock.lockReleaseGroupJobs.Unlock()
+	return mock.ReleaseGroupJobsFunc(ctx, groupID, state)
+}
+
+// ReleaseGroupJobsCalls gets all the calls that were made to ReleaseGroupJobs.
+// Check the length with:
+//
+//	len(mockedMongoDB.ReleaseGroupJobsCalls())
+func (mock *MongoDBMock) ReleaseGroupJobsCalls() []struct {
+	Ctx     context.Context
+	GroupID string
+	State   domain.State
+} {
+	var calls []struct {
+		Ctx     context.Context
+		GroupID string
+		State   domain.State
+	}
+	mock.lockReleaseGroupJobs.RLock()
+	calls = mock.calls.ReleaseGroupJobs
+	mock.lockReleaseGroupJobs.RUnlock()
+	return calls
+}
+
+// RemoveJobsFromReleaseGroup calls RemoveJobsFromReleaseGroupFunc.
+func (mock *MongoDBMock) RemoveJobsFromReleaseGroup(ctx context.Context, groupID string) error {
+	if mock.RemoveJobsFromReleaseGroupFunc == nil {
+		panic("MongoDBMock.RemoveJobsFromReleaseGroupFunc: method is nil but MongoDB.RemoveJobsFromReleaseGroup was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		GroupID string
+	}{
+		Ctx:     ctx,
+		GroupID: groupID,
+	}
+	mock.lockRemoveJobsFromReleaseGroup.Lock()
+	mock.calls.RemoveJobsFromReleaseGroup = append(mock.calls.RemoveJobsFromReleaseGroup, callInfo)
+	mock.lockRemoveJobsFromReleaseGroup.Unlock()
+	return mock.RemoveJobsFromReleaseGroupFunc(ctx, groupID)
+}
+
+// RemoveJobsFromReleaseGroupCalls gets all the calls that were made to RemoveJobsFromReleaseGroup.
+// Check the length with:
+//
+//	len(mockedMongoDB.RemoveJobsFromReleaseGroupCalls())
+func (mock *MongoDBMock) RemoveJobsFromReleaseGroupCalls() []struct {
+	Ctx     context.Context
+	GroupID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		GroupID string
+	}
+	mock.lockRemoveJobsFromReleaseGroup.RLock()
+	calls = mock.calls.RemoveJobsFromReleaseGroup
+	mock.lockRemoveJobsFromReleaseGroup.RUnlock()
+	return calls
+}
+
+// ReplaceExpiredIdempotencyRecord calls ReplaceExpiredIdempotencyRecordFunc.
+func (mock *MongoDBMock) ReplaceExpiredIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error {
+	if mock.ReplaceExpiredIdempotencyRecordFunc == nil {
+		panic("MongoDBMock.ReplaceExpiredIdempotencyRecordFunc: method is nil but MongoDB.ReplaceExpiredIdempotencyRecord was just called")
 	}
 	callInfo := struct {
 		Ctx    context.Context
-		TaskID string
+		Record *domain.IdempotencyRecord
 	}{
 		Ctx:    ctx,
-		TaskID: taskID,
+		Record: record,
 	}
-	mock.lockGetTask.Lock()
-	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
-	mock.lockGetTask.Unlock()
-	return mock.GetTaskFunc(ctx, taskID)
+	mock.lockReplaceExpiredIdempotencyRecord.Lock()
+	mock.calls.ReplaceExpiredIdempotencyRecord = append(mock.calls.ReplaceExpiredIdempotencyRecord, callInfo)
+	mock.lockReplaceExpiredIdempotencyRecord.Unlock()
+	return mock.ReplaceExpiredIdempotencyRecordFunc(ctx, record)
 }
 
-// GetTaskCalls gets all the calls that were made to GetTask.
+// ReplaceExpiredIdempotencyRecordCalls gets all the calls that were made to ReplaceExpiredIdempotencyRecord.
 // Check the length with:
 //
-//	len(mockedMongoDB.GetTaskCalls())
-func (mock *MongoDBMock) GetTaskCalls() []struct {
+//	len(mockedMongoDB.ReplaceExpiredIdempotencyRecordCalls())
+func (mock *MongoDBMock) ReplaceExpiredIdempotencyRecordCalls() []struct {
 	Ctx    context.Context
-	TaskID string
+	Record *domain.IdempotencyRecord
 } {
 	var calls []struct {
 		Ctx    context.Context
-		TaskID string
+		Record *domain.IdempotencyRecord
 	}
-	mock.lockGetTask.RLock()
-	calls = mock.calls.GetTask
-	mock.lockGetTask.RUnlock()
+	mock.lockReplaceExpiredIdempotencyRecord.RLock()
+	calls = mock.calls.ReplaceExpiredIdempotencyRecord
+	mock.lockReplaceExpiredIdempotencyRecord.RUnlock()
+	return calls
+}
+
+// UpdateBatch calls UpdateBatchFunc.
+func (mock *MongoDBMock) UpdateBatch(ctx context.Context, batch *domain.Batch) error {
+	if mock.UpdateBatchFunc == nil {
+		panic("MongoDBMock.UpdateBatchFunc: method is nil but MongoDB.UpdateBatch was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Batch *domain.Batch
+	}{
+		Ctx:   ctx,
+		Batch: batch,
+	}
+	mock.lockUpdateBatch.Lock()
+	mock.calls.UpdateBatch = append(mock.calls.UpdateBatch, callInfo)
+	mock.lockUpdateBatch.Unlock()
+	return mock.UpdateBatchFunc(ctx, batch)
+}
+
+// UpdateBatchCalls gets all the calls that were made to UpdateBatch.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateBatchCalls())
+func (mock *MongoDBMock) UpdateBatchCalls() []struct {
+	Ctx   context.Context
+	Batch *domain.Batch
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Batch *domain.Batch
+	}
+	mock.lockUpdateBatch.RLock()
+	calls = mock.calls.UpdateBatch
+	mock.lockUpdateBatch.RUnlock()
 	return calls
 }
 
@@ -1054,6 +3070,170 @@ func (mock *MongoDBMock) UpdateJobCalls() []struct {
 	return calls
 }
 
+// UpdateJobCollectionID calls UpdateJobCollectionIDFunc.
+func (mock *MongoDBMock) UpdateJobCollectionID(ctx context.Context, jobID string, collectionID string) error {
+	if mock.UpdateJobCollectionIDFunc == nil {
+		panic("MongoDBMock.UpdateJobCollectionIDFunc: method is nil but MongoDB.UpdateJobCollectionID was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		JobID        string
+		CollectionID string
+	}{
+		Ctx:          ctx,
+		JobID:        jobID,
+		CollectionID: collectionID,
+	}
+	mock.lockUpdateJobCollectionID.Lock()
+	mock.calls.UpdateJobCollectionID = append(mock.calls.UpdateJobCollectionID, callInfo)
+	mock.lockUpdateJobCollectionID.Unlock()
+	return mock.UpdateJobCollectionIDFunc(ctx, jobID, collectionID)
+}
+
+// UpdateJobCollectionIDCalls gets all the calls that were made to UpdateJobCollectionID.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateJobCollectionIDCalls())
+func (mock *MongoDBMock) UpdateJobCollectionIDCalls() []struct {
+	Ctx          context.Context
+	JobID        string
+	CollectionID string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		JobID        string
+		CollectionID string
+	}
+	mock.lockUpdateJobCollectionID.RLock()
+	calls = mock.calls.UpdateJobCollectionID
+	mock.lockUpdateJobCollectionID.RUnlock()
+	return calls
+}
+
+// UpdateJobNotificationThread calls UpdateJobNotificationThreadFunc.
+func (mock *MongoDBMock) UpdateJobNotificationThread(ctx context.Context, jobID string, thread *domain.NotificationThread) error {
+	if mock.UpdateJobNotificationThreadFunc == nil {
+		panic("MongoDBMock.UpdateJobNotificationThreadFunc: method is nil but MongoDB.UpdateJobNotificationThread was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		JobID  string
+		Thread *domain.NotificationThread
+	}{
+		Ctx:    ctx,
+		JobID:  jobID,
+		Thread: thread,
+	}
+	mock.lockUpdateJobNotificationThread.Lock()
+	mock.calls.UpdateJobNotificationThread = append(mock.calls.UpdateJobNotificationThread, callInfo)
+	mock.lockUpdateJobNotificationThread.Unlock()
+	return mock.UpdateJobNotificationThreadFunc(ctx, jobID, thread)
+}
+
+// UpdateJobNotificationThreadCalls gets all the calls that were made to UpdateJobNotificationThread.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateJobNotificationThreadCalls())
+func (mock *MongoDBMock) UpdateJobNotificationThreadCalls() []struct {
+	Ctx    context.Context
+	JobID  string
+	Thread *domain.NotificationThread
+} {
+	var calls []struct {
+		Ctx    context.Context
+		JobID  string
+		Thread *domain.NotificationThread
+	}
+	mock.lockUpdateJobNotificationThread.RLock()
+	calls = mock.calls.UpdateJobNotificationThread
+	mock.lockUpdateJobNotificationThread.RUnlock()
+	return calls
+}
+
+// UpdateJobPriority calls UpdateJobPriorityFunc.
+func (mock *MongoDBMock) UpdateJobPriority(ctx context.Context, jobID string, priority int) error {
+	if mock.UpdateJobPriorityFunc == nil {
+		panic("MongoDBMock.UpdateJobPriorityFunc: method is nil but MongoDB.UpdateJobPriority was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		JobID    string
+		Priority int
+	}{
+		Ctx:      ctx,
+		JobID:    jobID,
+		Priority: priority,
+	}
+	mock.lockUpdateJobPriority.Lock()
+	mock.calls.UpdateJobPriority = append(mock.calls.UpdateJobPriority, callInfo)
+	mock.lockUpdateJobPriority.Unlock()
+	return mock.UpdateJobPriorityFunc(ctx, jobID, priority)
+}
+
+// UpdateJobPriorityCalls gets all the calls that were made to UpdateJobPriority.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateJobPriorityCalls())
+func (mock *MongoDBMock) UpdateJobPriorityCalls() []struct {
+	Ctx      context.Context
+	JobID    string
+	Priority int
+} {
+	var calls []struct {
+		Ctx      context.Context
+		JobID    string
+		Priority int
+	}
+	mock.lockUpdateJobPriority.RLock()
+	calls = mock.calls.UpdateJobPriority
+	mock.lockUpdateJobPriority.RUnlock()
+	return calls
+}
+
+// UpdateJobPublishAt calls UpdateJobPublishAtFunc.
+func (mock *MongoDBMock) UpdateJobPublishAt(ctx context.Context, jobID string, states []domain.State, publishAt *time.Time) error {
+	if mock.UpdateJobPublishAtFunc == nil {
+		panic("MongoDBMock.UpdateJobPublishAtFunc: method is nil but MongoDB.UpdateJobPublishAt was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobID     string
+		States    []domain.State
+		PublishAt *time.Time
+	}{
+		Ctx:       ctx,
+		JobID:     jobID,
+		States:    states,
+		PublishAt: publishAt,
+	}
+	mock.lockUpdateJobPublishAt.Lock()
+	mock.calls.UpdateJobPublishAt = append(mock.calls.UpdateJobPublishAt, callInfo)
+	mock.lockUpdateJobPublishAt.Unlock()
+	return mock.UpdateJobPublishAtFunc(ctx, jobID, states, publishAt)
+}
+
+// UpdateJobPublishAtCalls gets all the calls that were made to UpdateJobPublishAt.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateJobPublishAtCalls())
+func (mock *MongoDBMock) UpdateJobPublishAtCalls() []struct {
+	Ctx       context.Context
+	JobID     string
+	States    []domain.State
+	PublishAt *time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobID     string
+		States    []domain.State
+		PublishAt *time.Time
+	}
+	mock.lockUpdateJobPublishAt.RLock()
+	calls = mock.calls.UpdateJobPublishAt
+	mock.lockUpdateJobPublishAt.RUnlock()
+	return calls
+}
+
 // UpdateJobState calls UpdateJobStateFunc.
 func (mock *MongoDBMock) UpdateJobState(ctx context.Context, id string, oldState domain.State, newState domain.State, lastUpdated time.Time) error {
 	if mock.UpdateJobStateFunc == nil {
@@ -1102,6 +3282,46 @@ func (mock *MongoDBMock) UpdateJobStateCalls() []struct {
 	return calls
 }
 
+// UpdateJobTasksPriority calls UpdateJobTasksPriorityFunc.
+func (mock *MongoDBMock) UpdateJobTasksPriority(ctx context.Context, jobNumber int, priority int) error {
+	if mock.UpdateJobTasksPriorityFunc == nil {
+		panic("MongoDBMock.UpdateJobTasksPriorityFunc: method is nil but MongoDB.UpdateJobTasksPriority was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		JobNumber int
+		Priority  int
+	}{
+		Ctx:       ctx,
+		JobNumber: jobNumber,
+		Priority:  priority,
+	}
+	mock.lockUpdateJobTasksPriority.Lock()
+	mock.calls.UpdateJobTasksPriority = append(mock.calls.UpdateJobTasksPriority, callInfo)
+	mock.lockUpdateJobTasksPriority.Unlock()
+	return mock.UpdateJobTasksPriorityFunc(ctx, jobNumber, priority)
+}
+
+// UpdateJobTasksPriorityCalls gets all the calls that were made to UpdateJobTasksPriority.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateJobTasksPriorityCalls())
+func (mock *MongoDBMock) UpdateJobTasksPriorityCalls() []struct {
+	Ctx       context.Context
+	JobNumber int
+	Priority  int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		JobNumber int
+		Priority  int
+	}
+	mock.lockUpdateJobTasksPriority.RLock()
+	calls = mock.calls.UpdateJobTasksPriority
+	mock.lockUpdateJobTasksPriority.RUnlock()
+	return calls
+}
+
 // UpdateTask calls UpdateTaskFunc.
 func (mock *MongoDBMock) UpdateTask(ctx context.Context, task *domain.Task) error {
 	if mock.UpdateTaskFunc == nil {
@@ -1181,3 +3401,111 @@ func (mock *MongoDBMock) UpdateTaskStateCalls() []struct {
 	mock.lockUpdateTaskState.RUnlock()
 	return calls
 }
+
+// UpdateWebhook calls UpdateWebhookFunc.
+func (mock *MongoDBMock) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	if mock.UpdateWebhookFunc == nil {
+		panic("MongoDBMock.UpdateWebhookFunc: method is nil but MongoDB.UpdateWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Webhook *domain.Webhook
+	}{
+		Ctx:     ctx,
+		Webhook: webhook,
+	}
+	mock.lockUpdateWebhook.Lock()
+	mock.calls.UpdateWebhook = append(mock.calls.UpdateWebhook, callInfo)
+	mock.lockUpdateWebhook.Unlock()
+	return mock.UpdateWebhookFunc(ctx, webhook)
+}
+
+// UpdateWebhookCalls gets all the calls that were made to UpdateWebhook.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateWebhookCalls())
+func (mock *MongoDBMock) UpdateWebhookCalls() []struct {
+	Ctx     context.Context
+	Webhook *domain.Webhook
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Webhook *domain.Webhook
+	}
+	mock.lockUpdateWebhook.RLock()
+	calls = mock.calls.UpdateWebhook
+	mock.lockUpdateWebhook.RUnlock()
+	return calls
+}
+
+// UpdateWebhookDelivery calls UpdateWebhookDeliveryFunc.
+func (mock *MongoDBMock) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if mock.UpdateWebhookDeliveryFunc == nil {
+		panic("MongoDBMock.UpdateWebhookDeliveryFunc: method is nil but MongoDB.UpdateWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Delivery *domain.WebhookDelivery
+	}{
+		Ctx:      ctx,
+		Delivery: delivery,
+	}
+	mock.lockUpdateWebhookDelivery.Lock()
+	mock.calls.UpdateWebhookDelivery = append(mock.calls.UpdateWebhookDelivery, callInfo)
+	mock.lockUpdateWebhookDelivery.Unlock()
+	return mock.UpdateWebhookDeliveryFunc(ctx, delivery)
+}
+
+// UpdateWebhookDeliveryCalls gets all the calls that were made to UpdateWebhookDelivery.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateWebhookDeliveryCalls())
+func (mock *MongoDBMock) UpdateWebhookDeliveryCalls() []struct {
+	Ctx      context.Context
+	Delivery *domain.WebhookDelivery
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Delivery *domain.WebhookDelivery
+	}
+	mock.lockUpdateWebhookDelivery.RLock()
+	calls = mock.calls.UpdateWebhookDelivery
+	mock.lockUpdateWebhookDelivery.RUnlock()
+	return calls
+}
+
+// WatchWork calls WatchWorkFunc.
+func (mock *MongoDBMock) WatchWork(ctx context.Context, notify func()) error {
+	if mock.WatchWorkFunc == nil {
+		panic("MongoDBMock.WatchWorkFunc: method is nil but MongoDB.WatchWork was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Notify func()
+	}{
+		Ctx:    ctx,
+		Notify: notify,
+	}
+	mock.lockWatchWork.Lock()
+	mock.calls.WatchWork = append(mock.calls.WatchWork, callInfo)
+	mock.lockWatchWork.Unlock()
+	return mock.WatchWorkFunc(ctx, notify)
+}
+
+// WatchWorkCalls gets all the calls that were made to WatchWork.
+// Check the length with:
+//
+//	len(mockedMongoDB.WatchWorkCalls())
+func (mock *MongoDBMock) WatchWorkCalls() []struct {
+	Ctx    context.Context
+	Notify func()
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Notify func()
+	}
+	mock.lockWatchWork.RLock()
+	calls = mock.calls.WatchWork
+	mock.lockWatchWork.RUnlock()
+	return calls
+}
@@ -23,27 +23,81 @@ type dataMongoDB interface {
 	// Jobs
 	CreateJob(ctx context.Context, job *domain.Job) error
 	GetJob(ctx context.Context, jobNumber int) (*domain.Job, error)
-	GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error)
+	GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error)
+	GetJobsAfter(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error)
 	GetJobStateCounts(ctx context.Context) ([]mongo.StateCountResult, error)
 	ClaimJob(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Job, error)
 	GetJobsBySourceOrTargetAndState(ctx context.Context, jc *domain.JobConfig, states []domain.State, limit, offset int) ([]*domain.Job, error)
 	GetNextJobNumberCounter(ctx context.Context) (*domain.Counter, error)
 	UpdateJob(ctx context.Context, job *domain.Job) error
+	UpdateJobCollectionID(ctx context.Context, jobID, collectionID string) error
+	UpdateJobNotificationThread(ctx context.Context, jobID string, thread *domain.NotificationThread) error
 	UpdateJobState(ctx context.Context, id string, oldState domain.State, newState domain.State, lastUpdated time.Time) error
+	ApproveJob(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error)
+	UpdateJobPublishAt(ctx context.Context, jobID string, states []domain.State, publishAt *time.Time) error
+	UpdateJobPriority(ctx context.Context, jobID string, priority int) error
 
 	// Tasks
 	CreateTask(ctx context.Context, task *domain.Task) error
 	GetTask(ctx context.Context, taskID string) (*domain.Task, error)
-	ClaimTask(ctx context.Context, pendingState domain.State, activeState domain.State) (*domain.Task, error)
+	ClaimTask(ctx context.Context, pendingState domain.State, activeState domain.State, dependencyState domain.State, afterJobNumber int, excludedTypes []domain.TaskType, excludedJobNumbers []int) (*domain.Task, error)
+	UpdateJobTasksPriority(ctx context.Context, jobNumber, priority int) error
 	GetJobTasks(ctx context.Context, states []domain.State, jobNumber, limit, offset int) ([]*domain.Task, int, error)
+	GetJobTasksAfter(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error)
 	CountTasksByJobNumber(ctx context.Context, jobNumber int) (int, error)
+	GetStaleTasks(ctx context.Context, states []domain.State, updatedBefore time.Time, limit int) ([]*domain.Task, int, error)
+	GetDependentTasks(ctx context.Context, taskID string) ([]*domain.Task, error)
 	UpdateTask(ctx context.Context, task *domain.Task) error
 	UpdateTaskState(ctx context.Context, taskID string, newState domain.State, lastUpdated time.Time) error
+	WatchWork(ctx context.Context, notify func()) error
 
 	// Events
 	CreateEvent(ctx context.Context, event *domain.Event) error
-	GetJobEvents(ctx context.Context, jobNumber int, limit, offset int) ([]*domain.Event, int, error)
+	GetJobEvents(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error)
+	GetJobEventsAfter(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error)
 	CountEventsByJobNumber(ctx context.Context, jobNumber int) (int, error)
+	GetJobEventStateCounts(ctx context.Context, action domain.EventAction, since time.Time) ([]mongo.StateCountResult, error)
+
+	// Batches
+	CreateBatch(ctx context.Context, batch *domain.Batch) error
+	GetBatch(ctx context.Context, batchID string) (*domain.Batch, error)
+	UpdateBatch(ctx context.Context, batch *domain.Batch) error
+	GetBatchJobStateCounts(ctx context.Context, batchID string) ([]mongo.StateCountResult, error)
+
+	// Idempotency keys
+	CreateIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error
+	GetIdempotencyRecord(ctx context.Context, id string) (*domain.IdempotencyRecord, error)
+	CompleteIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord, job *domain.Job) error
+	ReplaceExpiredIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error
+	DeleteIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error
+
+	// Webhooks
+	CreateWebhook(ctx context.Context, webhook *domain.Webhook) error
+	GetWebhook(ctx context.Context, webhookID string) (*domain.Webhook, error)
+	GetWebhooks(ctx context.Context, limit, offset int) ([]*domain.Webhook, int, error)
+	GetWebhooksForEvent(ctx context.Context, eventType domain.WebhookEventType, jobType domain.JobType) ([]*domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	CreateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetWebhookDeliveries(ctx context.Context, webhookID string, status domain.WebhookDeliveryStatus, limit, offset int) ([]*domain.WebhookDelivery, int, error)
+	ClaimWebhookDelivery(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// Comments
+	CreateComment(ctx context.Context, comment *domain.Comment) error
+	GetJobComments(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Comment, int, error)
+
+	// Release groups
+	CreateReleaseGroup(ctx context.Context, group *domain.ReleaseGroup) error
+	GetReleaseGroup(ctx context.Context, groupID string) (*domain.ReleaseGroup, error)
+	DeleteReleaseGroup(ctx context.Context, groupID string) error
+	AddJobsToReleaseGroup(ctx context.Context, groupID string, jobNumbers []int, states []domain.State) (int, error)
+	RemoveJobsFromReleaseGroup(ctx context.Context, groupID string) error
+	GetReleaseGroupJobs(ctx context.Context, groupID string) ([]*domain.Job, error)
+	ReleaseGroupJobs(ctx context.Context, groupID string, state domain.State) error
+
+	// Digests
+	ClaimDigestRun(ctx context.Context, run *domain.DigestRun) error
 
 	// Other
 	Checker(ctx context.Context, state *healthcheck.CheckState) error
@@ -73,6 +127,23 @@ func (ds *Datastore) UpdateJobState(ctx context.Context, jobID string, oldState,
 	return ds.Backend.UpdateJobState(ctx, jobID, oldState, newState, lastUpdated)
 }
 
+// ApproveJob records a user's approval of a job in review and when it is to
+// be published, moving it to approved once it has the required approvals.
+func (ds *Datastore) ApproveJob(ctx context.Context, jobID string, approval *domain.Approval, requiredApprovals int, publishAt *time.Time) (*domain.Job, error) {
+	return ds.Backend.ApproveJob(ctx, jobID, approval, requiredApprovals, publishAt)
+}
+
+// UpdateJobPublishAt schedules when a job in one of the provided states is
+// published, or cancels its schedule if publishAt is nil.
+func (ds *Datastore) UpdateJobPublishAt(ctx context.Context, jobID string, states []domain.State, publishAt *time.Time) error {
+	return ds.Backend.UpdateJobPublishAt(ctx, jobID, states, publishAt)
+}
+
+// UpdateJobPriority sets the priority of a job.
+func (ds *Datastore) UpdateJobPriority(ctx context.Context, jobID string, priority int) error {
+	return ds.Backend.UpdateJobPriority(ctx, jobID, priority)
+}
+
 // GetJob retrieves a job by its job number.
 func (ds *Datastore) GetJob(ctx context.Context, jobNumber int) (*domain.Job, error) {
 	return ds.Backend.GetJob(ctx, jobNumber)
@@ -88,9 +159,26 @@ func (ds *Datastore) UpdateJob(ctx context.Context, job *domain.Job) error {
 	return ds.Backend.UpdateJob(ctx, job)
 }
 
+// UpdateJobCollectionID sets the collection ID of a migration job.
+func (ds *Datastore) UpdateJobCollectionID(ctx context.Context, jobID, collectionID string) error {
+	return ds.Backend.UpdateJobCollectionID(ctx, jobID, collectionID)
+}
+
+// UpdateJobNotificationThread sets the notification thread of a
+// migration job.
+func (ds *Datastore) UpdateJobNotificationThread(ctx context.Context, jobID string, thread *domain.NotificationThread) error {
+	return ds.Backend.UpdateJobNotificationThread(ctx, jobID, thread)
+}
+
 // GetJobs retrieves a list of migration jobs with pagination.
-func (ds *Datastore) GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, states []domain.State, limit, offset int) ([]*domain.Job, int, error) {
-	return ds.Backend.GetJobs(ctx, field, direction, states, limit, offset)
+func (ds *Datastore) GetJobs(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, limit, offset int) ([]*domain.Job, int, error) {
+	return ds.Backend.GetJobs(ctx, field, direction, filter, limit, offset)
+}
+
+// GetJobsAfter retrieves a page of migration jobs, starting after the
+// provided cursor.
+func (ds *Datastore) GetJobsAfter(ctx context.Context, field sort.SortParameterField, direction sort.SortParameterDirection, filter *domain.JobFilter, after *domain.Cursor, limit int) ([]*domain.Job, *domain.Cursor, error) {
+	return ds.Backend.GetJobsAfter(ctx, field, direction, filter, after, limit)
 }
 
 // GetJobStateCounts retrieves a summary of job counts by state, sorted by count
@@ -125,9 +213,29 @@ func (ds *Datastore) GetTask(ctx context.Context, taskID string) (*domain.Task,
 	return ds.Backend.GetTask(ctx, taskID)
 }
 
-// ClaimTask claims a pending task for processing.
-func (ds *Datastore) ClaimTask(ctx context.Context, pendingState, activeState domain.State) (*domain.Task, error) {
-	return ds.Backend.ClaimTask(ctx, pendingState, activeState)
+// ClaimTask claims a pending task for processing, taking the highest
+// priority first and taking turns between jobs, starting after the
+// provided job number. Tasks of the excluded types or jobs are not claimed,
+// nor are tasks whose dependencies have not all reached the dependency
+// state.
+func (ds *Datastore) ClaimTask(ctx context.Context, pendingState, activeState, dependencyState domain.State, afterJobNumber int, excludedTypes []domain.TaskType, excludedJobNumbers []int) (*domain.Task, error) {
+	return ds.Backend.ClaimTask(ctx, pendingState, activeState, dependencyState, afterJobNumber, excludedTypes, excludedJobNumbers)
+}
+
+// GetDependentTasks retrieves the tasks that depend on a task.
+func (ds *Datastore) GetDependentTasks(ctx context.Context, taskID string) ([]*domain.Task, error) {
+	return ds.Backend.GetDependentTasks(ctx, taskID)
+}
+
+// WatchWork calls notify whenever a job or task is created or changes
+// state, until the context is done or the change stream fails.
+func (ds *Datastore) WatchWork(ctx context.Context, notify func()) error {
+	return ds.Backend.WatchWork(ctx, notify)
+}
+
+// UpdateJobTasksPriority sets the priority of every task of a job.
+func (ds *Datastore) UpdateJobTasksPriority(ctx context.Context, jobNumber, priority int) error {
+	return ds.Backend.UpdateJobTasksPriority(ctx, jobNumber, priority)
 }
 
 // GetJobTasks retrieves a list of migration tasks for a job with pagination.
@@ -135,11 +243,23 @@ func (ds *Datastore) GetJobTasks(ctx context.Context, states []domain.State, job
 	return ds.Backend.GetJobTasks(ctx, states, jobNumber, limit, offset)
 }
 
+// GetJobTasksAfter retrieves a page of migration tasks for a job, starting
+// after the provided cursor.
+func (ds *Datastore) GetJobTasksAfter(ctx context.Context, states []domain.State, jobNumber int, after *domain.Cursor, limit int) ([]*domain.Task, *domain.Cursor, error) {
+	return ds.Backend.GetJobTasksAfter(ctx, states, jobNumber, after, limit)
+}
+
 // CountTasksByJobNumber returns the total count of tasks for a job.
 func (ds *Datastore) CountTasksByJobNumber(ctx context.Context, jobNumber int) (int, error) {
 	return ds.Backend.CountTasksByJobNumber(ctx, jobNumber)
 }
 
+// GetStaleTasks retrieves the tasks in any of the given states that were
+// last updated before the provided time, longest waiting first.
+func (ds *Datastore) GetStaleTasks(ctx context.Context, states []domain.State, updatedBefore time.Time, limit int) ([]*domain.Task, int, error) {
+	return ds.Backend.GetStaleTasks(ctx, states, updatedBefore, limit)
+}
+
 // GetNextJobNumberCounter increments the job number counter,
 // in mongoDB, and then returns it.
 func (ds *Datastore) GetNextJobNumberCounter(ctx context.Context) (*domain.Counter, error) {
@@ -152,11 +272,175 @@ func (ds *Datastore) CreateEvent(ctx context.Context, event *domain.Event) error
 }
 
 // GetJobEvents retrieves a list of migration events for a job with pagination.
-func (ds *Datastore) GetJobEvents(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Event, int, error) {
-	return ds.Backend.GetJobEvents(ctx, jobNumber, limit, offset)
+func (ds *Datastore) GetJobEvents(ctx context.Context, jobNumber int, filter *domain.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
+	return ds.Backend.GetJobEvents(ctx, jobNumber, filter, limit, offset)
+}
+
+// GetJobEventsAfter retrieves a page of migration events for a job, starting
+// after the provided cursor.
+func (ds *Datastore) GetJobEventsAfter(ctx context.Context, jobNumber int, filter *domain.EventFilter, after *domain.Cursor, limit int) ([]*domain.Event, *domain.Cursor, error) {
+	return ds.Backend.GetJobEventsAfter(ctx, jobNumber, filter, after, limit)
 }
 
 // CountEventsByJobNumber returns the total count of events for a job.
 func (ds *Datastore) CountEventsByJobNumber(ctx context.Context, jobNumber int) (int, error) {
 	return ds.Backend.CountEventsByJobNumber(ctx, jobNumber)
 }
+
+// GetJobEventStateCounts counts the job events with the given action created
+// since the provided time, grouped by the state the job moved to.
+func (ds *Datastore) GetJobEventStateCounts(ctx context.Context, action domain.EventAction, since time.Time) ([]mongo.StateCountResult, error) {
+	return ds.Backend.GetJobEventStateCounts(ctx, action, since)
+}
+
+// CreateBatch creates a new migration batch.
+func (ds *Datastore) CreateBatch(ctx context.Context, batch *domain.Batch) error {
+	return ds.Backend.CreateBatch(ctx, batch)
+}
+
+// GetBatch retrieves a migration batch by its ID.
+func (ds *Datastore) GetBatch(ctx context.Context, batchID string) (*domain.Batch, error) {
+	return ds.Backend.GetBatch(ctx, batchID)
+}
+
+// UpdateBatch updates an existing migration batch.
+func (ds *Datastore) UpdateBatch(ctx context.Context, batch *domain.Batch) error {
+	return ds.Backend.UpdateBatch(ctx, batch)
+}
+
+// GetBatchJobStateCounts retrieves a summary of job counts by state for the
+// jobs created by a batch, sorted by count descending.
+func (ds *Datastore) GetBatchJobStateCounts(ctx context.Context, batchID string) ([]mongo.StateCountResult, error) {
+	return ds.Backend.GetBatchJobStateCounts(ctx, batchID)
+}
+
+// CreateIdempotencyRecord stores a new idempotency record.
+func (ds *Datastore) CreateIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error {
+	return ds.Backend.CreateIdempotencyRecord(ctx, record)
+}
+
+// GetIdempotencyRecord retrieves an idempotency record by its ID.
+func (ds *Datastore) GetIdempotencyRecord(ctx context.Context, id string) (*domain.IdempotencyRecord, error) {
+	return ds.Backend.GetIdempotencyRecord(ctx, id)
+}
+
+// CompleteIdempotencyRecord records the job created for an idempotency record.
+func (ds *Datastore) CompleteIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord, job *domain.Job) error {
+	return ds.Backend.CompleteIdempotencyRecord(ctx, record, job)
+}
+
+// ReplaceExpiredIdempotencyRecord replaces an expired idempotency record.
+func (ds *Datastore) ReplaceExpiredIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error {
+	return ds.Backend.ReplaceExpiredIdempotencyRecord(ctx, record)
+}
+
+// DeleteIdempotencyRecord removes an idempotency record.
+func (ds *Datastore) DeleteIdempotencyRecord(ctx context.Context, record *domain.IdempotencyRecord) error {
+	return ds.Backend.DeleteIdempotencyRecord(ctx, record)
+}
+
+// CreateWebhook creates a new webhook subscription.
+func (ds *Datastore) CreateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	return ds.Backend.CreateWebhook(ctx, webhook)
+}
+
+// GetWebhook retrieves a webhook subscription by its ID.
+func (ds *Datastore) GetWebhook(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+	return ds.Backend.GetWebhook(ctx, webhookID)
+}
+
+// GetWebhooks retrieves a list of webhook subscriptions with pagination.
+func (ds *Datastore) GetWebhooks(ctx context.Context, limit, offset int) ([]*domain.Webhook, int, error) {
+	return ds.Backend.GetWebhooks(ctx, limit, offset)
+}
+
+// GetWebhooksForEvent retrieves the enabled webhook subscriptions matching
+// an event for a job of the given type.
+func (ds *Datastore) GetWebhooksForEvent(ctx context.Context, eventType domain.WebhookEventType, jobType domain.JobType) ([]*domain.Webhook, error) {
+	return ds.Backend.GetWebhooksForEvent(ctx, eventType, jobType)
+}
+
+// UpdateWebhook updates an existing webhook subscription.
+func (ds *Datastore) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	return ds.Backend.UpdateWebhook(ctx, webhook)
+}
+
+// DeleteWebhook deletes a webhook subscription.
+func (ds *Datastore) DeleteWebhook(ctx context.Context, webhookID string) error {
+	return ds.Backend.DeleteWebhook(ctx, webhookID)
+}
+
+// CreateWebhookDelivery creates a new webhook delivery.
+func (ds *Datastore) CreateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return ds.Backend.CreateWebhookDelivery(ctx, delivery)
+}
+
+// GetWebhookDeliveries retrieves a list of the deliveries to a webhook
+// with pagination.
+func (ds *Datastore) GetWebhookDeliveries(ctx context.Context, webhookID string, status domain.WebhookDeliveryStatus, limit, offset int) ([]*domain.WebhookDelivery, int, error) {
+	return ds.Backend.GetWebhookDeliveries(ctx, webhookID, status, limit, offset)
+}
+
+// ClaimWebhookDelivery claims a due webhook delivery, leasing it until the
+// given time.
+func (ds *Datastore) ClaimWebhookDelivery(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error) {
+	return ds.Backend.ClaimWebhookDelivery(ctx, leaseUntil)
+}
+
+// UpdateWebhookDelivery updates an existing webhook delivery.
+func (ds *Datastore) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return ds.Backend.UpdateWebhookDelivery(ctx, delivery)
+}
+
+// CreateComment creates a new comment on a job.
+func (ds *Datastore) CreateComment(ctx context.Context, comment *domain.Comment) error {
+	return ds.Backend.CreateComment(ctx, comment)
+}
+
+// GetJobComments retrieves a list of the comments on a job with
+// pagination.
+func (ds *Datastore) GetJobComments(ctx context.Context, jobNumber, limit, offset int) ([]*domain.Comment, int, error) {
+	return ds.Backend.GetJobComments(ctx, jobNumber, limit, offset)
+}
+
+// CreateReleaseGroup creates a new release group.
+func (ds *Datastore) CreateReleaseGroup(ctx context.Context, group *domain.ReleaseGroup) error {
+	return ds.Backend.CreateReleaseGroup(ctx, group)
+}
+
+// GetReleaseGroup retrieves a release group by its ID.
+func (ds *Datastore) GetReleaseGroup(ctx context.Context, groupID string) (*domain.ReleaseGroup, error) {
+	return ds.Backend.GetReleaseGroup(ctx, groupID)
+}
+
+// DeleteReleaseGroup deletes a release group by its ID.
+func (ds *Datastore) DeleteReleaseGroup(ctx context.Context, groupID string) error {
+	return ds.Backend.DeleteReleaseGroup(ctx, groupID)
+}
+
+// AddJobsToReleaseGroup adds the jobs with the provided job numbers, that
+// are in one of the provided states, to a release group.
+func (ds *Datastore) AddJobsToReleaseGroup(ctx context.Context, groupID string, jobNumbers []int, states []domain.State) (int, error) {
+	return ds.Backend.AddJobsToReleaseGroup(ctx, groupID, jobNumbers, states)
+}
+
+// RemoveJobsFromReleaseGroup removes every job from a release group.
+func (ds *Datastore) RemoveJobsFromReleaseGroup(ctx context.Context, groupID string) error {
+	return ds.Backend.RemoveJobsFromReleaseGroup(ctx, groupID)
+}
+
+// GetReleaseGroupJobs retrieves every job in a release group.
+func (ds *Datastore) GetReleaseGroupJobs(ctx context.Context, groupID string) ([]*domain.Job, error) {
+	return ds.Backend.GetReleaseGroupJobs(ctx, groupID)
+}
+
+// ReleaseGroupJobs releases every job in a release group from the provided
+// release gate state.
+func (ds *Datastore) ReleaseGroupJobs(ctx context.Context, groupID string, state domain.State) error {
+	return ds.Backend.ReleaseGroupJobs(ctx, groupID, state)
+}
+
+// ClaimDigestRun claims the scheduled digest for a day.
+func (ds *Datastore) ClaimDigestRun(ctx context.Context, run *domain.DigestRun) error {
+	return ds.Backend.ClaimDigestRun(ctx, run)
+}
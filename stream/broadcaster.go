@@ -0,0 +1,163 @@
+package stream
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// subscriptionBufferSize is the number of messages that can be queued for a
+// subscriber before it is considered too slow and is dropped.
+const subscriptionBufferSize = 64
+
+// Broadcaster fans out messages about migration jobs to in-process
+// subscribers. It keeps the most recent messages so that a subscriber can
+// resume from the last message it received.
+type Broadcaster struct {
+	mu          sync.Mutex
+	epoch       string
+	seq         uint64
+	history     []*Message
+	historySize int
+	subscribers map[int]map[*Subscription]struct{}
+}
+
+// Subscription receives the messages published for a single job.
+type Subscription struct {
+	broadcaster *Broadcaster
+	jobNumber   int
+	messages    chan *Message
+	closed      bool
+}
+
+// NewBroadcaster creates a Broadcaster that keeps up to historySize
+// messages for resuming subscriptions.
+func NewBroadcaster(historySize int) *Broadcaster {
+	return &Broadcaster{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		historySize: historySize,
+		subscribers: make(map[int]map[*Subscription]struct{}),
+	}
+}
+
+// Publish sends a message to every subscriber of the job. Subscribers that
+// are not keeping up are dropped rather than blocking the publisher, and
+// can resume from the last message they received.
+func (b *Broadcaster) Publish(jobNumber int, msgType MessageType, data interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	msg := &Message{
+		ID:        fmt.Sprintf("%s-%d", b.epoch, b.seq),
+		JobNumber: jobNumber,
+		Type:      msgType,
+		Data:      data,
+		seq:       b.seq,
+	}
+
+	if b.historySize > 0 {
+		if len(b.history) == b.historySize {
+			b.history = b.history[1:]
+		}
+		b.history = append(b.history, msg)
+	}
+
+	for sub := range b.subscribers[jobNumber] {
+		select {
+		case sub.messages <- msg:
+		default:
+			b.unsubscribe(sub)
+		}
+	}
+}
+
+// Subscribe registers a subscription for the messages of a job. If
+// lastEventID identifies a message that is still held, the messages for
+// the job published since are returned and resumed is true. Otherwise the
+// subscriber has missed messages that cannot be replayed.
+func (b *Broadcaster) Subscribe(jobNumber int, lastEventID string) (sub *Subscription, missed []*Message, resumed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub = &Subscription{
+		broadcaster: b,
+		jobNumber:   jobNumber,
+		messages:    make(chan *Message, subscriptionBufferSize),
+	}
+
+	if b.subscribers[jobNumber] == nil {
+		b.subscribers[jobNumber] = make(map[*Subscription]struct{})
+	}
+	b.subscribers[jobNumber][sub] = struct{}{}
+
+	lastSeq, ok := b.parseID(lastEventID)
+	if !ok || !b.canResumeFrom(lastSeq) {
+		return sub, nil, false
+	}
+
+	for _, msg := range b.history {
+		if msg.seq > lastSeq && msg.JobNumber == jobNumber {
+			missed = append(missed, msg)
+		}
+	}
+
+	return sub, missed, true
+}
+
+// parseID returns the sequence number of a message ID issued by this
+// broadcaster.
+func (b *Broadcaster) parseID(id string) (uint64, bool) {
+	epoch, seq, found := strings.Cut(id, "-")
+	if !found || epoch != b.epoch {
+		return 0, false
+	}
+
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil || n > b.seq {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// canResumeFrom reports whether every message after lastSeq is still held.
+func (b *Broadcaster) canResumeFrom(lastSeq uint64) bool {
+	if lastSeq == b.seq {
+		return true
+	}
+
+	return len(b.history) > 0 && b.history[0].seq <= lastSeq+1
+}
+
+// unsubscribe removes a subscription and closes its channel. It must be
+// called with the lock held.
+func (b *Broadcaster) unsubscribe(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+
+	sub.closed = true
+	close(sub.messages)
+
+	delete(b.subscribers[sub.jobNumber], sub)
+	if len(b.subscribers[sub.jobNumber]) == 0 {
+		delete(b.subscribers, sub.jobNumber)
+	}
+}
+
+// Messages returns the channel messages are delivered on. The channel is
+// closed when the subscription is closed or dropped for falling behind.
+func (s *Subscription) Messages() <-chan *Message {
+	return s.messages
+}
+
+// Close stops the subscription receiving messages.
+func (s *Subscription) Close() {
+	s.broadcaster.mu.Lock()
+	defer s.broadcaster.mu.Unlock()
+
+	s.broadcaster.unsubscribe(s)
+}
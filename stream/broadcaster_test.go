@@ -0,0 +1,116 @@
+package stream
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBroadcasterPublish(t *testing.T) {
+	Convey("Given a broadcaster with subscribers to two jobs", t, func() {
+		b := NewBroadcaster(10)
+		sub1, _, _ := b.Subscribe(1, "")
+		sub2, _, _ := b.Subscribe(2, "")
+
+		Convey("When a message is published for job 1", func() {
+			b.Publish(1, MessageTypeEvent, "data")
+
+			Convey("Then only the subscriber to job 1 receives it", func() {
+				So(sub1.Messages(), ShouldHaveLength, 1)
+				So(sub2.Messages(), ShouldHaveLength, 0)
+
+				msg := <-sub1.Messages()
+				So(msg.JobNumber, ShouldEqual, 1)
+				So(msg.Type, ShouldEqual, MessageTypeEvent)
+				So(msg.Data, ShouldEqual, "data")
+				So(msg.ID, ShouldNotBeEmpty)
+			})
+		})
+
+		Convey("When a subscription is closed", func() {
+			sub1.Close()
+			b.Publish(1, MessageTypeEvent, "data")
+
+			Convey("Then its channel is closed", func() {
+				_, ok := <-sub1.Messages()
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("And closing it again does not panic", func() {
+				So(sub1.Close, ShouldNotPanic)
+			})
+		})
+
+		Convey("When a subscriber falls behind", func() {
+			for i := 0; i <= subscriptionBufferSize; i++ {
+				b.Publish(1, MessageTypeEvent, i)
+			}
+
+			Convey("Then it is dropped and its channel closed once drained", func() {
+				for range subscriptionBufferSize {
+					<-sub1.Messages()
+				}
+				_, ok := <-sub1.Messages()
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestBroadcasterSubscribe(t *testing.T) {
+	Convey("Given a broadcaster that has published messages for two jobs", t, func() {
+		b := NewBroadcaster(3)
+		first, _, _ := b.Subscribe(1, "")
+		b.Publish(1, MessageTypeEvent, "a")
+		b.Publish(2, MessageTypeEvent, "b")
+		b.Publish(1, MessageTypeEvent, "c")
+		firstID := (<-first.Messages()).ID
+		lastID := (<-first.Messages()).ID
+
+		Convey("When subscribing with the ID of a held message", func() {
+			_, missed, resumed := b.Subscribe(1, firstID)
+
+			Convey("Then the later messages for the job are replayed", func() {
+				So(resumed, ShouldBeTrue)
+				So(missed, ShouldHaveLength, 1)
+				So(missed[0].Data, ShouldEqual, "c")
+			})
+		})
+
+		Convey("When subscribing with the ID of the latest message", func() {
+			_, missed, resumed := b.Subscribe(1, lastID)
+
+			Convey("Then the subscription resumes with nothing to replay", func() {
+				So(resumed, ShouldBeTrue)
+				So(missed, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When subscribing with an ID that is no longer held", func() {
+			b.Publish(2, MessageTypeEvent, "d")
+			b.Publish(2, MessageTypeEvent, "e")
+			_, missed, resumed := b.Subscribe(1, firstID)
+
+			Convey("Then the subscription cannot be resumed", func() {
+				So(resumed, ShouldBeFalse)
+				So(missed, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When subscribing with an ID from another broadcaster", func() {
+			_, _, resumed := b.Subscribe(1, "other-1")
+
+			Convey("Then the subscription cannot be resumed", func() {
+				So(resumed, ShouldBeFalse)
+			})
+		})
+
+		Convey("When subscribing without an ID", func() {
+			_, _, resumed := b.Subscribe(1, "")
+
+			Convey("Then the subscription is not resumed", func() {
+				So(resumed, ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,39 @@
+package stream
+
+import (
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/domain"
+)
+
+// MessageType identifies the kind of update carried by a Message. It is
+// sent to clients as the SSE event name.
+type MessageType string
+
+// Message types
+const (
+	MessageTypeJob              MessageType = "job"
+	MessageTypeJobStateChanged  MessageType = "job_state_changed"
+	MessageTypeTaskStateChanged MessageType = "task_state_changed"
+	MessageTypeEvent            MessageType = "event"
+)
+
+// Message is a single update about a migration job.
+type Message struct {
+	// ID identifies the message for resuming a stream. Snapshot messages
+	// that are not published through the broadcaster have no ID.
+	ID        string
+	JobNumber int
+	Type      MessageType
+	Data      interface{}
+	seq       uint64
+}
+
+// StateChange is the data of a job or task state changed message.
+type StateChange struct {
+	JobNumber int          `json:"job_number"`
+	TaskID    string       `json:"task_id,omitempty"`
+	FromState domain.State `json:"from_state"`
+	ToState   domain.State `json:"to_state"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
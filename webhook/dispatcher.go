@@ -0,0 +1,257 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dis-migration-service/application"
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// maxResponseBodySize is the most of a webhook's response that is read
+// before the connection is reused.
+const maxResponseBodySize = 64 * 1024
+
+// HTTPClient is the client used to make webhook deliveries.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Dispatcher delivers pending webhook deliveries in the background,
+// retrying failed deliveries with exponential backoff until they succeed
+// or run out of attempts and are moved to the dead-letter list. Up to a
+// configured number of deliveries are made at once, so a slow endpoint
+// does not hold up deliveries to the others.
+type Dispatcher struct {
+	jobService    application.JobService
+	client        HTTPClient
+	pollInterval  time.Duration
+	retryBackoff  time.Duration
+	timeout       time.Duration
+	maxAttempts   int
+	maxConcurrent int
+	wg            sync.WaitGroup
+	stopFunc      context.CancelFunc
+}
+
+// NewHTTPClient returns a client for making webhook deliveries that gives
+// up after the timeout. Redirects are not followed, so that a webhook
+// cannot redirect deliveries to hosts it was not registered with, and the
+// redirect response is treated as a failed delivery.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// NewDispatcher creates a new Dispatcher that makes deliveries with the
+// provided client.
+func NewDispatcher(cfg *config.Config, jobService application.JobService, client HTTPClient) *Dispatcher {
+	return &Dispatcher{
+		jobService:    jobService,
+		client:        client,
+		pollInterval:  cfg.WebhookPollInterval,
+		retryBackoff:  cfg.WebhookRetryBackoff,
+		timeout:       cfg.WebhookTimeout,
+		maxAttempts:   cfg.WebhookMaxAttempts,
+		maxConcurrent: max(cfg.WebhookMaxConcurrentDeliveries, 1),
+	}
+}
+
+// Start begins delivering pending webhook deliveries
+func (d *Dispatcher) Start(ctx context.Context) {
+	log.Info(ctx, "starting webhook dispatcher")
+	ctx, cancel := context.WithCancel(ctx) //nolint:gosec // Context is cancelled on shutdown
+	d.stopFunc = cancel
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.monitorDeliveries(ctx)
+	}()
+}
+
+// Shutdown waits for any deliveries in progress to complete or times out
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	log.Info(ctx, "shutting down webhook dispatcher")
+
+	if d.stopFunc != nil {
+		d.stopFunc()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Info(ctx, "webhook dispatcher shut down completed successfully")
+		return nil
+	case <-ctx.Done():
+		err := fmt.Errorf("timed out waiting for webhook deliveries to complete")
+		log.Error(ctx, "error shutting down webhook dispatcher", err)
+		return err
+	}
+}
+
+// monitorDeliveries claims due deliveries whenever a delivery slot is free
+// and makes them in the background, waiting for the poll interval whenever
+// there are none.
+func (d *Dispatcher) monitorDeliveries(ctx context.Context) {
+	log.Info(ctx, "monitoring webhook deliveries", log.Data{
+		"poll_interval":  d.pollInterval,
+		"max_concurrent": d.maxConcurrent,
+	})
+
+	slots := make(chan struct{}, d.maxConcurrent)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info(ctx, "stopping monitoring webhook deliveries")
+			return
+		case slots <- struct{}{}:
+			// Lease the delivery for long enough to make the request, so it is
+			// only retried by another claim if this one is interrupted.
+			delivery, err := d.jobService.ClaimWebhookDelivery(ctx, time.Now().UTC().Add(2*d.timeout))
+			if err != nil && !errors.Is(err, context.Canceled) {
+				log.Error(ctx, "error claiming webhook delivery", err)
+			}
+			if delivery == nil {
+				<-slots
+				select {
+				case <-ctx.Done():
+					log.Info(ctx, "stopping monitoring webhook deliveries")
+					return
+				case <-time.After(d.pollInterval):
+					continue
+				}
+			}
+
+			d.wg.Add(1)
+			go func() {
+				defer d.wg.Done()
+				defer func() { <-slots }()
+				d.deliver(ctx, delivery)
+			}()
+		}
+	}
+}
+
+// deliver makes an attempt at a delivery and records the outcome. A
+// delivery whose webhook has been deleted or disabled is moved to the
+// dead-letter list without an attempt.
+func (d *Dispatcher) deliver(ctx context.Context, delivery *domain.WebhookDelivery) {
+	logData := log.Data{
+		"delivery_id": delivery.ID,
+		"webhook_id":  delivery.WebhookID,
+		"event_type":  delivery.EventType,
+		"job_number":  delivery.JobNumber,
+	}
+
+	webhook, err := d.jobService.GetWebhook(ctx, delivery.WebhookID)
+	switch {
+	case errors.Is(err, appErrors.ErrWebhookNotFound):
+		delivery.Status = domain.WebhookDeliveryStatusDeadLetter
+		delivery.LastError = "webhook no longer exists"
+	case err != nil:
+		// Leave the delivery to be claimed again once its lease expires.
+		log.Error(ctx, "failed to get webhook for delivery", err, logData)
+		return
+	case !webhook.Enabled:
+		delivery.Status = domain.WebhookDeliveryStatusDeadLetter
+		delivery.LastError = "webhook is disabled"
+	default:
+		d.attempt(ctx, webhook, delivery)
+	}
+
+	logData["status"] = delivery.Status
+	logData["attempts"] = delivery.Attempts
+
+	if err := d.jobService.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		log.Error(ctx, "failed to record webhook delivery attempt", err, logData)
+		return
+	}
+
+	if delivery.Status == domain.WebhookDeliveryStatusDeadLetter {
+		log.Warn(ctx, "webhook delivery moved to dead-letter list", logData)
+		return
+	}
+
+	log.Info(ctx, "webhook delivery attempted", logData)
+}
+
+// attempt sends a delivery to its webhook and updates the delivery with
+// the outcome, scheduling a retry if it failed and has attempts left.
+func (d *Dispatcher) attempt(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) {
+	responseStatus, err := d.send(ctx, webhook, delivery)
+
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.LastStatus = responseStatus
+
+	if err == nil {
+		delivery.Status = domain.WebhookDeliveryStatusSucceeded
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+
+	if delivery.Attempts >= d.maxAttempts {
+		delivery.Status = domain.WebhookDeliveryStatusDeadLetter
+		return
+	}
+
+	next := now.Add(d.retryBackoff << (delivery.Attempts - 1))
+	delivery.Status = domain.WebhookDeliveryStatusPending
+	delivery.NextAttemptAt = &next
+}
+
+// send posts a delivery's payload to its webhook, returning the response
+// status. Any response other than a 2xx is an error.
+func (d *Dispatcher) send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
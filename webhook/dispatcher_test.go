@@ -0,0 +1,352 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	applicationMock "github.com/ONSdigital/dis-migration-service/application/mock"
+	"github.com/ONSdigital/dis-migration-service/config"
+	"github.com/ONSdigital/dis-migration-service/domain"
+	appErrors "github.com/ONSdigital/dis-migration-service/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testWebhookID = "webhook-1"
+	testSecret    = "test-secret"
+)
+
+var testConfig = &config.Config{
+	WebhookMaxAttempts:  3,
+	WebhookPollInterval: time.Millisecond,
+	WebhookRetryBackoff: time.Minute,
+	WebhookTimeout:      time.Second,
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newTestServer(status int, received *[]receivedRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*received = append(*received, receivedRequest{header: r.Header.Clone(), body: body})
+		w.WriteHeader(status)
+	}))
+}
+
+func newTestDelivery(attempts int) *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:        "delivery-1",
+		WebhookID: testWebhookID,
+		EventType: domain.WebhookEventTypeForState(domain.StatePublished),
+		JobNumber: 1,
+		Payload:   []byte(`{"job_number":1}`),
+		Status:    domain.WebhookDeliveryStatusPending,
+		Attempts:  attempts,
+	}
+}
+
+func newMockJobService(webhook *domain.Webhook, getErr error) *applicationMock.JobServiceMock {
+	return &applicationMock.JobServiceMock{
+		GetWebhookFunc: func(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+			return webhook, getErr
+		},
+		UpdateWebhookDeliveryFunc: func(ctx context.Context, delivery *domain.WebhookDelivery) error {
+			return nil
+		},
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a webhook that accepts deliveries", t, func() {
+		var received []receivedRequest
+		server := newTestServer(http.StatusNoContent, &received)
+		defer server.Close()
+
+		mockService := newMockJobService(&domain.Webhook{ID: testWebhookID, URL: server.URL, Secret: testSecret, Enabled: true}, nil)
+		dispatcher := NewDispatcher(testConfig, mockService, server.Client())
+
+		Convey("When a delivery is made", func() {
+			delivery := newTestDelivery(0)
+			dispatcher.deliver(ctx, delivery)
+
+			Convey("Then the payload is posted with a valid signature", func() {
+				So(received, ShouldHaveLength, 1)
+				So(string(received[0].body), ShouldEqual, `{"job_number":1}`)
+				So(received[0].header.Get("Content-Type"), ShouldEqual, "application/json")
+				So(received[0].header.Get(HeaderDelivery), ShouldEqual, "delivery-1")
+				So(received[0].header.Get(HeaderEvent), ShouldEqual, "job.published")
+				So(Verify(testSecret, received[0].header.Get(HeaderTimestamp), received[0].body, received[0].header.Get(HeaderSignature), time.Minute), ShouldBeTrue)
+			})
+
+			Convey("And the delivery is recorded as succeeded", func() {
+				So(mockService.UpdateWebhookDeliveryCalls(), ShouldHaveLength, 1)
+				So(delivery.Status, ShouldEqual, domain.WebhookDeliveryStatusSucceeded)
+				So(delivery.Attempts, ShouldEqual, 1)
+				So(delivery.LastStatus, ShouldEqual, http.StatusNoContent)
+				So(delivery.LastAttemptAt, ShouldNotBeNil)
+				So(delivery.LastError, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a webhook that redirects deliveries to another host", t, func() {
+		var redirected []receivedRequest
+		target := newTestServer(http.StatusNoContent, &redirected)
+		defer target.Close()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}))
+		defer server.Close()
+
+		mockService := newMockJobService(&domain.Webhook{ID: testWebhookID, URL: server.URL, Secret: testSecret, Enabled: true}, nil)
+		dispatcher := NewDispatcher(testConfig, mockService, NewHTTPClient(time.Second))
+
+		Convey("When a delivery is made", func() {
+			delivery := newTestDelivery(0)
+			dispatcher.deliver(ctx, delivery)
+
+			Convey("Then the redirect is not followed and the delivery fails", func() {
+				So(redirected, ShouldHaveLength, 0)
+				So(delivery.Status, ShouldEqual, domain.WebhookDeliveryStatusPending)
+				So(delivery.LastStatus, ShouldEqual, http.StatusTemporaryRedirect)
+			})
+		})
+	})
+
+	Convey("Given a webhook that rejects deliveries", t, func() {
+		var received []receivedRequest
+		server := newTestServer(http.StatusInternalServerError, &received)
+		defer server.Close()
+
+		mockService := newMockJobService(&domain.Webhook{ID: testWebhookID, URL: server.URL, Secret: testSecret, Enabled: true}, nil)
+		dispatcher := NewDispatcher(testConfig, mockService, server.Client())
+
+		Convey("When a delivery with attempts left fails", func() {
+			delivery := newTestDelivery(1)
+			dispatcher.deliver(ctx, delivery)
+
+			Convey("Then a retry is scheduled with exponential backoff", func() {
+				So(delivery.Status, ShouldEqual, domain.WebhookDeliveryStatusPending)
+				So(delivery.Attempts, ShouldEqual, 2)
+				So(delivery.LastStatus, ShouldEqual, http.StatusInternalServerError)
+				So(delivery.LastError, ShouldEqual, "webhook responded with status 500")
+				So(delivery.NextAttemptAt.Sub(*delivery.LastAttemptAt), ShouldEqual, 2*time.Minute)
+			})
+		})
+
+		Convey("When a delivery on its last attempt fails", func() {
+			delivery := newTestDelivery(2)
+			dispatcher.deliver(ctx, delivery)
+
+			Convey("Then it is moved to the dead-letter list", func() {
+				So(received, ShouldHaveLength, 1)
+				So(delivery.Status, ShouldEqual, domain.WebhookDeliveryStatusDeadLetter)
+				So(delivery.Attempts, ShouldEqual, 3)
+				So(mockService.UpdateWebhookDeliveryCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a webhook that has been disabled", t, func() {
+		mockService := newMockJobService(&domain.Webhook{ID: testWebhookID, URL: "http://localhost", Secret: testSecret}, nil)
+		dispatcher := NewDispatcher(testConfig, mockService, http.DefaultClient)
+
+		Convey("When a delivery is made", func() {
+			delivery := newTestDelivery(0)
+			dispatcher.deliver(ctx, delivery)
+
+			Convey("Then it is moved to the dead-letter list without an attempt", func() {
+				So(delivery.Status, ShouldEqual, domain.WebhookDeliveryStatusDeadLetter)
+				So(delivery.Attempts, ShouldEqual, 0)
+				So(delivery.LastError, ShouldEqual, "webhook is disabled")
+			})
+		})
+	})
+
+	Convey("Given a webhook that has been deleted", t, func() {
+		mockService := newMockJobService(nil, appErrors.ErrWebhookNotFound)
+		dispatcher := NewDispatcher(testConfig, mockService, http.DefaultClient)
+
+		Convey("When a delivery is made", func() {
+			delivery := newTestDelivery(0)
+			dispatcher.deliver(ctx, delivery)
+
+			Convey("Then it is moved to the dead-letter list", func() {
+				So(delivery.Status, ShouldEqual, domain.WebhookDeliveryStatusDeadLetter)
+				So(delivery.LastError, ShouldEqual, "webhook no longer exists")
+				So(mockService.UpdateWebhookDeliveryCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given the webhook cannot be retrieved", t, func() {
+		mockService := newMockJobService(nil, appErrors.ErrInternalServerError)
+		dispatcher := NewDispatcher(testConfig, mockService, http.DefaultClient)
+
+		Convey("When a delivery is made", func() {
+			delivery := newTestDelivery(0)
+			dispatcher.deliver(ctx, delivery)
+
+			Convey("Then the delivery is left to be claimed again", func() {
+				So(delivery.Status, ShouldEqual, domain.WebhookDeliveryStatusPending)
+				So(mockService.UpdateWebhookDeliveryCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestDispatcherShutdown(t *testing.T) {
+	Convey("Given a started dispatcher with no pending deliveries", t, func() {
+		mockService := &applicationMock.JobServiceMock{
+			ClaimWebhookDeliveryFunc: func(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error) {
+				return nil, nil
+			},
+		}
+		dispatcher := NewDispatcher(testConfig, mockService, http.DefaultClient)
+		dispatcher.Start(context.Background())
+
+		Convey("When Shutdown is called", func() {
+			err := dispatcher.Shutdown(context.Background())
+
+			Convey("Then it stops polling and returns nil error", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestMonitorDeliveries(t *testing.T) {
+	Convey("Given a dispatcher with two delivery slots and a webhook that is slow to respond", t, func() {
+		release := make(chan struct{})
+		var inFlight, maxInFlight atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := inFlight.Add(1)
+			for {
+				current := maxInFlight.Load()
+				if n <= current || maxInFlight.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			<-release
+			inFlight.Add(-1)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		var claims atomic.Int32
+		mockService := newMockJobService(&domain.Webhook{ID: testWebhookID, URL: server.URL, Secret: testSecret, Enabled: true}, nil)
+		mockService.ClaimWebhookDeliveryFunc = func(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error) {
+			if claims.Add(1) > 3 {
+				return nil, nil
+			}
+			return newTestDelivery(0), nil
+		}
+
+		cfg := *testConfig
+		cfg.WebhookMaxConcurrentDeliveries = 2
+		dispatcher := NewDispatcher(&cfg, mockService, server.Client())
+
+		Convey("When deliveries are monitored", func() {
+			dispatcher.Start(context.Background())
+			time.Sleep(50 * time.Millisecond)
+
+			Convey("Then deliveries are made at once up to the number of slots", func() {
+				So(maxInFlight.Load(), ShouldEqual, 2)
+				So(claims.Load(), ShouldEqual, 2)
+
+				Convey("And the remaining delivery is made once a slot is free", func() {
+					close(release)
+					for i := 0; i < 100 && len(mockService.UpdateWebhookDeliveryCalls()) < 3; i++ {
+						time.Sleep(5 * time.Millisecond)
+					}
+					So(dispatcher.Shutdown(context.Background()), ShouldBeNil)
+					So(mockService.UpdateWebhookDeliveryCalls(), ShouldHaveLength, 3)
+				})
+			})
+		})
+	})
+}
+
+func TestStart(t *testing.T) {
+	Convey("Given a dispatcher with no deliveries to make", t, func() {
+		mockService := newMockJobService(nil, nil)
+		mockService.ClaimWebhookDeliveryFunc = func(ctx context.Context, leaseUntil time.Time) (*domain.WebhookDelivery, error) {
+			return nil, nil
+		}
+		dispatcher := NewDispatcher(testConfig, mockService, http.DefaultClient)
+
+		Convey("When it is started with a context that is then cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			dispatcher.Start(ctx)
+			cancel()
+
+			Convey("Then it stops monitoring deliveries without being shut down", func() {
+				done := make(chan struct{})
+				go func() {
+					dispatcher.wg.Wait()
+					close(done)
+				}()
+
+				select {
+				case <-done:
+				case <-time.After(time.Second):
+					t.Error("dispatcher did not stop when its context was cancelled")
+				}
+			})
+		})
+	})
+}
+
+func TestSign(t *testing.T) {
+	Convey("Given a delivery body, timestamp and secret", t, func() {
+		body := []byte(`{"job_number":1}`)
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+		Convey("When the body is signed", func() {
+			signature := Sign(testSecret, timestamp, body)
+
+			Convey("Then the signature is a prefixed hex encoded HMAC-SHA256", func() {
+				So(signature, ShouldStartWith, "sha256=")
+				So(signature, ShouldHaveLength, len("sha256=")+64)
+				So(Verify(testSecret, timestamp, body, signature, time.Minute), ShouldBeTrue)
+			})
+
+			Convey("And it does not verify with a different secret, body or timestamp", func() {
+				So(Verify("other-secret", timestamp, body, signature, time.Minute), ShouldBeFalse)
+				So(Verify(testSecret, timestamp, []byte(`{}`), signature, time.Minute), ShouldBeFalse)
+
+				otherTimestamp := strconv.FormatInt(time.Now().Unix()-1, 10)
+				So(Verify(testSecret, otherTimestamp, body, signature, time.Minute), ShouldBeFalse)
+			})
+		})
+
+		Convey("When a body signed longer ago than the tolerance is verified", func() {
+			oldTimestamp := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+			signature := Sign(testSecret, oldTimestamp, body)
+
+			Convey("Then it does not verify, so a replayed delivery is rejected", func() {
+				So(Verify(testSecret, oldTimestamp, body, signature, 5*time.Minute), ShouldBeFalse)
+			})
+		})
+
+		Convey("When the timestamp is not a Unix time", func() {
+			signature := Sign(testSecret, "not-a-time", body)
+
+			Convey("Then it does not verify", func() {
+				So(Verify(testSecret, "not-a-time", body, signature, time.Minute), ShouldBeFalse)
+			})
+		})
+	})
+}
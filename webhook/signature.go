@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const (
+	// HeaderDelivery is the name of the header holding the ID of a delivery,
+	// which stays the same across retries.
+	HeaderDelivery = "X-Migration-Webhook-Delivery"
+	// HeaderEvent is the name of the header holding the event type of a
+	// delivery.
+	HeaderEvent = "X-Migration-Webhook-Event"
+	// HeaderSignature is the name of the header holding the signature of a
+	// delivery attempt.
+	HeaderSignature = "X-Migration-Webhook-Signature"
+	// HeaderTimestamp is the name of the header holding the Unix time, in
+	// seconds, a delivery attempt was signed at.
+	HeaderTimestamp = "X-Migration-Webhook-Timestamp"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the signature of a delivery attempt, the hex encoded
+// HMAC-SHA256 of the timestamp, a full stop and the body, keyed with the
+// webhook's secret, in the form sent in the signature header. Signing the
+// timestamp lets receivers reject a delivery that is replayed later.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether a signature header value is valid for the
+// timestamp, body and secret, and the timestamp is within the tolerance of
+// the current time. Receivers should use it, or an equivalent constant time
+// comparison and timestamp check, to authenticate deliveries.
+func Verify(secret, timestamp string, body []byte, signature string, tolerance time.Duration) bool {
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	age := time.Since(time.Unix(signedAt, 0))
+	if age > tolerance || age < -tolerance {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}